@@ -0,0 +1,90 @@
+// Command rebound-import-asynq copies delayed jobs from asynq's scheduled and
+// retry sets into rebound, delivering each job's payload to one destination.
+// For custom payload mapping, call Rebound.ImportAsynq from Go instead.
+//
+// Rebound's own Redis is configured with the usual REDIS_* variables:
+//
+//	rebound-import-asynq -asynq-redis localhost:6380 -queues default,critical \
+//	    -url https://jobs.internal/run -dead-url https://jobs.internal/dead
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/ruudy-sib/rebound/internal/config"
+	"github.com/ruudy-sib/rebound/pkg/rebound"
+)
+
+func main() {
+	if err := run(); err != nil {
+		fmt.Fprintf(os.Stderr, "fatal: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	asynqAddr := flag.String("asynq-redis", "localhost:6379", "address of the Redis used by asynq")
+	asynqPassword := flag.String("asynq-password", "", "password of the Redis used by asynq")
+	queues := flag.String("queues", "default", "comma-separated asynq queues to import")
+	source := flag.String("source", "asynq", "source recorded on imported tasks")
+	url := flag.String("url", "", "HTTP destination for job payloads")
+	topic := flag.String("topic", "", "Kafka topic for job payloads")
+	kafkaHost := flag.String("kafka-host", "localhost", "Kafka broker host, with -topic")
+	kafkaPort := flag.String("kafka-port", "9092", "Kafka broker port, with -topic")
+	deadURL := flag.String("dead-url", "", "HTTP dead-letter destination")
+	deadTopic := flag.String("dead-topic", "", "Kafka dead-letter topic")
+	flag.Parse()
+
+	var destType rebound.DestinationType
+	var dest, dead rebound.Destination
+	switch {
+	case *url != "":
+		destType = rebound.DestinationTypeHTTP
+		dest = rebound.Destination{URL: *url}
+	case *topic != "":
+		destType = rebound.DestinationTypeKafka
+		dest = rebound.Destination{Host: *kafkaHost, Port: *kafkaPort, Topic: *topic}
+	default:
+		return fmt.Errorf("one of -url or -topic is required")
+	}
+	switch {
+	case *deadURL != "":
+		dead = rebound.Destination{URL: *deadURL}
+	case *deadTopic != "":
+		dead = rebound.Destination{Host: *kafkaHost, Port: *kafkaPort, Topic: *deadTopic}
+	}
+
+	cfg := config.New()
+	rb, err := rebound.New(&rebound.Config{
+		RedisMode:          cfg.RedisMode,
+		RedisAddr:          cfg.RedisAddr,
+		RedisPassword:      cfg.RedisPassword,
+		RedisDB:            cfg.RedisDB,
+		RedisMasterName:    cfg.RedisMasterName,
+		RedisSentinelAddrs: cfg.RedisSentinelAddrs,
+		RedisClusterAddrs:  cfg.RedisClusterAddrs,
+	})
+	if err != nil {
+		return fmt.Errorf("creating rebound: %w", err)
+	}
+	defer rb.Close()
+
+	asynqClient := goredis.NewClient(&goredis.Options{Addr: *asynqAddr, Password: *asynqPassword})
+	defer asynqClient.Close()
+
+	report, err := rb.ImportAsynq(context.Background(), asynqClient, strings.Split(*queues, ","),
+		rebound.AsynqPayloadMapper(*source, destType, dest, dead))
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("read %d, imported %d, skipped %d, failed %d\n",
+		report.Read, report.Imported, report.Skipped, report.Failed)
+	return nil
+}
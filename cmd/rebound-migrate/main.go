@@ -0,0 +1,60 @@
+// Command rebound-migrate rewrites tasks scheduled by the legacy top-level
+// service into the current schema. Stop all workers before running it.
+//
+// It reads the same REDIS_* environment variables as the main service:
+//
+//	rebound-migrate -dry-run
+//	rebound-migrate
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/adapter/secondary/redisstore"
+	"github.com/ruudy-sib/rebound/internal/config"
+)
+
+func main() {
+	if err := run(); err != nil {
+		fmt.Fprintf(os.Stderr, "fatal: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	dryRun := flag.Bool("dry-run", false, "report what would be migrated without writing")
+	flag.Parse()
+
+	logger, err := zap.NewProduction()
+	if err != nil {
+		return fmt.Errorf("creating logger: %w", err)
+	}
+	defer func() { _ = logger.Sync() }()
+
+	ctx := context.Background()
+	cfg := config.New()
+
+	client, err := redisstore.NewClient(ctx, cfg, logger)
+	if err != nil {
+		return fmt.Errorf("connecting to redis: %w", err)
+	}
+	defer client.Close()
+
+	report, err := redisstore.NewLegacyMigrator(client, logger).Migrate(ctx, *dryRun)
+	if err != nil {
+		return err
+	}
+
+	verb := "migrated"
+	if *dryRun {
+		verb = "would migrate"
+	}
+	fmt.Printf("scanned %d, already current %d, %s %d, unreadable %d\n",
+		report.Scanned, report.Current, verb, report.Migrated, report.Failed)
+	return nil
+}
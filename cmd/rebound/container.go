@@ -2,20 +2,30 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"time"
 
 	goredis "github.com/redis/go-redis/v9"
 	"go.uber.org/dig"
 	"go.uber.org/zap"
 
 	httphandler "github.com/ruudy-sib/rebound/internal/adapter/primary/http"
+	"github.com/ruudy-sib/rebound/internal/adapter/primary/kafkaingest"
 	"github.com/ruudy-sib/rebound/internal/adapter/primary/worker"
+	"github.com/ruudy-sib/rebound/internal/adapter/secondary/egress"
+	"github.com/ruudy-sib/rebound/internal/adapter/secondary/healthprobe"
 	"github.com/ruudy-sib/rebound/internal/adapter/secondary/httpproducer"
+	"github.com/ruudy-sib/rebound/internal/adapter/secondary/idgen"
 	"github.com/ruudy-sib/rebound/internal/adapter/secondary/kafkaproducer"
 	"github.com/ruudy-sib/rebound/internal/adapter/secondary/producerfactory"
+	"github.com/ruudy-sib/rebound/internal/adapter/secondary/producermetrics"
 	"github.com/ruudy-sib/rebound/internal/adapter/secondary/redisstore"
 	"github.com/ruudy-sib/rebound/internal/config"
+	"github.com/ruudy-sib/rebound/internal/domain"
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
 	"github.com/ruudy-sib/rebound/internal/domain/service"
+	"github.com/ruudy-sib/rebound/internal/domain/valueobject"
 	"github.com/ruudy-sib/rebound/internal/port/primary"
 	"github.com/ruudy-sib/rebound/internal/port/secondary"
 )
@@ -42,9 +52,72 @@ func buildContainer(ctx context.Context) (*dig.Container, error) {
 		return nil, err
 	}
 
+	// Redis the schedule is migrated to; nil unless MIGRATION_TARGET_REDIS_* is set
+	if err := c.Provide(func(client goredis.UniversalClient, cfg *config.Config, logger *zap.Logger) (*redisstore.Migrator, error) {
+		targetCfg := &config.Config{
+			RedisAddr:     cfg.MigrationTargetRedisAddr,
+			RedisPassword: cfg.MigrationTargetRedisPassword,
+		}
+		switch {
+		case len(cfg.MigrationTargetRedisClusterAddrs) > 0:
+			targetCfg.RedisMode = "cluster"
+			targetCfg.RedisClusterAddrs = cfg.MigrationTargetRedisClusterAddrs
+		case cfg.MigrationTargetRedisAddr == "":
+			return nil, nil
+		}
+		target, err := redisstore.NewClient(ctx, targetCfg, logger)
+		if err != nil {
+			return nil, fmt.Errorf("connecting to migration target redis: %w", err)
+		}
+		return redisstore.NewMigrator(client, target, logger), nil
+	}); err != nil {
+		return nil, err
+	}
+
+	// Standby Redis the schedule is mirrored to; nil unless REPLICA_REDIS_ADDR
+	// is set or a migration dual-writes to its target
+	if err := c.Provide(func(client goredis.UniversalClient, migrator *redisstore.Migrator, cfg *config.Config, logger *zap.Logger) (*redisstore.Replicator, error) {
+		if migrator != nil {
+			if cfg.ReplicaRedisAddr != "" {
+				return nil, fmt.Errorf("REPLICA_REDIS_ADDR cannot be set during a migration")
+			}
+			return migrator.Replicator(), nil
+		}
+		if cfg.ReplicaRedisAddr == "" {
+			return nil, nil
+		}
+		standby, err := redisstore.NewClient(ctx, &config.Config{
+			RedisAddr:     cfg.ReplicaRedisAddr,
+			RedisPassword: cfg.ReplicaRedisPassword,
+		}, logger)
+		if err != nil {
+			return nil, fmt.Errorf("connecting to standby redis: %w", err)
+		}
+		return redisstore.NewReplicator(client, standby, logger), nil
+	}); err != nil {
+		return nil, err
+	}
+
 	// Task scheduler (implements secondary.TaskScheduler)
-	if err := c.Provide(func(client goredis.UniversalClient, logger *zap.Logger) secondary.TaskScheduler {
-		return redisstore.NewScheduler(client, logger)
+	if err := c.Provide(func(client goredis.UniversalClient, replicator *redisstore.Replicator, cfg *config.Config, logger *zap.Logger) (secondary.TaskScheduler, error) {
+		opts := []redisstore.StoreOption{
+			redisstore.WithTaskEncoding(redisstore.TaskEncoding(cfg.TaskEncoding)),
+			redisstore.WithReplicator(replicator),
+			redisstore.WithLowLatency(cfg.PollInterval < domain.LowLatencyPollInterval),
+			redisstore.WithPriorityShare(cfg.PriorityShare),
+			redisstore.WithNamedQueues(len(cfg.Queues) > 0),
+		}
+		if cfg.PriorityShare < 0 || cfg.PriorityShare > 100 {
+			return nil, fmt.Errorf("PRIORITY_SHARE: must be between 0 and 100, got %d", cfg.PriorityShare)
+		}
+		switch {
+		case cfg.ShardCount < 0:
+			return nil, fmt.Errorf("SHARD_COUNT: must not be negative, got %d", cfg.ShardCount)
+		case cfg.ShardCount > 0:
+			ring := redisstore.NewShardRing(client, cfg.InstanceID, cfg.ShardCount, heartbeatTTL(cfg), logger)
+			opts = append(opts, redisstore.WithShardRing(ring))
+		}
+		return redisstore.NewScheduler(client, logger, opts...), nil
 	}); err != nil {
 		return nil, err
 	}
@@ -56,23 +129,134 @@ func buildContainer(ctx context.Context) (*dig.Container, error) {
 		return nil, err
 	}
 
-	// Collect all health checks
-	if err := c.Provide(func(redisCheck secondary.HealthChecker) []secondary.HealthChecker {
-		return []secondary.HealthChecker{redisCheck}
+	// Worker heartbeat for this instance, checked by /readyz
+	if err := c.Provide(func(client goredis.UniversalClient, cfg *config.Config) *redisstore.Heartbeat {
+		return redisstore.NewHeartbeat(client, cfg.InstanceID)
+	}); err != nil {
+		return nil, err
+	}
+
+	// Quarantine store for poison messages (implements secondary.QuarantineStore)
+	if err := c.Provide(func(client goredis.UniversalClient, logger *zap.Logger) secondary.QuarantineStore {
+		return redisstore.NewQuarantineStore(client, logger)
+	}); err != nil {
+		return nil, err
+	}
+
+	// Dead-letter fallback store (implements secondary.DeadLetterStore)
+	if err := c.Provide(func(client goredis.UniversalClient, logger *zap.Logger) secondary.DeadLetterStore {
+		return redisstore.NewDeadLetterStore(client, logger)
+	}); err != nil {
+		return nil, err
+	}
+
+	// Ordering store for per-key FIFO delivery (implements secondary.OrderingStore)
+	if err := c.Provide(func(client goredis.UniversalClient, cfg *config.Config, logger *zap.Logger) secondary.OrderingStore {
+		return redisstore.NewOrderingStore(client, logger, redisstore.WithTaskEncoding(redisstore.TaskEncoding(cfg.TaskEncoding)))
+	}); err != nil {
+		return nil, err
+	}
+
+	// Completion markers for duplicate delivery protection (implements secondary.CompletionStore)
+	if err := c.Provide(func(client goredis.UniversalClient) secondary.CompletionStore {
+		return redisstore.NewCompletionStore(client)
+	}); err != nil {
+		return nil, err
+	}
+
+	// Results of successful deliveries (implements secondary.DeliveryResultStore)
+	if err := c.Provide(func(client goredis.UniversalClient) secondary.DeliveryResultStore {
+		return redisstore.NewDeliveryResultStore(client)
+	}); err != nil {
+		return nil, err
+	}
+
+	// Spill store for new tasks beyond MAX_PENDING_TASKS (implements secondary.OverflowStore)
+	if err := c.Provide(func(client goredis.UniversalClient, cfg *config.Config, logger *zap.Logger) secondary.OverflowStore {
+		return redisstore.NewOverflowStore(client, logger, redisstore.WithTaskEncoding(redisstore.TaskEncoding(cfg.TaskEncoding)))
+	}); err != nil {
+		return nil, err
+	}
+
+	// Record of tasks being delivered (implements secondary.InFlightStore)
+	if err := c.Provide(func(client goredis.UniversalClient, logger *zap.Logger) secondary.InFlightStore {
+		return redisstore.NewInFlightStore(client, logger)
 	}); err != nil {
 		return nil, err
 	}
 
+	// Holding area for cancelled tasks (implements secondary.CancelledStore)
+	if err := c.Provide(func(client goredis.UniversalClient) secondary.CancelledStore {
+		return redisstore.NewCancelledStore(client)
+	}); err != nil {
+		return nil, err
+	}
+
+	// Whole-queue admin operations (implements secondary.QueueStore)
+	if err := c.Provide(func(client goredis.UniversalClient) secondary.QueueStore {
+		return redisstore.NewQueueStore(client)
+	}); err != nil {
+		return nil, err
+	}
+
+	// Destination maintenance windows (implements secondary.MaintenanceStore)
+	if err := c.Provide(func(client goredis.UniversalClient, logger *zap.Logger) secondary.MaintenanceStore {
+		return redisstore.NewMaintenanceStore(client, logger)
+	}); err != nil {
+		return nil, err
+	}
+
+	// Time-to-success samples for SLA tracking (implements secondary.SLAStore)
+	if err := c.Provide(func(client goredis.UniversalClient, logger *zap.Logger) secondary.SLAStore {
+		return redisstore.NewSLAStore(client, logger)
+	}); err != nil {
+		return nil, err
+	}
+
+	// Per-client usage counters for billing (implements secondary.UsageStore)
+	if err := c.Provide(func(client goredis.UniversalClient, logger *zap.Logger) secondary.UsageStore {
+		return redisstore.NewUsageStore(client, logger)
+	}); err != nil {
+		return nil, err
+	}
+
+	// Janitor repairs for derived state and retention of terminal records (implements secondary.StateReconciler)
+	if err := c.Provide(func(client goredis.UniversalClient, cfg *config.Config, logger *zap.Logger) secondary.StateReconciler {
+		return redisstore.NewReconciler(client, redisstore.Retention{
+			DeadLetters: cfg.DeadLetterRetention,
+			Quarantined: cfg.QuarantineRetention,
+		}, logger)
+	}); err != nil {
+		return nil, err
+	}
+
+	// Collect all health checks. Stalled replicas are reported here but not
+	// in readiness, so one stuck pod does not make every pod unready.
+	if err := c.Provide(func(redisCheck secondary.HealthChecker, client goredis.UniversalClient, replicator *redisstore.Replicator, cfg *config.Config) []secondary.HealthChecker {
+		checks := []secondary.HealthChecker{redisCheck, redisstore.NewWorkersCheck(client, heartbeatTTL(cfg))}
+		if replicator != nil {
+			checks = append(checks, replicator)
+		}
+		return checks
+	}); err != nil {
+		return nil, err
+	}
+
+	// Producer metrics, shared by both producers and reported by the router
+	if err := c.Provide(producermetrics.NewRecorder); err != nil {
+		return nil, err
+	}
+
 	// Kafka producer
-	if err := c.Provide(func(cfg *config.Config, logger *zap.Logger) secondary.MessageProducer {
-		return kafkaproducer.NewProducer(cfg, logger)
+	if err := c.Provide(func(cfg *config.Config, metrics *producermetrics.Recorder, logger *zap.Logger) secondary.MessageProducer {
+		return kafkaproducer.NewProducer(cfg, logger, kafkaproducer.WithMetrics(metrics))
 	}, dig.Name("kafka")); err != nil {
 		return nil, err
 	}
 
 	// HTTP producer
-	if err := c.Provide(func(cfg *config.Config, logger *zap.Logger) secondary.MessageProducer {
-		return httpproducer.NewProducer(cfg, logger)
+	if err := c.Provide(func(cfg *config.Config, metrics *producermetrics.Recorder, logger *zap.Logger) secondary.MessageProducer {
+		return httpproducer.NewProducer(cfg, logger, httpproducer.WithMetrics(metrics))
 	}, dig.Name("http")); err != nil {
 		return nil, err
 	}
@@ -92,9 +276,133 @@ func buildContainer(ctx context.Context) (*dig.Container, error) {
 		return nil, err
 	}
 
+	// Terminal state notifier (implements secondary.TaskNotifier), nil unless NOTIFY_CHANNEL is set
+	if err := c.Provide(func(client goredis.UniversalClient, cfg *config.Config) secondary.TaskNotifier {
+		if cfg.NotifyChannel == "" {
+			return nil
+		}
+		return redisstore.NewNotifier(client, cfg.NotifyChannel)
+	}); err != nil {
+		return nil, err
+	}
+
+	// Destination health prober (implements secondary.HealthProber)
+	if err := c.Provide(func(cfg *config.Config, logger *zap.Logger) secondary.HealthProber {
+		return healthprobe.NewProber(cfg.ProbeTimeout, logger,
+			healthprobe.WithEgressPolicy(egress.NewPolicy(cfg.HTTPEgressDenyPrivate, cfg.HTTPEgressAllow)))
+	}); err != nil {
+		return nil, err
+	}
+
 	// --- Domain Services ---
 
-	if err := c.Provide(service.NewTaskService); err != nil {
+	type serviceParams struct {
+		dig.In
+		Scheduler   secondary.TaskScheduler
+		Producer    secondary.MessageProducer
+		Quarantine  secondary.QuarantineStore
+		DeadLetter  secondary.DeadLetterStore
+		Ordering    secondary.OrderingStore
+		Completion  secondary.CompletionStore
+		Results     secondary.DeliveryResultStore
+		Cancelled   secondary.CancelledStore
+		InFlight    secondary.InFlightStore
+		Overflow    secondary.OverflowStore
+		Maintenance secondary.MaintenanceStore
+		SLA         secondary.SLAStore
+		Usage       secondary.UsageStore
+		Notifier    secondary.TaskNotifier
+		Prober      secondary.HealthProber
+		Heartbeat   *redisstore.Heartbeat
+		Config      *config.Config
+		Logger      *zap.Logger
+	}
+
+	if err := c.Provide(func(params serviceParams) (*service.TaskService, error) {
+		redaction, err := valueobject.NewRedaction(params.Config.RedactFields)
+		if err != nil {
+			return nil, fmt.Errorf("REDACT_FIELDS: %w", err)
+		}
+		deadLetterMode := entity.DeadLetterMode(params.Config.DeadLetterMode)
+		if !deadLetterMode.Valid() {
+			return nil, fmt.Errorf("DEAD_LETTER_MODE: unknown mode %q", params.Config.DeadLetterMode)
+		}
+		jitter := entity.JitterMode(params.Config.RetryJitter)
+		if !jitter.Valid() {
+			return nil, fmt.Errorf("RETRY_JITTER: unknown mode %q", params.Config.RetryJitter)
+		}
+		retryableStatuses, err := valueobject.NewRetryableStatuses(params.Config.HTTPRetryableStatuses)
+		if err != nil {
+			return nil, fmt.Errorf("HTTP_RETRYABLE_STATUSES: %w", err)
+		}
+		var newID service.IDGenerator
+		if params.Config.IDStrategy != "" {
+			if newID, err = idgen.New(params.Config.IDStrategy, params.Config.IDNodeID); err != nil {
+				return nil, fmt.Errorf("ID_STRATEGY: %w", err)
+			}
+		}
+		if params.Config.MaxRetryDelay < 0 {
+			return nil, fmt.Errorf("MAX_RETRY_DELAY: must not be negative, got %s", params.Config.MaxRetryDelay)
+		}
+		return service.NewTaskService(params.Scheduler, params.Producer, params.Logger,
+			service.WithQuarantine(params.Quarantine, service.PoisonPolicy{
+				Threshold:     params.Config.PoisonThreshold,
+				FailureWindow: params.Config.PoisonFailureWindow,
+			}),
+			service.WithDeadLetterPolicy(service.DeadLetterPolicy{
+				MaxAttempts: params.Config.DeadLetterMaxAttempts,
+				Backoff:     params.Config.DeadLetterBackoff,
+			}),
+			service.WithDeadLetterFallback(params.DeadLetter),
+			service.WithDeadLetterMode(deadLetterMode),
+			service.WithRedrivePolicies(redrivePolicies(params.Config)),
+			service.WithOrdering(params.Ordering),
+			service.WithCompletionMarkers(params.Completion, params.Config.CompletionMarkerTTL),
+			service.WithDeliveryResults(params.Results, params.Config.DeliveryResultTTL),
+			service.WithCancellation(params.Cancelled, params.Config.CancelledTaskTTL),
+			service.WithBatchSize(params.Config.BatchSize),
+			service.WithQueues(queuePolicies(params.Config)),
+			service.WithDeliveryTimeout(params.Config.DeliveryTimeout),
+			service.WithInFlightReclaim(params.InFlight, params.Config.ReclaimAfterTimeouts),
+			service.WithHTTPBatching(params.Config.HTTPBatchSize),
+			service.WithConcurrency(params.Config.DeliveryConcurrency),
+			service.WithIDGenerator(newID),
+			service.WithValidationBounds(service.ValidationBounds{
+				MinBaseDelay:  params.Config.MinBaseDelay,
+				MaxBaseDelay:  params.Config.MaxBaseDelay,
+				MaxRetryLimit: params.Config.MaxRetryLimit,
+			}),
+			service.WithMaxPending(params.Config.MaxPendingTasks),
+			service.WithJitter(jitter),
+			service.WithMaxDelay(params.Config.MaxRetryDelay),
+			service.WithRetryableStatuses(retryableStatuses),
+			service.WithOverflow(entity.OverflowAction(params.Config.OverflowPolicy), params.Overflow),
+			service.WithMaintenance(params.Maintenance),
+			service.WithSLATracking(params.SLA, service.SLAPolicy{
+				Threshold: params.Config.SLAThreshold,
+				BreachURL: params.Config.SLABreachURL,
+			}),
+			service.WithUsageTracking(params.Usage, entity.Destination{Topic: params.Config.UsageEventsTopic}),
+			service.WithTerminalNotifications(params.Notifier),
+			service.WithHealthProbes(params.Prober, service.ProbePolicy{
+				BacklogThreshold: params.Config.ProbeBacklogThreshold,
+				Interval:         params.Config.ProbeInterval,
+				ReleaseRate:      params.Config.ProbeReleaseRate,
+			}),
+			service.WithRetryStormThrottling(service.StormPolicy{
+				Threshold:  params.Config.RetryStormThreshold,
+				Window:     params.Config.RetryStormWindow,
+				Multiplier: params.Config.RetryStormMultiplier,
+			}),
+			service.WithHeartbeat(params.Heartbeat, heartbeatTTL(params.Config)),
+			service.WithRedaction(redaction),
+			service.WithLifecycleWebhooks(params.Config.LifecycleWebhookURLs),
+			service.WithDeliveryLogSampling(service.LogSampling{
+				First:      params.Config.DeliveryLogSampleFirst,
+				Thereafter: params.Config.DeliveryLogSampleThereafter,
+			}),
+		), nil
+	}); err != nil {
 		return nil, err
 	}
 
@@ -105,21 +413,181 @@ func buildContainer(ctx context.Context) (*dig.Container, error) {
 		return nil, err
 	}
 
+	// Maintenance service backing the admin maintenance endpoints
+	if err := c.Provide(func(store secondary.MaintenanceStore, logger *zap.Logger) primary.MaintenanceService {
+		return service.NewMaintenanceService(store, logger)
+	}); err != nil {
+		return nil, err
+	}
+
+	// SLA service backing the admin SLA endpoint
+	if err := c.Provide(func(store secondary.SLAStore) primary.SLAService {
+		return service.NewSLAService(store)
+	}); err != nil {
+		return nil, err
+	}
+
+	// Usage service backing the admin usage endpoint
+	if err := c.Provide(func(store secondary.UsageStore) primary.UsageService {
+		return service.NewUsageService(store)
+	}); err != nil {
+		return nil, err
+	}
+
+	// Schedule service backing the schedule-wide endpoints
+	if err := c.Provide(func(scheduler secondary.TaskScheduler, logger *zap.Logger) primary.ScheduleService {
+		return service.NewScheduleService(scheduler, logger)
+	}); err != nil {
+		return nil, err
+	}
+
+	// Queue service backing the admin purge endpoint
+	if err := c.Provide(func(store secondary.QueueStore, logger *zap.Logger) primary.QueueService {
+		return service.NewQueueService(store, logger)
+	}); err != nil {
+		return nil, err
+	}
+
+	// Janitor service backing the periodic and on-demand state repair
+	if err := c.Provide(func(reconciler secondary.StateReconciler, logger *zap.Logger) primary.JanitorService {
+		return service.NewJanitorService(reconciler, logger)
+	}); err != nil {
+		return nil, err
+	}
+
+	// Worker service (implements primary.WorkerService)
+	if err := c.Provide(func(client goredis.UniversalClient, cfg *config.Config) primary.WorkerService {
+		return service.NewWorkerService(redisstore.NewWorkerRegistry(client), heartbeatTTL(cfg))
+	}); err != nil {
+		return nil, err
+	}
+
 	// --- Primary Adapters ---
 
 	// HTTP router
-	if err := c.Provide(func(taskSvc primary.TaskService, checks []secondary.HealthChecker, logger *zap.Logger) http.Handler {
-		return httphandler.NewRouter(taskSvc, checks, logger)
+	type routerParams struct {
+		dig.In
+		TaskService        primary.TaskService
+		MaintenanceService primary.MaintenanceService
+		SLAService         primary.SLAService
+		ScheduleService    primary.ScheduleService
+		QueueService       primary.QueueService
+		JanitorService     primary.JanitorService
+		WorkerService      primary.WorkerService
+		UsageService       primary.UsageService
+		HealthChecks       []secondary.HealthChecker
+		RedisCheck         secondary.HealthChecker
+		Heartbeat          *redisstore.Heartbeat
+		Worker             *worker.Worker
+		ProducerMetrics    *producermetrics.Recorder
+		Config             *config.Config
+		Logger             *zap.Logger
+	}
+
+	if err := c.Provide(func(params routerParams) (http.Handler, error) {
+		allowlist, err := httphandler.ParseSourceAllowlist(params.Config.SourceAllowlist)
+		if err != nil {
+			return nil, fmt.Errorf("SOURCE_ALLOWLIST: %w", err)
+		}
+		opts := []httphandler.RouterOption{
+			httphandler.WithSourceAllowlist(allowlist),
+			httphandler.WithRequestSigning(httphandler.RequestSigning{
+				Secrets:   params.Config.RequestSigningSecrets,
+				Tolerance: params.Config.RequestSignatureTolerance,
+			}),
+			httphandler.WithJanitor(params.JanitorService),
+			httphandler.WithProducerStats(params.ProducerMetrics),
+			httphandler.WithWorkers(params.WorkerService),
+			httphandler.WithUsage(params.UsageService),
+			httphandler.WithHealthPolicy(httphandler.HealthPolicy{
+				Timeout:  params.Config.HealthCheckTimeout,
+				CacheTTL: params.Config.HealthCheckCacheTTL,
+			}),
+		}
+
+		switch params.Config.RunMode {
+		case config.RunModeAll:
+			opts = append(opts,
+				httphandler.WithReadinessChecks(params.RedisCheck, params.Heartbeat),
+				httphandler.WithDrainer(params.Worker),
+			)
+		case config.RunModeAPI:
+			// No worker runs here, so readiness must not wait for its heartbeat.
+			opts = append(opts, httphandler.WithReadinessChecks(params.RedisCheck))
+		case config.RunModeWorker:
+			opts = append(opts, httphandler.WithReadinessChecks(params.RedisCheck, params.Heartbeat))
+			return httphandler.NewHealthRouter(params.HealthChecks, params.Logger, opts...), nil
+		default:
+			return nil, fmt.Errorf("RUN_MODE: unknown mode %q", params.Config.RunMode)
+		}
+
+		return httphandler.NewRouter(params.TaskService, params.MaintenanceService, params.SLAService, params.ScheduleService, params.QueueService, params.HealthChecks, params.Logger, opts...), nil
 	}); err != nil {
 		return nil, err
 	}
 
 	// Worker
 	if err := c.Provide(func(taskSvc primary.TaskService, cfg *config.Config, logger *zap.Logger) *worker.Worker {
-		return worker.NewWorker(taskSvc, cfg.PollInterval, logger)
+		return worker.NewWorker(taskSvc, cfg.PollInterval, logger,
+			worker.WithDrainTimeout(cfg.DrainTimeout),
+			worker.WithQueueIntervals(queueIntervals(cfg)),
+		)
+	}); err != nil {
+		return nil, err
+	}
+
+	// Janitor
+	if err := c.Provide(func(janitorSvc primary.JanitorService, cfg *config.Config, logger *zap.Logger) *worker.Janitor {
+		return worker.NewJanitor(janitorSvc, cfg.JanitorInterval, logger)
+	}); err != nil {
+		return nil, err
+	}
+
+	// Kafka ingester of task requests, nil unless INGEST_TOPIC is set. Task
+	// requests are scheduled like API requests, so worker-only processes
+	// leave them to the API processes; creating the reader would already
+	// join the consumer group.
+	if err := c.Provide(func(taskSvc primary.TaskService, cfg *config.Config, logger *zap.Logger) *kafkaingest.Ingester {
+		if cfg.IngestTopic == "" || !cfg.RunsAPI() {
+			return nil
+		}
+		return kafkaingest.NewIngester(cfg.KafkaBrokers, cfg.IngestTopic, cfg.IngestGroupID, taskSvc, httphandler.DecodeCreateTaskRequest, logger)
 	}); err != nil {
 		return nil, err
 	}
 
 	return c, nil
 }
+
+// heartbeatTTL allows a few missed polls before an instance stops being
+// ready or is reported as stalled.
+func heartbeatTTL(cfg *config.Config) time.Duration {
+	return max(domain.DefaultHeartbeatTTL, 3*cfg.PollInterval)
+}
+
+// redrivePolicies converts the configured DEAD_LETTER_REDRIVE policies.
+func redrivePolicies(cfg *config.Config) map[string]service.RedrivePolicy {
+	policies := make(map[string]service.RedrivePolicy, len(cfg.DeadLetterRedrive))
+	for dest, p := range cfg.DeadLetterRedrive {
+		policies[dest] = service.RedrivePolicy(p)
+	}
+	return policies
+}
+
+// queuePolicies converts the configured QUEUES into service policies.
+func queuePolicies(cfg *config.Config) map[string]service.QueuePolicy {
+	policies := make(map[string]service.QueuePolicy, len(cfg.Queues))
+	for name, q := range cfg.Queues {
+		policies[name] = service.QueuePolicy{BatchSize: q.BatchSize, Weight: q.Weight}
+	}
+	return policies
+}
+
+// queueIntervals collects the poll interval of each configured queue.
+func queueIntervals(cfg *config.Config) map[string]time.Duration {
+	intervals := make(map[string]time.Duration, len(cfg.Queues))
+	for name, q := range cfg.Queues {
+		intervals[name] = q.PollInterval
+	}
+	return intervals
+}
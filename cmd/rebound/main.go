@@ -12,7 +12,9 @@ import (
 	goredis "github.com/redis/go-redis/v9"
 	"go.uber.org/zap"
 
+	"github.com/ruudy-sib/rebound/internal/adapter/primary/kafkaingest"
 	"github.com/ruudy-sib/rebound/internal/adapter/primary/worker"
+	"github.com/ruudy-sib/rebound/internal/adapter/secondary/redisstore"
 	"github.com/ruudy-sib/rebound/internal/config"
 	"github.com/ruudy-sib/rebound/internal/port/secondary"
 )
@@ -43,16 +45,34 @@ func run() error {
 	return c.Invoke(func(
 		router http.Handler,
 		w *worker.Worker,
+		janitor *worker.Janitor,
+		ingester *kafkaingest.Ingester,
 		cfg *config.Config,
 		logger *zap.Logger,
-		redisClient *goredis.Client,
+		redisClient goredis.UniversalClient,
+		heartbeat *redisstore.Heartbeat,
 		producer secondary.MessageProducer,
+		replicator *redisstore.Replicator,
+		migrator *redisstore.Migrator,
 	) {
 		defer func() {
-			// Clean up resources on shutdown.
+			// Clean up resources on shutdown. Leaving the worker registry
+			// keeps a clean shutdown from being reported as a stalled worker.
+			if cfg.RunsWorker() {
+				forgetCtx, forgetCancel := context.WithTimeout(context.Background(), 2*time.Second)
+				if err := heartbeat.Forget(forgetCtx); err != nil {
+					logger.Error("error removing worker heartbeat", zap.Error(err))
+				}
+				forgetCancel()
+			}
 			if err := redisClient.Close(); err != nil {
 				logger.Error("error closing redis", zap.Error(err))
 			}
+			if replicator != nil {
+				if err := replicator.Close(); err != nil {
+					logger.Error("error closing standby redis", zap.Error(err))
+				}
+			}
 			if err := producer.Close(); err != nil {
 				logger.Error("error closing kafka producer", zap.Error(err))
 			}
@@ -63,19 +83,47 @@ func run() error {
 			zap.String("app", appName),
 			zap.String("version", version),
 			zap.String("environment", cfg.Environment),
+			zap.String("run_mode", cfg.RunMode),
 			zap.String("http_addr", cfg.HTTPAddr),
 		)
 
-		// Start the background worker.
+		// Start the background worker, unless this process only serves the API.
 		workerCtx, workerCancel := context.WithCancel(ctx)
 		defer workerCancel()
 
 		errCh := make(chan error, 2)
+		workerDone := make(chan struct{})
+		if cfg.RunsWorker() {
+			go func() {
+				defer close(workerDone)
+				errCh <- w.Run(workerCtx)
+			}()
+			go janitor.Run(workerCtx)
+		} else {
+			close(workerDone)
+		}
+
+		// Replication outlives the worker, so writes made while it drains
+		// still reach the standby.
+		replicationCtx, replicationCancel := context.WithCancel(context.Background())
+		defer replicationCancel()
+		replicationDone := make(chan struct{})
 		go func() {
-			errCh <- w.Run(workerCtx)
+			defer close(replicationDone)
+			if replicator != nil {
+				replicator.Run(replicationCtx)
+			}
 		}()
+		if migrator != nil && cfg.RunsWorker() {
+			go migrator.Run(workerCtx)
+		}
+
+		if ingester != nil {
+			go ingester.Run(ctx)
+		}
 
-		// Start the HTTP server.
+		// Start the HTTP server. Worker-only processes serve just the health
+		// and stats endpoints.
 		server := &http.Server{
 			Addr:              cfg.HTTPAddr,
 			Handler:           router,
@@ -114,6 +162,12 @@ func run() error {
 			logger.Error("http server shutdown error", zap.Error(err))
 		}
 
+		// Let in-flight deliveries finish before Redis is closed; the worker
+		// bounds this by its drain timeout.
+		<-workerDone
+		replicationCancel()
+		<-replicationDone
+
 		logger.Info("shutdown complete")
 	})
 }
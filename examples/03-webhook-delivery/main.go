@@ -118,7 +118,7 @@ func (s *WebhookService) scheduleRetry(ctx context.Context, webhook *CustomerWeb
 			URL: "http://internal-api.brevo.com/webhooks/failed",
 		},
 		MaxRetries:      maxRetries,
-		BaseDelay:       baseDelay,
+		BaseDelay:       time.Duration(baseDelay) * time.Second,
 		ClientID:        webhook.CustomerID,
 		IsPriority:      isPriority,
 		MessageData:     string(eventJSON),
@@ -158,7 +158,7 @@ func (s *WebhookService) BroadcastEvent(ctx context.Context, event *WebhookEvent
 }
 
 func main() {
-	fmt.Println("=== Rebound Example 3: Webhook Delivery Service ===\n")
+	fmt.Print("=== Rebound Example 3: Webhook Delivery Service ===\n\n")
 
 	// Setup logger
 	logger, _ := zap.NewDevelopment()
@@ -184,7 +184,7 @@ func main() {
 		log.Fatalf("Failed to start rebound: %v", err)
 	}
 
-	fmt.Println("✓ Webhook delivery service with Rebound started\n")
+	fmt.Print("✓ Webhook delivery service with Rebound started\n\n")
 
 	// Create webhook service
 	webhookService := NewWebhookService(rb, logger)
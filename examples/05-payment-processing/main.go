@@ -153,7 +153,7 @@ func (s *PaymentService) scheduleRetry(ctx context.Context, payment *Payment, st
 			URL: "http://internal-api.brevo.com/payments/failed",
 		},
 		MaxRetries:      strategy.MaxRetries,
-		BaseDelay:       strategy.BaseDelay,
+		BaseDelay:       time.Duration(strategy.BaseDelay) * time.Second,
 		ClientID:        payment.CustomerID,
 		IsPriority:      strategy.IsPriority,
 		MessageData:     string(paymentJSON),
@@ -196,7 +196,7 @@ func (s *PaymentService) sendToFailureQueue(ctx context.Context, payment *Paymen
 }
 
 func main() {
-	fmt.Println("=== Rebound Example 5: Payment Processing with Smart Retry ===\n")
+	fmt.Print("=== Rebound Example 5: Payment Processing with Smart Retry ===\n\n")
 
 	// Setup logger
 	logger, _ := zap.NewDevelopment()
@@ -222,13 +222,13 @@ func main() {
 		log.Fatalf("Failed to start rebound: %v", err)
 	}
 
-	fmt.Println("✓ Payment service with smart retry started\n")
+	fmt.Print("✓ Payment service with smart retry started\n\n")
 
 	// Create payment service
 	paymentService := NewPaymentService(rb, logger)
 
 	// Test different failure scenarios
-	fmt.Println("💳 Testing different payment failure scenarios:\n")
+	fmt.Print("💳 Testing different payment failure scenarios:\n\n")
 
 	scenarios := []struct {
 		payment *Payment
@@ -110,7 +110,7 @@ func (s *TenantService) SendNotification(ctx context.Context, tenant *Tenant, ev
 			URL: fmt.Sprintf("http://internal-api.brevo.com/tenants/%s/failed-notifications", tenant.ID),
 		},
 		MaxRetries:      policy.MaxRetries,
-		BaseDelay:       policy.BaseDelay,
+		BaseDelay:       time.Duration(policy.BaseDelay) * time.Second,
 		ClientID:        tenant.ID,
 		IsPriority:      policy.IsPriority,
 		MessageData:     string(eventJSON),
@@ -155,18 +155,8 @@ func (s *TenantService) BroadcastToAllTenants(ctx context.Context, tenants []*Te
 	}
 }
 
-// CalculateRetrySchedule shows when retries will occur for a tenant
-func CalculateRetrySchedule(policy RetryPolicy) []time.Duration {
-	var schedule []time.Duration
-	for i := 0; i < policy.MaxRetries; i++ {
-		delay := time.Duration(policy.BaseDelay) * time.Second * time.Duration(1<<i)
-		schedule = append(schedule, delay)
-	}
-	return schedule
-}
-
 func main() {
-	fmt.Println("=== Rebound Example 6: Multi-Tenant Service ===\n")
+	fmt.Print("=== Rebound Example 6: Multi-Tenant Service ===\n\n")
 
 	// Setup logger
 	logger, _ := zap.NewDevelopment()
@@ -192,7 +182,7 @@ func main() {
 		log.Fatalf("Failed to start rebound: %v", err)
 	}
 
-	fmt.Println("✓ Multi-tenant service with Rebound started\n")
+	fmt.Print("✓ Multi-tenant service with Rebound started\n\n")
 
 	// Create tenant service
 	tenantService := NewTenantService(rb, logger)
@@ -226,7 +216,7 @@ func main() {
 		},
 	}
 
-	fmt.Println("📊 Tenant Retry Policies:\n")
+	fmt.Print("📊 Tenant Retry Policies:\n\n")
 	fmt.Println("┌──────────────┬──────────────────┬────────────┬──────────┬───────────┬──────────┐")
 	fmt.Println("│ Tenant ID    │ Name             │ Plan       │ Retries  │ Base Delay│ Priority │")
 	fmt.Println("├──────────────┼──────────────────┼────────────┼──────────┼───────────┼──────────┤")
@@ -243,21 +233,28 @@ func main() {
 	fmt.Println("└──────────────┴──────────────────┴────────────┴──────────┴───────────┴──────────┘")
 
 	// Show retry schedules for each plan
-	fmt.Println("\n⏱️  Retry Schedules by Plan:\n")
+	fmt.Print("\n⏱️  Retry Schedules by Plan:\n\n")
 
 	for _, plan := range []string{"enterprise", "pro", "free"} {
 		policy := tenantService.GetTenantRetryPolicy(&Tenant{Plan: plan})
-		schedule := CalculateRetrySchedule(policy)
+		now := time.Now()
+		schedule, err := rebound.PreviewSchedule(&rebound.Task{
+			MaxRetries: policy.MaxRetries,
+			BaseDelay:  time.Duration(policy.BaseDelay) * time.Second,
+		})
+		if err != nil {
+			log.Fatalf("Failed to preview retry schedule: %v", err)
+		}
 
 		fmt.Printf("%s Plan:\n", plan)
-		for i, delay := range schedule {
-			fmt.Printf("  Attempt %d: %v delay\n", i+1, delay)
+		for i, w := range schedule {
+			fmt.Printf("  Attempt %d: after %v\n", i+1, w.Earliest.Sub(now).Round(time.Second))
 		}
 		fmt.Println()
 	}
 
 	// Broadcast event to all tenants
-	fmt.Println("📡 Broadcasting system update to all tenants...\n")
+	fmt.Print("📡 Broadcasting system update to all tenants...\n\n")
 
 	event := map[string]interface{}{
 		"update_type": "feature_release",
@@ -14,6 +14,9 @@ import (
 
 	"github.com/segmentio/kafka-go"
 	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/pkg/consumer"
+	"github.com/ruudy-sib/rebound/pkg/rebound"
 )
 
 var (
@@ -28,36 +31,51 @@ var (
 func main() {
 	flag.Parse()
 
-	fmt.Println("=== Rebound Consumer Benchmark ===\n")
+	fmt.Print("=== Rebound Consumer Benchmark ===\n\n")
 
 	// Create logger
 	logger, _ := zap.NewDevelopment()
 	defer logger.Sync()
 
+	// Create Rebound instance for retry logic
+	rb, err := rebound.New(&rebound.Config{
+		RedisAddr:    *redisAddr,
+		PollInterval: 1 * time.Second,
+		Logger:       logger,
+	})
+	if err != nil {
+		logger.Fatal("failed to create rebound", zap.Error(err))
+	}
+	defer rb.Close()
+
 	// Create consumer with simulated failure processor
-	consumer, err := NewConsumer(&ConsumerConfig{
-		KafkaBrokers:   []string{*kafkaBrokers},
+	c, err := consumer.New(consumer.Config{
+		Brokers:        []string{*kafkaBrokers},
 		GroupID:        *groupID,
 		Topic:          *topic,
-		RedisAddr:      *redisAddr,
+		CommitInterval: time.Second,
 		MaxRetries:     3,
 		BaseDelay:      5,
-		ProcessingFunc: simulatedProcessor(*failureRate),
 		Logger:         logger,
-	})
+	}, rb, simulatedProcessor(*failureRate))
 	if err != nil {
 		logger.Fatal("failed to create consumer", zap.Error(err))
 	}
-	defer consumer.Close()
+	defer c.Close()
 
 	// Create context with cancellation
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	// Start consumer
-	if err := consumer.Start(ctx); err != nil {
-		logger.Fatal("failed to start consumer", zap.Error(err))
+	// Start rebound worker and consumer
+	if err := rb.Start(ctx); err != nil {
+		logger.Fatal("failed to start rebound", zap.Error(err))
 	}
+	consumerDone := make(chan struct{})
+	go func() {
+		defer close(consumerDone)
+		_ = c.Run(ctx)
+	}()
 
 	fmt.Printf("✓ Consumer started\n")
 	fmt.Printf("  Topic: %s\n", *topic)
@@ -79,17 +97,18 @@ func main() {
 		fmt.Printf("\n📡 Received signal %v, shutting down...\n", sig)
 	}
 
-	// Graceful shutdown
+	// Graceful shutdown: Run returns once in-flight messages are handled
 	cancel()
-	time.Sleep(2 * time.Second) // Allow in-flight messages to complete
+	<-consumerDone
 
 	// Print statistics
-	stats := consumer.Stats()
+	stats := c.Stats()
 	fmt.Println("\n=== Final Statistics ===")
-	fmt.Println(stats.String())
+	data, _ := json.MarshalIndent(stats, "", "  ")
+	fmt.Println(string(data))
 
-	successRate := float64(stats.SuccessfulMessages) / float64(stats.TotalMessages) * 100
-	retryRate := float64(stats.RetriedMessages) / float64(stats.TotalMessages) * 100
+	successRate := float64(stats.Succeeded) / float64(stats.Messages) * 100
+	retryRate := float64(stats.Retried) / float64(stats.Messages) * 100
 
 	fmt.Printf("\nSuccess Rate: %.2f%%\n", successRate)
 	fmt.Printf("Retry Rate: %.2f%%\n", retryRate)
@@ -97,7 +116,7 @@ func main() {
 }
 
 // simulatedProcessor creates a message processor that fails randomly based on the failure rate.
-func simulatedProcessor(failureRate float64) MessageProcessor {
+func simulatedProcessor(failureRate float64) consumer.Handler {
 	return func(ctx context.Context, message kafka.Message) error {
 		// Simulate some processing time
 		time.Sleep(time.Duration(10+rand.Intn(40)) * time.Millisecond)
@@ -0,0 +1,277 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+func TestParseSourceAllowlist(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    SourceAllowlist
+		wantErr bool
+	}{
+		{name: "empty disables", input: "", want: SourceAllowlist{}},
+		{
+			name:  "multiple keys and sources",
+			input: "key-a:billing,invoices; key-b: notifier;",
+			want:  SourceAllowlist{"key-a": {"billing", "invoices"}, "key-b": {"notifier"}},
+		},
+		{name: "key without sources", input: "key-a:billing;key-c:", wantErr: true},
+		{name: "key with blank sources", input: "key-c: , ", wantErr: true},
+		{name: "missing colon", input: "no-colon", wantErr: true},
+		{name: "missing key", input: ":orphan", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseSourceAllowlist(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+			for key, sources := range tt.want {
+				if strings.Join(got[key], ",") != strings.Join(sources, ",") {
+					t.Fatalf("key %q: got %v, want %v", key, got[key], sources)
+				}
+			}
+		})
+	}
+}
+
+func TestSourceAllowlist(t *testing.T) {
+	task := func(source string) CreateTaskRequest {
+		return CreateTaskRequest{
+			ID:              "task-1",
+			Source:          source,
+			Destination:     DestinationDTO{URL: "http://localhost:8090/hook"},
+			MaxRetries:      3,
+			BaseDelay:       Delay(1 * time.Second),
+			DestinationType: "http",
+		}
+	}
+	allowlist := SourceAllowlist{"billing-key": {"billing", "invoices"}}
+
+	tests := []struct {
+		name           string
+		allowlist      SourceAllowlist
+		path           string
+		apiKey         string
+		body           interface{}
+		wantStatusCode int
+		wantCreated    bool
+	}{
+		{
+			name:           "no allowlist leaves endpoints open",
+			path:           "/tasks",
+			body:           task("anything"),
+			wantStatusCode: http.StatusCreated,
+			wantCreated:    true,
+		},
+		{
+			name:           "missing api key",
+			allowlist:      allowlist,
+			path:           "/tasks",
+			body:           task("billing"),
+			wantStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:           "unknown api key",
+			allowlist:      allowlist,
+			path:           "/tasks",
+			apiKey:         "other-key",
+			body:           task("billing"),
+			wantStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:           "allowed source",
+			allowlist:      allowlist,
+			path:           "/tasks",
+			apiKey:         "billing-key",
+			body:           task("invoices"),
+			wantStatusCode: http.StatusCreated,
+			wantCreated:    true,
+		},
+		{
+			name:           "source of another service",
+			allowlist:      allowlist,
+			path:           "/tasks",
+			apiKey:         "billing-key",
+			body:           task("notifier"),
+			wantStatusCode: http.StatusForbidden,
+		},
+		{
+			name:      "broadcast with one foreign source",
+			allowlist: allowlist,
+			path:      "/tasks/broadcast",
+			apiKey:    "billing-key",
+			body: BroadcastTasksRequest{
+				Window: "1m",
+				Tasks:  []CreateTaskRequest{task("billing"), task("notifier")},
+			},
+			wantStatusCode: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := &mockTaskService{}
+			router := NewRouter(mockSvc, &mockMaintenanceService{}, &mockSLAService{}, &mockScheduleService{}, &mockQueueService{}, nil, zap.NewNop(),
+				WithSourceAllowlist(tt.allowlist),
+			)
+
+			body, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest(http.MethodPost, tt.path, bytes.NewReader(body))
+			if tt.apiKey != "" {
+				req.Header.Set(APIKeyHeader, tt.apiKey)
+			}
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatusCode {
+				t.Fatalf("expected status %d, got %d (body: %s)", tt.wantStatusCode, rec.Code, rec.Body.String())
+			}
+			created := mockSvc.createCalled > 0 || mockSvc.pacedTasks != nil
+			if created != tt.wantCreated {
+				t.Fatalf("wantCreated=%v, got created=%v", tt.wantCreated, created)
+			}
+		})
+	}
+}
+
+func TestSourceAllowlist_taskByID(t *testing.T) {
+	allowlist := SourceAllowlist{"billing-key": {"billing"}}
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		body           string
+		wantStatusCode int
+		wantActed      bool
+		wantResult     string // status of the one bulk result
+	}{
+		{
+			name:           "prioritize own task",
+			method:         http.MethodPost,
+			path:           "/tasks/own/prioritize",
+			wantStatusCode: http.StatusOK,
+			wantActed:      true,
+		},
+		{
+			name:           "prioritize task of another service",
+			method:         http.MethodPost,
+			path:           "/tasks/foreign/prioritize",
+			wantStatusCode: http.StatusForbidden,
+		},
+		{
+			name:           "reschedule task of another service",
+			method:         http.MethodPatch,
+			path:           "/tasks/foreign/schedule",
+			body:           `{"shift":"1h"}`,
+			wantStatusCode: http.StatusForbidden,
+		},
+		{
+			name:           "result of own task",
+			method:         http.MethodGet,
+			path:           "/tasks/own/result",
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:           "result of task of another service",
+			method:         http.MethodGet,
+			path:           "/tasks/foreign/result",
+			wantStatusCode: http.StatusForbidden,
+		},
+		{
+			name:           "cancel task of another service",
+			method:         http.MethodPost,
+			path:           "/tasks/cancel",
+			body:           `{"ids":["foreign"]}`,
+			wantStatusCode: http.StatusOK,
+			wantResult:     "forbidden",
+		},
+		{
+			name:           "restore task of another service",
+			method:         http.MethodPost,
+			path:           "/tasks/restore",
+			body:           `{"ids":["foreign"]}`,
+			wantStatusCode: http.StatusOK,
+			wantResult:     "forbidden",
+		},
+		{
+			name:           "restore own task",
+			method:         http.MethodPost,
+			path:           "/tasks/restore",
+			body:           `{"ids":["own"]}`,
+			wantStatusCode: http.StatusOK,
+			wantActed:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := &mockTaskService{
+				tasks: map[string]*entity.Task{
+					"own":     {ID: "own", Source: "billing"},
+					"foreign": {ID: "foreign", Source: "notifier"},
+				},
+				held: map[string]*entity.Task{
+					"own":     {ID: "own", Source: "billing"},
+					"foreign": {ID: "foreign", Source: "notifier"},
+				},
+				results: map[string]*entity.DeliveryResult{
+					"own":     {TaskID: "own", Source: "billing"},
+					"foreign": {TaskID: "foreign", Source: "notifier"},
+				},
+			}
+			scheduleSvc := &mockScheduleService{}
+			router := NewRouter(mockSvc, &mockMaintenanceService{}, &mockSLAService{}, scheduleSvc, &mockQueueService{}, nil, zap.NewNop(),
+				WithSourceAllowlist(allowlist),
+			)
+
+			req := httptest.NewRequest(tt.method, tt.path, bytes.NewBufferString(tt.body))
+			req.Header.Set(APIKeyHeader, "billing-key")
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatusCode {
+				t.Fatalf("expected status %d, got %d (body: %s)", tt.wantStatusCode, rec.Code, rec.Body.String())
+			}
+			acted := len(mockSvc.prioritized) > 0 || len(mockSvc.cancelled) > 0 || len(mockSvc.restored) > 0 ||
+				scheduleSvc.rescheduledID != ""
+			if acted != tt.wantActed {
+				t.Fatalf("wantActed=%v, got acted=%v", tt.wantActed, acted)
+			}
+			if tt.wantResult != "" {
+				var resp BulkTaskResponse
+				if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+					t.Fatalf("decoding response: %v", err)
+				}
+				if resp.Results[0].Status != tt.wantResult {
+					t.Fatalf("expected %s, got %+v", tt.wantResult, resp.Results[0])
+				}
+			}
+		})
+	}
+}
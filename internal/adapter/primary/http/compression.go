@@ -0,0 +1,106 @@
+package http
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	// minGzipBytes is the response size below which compressing costs more
+	// than it saves.
+	minGzipBytes = 1024
+
+	// maxDecompressedBytes bounds a gzip request body once inflated, so a
+	// small compressed body cannot expand without limit.
+	maxDecompressedBytes = 32 << 20
+)
+
+// compression inflates gzip request bodies and gzips responses of at least
+// minGzipBytes for clients that accept it.
+func compression(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch encoding := strings.ToLower(strings.TrimSpace(r.Header.Get("Content-Encoding"))); encoding {
+		case "", "identity":
+		case "gzip":
+			gz, err := gzip.NewReader(r.Body)
+			if err != nil {
+				respondJSON(w, http.StatusBadRequest, ErrorResponse{
+					Error: "invalid gzip body: " + err.Error(),
+					Code:  "INVALID_BODY",
+				})
+				return
+			}
+			defer gz.Close()
+			r.Body = http.MaxBytesReader(w, gz, maxDecompressedBytes)
+			r.Header.Del("Content-Encoding")
+			r.ContentLength = -1
+		default:
+			respondJSON(w, http.StatusUnsupportedMediaType, ErrorResponse{
+				Error: "unsupported Content-Encoding " + encoding + "; use gzip",
+				Code:  "UNSUPPORTED_ENCODING",
+			})
+			return
+		}
+
+		w.Header().Add("Vary", "Accept-Encoding")
+		if !acceptsGzip(r.Header.Get("Accept-Encoding")) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		bw := &bufferedResponseWriter{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(bw, r)
+		bw.flush()
+	})
+}
+
+// acceptsGzip reports whether an Accept-Encoding header allows gzip.
+func acceptsGzip(header string) bool {
+	for _, part := range strings.Split(header, ",") {
+		coding, params, _ := strings.Cut(part, ";")
+		if !strings.EqualFold(strings.TrimSpace(coding), "gzip") {
+			continue
+		}
+		q, ok := strings.CutPrefix(strings.TrimSpace(params), "q=")
+		if !ok {
+			return true
+		}
+		weight, err := strconv.ParseFloat(q, 64)
+		return err != nil || weight > 0
+	}
+	return false
+}
+
+// bufferedResponseWriter holds a response back until the handler is done,
+// so its size decides whether it is compressed.
+type bufferedResponseWriter struct {
+	http.ResponseWriter
+	status int
+	buf    bytes.Buffer
+}
+
+func (w *bufferedResponseWriter) WriteHeader(status int) {
+	w.status = status
+}
+
+func (w *bufferedResponseWriter) Write(p []byte) (int, error) {
+	return w.buf.Write(p)
+}
+
+// flush writes the response, gzipped if it is large enough.
+func (w *bufferedResponseWriter) flush() {
+	if w.buf.Len() < minGzipBytes || w.Header().Get("Content-Encoding") != "" {
+		w.ResponseWriter.WriteHeader(w.status)
+		_, _ = w.buf.WriteTo(w.ResponseWriter)
+		return
+	}
+	w.Header().Set("Content-Encoding", "gzip")
+	w.Header().Del("Content-Length")
+	w.ResponseWriter.WriteHeader(w.status)
+	gz := gzip.NewWriter(w.ResponseWriter)
+	_, _ = io.Copy(gz, &w.buf)
+	_ = gz.Close()
+}
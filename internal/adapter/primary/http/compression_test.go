@@ -0,0 +1,131 @@
+package http
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+func TestRouter_gzipRequest(t *testing.T) {
+	body, _ := json.Marshal(CreateTaskRequest{
+		ID:              "task-1",
+		Source:          "billing",
+		Destination:     DestinationDTO{URL: "http://localhost:8090/hook"},
+		MaxRetries:      3,
+		BaseDelay:       Delay(1 * time.Second),
+		DestinationType: "http",
+	})
+
+	tests := []struct {
+		name           string
+		encoding       string
+		body           []byte
+		wantStatusCode int
+	}{
+		{name: "gzip body", encoding: "gzip", body: gzipBytes(t, body), wantStatusCode: http.StatusCreated},
+		{name: "plain body", body: body, wantStatusCode: http.StatusCreated},
+		{name: "corrupt gzip", encoding: "gzip", body: body, wantStatusCode: http.StatusBadRequest},
+		{name: "unsupported encoding", encoding: "br", body: body, wantStatusCode: http.StatusUnsupportedMediaType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := &mockTaskService{}
+			router := NewRouter(mockSvc, &mockMaintenanceService{}, &mockSLAService{}, &mockScheduleService{}, &mockQueueService{}, nil, zap.NewNop())
+
+			req := httptest.NewRequest(http.MethodPost, "/v1/tasks", bytes.NewReader(tt.body))
+			if tt.encoding != "" {
+				req.Header.Set("Content-Encoding", tt.encoding)
+			}
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatusCode {
+				t.Fatalf("expected status %d, got %d (body: %s)", tt.wantStatusCode, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestRouter_gzipResponse(t *testing.T) {
+	tests := []struct {
+		name           string
+		deadLetters    int
+		acceptEncoding string
+		wantGzip       bool
+	}{
+		{name: "large response", deadLetters: 50, acceptEncoding: "gzip, deflate", wantGzip: true},
+		{name: "small response", deadLetters: 0, acceptEncoding: "gzip"},
+		{name: "gzip not accepted", deadLetters: 50},
+		{name: "gzip refused", deadLetters: 50, acceptEncoding: "gzip;q=0, identity"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := &mockTaskService{}
+			for i := 0; i < tt.deadLetters; i++ {
+				task := &entity.Task{ID: "task-" + strconv.Itoa(i), Source: "billing"}
+				mockSvc.deadLetters = append(mockSvc.deadLetters, entity.DeadLetter{Task: task, Reason: "max retries exceeded"})
+			}
+			router := NewRouter(mockSvc, &mockMaintenanceService{}, &mockSLAService{}, &mockScheduleService{}, &mockQueueService{}, nil, zap.NewNop())
+
+			req := httptest.NewRequest(http.MethodGet, "/v1/admin/dead-letters", nil)
+			if tt.acceptEncoding != "" {
+				req.Header.Set("Accept-Encoding", tt.acceptEncoding)
+			}
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusOK {
+				t.Fatalf("expected status 200, got %d", rec.Code)
+			}
+			gzipped := rec.Header().Get("Content-Encoding") == "gzip"
+			if gzipped != tt.wantGzip {
+				t.Fatalf("expected gzip=%v, got headers %v", tt.wantGzip, rec.Header())
+			}
+
+			body := rec.Body.Bytes()
+			if gzipped {
+				gz, err := gzip.NewReader(rec.Body)
+				if err != nil {
+					t.Fatalf("invalid gzip response: %v", err)
+				}
+				if body, err = io.ReadAll(gz); err != nil {
+					t.Fatalf("invalid gzip response: %v", err)
+				}
+			}
+			var resp DeadLettersResponse
+			if err := json.Unmarshal(body, &resp); err != nil {
+				t.Fatalf("invalid JSON response: %v", err)
+			}
+			if len(resp.DeadLetters) != tt.deadLetters {
+				t.Fatalf("expected %d dead letters, got %d", tt.deadLetters, len(resp.DeadLetters))
+			}
+		})
+	}
+}
+
+func gzipBytes(t *testing.T, data []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if _, err := gz.Write(data); err != nil {
+		t.Fatalf("gzip: %v", err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatalf("gzip: %v", err)
+	}
+	return buf.Bytes()
+}
@@ -0,0 +1,45 @@
+package http
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+// cursorToken is the JSON inside a cursor token. Clients treat tokens as
+// opaque, so the layout may change between releases.
+type cursorToken struct {
+	Score  float64 `json:"s"`
+	Member string  `json:"m"`
+}
+
+// encodeCursor turns a cursor into the opaque token clients pass back as
+// the cursor query parameter. The zero cursor, meaning there is no next
+// page, encodes to "".
+func encodeCursor(c entity.Cursor) string {
+	if c.IsZero() {
+		return ""
+	}
+	data, _ := json.Marshal(cursorToken{Score: c.Score, Member: c.Member})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// queryCursor reads the cursor query parameter, defaulting to the start.
+func queryCursor(r *http.Request) (entity.Cursor, error) {
+	v := r.URL.Query().Get("cursor")
+	if v == "" {
+		return entity.Cursor{}, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(v)
+	if err != nil {
+		return entity.Cursor{}, fmt.Errorf("invalid cursor")
+	}
+	var token cursorToken
+	if err := json.Unmarshal(data, &token); err != nil {
+		return entity.Cursor{}, fmt.Errorf("invalid cursor")
+	}
+	return entity.Cursor{Score: token.Score, Member: token.Member}, nil
+}
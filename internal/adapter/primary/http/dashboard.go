@@ -0,0 +1,24 @@
+package http
+
+import (
+	"embed"
+	"io/fs"
+	"net/http"
+)
+
+//go:embed dashboard
+var dashboardAssets embed.FS
+
+// dashboardPath is where the embedded admin dashboard is served.
+const dashboardPath = "/admin/dashboard/"
+
+// newDashboardHandler serves the admin dashboard's static assets. The page
+// itself calls the admin API from the browser, so it needs no server state.
+func newDashboardHandler() http.Handler {
+	assets, err := fs.Sub(dashboardAssets, "dashboard")
+	if err != nil {
+		// The directory is embedded at build time; this cannot fail.
+		panic(err)
+	}
+	return http.StripPrefix(dashboardPath, http.FileServer(http.FS(assets)))
+}
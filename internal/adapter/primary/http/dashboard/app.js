@@ -0,0 +1,164 @@
+// Rebound admin dashboard. Talks to the same admin API operators can call
+// directly; the API key, if any, is kept in localStorage.
+(function () {
+  "use strict";
+
+  const REFRESH_MS = 10000;
+  const API_BASE = "/v1";
+  const keyInput = document.getElementById("api-key");
+  keyInput.value = localStorage.getItem("rebound.apiKey") || "";
+  keyInput.addEventListener("change", () => {
+    localStorage.setItem("rebound.apiKey", keyInput.value);
+  });
+
+  async function api(path, options) {
+    const opts = Object.assign({ headers: {} }, options);
+    if (keyInput.value) {
+      opts.headers["X-API-Key"] = keyInput.value;
+    }
+    if (opts.body) {
+      opts.headers["Content-Type"] = "application/json";
+    }
+    const resp = await fetch(API_BASE + path, opts);
+    const body = await resp.json().catch(() => ({}));
+    if (!resp.ok) {
+      throw new Error(body.error || resp.statusText);
+    }
+    return body;
+  }
+
+  function cell(row, text, className) {
+    const td = row.insertCell();
+    td.textContent = text;
+    if (className) {
+      td.className = className;
+    }
+    return td;
+  }
+
+  function checkbox(row, id) {
+    const input = document.createElement("input");
+    input.type = "checkbox";
+    input.value = id;
+    row.insertCell().appendChild(input);
+  }
+
+  function fill(tbody, items, columns, render) {
+    tbody.replaceChildren();
+    if (items.length === 0) {
+      cell(tbody.insertRow(), "Nothing here.", "empty").colSpan = columns;
+      return;
+    }
+    items.forEach((item) => render(tbody.insertRow(), item));
+  }
+
+  function when(iso) {
+    return iso ? new Date(iso).toLocaleString() : "";
+  }
+
+  function selected(tbody) {
+    return Array.from(tbody.querySelectorAll("input:checked"), (input) => input.value);
+  }
+
+  function say(text, isError) {
+    const el = document.getElementById("message");
+    el.textContent = text;
+    el.className = isError ? "error" : "";
+  }
+
+  async function loadForecast() {
+    const forecast = await api("/schedule/forecast?window=1h");
+    const counts = forecast.buckets.map((b) => b.count);
+    const peak = Math.max(1, ...counts);
+    document.getElementById("overdue").textContent = forecast.overdue;
+    document.getElementById("next-hour").textContent = counts.reduce((a, b) => a + b, 0);
+
+    const chart = document.getElementById("forecast");
+    chart.replaceChildren();
+    forecast.buckets.forEach((b) => {
+      const bar = document.createElement("div");
+      bar.style.height = (100 * b.count / peak) + "%";
+      bar.title = new Date(b.start).toLocaleTimeString() + ": " + b.count;
+      chart.appendChild(bar);
+    });
+  }
+
+  async function loadSchedule() {
+    const { tasks } = await api("/schedule/upcoming?limit=500");
+
+    fill(document.getElementById("upcoming"), tasks.slice(0, 50), 6, (row, t) => {
+      checkbox(row, t.id);
+      cell(row, t.id);
+      cell(row, t.client_id);
+      cell(row, t.destination);
+      cell(row, t.attempt + " / " + t.max_retries);
+      cell(row, when(t.due_at));
+    });
+
+    const failures = tasks
+      .filter((t) => t.last_error)
+      .sort((a, b) => (b.last_attempt_at || "").localeCompare(a.last_attempt_at || ""))
+      .slice(0, 50);
+    fill(document.getElementById("failures"), failures, 6, (row, t) => {
+      cell(row, t.id);
+      cell(row, t.client_id);
+      cell(row, t.destination);
+      cell(row, t.attempt + " / " + t.max_retries);
+      cell(row, when(t.last_attempt_at));
+      cell(row, t.last_error, "error");
+    });
+  }
+
+  async function loadDeadLetters() {
+    const { dead_letters: entries } = await api("/admin/dead-letters?limit=50");
+    fill(document.getElementById("dead-letters"), entries, 6, (row, d) => {
+      checkbox(row, d.id);
+      cell(row, d.id);
+      cell(row, d.client_id);
+      cell(row, d.destination);
+      cell(row, when(d.stored_at));
+      cell(row, d.reason, "error");
+    });
+  }
+
+  async function refresh() {
+    const results = await Promise.allSettled([loadForecast(), loadSchedule(), loadDeadLetters()]);
+    const failed = results.find((r) => r.status === "rejected");
+    if (failed) {
+      say("Refresh failed: " + failed.reason.message, true);
+    }
+    document.getElementById("updated").textContent = "Updated " + new Date().toLocaleTimeString();
+    updateButtons();
+  }
+
+  function updateButtons() {
+    document.getElementById("cancel-selected").disabled = selected(document.getElementById("upcoming")).length === 0;
+    document.getElementById("requeue-selected").disabled = selected(document.getElementById("dead-letters")).length === 0;
+  }
+
+  async function bulk(path, tbody, verb) {
+    const ids = selected(tbody);
+    if (ids.length === 0 || !confirm(verb + " " + ids.length + " task(s)?")) {
+      return;
+    }
+    try {
+      const { results } = await api(path, { method: "POST", body: JSON.stringify({ ids }) });
+      const failed = results.filter((r) => r.status === "not_found" || r.status === "error");
+      say((results.length - failed.length) + " of " + results.length + " task(s) done" +
+        (failed.length ? "; failed: " + failed.map((r) => r.id + " (" + r.status + ")").join(", ") : "."),
+        failed.length > 0);
+    } catch (err) {
+      say(verb + " failed: " + err.message, true);
+    }
+    refresh();
+  }
+
+  document.addEventListener("change", updateButtons);
+  document.getElementById("cancel-selected").addEventListener("click", () =>
+    bulk("/tasks/cancel", document.getElementById("upcoming"), "Cancel"));
+  document.getElementById("requeue-selected").addEventListener("click", () =>
+    bulk("/admin/dead-letters/requeue", document.getElementById("dead-letters"), "Requeue"));
+
+  refresh();
+  setInterval(refresh, REFRESH_MS);
+})();
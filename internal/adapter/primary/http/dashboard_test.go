@@ -0,0 +1,44 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestDashboard_servesEmbeddedAssets(t *testing.T) {
+	router := NewRouter(&mockTaskService{}, &mockMaintenanceService{}, &mockSLAService{}, &mockScheduleService{}, &mockQueueService{}, nil, zap.NewNop())
+
+	tests := []struct {
+		path        string
+		wantStatus  int
+		wantType    string
+		wantContent string
+	}{
+		{path: "/admin/dashboard/", wantStatus: http.StatusOK, wantType: "text/html", wantContent: "<title>Rebound</title>"},
+		{path: "/admin/dashboard/app.js", wantStatus: http.StatusOK, wantType: "javascript", wantContent: "/admin/dead-letters"},
+		{path: "/admin/dashboard/style.css", wantStatus: http.StatusOK, wantType: "text/css"},
+		{path: "/admin/dashboard", wantStatus: http.StatusTemporaryRedirect},
+		{path: "/admin/dashboard/missing.js", wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d", tt.wantStatus, rec.Code)
+			}
+			if got := rec.Header().Get("Content-Type"); !strings.Contains(got, tt.wantType) {
+				t.Fatalf("expected content type containing %q, got %q", tt.wantType, got)
+			}
+			if !strings.Contains(rec.Body.String(), tt.wantContent) {
+				t.Fatalf("expected body to contain %q", tt.wantContent)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Delay is a duration given either as a Go duration string such as "500ms"
+// or "2m", or, as before duration strings were accepted, as a number of
+// seconds.
+type Delay time.Duration
+
+// UnmarshalJSON accepts a duration string or a number of seconds.
+func (d *Delay) UnmarshalJSON(data []byte) error {
+	if bytes.HasPrefix(data, []byte(`"`)) {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		parsed, err := time.ParseDuration(s)
+		if err != nil {
+			return fmt.Errorf("invalid delay %q: %w", s, err)
+		}
+		*d = Delay(parsed)
+		return nil
+	}
+
+	var seconds float64
+	if err := json.Unmarshal(data, &seconds); err != nil {
+		return fmt.Errorf("delay must be a duration string or a number of seconds: %w", err)
+	}
+	*d = Delay(seconds * float64(time.Second))
+	return nil
+}
+
+// MarshalJSON writes the delay as a duration string.
+func (d Delay) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
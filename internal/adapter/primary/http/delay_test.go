@@ -0,0 +1,52 @@
+package http
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestDelay_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{name: "duration string", input: `"500ms"`, want: 500 * time.Millisecond},
+		{name: "minutes", input: `"2m"`, want: 2 * time.Minute},
+		{name: "integer seconds", input: `5`, want: 5 * time.Second},
+		{name: "fractional seconds", input: `0.25`, want: 250 * time.Millisecond},
+		{name: "invalid string", input: `"soon"`, wantErr: true},
+		{name: "wrong type", input: `true`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var d Delay
+			err := json.Unmarshal([]byte(tt.input), &d)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if time.Duration(d) != tt.want {
+				t.Fatalf("expected %s, got %s", tt.want, time.Duration(d))
+			}
+		})
+	}
+}
+
+func TestDelay_MarshalJSON(t *testing.T) {
+	data, err := json.Marshal(Delay(1500 * time.Millisecond))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(data) != `"1.5s"` {
+		t.Fatalf(`expected "1.5s", got %s`, data)
+	}
+}
@@ -1,19 +1,292 @@
 package http
 
-import "github.com/ruudy-sib/rebound/internal/domain/entity"
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
 
 // CreateTaskRequest matches the OpenAPI Task schema.
 type CreateTaskRequest struct {
-	ID              string         `json:"id"`
-	Source          string         `json:"source"`
-	Destination     DestinationDTO `json:"destination"`
-	DeadDestination DestinationDTO `json:"dead_destination"`
-	MaxRetries      int            `json:"max_retries"`
-	BaseDelay       int            `json:"base_delay"`
-	ClientID        string         `json:"client_id"`
-	IsPriority      bool           `json:"is_priority"`
-	MessageData     string         `json:"message_data"`
-	DestinationType string         `json:"destination_type"`
+	ID              string            `json:"id"`
+	Source          string            `json:"source"`
+	Destination     DestinationDTO    `json:"destination"`
+	DeadDestination DestinationDTO    `json:"dead_destination"`
+	MaxRetries      int               `json:"max_retries"`
+	BaseDelay       Delay             `json:"base_delay"`
+	ClientID        string            `json:"client_id"`
+	IsPriority      bool              `json:"is_priority"`
+	MessageData     string            `json:"message_data"`
+	DestinationType string            `json:"destination_type"`
+	OrderingKey     string            `json:"ordering_key,omitempty"`
+	Queue           string            `json:"queue,omitempty"`
+	CallbackURL     string            `json:"callback_url,omitempty"`
+	Metadata        map[string]string `json:"metadata,omitempty"`
+	CorrelationID   string            `json:"correlation_id,omitempty"`
+
+	AttemptImmediately bool   `json:"attempt_immediately,omitempty"`
+	Jitter             string `json:"jitter,omitempty"`
+	MaxDelay           Delay  `json:"max_delay,omitempty"`
+
+	RetrySchedule []Delay `json:"retry_schedule,omitempty"`
+
+	FallbackDeadDestinations []DestinationDTO `json:"fallback_dead_destinations,omitempty"`
+	FanOutDestinations       []DestinationDTO `json:"fan_out_destinations,omitempty"`
+}
+
+// BroadcastTasksRequest schedules many tasks with their first attempts
+// spread evenly over Window (a Go duration string such as "10m").
+type BroadcastTasksRequest struct {
+	Window string              `json:"window"`
+	Tasks  []CreateTaskRequest `json:"tasks"`
+}
+
+// TaskIDsRequest selects tasks by ID for bulk operations such as cancel
+// and restore.
+type TaskIDsRequest struct {
+	IDs []string `json:"ids"`
+}
+
+// TaskResult reports the outcome of a bulk operation for one task.
+type TaskResult struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// BulkTaskResponse lists per-task outcomes of a bulk operation.
+type BulkTaskResponse struct {
+	Results []TaskResult `json:"results"`
+}
+
+// PrioritizeTaskRequest is the optional body of POST /tasks/{id}/prioritize.
+// Now makes the task due immediately as well.
+type PrioritizeTaskRequest struct {
+	Now bool `json:"now"`
+}
+
+// PrioritizeTaskResponse reports a prioritized task and when it is due.
+type PrioritizeTaskResponse struct {
+	ID         string    `json:"id"`
+	IsPriority bool      `json:"is_priority"`
+	DueAt      time.Time `json:"due_at"`
+}
+
+// MaintenanceWindowDTO declares a destination (its URL or Kafka topic)
+// under maintenance until the given RFC 3339 time.
+type MaintenanceWindowDTO struct {
+	Destination string    `json:"destination"`
+	Until       time.Time `json:"until"`
+}
+
+// MaintenanceListResponse lists the active maintenance windows.
+type MaintenanceListResponse struct {
+	Windows []MaintenanceWindowDTO `json:"windows"`
+}
+
+// TimeToSuccessDTO reports time-to-success percentiles, in milliseconds,
+// for one client and destination.
+type TimeToSuccessDTO struct {
+	ClientID    string `json:"client_id"`
+	Destination string `json:"destination"`
+	Count       int    `json:"count"`
+	P50MS       int64  `json:"p50_ms"`
+	P90MS       int64  `json:"p90_ms"`
+	P99MS       int64  `json:"p99_ms"`
+	MaxMS       int64  `json:"max_ms"`
+}
+
+// SLAResponse lists time-to-success statistics.
+type SLAResponse struct {
+	TimeToSuccess []TimeToSuccessDTO `json:"time_to_success"`
+}
+
+// ForecastBucketDTO counts the tasks becoming due in the bucket starting at Start.
+type ForecastBucketDTO struct {
+	Start time.Time `json:"start"`
+	Count int64     `json:"count"`
+}
+
+// ForecastResponse is returned by the schedule forecast endpoint.
+type ForecastResponse struct {
+	From          time.Time           `json:"from"`
+	BucketSeconds int                 `json:"bucket_seconds"`
+	Overdue       int64               `json:"overdue"`
+	Buckets       []ForecastBucketDTO `json:"buckets"`
+}
+
+// ShiftTasksRequest moves the due time of the scheduled tasks matching its
+// filter fields by Shift (a Go duration such as "2h" or "-30m").
+type ShiftTasksRequest struct {
+	ClientID    string    `json:"client_id,omitempty"`
+	Destination string    `json:"destination,omitempty"`
+	DueAfter    time.Time `json:"due_after"`
+	DueBefore   time.Time `json:"due_before"`
+	Shift       string    `json:"shift"`
+}
+
+// ShiftTasksResponse reports how many tasks were moved.
+type ShiftTasksResponse struct {
+	Shifted int `json:"shifted"`
+}
+
+// RescheduleTaskRequest moves one task's next attempt, either to DueAt or
+// by Shift (a Go duration such as "2h" or "-30m") from its current due
+// time. Exactly one must be set.
+type RescheduleTaskRequest struct {
+	DueAt time.Time `json:"due_at"`
+	Shift string    `json:"shift,omitempty"`
+}
+
+// RescheduleTaskResponse reports a task's old and new due times.
+type RescheduleTaskResponse struct {
+	ID            string    `json:"id"`
+	PreviousDueAt time.Time `json:"previous_due_at"`
+	DueAt         time.Time `json:"due_at"`
+}
+
+// DeliveryResultResponse proves a task's successful delivery. HTTP
+// deliveries report the response status and the start of its body, Kafka
+// deliveries the partition and offset, when the producer learned them.
+type DeliveryResultResponse struct {
+	ID              string    `json:"id"`
+	DestinationType string    `json:"destination_type"`
+	Destination     string    `json:"destination"`
+	Attempts        int       `json:"attempts"`
+	DeliveredAt     time.Time `json:"delivered_at"`
+	HTTPStatus      int       `json:"http_status,omitempty"`
+	ResponseBody    string    `json:"response_body,omitempty"`
+	Partition       *int      `json:"partition,omitempty"`
+	Offset          *int64    `json:"offset,omitempty"`
+}
+
+// ScheduledTaskDTO summarizes a scheduled task for admin listings.
+// Destination is the URL or Kafka topic.
+type ScheduledTaskDTO struct {
+	ID              string            `json:"id"`
+	Source          string            `json:"source"`
+	ClientID        string            `json:"client_id"`
+	DestinationType string            `json:"destination_type"`
+	Destination     string            `json:"destination"`
+	Attempt         int               `json:"attempt"`
+	MaxRetries      int               `json:"max_retries"`
+	DueAt           time.Time         `json:"due_at"`
+	LastAttemptAt   *time.Time        `json:"last_attempt_at,omitempty"`
+	LastError       string            `json:"last_error,omitempty"`
+	LastErrorCode   string            `json:"last_error_code,omitempty"`
+	Metadata        map[string]string `json:"metadata,omitempty"`
+	CorrelationID   string            `json:"correlation_id,omitempty"`
+}
+
+// UpcomingTasksResponse lists scheduled tasks, earliest due first.
+// NextCursor, if set, fetches the next page.
+type UpcomingTasksResponse struct {
+	Tasks      []ScheduledTaskDTO `json:"tasks"`
+	NextCursor string             `json:"next_cursor,omitempty"`
+}
+
+// DeadLetterDTO summarizes a task held in the dead-letter store.
+type DeadLetterDTO struct {
+	ID              string            `json:"id"`
+	Source          string            `json:"source"`
+	ClientID        string            `json:"client_id"`
+	DestinationType string            `json:"destination_type"`
+	Destination     string            `json:"destination"`
+	Attempts        int               `json:"attempts"`
+	LastError       string            `json:"last_error,omitempty"`
+	LastErrorCode   string            `json:"last_error_code,omitempty"`
+	Metadata        map[string]string `json:"metadata,omitempty"`
+	CorrelationID   string            `json:"correlation_id,omitempty"`
+	Reason          string            `json:"reason"`
+	StoredAt        time.Time         `json:"stored_at"`
+}
+
+// DeadLettersResponse lists dead-lettered tasks, most recent first.
+// NextCursor, if set, fetches the next page.
+type DeadLettersResponse struct {
+	DeadLetters []DeadLetterDTO `json:"dead_letters"`
+	NextCursor  string          `json:"next_cursor,omitempty"`
+}
+
+// QueueStatsDTO reports the size of one queue.
+type QueueStatsDTO struct {
+	Name        string `json:"name"`
+	Pending     int64  `json:"pending"`
+	Due         int64  `json:"due"`
+	MemoryBytes int64  `json:"memory_bytes"`
+}
+
+// QueuesResponse lists the size of every queue.
+type QueuesResponse struct {
+	Queues []QueueStatsDTO `json:"queues"`
+}
+
+// OverflowStatsResponse counts what the overflow policy has done.
+type OverflowStatsResponse struct {
+	Rejected int64 `json:"rejected"`
+	Dropped  int64 `json:"dropped"`
+	Spilled  int64 `json:"spilled"`
+	Restored int64 `json:"restored"`
+}
+
+// RepairReportResponse counts the inconsistencies fixed by a repair pass.
+type RepairReportResponse struct {
+	IndexEntriesRemoved int `json:"index_entries_removed"`
+	IndexEntriesAdded   int `json:"index_entries_added"`
+	DeadLettersRemoved  int `json:"dead_letters_removed"`
+	DeadLettersIndexed  int `json:"dead_letters_indexed"`
+	SLAGroupsRemoved    int `json:"sla_groups_removed"`
+	WorkersForgotten    int `json:"workers_forgotten"`
+	DeadLettersExpired  int `json:"dead_letters_expired"`
+	QuarantinedExpired  int `json:"quarantined_expired"`
+	Total               int `json:"total"`
+}
+
+// DrainStatusResponse reports this instance's worker drain. State is
+// "running", "draining" while a poll is still delivering, or "drained" once
+// the instance can be stopped.
+type DrainStatusResponse struct {
+	State          string     `json:"state"`
+	Since          *time.Time `json:"since,omitempty"`
+	PollInProgress bool       `json:"poll_in_progress"`
+}
+
+// CorruptMemberDTO is a scheduled member that could not be decoded.
+type CorruptMemberDTO struct {
+	ID      string    `json:"id"`
+	Raw     string    `json:"raw"`
+	Error   string    `json:"error"`
+	DueAt   time.Time `json:"due_at"`
+	FoundAt time.Time `json:"found_at"`
+}
+
+// CorruptMembersResponse lists corrupt members, most recently found first.
+// Total counts every member ever found, including deleted ones.
+type CorruptMembersResponse struct {
+	Total   int64              `json:"total"`
+	Members []CorruptMemberDTO `json:"members"`
+}
+
+// PurgeQueueRequest either asks for a dry run or confirms a purge with the
+// token the dry run returned.
+type PurgeQueueRequest struct {
+	DryRun            bool   `json:"dry_run"`
+	ConfirmationToken string `json:"confirmation_token"`
+}
+
+// PurgePlanResponse reports what a purge would drop and how to confirm it.
+type PurgePlanResponse struct {
+	Queue             string    `json:"queue"`
+	Count             int64     `json:"count"`
+	ConfirmationToken string    `json:"confirmation_token"`
+	ExpiresAt         time.Time `json:"expires_at"`
+}
+
+// PurgeQueueResponse reports how many tasks a purge dropped.
+type PurgeQueueResponse struct {
+	Queue  string `json:"queue"`
+	Purged int64  `json:"purged"`
 }
 
 // DestinationDTO matches the OpenAPI Destination schema.
@@ -24,15 +297,19 @@ type DestinationDTO struct {
 	URL   string `json:"url"`
 }
 
-// CreateTaskResponse is returned on successful task creation.
+// CreateTaskResponse is returned on successful task creation. TaskIDs
+// lists the task scheduled per destination when the task fans out.
 type CreateTaskResponse struct {
-	Message string `json:"message"`
+	Message string   `json:"message"`
+	ID      string   `json:"id,omitempty"`
+	TaskIDs []string `json:"task_ids,omitempty"`
 }
 
 // ErrorResponse is the standard error payload.
 type ErrorResponse struct {
 	Error string `json:"error"`
 	Code  string `json:"code,omitempty"`
+	Field string `json:"field,omitempty"` // offending request field of a validation error, e.g. "destination.url"
 }
 
 // HealthResponse is returned by the health check endpoint.
@@ -41,6 +318,16 @@ type HealthResponse struct {
 	Checks map[string]string `json:"checks"`
 }
 
+// DecodeCreateTaskRequest parses a JSON CreateTaskRequest into a task, for
+// other adapters accepting the same format as POST /tasks.
+func DecodeCreateTaskRequest(data []byte) (*entity.Task, error) {
+	var req CreateTaskRequest
+	if err := json.Unmarshal(data, &req); err != nil {
+		return nil, err
+	}
+	return req.toEntity(), nil
+}
+
 // toEntity converts a CreateTaskRequest DTO to a domain entity.
 func (r *CreateTaskRequest) toEntity() *entity.Task {
 	return &entity.Task{
@@ -59,10 +346,102 @@ func (r *CreateTaskRequest) toEntity() *entity.Task {
 			URL:   r.DeadDestination.URL,
 		},
 		MaxRetries:      r.MaxRetries,
-		BaseDelay:       r.BaseDelay,
+		BaseDelay:       time.Duration(r.BaseDelay),
 		ClientID:        r.ClientID,
 		IsPriority:      r.IsPriority,
 		MessageData:     r.MessageData,
 		DestinationType: entity.DestinationType(r.DestinationType),
+		OrderingKey:     r.OrderingKey,
+		Queue:           r.Queue,
+		CallbackURL:     r.CallbackURL,
+		Metadata:        r.Metadata,
+		CorrelationID:   r.CorrelationID,
+
+		AttemptImmediately: r.AttemptImmediately,
+		Jitter:             entity.JitterMode(r.Jitter),
+		MaxDelay:           time.Duration(r.MaxDelay),
+		RetryDelays:        delaysToDurations(r.RetrySchedule),
+
+		FallbackDeadDestinations: destinationsToEntity(r.FallbackDeadDestinations),
+		FanOutDestinations:       destinationsToEntity(r.FanOutDestinations),
+	}
+}
+
+func delaysToDurations(delays []Delay) []time.Duration {
+	if len(delays) == 0 {
+		return nil
+	}
+	durations := make([]time.Duration, len(delays))
+	for i, d := range delays {
+		durations[i] = time.Duration(d)
 	}
+	return durations
+}
+
+func destinationsToEntity(dtos []DestinationDTO) []entity.Destination {
+	if len(dtos) == 0 {
+		return nil
+	}
+	dests := make([]entity.Destination, len(dtos))
+	for i, d := range dtos {
+		dests[i] = entity.Destination{Host: d.Host, Port: d.Port, Topic: d.Topic, URL: d.URL}
+	}
+	return dests
+}
+
+// ProducerStatsDTO reports how one destination has performed for this
+// instance's producers. Latencies are in milliseconds over recent writes;
+// StatusCodes counts HTTP responses by status.
+type ProducerStatsDTO struct {
+	DestinationType string           `json:"destination_type"`
+	Destination     string           `json:"destination"`
+	Writes          int64            `json:"writes"`
+	Messages        int64            `json:"messages"`
+	Errors          int64            `json:"errors"`
+	ErrorRate       float64          `json:"error_rate"`
+	MaxBatchSize    int              `json:"max_batch_size"`
+	StatusCodes     map[string]int64 `json:"status_codes,omitempty"`
+	Oversized       int64            `json:"oversized_requests,omitempty"`
+	Truncated       int64            `json:"truncated_responses,omitempty"`
+	P50MS           int64            `json:"p50_ms"`
+	P90MS           int64            `json:"p90_ms"`
+	P99MS           int64            `json:"p99_ms"`
+	MaxMS           int64            `json:"max_ms"`
+}
+
+// ProducerStatsResponse lists producer statistics per destination.
+type ProducerStatsResponse struct {
+	Destinations []ProducerStatsDTO `json:"destinations"`
+}
+
+// WorkerDTO describes one worker instance. ThroughputPerMinute averages the
+// tasks processed since the instance started.
+type WorkerDTO struct {
+	ID                  string     `json:"id"`
+	LastHeartbeat       time.Time  `json:"last_heartbeat"`
+	HeartbeatAgeSeconds float64    `json:"heartbeat_age_seconds"`
+	Stale               bool       `json:"stale"`
+	StartedAt           *time.Time `json:"started_at,omitempty"`
+	Processed           int64      `json:"processed"`
+	Errors              int64      `json:"errors"`
+	ThroughputPerMinute float64    `json:"throughput_per_minute"`
+	Shards              []int      `json:"shards,omitempty"`
+}
+
+// WorkersResponse lists the registered worker instances.
+type WorkersResponse struct {
+	Workers []WorkerDTO `json:"workers"`
+}
+
+// ClientUsageDTO counts one client's delivered and retried attempts.
+type ClientUsageDTO struct {
+	ClientID  string `json:"client_id"`
+	Delivered int64  `json:"delivered"`
+	Retried   int64  `json:"retried"`
+}
+
+// UsageResponse reports per-client usage in a billing period.
+type UsageResponse struct {
+	Period  string           `json:"period"`
+	Clients []ClientUsageDTO `json:"clients"`
 }
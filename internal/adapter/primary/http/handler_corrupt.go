@@ -0,0 +1,85 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+	"github.com/ruudy-sib/rebound/internal/port/primary"
+)
+
+// CorruptMembersHandler handles GET /admin/corrupt requests.
+type CorruptMembersHandler struct {
+	service primary.QueueService
+	logger  *zap.Logger
+}
+
+// NewCorruptMembersHandler creates a handler listing corrupt members.
+func NewCorruptMembersHandler(service primary.QueueService, logger *zap.Logger) *CorruptMembersHandler {
+	return &CorruptMembersHandler{
+		service: service,
+		logger:  logger.Named("corrupt-members-handler"),
+	}
+}
+
+// NewDeleteCorruptHandler creates a handler that deletes corrupt members
+// by ID.
+func NewDeleteCorruptHandler(service primary.QueueService, logger *zap.Logger) *BulkTaskHandler {
+	return &BulkTaskHandler{
+		action:    service.DeleteCorrupt,
+		doneState: "deleted",
+		logger:    logger.Named("delete-corrupt-handler"),
+	}
+}
+
+// ServeHTTP returns the most recently found corrupt members, up to the
+// limit query parameter (default 50).
+func (h *CorruptMembersHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondJSON(w, http.StatusMethodNotAllowed, ErrorResponse{
+			Error: "method not allowed",
+			Code:  "METHOD_NOT_ALLOWED",
+		})
+		return
+	}
+
+	limit, err := queryLimit(r, defaultListLimit)
+	if err != nil {
+		respondJSON(w, http.StatusBadRequest, ErrorResponse{
+			Error: err.Error(),
+			Code:  "VALIDATION_ERROR",
+		})
+		return
+	}
+
+	report, err := h.service.ListCorrupt(r.Context(), limit)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidQuery) {
+			respondJSON(w, http.StatusBadRequest, ErrorResponse{
+				Error: err.Error(),
+				Code:  "VALIDATION_ERROR",
+			})
+			return
+		}
+		h.logger.Error("failed to list corrupt members", zap.Error(err))
+		respondJSON(w, http.StatusInternalServerError, ErrorResponse{
+			Error: "internal server error",
+			Code:  "INTERNAL_ERROR",
+		})
+		return
+	}
+
+	resp := CorruptMembersResponse{Total: report.Total, Members: make([]CorruptMemberDTO, len(report.Members))}
+	for i, m := range report.Members {
+		resp.Members[i] = CorruptMemberDTO{
+			ID:      m.ID,
+			Raw:     m.Raw,
+			Error:   m.Error,
+			DueAt:   m.DueAt.UTC(),
+			FoundAt: m.FoundAt.UTC(),
+		}
+	}
+	respondJSON(w, http.StatusOK, resp)
+}
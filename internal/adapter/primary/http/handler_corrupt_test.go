@@ -0,0 +1,127 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+func TestCorruptMembersHandler_ServeHTTP(t *testing.T) {
+	report := &entity.CorruptReport{
+		Total: 3,
+		Members: []entity.CorruptMember{{
+			ID:      "9f86d081884c7d65",
+			Raw:     "{not json",
+			Error:   "unexpected end of JSON input",
+			DueAt:   time.Now(),
+			FoundAt: time.Now(),
+		}},
+	}
+
+	tests := []struct {
+		name           string
+		method         string
+		query          string
+		svc            *mockQueueService
+		wantStatusCode int
+		wantLimit      int
+	}{
+		{
+			name:           "lists with the default limit",
+			method:         http.MethodGet,
+			svc:            &mockQueueService{corrupt: report},
+			wantStatusCode: http.StatusOK,
+			wantLimit:      defaultListLimit,
+		},
+		{
+			name:           "explicit limit",
+			method:         http.MethodGet,
+			query:          "?limit=5",
+			svc:            &mockQueueService{corrupt: report},
+			wantStatusCode: http.StatusOK,
+			wantLimit:      5,
+		},
+		{
+			name:           "limit out of range",
+			method:         http.MethodGet,
+			query:          "?limit=0",
+			svc:            &mockQueueService{err: domain.ErrInvalidQuery},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "store failure",
+			method:         http.MethodGet,
+			svc:            &mockQueueService{err: errors.New("redis down")},
+			wantStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name:           "method not allowed",
+			method:         http.MethodPost,
+			svc:            &mockQueueService{},
+			wantStatusCode: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewCorruptMembersHandler(tt.svc, zap.NewNop())
+
+			req := httptest.NewRequest(tt.method, "/admin/corrupt"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatusCode {
+				t.Fatalf("expected status %d, got %d", tt.wantStatusCode, rec.Code)
+			}
+			if tt.wantStatusCode != http.StatusOK {
+				return
+			}
+			if tt.svc.limit != tt.wantLimit {
+				t.Fatalf("expected limit %d, got %d", tt.wantLimit, tt.svc.limit)
+			}
+
+			var resp CorruptMembersResponse
+			if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+				t.Fatalf("decoding response: %v", err)
+			}
+			if resp.Total != 3 || len(resp.Members) != 1 {
+				t.Fatalf("unexpected response: %+v", resp)
+			}
+			if got := resp.Members[0]; got.ID != "9f86d081884c7d65" || got.Raw != "{not json" {
+				t.Fatalf("unexpected member: %+v", got)
+			}
+		})
+	}
+}
+
+func TestDeleteCorruptHandler_ServeHTTP(t *testing.T) {
+	svc := &mockQueueService{err: domain.ErrTaskNotFound}
+	handler := NewDeleteCorruptHandler(svc, zap.NewNop())
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/corrupt/delete", bytes.NewBufferString(`{"ids":["a"]}`))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rec.Code)
+	}
+	var resp BulkTaskResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+	if resp.Results[0].Status != "not_found" {
+		t.Fatalf("unexpected results: %+v", resp.Results)
+	}
+	if len(svc.deleted) != 1 || svc.deleted[0] != "a" {
+		t.Fatalf("expected a delete call for a, got %v", svc.deleted)
+	}
+}
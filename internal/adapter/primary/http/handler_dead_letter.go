@@ -0,0 +1,108 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+	"github.com/ruudy-sib/rebound/internal/port/primary"
+)
+
+// defaultListLimit is the page size of admin listings when no limit is given.
+const defaultListLimit = 50
+
+// DeadLetterHandler handles GET /admin/dead-letters requests.
+type DeadLetterHandler struct {
+	service primary.TaskService
+	logger  *zap.Logger
+}
+
+// NewDeadLetterHandler creates a handler listing dead-lettered tasks.
+func NewDeadLetterHandler(service primary.TaskService, logger *zap.Logger) *DeadLetterHandler {
+	return &DeadLetterHandler{
+		service: service,
+		logger:  logger.Named("dead-letter-handler"),
+	}
+}
+
+// NewRequeueDeadLettersHandler creates a handler that requeues
+// dead-lettered tasks for immediate delivery.
+func NewRequeueDeadLettersHandler(service primary.TaskService, logger *zap.Logger) *BulkTaskHandler {
+	return &BulkTaskHandler{
+		action:    service.RequeueDeadLetter,
+		doneState: "requeued",
+		logger:    logger.Named("requeue-dead-letters-handler"),
+	}
+}
+
+// ServeHTTP returns the most recently stored dead letters, up to the limit
+// query parameter (default 50). Later pages are fetched by passing the
+// response's next_cursor as the cursor query parameter.
+func (h *DeadLetterHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondJSON(w, http.StatusMethodNotAllowed, ErrorResponse{
+			Error: "method not allowed",
+			Code:  "METHOD_NOT_ALLOWED",
+		})
+		return
+	}
+
+	limit, err := queryLimit(r, defaultListLimit)
+	if err != nil {
+		respondJSON(w, http.StatusBadRequest, ErrorResponse{
+			Error: err.Error(),
+			Code:  "VALIDATION_ERROR",
+		})
+		return
+	}
+
+	cursor, err := queryCursor(r)
+	if err != nil {
+		respondJSON(w, http.StatusBadRequest, ErrorResponse{
+			Error: err.Error(),
+			Code:  "VALIDATION_ERROR",
+		})
+		return
+	}
+
+	entries, next, err := h.service.ListDeadLetters(r.Context(), cursor, limit)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidQuery) {
+			respondJSON(w, http.StatusBadRequest, ErrorResponse{
+				Error: err.Error(),
+				Code:  "VALIDATION_ERROR",
+			})
+			return
+		}
+		h.logger.Error("failed to list dead letters", zap.Error(err))
+		respondJSON(w, http.StatusInternalServerError, ErrorResponse{
+			Error: "internal server error",
+			Code:  "INTERNAL_ERROR",
+		})
+		return
+	}
+
+	resp := DeadLettersResponse{
+		DeadLetters: make([]DeadLetterDTO, len(entries)),
+		NextCursor:  encodeCursor(next),
+	}
+	for i, e := range entries {
+		resp.DeadLetters[i] = DeadLetterDTO{
+			ID:              e.Task.ID,
+			Source:          e.Task.Source,
+			ClientID:        e.Task.ClientID,
+			DestinationType: string(e.Task.DestinationType),
+			Destination:     e.Task.Destination.Name(),
+			Attempts:        e.Task.Attempt,
+			LastError:       e.Task.LastError,
+			LastErrorCode:   string(e.Task.LastErrorCode),
+			Metadata:        e.Task.Metadata,
+			CorrelationID:   e.Task.CorrelationID,
+			Reason:          e.Reason,
+			StoredAt:        e.StoredAt.UTC(),
+		}
+	}
+	respondJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,165 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+func TestDeadLetterHandler_ServeHTTP(t *testing.T) {
+	entry := entity.DeadLetter{
+		Task: &entity.Task{
+			ID:              "task-1",
+			ClientID:        "client-1",
+			Destination:     entity.Destination{URL: "http://localhost/hook"},
+			DestinationType: entity.DestinationTypeHTTP,
+			Attempt:         4,
+		},
+		Reason:   "dead-letter delivery failed: timeout",
+		StoredAt: time.Now(),
+	}
+	cursor := entity.Cursor{Score: 1700000000, Member: "task-0"}
+	next := entity.Cursor{Score: 1690000000, Member: "task-1"}
+
+	tests := []struct {
+		name           string
+		method         string
+		query          string
+		svc            *mockTaskService
+		wantStatusCode int
+		wantLimit      int
+		wantCursor     entity.Cursor
+		wantNext       string
+	}{
+		{
+			name:           "lists with the default limit",
+			method:         http.MethodGet,
+			svc:            &mockTaskService{deadLetters: []entity.DeadLetter{entry}},
+			wantStatusCode: http.StatusOK,
+			wantLimit:      defaultListLimit,
+		},
+		{
+			name:           "explicit limit",
+			method:         http.MethodGet,
+			query:          "?limit=5",
+			svc:            &mockTaskService{deadLetters: []entity.DeadLetter{entry}},
+			wantStatusCode: http.StatusOK,
+			wantLimit:      5,
+		},
+		{
+			name:           "follows a cursor",
+			method:         http.MethodGet,
+			query:          "?cursor=" + encodeCursor(cursor),
+			svc:            &mockTaskService{deadLetters: []entity.DeadLetter{entry}, listNext: next},
+			wantStatusCode: http.StatusOK,
+			wantLimit:      defaultListLimit,
+			wantCursor:     cursor,
+			wantNext:       encodeCursor(next),
+		},
+		{
+			name:           "malformed cursor",
+			method:         http.MethodGet,
+			query:          "?cursor=not-a-cursor",
+			svc:            &mockTaskService{},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "malformed limit",
+			method:         http.MethodGet,
+			query:          "?limit=many",
+			svc:            &mockTaskService{},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "limit out of range",
+			method:         http.MethodGet,
+			query:          "?limit=0",
+			svc:            &mockTaskService{listErr: fmt.Errorf("%w: limit", domain.ErrInvalidQuery)},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "store failure",
+			method:         http.MethodGet,
+			svc:            &mockTaskService{listErr: errors.New("redis down")},
+			wantStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name:           "method not allowed",
+			method:         http.MethodDelete,
+			svc:            &mockTaskService{},
+			wantStatusCode: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewDeadLetterHandler(tt.svc, zap.NewNop())
+
+			req := httptest.NewRequest(tt.method, "/admin/dead-letters"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatusCode {
+				t.Fatalf("expected status %d, got %d", tt.wantStatusCode, rec.Code)
+			}
+			if tt.wantStatusCode != http.StatusOK {
+				return
+			}
+			if tt.svc.listLimit != tt.wantLimit {
+				t.Fatalf("expected limit %d, got %d", tt.wantLimit, tt.svc.listLimit)
+			}
+			if tt.svc.listCursor != tt.wantCursor {
+				t.Fatalf("expected cursor %+v, got %+v", tt.wantCursor, tt.svc.listCursor)
+			}
+
+			var resp DeadLettersResponse
+			if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+				t.Fatalf("decoding response: %v", err)
+			}
+			if resp.NextCursor != tt.wantNext {
+				t.Fatalf("expected next_cursor %q, got %q", tt.wantNext, resp.NextCursor)
+			}
+			if len(resp.DeadLetters) != 1 {
+				t.Fatalf("expected 1 dead letter, got %d", len(resp.DeadLetters))
+			}
+			if got := resp.DeadLetters[0]; got.ID != "task-1" || got.Destination != "http://localhost/hook" || got.Attempts != 4 {
+				t.Fatalf("unexpected dead letter: %+v", got)
+			}
+		})
+	}
+}
+
+func TestRequeueDeadLettersHandler_ServeHTTP(t *testing.T) {
+	svc := &mockTaskService{taskErrs: map[string]error{
+		"b": fmt.Errorf("requeueing task b: %w", domain.ErrTaskNotFound),
+	}}
+	handler := NewRequeueDeadLettersHandler(svc, zap.NewNop())
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/dead-letters/requeue", bytes.NewBufferString(`{"ids":["a","b"]}`))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rec.Code)
+	}
+	var resp BulkTaskResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+	if resp.Results[0].Status != "requeued" || resp.Results[1].Status != "not_found" {
+		t.Fatalf("unexpected results: %+v", resp.Results)
+	}
+	if len(svc.requeued) != 2 {
+		t.Fatalf("expected 2 requeue calls, got %d", len(svc.requeued))
+	}
+}
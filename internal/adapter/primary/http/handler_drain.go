@@ -0,0 +1,84 @@
+package http
+
+import (
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+	"github.com/ruudy-sib/rebound/internal/port/primary"
+)
+
+// Drain states reported by the drain endpoints.
+const (
+	drainStateRunning  = "running"
+	drainStateDraining = "draining"
+	drainStateDrained  = "drained"
+)
+
+// DrainHandler handles POST /admin/drain requests.
+type DrainHandler struct {
+	drainer primary.Drainer
+	logger  *zap.Logger
+}
+
+// NewDrainHandler creates a handler that drains this instance's worker.
+func NewDrainHandler(drainer primary.Drainer, logger *zap.Logger) *DrainHandler {
+	return &DrainHandler{
+		drainer: drainer,
+		logger:  logger.Named("drain-handler"),
+	}
+}
+
+// ServeHTTP stops the worker fetching new tasks and reports the drain. It
+// does not wait for deliveries under way; poll /admin/drain/status until
+// the state is "drained".
+func (h *DrainHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondJSON(w, http.StatusMethodNotAllowed, ErrorResponse{
+			Error: "method not allowed",
+			Code:  "METHOD_NOT_ALLOWED",
+		})
+		return
+	}
+
+	status := h.drainer.Drain()
+	h.logger.Info("drain requested", zap.Time("since", status.Since))
+	respondJSON(w, http.StatusAccepted, newDrainStatusResponse(status))
+}
+
+// DrainStatusHandler handles GET /admin/drain/status requests.
+type DrainStatusHandler struct {
+	drainer primary.Drainer
+}
+
+// NewDrainStatusHandler creates a handler reporting the worker drain.
+func NewDrainStatusHandler(drainer primary.Drainer) *DrainStatusHandler {
+	return &DrainStatusHandler{drainer: drainer}
+}
+
+// ServeHTTP reports whether the worker is running, draining or drained.
+func (h *DrainStatusHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondJSON(w, http.StatusMethodNotAllowed, ErrorResponse{
+			Error: "method not allowed",
+			Code:  "METHOD_NOT_ALLOWED",
+		})
+		return
+	}
+
+	respondJSON(w, http.StatusOK, newDrainStatusResponse(h.drainer.DrainStatus()))
+}
+
+func newDrainStatusResponse(status entity.DrainStatus) DrainStatusResponse {
+	resp := DrainStatusResponse{State: drainStateRunning, PollInProgress: status.Polling}
+	if status.Draining {
+		since := status.Since
+		resp.Since = &since
+		resp.State = drainStateDraining
+	}
+	if status.Drained() {
+		resp.State = drainStateDrained
+	}
+	return resp
+}
@@ -0,0 +1,112 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+func TestDrainHandler_ServeHTTP(t *testing.T) {
+	tests := []struct {
+		name           string
+		method         string
+		drainer        *mockDrainer
+		wantStatusCode int
+		wantBody       string
+		wantDrained    bool
+	}{
+		{
+			name:           "starts draining during a poll",
+			method:         http.MethodPost,
+			drainer:        &mockDrainer{status: entity.DrainStatus{Polling: true}},
+			wantStatusCode: http.StatusAccepted,
+			wantBody:       `"state":"draining"`,
+			wantDrained:    true,
+		},
+		{
+			name:           "idle worker is drained at once",
+			method:         http.MethodPost,
+			drainer:        &mockDrainer{},
+			wantStatusCode: http.StatusAccepted,
+			wantBody:       `"state":"drained"`,
+			wantDrained:    true,
+		},
+		{
+			name:           "method not allowed",
+			method:         http.MethodGet,
+			drainer:        &mockDrainer{},
+			wantStatusCode: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := NewRouter(&mockTaskService{}, &mockMaintenanceService{}, &mockSLAService{}, &mockScheduleService{}, &mockQueueService{}, nil, zap.NewNop(),
+				WithDrainer(tt.drainer))
+
+			req := httptest.NewRequest(tt.method, "/v1/admin/drain", nil)
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatusCode {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatusCode, rec.Code, rec.Body.String())
+			}
+			if !strings.Contains(rec.Body.String(), tt.wantBody) {
+				t.Fatalf("expected body to contain %q, got %s", tt.wantBody, rec.Body.String())
+			}
+			if tt.drainer.drained != tt.wantDrained {
+				t.Fatalf("expected drained=%v, got %v", tt.wantDrained, tt.drainer.drained)
+			}
+		})
+	}
+}
+
+func TestDrainStatusHandler_ServeHTTP(t *testing.T) {
+	since := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		status   entity.DrainStatus
+		wantBody string
+	}{
+		{name: "running", status: entity.DrainStatus{Polling: true}, wantBody: `{"state":"running","poll_in_progress":true}`},
+		{name: "draining", status: entity.DrainStatus{Draining: true, Since: since, Polling: true}, wantBody: `"state":"draining","since":"2024-03-01T12:00:00Z"`},
+		{name: "drained", status: entity.DrainStatus{Draining: true, Since: since}, wantBody: `"state":"drained"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := NewRouter(&mockTaskService{}, &mockMaintenanceService{}, &mockSLAService{}, &mockScheduleService{}, &mockQueueService{}, nil, zap.NewNop(),
+				WithDrainer(&mockDrainer{status: tt.status}))
+
+			req := httptest.NewRequest(http.MethodGet, "/v1/admin/drain/status", nil)
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusOK {
+				t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+			}
+			if !strings.Contains(rec.Body.String(), tt.wantBody) {
+				t.Fatalf("expected body to contain %q, got %s", tt.wantBody, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestDrainHandler_NotRegisteredWithoutDrainer(t *testing.T) {
+	router := NewRouter(&mockTaskService{}, &mockMaintenanceService{}, &mockSLAService{}, &mockScheduleService{}, &mockQueueService{}, nil, zap.NewNop())
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/admin/drain", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", rec.Code)
+	}
+}
@@ -1,30 +1,96 @@
 package http
 
 import (
+	"context"
+	"errors"
 	"net/http"
+	"sync"
+	"time"
 
 	"github.com/ruudy-sib/rebound/internal/port/secondary"
 )
 
+// HealthPolicy bounds the cost of health checks. Each check fails after
+// Timeout, and the aggregate result is reused for CacheTTL so a burst of
+// probes runs the checks once. Zero values disable the timeout and the
+// cache respectively.
+type HealthPolicy struct {
+	Timeout  time.Duration
+	CacheTTL time.Duration
+}
+
 // HealthHandler handles GET /health requests.
 type HealthHandler struct {
 	checks []secondary.HealthChecker
+	policy HealthPolicy
+
+	mu       sync.Mutex
+	cached   healthResult
+	cachedAt time.Time
+}
+
+type healthResult struct {
+	status int
+	body   HealthResponse
 }
 
 // NewHealthHandler creates a health check handler with the given checkers.
-func NewHealthHandler(checks []secondary.HealthChecker) *HealthHandler {
-	return &HealthHandler{checks: checks}
+func NewHealthHandler(checks []secondary.HealthChecker, policy HealthPolicy) *HealthHandler {
+	return &HealthHandler{checks: checks, policy: policy}
+}
+
+// NewLivenessHandler creates a handler for GET /livez, which succeeds
+// whenever the process can serve HTTP. It checks no dependencies, so an
+// outage never gets the process restarted.
+func NewLivenessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		respondJSON(w, http.StatusOK, HealthResponse{Status: "alive", Checks: map[string]string{}})
+	})
 }
 
 // ServeHTTP performs all health checks and reports the aggregate status.
 func (h *HealthHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	result := h.result(r.Context())
+	respondJSON(w, result.status, result.body)
+}
+
+// result returns the cached result while it is fresh and otherwise runs
+// the checks. Concurrent requests wait for the run in progress instead of
+// starting their own.
+func (h *HealthHandler) result(ctx context.Context) healthResult {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.policy.CacheTTL > 0 && !h.cachedAt.IsZero() && time.Since(h.cachedAt) < h.policy.CacheTTL {
+		return h.cached
+	}
+
+	// The result may be served to other requests, so one caller going away
+	// must not fail the checks.
+	h.cached = h.run(context.WithoutCancel(ctx))
+	h.cachedAt = time.Now()
+	return h.cached
+}
+
+// run executes the checks concurrently, each bounded by the policy timeout.
+func (h *HealthHandler) run(ctx context.Context) healthResult {
+	errs := make([]error, len(h.checks))
+	var wg sync.WaitGroup
+	for i, check := range h.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = h.runCheck(ctx, check)
+		}()
+	}
+	wg.Wait()
+
 	status := http.StatusOK
 	checks := make(map[string]string)
-
-	for _, check := range h.checks {
-		if err := check.Check(r.Context()); err != nil {
+	for i, check := range h.checks {
+		if errs[i] != nil {
 			status = http.StatusServiceUnavailable
-			checks[check.Name()] = err.Error()
+			checks[check.Name()] = errs[i].Error()
 		} else {
 			checks[check.Name()] = "ok"
 		}
@@ -35,8 +101,34 @@ func (h *HealthHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		statusText = "unhealthy"
 	}
 
-	respondJSON(w, status, HealthResponse{
-		Status: statusText,
-		Checks: checks,
-	})
+	return healthResult{
+		status: status,
+		body: HealthResponse{
+			Status: statusText,
+			Checks: checks,
+		},
+	}
+}
+
+// runCheck returns once the check finishes or the timeout passes, even if
+// the check ignores its context.
+func (h *HealthHandler) runCheck(ctx context.Context, check secondary.HealthChecker) error {
+	if h.policy.Timeout <= 0 {
+		return check.Check(ctx)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, h.policy.Timeout)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- check.Check(ctx)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return errors.New("timed out after " + h.policy.Timeout.String())
+	}
 }
@@ -0,0 +1,96 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/port/secondary"
+)
+
+// blockingCheck counts its calls and blocks until release is closed,
+// ignoring its context.
+type blockingCheck struct {
+	calls   atomic.Int32
+	release chan struct{}
+}
+
+func (c *blockingCheck) Name() string { return "redis" }
+
+func (c *blockingCheck) Check(context.Context) error {
+	c.calls.Add(1)
+	<-c.release
+	return nil
+}
+
+func TestHealthHandler_timeout(t *testing.T) {
+	check := &blockingCheck{release: make(chan struct{})}
+	defer close(check.release)
+
+	handler := NewHealthHandler([]secondary.HealthChecker{check}, HealthPolicy{Timeout: 20 * time.Millisecond})
+
+	rec := httptest.NewRecorder()
+	start := time.Now()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("expected the check to time out, took %s", elapsed)
+	}
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected status %d, got %d", http.StatusServiceUnavailable, rec.Code)
+	}
+	if !strings.Contains(rec.Body.String(), "timed out") {
+		t.Fatalf("expected a timeout message, got %s", rec.Body.String())
+	}
+}
+
+func TestHealthHandler_cache(t *testing.T) {
+	check := &blockingCheck{release: make(chan struct{})}
+	close(check.release)
+
+	handler := NewHealthHandler([]secondary.HealthChecker{check}, HealthPolicy{CacheTTL: time.Hour})
+
+	for range 5 {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health", nil))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status %d, got %d", http.StatusOK, rec.Code)
+		}
+	}
+	if n := check.calls.Load(); n != 1 {
+		t.Fatalf("expected the check to run once, ran %d times", n)
+	}
+}
+
+func TestNewHealthRouter(t *testing.T) {
+	router := NewHealthRouter(nil, zap.NewNop(), WithWorkers(&mockWorkerService{}))
+
+	tests := []struct {
+		path           string
+		wantStatusCode int
+	}{
+		{"/health", http.StatusOK},
+		{"/livez", http.StatusOK},
+		{"/readyz", http.StatusOK},
+		{"/v1/admin/workers", http.StatusOK},
+		{"/v1/admin/producers", http.StatusNotFound},
+		{"/v1/tasks", http.StatusNotFound},
+		{"/v1/admin/queues", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			if rec.Code != tt.wantStatusCode {
+				t.Fatalf("expected status %d, got %d", tt.wantStatusCode, rec.Code)
+			}
+		})
+	}
+}
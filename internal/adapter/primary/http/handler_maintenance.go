@@ -0,0 +1,111 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+	"github.com/ruudy-sib/rebound/internal/port/primary"
+)
+
+// MaintenanceHandler handles /admin/maintenance requests:
+// GET lists active windows, POST starts one, DELETE ends one.
+type MaintenanceHandler struct {
+	service primary.MaintenanceService
+	logger  *zap.Logger
+}
+
+// NewMaintenanceHandler creates a handler for destination maintenance windows.
+func NewMaintenanceHandler(service primary.MaintenanceService, logger *zap.Logger) *MaintenanceHandler {
+	return &MaintenanceHandler{
+		service: service,
+		logger:  logger.Named("maintenance-handler"),
+	}
+}
+
+// ServeHTTP dispatches on the request method.
+func (h *MaintenanceHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.list(w, r)
+	case http.MethodPost:
+		h.start(w, r)
+	case http.MethodDelete:
+		h.end(w, r)
+	default:
+		respondJSON(w, http.StatusMethodNotAllowed, ErrorResponse{
+			Error: "method not allowed",
+			Code:  "METHOD_NOT_ALLOWED",
+		})
+	}
+}
+
+func (h *MaintenanceHandler) list(w http.ResponseWriter, r *http.Request) {
+	windows, err := h.service.ListMaintenance(r.Context())
+	if err != nil {
+		h.internalError(w, "failed to list maintenance windows", err)
+		return
+	}
+
+	resp := MaintenanceListResponse{Windows: make([]MaintenanceWindowDTO, len(windows))}
+	for i, window := range windows {
+		resp.Windows[i] = MaintenanceWindowDTO{Destination: window.Destination, Until: window.Until}
+	}
+	respondJSON(w, http.StatusOK, resp)
+}
+
+func (h *MaintenanceHandler) start(w http.ResponseWriter, r *http.Request) {
+	var req MaintenanceWindowDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondJSON(w, http.StatusBadRequest, ErrorResponse{
+			Error: "invalid request body",
+			Code:  "INVALID_BODY",
+		})
+		return
+	}
+
+	if err := h.service.StartMaintenance(r.Context(), req.Destination, req.Until); err != nil {
+		if h.validationError(w, err) {
+			return
+		}
+		h.internalError(w, "failed to start maintenance", err)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, req)
+}
+
+func (h *MaintenanceHandler) end(w http.ResponseWriter, r *http.Request) {
+	destination := r.URL.Query().Get("destination")
+	if err := h.service.EndMaintenance(r.Context(), destination); err != nil {
+		if h.validationError(w, err) {
+			return
+		}
+		h.internalError(w, "failed to end maintenance", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *MaintenanceHandler) validationError(w http.ResponseWriter, err error) bool {
+	if !errors.Is(err, domain.ErrInvalidMaintenanceWindow) {
+		return false
+	}
+	respondJSON(w, http.StatusBadRequest, ErrorResponse{
+		Error: err.Error(),
+		Code:  "VALIDATION_ERROR",
+	})
+	return true
+}
+
+func (h *MaintenanceHandler) internalError(w http.ResponseWriter, msg string, err error) {
+	h.logger.Error(msg, zap.Error(err))
+	respondJSON(w, http.StatusInternalServerError, ErrorResponse{
+		Error: "internal server error",
+		Code:  "INTERNAL_ERROR",
+	})
+}
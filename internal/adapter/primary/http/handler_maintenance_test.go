@@ -0,0 +1,124 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+func TestMaintenanceHandler_ServeHTTP(t *testing.T) {
+	until := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		method         string
+		target         string
+		body           string
+		svcErr         error
+		wantStatusCode int
+		wantStarted    string
+		wantEnded      string
+	}{
+		{
+			name:           "start maintenance",
+			method:         http.MethodPost,
+			target:         "/admin/maintenance",
+			body:           fmt.Sprintf(`{"destination":"orders","until":%q}`, until.Format(time.RFC3339)),
+			wantStatusCode: http.StatusCreated,
+			wantStarted:    "orders",
+		},
+		{
+			name:           "invalid body",
+			method:         http.MethodPost,
+			target:         "/admin/maintenance",
+			body:           `{"until":"tomorrow"}`,
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "validation error",
+			method:         http.MethodPost,
+			target:         "/admin/maintenance",
+			body:           `{"destination":""}`,
+			svcErr:         domain.ErrInvalidMaintenanceWindow,
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "end maintenance",
+			method:         http.MethodDelete,
+			target:         "/admin/maintenance?destination=orders",
+			wantStatusCode: http.StatusNoContent,
+			wantEnded:      "orders",
+		},
+		{
+			name:           "store failure",
+			method:         http.MethodDelete,
+			target:         "/admin/maintenance?destination=orders",
+			svcErr:         errors.New("redis down"),
+			wantStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name:           "method not allowed",
+			method:         http.MethodPut,
+			target:         "/admin/maintenance",
+			wantStatusCode: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := &mockMaintenanceService{err: tt.svcErr}
+			handler := NewMaintenanceHandler(mockSvc, zap.NewNop())
+
+			req := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatusCode {
+				t.Fatalf("expected status %d, got %d (body: %s)", tt.wantStatusCode, rec.Code, rec.Body.String())
+			}
+			if tt.wantStarted != "" {
+				if mockSvc.started != tt.wantStarted || !mockSvc.startedTill.Equal(until) {
+					t.Fatalf("expected start for %q until %v, got %q until %v", tt.wantStarted, until, mockSvc.started, mockSvc.startedTill)
+				}
+			}
+			if tt.wantEnded != "" && mockSvc.ended != tt.wantEnded {
+				t.Fatalf("expected end for %q, got %q", tt.wantEnded, mockSvc.ended)
+			}
+		})
+	}
+}
+
+func TestMaintenanceHandler_List(t *testing.T) {
+	until := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+	mockSvc := &mockMaintenanceService{
+		windows: []entity.MaintenanceWindow{{Destination: "orders", Until: until}},
+	}
+	handler := NewMaintenanceHandler(mockSvc, zap.NewNop())
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/maintenance", nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rec.Code)
+	}
+
+	var resp MaintenanceListResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+	if len(resp.Windows) != 1 || resp.Windows[0].Destination != "orders" || !resp.Windows[0].Until.Equal(until) {
+		t.Fatalf("unexpected windows: %+v", resp.Windows)
+	}
+}
@@ -0,0 +1,43 @@
+package http
+
+import (
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/port/primary"
+)
+
+// OverflowStatsHandler handles GET /admin/overflow requests.
+type OverflowStatsHandler struct {
+	service primary.TaskService
+	logger  *zap.Logger
+}
+
+// NewOverflowStatsHandler creates a handler reporting overflow policy counters.
+func NewOverflowStatsHandler(service primary.TaskService, logger *zap.Logger) *OverflowStatsHandler {
+	return &OverflowStatsHandler{
+		service: service,
+		logger:  logger.Named("overflow-stats-handler"),
+	}
+}
+
+// ServeHTTP returns how many new tasks this instance rejected, spilled or
+// made room for by dropping others since it started.
+func (h *OverflowStatsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondJSON(w, http.StatusMethodNotAllowed, ErrorResponse{
+			Error: "method not allowed",
+			Code:  "METHOD_NOT_ALLOWED",
+		})
+		return
+	}
+
+	stats := h.service.OverflowStats()
+	respondJSON(w, http.StatusOK, OverflowStatsResponse{
+		Rejected: stats.Rejected,
+		Dropped:  stats.Dropped,
+		Spilled:  stats.Spilled,
+		Restored: stats.Restored,
+	})
+}
@@ -0,0 +1,51 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+func TestOverflowStatsHandler_ServeHTTP(t *testing.T) {
+	tests := []struct {
+		name           string
+		method         string
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name:           "reports counters",
+			method:         http.MethodGet,
+			wantStatusCode: http.StatusOK,
+			wantBody:       `{"rejected":4,"dropped":0,"spilled":7,"restored":2}`,
+		},
+		{
+			name:           "method not allowed",
+			method:         http.MethodPost,
+			wantStatusCode: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &mockTaskService{overflow: entity.OverflowStats{Rejected: 4, Spilled: 7, Restored: 2}}
+			router := NewRouter(svc, &mockMaintenanceService{}, &mockSLAService{}, &mockScheduleService{}, &mockQueueService{}, nil, zap.NewNop())
+
+			req := httptest.NewRequest(tt.method, "/admin/overflow", nil)
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatusCode {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatusCode, rec.Code, rec.Body.String())
+			}
+			if !strings.Contains(rec.Body.String(), tt.wantBody) {
+				t.Fatalf("expected body to contain %q, got %s", tt.wantBody, rec.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/ruudy-sib/rebound/internal/port/primary"
+)
+
+// ProducerStatsHandler handles GET /admin/producers requests.
+type ProducerStatsHandler struct {
+	service primary.ProducerStatsService
+}
+
+// NewProducerStatsHandler creates a handler reporting producer performance.
+func NewProducerStatsHandler(service primary.ProducerStatsService) *ProducerStatsHandler {
+	return &ProducerStatsHandler{service: service}
+}
+
+// ServeHTTP returns write latency percentiles, batch sizes, response
+// statuses and error rates per destination for this instance.
+func (h *ProducerStatsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondJSON(w, http.StatusMethodNotAllowed, ErrorResponse{
+			Error: "method not allowed",
+			Code:  "METHOD_NOT_ALLOWED",
+		})
+		return
+	}
+
+	stats := h.service.ProducerStats()
+	resp := ProducerStatsResponse{Destinations: make([]ProducerStatsDTO, len(stats))}
+	for i, s := range stats {
+		dto := ProducerStatsDTO{
+			DestinationType: string(s.DestinationType),
+			Destination:     s.Destination,
+			Writes:          s.Writes,
+			Messages:        s.Messages,
+			Errors:          s.Errors,
+			ErrorRate:       s.ErrorRate(),
+			MaxBatchSize:    s.MaxBatchSize,
+			Oversized:       s.Oversized,
+			Truncated:       s.Truncated,
+			P50MS:           s.P50.Milliseconds(),
+			P90MS:           s.P90.Milliseconds(),
+			P99MS:           s.P99.Milliseconds(),
+			MaxMS:           s.Max.Milliseconds(),
+		}
+		if len(s.StatusCodes) > 0 {
+			dto.StatusCodes = make(map[string]int64, len(s.StatusCodes))
+			for status, n := range s.StatusCodes {
+				dto.StatusCodes[strconv.Itoa(status)] = n
+			}
+		}
+		resp.Destinations[i] = dto
+	}
+	respondJSON(w, http.StatusOK, resp)
+}
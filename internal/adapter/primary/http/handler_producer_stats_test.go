@@ -0,0 +1,86 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+func TestProducerStatsHandler_ServeHTTP(t *testing.T) {
+	service := &mockProducerStatsService{stats: []entity.ProducerStats{{
+		DestinationType: entity.DestinationTypeHTTP,
+		Destination:     "https://partner/hook",
+		Writes:          4,
+		Messages:        10,
+		Errors:          1,
+		MaxBatchSize:    5,
+		StatusCodes:     map[int]int64{200: 3, 503: 1},
+		P50:             12 * time.Millisecond,
+		P90:             80 * time.Millisecond,
+		P99:             80 * time.Millisecond,
+		Max:             80 * time.Millisecond,
+	}}}
+
+	tests := []struct {
+		name           string
+		method         string
+		wantStatusCode int
+		wantBody       []string
+	}{
+		{
+			name:           "reports stats per destination",
+			method:         http.MethodGet,
+			wantStatusCode: http.StatusOK,
+			wantBody: []string{
+				`"destination":"https://partner/hook"`,
+				`"error_rate":0.25`,
+				`"max_batch_size":5`,
+				`"status_codes":{"200":3,"503":1}`,
+				`"p50_ms":12`,
+			},
+		},
+		{
+			name:           "method not allowed",
+			method:         http.MethodPost,
+			wantStatusCode: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := NewRouter(&mockTaskService{}, &mockMaintenanceService{}, &mockSLAService{}, &mockScheduleService{}, &mockQueueService{}, nil, zap.NewNop(),
+				WithProducerStats(service))
+
+			req := httptest.NewRequest(tt.method, "/v1/admin/producers", nil)
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatusCode {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatusCode, rec.Code, rec.Body.String())
+			}
+			for _, want := range tt.wantBody {
+				if !strings.Contains(rec.Body.String(), want) {
+					t.Fatalf("expected body to contain %s, got %s", want, rec.Body.String())
+				}
+			}
+		})
+	}
+}
+
+func TestProducerStatsHandler_notRegistered(t *testing.T) {
+	router := NewRouter(&mockTaskService{}, &mockMaintenanceService{}, &mockSLAService{}, &mockScheduleService{}, &mockQueueService{}, nil, zap.NewNop())
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/admin/producers", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected status 404, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,92 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+	"github.com/ruudy-sib/rebound/internal/port/primary"
+)
+
+// PurgeQueueHandler handles POST /admin/queues/{name}/purge requests.
+type PurgeQueueHandler struct {
+	service primary.QueueService
+	logger  *zap.Logger
+}
+
+// NewPurgeQueueHandler creates a handler for queue purges.
+func NewPurgeQueueHandler(service primary.QueueService, logger *zap.Logger) *PurgeQueueHandler {
+	return &PurgeQueueHandler{
+		service: service,
+		logger:  logger.Named("purge-queue-handler"),
+	}
+}
+
+// ServeHTTP runs a dry run when dry_run is set, returning the count and a
+// confirmation token, and otherwise purges the queue if the token matches.
+func (h *PurgeQueueHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondJSON(w, http.StatusMethodNotAllowed, ErrorResponse{
+			Error: "method not allowed",
+			Code:  "METHOD_NOT_ALLOWED",
+		})
+		return
+	}
+
+	var req PurgeQueueRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondJSON(w, http.StatusBadRequest, ErrorResponse{
+			Error: "invalid request body",
+			Code:  "INVALID_BODY",
+		})
+		return
+	}
+	queue := entity.Queue(r.PathValue("name"))
+
+	if req.DryRun {
+		plan, err := h.service.PreparePurge(r.Context(), queue)
+		if err != nil {
+			h.respondError(w, queue, err)
+			return
+		}
+		respondJSON(w, http.StatusOK, PurgePlanResponse{
+			Queue:             string(plan.Queue),
+			Count:             plan.Count,
+			ConfirmationToken: plan.Token,
+			ExpiresAt:         plan.ExpiresAt.UTC(),
+		})
+		return
+	}
+
+	purged, err := h.service.Purge(r.Context(), queue, req.ConfirmationToken)
+	if err != nil {
+		h.respondError(w, queue, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, PurgeQueueResponse{Queue: string(queue), Purged: purged})
+}
+
+func (h *PurgeQueueHandler) respondError(w http.ResponseWriter, queue entity.Queue, err error) {
+	switch {
+	case errors.Is(err, domain.ErrUnknownQueue):
+		respondJSON(w, http.StatusNotFound, ErrorResponse{
+			Error: err.Error(),
+			Code:  "NOT_FOUND",
+		})
+	case errors.Is(err, domain.ErrConfirmationRequired):
+		respondJSON(w, http.StatusConflict, ErrorResponse{
+			Error: err.Error(),
+			Code:  "CONFIRMATION_REQUIRED",
+		})
+	default:
+		h.logger.Error("queue purge failed", zap.Error(err), zap.String("queue", string(queue)))
+		respondJSON(w, http.StatusInternalServerError, ErrorResponse{
+			Error: "internal server error",
+			Code:  "INTERNAL_ERROR",
+		})
+	}
+}
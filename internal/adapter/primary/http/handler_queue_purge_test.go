@@ -0,0 +1,119 @@
+package http
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+func TestPurgeQueueHandler_ServeHTTP(t *testing.T) {
+	plan := &entity.PurgePlan{Queue: entity.QueueScheduled, Count: 120, Token: "abc123", ExpiresAt: time.Now().Add(5 * time.Minute)}
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		body           string
+		svc            *mockQueueService
+		wantStatusCode int
+		wantBody       string
+		wantToken      string
+	}{
+		{
+			name:           "dry run returns count and token",
+			method:         http.MethodPost,
+			path:           "/admin/queues/scheduled/purge",
+			body:           `{"dry_run":true}`,
+			svc:            &mockQueueService{plan: plan},
+			wantStatusCode: http.StatusOK,
+			wantBody:       `"confirmation_token":"abc123"`,
+		},
+		{
+			name:           "confirmed purge",
+			method:         http.MethodPost,
+			path:           "/admin/queues/scheduled/purge",
+			body:           `{"confirmation_token":"abc123"}`,
+			svc:            &mockQueueService{purged: 118},
+			wantStatusCode: http.StatusOK,
+			wantBody:       `"purged":118`,
+			wantToken:      "abc123",
+		},
+		{
+			name:           "invalid token",
+			method:         http.MethodPost,
+			path:           "/admin/queues/scheduled/purge",
+			body:           `{"confirmation_token":"stale"}`,
+			svc:            &mockQueueService{err: fmt.Errorf("%w: expired", domain.ErrConfirmationRequired)},
+			wantStatusCode: http.StatusConflict,
+			wantBody:       "CONFIRMATION_REQUIRED",
+			wantToken:      "stale",
+		},
+		{
+			name:           "unknown queue",
+			method:         http.MethodPost,
+			path:           "/admin/queues/nope/purge",
+			body:           `{"dry_run":true}`,
+			svc:            &mockQueueService{err: fmt.Errorf("%w: \"nope\"", domain.ErrUnknownQueue)},
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name:           "store failure",
+			method:         http.MethodPost,
+			path:           "/admin/queues/scheduled/purge",
+			body:           `{"dry_run":true}`,
+			svc:            &mockQueueService{err: errors.New("redis down")},
+			wantStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name:           "invalid body",
+			method:         http.MethodPost,
+			path:           "/admin/queues/scheduled/purge",
+			body:           `{`,
+			svc:            &mockQueueService{},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "method not allowed",
+			method:         http.MethodGet,
+			path:           "/admin/queues/scheduled/purge",
+			svc:            &mockQueueService{},
+			wantStatusCode: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := NewRouter(&mockTaskService{}, &mockMaintenanceService{}, &mockSLAService{}, &mockScheduleService{}, tt.svc, nil, zap.NewNop())
+
+			req := httptest.NewRequest(tt.method, tt.path, bytes.NewBufferString(tt.body))
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatusCode {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatusCode, rec.Code, rec.Body.String())
+			}
+			if !strings.Contains(rec.Body.String(), tt.wantBody) {
+				t.Fatalf("expected body to contain %q, got %s", tt.wantBody, rec.Body.String())
+			}
+			if tt.method == http.MethodPost && tt.body != "{" {
+				wantQueue := strings.Split(tt.path, "/")[3]
+				if string(tt.svc.queue) != wantQueue {
+					t.Fatalf("expected queue %q, got %q", wantQueue, tt.svc.queue)
+				}
+			}
+			if tt.svc.token != tt.wantToken {
+				t.Fatalf("expected token %q, got %q", tt.wantToken, tt.svc.token)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package http
+
+import (
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/port/primary"
+)
+
+// QueueStatsHandler handles GET /admin/queues requests.
+type QueueStatsHandler struct {
+	service primary.QueueService
+	logger  *zap.Logger
+}
+
+// NewQueueStatsHandler creates a handler reporting queue sizes.
+func NewQueueStatsHandler(service primary.QueueService, logger *zap.Logger) *QueueStatsHandler {
+	return &QueueStatsHandler{
+		service: service,
+		logger:  logger.Named("queue-stats-handler"),
+	}
+}
+
+// ServeHTTP returns the pending and due counts and approximate memory usage
+// of every queue.
+func (h *QueueStatsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondJSON(w, http.StatusMethodNotAllowed, ErrorResponse{
+			Error: "method not allowed",
+			Code:  "METHOD_NOT_ALLOWED",
+		})
+		return
+	}
+
+	stats, err := h.service.Stats(r.Context())
+	if err != nil {
+		h.logger.Error("failed to read queue stats", zap.Error(err))
+		respondJSON(w, http.StatusInternalServerError, ErrorResponse{
+			Error: "internal server error",
+			Code:  "INTERNAL_ERROR",
+		})
+		return
+	}
+
+	resp := QueuesResponse{Queues: make([]QueueStatsDTO, len(stats))}
+	for i, s := range stats {
+		resp.Queues[i] = QueueStatsDTO{
+			Name:        string(s.Queue),
+			Pending:     s.Pending,
+			Due:         s.Due,
+			MemoryBytes: s.MemoryBytes,
+		}
+	}
+	respondJSON(w, http.StatusOK, resp)
+}
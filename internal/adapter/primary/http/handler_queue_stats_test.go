@@ -0,0 +1,64 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+func TestQueueStatsHandler_ServeHTTP(t *testing.T) {
+	tests := []struct {
+		name           string
+		method         string
+		svc            *mockQueueService
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name:   "lists queues",
+			method: http.MethodGet,
+			svc: &mockQueueService{stats: []entity.QueueStats{
+				{Queue: entity.QueueScheduled, Pending: 90, Due: 10, MemoryBytes: 2048},
+				{Queue: entity.QueueDeadLetter, Pending: 3, MemoryBytes: 512},
+			}},
+			wantStatusCode: http.StatusOK,
+			wantBody:       `{"name":"scheduled","pending":90,"due":10,"memory_bytes":2048}`,
+		},
+		{
+			name:           "store failure",
+			method:         http.MethodGet,
+			svc:            &mockQueueService{err: errors.New("redis down")},
+			wantStatusCode: http.StatusInternalServerError,
+			wantBody:       "INTERNAL_ERROR",
+		},
+		{
+			name:           "method not allowed",
+			method:         http.MethodPost,
+			svc:            &mockQueueService{},
+			wantStatusCode: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := NewRouter(&mockTaskService{}, &mockMaintenanceService{}, &mockSLAService{}, &mockScheduleService{}, tt.svc, nil, zap.NewNop())
+
+			req := httptest.NewRequest(tt.method, "/admin/queues", nil)
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatusCode {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatusCode, rec.Code, rec.Body.String())
+			}
+			if !strings.Contains(rec.Body.String(), tt.wantBody) {
+				t.Fatalf("expected body to contain %q, got %s", tt.wantBody, rec.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+	"github.com/ruudy-sib/rebound/internal/port/primary"
+)
+
+// ReconcileHandler handles POST /admin/reconcile requests.
+type ReconcileHandler struct {
+	service primary.JanitorService
+	logger  *zap.Logger
+}
+
+// NewReconcileHandler creates a handler for on-demand state repair.
+func NewReconcileHandler(service primary.JanitorService, logger *zap.Logger) *ReconcileHandler {
+	return &ReconcileHandler{
+		service: service,
+		logger:  logger.Named("reconcile-handler"),
+	}
+}
+
+// ServeHTTP runs a repair pass and returns what it fixed. A pass that fails
+// partway still reports the repairs made before the failure, with a 500.
+func (h *ReconcileHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondJSON(w, http.StatusMethodNotAllowed, ErrorResponse{
+			Error: "method not allowed",
+			Code:  "METHOD_NOT_ALLOWED",
+		})
+		return
+	}
+
+	report, err := h.service.ReconcileNow(r.Context())
+	switch {
+	case errors.Is(err, domain.ErrReconcileInProgress):
+		respondJSON(w, http.StatusConflict, ErrorResponse{
+			Error: err.Error(),
+			Code:  "RECONCILE_IN_PROGRESS",
+		})
+		return
+	case err != nil:
+		h.logger.Error("reconcile failed", zap.Error(err))
+		if report == nil {
+			respondJSON(w, http.StatusInternalServerError, ErrorResponse{
+				Error: "failed to reconcile state",
+				Code:  "INTERNAL_ERROR",
+			})
+			return
+		}
+		respondJSON(w, http.StatusInternalServerError, newRepairReportResponse(report))
+		return
+	}
+
+	respondJSON(w, http.StatusOK, newRepairReportResponse(report))
+}
+
+func newRepairReportResponse(report *entity.RepairReport) RepairReportResponse {
+	return RepairReportResponse{
+		IndexEntriesRemoved: report.IndexEntriesRemoved,
+		IndexEntriesAdded:   report.IndexEntriesAdded,
+		DeadLettersRemoved:  report.DeadLettersRemoved,
+		DeadLettersIndexed:  report.DeadLettersIndexed,
+		SLAGroupsRemoved:    report.SLAGroupsRemoved,
+		WorkersForgotten:    report.WorkersForgotten,
+		DeadLettersExpired:  report.DeadLettersExpired,
+		QuarantinedExpired:  report.QuarantinedExpired,
+		Total:               report.Total(),
+	}
+}
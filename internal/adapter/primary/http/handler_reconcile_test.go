@@ -0,0 +1,99 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+func TestReconcileHandler_ServeHTTP(t *testing.T) {
+	tests := []struct {
+		name           string
+		method         string
+		svc            *mockJanitorService
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name:           "reports repairs",
+			method:         http.MethodPost,
+			svc:            &mockJanitorService{report: &entity.RepairReport{IndexEntriesAdded: 2, WorkersForgotten: 1}},
+			wantStatusCode: http.StatusOK,
+			wantBody:       `"index_entries_added":2`,
+		},
+		{
+			name:           "reports the total",
+			method:         http.MethodPost,
+			svc:            &mockJanitorService{report: &entity.RepairReport{IndexEntriesAdded: 2, WorkersForgotten: 1}},
+			wantStatusCode: http.StatusOK,
+			wantBody:       `"total":3`,
+		},
+		{
+			name:           "pass already running",
+			method:         http.MethodPost,
+			svc:            &mockJanitorService{err: domain.ErrReconcileInProgress},
+			wantStatusCode: http.StatusConflict,
+			wantBody:       "RECONCILE_IN_PROGRESS",
+		},
+		{
+			name:   "partial repairs are reported with the failure",
+			method: http.MethodPost,
+			svc: &mockJanitorService{
+				report: &entity.RepairReport{DeadLettersRemoved: 4},
+				err:    errors.New("redis down"),
+			},
+			wantStatusCode: http.StatusInternalServerError,
+			wantBody:       `"dead_letters_removed":4`,
+		},
+		{
+			name:           "failure before any repair",
+			method:         http.MethodPost,
+			svc:            &mockJanitorService{err: errors.New("redis down")},
+			wantStatusCode: http.StatusInternalServerError,
+			wantBody:       "INTERNAL_ERROR",
+		},
+		{
+			name:           "method not allowed",
+			method:         http.MethodGet,
+			svc:            &mockJanitorService{},
+			wantStatusCode: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := NewRouter(&mockTaskService{}, &mockMaintenanceService{}, &mockSLAService{}, &mockScheduleService{}, &mockQueueService{}, nil, zap.NewNop(),
+				WithJanitor(tt.svc))
+
+			req := httptest.NewRequest(tt.method, "/v1/admin/reconcile", nil)
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatusCode {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatusCode, rec.Code, rec.Body.String())
+			}
+			if !strings.Contains(rec.Body.String(), tt.wantBody) {
+				t.Fatalf("expected body to contain %q, got %s", tt.wantBody, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestReconcileHandler_NotRegisteredWithoutJanitor(t *testing.T) {
+	router := NewRouter(&mockTaskService{}, &mockMaintenanceService{}, &mockSLAService{}, &mockScheduleService{}, &mockQueueService{}, nil, zap.NewNop())
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/admin/reconcile", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected status %d, got %d", http.StatusNotFound, rec.Code)
+	}
+}
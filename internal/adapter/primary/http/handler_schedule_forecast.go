@@ -0,0 +1,83 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+	"github.com/ruudy-sib/rebound/internal/port/primary"
+)
+
+// ForecastHandler handles GET /schedule/forecast requests.
+type ForecastHandler struct {
+	service primary.ScheduleService
+	logger  *zap.Logger
+}
+
+// NewForecastHandler creates a handler for schedule forecasts.
+func NewForecastHandler(service primary.ScheduleService, logger *zap.Logger) *ForecastHandler {
+	return &ForecastHandler{
+		service: service,
+		logger:  logger.Named("forecast-handler"),
+	}
+}
+
+// ServeHTTP returns per-minute due counts over the window query parameter
+// (a Go duration, default 1h).
+func (h *ForecastHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondJSON(w, http.StatusMethodNotAllowed, ErrorResponse{
+			Error: "method not allowed",
+			Code:  "METHOD_NOT_ALLOWED",
+		})
+		return
+	}
+
+	window := time.Hour
+	if v := r.URL.Query().Get("window"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			respondJSON(w, http.StatusBadRequest, ErrorResponse{
+				Error: fmt.Sprintf("invalid window %q: must be a duration such as \"1h\"", v),
+				Code:  "VALIDATION_ERROR",
+			})
+			return
+		}
+		window = d
+	}
+
+	forecast, err := h.service.Forecast(r.Context(), window)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidQuery) {
+			respondJSON(w, http.StatusBadRequest, ErrorResponse{
+				Error: err.Error(),
+				Code:  "VALIDATION_ERROR",
+			})
+			return
+		}
+		h.logger.Error("failed to forecast schedule", zap.Error(err))
+		respondJSON(w, http.StatusInternalServerError, ErrorResponse{
+			Error: "internal server error",
+			Code:  "INTERNAL_ERROR",
+		})
+		return
+	}
+
+	resp := ForecastResponse{
+		From:          forecast.From.UTC(),
+		BucketSeconds: int(forecast.Bucket / time.Second),
+		Overdue:       forecast.Overdue,
+		Buckets:       make([]ForecastBucketDTO, len(forecast.Counts)),
+	}
+	for i, count := range forecast.Counts {
+		resp.Buckets[i] = ForecastBucketDTO{
+			Start: resp.From.Add(time.Duration(i) * forecast.Bucket),
+			Count: count,
+		}
+	}
+	respondJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,95 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+func TestForecastHandler_ServeHTTP(t *testing.T) {
+	from := time.Date(2030, 1, 1, 12, 0, 0, 0, time.UTC)
+	forecast := &entity.ScheduleForecast{From: from, Bucket: time.Minute, Overdue: 4, Counts: []int64{1, 0, 7}}
+
+	tests := []struct {
+		name           string
+		method         string
+		target         string
+		svcErr         error
+		wantStatusCode int
+		wantWindow     time.Duration
+	}{
+		{
+			name:           "default window",
+			method:         http.MethodGet,
+			target:         "/schedule/forecast",
+			wantStatusCode: http.StatusOK,
+			wantWindow:     time.Hour,
+		},
+		{
+			name:           "explicit window",
+			method:         http.MethodGet,
+			target:         "/schedule/forecast?window=3m",
+			wantStatusCode: http.StatusOK,
+			wantWindow:     3 * time.Minute,
+		},
+		{
+			name:           "unparseable window",
+			method:         http.MethodGet,
+			target:         "/schedule/forecast?window=soon",
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "window out of range",
+			method:         http.MethodGet,
+			target:         "/schedule/forecast?window=48h",
+			svcErr:         fmt.Errorf("%w: too long", domain.ErrInvalidQuery),
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "method not allowed",
+			method:         http.MethodPost,
+			target:         "/schedule/forecast",
+			wantStatusCode: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := &mockScheduleService{forecast: forecast, err: tt.svcErr}
+			handler := NewForecastHandler(mockSvc, zap.NewNop())
+
+			req := httptest.NewRequest(tt.method, tt.target, nil)
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatusCode {
+				t.Fatalf("expected status %d, got %d (body: %s)", tt.wantStatusCode, rec.Code, rec.Body.String())
+			}
+			if tt.wantStatusCode != http.StatusOK {
+				return
+			}
+			if mockSvc.window != tt.wantWindow {
+				t.Fatalf("expected window %v, got %v", tt.wantWindow, mockSvc.window)
+			}
+
+			var resp ForecastResponse
+			if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+				t.Fatalf("decoding response: %v", err)
+			}
+			if resp.Overdue != 4 || resp.BucketSeconds != 60 || len(resp.Buckets) != 3 {
+				t.Fatalf("unexpected response: %+v", resp)
+			}
+			if !resp.Buckets[2].Start.Equal(from.Add(2*time.Minute)) || resp.Buckets[2].Count != 7 {
+				t.Fatalf("unexpected last bucket: %+v", resp.Buckets[2])
+			}
+		})
+	}
+}
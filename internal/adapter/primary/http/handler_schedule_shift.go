@@ -0,0 +1,83 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+	"github.com/ruudy-sib/rebound/internal/port/primary"
+)
+
+// ShiftTasksHandler handles POST /schedule/shift requests.
+type ShiftTasksHandler struct {
+	service primary.ScheduleService
+	logger  *zap.Logger
+}
+
+// NewShiftTasksHandler creates a handler for bulk rescheduling.
+func NewShiftTasksHandler(service primary.ScheduleService, logger *zap.Logger) *ShiftTasksHandler {
+	return &ShiftTasksHandler{
+		service: service,
+		logger:  logger.Named("shift-tasks-handler"),
+	}
+}
+
+// ServeHTTP shifts the matching tasks and reports how many moved.
+func (h *ShiftTasksHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondJSON(w, http.StatusMethodNotAllowed, ErrorResponse{
+			Error: "method not allowed",
+			Code:  "METHOD_NOT_ALLOWED",
+		})
+		return
+	}
+
+	var req ShiftTasksRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondJSON(w, http.StatusBadRequest, ErrorResponse{
+			Error: "invalid request body",
+			Code:  "INVALID_BODY",
+		})
+		return
+	}
+
+	by, err := time.ParseDuration(req.Shift)
+	if err != nil {
+		respondJSON(w, http.StatusBadRequest, ErrorResponse{
+			Error: fmt.Sprintf("invalid shift %q: must be a duration such as \"2h\"", req.Shift),
+			Code:  "VALIDATION_ERROR",
+		})
+		return
+	}
+
+	filter := entity.TaskFilter{
+		ClientID:    req.ClientID,
+		Destination: req.Destination,
+		DueAfter:    req.DueAfter,
+		DueBefore:   req.DueBefore,
+	}
+	shifted, err := h.service.Shift(r.Context(), filter, by)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidQuery) {
+			respondJSON(w, http.StatusBadRequest, ErrorResponse{
+				Error: err.Error(),
+				Code:  "VALIDATION_ERROR",
+			})
+			return
+		}
+		h.logger.Error("failed to shift tasks", zap.Error(err), zap.Int("shifted", shifted))
+		respondJSON(w, http.StatusInternalServerError, ErrorResponse{
+			Error: "internal server error",
+			Code:  "INTERNAL_ERROR",
+		})
+		return
+	}
+
+	respondJSON(w, http.StatusOK, ShiftTasksResponse{Shifted: shifted})
+}
@@ -0,0 +1,86 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+)
+
+func TestShiftTasksHandler_ServeHTTP(t *testing.T) {
+	tests := []struct {
+		name           string
+		method         string
+		body           string
+		svcErr         error
+		wantStatusCode int
+		wantBy         time.Duration
+	}{
+		{
+			name:           "shift by destination",
+			method:         http.MethodPost,
+			body:           `{"destination":"http://partner/hook","shift":"2h"}`,
+			wantStatusCode: http.StatusOK,
+			wantBy:         2 * time.Hour,
+		},
+		{
+			name:           "invalid shift",
+			method:         http.MethodPost,
+			body:           `{"client_id":"client-1","shift":"later"}`,
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "validation error",
+			method:         http.MethodPost,
+			body:           `{"shift":"1h"}`,
+			svcErr:         fmt.Errorf("%w: empty filter", domain.ErrInvalidQuery),
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "store failure",
+			method:         http.MethodPost,
+			body:           `{"client_id":"client-1","shift":"1h"}`,
+			svcErr:         errors.New("redis down"),
+			wantStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name:           "method not allowed",
+			method:         http.MethodGet,
+			wantStatusCode: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := &mockScheduleService{shifted: 12, err: tt.svcErr}
+			handler := NewShiftTasksHandler(mockSvc, zap.NewNop())
+
+			req := httptest.NewRequest(tt.method, "/schedule/shift", bytes.NewBufferString(tt.body))
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatusCode {
+				t.Fatalf("expected status %d, got %d (body: %s)", tt.wantStatusCode, rec.Code, rec.Body.String())
+			}
+			if tt.wantStatusCode != http.StatusOK {
+				return
+			}
+
+			var resp ShiftTasksResponse
+			if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+				t.Fatalf("decoding response: %v", err)
+			}
+			if resp.Shifted != 12 || mockSvc.shiftBy != tt.wantBy || mockSvc.shiftFilter.Destination != "http://partner/hook" {
+				t.Fatalf("unexpected shift: %+v by %v filter %+v", resp, mockSvc.shiftBy, mockSvc.shiftFilter)
+			}
+		})
+	}
+}
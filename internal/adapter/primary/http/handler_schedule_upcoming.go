@@ -0,0 +1,119 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+	"github.com/ruudy-sib/rebound/internal/port/primary"
+)
+
+// UpcomingTasksHandler handles GET /schedule/upcoming requests.
+type UpcomingTasksHandler struct {
+	service primary.ScheduleService
+	logger  *zap.Logger
+}
+
+// NewUpcomingTasksHandler creates a handler listing the next due tasks.
+func NewUpcomingTasksHandler(service primary.ScheduleService, logger *zap.Logger) *UpcomingTasksHandler {
+	return &UpcomingTasksHandler{
+		service: service,
+		logger:  logger.Named("upcoming-tasks-handler"),
+	}
+}
+
+// ServeHTTP returns the scheduled tasks due soonest, up to the limit query
+// parameter (default 50). Overdue tasks come first. Later pages are fetched
+// by passing the response's next_cursor as the cursor query parameter. The
+// destination query parameter narrows the list to one URL or Kafka topic;
+// such lists are not paged.
+func (h *UpcomingTasksHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondJSON(w, http.StatusMethodNotAllowed, ErrorResponse{
+			Error: "method not allowed",
+			Code:  "METHOD_NOT_ALLOWED",
+		})
+		return
+	}
+
+	limit, err := queryLimit(r, defaultListLimit)
+	if err != nil {
+		respondJSON(w, http.StatusBadRequest, ErrorResponse{
+			Error: err.Error(),
+			Code:  "VALIDATION_ERROR",
+		})
+		return
+	}
+
+	cursor, err := queryCursor(r)
+	if err != nil {
+		respondJSON(w, http.StatusBadRequest, ErrorResponse{
+			Error: err.Error(),
+			Code:  "VALIDATION_ERROR",
+		})
+		return
+	}
+
+	var (
+		scheduled []entity.ScheduledTask
+		next      entity.Cursor
+	)
+	if destination := r.URL.Query().Get("destination"); destination != "" {
+		if !cursor.IsZero() {
+			respondJSON(w, http.StatusBadRequest, ErrorResponse{
+				Error: "cursor cannot be combined with destination",
+				Code:  "VALIDATION_ERROR",
+			})
+			return
+		}
+		scheduled, err = h.service.ByDestination(r.Context(), destination, limit)
+	} else {
+		scheduled, next, err = h.service.Upcoming(r.Context(), cursor, limit)
+	}
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidQuery) {
+			respondJSON(w, http.StatusBadRequest, ErrorResponse{
+				Error: err.Error(),
+				Code:  "VALIDATION_ERROR",
+			})
+			return
+		}
+		h.logger.Error("failed to list upcoming tasks", zap.Error(err))
+		respondJSON(w, http.StatusInternalServerError, ErrorResponse{
+			Error: "internal server error",
+			Code:  "INTERNAL_ERROR",
+		})
+		return
+	}
+
+	resp := UpcomingTasksResponse{
+		Tasks:      make([]ScheduledTaskDTO, len(scheduled)),
+		NextCursor: encodeCursor(next),
+	}
+	for i, st := range scheduled {
+		t := st.Task
+		dto := ScheduledTaskDTO{
+			ID:              t.ID,
+			Source:          t.Source,
+			ClientID:        t.ClientID,
+			DestinationType: string(t.DestinationType),
+			Destination:     t.Destination.Name(),
+			Attempt:         t.Attempt,
+			MaxRetries:      t.MaxRetries,
+			DueAt:           st.DueAt.UTC(),
+			LastError:       t.LastError,
+			LastErrorCode:   string(t.LastErrorCode),
+			Metadata:        t.Metadata,
+			CorrelationID:   t.CorrelationID,
+		}
+		if !t.LastAttemptAt.IsZero() {
+			last := t.LastAttemptAt.UTC()
+			dto.LastAttemptAt = &last
+		}
+		resp.Tasks[i] = dto
+	}
+	respondJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,151 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+func TestUpcomingTasksHandler_ServeHTTP(t *testing.T) {
+	dueAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	failing := &entity.Task{
+		ID:            "task-2",
+		Destination:   entity.Destination{Topic: "orders"},
+		Attempt:       2,
+		MaxRetries:    5,
+		LastAttemptAt: dueAt.Add(-time.Minute),
+		LastError:     "broker unavailable",
+	}
+
+	tests := []struct {
+		name           string
+		method         string
+		query          string
+		svc            *mockScheduleService
+		wantStatusCode int
+		wantLimit      int
+		wantDest       string
+	}{
+		{
+			name:   "lists upcoming tasks",
+			method: http.MethodGet,
+			query:  "?limit=10",
+			svc: &mockScheduleService{upcoming: []entity.ScheduledTask{
+				{Task: &entity.Task{ID: "task-1", Destination: entity.Destination{URL: "http://localhost/hook"}}, DueAt: dueAt},
+				{Task: failing, DueAt: dueAt},
+			}},
+			wantStatusCode: http.StatusOK,
+			wantLimit:      10,
+		},
+		{
+			name:   "lists tasks for one destination",
+			method: http.MethodGet,
+			query:  "?destination=orders&limit=5",
+			svc: &mockScheduleService{upcoming: []entity.ScheduledTask{
+				{Task: &entity.Task{ID: "task-1", Destination: entity.Destination{Topic: "orders"}}, DueAt: dueAt},
+				{Task: failing, DueAt: dueAt},
+			}},
+			wantStatusCode: http.StatusOK,
+			wantLimit:      5,
+			wantDest:       "orders",
+		},
+		{
+			name:           "cursor with destination",
+			method:         http.MethodGet,
+			query:          "?destination=orders&cursor=" + encodeCursor(entity.Cursor{Score: 1, Member: "x"}),
+			svc:            &mockScheduleService{},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "malformed limit",
+			method:         http.MethodGet,
+			query:          "?limit=-",
+			svc:            &mockScheduleService{},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "scheduler failure",
+			method:         http.MethodGet,
+			svc:            &mockScheduleService{err: errors.New("redis down")},
+			wantStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name:           "method not allowed",
+			method:         http.MethodPost,
+			svc:            &mockScheduleService{},
+			wantStatusCode: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewUpcomingTasksHandler(tt.svc, zap.NewNop())
+
+			req := httptest.NewRequest(tt.method, "/schedule/upcoming"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatusCode {
+				t.Fatalf("expected status %d, got %d", tt.wantStatusCode, rec.Code)
+			}
+			if tt.wantStatusCode != http.StatusOK {
+				return
+			}
+			if tt.svc.upcomingLimit != tt.wantLimit {
+				t.Fatalf("expected limit %d, got %d", tt.wantLimit, tt.svc.upcomingLimit)
+			}
+			if tt.svc.destination != tt.wantDest {
+				t.Fatalf("expected destination %q, got %q", tt.wantDest, tt.svc.destination)
+			}
+
+			var resp UpcomingTasksResponse
+			if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+				t.Fatalf("decoding response: %v", err)
+			}
+			if len(resp.Tasks) != 2 {
+				t.Fatalf("expected 2 tasks, got %d", len(resp.Tasks))
+			}
+			if resp.Tasks[0].LastAttemptAt != nil {
+				t.Fatalf("expected no last attempt for a fresh task, got %v", resp.Tasks[0].LastAttemptAt)
+			}
+			if got := resp.Tasks[1]; got.Destination != "orders" || got.LastError != "broker unavailable" || got.LastAttemptAt == nil {
+				t.Fatalf("unexpected task: %+v", got)
+			}
+		})
+	}
+}
+
+func TestUpcomingTasksHandler_cursor(t *testing.T) {
+	cursor := entity.Cursor{Score: 1709294400, Member: `{"schema_version":2,"id":"task-1"`}
+	next := entity.Cursor{Score: 1709294460, Member: `{"schema_version":2,"id":"task-2"`}
+	svc := &mockScheduleService{
+		upcoming:     []entity.ScheduledTask{{Task: &entity.Task{ID: "task-2"}, DueAt: time.Now()}},
+		upcomingNext: next,
+	}
+	handler := NewUpcomingTasksHandler(svc, zap.NewNop())
+
+	req := httptest.NewRequest(http.MethodGet, "/schedule/upcoming?cursor="+encodeCursor(cursor), nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rec.Code)
+	}
+	if svc.upcomingCursor != cursor {
+		t.Fatalf("expected cursor %+v, got %+v", cursor, svc.upcomingCursor)
+	}
+	var resp UpcomingTasksResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+	if resp.NextCursor != encodeCursor(next) {
+		t.Fatalf("expected next_cursor %q, got %q", encodeCursor(next), resp.NextCursor)
+	}
+}
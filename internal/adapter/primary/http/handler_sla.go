@@ -0,0 +1,58 @@
+package http
+
+import (
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/port/primary"
+)
+
+// SLAHandler handles GET /admin/sla requests.
+type SLAHandler struct {
+	service primary.SLAService
+	logger  *zap.Logger
+}
+
+// NewSLAHandler creates a handler reporting delivery SLA statistics.
+func NewSLAHandler(service primary.SLAService, logger *zap.Logger) *SLAHandler {
+	return &SLAHandler{
+		service: service,
+		logger:  logger.Named("sla-handler"),
+	}
+}
+
+// ServeHTTP returns time-to-success percentiles per client and destination.
+func (h *SLAHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondJSON(w, http.StatusMethodNotAllowed, ErrorResponse{
+			Error: "method not allowed",
+			Code:  "METHOD_NOT_ALLOWED",
+		})
+		return
+	}
+
+	stats, err := h.service.TimeToSuccess(r.Context())
+	if err != nil {
+		h.logger.Error("failed to read SLA statistics", zap.Error(err))
+		respondJSON(w, http.StatusInternalServerError, ErrorResponse{
+			Error: "internal server error",
+			Code:  "INTERNAL_ERROR",
+		})
+		return
+	}
+
+	resp := SLAResponse{TimeToSuccess: make([]TimeToSuccessDTO, len(stats))}
+	for i, s := range stats {
+		resp.TimeToSuccess[i] = TimeToSuccessDTO{
+			ClientID:    s.ClientID,
+			Destination: s.Destination,
+			Count:       s.Count,
+			P50MS:       s.P50.Milliseconds(),
+			P90MS:       s.P90.Milliseconds(),
+			P99MS:       s.P99.Milliseconds(),
+			MaxMS:       s.Max.Milliseconds(),
+		}
+	}
+	respondJSON(w, http.StatusOK, resp)
+}
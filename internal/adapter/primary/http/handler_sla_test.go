@@ -0,0 +1,80 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+func TestSLAHandler_ServeHTTP(t *testing.T) {
+	tests := []struct {
+		name           string
+		method         string
+		svc            *mockSLAService
+		wantStatusCode int
+		wantGroups     int
+	}{
+		{
+			name:   "returns percentiles",
+			method: http.MethodGet,
+			svc: &mockSLAService{stats: []entity.TimeToSuccessStats{{
+				ClientID:    "client-1",
+				Destination: "orders",
+				Count:       10,
+				P50:         1500 * time.Millisecond,
+				P90:         3 * time.Second,
+				P99:         4 * time.Second,
+				Max:         5 * time.Second,
+			}}},
+			wantStatusCode: http.StatusOK,
+			wantGroups:     1,
+		},
+		{
+			name:           "store failure",
+			method:         http.MethodGet,
+			svc:            &mockSLAService{err: errors.New("redis down")},
+			wantStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name:           "method not allowed",
+			method:         http.MethodPost,
+			svc:            &mockSLAService{},
+			wantStatusCode: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewSLAHandler(tt.svc, zap.NewNop())
+
+			req := httptest.NewRequest(tt.method, "/admin/sla", nil)
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatusCode {
+				t.Fatalf("expected status %d, got %d", tt.wantStatusCode, rec.Code)
+			}
+			if tt.wantStatusCode != http.StatusOK {
+				return
+			}
+
+			var resp SLAResponse
+			if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+				t.Fatalf("decoding response: %v", err)
+			}
+			if len(resp.TimeToSuccess) != tt.wantGroups {
+				t.Fatalf("expected %d groups, got %d", tt.wantGroups, len(resp.TimeToSuccess))
+			}
+			if got := resp.TimeToSuccess[0]; got.P50MS != 1500 || got.MaxMS != 5000 {
+				t.Fatalf("unexpected stats: %+v", got)
+			}
+		})
+	}
+}
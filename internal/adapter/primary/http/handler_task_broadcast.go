@@ -0,0 +1,102 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+	"github.com/ruudy-sib/rebound/internal/port/primary"
+)
+
+// BroadcastTasksHandler handles POST /tasks/broadcast requests.
+type BroadcastTasksHandler struct {
+	service primary.TaskService
+	logger  *zap.Logger
+}
+
+// NewBroadcastTasksHandler creates a handler for paced task creation.
+func NewBroadcastTasksHandler(service primary.TaskService, logger *zap.Logger) *BroadcastTasksHandler {
+	return &BroadcastTasksHandler{
+		service: service,
+		logger:  logger.Named("broadcast-tasks-handler"),
+	}
+}
+
+// ServeHTTP schedules all tasks in the request spread over its window.
+func (h *BroadcastTasksHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondJSON(w, http.StatusMethodNotAllowed, ErrorResponse{
+			Error: "method not allowed",
+			Code:  "METHOD_NOT_ALLOWED",
+		})
+		return
+	}
+
+	var req BroadcastTasksRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondJSON(w, http.StatusBadRequest, ErrorResponse{
+			Error: "invalid request body",
+			Code:  "INVALID_BODY",
+		})
+		return
+	}
+
+	window, err := time.ParseDuration(req.Window)
+	if err != nil || window < 0 {
+		respondJSON(w, http.StatusBadRequest, ErrorResponse{
+			Error: fmt.Sprintf("invalid window %q: must be a duration such as \"10m\"", req.Window),
+			Code:  "VALIDATION_ERROR",
+		})
+		return
+	}
+
+	if len(req.Tasks) == 0 {
+		respondJSON(w, http.StatusBadRequest, ErrorResponse{
+			Error: "at least one task is required",
+			Code:  "VALIDATION_ERROR",
+		})
+		return
+	}
+
+	tasks := make([]*entity.Task, len(req.Tasks))
+	for i := range req.Tasks {
+		tasks[i] = req.Tasks[i].toEntity()
+	}
+
+	if err := authorizeSources(r.Context(), tasks...); err != nil {
+		respondForbidden(w, err)
+		return
+	}
+
+	if err := h.service.CreateTasksPaced(r.Context(), tasks, window); err != nil {
+		if errors.Is(err, domain.ErrInvalidTask) {
+			respondValidationError(w, err)
+			return
+		}
+		if errors.Is(err, domain.ErrQueueFull) {
+			respondQueueFull(w, err)
+			return
+		}
+		h.logger.Error("failed to create paced tasks", zap.Error(err))
+		respondJSON(w, http.StatusInternalServerError, ErrorResponse{
+			Error: "internal server error",
+			Code:  "INTERNAL_ERROR",
+		})
+		return
+	}
+
+	ids := make([]string, len(tasks))
+	for i, task := range tasks {
+		ids[i] = task.ID
+	}
+	respondJSON(w, http.StatusCreated, CreateTaskResponse{
+		Message: fmt.Sprintf("%d tasks scheduled over %s", len(tasks), window),
+		TaskIDs: ids,
+	})
+}
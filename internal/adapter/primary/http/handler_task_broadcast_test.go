@@ -0,0 +1,104 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+)
+
+func TestBroadcastTasksHandler_ServeHTTP(t *testing.T) {
+	validTask := CreateTaskRequest{
+		ID:              "tenant-1",
+		Source:          "notifier",
+		Destination:     DestinationDTO{URL: "http://localhost:8090/hook"},
+		MaxRetries:      3,
+		BaseDelay:       Delay(1 * time.Second),
+		DestinationType: "http",
+	}
+
+	tests := []struct {
+		name           string
+		method         string
+		body           interface{}
+		createErr      error
+		wantStatusCode int
+		wantWindow     time.Duration
+		wantTasks      int
+	}{
+		{
+			name:           "tasks are paced over window",
+			method:         http.MethodPost,
+			body:           BroadcastTasksRequest{Window: "10m", Tasks: []CreateTaskRequest{validTask, validTask}},
+			wantStatusCode: http.StatusCreated,
+			wantWindow:     10 * time.Minute,
+			wantTasks:      2,
+		},
+		{
+			name:           "method not allowed",
+			method:         http.MethodGet,
+			wantStatusCode: http.StatusMethodNotAllowed,
+		},
+		{
+			name:           "invalid window",
+			method:         http.MethodPost,
+			body:           BroadcastTasksRequest{Window: "soon", Tasks: []CreateTaskRequest{validTask}},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "no tasks",
+			method:         http.MethodPost,
+			body:           BroadcastTasksRequest{Window: "1m"},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "validation error",
+			method:         http.MethodPost,
+			body:           BroadcastTasksRequest{Window: "1m", Tasks: []CreateTaskRequest{{}}},
+			createErr:      domain.ErrInvalidTask,
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "internal error",
+			method:         http.MethodPost,
+			body:           BroadcastTasksRequest{Window: "1m", Tasks: []CreateTaskRequest{validTask}},
+			createErr:      errors.New("redis down"),
+			wantStatusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := &mockTaskService{createErr: tt.createErr}
+			handler := NewBroadcastTasksHandler(mockSvc, zap.NewNop())
+
+			var bodyBytes []byte
+			if tt.body != nil {
+				bodyBytes, _ = json.Marshal(tt.body)
+			}
+			req := httptest.NewRequest(tt.method, "/tasks/broadcast", bytes.NewReader(bodyBytes))
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatusCode {
+				t.Fatalf("expected status %d, got %d (body: %s)", tt.wantStatusCode, rec.Code, rec.Body.String())
+			}
+			if tt.wantTasks > 0 {
+				if len(mockSvc.pacedTasks) != tt.wantTasks {
+					t.Fatalf("expected %d tasks, got %d", tt.wantTasks, len(mockSvc.pacedTasks))
+				}
+				if mockSvc.pacedWindow != tt.wantWindow {
+					t.Fatalf("expected window %v, got %v", tt.wantWindow, mockSvc.pacedWindow)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+	"github.com/ruudy-sib/rebound/internal/port/primary"
+)
+
+// BulkTaskHandler applies one operation to every task ID in a request.
+// It serves POST /tasks/cancel and POST /tasks/restore.
+type BulkTaskHandler struct {
+	action    func(ctx context.Context, taskID string) error
+	lookup    func(ctx context.Context, taskID string) (*entity.Task, error) // for source authorization
+	doneState string
+	logger    *zap.Logger
+}
+
+// NewCancelTasksHandler creates a handler that soft-cancels tasks.
+func NewCancelTasksHandler(service primary.TaskService, logger *zap.Logger) *BulkTaskHandler {
+	return &BulkTaskHandler{
+		action:    service.CancelTask,
+		lookup:    service.GetTask,
+		doneState: "cancelled",
+		logger:    logger.Named("cancel-tasks-handler"),
+	}
+}
+
+// NewRestoreTasksHandler creates a handler that restores cancelled tasks.
+func NewRestoreTasksHandler(service primary.TaskService, logger *zap.Logger) *BulkTaskHandler {
+	return &BulkTaskHandler{
+		action:    service.RestoreTask,
+		lookup:    service.GetCancelledTask,
+		doneState: "restored",
+		logger:    logger.Named("restore-tasks-handler"),
+	}
+}
+
+// ServeHTTP applies the operation to each ID and reports per-task results.
+// The response is 200 even when some tasks fail; callers inspect results.
+// Tasks whose source the caller's API key may not use are reported as
+// forbidden and left alone.
+func (h *BulkTaskHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondJSON(w, http.StatusMethodNotAllowed, ErrorResponse{
+			Error: "method not allowed",
+			Code:  "METHOD_NOT_ALLOWED",
+		})
+		return
+	}
+
+	var req TaskIDsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondJSON(w, http.StatusBadRequest, ErrorResponse{
+			Error: "invalid request body",
+			Code:  "INVALID_BODY",
+		})
+		return
+	}
+	if len(req.IDs) == 0 {
+		respondJSON(w, http.StatusBadRequest, ErrorResponse{
+			Error: "at least one task id is required",
+			Code:  "VALIDATION_ERROR",
+		})
+		return
+	}
+
+	resp := BulkTaskResponse{Results: make([]TaskResult, len(req.IDs))}
+	for i, id := range req.IDs {
+		result := TaskResult{ID: id, Status: h.doneState}
+		if err := h.apply(r.Context(), id); err != nil {
+			switch {
+			case errors.Is(err, domain.ErrTaskNotFound):
+				result.Status = "not_found"
+			case errors.Is(err, errSourceNotAllowed):
+				result.Status = "forbidden"
+				result.Error = err.Error()
+			default:
+				h.logger.Error("bulk task operation failed", zap.Error(err), zap.String("task_id", id))
+				result.Status = "error"
+				result.Error = "internal error"
+			}
+		}
+		resp.Results[i] = result
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// apply runs the operation on one task once the caller is authorized for it.
+func (h *BulkTaskHandler) apply(ctx context.Context, id string) error {
+	if h.lookup != nil {
+		if err := authorizeTask(ctx, id, h.lookup); err != nil {
+			return err
+		}
+	}
+	return h.action(ctx, id)
+}
@@ -0,0 +1,99 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+)
+
+func TestBulkTaskHandler_ServeHTTP(t *testing.T) {
+	tests := []struct {
+		name           string
+		restore        bool
+		method         string
+		body           string
+		taskErrs       map[string]error
+		wantStatusCode int
+		wantResults    []TaskResult
+	}{
+		{
+			name:           "cancel reports per-task results",
+			method:         http.MethodPost,
+			body:           `{"ids":["a","b","c"]}`,
+			taskErrs:       map[string]error{"b": fmt.Errorf("cancelling task b: %w", domain.ErrTaskNotFound), "c": errors.New("redis down")},
+			wantStatusCode: http.StatusOK,
+			wantResults: []TaskResult{
+				{ID: "a", Status: "cancelled"},
+				{ID: "b", Status: "not_found"},
+				{ID: "c", Status: "error", Error: "internal error"},
+			},
+		},
+		{
+			name:           "restore",
+			restore:        true,
+			method:         http.MethodPost,
+			body:           `{"ids":["a"]}`,
+			wantStatusCode: http.StatusOK,
+			wantResults:    []TaskResult{{ID: "a", Status: "restored"}},
+		},
+		{
+			name:           "no ids",
+			method:         http.MethodPost,
+			body:           `{"ids":[]}`,
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid body",
+			method:         http.MethodPost,
+			body:           `not json`,
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "method not allowed",
+			method:         http.MethodDelete,
+			wantStatusCode: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := &mockTaskService{taskErrs: tt.taskErrs}
+			handler := NewCancelTasksHandler(mockSvc, zap.NewNop())
+			if tt.restore {
+				handler = NewRestoreTasksHandler(mockSvc, zap.NewNop())
+			}
+
+			req := httptest.NewRequest(tt.method, "/tasks/cancel", bytes.NewBufferString(tt.body))
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatusCode {
+				t.Fatalf("expected status %d, got %d (body: %s)", tt.wantStatusCode, rec.Code, rec.Body.String())
+			}
+			if tt.wantResults == nil {
+				return
+			}
+
+			var resp BulkTaskResponse
+			if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+				t.Fatalf("decoding response: %v", err)
+			}
+			if len(resp.Results) != len(tt.wantResults) {
+				t.Fatalf("expected %d results, got %d", len(tt.wantResults), len(resp.Results))
+			}
+			for i, want := range tt.wantResults {
+				if resp.Results[i] != want {
+					t.Fatalf("result %d = %+v, want %+v", i, resp.Results[i], want)
+				}
+			}
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/ruudy-sib/rebound/internal/domain"
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
 	"github.com/ruudy-sib/rebound/internal/port/primary"
 )
 
@@ -46,12 +47,19 @@ func (h *CreateTaskHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 
 	task := req.toEntity()
+	fanOuts := len(task.FanOutDestinations)
+	if err := authorizeSources(r.Context(), task); err != nil {
+		respondForbidden(w, err)
+		return
+	}
+
 	if err := h.service.CreateTask(r.Context(), task); err != nil {
 		if errors.Is(err, domain.ErrInvalidTask) {
-			respondJSON(w, http.StatusBadRequest, ErrorResponse{
-				Error: err.Error(),
-				Code:  "VALIDATION_ERROR",
-			})
+			respondValidationError(w, err)
+			return
+		}
+		if errors.Is(err, domain.ErrQueueFull) {
+			respondQueueFull(w, err)
 			return
 		}
 		h.logger.Error("failed to create task", zap.Error(err))
@@ -62,7 +70,23 @@ func (h *CreateTaskHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// The ID may have been generated.
 	respondJSON(w, http.StatusCreated, CreateTaskResponse{
 		Message: fmt.Sprintf("Task %s scheduled successfully", task.ID),
+		ID:      task.ID,
+		TaskIDs: fanOutTaskIDs(task.ID, fanOuts),
 	})
 }
+
+// fanOutTaskIDs returns the IDs of the tasks a task with the given ID and
+// fanOuts fan-out destinations is split into, or nil if it has none.
+func fanOutTaskIDs(id string, fanOuts int) []string {
+	if fanOuts == 0 {
+		return nil
+	}
+	ids := []string{id}
+	for n := range fanOuts {
+		ids = append(ids, entity.FanOutTaskID(id, n+1))
+	}
+	return ids
+}
@@ -4,13 +4,16 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"go.uber.org/zap"
 
 	"github.com/ruudy-sib/rebound/internal/domain"
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
 )
 
 func TestCreateTaskHandler_ServeHTTP(t *testing.T) {
@@ -21,6 +24,7 @@ func TestCreateTaskHandler_ServeHTTP(t *testing.T) {
 		createErr      error
 		wantStatusCode int
 		wantMessage    string
+		wantField      string
 	}{
 		{
 			name:   "successful task creation",
@@ -39,7 +43,7 @@ func TestCreateTaskHandler_ServeHTTP(t *testing.T) {
 					Topic: "dead-topic",
 				},
 				MaxRetries:      3,
-				BaseDelay:       2,
+				BaseDelay:       Delay(2 * time.Second),
 				ClientID:        "client-1",
 				MessageData:     "test data",
 				DestinationType: "kafka",
@@ -68,6 +72,35 @@ func TestCreateTaskHandler_ServeHTTP(t *testing.T) {
 			createErr:      domain.ErrInvalidTask,
 			wantStatusCode: http.StatusBadRequest,
 		},
+		{
+			name:   "field validation error",
+			method: http.MethodPost,
+			body: CreateTaskRequest{
+				ID:              "task-4",
+				Destination:     DestinationDTO{URL: "partner.example.com/hook"},
+				DestinationType: "http",
+			},
+			createErr: fmt.Errorf("%w: %w", domain.ErrInvalidTask,
+				&entity.FieldError{Field: "destination.url", Err: errors.New("must be an absolute http or https URL")}),
+			wantStatusCode: http.StatusBadRequest,
+			wantField:      "destination.url",
+		},
+		{
+			name:   "queue full",
+			method: http.MethodPost,
+			body: CreateTaskRequest{
+				ID:     "task-3",
+				Source: "test-app",
+				Destination: DestinationDTO{
+					Host: "localhost", Port: "9092", Topic: "my-topic",
+				},
+				MaxRetries:      3,
+				BaseDelay:       Delay(2 * time.Second),
+				DestinationType: "kafka",
+			},
+			createErr:      domain.ErrQueueFull,
+			wantStatusCode: http.StatusServiceUnavailable,
+		},
 		{
 			name:   "internal server error",
 			method: http.MethodPost,
@@ -78,7 +111,7 @@ func TestCreateTaskHandler_ServeHTTP(t *testing.T) {
 					Host: "localhost", Port: "9092", Topic: "my-topic",
 				},
 				MaxRetries:      3,
-				BaseDelay:       2,
+				BaseDelay:       Delay(2 * time.Second),
 				DestinationType: "kafka",
 			},
 			createErr:      errors.New("unexpected error"),
@@ -122,10 +155,78 @@ func TestCreateTaskHandler_ServeHTTP(t *testing.T) {
 					t.Fatalf("expected message %q, got %q", tt.wantMessage, resp.Message)
 				}
 			}
+			if tt.wantField != "" {
+				var resp ErrorResponse
+				if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+					t.Fatalf("failed to decode response: %v", err)
+				}
+				if resp.Field != tt.wantField || resp.Code != "VALIDATION_ERROR" {
+					t.Fatalf("expected a validation error for %q, got %+v", tt.wantField, resp)
+				}
+			}
 		})
 	}
 }
 
+func TestCreateTaskHandler_fanOut(t *testing.T) {
+	body, _ := json.Marshal(CreateTaskRequest{
+		ID:              "task-1",
+		Source:          "test-app",
+		Destination:     DestinationDTO{Topic: "orders"},
+		MaxRetries:      3,
+		BaseDelay:       Delay(2 * time.Second),
+		DestinationType: "kafka",
+		FanOutDestinations: []DestinationDTO{
+			{URL: "https://partner/hook"},
+			{Topic: "audit"},
+		},
+	})
+
+	handler := NewCreateTaskHandler(&mockTaskService{}, zap.NewNop())
+	req := httptest.NewRequest(http.MethodPost, "/tasks", bytes.NewReader(body))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp CreateTaskResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	want := []string{"task-1", "task-1#1", "task-1#2"}
+	if len(resp.TaskIDs) != len(want) {
+		t.Fatalf("expected task IDs %v, got %v", want, resp.TaskIDs)
+	}
+	for i := range want {
+		if resp.TaskIDs[i] != want[i] {
+			t.Fatalf("expected task IDs %v, got %v", want, resp.TaskIDs)
+		}
+	}
+}
+
+func TestCreateTaskHandler_generatedID(t *testing.T) {
+	body, _ := json.Marshal(CreateTaskRequest{
+		Source:             "test-app",
+		Destination:        DestinationDTO{Topic: "orders"},
+		DestinationType:    "kafka",
+		FanOutDestinations: []DestinationDTO{{Topic: "audit"}},
+	})
+
+	handler := NewCreateTaskHandler(&mockTaskService{generatedID: "01J8Z3K4M5"}, zap.NewNop())
+	req := httptest.NewRequest(http.MethodPost, "/tasks", bytes.NewReader(body))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	var resp CreateTaskResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.ID != "01J8Z3K4M5" || len(resp.TaskIDs) != 2 || resp.TaskIDs[1] != "01J8Z3K4M5#1" {
+		t.Fatalf("expected the generated ID in the response, got %+v", resp)
+	}
+}
+
 func TestHealthHandler_ServeHTTP(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -164,7 +265,7 @@ func TestHealthHandler_ServeHTTP(t *testing.T) {
 				checks = append(checks, healthCheckerAdapter{check: c})
 			}
 
-			handler := NewHealthHandler(toHealthCheckers(checks))
+			handler := NewHealthHandler(toHealthCheckers(checks), HealthPolicy{})
 
 			req := httptest.NewRequest(http.MethodGet, "/health", nil)
 			rec := httptest.NewRecorder()
@@ -185,3 +286,40 @@ func TestHealthHandler_ServeHTTP(t *testing.T) {
 		})
 	}
 }
+
+func TestRouter_LivenessAndReadiness(t *testing.T) {
+	redis := healthCheckerAdapter{check: mockHealthCheck{name: "redis"}}
+	stalled := healthCheckerAdapter{check: mockHealthCheck{name: "worker", err: errors.New("no recent worker heartbeat")}}
+
+	router := NewRouter(&mockTaskService{}, &mockMaintenanceService{}, &mockSLAService{}, &mockScheduleService{}, &mockQueueService{},
+		toHealthCheckers([]healthCheckerAdapter{redis}), zap.NewNop(), WithReadinessChecks(redis, stalled))
+
+	tests := []struct {
+		path           string
+		wantStatusCode int
+		wantStatus     string
+	}{
+		{path: "/livez", wantStatusCode: http.StatusOK, wantStatus: "alive"},
+		{path: "/health", wantStatusCode: http.StatusOK, wantStatus: "healthy"},
+		{path: "/readyz", wantStatusCode: http.StatusServiceUnavailable, wantStatus: "unhealthy"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatusCode {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatusCode, rec.Code, rec.Body.String())
+			}
+			var resp HealthResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if resp.Status != tt.wantStatus {
+				t.Fatalf("expected status %q, got %q", tt.wantStatus, resp.Status)
+			}
+		})
+	}
+}
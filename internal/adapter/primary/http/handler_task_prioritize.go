@@ -0,0 +1,77 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+	"github.com/ruudy-sib/rebound/internal/port/primary"
+)
+
+// PrioritizeTaskHandler handles POST /tasks/{id}/prioritize requests.
+type PrioritizeTaskHandler struct {
+	service primary.TaskService
+	logger  *zap.Logger
+}
+
+// NewPrioritizeTaskHandler creates a handler that raises a scheduled task
+// to priority in place.
+func NewPrioritizeTaskHandler(service primary.TaskService, logger *zap.Logger) *PrioritizeTaskHandler {
+	return &PrioritizeTaskHandler{
+		service: service,
+		logger:  logger.Named("prioritize-task-handler"),
+	}
+}
+
+// ServeHTTP marks the task as priority. The body is optional; with
+// {"now": true} the task is also made due immediately.
+func (h *PrioritizeTaskHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondJSON(w, http.StatusMethodNotAllowed, ErrorResponse{
+			Error: "method not allowed",
+			Code:  "METHOD_NOT_ALLOWED",
+		})
+		return
+	}
+
+	var req PrioritizeTaskRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		respondJSON(w, http.StatusBadRequest, ErrorResponse{
+			Error: "invalid request body",
+			Code:  "INVALID_BODY",
+		})
+		return
+	}
+	id := r.PathValue("id")
+
+	err := authorizeTask(r.Context(), id, h.service.GetTask)
+	var dueAt time.Time
+	if err == nil {
+		dueAt, err = h.service.PrioritizeTask(r.Context(), id, req.Now)
+	}
+	switch {
+	case errors.Is(err, errSourceNotAllowed):
+		respondForbidden(w, err)
+		return
+	case errors.Is(err, domain.ErrTaskNotFound):
+		respondJSON(w, http.StatusNotFound, ErrorResponse{
+			Error: "task is not scheduled",
+			Code:  "NOT_FOUND",
+		})
+		return
+	case err != nil:
+		h.logger.Error("failed to prioritize task", zap.Error(err), zap.String("task_id", id))
+		respondJSON(w, http.StatusInternalServerError, ErrorResponse{
+			Error: "failed to prioritize task",
+			Code:  "INTERNAL_ERROR",
+		})
+		return
+	}
+
+	respondJSON(w, http.StatusOK, PrioritizeTaskResponse{ID: id, IsPriority: true, DueAt: dueAt})
+}
@@ -0,0 +1,105 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+)
+
+func TestPrioritizeTaskHandler_ServeHTTP(t *testing.T) {
+	dueAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		method         string
+		body           string
+		taskErr        error
+		wantStatusCode int
+		wantBody       string
+		wantNow        bool
+	}{
+		{
+			name:           "without a body",
+			method:         http.MethodPost,
+			wantStatusCode: http.StatusOK,
+			wantBody:       `{"id":"task-1","is_priority":true,"due_at":"2024-03-01T12:00:00Z"}`,
+		},
+		{
+			name:           "due now",
+			method:         http.MethodPost,
+			body:           `{"now": true}`,
+			wantStatusCode: http.StatusOK,
+			wantNow:        true,
+		},
+		{
+			name:           "task not scheduled",
+			method:         http.MethodPost,
+			taskErr:        domain.ErrTaskNotFound,
+			wantStatusCode: http.StatusNotFound,
+			wantBody:       "NOT_FOUND",
+		},
+		{
+			name:           "reschedule failure",
+			method:         http.MethodPost,
+			taskErr:        domain.ErrScheduleFailed,
+			wantStatusCode: http.StatusInternalServerError,
+			wantBody:       "INTERNAL_ERROR",
+		},
+		{
+			name:           "invalid body",
+			method:         http.MethodPost,
+			body:           `{"now":`,
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       "INVALID_BODY",
+		},
+		{
+			name:           "method not allowed",
+			method:         http.MethodGet,
+			wantStatusCode: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &mockTaskService{dueAt: dueAt, taskErrs: map[string]error{"task-1": tt.taskErr}}
+			router := NewRouter(svc, &mockMaintenanceService{}, &mockSLAService{}, &mockScheduleService{}, &mockQueueService{}, nil, zap.NewNop())
+
+			req := httptest.NewRequest(tt.method, "/v1/tasks/task-1/prioritize", strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatusCode {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatusCode, rec.Code, rec.Body.String())
+			}
+			if !strings.Contains(rec.Body.String(), tt.wantBody) {
+				t.Fatalf("expected body to contain %q, got %s", tt.wantBody, rec.Body.String())
+			}
+			if svc.prioritizeNow != tt.wantNow {
+				t.Fatalf("expected now=%v, got %v", tt.wantNow, svc.prioritizeNow)
+			}
+		})
+	}
+}
+
+func TestPrioritizeTaskHandler_unversionedPath(t *testing.T) {
+	svc := &mockTaskService{taskErrs: map[string]error{"task-1": errors.New("boom")}}
+	router := NewRouter(svc, &mockMaintenanceService{}, &mockSLAService{}, &mockScheduleService{}, &mockQueueService{}, nil, zap.NewNop())
+
+	req := httptest.NewRequest(http.MethodPost, "/tasks/task-1/prioritize", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("expected 500, got %d", rec.Code)
+	}
+	if len(svc.prioritized) != 1 || svc.prioritized[0] != "task-1" {
+		t.Fatalf("expected task-1 to be prioritized, got %v", svc.prioritized)
+	}
+}
@@ -0,0 +1,99 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+	"github.com/ruudy-sib/rebound/internal/port/primary"
+)
+
+// RescheduleTaskHandler handles PATCH /tasks/{id}/schedule requests.
+type RescheduleTaskHandler struct {
+	service primary.ScheduleService
+	tasks   primary.TaskService
+	logger  *zap.Logger
+}
+
+// NewRescheduleTaskHandler creates a handler that moves one task's next
+// attempt. tasks looks the task up for source authorization.
+func NewRescheduleTaskHandler(service primary.ScheduleService, tasks primary.TaskService, logger *zap.Logger) *RescheduleTaskHandler {
+	return &RescheduleTaskHandler{
+		service: service,
+		tasks:   tasks,
+		logger:  logger.Named("reschedule-task-handler"),
+	}
+}
+
+// ServeHTTP moves the task to due_at, or by shift from its current due
+// time, and reports the old and new due times.
+func (h *RescheduleTaskHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPatch {
+		respondJSON(w, http.StatusMethodNotAllowed, ErrorResponse{
+			Error: "method not allowed",
+			Code:  "METHOD_NOT_ALLOWED",
+		})
+		return
+	}
+
+	var req RescheduleTaskRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondJSON(w, http.StatusBadRequest, ErrorResponse{
+			Error: "invalid request body",
+			Code:  "INVALID_BODY",
+		})
+		return
+	}
+
+	change := entity.Reschedule{At: req.DueAt}
+	if req.Shift != "" {
+		by, err := time.ParseDuration(req.Shift)
+		if err != nil {
+			respondJSON(w, http.StatusBadRequest, ErrorResponse{
+				Error: fmt.Sprintf("invalid shift %q: must be a duration such as \"2h\"", req.Shift),
+				Code:  "VALIDATION_ERROR",
+			})
+			return
+		}
+		change.By = by
+	}
+	id := r.PathValue("id")
+
+	err := authorizeTask(r.Context(), id, h.tasks.GetTask)
+	var previous, next time.Time
+	if err == nil {
+		previous, next, err = h.service.Reschedule(r.Context(), id, change)
+	}
+	switch {
+	case errors.Is(err, errSourceNotAllowed):
+		respondForbidden(w, err)
+		return
+	case errors.Is(err, domain.ErrInvalidQuery):
+		respondJSON(w, http.StatusBadRequest, ErrorResponse{
+			Error: err.Error(),
+			Code:  "VALIDATION_ERROR",
+		})
+		return
+	case errors.Is(err, domain.ErrTaskNotFound):
+		respondJSON(w, http.StatusNotFound, ErrorResponse{
+			Error: "task is not scheduled",
+			Code:  "NOT_FOUND",
+		})
+		return
+	case err != nil:
+		h.logger.Error("failed to reschedule task", zap.Error(err), zap.String("task_id", id))
+		respondJSON(w, http.StatusInternalServerError, ErrorResponse{
+			Error: "internal server error",
+			Code:  "INTERNAL_ERROR",
+		})
+		return
+	}
+
+	respondJSON(w, http.StatusOK, RescheduleTaskResponse{ID: id, PreviousDueAt: previous, DueAt: next})
+}
@@ -0,0 +1,115 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+func TestRescheduleTaskHandler_ServeHTTP(t *testing.T) {
+	previous := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	next := time.Date(2024, 3, 1, 14, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		method         string
+		body           string
+		err            error
+		wantStatusCode int
+		wantBody       string
+		wantChange     entity.Reschedule
+	}{
+		{
+			name:           "absolute due time",
+			method:         http.MethodPatch,
+			body:           `{"due_at": "2024-03-01T14:00:00Z"}`,
+			wantStatusCode: http.StatusOK,
+			wantBody:       `{"id":"task-1","previous_due_at":"2024-03-01T12:00:00Z","due_at":"2024-03-01T14:00:00Z"}`,
+			wantChange:     entity.Reschedule{At: next},
+		},
+		{
+			name:           "relative shift",
+			method:         http.MethodPatch,
+			body:           `{"shift": "-30m"}`,
+			wantStatusCode: http.StatusOK,
+			wantChange:     entity.Reschedule{By: -30 * time.Minute},
+		},
+		{
+			name:           "invalid shift",
+			method:         http.MethodPatch,
+			body:           `{"shift": "soon"}`,
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       "VALIDATION_ERROR",
+		},
+		{
+			name:           "rejected change",
+			method:         http.MethodPatch,
+			body:           `{}`,
+			err:            domain.ErrInvalidQuery,
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       "VALIDATION_ERROR",
+		},
+		{
+			name:           "task not scheduled",
+			method:         http.MethodPatch,
+			body:           `{"shift": "1h"}`,
+			err:            domain.ErrTaskNotFound,
+			wantStatusCode: http.StatusNotFound,
+			wantBody:       "NOT_FOUND",
+			wantChange:     entity.Reschedule{By: time.Hour},
+		},
+		{
+			name:           "store failure",
+			method:         http.MethodPatch,
+			body:           `{"shift": "1h"}`,
+			err:            errors.New("redis down"),
+			wantStatusCode: http.StatusInternalServerError,
+			wantBody:       "INTERNAL_ERROR",
+			wantChange:     entity.Reschedule{By: time.Hour},
+		},
+		{
+			name:           "invalid body",
+			method:         http.MethodPatch,
+			body:           `{"due_at":`,
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       "INVALID_BODY",
+		},
+		{
+			name:           "method not allowed",
+			method:         http.MethodPost,
+			wantStatusCode: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &mockScheduleService{previousDueAt: previous, nextDueAt: next, err: tt.err}
+			router := NewRouter(&mockTaskService{}, &mockMaintenanceService{}, &mockSLAService{}, svc, &mockQueueService{}, nil, zap.NewNop())
+
+			req := httptest.NewRequest(tt.method, "/v1/tasks/task-1/schedule", strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatusCode {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatusCode, rec.Code, rec.Body.String())
+			}
+			if !strings.Contains(rec.Body.String(), tt.wantBody) {
+				t.Fatalf("expected body to contain %q, got %s", tt.wantBody, rec.Body.String())
+			}
+			if svc.rescheduled != tt.wantChange {
+				t.Fatalf("expected change %+v, got %+v", tt.wantChange, svc.rescheduled)
+			}
+			if tt.wantChange != (entity.Reschedule{}) && svc.rescheduledID != "task-1" {
+				t.Fatalf("expected task-1, got %q", svc.rescheduledID)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+	"github.com/ruudy-sib/rebound/internal/port/primary"
+)
+
+// DeliveryResultHandler handles GET /tasks/{id}/result requests.
+type DeliveryResultHandler struct {
+	service primary.TaskService
+	logger  *zap.Logger
+}
+
+// NewDeliveryResultHandler creates a handler returning the recorded result
+// of a task's successful delivery.
+func NewDeliveryResultHandler(service primary.TaskService, logger *zap.Logger) *DeliveryResultHandler {
+	return &DeliveryResultHandler{
+		service: service,
+		logger:  logger.Named("delivery-result-handler"),
+	}
+}
+
+// ServeHTTP returns the delivery result, or 404 if none is recorded: the
+// task was not delivered yet, or its result expired. Results of tasks whose
+// source the caller's API key may not use are refused with 403.
+func (h *DeliveryResultHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondJSON(w, http.StatusMethodNotAllowed, ErrorResponse{
+			Error: "method not allowed",
+			Code:  "METHOD_NOT_ALLOWED",
+		})
+		return
+	}
+	id := r.PathValue("id")
+
+	result, err := h.service.DeliveryResult(r.Context(), id)
+	if err == nil {
+		err = authorizeSource(r.Context(), result.Source)
+	}
+	switch {
+	case errors.Is(err, errSourceNotAllowed):
+		respondForbidden(w, err)
+		return
+	case errors.Is(err, domain.ErrTaskNotFound):
+		respondJSON(w, http.StatusNotFound, ErrorResponse{
+			Error: "no delivery result recorded for task",
+			Code:  "NOT_FOUND",
+		})
+		return
+	case err != nil:
+		h.logger.Error("failed to read delivery result", zap.Error(err), zap.String("task_id", id))
+		respondJSON(w, http.StatusInternalServerError, ErrorResponse{
+			Error: "failed to read delivery result",
+			Code:  "INTERNAL_ERROR",
+		})
+		return
+	}
+
+	resp := DeliveryResultResponse{
+		ID:              result.TaskID,
+		DestinationType: string(result.DestinationType),
+		Destination:     result.Destination,
+		Attempts:        result.Attempts,
+		DeliveredAt:     result.DeliveredAt,
+		HTTPStatus:      result.Receipt.HTTPStatus,
+		ResponseBody:    result.Receipt.ResponseBody,
+	}
+	if result.Receipt.Offset >= 0 {
+		resp.Partition = &result.Receipt.Partition
+		resp.Offset = &result.Receipt.Offset
+	}
+	respondJSON(w, http.StatusOK, resp)
+}
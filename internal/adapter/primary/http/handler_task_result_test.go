@@ -0,0 +1,97 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+func TestDeliveryResultHandler_ServeHTTP(t *testing.T) {
+	deliveredAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	results := map[string]*entity.DeliveryResult{
+		"http-task": {
+			TaskID:          "http-task",
+			DestinationType: entity.DestinationTypeHTTP,
+			Destination:     "https://example.com/hook",
+			Attempts:        2,
+			DeliveredAt:     deliveredAt,
+			Receipt:         entity.DeliveryReceipt{HTTPStatus: 202, ResponseBody: `{"ok":true}`, Partition: -1, Offset: -1},
+		},
+		"kafka-task": {
+			TaskID:          "kafka-task",
+			DestinationType: entity.DestinationTypeKafka,
+			Destination:     "orders",
+			Attempts:        1,
+			DeliveredAt:     deliveredAt,
+			Receipt:         entity.DeliveryReceipt{Partition: 3, Offset: 0},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		method         string
+		id             string
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name:           "http delivery",
+			method:         http.MethodGet,
+			id:             "http-task",
+			wantStatusCode: http.StatusOK,
+			wantBody:       `{"id":"http-task","destination_type":"http","destination":"https://example.com/hook","attempts":2,"delivered_at":"2024-03-01T12:00:00Z","http_status":202,"response_body":"{\"ok\":true}"}`,
+		},
+		{
+			name:           "kafka delivery",
+			method:         http.MethodGet,
+			id:             "kafka-task",
+			wantStatusCode: http.StatusOK,
+			wantBody:       `"partition":3,"offset":0}`,
+		},
+		{
+			name:           "no result recorded",
+			method:         http.MethodGet,
+			id:             "unknown",
+			wantStatusCode: http.StatusNotFound,
+			wantBody:       "NOT_FOUND",
+		},
+		{
+			name:           "store failure",
+			method:         http.MethodGet,
+			id:             "broken",
+			wantStatusCode: http.StatusInternalServerError,
+			wantBody:       "INTERNAL_ERROR",
+		},
+		{
+			name:           "method not allowed",
+			method:         http.MethodPost,
+			id:             "http-task",
+			wantStatusCode: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &mockTaskService{results: results, taskErrs: map[string]error{"broken": errors.New("redis down")}}
+			router := NewRouter(svc, &mockMaintenanceService{}, &mockSLAService{}, &mockScheduleService{}, &mockQueueService{}, nil, zap.NewNop())
+
+			req := httptest.NewRequest(tt.method, "/v1/tasks/"+tt.id+"/result", nil)
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatusCode {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatusCode, rec.Code, rec.Body.String())
+			}
+			if !strings.Contains(rec.Body.String(), tt.wantBody) {
+				t.Fatalf("expected body to contain %q, got %s", tt.wantBody, rec.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+	"github.com/ruudy-sib/rebound/internal/port/primary"
+)
+
+// UsageHandler handles GET /admin/usage requests.
+type UsageHandler struct {
+	service primary.UsageService
+	logger  *zap.Logger
+}
+
+// NewUsageHandler creates a handler reporting per-client usage.
+func NewUsageHandler(service primary.UsageService, logger *zap.Logger) *UsageHandler {
+	return &UsageHandler{
+		service: service,
+		logger:  logger.Named("usage-handler"),
+	}
+}
+
+// ServeHTTP reports the delivered and retried attempts per client in the
+// billing period given by the "period" query parameter, the current month
+// by default. "client_id" narrows the report to one client.
+func (h *UsageHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondJSON(w, http.StatusMethodNotAllowed, ErrorResponse{
+			Error: "method not allowed",
+			Code:  "METHOD_NOT_ALLOWED",
+		})
+		return
+	}
+
+	period := r.URL.Query().Get("period")
+	if period == "" {
+		period = entity.UsagePeriod(time.Now())
+	}
+	clientID, filtered := r.URL.Query()["client_id"]
+
+	usage, err := h.service.Usage(r.Context(), period)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidQuery) {
+			respondJSON(w, http.StatusBadRequest, ErrorResponse{
+				Error: err.Error(),
+				Code:  "VALIDATION_ERROR",
+			})
+			return
+		}
+		h.logger.Error("failed to read client usage", zap.Error(err))
+		respondJSON(w, http.StatusInternalServerError, ErrorResponse{
+			Error: "internal server error",
+			Code:  "INTERNAL_ERROR",
+		})
+		return
+	}
+
+	resp := UsageResponse{Period: period, Clients: make([]ClientUsageDTO, 0, len(usage))}
+	for _, u := range usage {
+		if filtered && u.ClientID != clientID[0] {
+			continue
+		}
+		resp.Clients = append(resp.Clients, ClientUsageDTO{
+			ClientID:  u.ClientID,
+			Delivered: u.Delivered,
+			Retried:   u.Retried,
+		})
+	}
+	respondJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,115 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+func TestUsageHandler_ServeHTTP(t *testing.T) {
+	usage := []entity.ClientUsage{
+		{ClientID: "billing", Period: "2024-05", Delivered: 40, Retried: 3},
+		{ClientID: "notifier", Period: "2024-05", Delivered: 7},
+	}
+
+	tests := []struct {
+		name           string
+		method         string
+		query          string
+		service        *mockUsageService
+		wantStatusCode int
+		wantPeriod     string
+		wantBody       []string
+		wantNotBody    []string
+	}{
+		{
+			name:           "reports every client in the period",
+			method:         http.MethodGet,
+			query:          "?period=2024-05",
+			service:        &mockUsageService{usage: usage},
+			wantStatusCode: http.StatusOK,
+			wantPeriod:     "2024-05",
+			wantBody: []string{
+				`"period":"2024-05"`,
+				`{"client_id":"billing","delivered":40,"retried":3}`,
+				`{"client_id":"notifier","delivered":7,"retried":0}`,
+			},
+		},
+		{
+			name:           "defaults to the current month",
+			method:         http.MethodGet,
+			service:        &mockUsageService{},
+			wantStatusCode: http.StatusOK,
+			wantPeriod:     entity.UsagePeriod(time.Now()),
+			wantBody:       []string{`"clients":[]`},
+		},
+		{
+			name:           "filters by client",
+			method:         http.MethodGet,
+			query:          "?period=2024-05&client_id=notifier",
+			service:        &mockUsageService{usage: usage},
+			wantStatusCode: http.StatusOK,
+			wantPeriod:     "2024-05",
+			wantBody:       []string{`"client_id":"notifier"`},
+			wantNotBody:    []string{`"client_id":"billing"`},
+		},
+		{
+			name:           "invalid period",
+			method:         http.MethodGet,
+			query:          "?period=may",
+			service:        &mockUsageService{err: fmt.Errorf("%w: bad period", domain.ErrInvalidQuery)},
+			wantStatusCode: http.StatusBadRequest,
+			wantPeriod:     "may",
+		},
+		{
+			name:           "store error",
+			method:         http.MethodGet,
+			service:        &mockUsageService{err: errors.New("redis down")},
+			wantStatusCode: http.StatusInternalServerError,
+			wantPeriod:     entity.UsagePeriod(time.Now()),
+		},
+		{
+			name:           "method not allowed",
+			method:         http.MethodPost,
+			service:        &mockUsageService{},
+			wantStatusCode: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := NewRouter(&mockTaskService{}, &mockMaintenanceService{}, &mockSLAService{}, &mockScheduleService{}, &mockQueueService{}, nil, zap.NewNop(),
+				WithUsage(tt.service))
+
+			req := httptest.NewRequest(tt.method, "/v1/admin/usage"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatusCode {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatusCode, rec.Code, rec.Body.String())
+			}
+			if tt.service.period != tt.wantPeriod {
+				t.Fatalf("expected period %q, got %q", tt.wantPeriod, tt.service.period)
+			}
+			for _, want := range tt.wantBody {
+				if !strings.Contains(rec.Body.String(), want) {
+					t.Fatalf("expected body to contain %s, got %s", want, rec.Body.String())
+				}
+			}
+			for _, unwanted := range tt.wantNotBody {
+				if strings.Contains(rec.Body.String(), unwanted) {
+					t.Fatalf("expected body not to contain %s, got %s", unwanted, rec.Body.String())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package http
+
+import (
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/port/primary"
+)
+
+// WorkersHandler handles GET /admin/workers requests.
+type WorkersHandler struct {
+	service primary.WorkerService
+	logger  *zap.Logger
+}
+
+// NewWorkersHandler creates a handler listing worker instances.
+func NewWorkersHandler(service primary.WorkerService, logger *zap.Logger) *WorkersHandler {
+	return &WorkersHandler{
+		service: service,
+		logger:  logger.Named("workers-handler"),
+	}
+}
+
+// ServeHTTP lists every registered worker instance with its heartbeat age
+// and throughput.
+func (h *WorkersHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondJSON(w, http.StatusMethodNotAllowed, ErrorResponse{
+			Error: "method not allowed",
+			Code:  "METHOD_NOT_ALLOWED",
+		})
+		return
+	}
+
+	workers, err := h.service.Workers(r.Context())
+	if err != nil {
+		h.logger.Error("failed to list workers", zap.Error(err))
+		respondJSON(w, http.StatusInternalServerError, ErrorResponse{
+			Error: "internal server error",
+			Code:  "INTERNAL_ERROR",
+		})
+		return
+	}
+
+	now := time.Now()
+	resp := WorkersResponse{Workers: make([]WorkerDTO, len(workers))}
+	for i, wk := range workers {
+		dto := WorkerDTO{
+			ID:                  wk.ID,
+			LastHeartbeat:       wk.LastBeat.UTC(),
+			HeartbeatAgeSeconds: wk.HeartbeatAge(now).Truncate(time.Second).Seconds(),
+			Stale:               wk.Stale,
+			Processed:           wk.Processed,
+			Errors:              wk.Errors,
+			ThroughputPerMinute: wk.Throughput(now),
+			Shards:              wk.Shards,
+		}
+		if !wk.StartedAt.IsZero() {
+			started := wk.StartedAt.UTC()
+			dto.StartedAt = &started
+		}
+		resp.Workers[i] = dto
+	}
+	respondJSON(w, http.StatusOK, resp)
+}
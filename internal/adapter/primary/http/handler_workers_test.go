@@ -0,0 +1,76 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+func TestWorkersHandler_ServeHTTP(t *testing.T) {
+	beat := time.Now().Add(-3 * time.Second)
+
+	tests := []struct {
+		name           string
+		method         string
+		service        *mockWorkerService
+		wantStatusCode int
+		wantBody       []string
+	}{
+		{
+			name:   "lists workers",
+			method: http.MethodGet,
+			service: &mockWorkerService{workers: []entity.WorkerInstance{
+				{ID: "pod-a", LastBeat: beat, StartedAt: beat.Add(-time.Hour), Processed: 600, Errors: 6, Shards: []int{0, 3}},
+				{ID: "pod-b", LastBeat: beat.Add(-time.Minute), Stale: true},
+			}},
+			wantStatusCode: http.StatusOK,
+			wantBody: []string{
+				`"id":"pod-a"`,
+				`"heartbeat_age_seconds":3`,
+				`"processed":600,"errors":6`,
+				`"shards":[0,3]`,
+				`"id":"pod-b"`,
+				`"stale":true`,
+			},
+		},
+		{
+			name:           "registry error",
+			method:         http.MethodGet,
+			service:        &mockWorkerService{err: errors.New("redis down")},
+			wantStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name:           "method not allowed",
+			method:         http.MethodPost,
+			service:        &mockWorkerService{},
+			wantStatusCode: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := NewRouter(&mockTaskService{}, &mockMaintenanceService{}, &mockSLAService{}, &mockScheduleService{}, &mockQueueService{}, nil, zap.NewNop(),
+				WithWorkers(tt.service))
+
+			req := httptest.NewRequest(tt.method, "/v1/admin/workers", nil)
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatusCode {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatusCode, rec.Code, rec.Body.String())
+			}
+			for _, want := range tt.wantBody {
+				if !strings.Contains(rec.Body.String(), want) {
+					t.Fatalf("expected body to contain %s, got %s", want, rec.Body.String())
+				}
+			}
+		})
+	}
+}
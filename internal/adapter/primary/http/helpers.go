@@ -2,7 +2,12 @@ package http
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
+
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
 )
 
 // respondJSON writes a JSON response with the given status code and payload.
@@ -12,3 +17,43 @@ func respondJSON(w http.ResponseWriter, status int, data interface{}) {
 	// Encoding errors are not recoverable at this point, so we ignore the return.
 	_ = json.NewEncoder(w).Encode(data)
 }
+
+// queueFullRetryAfter is the Retry-After, in seconds, sent with a queue full
+// response. It gives the worker time to drain before clients retry.
+const queueFullRetryAfter = "30"
+
+// respondQueueFull rejects a task creation because the pending cap is reached.
+func respondQueueFull(w http.ResponseWriter, err error) {
+	w.Header().Set("Retry-After", queueFullRetryAfter)
+	respondJSON(w, http.StatusServiceUnavailable, ErrorResponse{
+		Error: err.Error(),
+		Code:  "QUEUE_FULL",
+	})
+}
+
+// respondValidationError rejects an invalid task, naming the offending
+// field when validation pinned one down.
+func respondValidationError(w http.ResponseWriter, err error) {
+	resp := ErrorResponse{
+		Error: err.Error(),
+		Code:  "VALIDATION_ERROR",
+	}
+	var fieldErr *entity.FieldError
+	if errors.As(err, &fieldErr) {
+		resp.Field = fieldErr.Field
+	}
+	respondJSON(w, http.StatusBadRequest, resp)
+}
+
+// queryLimit reads the limit query parameter, defaulting to fallback.
+func queryLimit(r *http.Request, fallback int) (int, error) {
+	v := r.URL.Query().Get("limit")
+	if v == "" {
+		return fallback, nil
+	}
+	limit, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, fmt.Errorf("invalid limit %q: must be an integer", v)
+	}
+	return limit, nil
+}
@@ -2,7 +2,9 @@ package http
 
 import (
 	"context"
+	"time"
 
+	"github.com/ruudy-sib/rebound/internal/domain"
 	"github.com/ruudy-sib/rebound/internal/domain/entity"
 	"github.com/ruudy-sib/rebound/internal/port/secondary"
 )
@@ -13,16 +15,322 @@ type mockTaskService struct {
 	processErr     error
 	createCalled   int
 	processCalled  int
+
+	pacedTasks  []*entity.Task
+	pacedWindow time.Duration
+
+	generatedID string // given by CreateTask to a task without an ID
+
+	tasks     map[string]*entity.Task // returned by GetTask
+	held      map[string]*entity.Task // returned by GetCancelledTask
+	taskErrs  map[string]error
+	cancelled []string
+	restored  []string
+
+	prioritized   []string
+	prioritizeNow bool
+	dueAt         time.Time
+
+	results map[string]*entity.DeliveryResult
+
+	deadLetters []entity.DeadLetter
+	listErr     error
+	listLimit   int
+	listCursor  entity.Cursor
+	listNext    entity.Cursor
+	requeued    []string
+	overflow    entity.OverflowStats
+}
+
+func (m *mockTaskService) CreateTask(_ context.Context, task *entity.Task) error {
+	m.createCalled++
+	if task.ID == "" {
+		task.ID = m.generatedID
+	}
+	return m.createErr
 }
 
-func (m *mockTaskService) CreateTask(_ context.Context, _ *entity.Task) error {
+func (m *mockTaskService) CreateTaskAt(_ context.Context, _ *entity.Task, _ time.Time) error {
 	m.createCalled++
 	return m.createErr
 }
 
-func (m *mockTaskService) ProcessDueTasks(_ context.Context) error {
+func (m *mockTaskService) CreateTasksPaced(_ context.Context, tasks []*entity.Task, window time.Duration) error {
+	m.pacedTasks = tasks
+	m.pacedWindow = window
+	return m.createErr
+}
+
+func (m *mockTaskService) GetTask(_ context.Context, taskID string) (*entity.Task, error) {
+	task, ok := m.tasks[taskID]
+	if !ok {
+		return nil, domain.ErrTaskNotFound
+	}
+	return task, nil
+}
+
+func (m *mockTaskService) CancelTask(_ context.Context, taskID string) error {
+	m.cancelled = append(m.cancelled, taskID)
+	if m.taskErrs != nil {
+		return m.taskErrs[taskID]
+	}
+	return nil
+}
+
+func (m *mockTaskService) GetCancelledTask(_ context.Context, taskID string) (*entity.Task, error) {
+	task, ok := m.held[taskID]
+	if !ok {
+		return nil, domain.ErrTaskNotFound
+	}
+	return task, nil
+}
+
+func (m *mockTaskService) RestoreTask(_ context.Context, taskID string) error {
+	m.restored = append(m.restored, taskID)
+	if m.taskErrs != nil {
+		return m.taskErrs[taskID]
+	}
+	return nil
+}
+
+func (m *mockTaskService) DeliveryResult(_ context.Context, taskID string) (*entity.DeliveryResult, error) {
+	if err := m.taskErrs[taskID]; err != nil {
+		return nil, err
+	}
+	result, ok := m.results[taskID]
+	if !ok {
+		return nil, domain.ErrTaskNotFound
+	}
+	return result, nil
+}
+
+func (m *mockTaskService) PrioritizeTask(_ context.Context, taskID string, now bool) (time.Time, error) {
+	m.prioritized = append(m.prioritized, taskID)
+	m.prioritizeNow = now
+	if m.taskErrs != nil {
+		return m.dueAt, m.taskErrs[taskID]
+	}
+	return m.dueAt, nil
+}
+
+func (m *mockTaskService) ListDeadLetters(_ context.Context, after entity.Cursor, limit int) ([]entity.DeadLetter, entity.Cursor, error) {
+	m.listCursor = after
+	m.listLimit = limit
+	return m.deadLetters, m.listNext, m.listErr
+}
+
+func (m *mockTaskService) OverflowStats() entity.OverflowStats {
+	return m.overflow
+}
+
+func (m *mockTaskService) RequeueDeadLetter(_ context.Context, taskID string) error {
+	m.requeued = append(m.requeued, taskID)
+	if m.taskErrs != nil {
+		return m.taskErrs[taskID]
+	}
+	return nil
+}
+
+func (m *mockTaskService) ProcessDueTasks(_ context.Context) (entity.ProcessResult, error) {
+	m.processCalled++
+	return entity.ProcessResult{}, m.processErr
+}
+
+func (m *mockTaskService) ProcessDueTasksIn(_ context.Context, _ string) (entity.ProcessResult, error) {
 	m.processCalled++
-	return m.processErr
+	return entity.ProcessResult{}, m.processErr
+}
+
+// mockMaintenanceService implements primary.MaintenanceService for testing.
+type mockMaintenanceService struct {
+	err     error
+	windows []entity.MaintenanceWindow
+
+	started     string
+	startedTill time.Time
+	ended       string
+}
+
+func (m *mockMaintenanceService) StartMaintenance(_ context.Context, destination string, until time.Time) error {
+	m.started = destination
+	m.startedTill = until
+	return m.err
+}
+
+func (m *mockMaintenanceService) EndMaintenance(_ context.Context, destination string) error {
+	m.ended = destination
+	return m.err
+}
+
+func (m *mockMaintenanceService) ListMaintenance(_ context.Context) ([]entity.MaintenanceWindow, error) {
+	return m.windows, m.err
+}
+
+// mockSLAService implements primary.SLAService for testing.
+type mockSLAService struct {
+	stats []entity.TimeToSuccessStats
+	err   error
+}
+
+func (m *mockSLAService) TimeToSuccess(_ context.Context) ([]entity.TimeToSuccessStats, error) {
+	return m.stats, m.err
+}
+
+// mockScheduleService implements primary.ScheduleService for testing.
+type mockScheduleService struct {
+	forecast *entity.ScheduleForecast
+	err      error
+
+	window time.Duration
+
+	shifted     int
+	shiftFilter entity.TaskFilter
+	shiftBy     time.Duration
+
+	upcoming       []entity.ScheduledTask
+	upcomingLimit  int
+	upcomingCursor entity.Cursor
+	upcomingNext   entity.Cursor
+
+	destination string
+
+	rescheduledID string
+	rescheduled   entity.Reschedule
+	previousDueAt time.Time
+	nextDueAt     time.Time
+}
+
+func (m *mockScheduleService) Upcoming(_ context.Context, after entity.Cursor, limit int) ([]entity.ScheduledTask, entity.Cursor, error) {
+	m.upcomingCursor = after
+	m.upcomingLimit = limit
+	return m.upcoming, m.upcomingNext, m.err
+}
+
+func (m *mockScheduleService) ByDestination(_ context.Context, destination string, limit int) ([]entity.ScheduledTask, error) {
+	m.destination = destination
+	m.upcomingLimit = limit
+	return m.upcoming, m.err
+}
+
+func (m *mockScheduleService) Shift(_ context.Context, filter entity.TaskFilter, by time.Duration) (int, error) {
+	m.shiftFilter = filter
+	m.shiftBy = by
+	return m.shifted, m.err
+}
+
+func (m *mockScheduleService) Reschedule(_ context.Context, taskID string, change entity.Reschedule) (time.Time, time.Time, error) {
+	m.rescheduledID = taskID
+	m.rescheduled = change
+	return m.previousDueAt, m.nextDueAt, m.err
+}
+
+func (m *mockScheduleService) Forecast(_ context.Context, window time.Duration) (*entity.ScheduleForecast, error) {
+	m.window = window
+	return m.forecast, m.err
+}
+
+// mockQueueService implements primary.QueueService for testing.
+type mockQueueService struct {
+	stats  []entity.QueueStats
+	plan   *entity.PurgePlan
+	purged int64
+	err    error
+
+	queue entity.Queue
+	token string
+
+	corrupt *entity.CorruptReport
+	limit   int
+	deleted []string
+}
+
+func (m *mockQueueService) Stats(_ context.Context) ([]entity.QueueStats, error) {
+	return m.stats, m.err
+}
+
+func (m *mockQueueService) PreparePurge(_ context.Context, queue entity.Queue) (*entity.PurgePlan, error) {
+	m.queue = queue
+	return m.plan, m.err
+}
+
+func (m *mockQueueService) Purge(_ context.Context, queue entity.Queue, token string) (int64, error) {
+	m.queue = queue
+	m.token = token
+	return m.purged, m.err
+}
+
+func (m *mockQueueService) ListCorrupt(_ context.Context, limit int) (*entity.CorruptReport, error) {
+	m.limit = limit
+	return m.corrupt, m.err
+}
+
+func (m *mockQueueService) DeleteCorrupt(_ context.Context, id string) error {
+	m.deleted = append(m.deleted, id)
+	return m.err
+}
+
+// mockJanitorService implements primary.JanitorService for testing.
+type mockJanitorService struct {
+	report *entity.RepairReport
+	err    error
+}
+
+func (m *mockJanitorService) Reconcile(_ context.Context, _ time.Duration) (*entity.RepairReport, error) {
+	return m.report, m.err
+}
+
+func (m *mockJanitorService) ReconcileNow(_ context.Context) (*entity.RepairReport, error) {
+	return m.report, m.err
+}
+
+// mockDrainer implements primary.Drainer for testing.
+type mockDrainer struct {
+	status  entity.DrainStatus
+	drained bool
+}
+
+func (m *mockDrainer) Drain() entity.DrainStatus {
+	m.drained = true
+	if !m.status.Draining {
+		m.status.Draining = true
+		m.status.Since = time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	}
+	return m.status
+}
+
+func (m *mockDrainer) DrainStatus() entity.DrainStatus {
+	return m.status
+}
+
+// mockProducerStatsService implements primary.ProducerStatsService for testing.
+type mockProducerStatsService struct {
+	stats []entity.ProducerStats
+}
+
+func (m *mockProducerStatsService) ProducerStats() []entity.ProducerStats {
+	return m.stats
+}
+
+// mockWorkerService implements primary.WorkerService for testing.
+type mockWorkerService struct {
+	workers []entity.WorkerInstance
+	err     error
+}
+
+func (m *mockWorkerService) Workers(_ context.Context) ([]entity.WorkerInstance, error) {
+	return m.workers, m.err
+}
+
+// mockUsageService implements primary.UsageService for testing.
+type mockUsageService struct {
+	usage  []entity.ClientUsage
+	err    error
+	period string
+}
+
+func (m *mockUsageService) Usage(_ context.Context, period string) ([]entity.ClientUsage, error) {
+	m.period = period
+	return m.usage, m.err
 }
 
 // mockHealthCheck is a test double for health checks.
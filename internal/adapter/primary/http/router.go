@@ -9,21 +9,262 @@ import (
 	"github.com/ruudy-sib/rebound/internal/port/secondary"
 )
 
+// RouterOption configures optional router behaviour.
+type RouterOption func(*routerOptions)
+
+type routerOptions struct {
+	sourceAllowlist SourceAllowlist
+	requestSigning  RequestSigning
+	readinessChecks []secondary.HealthChecker
+	healthPolicy    HealthPolicy
+	janitorService  primary.JanitorService
+	drainer         primary.Drainer
+	producerStats   primary.ProducerStatsService
+	workerService   primary.WorkerService
+	usageService    primary.UsageService
+}
+
+// WithSourceAllowlist requires an API key on task endpoints and restricts
+// each key to creating, and acting on, tasks of the sources listed for it.
+// An empty allowlist leaves the endpoints open.
+func WithSourceAllowlist(allowlist SourceAllowlist) RouterOption {
+	return func(o *routerOptions) {
+		o.sourceAllowlist = allowlist
+	}
+}
+
+// WithRequestSigning requires every API request to carry an HMAC signature
+// from a caller listed in signing.Secrets. Health endpoints and the
+// dashboard page stay open. Empty secrets leave signing disabled.
+func WithRequestSigning(signing RequestSigning) RouterOption {
+	return func(o *routerOptions) {
+		o.requestSigning = signing
+	}
+}
+
+// WithReadinessChecks sets the checks /readyz runs, such as whether the
+// worker loop is processing. They are kept apart from the health checks so
+// that checks spanning every replica do not gate a single pod's readiness.
+func WithReadinessChecks(checks ...secondary.HealthChecker) RouterOption {
+	return func(o *routerOptions) {
+		o.readinessChecks = append(o.readinessChecks, checks...)
+	}
+}
+
+// WithHealthPolicy sets the timeout and result caching of the /health and
+// /readyz checks.
+func WithHealthPolicy(policy HealthPolicy) RouterOption {
+	return func(o *routerOptions) {
+		o.healthPolicy = policy
+	}
+}
+
+// WithJanitor exposes POST /admin/reconcile, which runs a state repair
+// pass on demand. Without it the endpoint is not registered.
+func WithJanitor(service primary.JanitorService) RouterOption {
+	return func(o *routerOptions) {
+		o.janitorService = service
+	}
+}
+
+// WithDrainer exposes POST /admin/drain and GET /admin/drain/status, which
+// drain this instance's worker ahead of shutdown. Without it the endpoints
+// are not registered.
+func WithDrainer(drainer primary.Drainer) RouterOption {
+	return func(o *routerOptions) {
+		o.drainer = drainer
+	}
+}
+
+// WithProducerStats exposes GET /admin/producers, which reports producer
+// performance per destination. Without it the endpoint is not registered.
+func WithProducerStats(service primary.ProducerStatsService) RouterOption {
+	return func(o *routerOptions) {
+		o.producerStats = service
+	}
+}
+
+// WithWorkers exposes GET /admin/workers, which lists the worker instances
+// sharing the schedule. Without it the endpoint is not registered.
+func WithWorkers(service primary.WorkerService) RouterOption {
+	return func(o *routerOptions) {
+		o.workerService = service
+	}
+}
+
+// WithUsage exposes GET /admin/usage, which reports per-client usage for
+// billing. Without it the endpoint is not registered.
+func WithUsage(service primary.UsageService) RouterOption {
+	return func(o *routerOptions) {
+		o.usageService = service
+	}
+}
+
 // NewRouter creates an HTTP mux with all application routes registered.
 func NewRouter(
 	taskService primary.TaskService,
+	maintenanceService primary.MaintenanceService,
+	slaService primary.SLAService,
+	scheduleService primary.ScheduleService,
+	queueService primary.QueueService,
 	healthChecks []secondary.HealthChecker,
 	logger *zap.Logger,
+	opts ...RouterOption,
 ) http.Handler {
+	var options routerOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	taskEndpoint := func(h http.Handler) http.Handler {
+		if len(options.sourceAllowlist) == 0 {
+			return h
+		}
+		return options.sourceAllowlist.requireAPIKey(h)
+	}
+
 	mux := http.NewServeMux()
+	handle := options.handler(mux)
 
 	// Task endpoints
 	createHandler := NewCreateTaskHandler(taskService, logger)
-	mux.Handle("/tasks", createHandler)
+	handle("/tasks", taskEndpoint(createHandler))
 
-	// Health check endpoint
-	healthHandler := NewHealthHandler(healthChecks)
-	mux.Handle("/health", healthHandler)
+	broadcastHandler := NewBroadcastTasksHandler(taskService, logger)
+	handle("/tasks/broadcast", taskEndpoint(broadcastHandler))
+
+	cancelHandler := NewCancelTasksHandler(taskService, logger)
+	handle("/tasks/cancel", taskEndpoint(cancelHandler))
+
+	restoreHandler := NewRestoreTasksHandler(taskService, logger)
+	handle("/tasks/restore", taskEndpoint(restoreHandler))
+
+	prioritizeHandler := NewPrioritizeTaskHandler(taskService, logger)
+	handle("/tasks/{id}/prioritize", taskEndpoint(prioritizeHandler))
+
+	rescheduleHandler := NewRescheduleTaskHandler(scheduleService, taskService, logger)
+	handle("/tasks/{id}/schedule", taskEndpoint(rescheduleHandler))
+
+	resultHandler := NewDeliveryResultHandler(taskService, logger)
+	handle("/tasks/{id}/result", taskEndpoint(resultHandler))
+
+	// Schedule endpoints
+	forecastHandler := NewForecastHandler(scheduleService, logger)
+	handle("/schedule/forecast", forecastHandler)
+
+	shiftHandler := NewShiftTasksHandler(scheduleService, logger)
+	handle("/schedule/shift", shiftHandler)
+
+	upcomingHandler := NewUpcomingTasksHandler(scheduleService, logger)
+	handle("/schedule/upcoming", upcomingHandler)
+
+	// Admin endpoints
+	maintenanceHandler := NewMaintenanceHandler(maintenanceService, logger)
+	handle("/admin/maintenance", maintenanceHandler)
+
+	slaHandler := NewSLAHandler(slaService, logger)
+	handle("/admin/sla", slaHandler)
+
+	deadLetterHandler := NewDeadLetterHandler(taskService, logger)
+	handle("/admin/dead-letters", deadLetterHandler)
+
+	requeueHandler := NewRequeueDeadLettersHandler(taskService, logger)
+	handle("/admin/dead-letters/requeue", requeueHandler)
+
+	queueStatsHandler := NewQueueStatsHandler(queueService, logger)
+	handle("/admin/queues", queueStatsHandler)
+
+	purgeHandler := NewPurgeQueueHandler(queueService, logger)
+	handle("/admin/queues/{name}/purge", purgeHandler)
+
+	overflowHandler := NewOverflowStatsHandler(taskService, logger)
+	handle("/admin/overflow", overflowHandler)
+
+	corruptHandler := NewCorruptMembersHandler(queueService, logger)
+	handle("/admin/corrupt", corruptHandler)
+
+	deleteCorruptHandler := NewDeleteCorruptHandler(queueService, logger)
+	handle("/admin/corrupt/delete", deleteCorruptHandler)
+
+	if options.janitorService != nil {
+		reconcileHandler := NewReconcileHandler(options.janitorService, logger)
+		handle("/admin/reconcile", reconcileHandler)
+	}
+
+	if options.drainer != nil {
+		drainHandler := NewDrainHandler(options.drainer, logger)
+		handle("/admin/drain", drainHandler)
+
+		drainStatusHandler := NewDrainStatusHandler(options.drainer)
+		handle("/admin/drain/status", drainStatusHandler)
+	}
+
+	if options.usageService != nil {
+		usageHandler := NewUsageHandler(options.usageService, logger)
+		handle("/admin/usage", usageHandler)
+	}
+
+	registerStats(handle, options, logger)
+
+	mux.Handle(dashboardPath, newDashboardHandler())
+
+	registerHealth(mux, healthChecks, options)
 
 	return mux
 }
+
+// NewHealthRouter creates an HTTP mux with only the health endpoints and the
+// configured producer and worker stats registered, for processes that run
+// the worker without serving the task API.
+func NewHealthRouter(healthChecks []secondary.HealthChecker, logger *zap.Logger, opts ...RouterOption) http.Handler {
+	var options routerOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	mux := http.NewServeMux()
+	registerStats(options.handler(mux), options, logger)
+	registerHealth(mux, healthChecks, options)
+	return mux
+}
+
+// handler returns a function that registers an API endpoint under the
+// version prefix, and at its deprecated unversioned path for clients written
+// before versioning. It requires a signature when request signing is
+// enabled, computed over the body as sent, before any gzip is inflated.
+func (o *routerOptions) handler(mux *http.ServeMux) func(pattern string, h http.Handler) {
+	return func(pattern string, h http.Handler) {
+		h = compression(h)
+		if len(o.requestSigning.Secrets) > 0 {
+			h = o.requestSigning.requireSignature(h)
+		}
+		h = negotiateVersion(h)
+		mux.Handle(versionPrefix+pattern, h)
+		mux.Handle(pattern, deprecated(h))
+	}
+}
+
+// registerStats registers the producer and worker stats endpoints that are
+// configured.
+func registerStats(handle func(string, http.Handler), options routerOptions, logger *zap.Logger) {
+	if options.producerStats != nil {
+		producerStatsHandler := NewProducerStatsHandler(options.producerStats)
+		handle("/admin/producers", producerStatsHandler)
+	}
+
+	if options.workerService != nil {
+		workersHandler := NewWorkersHandler(options.workerService, logger)
+		handle("/admin/workers", workersHandler)
+	}
+}
+
+// registerHealth registers the health check endpoints. /livez only reports
+// that the process is up; /readyz runs the readiness checks.
+func registerHealth(mux *http.ServeMux, healthChecks []secondary.HealthChecker, options routerOptions) {
+	healthHandler := NewHealthHandler(healthChecks, options.healthPolicy)
+	mux.Handle("/health", healthHandler)
+
+	mux.Handle("/livez", NewLivenessHandler())
+
+	mux.Handle("/readyz", NewHealthHandler(options.readinessChecks, options.healthPolicy))
+}
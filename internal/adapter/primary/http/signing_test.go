@@ -0,0 +1,111 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func TestRequestSigning(t *testing.T) {
+	const secret = "billing-secret"
+	body := `{"id":"task-1","source":"billing","destination":{"url":"http://localhost:8090/hook"},"max_retries":3,"base_delay":1,"destination_type":"http"}`
+	now := time.Now().Unix()
+
+	tests := []struct {
+		name           string
+		path           string
+		client         string
+		timestamp      int64
+		signature      func(ts int64) string
+		body           string
+		wantStatusCode int
+	}{
+		{
+			name:           "valid signature",
+			path:           "/tasks",
+			client:         "billing",
+			timestamp:      now,
+			signature:      func(ts int64) string { return SignRequest(secret, ts, http.MethodPost, "/tasks", []byte(body)) },
+			wantStatusCode: http.StatusCreated,
+		},
+		{
+			name:           "unknown client",
+			path:           "/tasks",
+			client:         "other",
+			timestamp:      now,
+			signature:      func(ts int64) string { return SignRequest(secret, ts, http.MethodPost, "/tasks", []byte(body)) },
+			wantStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:           "stale timestamp",
+			path:           "/tasks",
+			client:         "billing",
+			timestamp:      now - int64(time.Hour/time.Second),
+			signature:      func(ts int64) string { return SignRequest(secret, ts, http.MethodPost, "/tasks", []byte(body)) },
+			wantStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:           "tampered body",
+			path:           "/tasks",
+			client:         "billing",
+			timestamp:      now,
+			signature:      func(ts int64) string { return SignRequest(secret, ts, http.MethodPost, "/tasks", []byte(body)) },
+			body:           strings.Replace(body, "billing", "invoices", 1),
+			wantStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:           "signed for another endpoint",
+			path:           "/tasks/cancel",
+			client:         "billing",
+			timestamp:      now,
+			signature:      func(ts int64) string { return SignRequest(secret, ts, http.MethodPost, "/tasks", []byte(body)) },
+			wantStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:           "missing signature",
+			path:           "/tasks",
+			client:         "billing",
+			timestamp:      now,
+			signature:      func(int64) string { return "" },
+			wantStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:           "health endpoints stay open",
+			path:           "/livez",
+			signature:      func(int64) string { return "" },
+			wantStatusCode: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := NewRouter(&mockTaskService{}, &mockMaintenanceService{}, &mockSLAService{}, &mockScheduleService{}, &mockQueueService{}, nil, zap.NewNop(),
+				WithRequestSigning(RequestSigning{Secrets: map[string]string{"billing": secret}}),
+			)
+
+			sent := body
+			if tt.body != "" {
+				sent = tt.body
+			}
+			req := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(sent))
+			if tt.path == "/livez" {
+				req = httptest.NewRequest(http.MethodGet, tt.path, nil)
+			}
+			req.Header.Set(SignatureClientHeader, tt.client)
+			req.Header.Set(SignatureTimestampHeader, strconv.FormatInt(tt.timestamp, 10))
+			req.Header.Set(SignatureHeader, tt.signature(tt.timestamp))
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatusCode {
+				t.Fatalf("expected status %d, got %d (body: %s)", tt.wantStatusCode, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package http
+
+import (
+	"net/http"
+	"slices"
+)
+
+// APIVersionHeader lets a client name the API version it was written
+// against. Every API response carries it with the version that served it.
+const APIVersionHeader = "X-Rebound-API-Version"
+
+// currentAPIVersion is the version served under its own prefix and, for
+// now, by the deprecated unversioned paths.
+const currentAPIVersion = "1"
+
+// supportedAPIVersions are the versions a client may ask for.
+var supportedAPIVersions = []string{currentAPIVersion}
+
+// versionPrefix is the path prefix of the current API version.
+const versionPrefix = "/v" + currentAPIVersion
+
+// negotiateVersion rejects requests asking for a version this server does
+// not serve, so a client built for a newer API fails loudly rather than
+// being handled by the wrong one.
+func negotiateVersion(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if v := r.Header.Get(APIVersionHeader); v != "" && !slices.Contains(supportedAPIVersions, v) {
+			respondJSON(w, http.StatusBadRequest, ErrorResponse{
+				Error: "unsupported API version " + v + "; supported: " + currentAPIVersion,
+				Code:  "UNSUPPORTED_API_VERSION",
+			})
+			return
+		}
+		w.Header().Set(APIVersionHeader, currentAPIVersion)
+		next.ServeHTTP(w, r)
+	})
+}
+
+// deprecated marks responses of an unversioned path with a Deprecation
+// header and links the versioned path replacing it.
+func deprecated(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Deprecation", "true")
+		w.Header().Set("Link", "<"+versionPrefix+r.URL.Path+`>; rel="successor-version"`)
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,74 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestRouter_versioning(t *testing.T) {
+	tests := []struct {
+		name           string
+		path           string
+		version        string
+		wantStatusCode int
+		wantDeprecated bool
+		wantLink       string
+	}{
+		{
+			name:           "versioned path",
+			path:           "/v1/admin/dead-letters",
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:           "unversioned path is deprecated",
+			path:           "/admin/dead-letters",
+			wantStatusCode: http.StatusOK,
+			wantDeprecated: true,
+			wantLink:       `</v1/admin/dead-letters>; rel="successor-version"`,
+		},
+		{
+			name:           "supported version requested",
+			path:           "/v1/admin/dead-letters",
+			version:        "1",
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:           "unsupported version requested",
+			path:           "/admin/dead-letters",
+			version:        "2",
+			wantStatusCode: http.StatusBadRequest,
+			wantDeprecated: true,
+			wantLink:       `</v1/admin/dead-letters>; rel="successor-version"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := NewRouter(&mockTaskService{}, &mockMaintenanceService{}, &mockSLAService{}, &mockScheduleService{}, &mockQueueService{}, nil, zap.NewNop())
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.version != "" {
+				req.Header.Set(APIVersionHeader, tt.version)
+			}
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatusCode {
+				t.Fatalf("expected status %d, got %d (body: %s)", tt.wantStatusCode, rec.Code, rec.Body.String())
+			}
+			if got := rec.Header().Get("Deprecation") != ""; got != tt.wantDeprecated {
+				t.Fatalf("expected deprecated=%v, got headers %v", tt.wantDeprecated, rec.Header())
+			}
+			if got := rec.Header().Get("Link"); got != tt.wantLink {
+				t.Fatalf("expected Link %q, got %q", tt.wantLink, got)
+			}
+			if tt.wantStatusCode == http.StatusOK && rec.Header().Get(APIVersionHeader) != "1" {
+				t.Fatalf("expected %s: 1, got %v", APIVersionHeader, rec.Header())
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+// Package kafkaingest creates tasks from requests consumed from a Kafka
+// topic, for producers that enqueue retries without an HTTP round trip.
+package kafkaingest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+// Reader is the subset of *kafka.Reader the ingester uses.
+type Reader interface {
+	FetchMessage(ctx context.Context) (kafka.Message, error)
+	CommitMessages(ctx context.Context, msgs ...kafka.Message) error
+	Close() error
+}
+
+// TaskCreator schedules new tasks; primary.TaskService satisfies it.
+type TaskCreator interface {
+	CreateTask(ctx context.Context, task *entity.Task) error
+}
+
+// Decoder parses a message value into a task.
+type Decoder func(data []byte) (*entity.Task, error)
+
+// Ingester consumes task creation requests and schedules them. A message
+// is committed once its task is scheduled, or once it is found invalid;
+// other failures are retried until they succeed, so the partition waits
+// rather than skipping the request.
+type Ingester struct {
+	reader  Reader
+	service TaskCreator
+	decode  Decoder
+	backoff time.Duration
+	logger  *zap.Logger
+}
+
+// NewIngester creates an ingester consuming topic as groupID.
+func NewIngester(brokers []string, topic, groupID string, service TaskCreator, decode Decoder, logger *zap.Logger) *Ingester {
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:  brokers,
+		GroupID:  groupID,
+		Topic:    topic,
+		MinBytes: 1,
+		MaxBytes: 10e6, // 10MB
+	})
+	return newIngester(reader, service, decode, logger)
+}
+
+func newIngester(reader Reader, service TaskCreator, decode Decoder, logger *zap.Logger) *Ingester {
+	return &Ingester{
+		reader:  reader,
+		service: service,
+		decode:  decode,
+		backoff: domain.DefaultIngestBackoff,
+		logger:  logger.Named("kafka-ingester"),
+	}
+}
+
+// Run consumes until ctx is cancelled, then closes the reader.
+func (i *Ingester) Run(ctx context.Context) error {
+	i.logger.Info("kafka ingester started")
+	defer i.reader.Close()
+
+	for {
+		msg, err := i.reader.FetchMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if errors.Is(err, io.EOF) {
+				return fmt.Errorf("kafka reader closed: %w", err)
+			}
+			i.logger.Error("failed to fetch task request", zap.Error(err))
+			continue
+		}
+
+		if !i.ingest(ctx, msg) {
+			return ctx.Err()
+		}
+		if err := i.reader.CommitMessages(ctx, msg); err != nil && ctx.Err() == nil {
+			i.logger.Error("failed to commit task request", zap.Error(err), zap.Int64("offset", msg.Offset))
+		}
+	}
+}
+
+// ingest schedules the task msg requests. It reports false only when ctx
+// was cancelled before the request was settled.
+func (i *Ingester) ingest(ctx context.Context, msg kafka.Message) bool {
+	logger := i.logger.With(zap.Int("partition", msg.Partition), zap.Int64("offset", msg.Offset))
+
+	task, err := i.decode(msg.Value)
+	if err != nil {
+		logger.Warn("dropping undecodable task request", zap.Error(err))
+		return true
+	}
+
+	for {
+		err := i.service.CreateTask(ctx, task)
+		switch {
+		case err == nil:
+			return true
+		case errors.Is(err, domain.ErrInvalidTask):
+			logger.Warn("dropping invalid task request", zap.String("task_id", task.ID), zap.Error(err))
+			return true
+		}
+
+		logger.Error("failed to schedule task request, retrying", zap.String("task_id", task.ID), zap.Error(err))
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(i.backoff):
+		}
+	}
+}
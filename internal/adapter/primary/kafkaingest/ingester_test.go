@@ -0,0 +1,142 @@
+package kafkaingest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+// fakeReader serves messages then blocks until ctx is cancelled.
+type fakeReader struct {
+	mu       sync.Mutex
+	messages []kafka.Message
+	commits  []int64
+	closed   bool
+}
+
+func (r *fakeReader) FetchMessage(ctx context.Context) (kafka.Message, error) {
+	r.mu.Lock()
+	if len(r.messages) > 0 {
+		msg := r.messages[0]
+		r.messages = r.messages[1:]
+		r.mu.Unlock()
+		return msg, nil
+	}
+	r.mu.Unlock()
+	<-ctx.Done()
+	return kafka.Message{}, ctx.Err()
+}
+
+func (r *fakeReader) CommitMessages(_ context.Context, msgs ...kafka.Message) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, m := range msgs {
+		r.commits = append(r.commits, m.Offset)
+	}
+	return nil
+}
+
+func (r *fakeReader) Close() error {
+	r.closed = true
+	return nil
+}
+
+type fakeCreator struct {
+	errs    []error // returned by successive calls, then nil
+	created []string
+	calls   int
+}
+
+func (c *fakeCreator) CreateTask(_ context.Context, task *entity.Task) error {
+	c.calls++
+	if len(c.errs) > 0 {
+		err := c.errs[0]
+		c.errs = c.errs[1:]
+		if err != nil {
+			return err
+		}
+	}
+	c.created = append(c.created, task.ID)
+	return nil
+}
+
+func decodeID(data []byte) (*entity.Task, error) {
+	var req struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(data, &req); err != nil {
+		return nil, err
+	}
+	return &entity.Task{ID: req.ID}, nil
+}
+
+func TestIngester_Run(t *testing.T) {
+	reader := &fakeReader{messages: []kafka.Message{
+		{Offset: 1, Value: []byte(`{"id":"task-1"}`)},
+		{Offset: 2, Value: []byte(`not json`)},
+		{Offset: 3, Value: []byte(`{"id":"task-3"}`)},
+		{Offset: 4, Value: []byte(`{"id":"task-4"}`)},
+	}}
+	creator := &fakeCreator{errs: []error{
+		nil,
+		fmt.Errorf("%w: source is required", domain.ErrInvalidTask), // task-3 dropped
+		domain.ErrQueueFull, // task-4 retried
+	}}
+
+	ing := newIngester(reader, creator, decodeID, zap.NewNop())
+	ing.backoff = time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- ing.Run(ctx) }()
+
+	deadline := time.After(time.Second)
+	for {
+		reader.mu.Lock()
+		n := len(reader.commits)
+		reader.mu.Unlock()
+		if n == 4 {
+			break
+		}
+		select {
+		case <-deadline:
+			t.Fatalf("expected 4 commits, got %d", n)
+		case <-time.After(time.Millisecond):
+		}
+	}
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+
+	if want := []string{"task-1", "task-4"}; fmt.Sprint(creator.created) != fmt.Sprint(want) {
+		t.Fatalf("created %v, want %v", creator.created, want)
+	}
+	if creator.calls != 4 {
+		t.Fatalf("expected 4 create calls, got %d", creator.calls)
+	}
+	if !reader.closed {
+		t.Fatal("expected the reader to be closed")
+	}
+}
+
+func TestIngester_ingestStopsOnCancel(t *testing.T) {
+	creator := &fakeCreator{errs: []error{errors.New("redis down")}}
+	ing := newIngester(&fakeReader{}, creator, decodeID, zap.NewNop())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if ing.ingest(ctx, kafka.Message{Value: []byte(`{"id":"task-1"}`)}) {
+		t.Fatal("expected an unsettled request once ctx is cancelled")
+	}
+}
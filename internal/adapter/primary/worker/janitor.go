@@ -0,0 +1,63 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+	"github.com/ruudy-sib/rebound/internal/port/primary"
+)
+
+// Janitor runs a state repair pass at regular intervals. Every replica runs
+// one, but the pass is claimed for one interval, so only one replica does
+// the work each time.
+type Janitor struct {
+	service  primary.JanitorService
+	interval time.Duration
+	logger   *zap.Logger
+}
+
+// NewJanitor creates a Janitor running a pass every interval. A zero value
+// uses domain.DefaultJanitorInterval.
+func NewJanitor(service primary.JanitorService, interval time.Duration, logger *zap.Logger) *Janitor {
+	if interval <= 0 {
+		interval = domain.DefaultJanitorInterval
+	}
+	return &Janitor{
+		service:  service,
+		interval: interval,
+		logger:   logger.Named("janitor"),
+	}
+}
+
+// Run starts the repair loop. It blocks until the context is cancelled.
+func (j *Janitor) Run(ctx context.Context) error {
+	j.logger.Info("janitor started", zap.Duration("interval", j.interval))
+
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			j.pass(ctx)
+		}
+	}
+}
+
+// pass runs one repair pass, logging failures without stopping the loop.
+func (j *Janitor) pass(ctx context.Context) {
+	defer func() {
+		if r := recover(); r != nil {
+			j.logger.Error("panic reconciling state", zap.Any("panic", r), zap.Stack("stack"))
+		}
+	}()
+
+	if _, err := j.service.Reconcile(ctx, j.interval); err != nil {
+		j.logger.Error("error reconciling state", zap.Error(err))
+	}
+}
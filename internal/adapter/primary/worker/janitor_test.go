@@ -0,0 +1,50 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+// mockJanitorService implements primary.JanitorService for janitor tests.
+type mockJanitorService struct {
+	calls atomic.Int32
+	lease atomic.Int64
+}
+
+func (m *mockJanitorService) Reconcile(_ context.Context, lease time.Duration) (*entity.RepairReport, error) {
+	m.lease.Store(int64(lease))
+	if m.calls.Add(1) == 1 {
+		return nil, errors.New("redis down")
+	}
+	return &entity.RepairReport{}, nil
+}
+
+func (m *mockJanitorService) ReconcileNow(_ context.Context) (*entity.RepairReport, error) {
+	return &entity.RepairReport{}, nil
+}
+
+func TestJanitor_Run(t *testing.T) {
+	svc := &mockJanitorService{}
+	janitor := NewJanitor(svc, 10*time.Millisecond, zap.NewNop())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 55*time.Millisecond)
+	defer cancel()
+
+	if err := janitor.Run(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context deadline error, got %v", err)
+	}
+	// A failed pass must not stop the loop.
+	if calls := svc.calls.Load(); calls < 2 {
+		t.Fatalf("expected several passes, got %d", calls)
+	}
+	if lease := time.Duration(svc.lease.Load()); lease != 10*time.Millisecond {
+		t.Fatalf("expected the interval as lease, got %v", lease)
+	}
+}
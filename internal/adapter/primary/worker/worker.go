@@ -2,19 +2,50 @@ package worker
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"go.uber.org/zap"
 
+	"github.com/ruudy-sib/rebound/internal/domain"
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
 	"github.com/ruudy-sib/rebound/internal/port/primary"
 )
 
 // Worker polls for due tasks at regular intervals and processes them.
-// It respects context cancellation for graceful shutdown.
+// It respects context cancellation for graceful shutdown, and can be
+// drained ahead of it through Drain.
 type Worker struct {
-	service      primary.TaskService
-	pollInterval time.Duration
-	logger       *zap.Logger
+	service        primary.TaskService
+	pollInterval   time.Duration
+	queueIntervals map[string]time.Duration
+	drainTimeout   time.Duration
+	logger         *zap.Logger
+
+	mu            sync.Mutex
+	drainingSince time.Time
+	polls         int
+}
+
+// Option configures optional Worker behaviour.
+type Option func(*Worker)
+
+// WithDrainTimeout sets how long a poll in progress at shutdown may keep
+// delivering before it is cancelled. A zero value uses
+// domain.DefaultDrainTimeout.
+func WithDrainTimeout(timeout time.Duration) Option {
+	return func(w *Worker) {
+		w.drainTimeout = timeout
+	}
+}
+
+// WithQueueIntervals polls each named queue on its own loop, at the
+// interval given for it, next to the default queue's loop. Queues with a
+// zero interval use the worker's poll interval.
+func WithQueueIntervals(intervals map[string]time.Duration) Option {
+	return func(w *Worker) {
+		w.queueIntervals = intervals
+	}
 }
 
 // NewWorker creates a Worker that processes tasks at the given interval.
@@ -22,33 +53,189 @@ func NewWorker(
 	service primary.TaskService,
 	pollInterval time.Duration,
 	logger *zap.Logger,
+	opts ...Option,
 ) *Worker {
-	return &Worker{
+	w := &Worker{
 		service:      service,
 		pollInterval: pollInterval,
 		logger:       logger.Named("worker"),
 	}
+	for _, opt := range opts {
+		opt(w)
+	}
+	if w.drainTimeout <= 0 {
+		w.drainTimeout = domain.DefaultDrainTimeout
+	}
+	return w
 }
 
-// Run starts the polling loop. It blocks until the context is cancelled.
+// Run starts the polling loops, one for the default queue and one for
+// each named queue. It blocks until the context is cancelled. Cancelling
+// stops fetching new tasks, but deliveries already under way keep running
+// for up to the drain timeout, so they are not aborted mid-request and
+// retried needlessly.
 func (w *Worker) Run(ctx context.Context) error {
 	w.logger.Info("worker started",
 		zap.Duration("poll_interval", w.pollInterval),
+		zap.Any("queue_intervals", w.queueIntervals),
 	)
 
-	ticker := time.NewTicker(w.pollInterval)
+	// Deliveries run on their own context, cancelled only once draining
+	// takes longer than the drain timeout.
+	workCtx, cancelWork := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancelWork()
+
+	stopped := make(chan struct{})
+	defer close(stopped)
+	go w.cancelAfterDrain(ctx, stopped, cancelWork)
+
+	var wg sync.WaitGroup
+	for queue, interval := range w.queueIntervals {
+		if interval <= 0 {
+			interval = w.pollInterval
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w.loop(ctx, workCtx, queue, interval)
+		}()
+	}
+	w.loop(ctx, workCtx, "", w.pollInterval)
+
+	w.logger.Info("worker shutting down")
+	wg.Wait()
+	return ctx.Err()
+}
+
+// loop polls queue every interval until ctx is cancelled, delivering on
+// workCtx.
+func (w *Worker) loop(ctx, workCtx context.Context, queue string, interval time.Duration) {
+	ticker := time.NewTicker(interval)
 	defer ticker.Stop()
 
 	for {
 		select {
 		case <-ctx.Done():
-			w.logger.Info("worker shutting down")
-			return ctx.Err()
+			return
 		case <-ticker.C:
-			if err := w.service.ProcessDueTasks(ctx); err != nil {
-				// Log but do not return -- the worker should keep running.
-				w.logger.Error("error processing due tasks", zap.Error(err))
+			// Both cases may be ready at once; never start a poll after
+			// shutdown began.
+			if ctx.Err() != nil || !w.startPoll() {
+				continue
 			}
+			w.poll(workCtx, queue)
+			w.endPoll()
 		}
 	}
 }
+
+// Drain stops the worker starting new polls. A poll under way finishes its
+// deliveries. Draining lasts until the process exits.
+func (w *Worker) Drain() entity.DrainStatus {
+	w.mu.Lock()
+	if w.drainingSince.IsZero() {
+		w.drainingSince = time.Now()
+		w.logger.Info("worker draining", zap.Bool("poll_in_progress", w.polls > 0))
+	}
+	w.mu.Unlock()
+	return w.DrainStatus()
+}
+
+// DrainStatus reports whether the worker is draining and whether a poll of
+// any queue is still delivering.
+func (w *Worker) DrainStatus() entity.DrainStatus {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return entity.DrainStatus{
+		Draining: !w.drainingSince.IsZero(),
+		Since:    w.drainingSince,
+		Polling:  w.polls > 0,
+	}
+}
+
+// startPoll marks a poll as started unless the worker is draining.
+func (w *Worker) startPoll() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if !w.drainingSince.IsZero() {
+		return false
+	}
+	w.polls++
+	return true
+}
+
+func (w *Worker) endPoll() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.polls--
+	if w.polls == 0 && !w.drainingSince.IsZero() {
+		w.logger.Info("worker drained", zap.Duration("took", time.Since(w.drainingSince)))
+	}
+}
+
+// poll runs one processing cycle of queue. Errors and panics are logged
+// but never stop the loop, so one bad cycle cannot silently halt
+// processing.
+func (w *Worker) poll(ctx context.Context, queue string) {
+	logger := w.logger
+	if queue != "" {
+		logger = logger.With(zap.String("queue", queue))
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Error("panic processing due tasks", zap.Any("panic", r), zap.Stack("stack"))
+		}
+	}()
+
+	var result entity.ProcessResult
+	var err error
+	if queue == "" {
+		result, err = w.service.ProcessDueTasks(ctx)
+	} else {
+		result, err = w.service.ProcessDueTasksIn(ctx, queue)
+	}
+	if err != nil {
+		// Log but do not return -- the worker should keep running.
+		logger.Error("error processing due tasks", zap.Error(err))
+		return
+	}
+	if len(result.Tasks) == 0 && result.Reclaimed == 0 {
+		return
+	}
+
+	logger.Info("due tasks processed",
+		zap.Int("tasks", len(result.Tasks)),
+		zap.Int("delivered", result.Count(entity.OutcomeDelivered)),
+		zap.Int("rescheduled", result.Count(entity.OutcomeRescheduled)),
+		zap.Int("dead", result.Count(entity.OutcomeDead)),
+		zap.Int("quarantined", result.Count(entity.OutcomeQuarantined)),
+		zap.Int("held", result.Count(entity.OutcomeHeld)),
+		zap.Int("skipped", result.Count(entity.OutcomeSkipped)),
+		zap.Int("requeued", result.Count(entity.OutcomeRequeued)),
+		zap.Int("errored", result.Count(entity.OutcomeErrored)),
+		zap.Int("reclaimed", result.Reclaimed),
+		zap.Any("error_codes", result.ErrorCodes()),
+	)
+}
+
+// cancelAfterDrain cancels in-flight work if the loop has not stopped
+// within the drain timeout of ctx being cancelled.
+func (w *Worker) cancelAfterDrain(ctx context.Context, stopped <-chan struct{}, cancelWork context.CancelFunc) {
+	select {
+	case <-stopped:
+		return
+	case <-ctx.Done():
+	}
+
+	timer := time.NewTimer(w.drainTimeout)
+	defer timer.Stop()
+
+	select {
+	case <-stopped:
+	case <-timer.C:
+		w.logger.Warn("drain timeout exceeded, cancelling in-flight deliveries",
+			zap.Duration("drain_timeout", w.drainTimeout),
+		)
+		cancelWork()
+	}
+}
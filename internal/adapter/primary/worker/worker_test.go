@@ -16,18 +16,68 @@ import (
 type mockTaskService struct {
 	processFunc  func(ctx context.Context) error
 	processCalls atomic.Int32
+	queueCalls   atomic.Int32
 }
 
 func (m *mockTaskService) CreateTask(_ context.Context, _ *entity.Task) error {
 	return nil
 }
 
-func (m *mockTaskService) ProcessDueTasks(ctx context.Context) error {
+func (m *mockTaskService) CreateTaskAt(_ context.Context, _ *entity.Task, _ time.Time) error {
+	return nil
+}
+
+func (m *mockTaskService) CreateTasksPaced(_ context.Context, _ []*entity.Task, _ time.Duration) error {
+	return nil
+}
+
+func (m *mockTaskService) GetTask(_ context.Context, _ string) (*entity.Task, error) {
+	return nil, nil
+}
+
+func (m *mockTaskService) CancelTask(_ context.Context, _ string) error {
+	return nil
+}
+
+func (m *mockTaskService) GetCancelledTask(_ context.Context, _ string) (*entity.Task, error) {
+	return nil, nil
+}
+
+func (m *mockTaskService) RestoreTask(_ context.Context, _ string) error {
+	return nil
+}
+
+func (m *mockTaskService) PrioritizeTask(_ context.Context, _ string, _ bool) (time.Time, error) {
+	return time.Time{}, nil
+}
+
+func (m *mockTaskService) DeliveryResult(_ context.Context, _ string) (*entity.DeliveryResult, error) {
+	return nil, nil
+}
+
+func (m *mockTaskService) ListDeadLetters(_ context.Context, _ entity.Cursor, _ int) ([]entity.DeadLetter, entity.Cursor, error) {
+	return nil, entity.Cursor{}, nil
+}
+
+func (m *mockTaskService) RequeueDeadLetter(_ context.Context, _ string) error {
+	return nil
+}
+
+func (m *mockTaskService) OverflowStats() entity.OverflowStats {
+	return entity.OverflowStats{}
+}
+
+func (m *mockTaskService) ProcessDueTasks(ctx context.Context) (entity.ProcessResult, error) {
 	m.processCalls.Add(1)
 	if m.processFunc != nil {
-		return m.processFunc(ctx)
+		return entity.ProcessResult{}, m.processFunc(ctx)
 	}
-	return nil
+	return entity.ProcessResult{}, nil
+}
+
+func (m *mockTaskService) ProcessDueTasksIn(_ context.Context, _ string) (entity.ProcessResult, error) {
+	m.queueCalls.Add(1)
+	return entity.ProcessResult{}, nil
 }
 
 func TestWorker_Run(t *testing.T) {
@@ -89,6 +139,25 @@ func TestWorker_Run(t *testing.T) {
 	}
 }
 
+func TestWorker_Run_queueIntervals(t *testing.T) {
+	svc := &mockTaskService{}
+	w := NewWorker(svc, time.Hour, zap.NewNop(),
+		WithQueueIntervals(map[string]time.Duration{"payments": 10 * time.Millisecond}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	if err := w.Run(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected DeadlineExceeded, got %v", err)
+	}
+	if calls := svc.queueCalls.Load(); calls < 2 {
+		t.Fatalf("expected the payments queue polled on its own interval, got %d calls", calls)
+	}
+	if calls := svc.processCalls.Load(); calls != 0 {
+		t.Fatalf("expected the default queue not polled yet, got %d calls", calls)
+	}
+}
+
 func TestWorker_Run_respectsCancellation(t *testing.T) {
 	svc := &mockTaskService{}
 	w := NewWorker(svc, 1*time.Hour, zap.NewNop()) // Very long interval
@@ -112,3 +181,123 @@ func TestWorker_Run_respectsCancellation(t *testing.T) {
 		t.Fatal("worker did not stop within 2 seconds after cancellation")
 	}
 }
+
+func TestWorker_Run_drainsInFlightDeliveries(t *testing.T) {
+	tests := []struct {
+		name         string
+		drainTimeout time.Duration
+		wantAborted  bool
+	}{
+		{name: "delivery finishes within drain timeout", drainTimeout: time.Second},
+		{name: "delivery is cancelled after drain timeout", drainTimeout: 20 * time.Millisecond, wantAborted: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			started := make(chan struct{})
+			var aborted atomic.Bool
+			svc := &mockTaskService{}
+			svc.processFunc = func(ctx context.Context) error {
+				if svc.processCalls.Load() == 1 {
+					close(started)
+				}
+				select {
+				case <-time.After(200 * time.Millisecond):
+				case <-ctx.Done():
+					aborted.Store(true)
+				}
+				return nil
+			}
+
+			w := NewWorker(svc, 10*time.Millisecond, zap.NewNop(), WithDrainTimeout(tt.drainTimeout))
+
+			ctx, cancel := context.WithCancel(context.Background())
+			done := make(chan error, 1)
+			go func() {
+				done <- w.Run(ctx)
+			}()
+
+			<-started
+			cancel()
+
+			select {
+			case err := <-done:
+				if !errors.Is(err, context.Canceled) {
+					t.Fatalf("expected context.Canceled, got %v", err)
+				}
+			case <-time.After(2 * time.Second):
+				t.Fatal("worker did not stop within 2 seconds after cancellation")
+			}
+			if aborted.Load() != tt.wantAborted {
+				t.Fatalf("expected aborted=%v, got %v", tt.wantAborted, aborted.Load())
+			}
+			if calls := svc.processCalls.Load(); calls != 1 {
+				t.Fatalf("expected no poll after shutdown began, got %d calls", calls)
+			}
+		})
+	}
+}
+
+func TestWorker_Drain(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	svc := &mockTaskService{}
+	svc.processFunc = func(ctx context.Context) error {
+		if svc.processCalls.Load() == 1 {
+			close(started)
+			<-release
+		}
+		return nil
+	}
+
+	w := NewWorker(svc, 10*time.Millisecond, zap.NewNop())
+	if status := w.DrainStatus(); status.Draining {
+		t.Fatal("expected a new worker not to be draining")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go w.Run(ctx)
+
+	<-started
+	status := w.Drain()
+	if !status.Draining || status.Drained() {
+		t.Fatalf("expected draining with a poll in progress, got %+v", status)
+	}
+
+	close(release)
+	deadline := time.Now().Add(time.Second)
+	for !w.DrainStatus().Drained() {
+		if time.Now().After(deadline) {
+			t.Fatal("worker did not drain within a second")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	if calls := svc.processCalls.Load(); calls != 1 {
+		t.Fatalf("expected no poll after draining, got %d calls", calls)
+	}
+	if again := w.Drain(); !again.Since.Equal(status.Since) {
+		t.Fatalf("expected draining again to keep the start time %s, got %s", status.Since, again.Since)
+	}
+}
+
+func TestWorker_Run_survivesPanics(t *testing.T) {
+	svc := &mockTaskService{
+		processFunc: func(context.Context) error {
+			panic("scheduler bug")
+		},
+	}
+	w := NewWorker(svc, 10*time.Millisecond, zap.NewNop())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	if err := w.Run(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected DeadlineExceeded, got %v", err)
+	}
+	if calls := svc.processCalls.Load(); calls < 2 {
+		t.Fatalf("expected polling to continue after a panic, got %d calls", calls)
+	}
+}
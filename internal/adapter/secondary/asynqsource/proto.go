@@ -0,0 +1,92 @@
+package asynqsource
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// asynq stores each job as a protobuf-encoded TaskMessage. Only the fields
+// needed for import are decoded; the rest are skipped by wire type, so new
+// asynq fields do not break the reader.
+const (
+	fieldType     = 1 // string
+	fieldPayload  = 2 // bytes
+	fieldID       = 3 // string
+	fieldQueue    = 4 // string
+	fieldRetry    = 5 // int32
+	fieldRetried  = 6 // int32
+	fieldErrorMsg = 7 // string
+)
+
+const (
+	wireVarint = 0
+	wireI64    = 1
+	wireLen    = 2
+	wireI32    = 5
+)
+
+var errTruncated = errors.New("truncated protobuf message")
+
+func decodeTaskMessage(b []byte) (Job, error) {
+	var job Job
+	for len(b) > 0 {
+		tag, n := binary.Uvarint(b)
+		if n <= 0 {
+			return Job{}, errTruncated
+		}
+		b = b[n:]
+		field, wire := tag>>3, tag&7
+
+		switch wire {
+		case wireVarint:
+			v, n := binary.Uvarint(b)
+			if n <= 0 {
+				return Job{}, errTruncated
+			}
+			b = b[n:]
+			switch field {
+			case fieldRetry:
+				job.Retry = int(int32(v))
+			case fieldRetried:
+				job.Retried = int(int32(v))
+			}
+		case wireLen:
+			l, n := binary.Uvarint(b)
+			if n <= 0 || uint64(len(b)-n) < l {
+				return Job{}, errTruncated
+			}
+			v := b[n : n+int(l)]
+			b = b[n+int(l):]
+			switch field {
+			case fieldType:
+				job.Type = string(v)
+			case fieldPayload:
+				job.Payload = append([]byte(nil), v...)
+			case fieldID:
+				job.ID = string(v)
+			case fieldQueue:
+				job.Queue = string(v)
+			case fieldErrorMsg:
+				job.ErrorMsg = string(v)
+			}
+		case wireI64:
+			if len(b) < 8 {
+				return Job{}, errTruncated
+			}
+			b = b[8:]
+		case wireI32:
+			if len(b) < 4 {
+				return Job{}, errTruncated
+			}
+			b = b[4:]
+		default:
+			return Job{}, fmt.Errorf("unsupported protobuf wire type %d", wire)
+		}
+	}
+
+	if job.ID == "" {
+		return Job{}, errors.New("asynq job has no id")
+	}
+	return job, nil
+}
@@ -0,0 +1,133 @@
+package asynqsource
+
+import (
+	"encoding/binary"
+	"errors"
+	"testing"
+)
+
+// Protobuf wire encoders for building test messages.
+
+func appendTag(b []byte, field, wire uint64) []byte {
+	return binary.AppendUvarint(b, field<<3|wire)
+}
+
+func appendVarint(b []byte, field, v uint64) []byte {
+	return binary.AppendUvarint(appendTag(b, field, wireVarint), v)
+}
+
+func appendBytes(b []byte, field uint64, v string) []byte {
+	b = binary.AppendUvarint(appendTag(b, field, wireLen), uint64(len(v)))
+	return append(b, v...)
+}
+
+// encodeJob encodes the fields of job the reader decodes.
+func encodeJob(job Job) []byte {
+	b := appendBytes(nil, fieldType, job.Type)
+	b = appendBytes(b, fieldPayload, string(job.Payload))
+	b = appendBytes(b, fieldID, job.ID)
+	b = appendBytes(b, fieldQueue, job.Queue)
+	b = appendVarint(b, fieldRetry, uint64(job.Retry))
+	b = appendVarint(b, fieldRetried, uint64(job.Retried))
+	return appendBytes(b, fieldErrorMsg, job.ErrorMsg)
+}
+
+func TestDecodeTaskMessage(t *testing.T) {
+	full := Job{
+		ID:       "job-1",
+		Type:     "email:send",
+		Payload:  []byte(`{"to":"a@example.com"}`),
+		Queue:    "critical",
+		Retry:    25,
+		Retried:  3,
+		ErrorMsg: "smtp timeout",
+	}
+	// minimal returns a fresh message so cases never share a backing array.
+	minimal := func() []byte { return appendBytes(nil, fieldID, "job-1") }
+
+	tests := []struct {
+		name    string
+		msg     []byte
+		want    Job
+		wantErr error
+	}{
+		{
+			name: "every decoded field",
+			msg:  encodeJob(full),
+			want: full,
+		},
+		{
+			name: "multi-byte varint",
+			msg:  appendVarint(minimal(), fieldRetry, 300),
+			want: Job{ID: "job-1", Retry: 300},
+		},
+		{
+			name: "unknown fields of every wire type are skipped",
+			msg: func() []byte {
+				b := appendVarint(minimal(), 9, 1<<40)
+				b = appendBytes(b, 10, "deadline")
+				b = append(appendTag(b, 11, wireI64), make([]byte, 8)...)
+				b = append(appendTag(b, 12, wireI32), make([]byte, 4)...)
+				return appendBytes(b, fieldType, "after-unknown")
+			}(),
+			want: Job{ID: "job-1", Type: "after-unknown"},
+		},
+		{
+			name:    "truncated tag",
+			msg:     append(minimal(), 0x80),
+			wantErr: errTruncated,
+		},
+		{
+			name:    "truncated varint",
+			msg:     append(appendTag(minimal(), fieldRetry, wireVarint), 0x80),
+			wantErr: errTruncated,
+		},
+		{
+			name:    "length past the end",
+			msg:     append(appendTag(minimal(), fieldType, wireLen), 5, 'a'),
+			wantErr: errTruncated,
+		},
+		{
+			name:    "truncated fixed64",
+			msg:     append(appendTag(minimal(), 11, wireI64), 1, 2, 3),
+			wantErr: errTruncated,
+		},
+		{
+			name:    "truncated fixed32",
+			msg:     append(appendTag(minimal(), 12, wireI32), 1),
+			wantErr: errTruncated,
+		},
+		{
+			name: "unsupported wire type",
+			msg:  appendTag(minimal(), 13, 3),
+		},
+		{
+			name: "no id",
+			msg:  appendBytes(nil, fieldType, "email:send"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodeTaskMessage(tt.msg)
+			wantErr := tt.wantErr != nil || tt.want.ID == ""
+			if wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got %+v", got)
+				}
+				if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+					t.Fatalf("expected %v, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.ID != tt.want.ID || got.Type != tt.want.Type || string(got.Payload) != string(tt.want.Payload) ||
+				got.Queue != tt.want.Queue || got.Retry != tt.want.Retry || got.Retried != tt.want.Retried ||
+				got.ErrorMsg != tt.want.ErrorMsg {
+				t.Fatalf("expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+// Package asynqsource reads delayed jobs out of an asynq Redis deployment so
+// they can be imported as rebound tasks.
+package asynqsource
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+// Set names the asynq sorted set a job was read from.
+type Set string
+
+const (
+	SetScheduled Set = "scheduled"
+	SetRetry     Set = "retry"
+)
+
+// Job is a delayed asynq job.
+type Job struct {
+	ID        string
+	Type      string
+	Payload   []byte
+	Queue     string
+	Retry     int // max retries
+	Retried   int // retries already used
+	ErrorMsg  string
+	ProcessAt time.Time
+	Set       Set
+}
+
+// readBatchSize bounds the job IDs read per round trip.
+const readBatchSize = 500
+
+// Reader reads jobs from asynq's per-queue scheduled and retry sets. It never
+// modifies the asynq data.
+type Reader struct {
+	client redis.UniversalClient
+	logger *zap.Logger
+}
+
+// NewReader creates a Reader over the Redis instance asynq uses.
+func NewReader(client redis.UniversalClient, logger *zap.Logger) *Reader {
+	return &Reader{
+		client: client,
+		logger: logger.Named("asynq-reader"),
+	}
+}
+
+// Jobs returns every job in the scheduled and retry sets of queue.
+func (r *Reader) Jobs(ctx context.Context, queue string) ([]Job, error) {
+	var jobs []Job
+	for _, set := range []Set{SetScheduled, SetRetry} {
+		setJobs, err := r.readSet(ctx, queue, set)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, setJobs...)
+	}
+	return jobs, nil
+}
+
+// readSet pages through asynq:{queue}:<set>, whose members are job IDs
+// scored by process time, and loads each job's message from its hash.
+func (r *Reader) readSet(ctx context.Context, queue string, set Set) ([]Job, error) {
+	key := fmt.Sprintf("asynq:{%s}:%s", queue, set)
+
+	var jobs []Job
+	for start := int64(0); ; start += readBatchSize {
+		entries, err := r.client.ZRangeWithScores(ctx, key, start, start+readBatchSize-1).Result()
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", key, err)
+		}
+
+		cmds := make([]*redis.StringCmd, len(entries))
+		_, err = r.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+			for i, z := range entries {
+				id, _ := z.Member.(string)
+				cmds[i] = pipe.HGet(ctx, fmt.Sprintf("asynq:{%s}:t:%s", queue, id), "msg")
+			}
+			return nil
+		})
+		if err != nil && err != redis.Nil {
+			return nil, fmt.Errorf("reading job messages for %s: %w", key, err)
+		}
+
+		for i, z := range entries {
+			raw, err := cmds[i].Bytes()
+			if err != nil {
+				r.logger.Warn("asynq job message missing", zap.Any("job_id", z.Member), zap.Error(err))
+				continue
+			}
+			job, err := decodeTaskMessage(raw)
+			if err != nil {
+				r.logger.Warn("undecodable asynq job", zap.Any("job_id", z.Member), zap.Error(err))
+				continue
+			}
+			job.ProcessAt = time.Unix(int64(z.Score), 0)
+			job.Set = set
+			if job.Queue == "" {
+				job.Queue = queue
+			}
+			jobs = append(jobs, job)
+		}
+
+		if len(entries) < readBatchSize {
+			return jobs, nil
+		}
+	}
+}
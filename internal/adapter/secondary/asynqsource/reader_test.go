@@ -0,0 +1,98 @@
+package asynqsource
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+// seedJob stores job the way asynq does: its message in the task hash and
+// its ID in the queue's sorted set, scored by process time.
+func seedJob(t *testing.T, mr *miniredis.Miniredis, queue string, set Set, processAt time.Time, id string, msg []byte) {
+	t.Helper()
+	if msg != nil {
+		mr.HSet("asynq:{"+queue+"}:t:"+id, "msg", string(msg))
+	}
+	if _, err := mr.ZAdd("asynq:{"+queue+"}:"+string(set), float64(processAt.Unix()), id); err != nil {
+		t.Fatalf("seeding %s: %v", id, err)
+	}
+}
+
+func TestReader_Jobs(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	at := time.Unix(1_900_000_000, 0)
+	seedJob(t, mr, "default", SetScheduled, at, "sched-1", encodeJob(Job{
+		ID: "sched-1", Type: "email:send", Payload: []byte("hello"), Retry: 25,
+	}))
+	seedJob(t, mr, "default", SetRetry, at.Add(time.Minute), "retry-1", encodeJob(Job{
+		ID: "retry-1", Type: "email:send", Queue: "default", Retry: 25, Retried: 2, ErrorMsg: "boom",
+	}))
+	seedJob(t, mr, "default", SetScheduled, at, "no-msg", nil)
+	seedJob(t, mr, "default", SetRetry, at, "garbled", []byte{0x80})
+	seedJob(t, mr, "other", SetScheduled, at, "other-1", encodeJob(Job{ID: "other-1"}))
+
+	jobs, err := NewReader(client, zap.NewNop()).Jobs(context.Background(), "default")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(jobs) != 2 {
+		t.Fatalf("expected 2 jobs, got %+v", jobs)
+	}
+
+	sched, retry := jobs[0], jobs[1]
+	if sched.ID != "sched-1" || sched.Set != SetScheduled || !sched.ProcessAt.Equal(at) {
+		t.Fatalf("unexpected scheduled job: %+v", sched)
+	}
+	if sched.Queue != "default" {
+		t.Fatalf("expected the queue name as the default queue, got %q", sched.Queue)
+	}
+	if string(sched.Payload) != "hello" || sched.Retry != 25 {
+		t.Fatalf("unexpected scheduled job message: %+v", sched)
+	}
+	if retry.ID != "retry-1" || retry.Set != SetRetry || !retry.ProcessAt.Equal(at.Add(time.Minute)) {
+		t.Fatalf("unexpected retry job: %+v", retry)
+	}
+	if retry.Retried != 2 || retry.ErrorMsg != "boom" {
+		t.Fatalf("unexpected retry job message: %+v", retry)
+	}
+}
+
+func TestReader_Jobs_pages(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	at := time.Unix(1_900_000_000, 0)
+	total := readBatchSize + 3
+	for i := 0; i < total; i++ {
+		id := fmt.Sprintf("job-%d", i)
+		seedJob(t, mr, "default", SetScheduled, at, id, encodeJob(Job{ID: id}))
+	}
+
+	jobs, err := NewReader(client, zap.NewNop()).Jobs(context.Background(), "default")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(jobs) != total {
+		t.Fatalf("expected %d jobs across pages, got %d", total, len(jobs))
+	}
+}
+
+func TestReader_Jobs_redisError(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	mr.SetError("LOADING")
+	if _, err := NewReader(client, zap.NewNop()).Jobs(context.Background(), "default"); err == nil {
+		t.Fatal("expected an error when Redis fails")
+	}
+}
@@ -0,0 +1,185 @@
+// Package egress restricts where outbound HTTP connections may go, guarding
+// against server-side request forgery through destination URLs.
+package egress
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+	"syscall"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+)
+
+// nonPublic lists the ranges a policy denying private addresses refuses on
+// top of what netip classifies as loopback, private, link-local, multicast
+// or unspecified.
+var nonPublic = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),      // "this" network
+	netip.MustParsePrefix("100.64.0.0/10"),  // carrier-grade NAT
+	netip.MustParsePrefix("192.0.0.0/24"),   // IETF protocol assignments
+	netip.MustParsePrefix("198.18.0.0/15"),  // benchmarking
+	netip.MustParsePrefix("240.0.0.0/4"),    // reserved, and broadcast
+	netip.MustParsePrefix("100::/64"),       // discard
+	netip.MustParsePrefix("2001::/23"),      // IETF protocol assignments
+	netip.MustParsePrefix("2001:db8::/32"),  // documentation
+	netip.MustParsePrefix("64:ff9b:1::/48"), // local-use NAT64
+	netip.MustParsePrefix("fec0::/10"),      // deprecated site-local
+}
+
+// IPv6 ranges that carry an IPv4 address, which decides whether they are
+// public.
+var (
+	nat64     = netip.MustParsePrefix("64:ff9b::/96")
+	sixToFour = netip.MustParsePrefix("2002::/16")
+)
+
+// Policy decides which addresses outbound HTTP connections may reach, so
+// destination URLs submitted through the task API cannot be pointed at
+// internal services. It is checked on the address actually dialed, after
+// name resolution and on every redirect, so DNS tricks do not get past it.
+// A nil Policy allows everything.
+type Policy struct {
+	denyPrivate bool
+
+	hosts    map[string]bool // exact hostnames
+	suffixes []string        // ".example.com" from "*.example.com"
+	prefixes []netip.Prefix
+}
+
+// NewPolicy returns a policy that refuses private, loopback, link-local and
+// other non-public addresses when denyPrivate is set, and, when allow is
+// not empty, every destination not listed in it. Entries of allow are
+// CIDRs, IP addresses, hostnames, or "*.example.com" for any subdomain. A
+// listed CIDR or IP is allowed even when denyPrivate is set; a listed
+// hostname still may not resolve to a private address.
+func NewPolicy(denyPrivate bool, allow []string) *Policy {
+	p := &Policy{denyPrivate: denyPrivate, hosts: make(map[string]bool)}
+	for _, entry := range allow {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		if prefix, err := netip.ParsePrefix(entry); err == nil {
+			p.prefixes = append(p.prefixes, prefix.Masked())
+			continue
+		}
+		if addr, err := netip.ParseAddr(entry); err == nil {
+			p.prefixes = append(p.prefixes, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+			continue
+		}
+		if suffix, ok := strings.CutPrefix(entry, "*"); ok && strings.HasPrefix(suffix, ".") {
+			p.suffixes = append(p.suffixes, suffix)
+			continue
+		}
+		if entry != "" {
+			p.hosts[entry] = true
+		}
+	}
+	return p
+}
+
+// Restricts reports whether the policy refuses any address.
+func (p *Policy) Restricts() bool {
+	return p != nil && (p.denyPrivate || p.allowlisted())
+}
+
+func (p *Policy) allowlisted() bool {
+	return len(p.hosts) > 0 || len(p.suffixes) > 0 || len(p.prefixes) > 0
+}
+
+// Dialer returns a copy of d that refuses to connect to addresses the policy
+// denies for host, the hostname of the destination being dialed. Without
+// restrictions d itself is returned.
+func (p *Policy) Dialer(d *net.Dialer, host string) *net.Dialer {
+	if !p.Restricts() {
+		return d
+	}
+	hostAllowed := p.allowsHost(strings.ToLower(host))
+	restricted := *d
+	restricted.Control = func(_, address string, _ syscall.RawConn) error {
+		addrPort, err := netip.ParseAddrPort(address)
+		if err != nil {
+			return fmt.Errorf("%w: unparsable address %q", domain.ErrEgressDenied, address)
+		}
+		return p.check(host, hostAllowed, addrPort.Addr())
+	}
+	return &restricted
+}
+
+// DialContext returns a dial function for an http.Transport that enforces
+// the policy with dialer d.
+func (p *Policy) DialContext(d *net.Dialer) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		host, _, err := net.SplitHostPort(addr)
+		if err != nil {
+			return nil, err
+		}
+		return p.Dialer(d, host).DialContext(ctx, network, addr)
+	}
+}
+
+// allowsHost reports whether host is listed by name in the allowlist.
+func (p *Policy) allowsHost(host string) bool {
+	if p.hosts[host] {
+		return true
+	}
+	for _, suffix := range p.suffixes {
+		if strings.HasSuffix(host, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// check decides whether host, listed by name when hostAllowed, may be
+// reached at addr.
+func (p *Policy) check(host string, hostAllowed bool, addr netip.Addr) error {
+	addr = addr.Unmap()
+	for _, prefix := range p.prefixes {
+		if prefix.Contains(addr) {
+			return nil
+		}
+	}
+	if p.allowlisted() && !hostAllowed {
+		return fmt.Errorf("%w: %s (%s) is not in the allowlist", domain.ErrEgressDenied, host, addr)
+	}
+	if p.denyPrivate && !public(addr) {
+		return fmt.Errorf("%w: %s resolves to non-public address %s", domain.ErrEgressDenied, host, addr)
+	}
+	return nil
+}
+
+// public reports whether addr is a globally routable unicast address. IPv6
+// addresses embedding an IPv4 one are judged by the IPv4 address.
+func public(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if embedded, ok := embeddedIPv4(addr); ok {
+		return public(embedded)
+	}
+	if addr.IsLoopback() || addr.IsPrivate() || addr.IsUnspecified() ||
+		addr.IsLinkLocalUnicast() || addr.IsMulticast() {
+		return false
+	}
+	for _, prefix := range nonPublic {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// embeddedIPv4 extracts the IPv4 address carried by a NAT64 or 6to4
+// address.
+func embeddedIPv4(addr netip.Addr) (netip.Addr, bool) {
+	if !addr.Is6() {
+		return netip.Addr{}, false
+	}
+	b := addr.As16()
+	switch {
+	case nat64.Contains(addr):
+		return netip.AddrFrom4([4]byte(b[12:16])), true
+	case sixToFour.Contains(addr):
+		return netip.AddrFrom4([4]byte(b[2:6])), true
+	}
+	return netip.Addr{}, false
+}
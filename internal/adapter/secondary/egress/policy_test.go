@@ -0,0 +1,82 @@
+package egress
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+)
+
+func TestPolicy_check(t *testing.T) {
+	tests := []struct {
+		name        string
+		denyPrivate bool
+		allow       []string
+		host        string
+		addr        string
+		wantDenied  bool
+	}{
+		{name: "no policy", host: "localhost", addr: "127.0.0.1"},
+		{name: "public address", denyPrivate: true, host: "api.partner.com", addr: "93.184.216.34"},
+		{name: "loopback", denyPrivate: true, host: "localhost", addr: "127.0.0.1", wantDenied: true},
+		{name: "rfc 1918", denyPrivate: true, host: "internal", addr: "10.1.2.3", wantDenied: true},
+		{name: "cloud metadata", denyPrivate: true, host: "metadata", addr: "169.254.169.254", wantDenied: true},
+		{name: "carrier-grade nat", denyPrivate: true, host: "cgn", addr: "100.64.0.1", wantDenied: true},
+		{name: "ipv6 loopback", denyPrivate: true, host: "localhost", addr: "::1", wantDenied: true},
+		{name: "ipv6 unique local", denyPrivate: true, host: "internal", addr: "fd00::1", wantDenied: true},
+		{name: "ipv6 link-local", denyPrivate: true, host: "internal", addr: "fe80::1", wantDenied: true},
+		{name: "ipv4-mapped loopback", denyPrivate: true, host: "mapped", addr: "::ffff:127.0.0.1", wantDenied: true},
+		{name: "nat64 private", denyPrivate: true, host: "nat64", addr: "64:ff9b::a00:1", wantDenied: true},
+		{name: "6to4 private", denyPrivate: true, host: "6to4", addr: "2002:a00:1::1", wantDenied: true},
+		{name: "public ipv6", denyPrivate: true, host: "api.partner.com", addr: "2606:2800:220:1::1"},
+		{name: "allowed cidr beats deny private", denyPrivate: true, allow: []string{"10.1.0.0/16"}, host: "internal", addr: "10.1.2.3"},
+		{name: "allowed ip", allow: []string{"93.184.216.34"}, host: "api.partner.com", addr: "93.184.216.34"},
+		{name: "not allowlisted", allow: []string{"api.partner.com"}, host: "evil.example.com", addr: "93.184.216.34", wantDenied: true},
+		{name: "allowlisted hostname", allow: []string{"API.partner.com"}, host: "api.partner.com", addr: "93.184.216.34"},
+		{name: "allowlisted wildcard", allow: []string{"*.partner.com"}, host: "hooks.eu.partner.com", addr: "93.184.216.34"},
+		{name: "wildcard skips the apex", allow: []string{"*.partner.com"}, host: "partner.com", addr: "93.184.216.34", wantDenied: true},
+		{name: "allowlisted hostname resolving privately", denyPrivate: true, allow: []string{"api.partner.com"}, host: "api.partner.com", addr: "10.0.0.5", wantDenied: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewPolicy(tt.denyPrivate, tt.allow)
+			err := p.check(tt.host, p.allowsHost(tt.host), netip.MustParseAddr(tt.addr))
+			if tt.wantDenied && !errors.Is(err, domain.ErrEgressDenied) {
+				t.Fatalf("expected ErrEgressDenied, got %v", err)
+			}
+			if !tt.wantDenied && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestPolicy_DialContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	defer server.Close()
+	addr := server.Listener.Addr().String()
+
+	dial := func(p *Policy) error {
+		conn, err := p.DialContext(&net.Dialer{})(context.Background(), "tcp", addr)
+		if err == nil {
+			conn.Close()
+		}
+		return err
+	}
+
+	if err := dial(nil); err != nil {
+		t.Fatalf("expected a nil policy to allow the dial, got %v", err)
+	}
+	if err := dial(NewPolicy(false, []string{"127.0.0.0/8"})); err != nil {
+		t.Fatalf("expected the allowlisted address to be dialed, got %v", err)
+	}
+	if err := dial(NewPolicy(true, nil)); !errors.Is(err, domain.ErrEgressDenied) {
+		t.Fatalf("expected ErrEgressDenied, got %v", err)
+	}
+}
@@ -0,0 +1,113 @@
+package healthprobe
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/adapter/secondary/egress"
+	"github.com/ruudy-sib/rebound/internal/domain"
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+	"github.com/ruudy-sib/rebound/internal/port/secondary"
+)
+
+// Prober implements secondary.HealthProber with a HEAD request for HTTP
+// destinations and a TCP connect for Kafka destinations.
+type Prober struct {
+	client *http.Client
+	dialer *net.Dialer
+	logger *zap.Logger
+}
+
+// Option configures optional Prober behaviour.
+type Option func(*Prober)
+
+// WithEgressPolicy makes HTTP probes obey policy, so a destination the
+// producer may not reach is not probed either.
+func WithEgressPolicy(policy *egress.Policy) Option {
+	return func(p *Prober) {
+		if !policy.Restricts() {
+			return
+		}
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		// A proxy would be dialed instead of the destination, bypassing the policy.
+		transport.Proxy = nil
+		transport.DialContext = policy.DialContext(p.dialer)
+		p.client.Transport = transport
+	}
+}
+
+// NewProber creates a Prober whose probes fail after timeout. A zero
+// timeout uses domain.DefaultProbeTimeout.
+func NewProber(timeout time.Duration, logger *zap.Logger, opts ...Option) secondary.HealthProber {
+	if timeout <= 0 {
+		timeout = domain.DefaultProbeTimeout
+	}
+	p := &Prober{
+		client: &http.Client{
+			Timeout: timeout,
+			// A redirect already proves the destination answers.
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		dialer: &net.Dialer{Timeout: timeout},
+		logger: logger.Named("health-prober"),
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// Probe checks the destination. An HTTP destination is healthy if a HEAD
+// request gets any response below 500, as many endpoints reject HEAD; a
+// Kafka destination if its broker accepts a TCP connection.
+func (p *Prober) Probe(ctx context.Context, destination entity.Destination) error {
+	var err error
+	switch destination.Type() {
+	case entity.DestinationTypeHTTP:
+		err = p.probeHTTP(ctx, destination.URL)
+	case entity.DestinationTypeKafka:
+		err = p.probeTCP(ctx, net.JoinHostPort(destination.Host, destination.Port))
+	default:
+		return fmt.Errorf("unable to determine destination type: neither URL nor Topic is set")
+	}
+
+	p.logger.Debug("destination probed",
+		zap.String("destination", destination.Name()),
+		zap.Bool("healthy", err == nil),
+	)
+	return err
+}
+
+func (p *Prober) probeHTTP(ctx context.Context, url string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
+	if err != nil {
+		return fmt.Errorf("creating probe request: %w", err)
+	}
+	req.Header.Set("User-Agent", "github.com/ruudy-sib/rebound/1.0")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("probing %q: %w", url, err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("probing %q: status %d", url, resp.StatusCode)
+	}
+	return nil
+}
+
+func (p *Prober) probeTCP(ctx context.Context, addr string) error {
+	conn, err := p.dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return fmt.Errorf("probing %q: %w", addr, err)
+	}
+	return conn.Close()
+}
@@ -0,0 +1,93 @@
+package healthprobe
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+func TestProber_Probe_http(t *testing.T) {
+	// behaviour is the status the destination answers with; zero makes it
+	// hang until the probe gives up.
+	var behaviour atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodHead {
+			t.Errorf("expected a HEAD probe, got %s", r.Method)
+		}
+		status := int(behaviour.Load())
+		if status == 0 {
+			<-r.Context().Done()
+			return
+		}
+		if status == http.StatusFound {
+			w.Header().Set("Location", "/elsewhere")
+		}
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(srv.Close)
+
+	prober := NewProber(100*time.Millisecond, zap.NewNop())
+	destination := entity.Destination{URL: srv.URL + "/hook"}
+
+	// Steps run in order against the same destination, so the prober is
+	// seen moving between healthy and unhealthy.
+	steps := []struct {
+		name        string
+		status      int
+		wantHealthy bool
+	}{
+		{name: "healthy", status: http.StatusOK, wantHealthy: true},
+		{name: "unhealthy", status: http.StatusServiceUnavailable},
+		{name: "timeout", status: 0},
+		{name: "recovered", status: http.StatusNoContent, wantHealthy: true},
+		{name: "HEAD rejected", status: http.StatusMethodNotAllowed, wantHealthy: true},
+		{name: "redirect not followed", status: http.StatusFound, wantHealthy: true},
+		{name: "unhealthy again", status: http.StatusBadGateway},
+	}
+	for _, step := range steps {
+		behaviour.Store(int32(step.status))
+
+		start := time.Now()
+		err := prober.Probe(context.Background(), destination)
+		if healthy := err == nil; healthy != step.wantHealthy {
+			t.Fatalf("%s: expected healthy=%v, got error %v", step.name, step.wantHealthy, err)
+		}
+		if elapsed := time.Since(start); elapsed > 2*time.Second {
+			t.Fatalf("%s: probe took %v, expected the timeout to bound it", step.name, elapsed)
+		}
+	}
+}
+
+func TestProber_Probe_kafka(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listening: %v", err)
+	}
+	host, port, _ := net.SplitHostPort(ln.Addr().String())
+	destination := entity.Destination{Host: host, Port: port, Topic: "orders"}
+	prober := NewProber(time.Second, zap.NewNop())
+
+	if err := prober.Probe(context.Background(), destination); err != nil {
+		t.Fatalf("expected a listening broker to be healthy, got %v", err)
+	}
+
+	ln.Close()
+	if err := prober.Probe(context.Background(), destination); err == nil {
+		t.Fatal("expected a closed broker to be unhealthy")
+	}
+}
+
+func TestProber_Probe_unknownType(t *testing.T) {
+	prober := NewProber(time.Second, zap.NewNop())
+	if err := prober.Probe(context.Background(), entity.Destination{}); err == nil {
+		t.Fatal("expected an error for a destination without URL or topic")
+	}
+}
@@ -0,0 +1,73 @@
+package httpproducer
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+// slackMessage is the body of a Slack incoming webhook message.
+type slackMessage struct {
+	Text string `json:"text"`
+}
+
+// teamsMessage is the body of a Teams incoming webhook or workflow message
+// carrying one Adaptive Card.
+type teamsMessage struct {
+	Type        string            `json:"type"`
+	Attachments []teamsAttachment `json:"attachments"`
+}
+
+type teamsAttachment struct {
+	ContentType string    `json:"contentType"`
+	Content     teamsCard `json:"content"`
+}
+
+type teamsCard struct {
+	Schema  string           `json:"$schema"`
+	Type    string           `json:"type"`
+	Version string           `json:"version"`
+	Body    []teamsTextBlock `json:"body"`
+}
+
+type teamsTextBlock struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+	Wrap bool   `json:"wrap"`
+}
+
+// formatPayload wraps value in the chat platform schema named by format.
+func formatPayload(format entity.PayloadFormat, value []byte) ([]byte, error) {
+	text := messageText(value)
+	switch format {
+	case entity.PayloadFormatSlack:
+		return json.Marshal(slackMessage{Text: text})
+	case entity.PayloadFormatTeams:
+		return json.Marshal(teamsMessage{
+			Type: "message",
+			Attachments: []teamsAttachment{{
+				ContentType: "application/vnd.microsoft.card.adaptive",
+				Content: teamsCard{
+					Schema:  "http://adaptivecards.io/schemas/adaptive-card.json",
+					Type:    "AdaptiveCard",
+					Version: "1.4",
+					Body:    []teamsTextBlock{{Type: "TextBlock", Text: text, Wrap: true}},
+				},
+			}},
+		})
+	}
+	return nil, fmt.Errorf("unsupported payload format %q", format)
+}
+
+// messageText returns the "text" field of a JSON object message, or the
+// whole message otherwise.
+func messageText(value []byte) string {
+	var msg struct {
+		Text *string `json:"text"`
+	}
+	if err := json.Unmarshal(value, &msg); err == nil && msg.Text != nil {
+		return *msg.Text
+	}
+	return string(value)
+}
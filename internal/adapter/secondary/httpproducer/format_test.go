@@ -0,0 +1,86 @@
+package httpproducer
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/config"
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+	"github.com/ruudy-sib/rebound/internal/port/secondary"
+)
+
+func TestFormatPayload(t *testing.T) {
+	tests := []struct {
+		name   string
+		format entity.PayloadFormat
+		value  string
+		want   string
+	}{
+		{
+			name:   "slack plain text",
+			format: entity.PayloadFormatSlack,
+			value:  "disk almost full",
+			want:   `{"text":"disk almost full"}`,
+		},
+		{
+			name:   "slack text field",
+			format: entity.PayloadFormatSlack,
+			value:  `{"text":"disk almost full","host":"db-1"}`,
+			want:   `{"text":"disk almost full"}`,
+		},
+		{
+			name:   "slack json without text",
+			format: entity.PayloadFormatSlack,
+			value:  `{"host":"db-1"}`,
+			want:   `{"text":"{\"host\":\"db-1\"}"}`,
+		},
+		{
+			name:   "teams adaptive card",
+			format: entity.PayloadFormatTeams,
+			value:  "disk almost full",
+			want: `{"type":"message","attachments":[{"contentType":"application/vnd.microsoft.card.adaptive","content":` +
+				`{"$schema":"http://adaptivecards.io/schemas/adaptive-card.json","type":"AdaptiveCard","version":"1.4",` +
+				`"body":[{"type":"TextBlock","text":"disk almost full","wrap":true}]}}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := formatPayload(tt.format, []byte(tt.value))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Fatalf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+
+	if _, err := formatPayload("discord", []byte("x")); err == nil {
+		t.Fatal("expected an error for an unsupported format")
+	}
+}
+
+func TestProducer_Produce_payloadFormat(t *testing.T) {
+	var got string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		got = string(b)
+	}))
+	defer server.Close()
+
+	p := NewProducer(&config.Config{}, zap.NewNop())
+	dest := entity.Destination{URL: server.URL, Format: entity.PayloadFormatSlack}
+	if err := p.Produce(context.Background(), dest, secondary.Message{Value: []byte("deploy failed")}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := `{"text":"deploy failed"}`; got != want {
+		t.Fatalf("expected body %s, got %s", want, got)
+	}
+}
@@ -3,79 +3,341 @@ package httpproducer
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"go.uber.org/zap"
 
+	"github.com/ruudy-sib/rebound/internal/adapter/secondary/egress"
 	"github.com/ruudy-sib/rebound/internal/config"
+	"github.com/ruudy-sib/rebound/internal/domain"
 	"github.com/ruudy-sib/rebound/internal/domain/entity"
 	"github.com/ruudy-sib/rebound/internal/port/secondary"
 )
 
-// Producer implements secondary.MessageProducer using HTTP POST requests.
+// Producer implements secondary.MessageProducer and secondary.BatchProducer
+// using HTTP POST requests.
 type Producer struct {
-	client *http.Client
-	logger *zap.Logger
+	client     *http.Client
+	rateLimits *rateLimits
+	metrics    secondary.ProducerMetrics
+	logger     *zap.Logger
+
+	maxRequestBytes  int
+	maxResponseBytes int
+}
+
+// Option configures optional Producer behaviour.
+type Option func(*Producer)
+
+// WithMetrics reports the latency, batch size and response status of every
+// request to metrics.
+func WithMetrics(metrics secondary.ProducerMetrics) Option {
+	return func(p *Producer) {
+		p.metrics = metrics
+	}
 }
 
-// NewProducer creates an HTTP producer with configurable timeout.
-func NewProducer(cfg *config.Config, logger *zap.Logger) secondary.MessageProducer {
+// NewProducer creates an HTTP producer whose requests time out after
+// cfg.DeliveryTimeout. The task service bounds each attempt by the same
+// timeout; the client's own is a backstop for callers that do not. Request
+// bodies over cfg.HTTPMaxRequestBytes are not sent, and at most
+// cfg.HTTPMaxResponseBytes of each response body are read. Connections
+// the egress policy in cfg refuses fail with domain.ErrEgressDenied.
+func NewProducer(cfg *config.Config, logger *zap.Logger, opts ...Option) secondary.MessageProducer {
+	timeout := cfg.DeliveryTimeout
+	if timeout <= 0 {
+		timeout = domain.DefaultDeliveryTimeout
+	}
+	transport := &http.Transport{
+		MaxIdleConns:        100,
+		MaxIdleConnsPerHost: 10,
+		IdleConnTimeout:     90 * time.Second,
+	}
+	policy := egress.NewPolicy(cfg.HTTPEgressDenyPrivate, cfg.HTTPEgressAllow)
+	if cfg.HTTPDNSCacheTTL > 0 || len(cfg.HTTPHostOverrides) > 0 || policy.Restricts() {
+		transport.DialContext = newResolver(cfg.HTTPHostOverrides, cfg.HTTPDNSCacheTTL, policy).DialContext
+	}
 	client := &http.Client{
-		Timeout: 30 * time.Second,
-		Transport: &http.Transport{
-			MaxIdleConns:        100,
-			MaxIdleConnsPerHost: 10,
-			IdleConnTimeout:     90 * time.Second,
-		},
+		Timeout:   timeout,
+		Transport: transport,
+	}
+
+	p := &Producer{
+		client:     client,
+		rateLimits: newRateLimits(),
+		logger:     logger.Named("http-producer"),
+
+		maxRequestBytes:  cfg.HTTPMaxRequestBytes,
+		maxResponseBytes: cfg.HTTPMaxResponseBytes,
+	}
+	if p.maxRequestBytes <= 0 {
+		p.maxRequestBytes = domain.DefaultHTTPMaxRequestBytes
+	}
+	if p.maxResponseBytes <= 0 {
+		p.maxResponseBytes = domain.DefaultHTTPMaxResponseBytes
 	}
 
 	logger.Info("http producer initialized",
 		zap.Duration("timeout", client.Timeout),
+		zap.Int("max_request_bytes", p.maxRequestBytes),
+		zap.Int("max_response_bytes", p.maxResponseBytes),
+		zap.Duration("dns_cache_ttl", cfg.HTTPDNSCacheTTL),
+		zap.Int("host_overrides", len(cfg.HTTPHostOverrides)),
+		zap.Bool("egress_deny_private", cfg.HTTPEgressDenyPrivate),
+		zap.Int("egress_allow", len(cfg.HTTPEgressAllow)),
 	)
 
-	return &Producer{
-		client: client,
-		logger: logger.Named("http-producer"),
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// Produce sends a message via HTTP POST to the destination URL, wrapped in
+// the destination's payload format if it has one. Task metadata is sent as
+// X-Metadata-<key> headers and transport headers as they are.
+func (p *Producer) Produce(ctx context.Context, destination entity.Destination, msg secondary.Message) error {
+	_, err := p.ProduceWithReceipt(ctx, destination, msg)
+	return err
+}
+
+// ProduceWithReceipt sends a message like Produce and returns the response
+// status and the first domain.DeliveryResultBodyLimit bytes of its body.
+func (p *Producer) ProduceWithReceipt(ctx context.Context, destination entity.Destination, msg secondary.Message) (entity.DeliveryReceipt, error) {
+	if destination.URL == "" {
+		return entity.DeliveryReceipt{}, fmt.Errorf("destination URL is required for HTTP delivery")
 	}
+
+	headers := map[string]string{
+		"X-Message-Key": string(msg.Key),
+	}
+	for k, v := range msg.Headers {
+		headers[metadataHeaderPrefix+k] = v
+	}
+	if msg.Priority {
+		headers[priorityHeader] = "true"
+	}
+	maps.Copy(headers, msg.TransportHeaders)
+
+	body := msg.Value
+	if destination.Format != "" {
+		var err error
+		if body, err = formatPayload(destination.Format, msg.Value); err != nil {
+			return entity.DeliveryReceipt{}, err
+		}
+	}
+
+	receipt, err := p.post(ctx, destination.URL, 1, body, headers)
+	if err != nil {
+		return entity.DeliveryReceipt{}, err
+	}
+
+	p.logger.Debug("message produced via http",
+		zap.String("url", destination.URL),
+		zap.Int("value_size", len(body)),
+	)
+
+	return receipt, nil
+}
+
+// metadataHeaderPrefix prefixes the header carrying each metadata entry.
+const metadataHeaderPrefix = "X-Metadata-"
+
+// priorityHeader marks requests carrying a priority task's message, so the
+// destination, or a gateway in front of it, can exempt them from its rate
+// limits.
+const priorityHeader = "X-Rebound-Priority"
+
+// batchItem is one element of the JSON array body sent by ProduceBatch.
+// Data is embedded as raw JSON when the message is valid JSON and as a
+// JSON string otherwise. Each message's metadata and correlation ID travel
+// in the item, as headers cannot differ per message.
+type batchItem struct {
+	Key           string            `json:"key"`
+	Data          json.RawMessage   `json:"data"`
+	Metadata      map[string]string `json:"metadata,omitempty"`
+	CorrelationID string            `json:"correlation_id,omitempty"`
 }
 
-// Produce sends a message via HTTP POST to the destination URL.
-func (p *Producer) Produce(ctx context.Context, destination entity.Destination, key, value []byte) error {
+// ProduceBatch sends all messages to the destination URL in a single POST
+// whose body is a JSON array. Message keys are also listed, comma separated,
+// in the X-Message-Keys header. Transport headers of all messages are sent
+// on the request, a later message's value winning over an earlier one,
+// except X-Correlation-ID, which is sent per item.
+func (p *Producer) ProduceBatch(ctx context.Context, destination entity.Destination, messages []secondary.Message) error {
 	if destination.URL == "" {
 		return fmt.Errorf("destination URL is required for HTTP delivery")
 	}
+	if destination.Format != "" {
+		return fmt.Errorf("payload format %q cannot be batched", destination.Format)
+	}
+
+	items := make([]batchItem, len(messages))
+	keys := make([]string, len(messages))
+	for i, m := range messages {
+		data := json.RawMessage(m.Value)
+		if !json.Valid(m.Value) {
+			quoted, err := json.Marshal(string(m.Value))
+			if err != nil {
+				return fmt.Errorf("encoding batch message: %w", err)
+			}
+			data = quoted
+		}
+		items[i] = batchItem{
+			Key:           string(m.Key),
+			Data:          data,
+			Metadata:      m.Headers,
+			CorrelationID: m.TransportHeaders[domain.CorrelationIDHeader],
+		}
+		keys[i] = string(m.Key)
+	}
+
+	body, err := json.Marshal(items)
+	if err != nil {
+		return fmt.Errorf("encoding batch body: %w", err)
+	}
+
+	headers := make(map[string]string)
+	for _, m := range messages {
+		if m.Priority {
+			headers[priorityHeader] = "true"
+		}
+		maps.Copy(headers, m.TransportHeaders)
+	}
+	delete(headers, domain.CorrelationIDHeader)
+	headers["X-Message-Keys"] = strings.Join(keys, ",")
+	headers["X-Rebound-Batch"] = strconv.Itoa(len(messages))
+
+	if _, err := p.post(ctx, destination.URL, len(messages), body, headers); err != nil {
+		return err
+	}
+
+	p.logger.Debug("message batch produced via http",
+		zap.String("url", destination.URL),
+		zap.Int("batch_size", len(messages)),
+		zap.Int("body_size", len(body)),
+	)
+
+	return nil
+}
+
+// post issues the HTTP request carrying messages messages and treats any
+// non-2xx status as a failure, passing on the Retry-After of a 429 or 503.
+// A body over the request size limit is not sent; a response body over the
+// response size limit is cut off there, which does not fail a delivery the
+// destination accepted.
+func (p *Producer) post(ctx context.Context, url string, messages int, body []byte, headers map[string]string) (_ entity.DeliveryReceipt, err error) {
+	write := entity.ProducerWrite{
+		DestinationType: entity.DestinationTypeHTTP,
+		Destination:     url,
+		Messages:        messages,
+	}
+	if len(body) > p.maxRequestBytes {
+		write.Failed, write.Oversized = true, true
+		p.observe(write)
+		return entity.DeliveryReceipt{}, &entity.DeliveryError{
+			Code: entity.ErrorCodePayloadTooLarge,
+			Err:  fmt.Errorf("%w: %d bytes exceeds the %d byte limit", domain.ErrRequestTooLarge, len(body), p.maxRequestBytes),
+		}
+	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, destination.URL, bytes.NewReader(value))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
 	if err != nil {
-		return fmt.Errorf("creating http request: %w", err)
+		return entity.DeliveryReceipt{}, fmt.Errorf("creating http request: %w", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("X-Message-Key", string(key))
 	req.Header.Set("User-Agent", "github.com/ruudy-sib/rebound/1.0")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	start := time.Now()
+	defer func() {
+		write.Latency = time.Since(start)
+		write.Failed = err != nil
+		p.observe(write)
+	}()
 
 	resp, err := p.client.Do(req)
+	if errors.Is(err, domain.ErrEgressDenied) {
+		return entity.DeliveryReceipt{}, &entity.DeliveryError{
+			Code: entity.ErrorCodeEgressDenied,
+			Err:  fmt.Errorf("executing http request to %q: %w", url, err),
+		}
+	}
 	if err != nil {
-		return fmt.Errorf("executing http request to %q: %w", destination.URL, err)
+		return entity.DeliveryReceipt{}, fmt.Errorf("executing http request to %q: %w", url, err)
 	}
 	defer resp.Body.Close()
+	write.HTTPStatus = resp.StatusCode
 
-	body, _ := io.ReadAll(resp.Body)
+	p.rateLimits.observe(url, resp.StatusCode, resp.Header)
+
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, int64(p.maxResponseBytes)+1))
+	if len(respBody) > p.maxResponseBytes {
+		respBody = respBody[:p.maxResponseBytes]
+		write.Truncated = true
+		p.logger.Warn("http response body exceeds size limit, rest discarded",
+			zap.String("url", url),
+			zap.Int("status", resp.StatusCode),
+			zap.Int("max_response_bytes", p.maxResponseBytes),
+		)
+	}
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return fmt.Errorf("http request failed with status %d: %s", resp.StatusCode, string(body))
+		return entity.DeliveryReceipt{}, &entity.DeliveryError{
+			Code: statusErrorCode(resp.StatusCode),
+			Err:  &entity.StatusError{StatusCode: resp.StatusCode, Body: string(respBody)},
+
+			RetryAfter: retryAfter(resp.StatusCode, resp.Header, p.rateLimits.nowFn()),
+		}
 	}
 
-	p.logger.Debug("message produced via http",
-		zap.String("url", destination.URL),
-		zap.Int("status_code", resp.StatusCode),
-		zap.Int("value_size", len(value)),
-	)
+	if len(respBody) > domain.DeliveryResultBodyLimit {
+		respBody = respBody[:domain.DeliveryResultBodyLimit]
+	}
+	return entity.DeliveryReceipt{
+		HTTPStatus:   resp.StatusCode,
+		ResponseBody: strings.ToValidUTF8(string(respBody), ""),
+		Partition:    -1,
+		Offset:       -1,
+	}, nil
+}
 
-	return nil
+// observe reports a request to the metrics, if any.
+func (p *Producer) observe(write entity.ProducerWrite) {
+	if p.metrics == nil {
+		return
+	}
+	p.metrics.ObserveWrite(write)
+}
+
+// statusErrorCode classifies a non-2xx response status.
+func statusErrorCode(status int) entity.ErrorCode {
+	switch {
+	case status == http.StatusUnauthorized, status == http.StatusForbidden:
+		return entity.ErrorCodeAuthFailed
+	case status >= 500:
+		return entity.ErrorCodeHTTP5xx
+	case status >= 400:
+		return entity.ErrorCodeHTTP4xx
+	}
+	return entity.ErrorCodeUnknown
+}
+
+// RateLimitedFor returns how long until the destination's rate-limit window
+// resets if its X-RateLimit-Remaining budget is spent, and zero otherwise.
+func (p *Producer) RateLimitedFor(destination entity.Destination) time.Duration {
+	return p.rateLimits.wait(destination.URL)
 }
 
 // Close releases resources.
@@ -0,0 +1,266 @@
+package httpproducer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/config"
+	"github.com/ruudy-sib/rebound/internal/domain"
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+	"github.com/ruudy-sib/rebound/internal/port/secondary"
+)
+
+func TestStatusErrorCode(t *testing.T) {
+	tests := []struct {
+		status int
+		want   entity.ErrorCode
+	}{
+		{http.StatusBadRequest, entity.ErrorCodeHTTP4xx},
+		{http.StatusUnauthorized, entity.ErrorCodeAuthFailed},
+		{http.StatusForbidden, entity.ErrorCodeAuthFailed},
+		{http.StatusTooManyRequests, entity.ErrorCodeHTTP4xx},
+		{http.StatusInternalServerError, entity.ErrorCodeHTTP5xx},
+		{http.StatusServiceUnavailable, entity.ErrorCodeHTTP5xx},
+		{http.StatusFound, entity.ErrorCodeUnknown},
+	}
+
+	for _, tt := range tests {
+		if got := statusErrorCode(tt.status); got != tt.want {
+			t.Errorf("status %d: expected %s, got %s", tt.status, tt.want, got)
+		}
+	}
+}
+
+func TestProducer_ProduceWithReceipt(t *testing.T) {
+	body := strings.Repeat("x", domain.DeliveryResultBodyLimit+10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	p := NewProducer(&config.Config{}, zap.NewNop()).(*Producer)
+	receipt, err := p.ProduceWithReceipt(context.Background(), entity.Destination{URL: server.URL}, secondary.Message{Value: []byte("{}")})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if receipt.HTTPStatus != http.StatusAccepted {
+		t.Fatalf("expected status 202, got %d", receipt.HTTPStatus)
+	}
+	if receipt.ResponseBody != body[:domain.DeliveryResultBodyLimit] {
+		t.Fatalf("expected body truncated to %d bytes, got %d", domain.DeliveryResultBodyLimit, len(receipt.ResponseBody))
+	}
+}
+
+func TestProducer_ProduceBatch_correlationID(t *testing.T) {
+	var header string
+	var items []batchItem
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Get(domain.CorrelationIDHeader)
+		_ = json.NewDecoder(r.Body).Decode(&items)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	p := NewProducer(&config.Config{}, zap.NewNop()).(*Producer)
+	messages := []secondary.Message{
+		{Key: []byte("a"), Value: []byte("{}"), TransportHeaders: map[string]string{domain.CorrelationIDHeader: "txn-1"}},
+		{Key: []byte("b"), Value: []byte("{}")},
+	}
+	if err := p.ProduceBatch(context.Background(), entity.Destination{URL: server.URL}, messages); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if header != "" {
+		t.Fatalf("expected no batch-wide correlation header, got %q", header)
+	}
+	if len(items) != 2 || items[0].CorrelationID != "txn-1" || items[1].CorrelationID != "" {
+		t.Fatalf("expected per-item correlation IDs, got %+v", items)
+	}
+}
+
+func TestProducer_priorityHeader(t *testing.T) {
+	var got []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.Header.Get(priorityHeader))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	p := NewProducer(&config.Config{}, zap.NewNop()).(*Producer)
+	dest := entity.Destination{URL: server.URL}
+	for _, priority := range []bool{true, false} {
+		if err := p.Produce(context.Background(), dest, secondary.Message{Value: []byte("{}"), Priority: priority}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	batch := []secondary.Message{{Value: []byte("{}")}, {Value: []byte("{}"), Priority: true}}
+	if err := p.ProduceBatch(context.Background(), dest, batch); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := []string{"true", "", "true"}; !slices.Equal(got, want) {
+		t.Fatalf("expected priority headers %q, got %q", want, got)
+	}
+}
+
+type recordingMetrics struct {
+	writes []entity.ProducerWrite
+}
+
+func (m *recordingMetrics) ObserveWrite(write entity.ProducerWrite) {
+	m.writes = append(m.writes, write)
+}
+
+func TestProducer_metrics(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Rebound-Batch") != "" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	metrics := &recordingMetrics{}
+	p := NewProducer(&config.Config{}, zap.NewNop(), WithMetrics(metrics)).(*Producer)
+	dest := entity.Destination{URL: server.URL}
+
+	if err := p.Produce(context.Background(), dest, secondary.Message{Value: []byte("{}")}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	batch := []secondary.Message{{Value: []byte("{}")}, {Value: []byte("{}")}}
+	if err := p.ProduceBatch(context.Background(), dest, batch); err == nil {
+		t.Fatal("expected the batch to fail")
+	}
+
+	if len(metrics.writes) != 2 {
+		t.Fatalf("expected 2 observed writes, got %d", len(metrics.writes))
+	}
+	single, failed := metrics.writes[0], metrics.writes[1]
+	if single.Destination != server.URL || single.Messages != 1 || single.HTTPStatus != http.StatusOK || single.Failed {
+		t.Fatalf("unexpected single write: %+v", single)
+	}
+	if failed.Messages != 2 || failed.HTTPStatus != http.StatusServiceUnavailable || !failed.Failed {
+		t.Fatalf("unexpected batch write: %+v", failed)
+	}
+	if single.DestinationType != entity.DestinationTypeHTTP || single.Latency <= 0 {
+		t.Fatalf("expected an http write with a latency, got %+v", single)
+	}
+}
+
+func TestProducer_sizeLimits(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		status := http.StatusOK
+		if r.Header.Get("X-Message-Key") == "fail" {
+			status = http.StatusBadGateway
+		}
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(strings.Repeat("x", 100)))
+	}))
+	defer server.Close()
+
+	metrics := &recordingMetrics{}
+	cfg := &config.Config{HTTPMaxRequestBytes: 16, HTTPMaxResponseBytes: 10}
+	p := NewProducer(cfg, zap.NewNop(), WithMetrics(metrics)).(*Producer)
+	dest := entity.Destination{URL: server.URL}
+
+	err := p.Produce(context.Background(), dest, secondary.Message{Value: []byte(strings.Repeat("y", 17))})
+	var deliveryErr *entity.DeliveryError
+	if !errors.As(err, &deliveryErr) || deliveryErr.Code != entity.ErrorCodePayloadTooLarge || !errors.Is(err, domain.ErrRequestTooLarge) {
+		t.Fatalf("expected a PAYLOAD_TOO_LARGE delivery error, got %v", err)
+	}
+	if requests != 0 {
+		t.Fatalf("expected the oversized request not to be sent, got %d requests", requests)
+	}
+
+	receipt, err := p.ProduceWithReceipt(context.Background(), dest, secondary.Message{Value: []byte("{}")})
+	if err != nil {
+		t.Fatalf("expected a truncated response not to fail the delivery, got %v", err)
+	}
+	if receipt.ResponseBody != strings.Repeat("x", 10) {
+		t.Fatalf("expected the response body cut off at 10 bytes, got %q", receipt.ResponseBody)
+	}
+
+	err = p.Produce(context.Background(), dest, secondary.Message{Key: []byte("fail"), Value: []byte("{}")})
+	if err == nil || !strings.HasSuffix(err.Error(), ": "+strings.Repeat("x", 10)) {
+		t.Fatalf("expected the error to carry the truncated body, got %v", err)
+	}
+
+	if len(metrics.writes) != 3 {
+		t.Fatalf("expected 3 observed writes, got %d", len(metrics.writes))
+	}
+	oversized, truncated := metrics.writes[0], metrics.writes[1]
+	if !oversized.Oversized || !oversized.Failed || oversized.HTTPStatus != 0 || oversized.Truncated {
+		t.Fatalf("unexpected oversized write: %+v", oversized)
+	}
+	if !truncated.Truncated || truncated.Failed || truncated.Oversized {
+		t.Fatalf("unexpected truncated write: %+v", truncated)
+	}
+}
+
+func TestProducer_statusError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = w.Write([]byte("schema mismatch"))
+	}))
+	defer server.Close()
+
+	p := NewProducer(&config.Config{}, zap.NewNop())
+	err := p.Produce(context.Background(), entity.Destination{URL: server.URL}, secondary.Message{Value: []byte("{}")})
+
+	var statusErr *entity.StatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusUnprocessableEntity || statusErr.Body != "schema mismatch" {
+		t.Fatalf("expected a 422 status error, got %+v", err)
+	}
+	if want := "http request failed with status 422: schema mismatch"; err.Error() != want {
+		t.Fatalf("expected %q, got %q", want, err.Error())
+	}
+}
+
+func TestProducer_retryAfter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "45")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	p := NewProducer(&config.Config{}, zap.NewNop())
+	err := p.Produce(context.Background(), entity.Destination{URL: server.URL}, secondary.Message{Value: []byte("{}")})
+
+	var deliveryErr *entity.DeliveryError
+	if !errors.As(err, &deliveryErr) || deliveryErr.RetryAfter != 45*time.Second {
+		t.Fatalf("expected a delivery error asking for 45s, got %+v", err)
+	}
+}
+
+func TestProducer_egressDenied(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		requests++
+	}))
+	defer server.Close()
+
+	p := NewProducer(&config.Config{HTTPEgressDenyPrivate: true}, zap.NewNop())
+	err := p.Produce(context.Background(), entity.Destination{URL: server.URL}, secondary.Message{Value: []byte("{}")})
+
+	var deliveryErr *entity.DeliveryError
+	if !errors.As(err, &deliveryErr) || deliveryErr.Code != entity.ErrorCodeEgressDenied || !errors.Is(err, domain.ErrEgressDenied) {
+		t.Fatalf("expected an EGRESS_DENIED delivery error, got %v", err)
+	}
+	if requests != 0 {
+		t.Fatalf("expected no request to reach the loopback server, got %d", requests)
+	}
+}
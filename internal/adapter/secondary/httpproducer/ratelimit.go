@@ -0,0 +1,145 @@
+package httpproducer
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+)
+
+// Rate-limit response headers. Reset is read either as seconds until the
+// window resets or, for values that can only be timestamps, as Unix time.
+const (
+	headerRateLimitRemaining = "X-RateLimit-Remaining"
+	headerRateLimitReset     = "X-RateLimit-Reset"
+	headerRetryAfter         = "Retry-After"
+)
+
+// minUnixReset separates Unix-time resets from delta-seconds resets: no
+// window lasts this many seconds.
+const minUnixReset = 1_000_000_000
+
+// maxRateLimits bounds the destination URLs whose spent budget is
+// remembered at once.
+const maxRateLimits = 10_000
+
+// rateLimits remembers, per destination URL, a rate-limit window the
+// destination reported as spent. Windows with budget left are not kept.
+type rateLimits struct {
+	mu    sync.Mutex
+	byURL map[string]rateLimit
+	nowFn func() time.Time
+}
+
+type rateLimit struct {
+	remaining int
+	reset     time.Time
+}
+
+func newRateLimits() *rateLimits {
+	return &rateLimits{byURL: make(map[string]rateLimit), nowFn: time.Now}
+}
+
+// observe records the rate-limit headers of a response from url. Responses
+// without a reset time are ignored. A 429 without a remaining count is
+// taken to mean the budget is spent.
+func (r *rateLimits) observe(url string, status int, header http.Header) {
+	reset, ok := parseReset(header.Get(headerRateLimitReset), r.nowFn())
+	if !ok {
+		return
+	}
+
+	remaining, err := strconv.Atoi(header.Get(headerRateLimitRemaining))
+	if err != nil {
+		if status != http.StatusTooManyRequests {
+			return
+		}
+		remaining = 0
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if remaining > 0 {
+		delete(r.byURL, url)
+		return
+	}
+	if _, ok := r.byURL[url]; !ok && len(r.byURL) >= maxRateLimits {
+		r.evict()
+	}
+	r.byURL[url] = rateLimit{remaining: remaining, reset: reset}
+}
+
+// evict drops every window that has reset, or the one resetting soonest
+// if none has. The caller holds r.mu.
+func (r *rateLimits) evict() {
+	now := r.nowFn()
+	var soonest string
+	for url, limit := range r.byURL {
+		if !limit.reset.After(now) {
+			delete(r.byURL, url)
+			continue
+		}
+		if soonest == "" || limit.reset.Before(r.byURL[soonest].reset) {
+			soonest = url
+		}
+	}
+	if len(r.byURL) >= maxRateLimits {
+		delete(r.byURL, soonest)
+	}
+}
+
+// wait returns how long until url's window resets if its budget is spent,
+// and zero otherwise.
+func (r *rateLimits) wait(url string) time.Duration {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	limit, ok := r.byURL[url]
+	if !ok {
+		return 0
+	}
+	wait := limit.reset.Sub(r.nowFn())
+	if wait <= 0 {
+		delete(r.byURL, url)
+		return 0
+	}
+	return wait
+}
+
+func parseReset(value string, now time.Time) (time.Time, bool) {
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || n < 0 {
+		return time.Time{}, false
+	}
+	if n >= minUnixReset {
+		return time.Unix(n, 0), true
+	}
+	return now.Add(time.Duration(n) * time.Second), true
+}
+
+// retryAfter returns how long a 429 or 503 response asks the client to wait
+// in its Retry-After header, given as seconds or an HTTP date, capped at
+// domain.MaxRetryAfter. Other responses, and headers that do not parse or
+// lie in the past, give zero.
+func retryAfter(status int, header http.Header, now time.Time) time.Duration {
+	if status != http.StatusTooManyRequests && status != http.StatusServiceUnavailable {
+		return 0
+	}
+	value := header.Get(headerRetryAfter)
+	if value == "" {
+		return 0
+	}
+
+	var wait time.Duration
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if seconds <= 0 {
+			return 0
+		}
+		wait = time.Duration(min(seconds, int64(domain.MaxRetryAfter/time.Second))) * time.Second
+	} else if at, err := http.ParseTime(value); err == nil {
+		wait = at.Sub(now)
+	}
+	return min(max(wait, 0), domain.MaxRetryAfter)
+}
@@ -0,0 +1,127 @@
+package httpproducer
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestRateLimits(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	const url = "http://localhost/hook"
+
+	tests := []struct {
+		name      string
+		status    int
+		remaining string
+		reset     string
+		want      time.Duration
+	}{
+		{name: "budget left", status: http.StatusOK, remaining: "3", reset: "30"},
+		{name: "budget spent", status: http.StatusOK, remaining: "0", reset: "30", want: 30 * time.Second},
+		{name: "unix reset", status: http.StatusOK, remaining: "0", reset: strconv.FormatInt(now.Add(time.Minute).Unix(), 10), want: time.Minute},
+		{name: "429 without remaining", status: http.StatusTooManyRequests, reset: "10", want: 10 * time.Second},
+		{name: "200 without remaining", status: http.StatusOK, reset: "10"},
+		{name: "no reset", status: http.StatusTooManyRequests, remaining: "0"},
+		{name: "reset passed", status: http.StatusOK, remaining: "0", reset: "0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limits := newRateLimits()
+			limits.nowFn = func() time.Time { return now }
+
+			header := http.Header{}
+			if tt.remaining != "" {
+				header.Set(headerRateLimitRemaining, tt.remaining)
+			}
+			if tt.reset != "" {
+				header.Set(headerRateLimitReset, tt.reset)
+			}
+			limits.observe(url, tt.status, header)
+
+			if got := limits.wait(url); got != tt.want {
+				t.Fatalf("wait() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRateLimits_bounded(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	limits := newRateLimits()
+	limits.nowFn = func() time.Time { return now }
+	header := func(remaining, reset string) http.Header {
+		h := http.Header{}
+		h.Set(headerRateLimitRemaining, remaining)
+		h.Set(headerRateLimitReset, reset)
+		return h
+	}
+	spent := func(reset string) http.Header { return header("0", reset) }
+
+	t.Run("budget left forgets the window", func(t *testing.T) {
+		limits.observe("http://a/hook", http.StatusOK, spent("30"))
+		if limits.wait("http://a/hook") != 30*time.Second {
+			t.Fatal("expected the spent window kept")
+		}
+		limits.observe("http://a/hook", http.StatusOK, header("5", "30"))
+		if len(limits.byURL) != 0 || limits.wait("http://a/hook") != 0 {
+			t.Fatalf("expected the window dropped, got %+v", limits.byURL)
+		}
+	})
+
+	t.Run("reset windows are evicted first", func(t *testing.T) {
+		for i := 0; i < maxRateLimits; i++ {
+			limits.observe("http://full/"+strconv.Itoa(i), http.StatusOK, spent("60"))
+		}
+		now = now.Add(2 * time.Minute)
+		limits.observe("http://new/hook", http.StatusOK, spent("30"))
+		if len(limits.byURL) != 1 || limits.wait("http://new/hook") != 30*time.Second {
+			t.Fatalf("expected only the new window kept, got %d windows", len(limits.byURL))
+		}
+	})
+
+	t.Run("the soonest reset is evicted when none has passed", func(t *testing.T) {
+		for i := 1; i < maxRateLimits; i++ {
+			limits.observe("http://full/"+strconv.Itoa(i), http.StatusOK, spent(strconv.Itoa(60+i)))
+		}
+		limits.observe("http://late/hook", http.StatusOK, spent("600"))
+		if len(limits.byURL) != maxRateLimits {
+			t.Fatalf("expected %d windows, got %d", maxRateLimits, len(limits.byURL))
+		}
+		if limits.wait("http://new/hook") != 0 || limits.wait("http://late/hook") != 10*time.Minute {
+			t.Fatal("expected the soonest window evicted for the new one")
+		}
+	})
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		status int
+		value  string
+		want   time.Duration
+	}{
+		{name: "seconds on 429", status: http.StatusTooManyRequests, value: "30", want: 30 * time.Second},
+		{name: "seconds on 503", status: http.StatusServiceUnavailable, value: "120", want: 2 * time.Minute},
+		{name: "http date", status: http.StatusServiceUnavailable, value: now.Add(90 * time.Second).Format(http.TimeFormat), want: 90 * time.Second},
+		{name: "date passed", status: http.StatusTooManyRequests, value: now.Add(-time.Minute).Format(http.TimeFormat)},
+		{name: "capped", status: http.StatusTooManyRequests, value: "86400", want: time.Hour},
+		{name: "ignored on other statuses", status: http.StatusBadGateway, value: "30"},
+		{name: "unparsable", status: http.StatusTooManyRequests, value: "soon"},
+		{name: "missing", status: http.StatusTooManyRequests},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			if tt.value != "" {
+				header.Set(headerRetryAfter, tt.value)
+			}
+			if got := retryAfter(tt.status, header, now); got != tt.want {
+				t.Fatalf("retryAfter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package httpproducer
+
+import (
+	"context"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ruudy-sib/rebound/internal/adapter/secondary/egress"
+)
+
+// resolver dials destinations for the delivery client. Hosts listed in
+// overrides are dialed at their replacement, a hostname or IP, instead;
+// the request keeps its Host header and TLS server name. With a positive
+// ttl, DNS answers are cached so mass retries to one hostname do not
+// resolve it on every new connection. Every connection, to an override
+// or not, is subject to the egress policy of the destination's hostname.
+type resolver struct {
+	dialer    *net.Dialer
+	egress    *egress.Policy
+	lookup    func(ctx context.Context, host string) ([]string, error)
+	overrides map[string]string
+	ttl       time.Duration
+	nowFn     func() time.Time
+
+	mu    sync.Mutex
+	cache map[string]cachedAddrs
+}
+
+type cachedAddrs struct {
+	addrs   []string
+	expires time.Time
+}
+
+func newResolver(overrides map[string]string, ttl time.Duration, policy *egress.Policy) *resolver {
+	normalized := make(map[string]string, len(overrides))
+	for host, target := range overrides {
+		normalized[strings.ToLower(host)] = target
+	}
+	return &resolver{
+		dialer:    &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second},
+		egress:    policy,
+		lookup:    net.DefaultResolver.LookupHost,
+		overrides: normalized,
+		ttl:       ttl,
+		nowFn:     time.Now,
+		cache:     make(map[string]cachedAddrs),
+	}
+}
+
+// DialContext dials addr, a host and port, applying the host overrides and
+// the DNS cache. Each cached address is tried in turn; when none accepts
+// the connection the answer is dropped so the next dial resolves afresh.
+func (r *resolver) DialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, err
+	}
+	host = strings.ToLower(host)
+	dialer := r.egress.Dialer(r.dialer, host)
+	if target, ok := r.overrides[host]; ok {
+		host = target
+	}
+	if r.ttl <= 0 || net.ParseIP(host) != nil {
+		return dialer.DialContext(ctx, network, net.JoinHostPort(host, port))
+	}
+
+	addrs, err := r.resolve(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	var firstErr error
+	for _, ip := range addrs {
+		conn, err := dialer.DialContext(ctx, network, net.JoinHostPort(ip, port))
+		if err == nil {
+			return conn, nil
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	r.forget(host)
+	return nil, firstErr
+}
+
+// resolve returns the cached addresses of host, looking it up once the
+// cached answer has expired.
+func (r *resolver) resolve(ctx context.Context, host string) ([]string, error) {
+	now := r.nowFn()
+	r.mu.Lock()
+	cached, ok := r.cache[host]
+	r.mu.Unlock()
+	if ok && now.Before(cached.expires) {
+		return cached.addrs, nil
+	}
+
+	addrs, err := r.lookup(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.forgetExpired(now)
+	r.cache[host] = cachedAddrs{addrs: addrs, expires: now.Add(r.ttl)}
+	return addrs, nil
+}
+
+func (r *resolver) forget(host string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.cache, host)
+}
+
+// forgetExpired drops expired answers, so the cache only holds hostnames
+// still being delivered to. Callers hold mu.
+func (r *resolver) forgetExpired(now time.Time) {
+	for host, cached := range r.cache {
+		if !now.Before(cached.expires) {
+			delete(r.cache, host)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package httpproducer
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestResolver_DialContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {}))
+	defer server.Close()
+	serverURL, _ := url.Parse(server.URL)
+	_, port, _ := net.SplitHostPort(serverURL.Host)
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	lookups := map[string]int{}
+	answers := map[string][]string{"api.partner.com": {"127.0.0.1"}}
+
+	r := newResolver(map[string]string{"Staging.Example.com": "api.partner.com"}, time.Minute, nil)
+	r.nowFn = func() time.Time { return now }
+	r.lookup = func(_ context.Context, host string) ([]string, error) {
+		lookups[host]++
+		return answers[host], nil
+	}
+
+	dial := func(host string) error {
+		t.Helper()
+		conn, err := r.DialContext(context.Background(), "tcp", net.JoinHostPort(host, port))
+		if err == nil {
+			conn.Close()
+		}
+		return err
+	}
+
+	for i := 0; i < 3; i++ {
+		if err := dial("api.partner.com"); err != nil {
+			t.Fatalf("dial: %v", err)
+		}
+	}
+	if err := dial("staging.example.com"); err != nil {
+		t.Fatalf("dial through override: %v", err)
+	}
+	if lookups["api.partner.com"] != 1 || lookups["staging.example.com"] != 0 {
+		t.Fatalf("expected one cached lookup of the override target, got %v", lookups)
+	}
+
+	now = now.Add(time.Minute)
+	if err := dial("api.partner.com"); err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	if lookups["api.partner.com"] != 2 {
+		t.Fatalf("expected the expired answer to be looked up again, got %v", lookups)
+	}
+
+	// An answer no address of which accepts connections is dropped.
+	server.Close()
+	if err := dial("api.partner.com"); err == nil {
+		t.Fatal("expected the dial to fail")
+	}
+	if _, ok := r.cache["api.partner.com"]; ok {
+		t.Fatal("expected the failing answer to be forgotten")
+	}
+}
+
+func TestResolver_DialContext_noCache(t *testing.T) {
+	r := newResolver(nil, 0, nil)
+	r.lookup = func(context.Context, string) ([]string, error) {
+		t.Fatal("expected no lookup without a cache TTL")
+		return nil, nil
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer listener.Close()
+
+	conn, err := r.DialContext(context.Background(), "tcp", listener.Addr().String())
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	conn.Close()
+}
@@ -0,0 +1,206 @@
+// Package idgen generates IDs for tasks created without one. The IDs of
+// every strategy sort, as strings, in the order they were generated, so
+// downstream systems that index by ID keep tasks in creation order.
+package idgen
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Strategies accepted by New.
+const (
+	// StrategyUUIDv7 generates RFC 9562 version 7 UUIDs.
+	StrategyUUIDv7 = "uuidv7"
+	// StrategyULID generates ULIDs: 26 Crockford base32 characters.
+	StrategyULID = "ulid"
+	// StrategySnowflake generates 64-bit snowflake IDs, written as 19
+	// decimal digits, from a millisecond timestamp, the node ID and a
+	// sequence number. Each instance needs its own node ID.
+	StrategySnowflake = "snowflake"
+)
+
+// MaxNodeID is the largest node ID a snowflake ID can carry.
+const MaxNodeID = 1<<snowflakeNodeBits - 1
+
+// New returns a generator for strategy. nodeID identifies this instance in
+// snowflake IDs and is ignored by the other strategies. The generator is
+// safe for concurrent use.
+func New(strategy string, nodeID int) (func() string, error) {
+	switch strategy {
+	case StrategyUUIDv7:
+		return newUUIDv7(time.Now).next, nil
+	case StrategyULID:
+		return newULID(time.Now).next, nil
+	case StrategySnowflake:
+		if nodeID < 0 || nodeID > MaxNodeID {
+			return nil, fmt.Errorf("snowflake node ID must be between 0 and %d, got %d", MaxNodeID, nodeID)
+		}
+		return newSnowflake(time.Now, nodeID).next, nil
+	}
+	return nil, fmt.Errorf("unknown ID strategy %q", strategy)
+}
+
+// clock hands out millisecond timestamps that never decrease. Generators
+// count IDs within a millisecond and, when a millisecond runs out of IDs
+// or the wall clock steps back, run ahead of the wall clock until it
+// catches up, so their IDs keep increasing.
+type clock struct {
+	now func() time.Time
+	ms  int64 // last millisecond handed out
+}
+
+// tick returns the millisecond for the next ID and whether it is a new one.
+func (c *clock) tick() (int64, bool) {
+	if ms := c.now().UnixMilli(); ms > c.ms {
+		c.ms = ms
+		return ms, true
+	}
+	return c.ms, false
+}
+
+// skip moves to the next millisecond once the current one is used up.
+func (c *clock) skip() int64 {
+	c.ms++
+	return c.ms
+}
+
+type uuidV7 struct {
+	mu    sync.Mutex
+	clock clock
+	seq   uint16 // the 12-bit rand_a field, counting within a millisecond
+}
+
+func newUUIDv7(now func() time.Time) *uuidV7 {
+	return &uuidV7{clock: clock{now: now}}
+}
+
+func (g *uuidV7) next() string {
+	g.mu.Lock()
+	ms, fresh := g.clock.tick()
+	switch {
+	case fresh:
+		// Start in the lower half to leave room for counting.
+		g.seq = uint16(randomUint64()) & 0x7ff
+	case g.seq == 0xfff:
+		ms = g.clock.skip()
+		g.seq = 0
+	default:
+		g.seq++
+	}
+	seq := g.seq
+	g.mu.Unlock()
+
+	var b [16]byte
+	binary.BigEndian.PutUint64(b[:8], uint64(ms)<<16|0x7000|uint64(seq))
+	binary.BigEndian.PutUint64(b[8:], randomUint64()&(1<<62-1)|1<<63) // variant 10
+
+	var s [36]byte
+	hex.Encode(s[0:8], b[0:4])
+	s[8] = '-'
+	hex.Encode(s[9:13], b[4:6])
+	s[13] = '-'
+	hex.Encode(s[14:18], b[6:8])
+	s[18] = '-'
+	hex.Encode(s[19:23], b[8:10])
+	s[23] = '-'
+	hex.Encode(s[24:], b[10:])
+	return string(s[:])
+}
+
+// crockford is the base32 alphabet of ULIDs, in ascending order.
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+type ulid struct {
+	mu      sync.Mutex
+	clock   clock
+	entropy [10]byte // random within a millisecond, then incremented
+}
+
+func newULID(now func() time.Time) *ulid {
+	return &ulid{clock: clock{now: now}}
+}
+
+func (g *ulid) next() string {
+	g.mu.Lock()
+	ms, fresh := g.clock.tick()
+	if fresh || !increment(g.entropy[:]) {
+		if !fresh {
+			ms = g.clock.skip()
+		}
+		_, _ = rand.Read(g.entropy[:])
+	}
+	hi := uint64(ms)<<16 | uint64(binary.BigEndian.Uint16(g.entropy[:2]))
+	lo := binary.BigEndian.Uint64(g.entropy[2:])
+	g.mu.Unlock()
+
+	// 26 characters of 5 bits hold the 128 bits, the first one only 3.
+	var s [26]byte
+	for i := len(s) - 1; i >= 0; i-- {
+		s[i] = crockford[lo&31]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return string(s[:])
+}
+
+// increment adds one to the big-endian number in b and reports whether it
+// did not overflow.
+func increment(b []byte) bool {
+	for i := len(b) - 1; i >= 0; i-- {
+		b[i]++
+		if b[i] != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+const (
+	snowflakeNodeBits = 10
+	snowflakeSeqBits  = 12
+
+	// snowflakeEpoch is when snowflake timestamps start, 2020-01-01 UTC,
+	// in Unix milliseconds. The 41-bit timestamp lasts until 2089.
+	snowflakeEpoch = 1577836800000
+)
+
+type snowflake struct {
+	mu    sync.Mutex
+	clock clock
+	node  int64
+	seq   int64
+}
+
+func newSnowflake(now func() time.Time, node int) *snowflake {
+	return &snowflake{clock: clock{now: now}, node: int64(node)}
+}
+
+func (g *snowflake) next() string {
+	g.mu.Lock()
+	ms, fresh := g.clock.tick()
+	switch {
+	case fresh:
+		g.seq = 0
+	case g.seq == 1<<snowflakeSeqBits-1:
+		ms = g.clock.skip()
+		g.seq = 0
+	default:
+		g.seq++
+	}
+	id := (ms-snowflakeEpoch)<<(snowflakeNodeBits+snowflakeSeqBits) | g.node<<snowflakeSeqBits | g.seq
+	g.mu.Unlock()
+
+	// Zero-padded so IDs sort as strings as they do as numbers.
+	return fmt.Sprintf("%019d", id)
+}
+
+func randomUint64() uint64 {
+	var b [8]byte
+	_, _ = rand.Read(b[:])
+	return binary.BigEndian.Uint64(b[:])
+}
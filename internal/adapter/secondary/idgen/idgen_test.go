@@ -0,0 +1,91 @@
+package idgen
+
+import (
+	"regexp"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestGenerators_sortInCreationOrder(t *testing.T) {
+	start := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		newNext func(now func() time.Time) func() string
+		format  *regexp.Regexp
+	}{
+		{
+			name:    "uuidv7",
+			newNext: func(now func() time.Time) func() string { return newUUIDv7(now).next },
+			format:  regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-7[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`),
+		},
+		{
+			name:    "ulid",
+			newNext: func(now func() time.Time) func() string { return newULID(now).next },
+			format:  regexp.MustCompile(`^[0-7][0-9A-HJKMNP-TV-Z]{25}$`),
+		},
+		{
+			name:    "snowflake",
+			newNext: func(now func() time.Time) func() string { return newSnowflake(now, 42).next },
+			format:  regexp.MustCompile(`^[0-9]{19}$`),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Many IDs per millisecond, and a clock stepping back.
+			steps := []time.Duration{0, 0, time.Millisecond, -time.Second, 2 * time.Second}
+			now := start
+			next := tt.newNext(func() time.Time { return now })
+
+			var ids []string
+			for _, step := range steps {
+				now = now.Add(step)
+				for i := 0; i < 5000; i++ {
+					ids = append(ids, next())
+				}
+			}
+
+			for _, id := range ids {
+				if !tt.format.MatchString(id) {
+					t.Fatalf("malformed ID %q", id)
+				}
+			}
+			if !slices.IsSorted(ids) {
+				t.Fatal("expected IDs to sort in creation order")
+			}
+			if len(slices.Compact(slices.Clone(ids))) != len(ids) {
+				t.Fatal("expected unique IDs")
+			}
+		})
+	}
+}
+
+func TestSnowflake_layout(t *testing.T) {
+	now := time.UnixMilli(snowflakeEpoch + 1000)
+	next := newSnowflake(func() time.Time { return now }, 7).next
+
+	id, err := strconv.ParseInt(next(), 10, 64)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ms, node := id>>22, id>>12&MaxNodeID; ms != 1000 || node != 7 {
+		t.Fatalf("expected 1000ms from node 7, got %dms from node %d", ms, node)
+	}
+}
+
+func TestNew(t *testing.T) {
+	for _, strategy := range []string{StrategyUUIDv7, StrategyULID, StrategySnowflake} {
+		if next, err := New(strategy, 1); err != nil || next() == "" {
+			t.Fatalf("%s: expected a generator, got error %v", strategy, err)
+		}
+	}
+	if _, err := New("uuidv4", 0); err == nil {
+		t.Fatal("expected an error for an unknown strategy")
+	}
+	if _, err := New(StrategySnowflake, MaxNodeID+1); err == nil {
+		t.Fatal("expected an error for an out-of-range node ID")
+	}
+}
@@ -15,48 +15,64 @@ import (
 
 // DestinationProducer implements secondary.MessageProducer by creating Kafka
 // writers on-demand per broker address derived from the task destination.
-// Writers are cached by "host:port" and reused across calls.
+// Writers are cached by "host:port", with a separate writer for priority
+// messages, and reused across calls.
 // This is used when no global broker list is configured (package embedding mode).
 type DestinationProducer struct {
-	writers map[string]*kafka.Writer
-	mu      sync.Mutex
-	logger  *zap.Logger
+	writers  map[writerKey]*kafka.Writer
+	receipts *receipts
+	options  options
+	mu       sync.Mutex
+	logger   *zap.Logger
 }
 
 // NewDestinationProducer creates a Kafka producer that connects per destination.
-func NewDestinationProducer(logger *zap.Logger) secondary.MessageProducer {
+func NewDestinationProducer(logger *zap.Logger, opts ...Option) secondary.MessageProducer {
 	return &DestinationProducer{
-		writers: make(map[string]*kafka.Writer),
-		logger:  logger.Named("kafka-destination-producer"),
+		writers:  make(map[writerKey]*kafka.Writer),
+		receipts: newReceipts(),
+		options:  newOptions(opts),
+		logger:   logger.Named("kafka-destination-producer"),
 	}
 }
 
 // Produce sends a message to the broker and topic specified in destination.
-func (p *DestinationProducer) Produce(ctx context.Context, destination entity.Destination, key, value []byte) error {
+func (p *DestinationProducer) Produce(ctx context.Context, destination entity.Destination, m secondary.Message) error {
+	_, err := p.ProduceWithReceipt(ctx, destination, m)
+	return err
+}
+
+// ProduceWithReceipt sends a message like Produce and returns the partition
+// and offset it was written to.
+func (p *DestinationProducer) ProduceWithReceipt(ctx context.Context, destination entity.Destination, m secondary.Message) (entity.DeliveryReceipt, error) {
 	if destination.Host == "" || destination.Port == "" {
-		return fmt.Errorf("kafka destination requires host and port")
+		return entity.DeliveryReceipt{}, fmt.Errorf("kafka destination requires host and port")
 	}
 
 	addr := destination.Host + ":" + destination.Port
-	writer := p.writerFor(addr)
+	writer := p.writerFor(writerKey{addr: addr, priority: m.Priority})
 
 	msg := kafka.Message{
-		Topic: destination.Topic,
-		Key:   key,
-		Value: value,
+		Topic:   destination.Topic,
+		Key:     m.Key,
+		Value:   m.Value,
+		Headers: kafkaHeaders(m.Headers, m.TransportHeaders),
 	}
 
-	if err := writer.WriteMessages(ctx, msg); err != nil {
-		return fmt.Errorf("writing message to kafka topic %q at %q: %w", destination.Topic, addr, err)
+	start := time.Now()
+	receipt, err := p.receipts.write(ctx, writer, msg)
+	p.options.observe(destination.Topic, time.Since(start), err)
+	if err != nil {
+		return entity.DeliveryReceipt{}, deliveryError(fmt.Errorf("writing message to kafka topic %q at %q: %w", destination.Topic, addr, err))
 	}
 
 	p.logger.Debug("message produced",
 		zap.String("broker", addr),
 		zap.String("topic", destination.Topic),
-		zap.Int("value_size", len(value)),
+		zap.Int("value_size", len(m.Value)),
 	)
 
-	return nil
+	return receipt, nil
 }
 
 // Close shuts down all cached writers.
@@ -65,9 +81,9 @@ func (p *DestinationProducer) Close() error {
 	defer p.mu.Unlock()
 
 	var errs []error
-	for addr, w := range p.writers {
+	for key, w := range p.writers {
 		if err := w.Close(); err != nil {
-			errs = append(errs, fmt.Errorf("closing writer for %s: %w", addr, err))
+			errs = append(errs, fmt.Errorf("closing writer for %s: %w", key.addr, err))
 		}
 	}
 
@@ -78,23 +94,24 @@ func (p *DestinationProducer) Close() error {
 	return nil
 }
 
-func (p *DestinationProducer) writerFor(addr string) *kafka.Writer {
+// writerKey identifies a cached writer.
+type writerKey struct {
+	addr     string
+	priority bool
+}
+
+func (p *DestinationProducer) writerFor(key writerKey) *kafka.Writer {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
-	if w, ok := p.writers[addr]; ok {
+	if w, ok := p.writers[key]; ok {
 		return w
 	}
 
-	w := &kafka.Writer{
-		Addr:         kafka.TCP(addr),
-		Balancer:     &kafka.LeastBytes{},
-		BatchTimeout: 100 * time.Millisecond,
-		RequiredAcks: kafka.RequireAll,
-	}
-	p.writers[addr] = w
+	w := newWriter(kafka.TCP(key.addr), key.priority, p.receipts.complete)
+	p.writers[key] = w
 
-	p.logger.Info("kafka writer created", zap.String("broker", addr))
+	p.logger.Info("kafka writer created", zap.String("broker", key.addr), zap.Bool("priority", key.priority))
 
 	return w
 }
@@ -0,0 +1,55 @@
+package kafkaproducer
+
+import (
+	"context"
+	"errors"
+	"net"
+
+	"github.com/segmentio/kafka-go"
+
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+// deliveryError tags a write error with an entity.ErrorCode where its cause
+// is recognisable, and returns it unchanged otherwise.
+func deliveryError(err error) error {
+	if code := writeErrorCode(err); code != "" {
+		return &entity.DeliveryError{Code: code, Err: err}
+	}
+	return err
+}
+
+// writeErrorCode classifies a WriteMessages error. Errors for a batch are
+// classified by the first message that failed.
+func writeErrorCode(err error) entity.ErrorCode {
+	var writeErrs kafka.WriteErrors
+	if errors.As(err, &writeErrs) {
+		for _, e := range writeErrs {
+			if e != nil {
+				return writeErrorCode(e)
+			}
+		}
+	}
+
+	var kafkaErr kafka.Error
+	if errors.As(err, &kafkaErr) {
+		switch kafkaErr {
+		case kafka.SASLAuthenticationFailed, kafka.TopicAuthorizationFailed,
+			kafka.ClusterAuthorizationFailed, kafka.IllegalSASLState, kafka.UnsupportedSASLMechanism:
+			return entity.ErrorCodeAuthFailed
+		}
+	}
+
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || errors.As(err, &netErr) && netErr.Timeout() {
+		return entity.ErrorCodeTimeout
+	}
+
+	// Refused connections and failed lookups mean no broker was reached.
+	var opErr *net.OpError
+	var dnsErr *net.DNSError
+	if errors.As(err, &opErr) || errors.As(err, &dnsErr) {
+		return entity.ErrorCodeKafkaUnreachable
+	}
+	return ""
+}
@@ -0,0 +1,29 @@
+package kafkaproducer
+
+import (
+	"maps"
+	"sort"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// kafkaHeaders converts task metadata and transport headers to record
+// headers, sorted by key so records are reproducible. A transport header
+// replaces a metadata entry of the same key.
+func kafkaHeaders(metadata, transport map[string]string) []kafka.Header {
+	if len(metadata) == 0 && len(transport) == 0 {
+		return nil
+	}
+	merged := maps.Clone(metadata)
+	if merged == nil {
+		merged = make(map[string]string, len(transport))
+	}
+	maps.Copy(merged, transport)
+
+	headers := make([]kafka.Header, 0, len(merged))
+	for k, v := range merged {
+		headers = append(headers, kafka.Header{Key: k, Value: []byte(v)})
+	}
+	sort.Slice(headers, func(i, j int) bool { return headers[i].Key < headers[j].Key })
+	return headers
+}
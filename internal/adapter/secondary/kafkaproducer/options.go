@@ -0,0 +1,44 @@
+package kafkaproducer
+
+import (
+	"time"
+
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+	"github.com/ruudy-sib/rebound/internal/port/secondary"
+)
+
+// Option configures optional behaviour of Producer and DestinationProducer.
+type Option func(*options)
+
+type options struct {
+	metrics secondary.ProducerMetrics
+}
+
+// WithMetrics reports the latency and outcome of every write to metrics.
+func WithMetrics(metrics secondary.ProducerMetrics) Option {
+	return func(o *options) {
+		o.metrics = metrics
+	}
+}
+
+func newOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// observe reports a write to the topic to the metrics, if any.
+func (o options) observe(topic string, latency time.Duration, err error) {
+	if o.metrics == nil {
+		return
+	}
+	o.metrics.ObserveWrite(entity.ProducerWrite{
+		DestinationType: entity.DestinationTypeKafka,
+		Destination:     topic,
+		Messages:        1,
+		Latency:         latency,
+		Failed:          err != nil,
+	})
+}
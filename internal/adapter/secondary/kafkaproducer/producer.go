@@ -2,7 +2,9 @@ package kafkaproducer
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net"
 	"time"
 
 	"github.com/segmentio/kafka-go"
@@ -14,55 +16,95 @@ import (
 )
 
 // Producer implements secondary.MessageProducer using segmentio/kafka-go.
-// It maintains a single writer connection for all message deliveries.
+// It maintains one writer for all message deliveries, and another for
+// priority messages.
 type Producer struct {
-	writer *kafka.Writer
-	logger *zap.Logger
+	writer         *kafka.Writer
+	priorityWriter *kafka.Writer
+	receipts       *receipts
+	options        options
+	logger         *zap.Logger
 }
 
 // NewProducer creates a Kafka producer from the application configuration.
-func NewProducer(cfg *config.Config, logger *zap.Logger) secondary.MessageProducer {
-	writer := &kafka.Writer{
-		Addr:         kafka.TCP(cfg.KafkaBrokers...),
-		Balancer:     &kafka.LeastBytes{},
-		BatchTimeout: 100 * time.Millisecond,
-		RequiredAcks: kafka.RequireAll,
-	}
+func NewProducer(cfg *config.Config, logger *zap.Logger, opts ...Option) secondary.MessageProducer {
+	receipts := newReceipts()
+	brokers := kafka.TCP(cfg.KafkaBrokers...)
 
 	logger.Info("kafka producer initialized",
 		zap.Strings("brokers", cfg.KafkaBrokers),
 	)
 
 	return &Producer{
-		writer: writer,
-		logger: logger.Named("kafka-producer"),
+		writer:         newWriter(brokers, false, receipts.complete),
+		priorityWriter: newWriter(brokers, true, receipts.complete),
+		receipts:       receipts,
+		options:        newOptions(opts),
+		logger:         logger.Named("kafka-producer"),
 	}
 }
 
+// newWriter creates a writer for the brokers at addr. A priority writer
+// sends every message as soon as it is written instead of waiting up to
+// BatchTimeout for a batch to fill, so priority messages do not queue
+// behind bulk traffic.
+func newWriter(addr net.Addr, priority bool, completion func([]kafka.Message, error)) *kafka.Writer {
+	w := &kafka.Writer{
+		Addr:         addr,
+		Balancer:     &kafka.LeastBytes{},
+		BatchTimeout: 100 * time.Millisecond,
+		RequiredAcks: kafka.RequireAll,
+		Completion:   completion,
+	}
+	if priority {
+		w.BatchSize = 1
+	}
+	return w
+}
+
 // Produce sends a message to the specified Kafka topic.
-func (p *Producer) Produce(ctx context.Context, destination entity.Destination, key, value []byte) error {
+func (p *Producer) Produce(ctx context.Context, destination entity.Destination, m secondary.Message) error {
+	_, err := p.ProduceWithReceipt(ctx, destination, m)
+	return err
+}
+
+// ProduceWithReceipt sends a message like Produce and returns the partition
+// and offset it was written to.
+func (p *Producer) ProduceWithReceipt(ctx context.Context, destination entity.Destination, m secondary.Message) (entity.DeliveryReceipt, error) {
 	msg := kafka.Message{
-		Topic: destination.Topic,
-		Key:   key,
-		Value: value,
+		Topic:   destination.Topic,
+		Key:     m.Key,
+		Value:   m.Value,
+		Headers: kafkaHeaders(m.Headers, m.TransportHeaders),
+	}
+
+	writer := p.writer
+	if m.Priority {
+		writer = p.priorityWriter
 	}
 
-	if err := p.writer.WriteMessages(ctx, msg); err != nil {
-		return fmt.Errorf("writing message to kafka topic %q: %w", destination.Topic, err)
+	start := time.Now()
+	receipt, err := p.receipts.write(ctx, writer, msg)
+	p.options.observe(destination.Topic, time.Since(start), err)
+	if err != nil {
+		return entity.DeliveryReceipt{}, deliveryError(fmt.Errorf("writing message to kafka topic %q: %w", destination.Topic, err))
 	}
 
 	p.logger.Debug("message produced",
 		zap.String("topic", destination.Topic),
-		zap.Int("value_size", len(value)),
+		zap.Int("value_size", len(m.Value)),
 	)
 
-	return nil
+	return receipt, nil
 }
 
-// Close shuts down the Kafka writer and releases its resources.
+// Close shuts down the Kafka writers and releases their resources.
 func (p *Producer) Close() error {
-	if p.writer != nil {
-		return p.writer.Close()
+	var errs []error
+	for _, w := range []*kafka.Writer{p.writer, p.priorityWriter} {
+		if w != nil {
+			errs = append(errs, w.Close())
+		}
 	}
-	return nil
+	return errors.Join(errs...)
 }
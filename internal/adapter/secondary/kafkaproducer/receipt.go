@@ -0,0 +1,67 @@
+package kafkaproducer
+
+import (
+	"context"
+	"sync"
+
+	"github.com/segmentio/kafka-go"
+
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+// receipts collects the partition and offset Kafka assigned to messages
+// being written. kafka.Writer only reports them to its Completion hook,
+// whose messages still reference the byte slices passed to WriteMessages,
+// so writes in progress are matched by the address of their value.
+type receipts struct {
+	mu      sync.Mutex
+	pending map[*byte]*entity.DeliveryReceipt
+}
+
+func newReceipts() *receipts {
+	return &receipts{pending: make(map[*byte]*entity.DeliveryReceipt)}
+}
+
+// complete is the writer's Completion hook.
+func (r *receipts) complete(messages []kafka.Message, err error) {
+	if err != nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, m := range messages {
+		if len(m.Value) == 0 {
+			continue
+		}
+		if receipt, ok := r.pending[&m.Value[0]]; ok {
+			receipt.Partition = m.Partition
+			receipt.Offset = m.Offset
+		}
+	}
+}
+
+// write writes msg and returns where it was written. A message without a
+// value cannot be matched and gets an unknown partition and offset.
+func (r *receipts) write(ctx context.Context, writer *kafka.Writer, msg kafka.Message) (entity.DeliveryReceipt, error) {
+	receipt := &entity.DeliveryReceipt{Partition: -1, Offset: -1}
+	if len(msg.Value) > 0 {
+		key := &msg.Value[0]
+		r.mu.Lock()
+		r.pending[key] = receipt
+		r.mu.Unlock()
+		defer func() {
+			r.mu.Lock()
+			delete(r.pending, key)
+			r.mu.Unlock()
+		}()
+	}
+
+	if err := writer.WriteMessages(ctx, msg); err != nil {
+		return entity.DeliveryReceipt{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return *receipt, nil
+}
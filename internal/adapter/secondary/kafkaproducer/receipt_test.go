@@ -0,0 +1,35 @@
+package kafkaproducer
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/segmentio/kafka-go"
+
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+func TestReceipts_complete(t *testing.T) {
+	r := newReceipts()
+	value := []byte("payload")
+	receipt := &entity.DeliveryReceipt{Partition: -1, Offset: -1}
+	r.pending[&value[0]] = receipt
+
+	// The writer hands Completion copies of the messages that still share
+	// their values with the caller; other values must not match.
+	other := []byte("payload")
+	r.complete([]kafka.Message{{Value: other, Partition: 1, Offset: 7}}, nil)
+	if receipt.Offset != -1 {
+		t.Fatalf("matched a message with a different value: %+v", receipt)
+	}
+
+	r.complete([]kafka.Message{{Value: value, Partition: 2, Offset: 42}}, errors.New("failed"))
+	if receipt.Offset != -1 {
+		t.Fatalf("recorded a failed write: %+v", receipt)
+	}
+
+	r.complete([]kafka.Message{{Value: value, Partition: 2, Offset: 42}}, nil)
+	if receipt.Partition != 2 || receipt.Offset != 42 {
+		t.Fatalf("expected partition 2 offset 42, got %+v", receipt)
+	}
+}
@@ -0,0 +1,281 @@
+// Package memstore keeps the schedule in process memory, on a clock the
+// caller provides. It backs test harnesses that step through retries
+// without Redis or real waiting; the schedule is neither shared between
+// processes nor kept across restarts.
+package memstore
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+// Scheduler implements secondary.TaskScheduler over an in-memory list kept
+// sorted by due time, then by insertion order.
+type Scheduler struct {
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries []entry
+	seq     int64
+}
+
+type entry struct {
+	task *entity.Task
+	due  time.Time
+	seq  int64
+}
+
+// cursor returns the listing position of e.
+func (e entry) cursor() entity.Cursor {
+	return entity.Cursor{Score: float64(e.due.UnixNano()), Member: strconv.FormatInt(e.seq, 10)}
+}
+
+// after reports whether e sorts after the cursor c.
+func (e entry) after(c entity.Cursor) bool {
+	score := float64(e.due.UnixNano())
+	if score != c.Score {
+		return score > c.Score
+	}
+	seq, _ := strconv.ParseInt(c.Member, 10, 64)
+	return e.seq > seq
+}
+
+// NewScheduler creates an empty schedule that reads the current time from now.
+func NewScheduler(now func() time.Time) *Scheduler {
+	return &Scheduler{now: now}
+}
+
+// Schedule stores a copy of task, due delay after now.
+func (s *Scheduler) Schedule(_ context.Context, task *entity.Task, delay time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.insert(task, s.now().Add(delay))
+	return nil
+}
+
+// insert adds a copy of task at its sorted position. Callers hold s.mu.
+func (s *Scheduler) insert(task *entity.Task, due time.Time) {
+	copied := *task
+	s.seq++
+	e := entry{task: &copied, due: due, seq: s.seq}
+
+	i := sort.Search(len(s.entries), func(i int) bool { return s.entries[i].due.After(due) })
+	s.entries = append(s.entries, entry{})
+	copy(s.entries[i+1:], s.entries[i:])
+	s.entries[i] = e
+}
+
+// NextDue returns when the earliest scheduled task is due, and false if
+// nothing is scheduled.
+func (s *Scheduler) NextDue() (time.Time, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.entries) == 0 {
+		return time.Time{}, false
+	}
+	return s.entries[0].due, true
+}
+
+// FetchDue removes and returns up to limit tasks of queue due at or
+// before now.
+func (s *Scheduler) FetchDue(_ context.Context, queue string, limit int) ([]*entity.Task, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	var tasks []*entity.Task
+	kept := s.entries[:0]
+	for _, e := range s.entries {
+		if len(tasks) < limit && !e.due.After(now) && e.task.Queue == queue {
+			tasks = append(tasks, e.task)
+			continue
+		}
+		kept = append(kept, e)
+	}
+	s.entries = kept
+	return tasks, nil
+}
+
+// Remove deletes the task whose ID is rawMember; memstore members are
+// task IDs.
+func (s *Scheduler) Remove(_ context.Context, rawMember string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if i := s.find(rawMember); i >= 0 {
+		s.delete(i)
+	}
+	return nil
+}
+
+// Get returns the task with the given ID without removing it.
+func (s *Scheduler) Get(_ context.Context, taskID string) (*entity.Task, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.find(taskID)
+	if i < 0 {
+		return nil, domain.ErrTaskNotFound
+	}
+	return s.entries[i].task, nil
+}
+
+// Dequeue removes the task with the given ID and returns it with its due time.
+func (s *Scheduler) Dequeue(_ context.Context, taskID string) (*entity.Task, time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.find(taskID)
+	if i < 0 {
+		return nil, time.Time{}, domain.ErrTaskNotFound
+	}
+	e := s.entries[i]
+	s.delete(i)
+	return e.task, e.due, nil
+}
+
+// Reschedule moves the task with the given ID to change.At, or by change.By.
+func (s *Scheduler) Reschedule(_ context.Context, taskID string, change entity.Reschedule) (time.Time, time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.find(taskID)
+	if i < 0 {
+		return time.Time{}, time.Time{}, domain.ErrTaskNotFound
+	}
+	e := s.entries[i]
+	next := change.At
+	if next.IsZero() {
+		next = e.due.Add(change.By)
+	}
+	s.delete(i)
+	s.insert(e.task, next)
+	return e.due, next, nil
+}
+
+// Peek lists up to limit tasks after the cursor, earliest due first.
+func (s *Scheduler) Peek(_ context.Context, after entity.Cursor, limit int) ([]entity.ScheduledTask, entity.Cursor, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var page []entity.ScheduledTask
+	var last entry
+	for _, e := range s.entries {
+		if !after.IsZero() && !e.after(after) {
+			continue
+		}
+		if len(page) == limit {
+			return page, last.cursor(), nil
+		}
+		page = append(page, entity.ScheduledTask{Task: e.task, DueAt: e.due})
+		last = e
+	}
+	return page, entity.Cursor{}, nil
+}
+
+// FindByDestination lists up to limit tasks for the destination URL or
+// topic, earliest due first.
+func (s *Scheduler) FindByDestination(_ context.Context, destination string, limit int) ([]entity.ScheduledTask, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var found []entity.ScheduledTask
+	for _, e := range s.entries {
+		if len(found) == limit {
+			break
+		}
+		if e.task.Destination.Name() == destination {
+			found = append(found, entity.ScheduledTask{Task: e.task, DueAt: e.due})
+		}
+	}
+	return found, nil
+}
+
+// Count returns how many tasks are scheduled.
+func (s *Scheduler) Count(context.Context) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return int64(len(s.entries)), nil
+}
+
+// CountByDestination returns how many tasks are scheduled for the
+// destination URL or topic.
+func (s *Scheduler) CountByDestination(_ context.Context, destination string) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var n int64
+	for _, e := range s.entries {
+		if e.task.Destination.Name() == destination {
+			n++
+		}
+	}
+	return n, nil
+}
+
+// CountDueBy counts, for each time, the tasks due at or before it.
+func (s *Scheduler) CountDueBy(_ context.Context, times []time.Time) ([]int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	counts := make([]int64, len(times))
+	for i, t := range times {
+		counts[i] = int64(sort.Search(len(s.entries), func(j int) bool { return s.entries[j].due.After(t) }))
+	}
+	return counts, nil
+}
+
+// Shift moves every task matching filter by the given amount.
+func (s *Scheduler) Shift(_ context.Context, filter entity.TaskFilter, by time.Duration) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var kept, moved []entry
+	for _, e := range s.entries {
+		if s.matches(e, filter) {
+			moved = append(moved, e)
+		} else {
+			kept = append(kept, e)
+		}
+	}
+	s.entries = kept
+	for _, e := range moved {
+		s.insert(e.task, e.due.Add(by))
+	}
+	return len(moved), nil
+}
+
+func (s *Scheduler) matches(e entry, filter entity.TaskFilter) bool {
+	if !filter.DueAfter.IsZero() && e.due.Before(filter.DueAfter) {
+		return false
+	}
+	if !filter.DueBefore.IsZero() && e.due.After(filter.DueBefore) {
+		return false
+	}
+	return filter.MatchesTask(e.task)
+}
+
+// find returns the index of the task with the given ID, or -1. Callers
+// hold s.mu.
+func (s *Scheduler) find(taskID string) int {
+	for i, e := range s.entries {
+		if e.task.ID == taskID {
+			return i
+		}
+	}
+	return -1
+}
+
+// delete drops the entry at index i. Callers hold s.mu.
+func (s *Scheduler) delete(i int) {
+	s.entries = append(s.entries[:i], s.entries[i+1:]...)
+}
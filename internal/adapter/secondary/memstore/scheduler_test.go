@@ -0,0 +1,89 @@
+package memstore
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+func TestScheduler_FetchDue(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := NewScheduler(func() time.Time { return now })
+
+	for i, delay := range []time.Duration{20 * time.Second, 10 * time.Second, 10 * time.Second} {
+		task := &entity.Task{ID: string(rune('a' + i))}
+		if err := s.Schedule(ctx, task, delay); err != nil {
+			t.Fatalf("Schedule: %v", err)
+		}
+	}
+
+	if due, ok := s.NextDue(); !ok || !due.Equal(now.Add(10*time.Second)) {
+		t.Fatalf("expected next due at +10s, got %v %v", due, ok)
+	}
+
+	now = now.Add(15 * time.Second)
+	tasks, err := s.FetchDue(ctx, "", 10)
+	if err != nil {
+		t.Fatalf("FetchDue: %v", err)
+	}
+	if len(tasks) != 2 || tasks[0].ID != "b" || tasks[1].ID != "c" {
+		t.Fatalf("expected b and c in insertion order, got %+v", tasks)
+	}
+	if n, _ := s.Count(ctx); n != 1 {
+		t.Fatalf("expected 1 task left, got %d", n)
+	}
+}
+
+func TestScheduler_FetchDue_queue(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := NewScheduler(func() time.Time { return now })
+
+	_ = s.Schedule(ctx, &entity.Task{ID: "a"}, 0)
+	_ = s.Schedule(ctx, &entity.Task{ID: "b", Queue: "payments"}, 0)
+	_ = s.Schedule(ctx, &entity.Task{ID: "c"}, 0)
+
+	tasks, err := s.FetchDue(ctx, "payments", 10)
+	if err != nil || len(tasks) != 1 || tasks[0].ID != "b" {
+		t.Fatalf("expected only b from payments, got %+v %v", tasks, err)
+	}
+	tasks, err = s.FetchDue(ctx, "", 10)
+	if err != nil || len(tasks) != 2 || tasks[0].ID != "a" || tasks[1].ID != "c" {
+		t.Fatalf("expected a and c from the default queue, got %+v %v", tasks, err)
+	}
+}
+
+func TestScheduler_Reschedule(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := NewScheduler(func() time.Time { return now })
+
+	_ = s.Schedule(ctx, &entity.Task{ID: "a"}, time.Minute)
+	_ = s.Schedule(ctx, &entity.Task{ID: "b"}, 2*time.Minute)
+
+	old, next, err := s.Reschedule(ctx, "b", entity.Reschedule{By: -90 * time.Second})
+	if err != nil {
+		t.Fatalf("Reschedule: %v", err)
+	}
+	if !old.Equal(now.Add(2*time.Minute)) || !next.Equal(now.Add(30*time.Second)) {
+		t.Fatalf("unexpected due times %v -> %v", old, next)
+	}
+
+	page, cursor, err := s.Peek(ctx, entity.Cursor{}, 1)
+	if err != nil || len(page) != 1 || page[0].Task.ID != "b" {
+		t.Fatalf("expected b first, got %+v %v", page, err)
+	}
+	page, cursor, _ = s.Peek(ctx, cursor, 1)
+	if len(page) != 1 || page[0].Task.ID != "a" || !cursor.IsZero() {
+		t.Fatalf("expected a on the last page, got %+v %v", page, cursor)
+	}
+
+	if _, _, err := s.Dequeue(ctx, "missing"); !errors.Is(err, domain.ErrTaskNotFound) {
+		t.Fatalf("expected ErrTaskNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,172 @@
+// Package outboxsource reads task rows out of a relational outbox table, so
+// services can create tasks in the same database transaction as their
+// business data.
+package outboxsource
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// Dialect selects the SQL placeholder syntax of the outbox database.
+type Dialect string
+
+const (
+	DialectPostgres Dialect = "postgres"
+	DialectMySQL    Dialect = "mysql"
+)
+
+// ErrRejected marks a row that can never become a task. Such rows are kept
+// with the error recorded instead of being retried.
+var ErrRejected = errors.New("outbox row rejected")
+
+// Row is one pending outbox row.
+type Row struct {
+	ID      int64
+	Payload []byte
+}
+
+// Result counts what one Process call did with the rows it claimed.
+type Result struct {
+	Relayed  int
+	Rejected int
+}
+
+// tableName allows plain and schema-qualified identifiers only, as the
+// table name is written into the queries.
+var tableName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?$`)
+
+// Reader claims rows from an outbox table with columns id (ascending
+// integer key), payload, and error (NULL while pending). Rows are locked
+// with FOR UPDATE SKIP LOCKED, so several relays may share a table.
+type Reader struct {
+	db      *sql.DB
+	table   string
+	dialect Dialect
+	logger  *zap.Logger
+}
+
+// NewReader creates a Reader over the outbox table in db.
+func NewReader(db *sql.DB, dialect Dialect, table string, logger *zap.Logger) (*Reader, error) {
+	if dialect != DialectPostgres && dialect != DialectMySQL {
+		return nil, fmt.Errorf("unsupported outbox dialect %q", dialect)
+	}
+	if !tableName.MatchString(table) {
+		return nil, fmt.Errorf("invalid outbox table name %q", table)
+	}
+	return &Reader{
+		db:      db,
+		table:   table,
+		dialect: dialect,
+		logger:  logger.Named("outbox-reader"),
+	}, nil
+}
+
+// Process claims up to limit pending rows, oldest first, and passes each to
+// handle in one transaction. Rows handle accepts are deleted; rows it
+// rejects with ErrRejected keep the error. Any other error stops the run:
+// the rows handled so far are still committed and the rest are left for
+// the next call, and the error is returned.
+func (r *Reader) Process(ctx context.Context, limit int, handle func(ctx context.Context, row Row) error) (Result, error) {
+	var result Result
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return result, fmt.Errorf("starting outbox transaction: %w", err)
+	}
+	defer tx.Rollback() // no-op once committed
+
+	rows, err := r.claim(ctx, tx, limit)
+	if err != nil {
+		return result, err
+	}
+
+	var relayed []int64
+	var handleErr error
+	for _, row := range rows {
+		err := handle(ctx, row)
+		if err == nil {
+			relayed = append(relayed, row.ID)
+			continue
+		}
+		if !errors.Is(err, ErrRejected) {
+			handleErr = err
+			break
+		}
+		if _, err := tx.ExecContext(ctx, r.rejectQuery(), err.Error(), row.ID); err != nil {
+			return Result{}, fmt.Errorf("recording rejected outbox row %d: %w", row.ID, err)
+		}
+		result.Rejected++
+		r.logger.Warn("outbox row rejected", zap.Int64("row_id", row.ID), zap.Error(err))
+	}
+
+	if len(relayed) > 0 {
+		args := make([]any, len(relayed))
+		for i, id := range relayed {
+			args[i] = id
+		}
+		if _, err := tx.ExecContext(ctx, r.deleteQuery(len(relayed)), args...); err != nil {
+			return Result{}, fmt.Errorf("deleting relayed outbox rows: %w", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return Result{}, fmt.Errorf("committing outbox transaction: %w", err)
+	}
+	result.Relayed = len(relayed)
+	return result, handleErr
+}
+
+// claim selects and locks the oldest pending rows.
+func (r *Reader) claim(ctx context.Context, tx *sql.Tx, limit int) ([]Row, error) {
+	rows, err := tx.QueryContext(ctx, r.claimQuery(limit))
+	if err != nil {
+		return nil, fmt.Errorf("reading outbox rows: %w", err)
+	}
+	defer rows.Close()
+
+	var claimed []Row
+	for rows.Next() {
+		var row Row
+		if err := rows.Scan(&row.ID, &row.Payload); err != nil {
+			return nil, fmt.Errorf("scanning outbox row: %w", err)
+		}
+		claimed = append(claimed, row)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("reading outbox rows: %w", err)
+	}
+	return claimed, nil
+}
+
+func (r *Reader) claimQuery(limit int) string {
+	return "SELECT id, payload FROM " + r.table +
+		" WHERE error IS NULL ORDER BY id LIMIT " + strconv.Itoa(limit) +
+		" FOR UPDATE SKIP LOCKED"
+}
+
+func (r *Reader) rejectQuery() string {
+	return "UPDATE " + r.table + " SET error = " + r.placeholder(1) + " WHERE id = " + r.placeholder(2)
+}
+
+func (r *Reader) deleteQuery(n int) string {
+	placeholders := make([]string, n)
+	for i := range placeholders {
+		placeholders[i] = r.placeholder(i + 1)
+	}
+	return "DELETE FROM " + r.table + " WHERE id IN (" + strings.Join(placeholders, ", ") + ")"
+}
+
+// placeholder returns the dialect's placeholder for the nth argument.
+func (r *Reader) placeholder(n int) string {
+	if r.dialect == DialectPostgres {
+		return "$" + strconv.Itoa(n)
+	}
+	return "?"
+}
@@ -0,0 +1,48 @@
+package outboxsource
+
+import (
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestNewReader_validation(t *testing.T) {
+	tests := []struct {
+		name    string
+		dialect Dialect
+		table   string
+		wantErr bool
+	}{
+		{name: "postgres", dialect: DialectPostgres, table: "rebound_outbox"},
+		{name: "schema qualified", dialect: DialectMySQL, table: "billing.rebound_outbox"},
+		{name: "unknown dialect", dialect: "sqlite", table: "rebound_outbox", wantErr: true},
+		{name: "injection", dialect: DialectPostgres, table: "outbox; DROP TABLE users", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewReader(nil, tt.dialect, tt.table, zap.NewNop())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestReader_queries(t *testing.T) {
+	pg, _ := NewReader(nil, DialectPostgres, "rebound_outbox", zap.NewNop())
+	my, _ := NewReader(nil, DialectMySQL, "rebound_outbox", zap.NewNop())
+
+	if got, want := pg.claimQuery(50), "SELECT id, payload FROM rebound_outbox WHERE error IS NULL ORDER BY id LIMIT 50 FOR UPDATE SKIP LOCKED"; got != want {
+		t.Fatalf("claim query = %q, want %q", got, want)
+	}
+	if got, want := pg.deleteQuery(3), "DELETE FROM rebound_outbox WHERE id IN ($1, $2, $3)"; got != want {
+		t.Fatalf("postgres delete query = %q, want %q", got, want)
+	}
+	if got, want := my.deleteQuery(2), "DELETE FROM rebound_outbox WHERE id IN (?, ?)"; got != want {
+		t.Fatalf("mysql delete query = %q, want %q", got, want)
+	}
+	if got, want := pg.rejectQuery(), "UPDATE rebound_outbox SET error = $1 WHERE id = $2"; got != want {
+		t.Fatalf("reject query = %q, want %q", got, want)
+	}
+}
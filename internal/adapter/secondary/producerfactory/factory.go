@@ -3,6 +3,7 @@ package producerfactory
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"go.uber.org/zap"
 
@@ -10,7 +11,9 @@ import (
 	"github.com/ruudy-sib/rebound/internal/port/secondary"
 )
 
-// Factory routes message production to the appropriate producer based on destination type.
+// Factory routes message production to the appropriate producer based on the
+// destination's inferred type (see entity.Destination.Type), so primary and
+// dead-letter destinations may use different mechanisms.
 type Factory struct {
 	kafkaProducer secondary.MessageProducer
 	httpProducer  secondary.MessageProducer
@@ -31,19 +34,61 @@ func NewFactory(
 }
 
 // Produce routes the message to the appropriate producer based on destination type.
-func (f *Factory) Produce(ctx context.Context, destination entity.Destination, key, value []byte) error {
-	// Route based on which fields are set
-	if destination.URL != "" {
+func (f *Factory) Produce(ctx context.Context, destination entity.Destination, msg secondary.Message) error {
+	switch destination.Type() {
+	case entity.DestinationTypeHTTP:
 		f.logger.Debug("routing to http producer", zap.String("url", destination.URL))
-		return f.httpProducer.Produce(ctx, destination, key, value)
+		return f.httpProducer.Produce(ctx, destination, msg)
+	case entity.DestinationTypeKafka:
+		f.logger.Debug("routing to kafka producer", zap.String("topic", destination.Topic))
+		return f.kafkaProducer.Produce(ctx, destination, msg)
+	default:
+		return fmt.Errorf("unable to determine destination type: neither URL nor Topic is set")
 	}
+}
 
-	if destination.Topic != "" {
-		f.logger.Debug("routing to kafka producer", zap.String("topic", destination.Topic))
-		return f.kafkaProducer.Produce(ctx, destination, key, value)
+// ProduceWithReceipt routes the message like Produce and returns the
+// receipt of the producer it was routed to, if that producer reports one.
+func (f *Factory) ProduceWithReceipt(ctx context.Context, destination entity.Destination, msg secondary.Message) (entity.DeliveryReceipt, error) {
+	var producer secondary.MessageProducer
+	switch destination.Type() {
+	case entity.DestinationTypeHTTP:
+		producer = f.httpProducer
+	case entity.DestinationTypeKafka:
+		producer = f.kafkaProducer
+	default:
+		return entity.DeliveryReceipt{}, fmt.Errorf("unable to determine destination type: neither URL nor Topic is set")
 	}
 
-	return fmt.Errorf("unable to determine destination type: neither URL nor Topic is set")
+	if receipts, ok := producer.(secondary.ReceiptProducer); ok {
+		return receipts.ProduceWithReceipt(ctx, destination, msg)
+	}
+	return entity.DeliveryReceipt{Partition: -1, Offset: -1}, f.Produce(ctx, destination, msg)
+}
+
+// ProduceBatch forwards HTTP batches to the HTTP producer when it supports
+// batching. Other destination types cannot be batched.
+func (f *Factory) ProduceBatch(ctx context.Context, destination entity.Destination, messages []secondary.Message) error {
+	batcher, ok := f.httpProducer.(secondary.BatchProducer)
+	if destination.Type() != entity.DestinationTypeHTTP || !ok {
+		return fmt.Errorf("batch delivery is not supported for destination type %q", destination.Type())
+	}
+
+	f.logger.Debug("routing batch to http producer",
+		zap.String("url", destination.URL),
+		zap.Int("batch_size", len(messages)),
+	)
+	return batcher.ProduceBatch(ctx, destination, messages)
+}
+
+// RateLimitedFor forwards to the HTTP producer when it tracks rate limits.
+// Kafka destinations are never rate limited.
+func (f *Factory) RateLimitedFor(destination entity.Destination) time.Duration {
+	limiter, ok := f.httpProducer.(secondary.RateLimitedProducer)
+	if destination.Type() != entity.DestinationTypeHTTP || !ok {
+		return 0
+	}
+	return limiter.RateLimitedFor(destination)
 }
 
 // Close closes all underlying producers.
@@ -0,0 +1,109 @@
+// Package producermetrics keeps producer performance per destination in
+// memory. Each instance reports its own writes only.
+package producermetrics
+
+import (
+	"maps"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+// Recorder implements secondary.ProducerMetrics and
+// primary.ProducerStatsService.
+type Recorder struct {
+	mu           sync.Mutex
+	destinations map[destinationKey]*destinationStats
+}
+
+type destinationKey struct {
+	destinationType entity.DestinationType
+	destination     string
+}
+
+type destinationStats struct {
+	stats     entity.ProducerStats
+	latencies []time.Duration // ring buffer of recent latencies
+	next      int
+}
+
+// NewRecorder creates an empty recorder.
+func NewRecorder() *Recorder {
+	return &Recorder{destinations: make(map[destinationKey]*destinationStats)}
+}
+
+// ObserveWrite records one write. Once domain.ProducerMetricsMaxDestinations
+// destinations are tracked, writes to new ones are counted together under
+// domain.ProducerMetricsOverflow.
+func (r *Recorder) ObserveWrite(write entity.ProducerWrite) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := destinationKey{destinationType: write.DestinationType, destination: write.Destination}
+	d, ok := r.destinations[key]
+	if !ok {
+		if len(r.destinations) >= domain.ProducerMetricsMaxDestinations {
+			key.destination = domain.ProducerMetricsOverflow
+			d = r.destinations[key]
+		}
+		if d == nil {
+			d = &destinationStats{stats: entity.ProducerStats{
+				DestinationType: key.destinationType,
+				Destination:     key.destination,
+				StatusCodes:     make(map[int]int64),
+			}}
+			r.destinations[key] = d
+		}
+	}
+
+	d.stats.Writes++
+	d.stats.Messages += int64(write.Messages)
+	if write.Failed {
+		d.stats.Errors++
+	}
+	if write.Messages > d.stats.MaxBatchSize {
+		d.stats.MaxBatchSize = write.Messages
+	}
+	if write.HTTPStatus != 0 {
+		d.stats.StatusCodes[write.HTTPStatus]++
+	}
+	if write.Oversized {
+		d.stats.Oversized++
+	}
+	if write.Truncated {
+		d.stats.Truncated++
+	}
+
+	if len(d.latencies) < domain.ProducerLatencySamples {
+		d.latencies = append(d.latencies, write.Latency)
+		return
+	}
+	d.latencies[d.next] = write.Latency
+	d.next = (d.next + 1) % len(d.latencies)
+}
+
+// ProducerStats returns a snapshot of every destination written to,
+// ordered by destination type and name.
+func (r *Recorder) ProducerStats() []entity.ProducerStats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stats := make([]entity.ProducerStats, 0, len(r.destinations))
+	for _, d := range r.destinations {
+		s := d.stats
+		s.StatusCodes = maps.Clone(d.stats.StatusCodes)
+		s.SetLatencies(d.latencies)
+		stats = append(stats, s)
+	}
+
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].DestinationType != stats[j].DestinationType {
+			return stats[i].DestinationType < stats[j].DestinationType
+		}
+		return stats[i].Destination < stats[j].Destination
+	})
+	return stats
+}
@@ -0,0 +1,108 @@
+package producermetrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+func TestRecorder_ObserveWrite(t *testing.T) {
+	r := NewRecorder()
+	for i := 1; i <= 10; i++ {
+		r.ObserveWrite(entity.ProducerWrite{
+			DestinationType: entity.DestinationTypeHTTP,
+			Destination:     "https://partner/hook",
+			Messages:        1,
+			Latency:         time.Duration(i) * time.Millisecond,
+			HTTPStatus:      200,
+		})
+	}
+	r.ObserveWrite(entity.ProducerWrite{
+		DestinationType: entity.DestinationTypeHTTP,
+		Destination:     "https://partner/hook",
+		Messages:        5,
+		Latency:         100 * time.Millisecond,
+		HTTPStatus:      503,
+		Failed:          true,
+	})
+	r.ObserveWrite(entity.ProducerWrite{
+		DestinationType: entity.DestinationTypeKafka,
+		Destination:     "orders",
+		Messages:        1,
+		Latency:         time.Millisecond,
+		Failed:          true,
+	})
+
+	stats := r.ProducerStats()
+	if len(stats) != 2 {
+		t.Fatalf("expected 2 destinations, got %d", len(stats))
+	}
+
+	http := stats[0]
+	if http.Destination != "https://partner/hook" || http.Writes != 11 || http.Messages != 15 || http.Errors != 1 {
+		t.Fatalf("unexpected http stats: %+v", http)
+	}
+	if http.MaxBatchSize != 5 {
+		t.Fatalf("expected max batch size 5, got %d", http.MaxBatchSize)
+	}
+	if http.StatusCodes[200] != 10 || http.StatusCodes[503] != 1 {
+		t.Fatalf("unexpected status codes: %v", http.StatusCodes)
+	}
+	if http.P50 != 6*time.Millisecond || http.Max != 100*time.Millisecond {
+		t.Fatalf("unexpected latencies: p50 %v, max %v", http.P50, http.Max)
+	}
+
+	kafka := stats[1]
+	if kafka.Destination != "orders" || kafka.ErrorRate() != 1 || len(kafka.StatusCodes) != 0 {
+		t.Fatalf("unexpected kafka stats: %+v", kafka)
+	}
+}
+
+func TestRecorder_latencyWindow(t *testing.T) {
+	r := NewRecorder()
+	for i := 0; i < domain.ProducerLatencySamples; i++ {
+		r.ObserveWrite(entity.ProducerWrite{Destination: "orders", Messages: 1, Latency: time.Second})
+	}
+	for i := 0; i < domain.ProducerLatencySamples; i++ {
+		r.ObserveWrite(entity.ProducerWrite{Destination: "orders", Messages: 1, Latency: time.Millisecond})
+	}
+
+	stats := r.ProducerStats()
+	if stats[0].Max != time.Millisecond {
+		t.Fatalf("expected old latencies to be evicted, got max %v", stats[0].Max)
+	}
+	if stats[0].Writes != int64(2*domain.ProducerLatencySamples) {
+		t.Fatalf("expected all writes counted, got %d", stats[0].Writes)
+	}
+}
+
+func TestRecorder_destinationCap(t *testing.T) {
+	r := NewRecorder()
+	for i := 0; i < domain.ProducerMetricsMaxDestinations+5; i++ {
+		r.ObserveWrite(entity.ProducerWrite{Destination: time.Duration(i).String(), Messages: 1})
+	}
+
+	stats := r.ProducerStats()
+	if len(stats) != domain.ProducerMetricsMaxDestinations+1 {
+		t.Fatalf("expected destinations capped, got %d", len(stats))
+	}
+	for _, s := range stats {
+		if s.Destination == domain.ProducerMetricsOverflow && s.Writes != 5 {
+			t.Fatalf("expected 5 overflow writes, got %d", s.Writes)
+		}
+	}
+}
+
+func TestRecorder_sizeLimits(t *testing.T) {
+	r := NewRecorder()
+	r.ObserveWrite(entity.ProducerWrite{Destination: "https://partner/hook", Failed: true, Oversized: true})
+	r.ObserveWrite(entity.ProducerWrite{Destination: "https://partner/hook", Messages: 1, HTTPStatus: 200, Truncated: true})
+	r.ObserveWrite(entity.ProducerWrite{Destination: "https://partner/hook", Messages: 1, HTTPStatus: 200})
+
+	stats := r.ProducerStats()
+	if stats[0].Oversized != 1 || stats[0].Truncated != 1 || stats[0].Errors != 1 {
+		t.Fatalf("unexpected stats: %+v", stats[0])
+	}
+}
@@ -0,0 +1,90 @@
+package redisstore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+	"github.com/ruudy-sib/rebound/internal/port/secondary"
+)
+
+// cancelledDTO is the Redis representation of a cancelled task.
+type cancelledDTO struct {
+	Task        taskDTO   `json:"task"`
+	DueAt       time.Time `json:"due_at"`
+	CancelledAt time.Time `json:"cancelled_at"`
+}
+
+// CancelledStore implements secondary.CancelledStore with one expiring key
+// per cancelled task.
+type CancelledStore struct {
+	client redis.UniversalClient
+	prefix string
+}
+
+// NewCancelledStore creates a Redis-backed holding area for cancelled tasks.
+func NewCancelledStore(client redis.UniversalClient) secondary.CancelledStore {
+	return &CancelledStore{
+		client: client,
+		prefix: domain.RedisCancelledKeyPrefix,
+	}
+}
+
+// Hold stores the task under its ID with the given TTL, replacing any
+// earlier cancellation of the same ID.
+func (c *CancelledStore) Hold(ctx context.Context, cancelled secondary.CancelledTask, ttl time.Duration) error {
+	data, err := json.Marshal(cancelledDTO{
+		Task:        toDTO(cancelled.Task),
+		DueAt:       cancelled.DueAt.UTC(),
+		CancelledAt: cancelled.CancelledAt.UTC(),
+	})
+	if err != nil {
+		return fmt.Errorf("marshaling cancelled task: %w", err)
+	}
+
+	if err := c.client.Set(ctx, c.prefix+cancelled.Task.ID, data, ttl).Err(); err != nil {
+		return fmt.Errorf("holding cancelled task in redis: %w", err)
+	}
+	return nil
+}
+
+// Get reads the held task.
+func (c *CancelledStore) Get(ctx context.Context, taskID string) (*secondary.CancelledTask, error) {
+	raw, err := c.client.Get(ctx, c.prefix+taskID).Result()
+	if err == redis.Nil {
+		return nil, domain.ErrTaskNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading cancelled task from redis: %w", err)
+	}
+	return decodeCancelled(raw)
+}
+
+// Take atomically reads and deletes the held task.
+func (c *CancelledStore) Take(ctx context.Context, taskID string) (*secondary.CancelledTask, error) {
+	raw, err := c.client.GetDel(ctx, c.prefix+taskID).Result()
+	if err == redis.Nil {
+		return nil, domain.ErrTaskNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("taking cancelled task from redis: %w", err)
+	}
+	return decodeCancelled(raw)
+}
+
+func decodeCancelled(raw string) (*secondary.CancelledTask, error) {
+	var dto cancelledDTO
+	if err := json.Unmarshal([]byte(raw), &dto); err != nil {
+		return nil, fmt.Errorf("invalid cancelled task data in redis: %w", err)
+	}
+
+	return &secondary.CancelledTask{
+		Task:        toEntity(dto.Task),
+		DueAt:       dto.DueAt,
+		CancelledAt: dto.CancelledAt,
+	}, nil
+}
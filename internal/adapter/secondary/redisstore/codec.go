@@ -0,0 +1,472 @@
+package redisstore
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// TaskEncoding selects how tasks are serialized in the schedule and ordering
+// sets. Reads detect the format of each member, so the encoding can be
+// changed on a live deployment without migrating existing tasks.
+type TaskEncoding string
+
+const (
+	// TaskEncodingJSON stores tasks as JSON objects (the default).
+	TaskEncodingJSON TaskEncoding = "json"
+	// TaskEncodingProtobuf stores tasks as a version byte followed by a
+	// protobuf-encoded taskDTO, roughly halving memory per task.
+	TaskEncodingProtobuf TaskEncoding = "protobuf"
+)
+
+// protobufTaskVersion prefixes protobuf-encoded tasks. JSON members always
+// start with '{', so the first byte tells the formats apart.
+const protobufTaskVersion byte = 0x01
+
+// StoreOption configures the task-storing Redis adapters.
+type StoreOption func(*storeOptions)
+
+type storeOptions struct {
+	encoding   TaskEncoding
+	replicator *Replicator
+	lowLatency bool
+	shards     *ShardRing
+
+	priorityShare int
+	namedQueues   bool
+}
+
+// WithTaskEncoding sets the encoding used for newly written tasks. Unknown
+// values fall back to JSON.
+func WithTaskEncoding(encoding TaskEncoding) StoreOption {
+	return func(o *storeOptions) {
+		o.encoding = encoding
+	}
+}
+
+// WithReplicator mirrors the scheduler's writes to a standby Redis through
+// r. Other adapters ignore it.
+func WithReplicator(r *Replicator) StoreOption {
+	return func(o *storeOptions) {
+		o.replicator = r
+	}
+}
+
+// WithLowLatency makes the scheduler claim due tasks with one script, for
+// workers polling more often than once a second. Other adapters ignore it.
+func WithLowLatency(enabled bool) StoreOption {
+	return func(o *storeOptions) {
+		o.lowLatency = enabled
+	}
+}
+
+// WithShardRing makes the scheduler claim only due tasks of the client
+// shards r assigns to this instance. It takes precedence over
+// WithLowLatency. Other adapters ignore it.
+func WithShardRing(r *ShardRing) StoreOption {
+	return func(o *storeOptions) {
+		o.shards = r
+	}
+}
+
+// WithPriorityShare caps the share of each fetched batch, in percent, that
+// priority tasks take while normal tasks are due too, so a flood of
+// priority tasks cannot starve the rest. Zero fetches strictly by due time.
+// It takes precedence over WithLowLatency. Other adapters ignore it.
+func WithPriorityShare(percent int) StoreOption {
+	return func(o *storeOptions) {
+		o.priorityShare = percent
+	}
+}
+
+// WithNamedQueues makes the scheduler claim the due tasks of each queue
+// separately, so named queues can be polled on their own schedule. It
+// takes precedence over WithLowLatency. Other adapters ignore it.
+func WithNamedQueues(enabled bool) StoreOption {
+	return func(o *storeOptions) {
+		o.namedQueues = enabled
+	}
+}
+
+func applyStoreOptions(opts []StoreOption) storeOptions {
+	o := storeOptions{encoding: TaskEncodingJSON}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// encodeTask serializes dto in the given encoding.
+func encodeTask(dto taskDTO, encoding TaskEncoding) ([]byte, error) {
+	if encoding == TaskEncodingProtobuf {
+		return appendTaskProto([]byte{protobufTaskVersion}, dto), nil
+	}
+	return json.Marshal(dto)
+}
+
+// decodeTask deserializes a member written in either encoding.
+func decodeTask(data []byte) (taskDTO, error) {
+	if len(data) > 0 && data[0] == protobufTaskVersion {
+		return decodeTaskProto(data[1:])
+	}
+	var dto taskDTO
+	err := json.Unmarshal(data, &dto)
+	return dto, err
+}
+
+// Protobuf field numbers for taskDTO. The ID comes first so encoded members
+// start with it, which lets Dequeue match them by prefix. Never reuse a
+// number; add new fields at the end.
+const (
+	taskFieldID               = 1
+	taskFieldAttempt          = 2
+	taskFieldSource           = 3
+	taskFieldDestination      = 4
+	taskFieldDeadDestination  = 5
+	taskFieldMaxRetries       = 6
+	taskFieldBaseDelay        = 7
+	taskFieldClientID         = 8
+	taskFieldIsPriority       = 9
+	taskFieldMessageData      = 10
+	taskFieldDestinationType  = 11
+	taskFieldOrderingKey      = 12
+	taskFieldCallbackURL      = 13
+	taskFieldCreatedAt        = 14 // Unix nanoseconds
+	taskFieldFirstAttemptAt   = 15
+	taskFieldLastAttemptAt    = 16
+	taskFieldLastError        = 17
+	taskFieldRepeatedFailures = 18
+	taskFieldSchemaVersion    = 19
+	taskFieldMetadata         = 20 // map<string, string>
+	taskFieldFallbackDead     = 21 // repeated Destination
+	taskFieldLastErrorCode    = 22
+	taskFieldBaseDelayMs      = 23
+	taskFieldRedrives         = 24
+	taskFieldCorrelationID    = 25
+	taskFieldAttemptNow       = 26
+	taskFieldJitter           = 27
+	taskFieldLastRetryDelayMs = 28
+	taskFieldMaxDelayMs       = 29
+	taskFieldRetryDelaysMs    = 30 // packed repeated int64
+	taskFieldQueue            = 31
+)
+
+// Fields of a map entry, as protobuf encodes map<string, string>.
+const (
+	mapEntryFieldKey   = 1
+	mapEntryFieldValue = 2
+)
+
+const (
+	destFieldHost   = 1
+	destFieldPort   = 2
+	destFieldTopic  = 3
+	destFieldURL    = 4
+	destFieldFormat = 5
+)
+
+const (
+	wireVarint = 0
+	wireI64    = 1
+	wireLen    = 2
+	wireI32    = 5
+)
+
+var errTruncatedTask = errors.New("truncated protobuf task")
+
+func appendTaskProto(b []byte, dto taskDTO) []byte {
+	b = appendString(b, taskFieldID, dto.ID)
+	b = appendVarint(b, taskFieldAttempt, uint64(dto.Attempt))
+	b = appendString(b, taskFieldSource, dto.Source)
+	b = appendBytes(b, taskFieldDestination, appendDestProto(nil, dto.Destination))
+	b = appendBytes(b, taskFieldDeadDestination, appendDestProto(nil, dto.DeadDestination))
+	b = appendVarint(b, taskFieldMaxRetries, uint64(dto.MaxRetries))
+	b = appendVarint(b, taskFieldBaseDelay, uint64(dto.BaseDelay))
+	b = appendString(b, taskFieldClientID, dto.ClientID)
+	if dto.IsPriority {
+		b = appendVarint(b, taskFieldIsPriority, 1)
+	}
+	b = appendString(b, taskFieldMessageData, dto.MessageData)
+	b = appendString(b, taskFieldDestinationType, dto.DestinationType)
+	b = appendString(b, taskFieldOrderingKey, dto.OrderingKey)
+	b = appendString(b, taskFieldCallbackURL, dto.CallbackURL)
+	b = appendTime(b, taskFieldCreatedAt, dto.CreatedAt)
+	b = appendTime(b, taskFieldFirstAttemptAt, dto.FirstAttemptAt)
+	b = appendTime(b, taskFieldLastAttemptAt, dto.LastAttemptAt)
+	b = appendString(b, taskFieldLastError, dto.LastError)
+	b = appendVarint(b, taskFieldRepeatedFailures, uint64(dto.RepeatedFailures))
+	b = appendVarint(b, taskFieldSchemaVersion, uint64(dto.SchemaVersion))
+	b = appendMap(b, taskFieldMetadata, dto.Metadata)
+	for _, dest := range dto.FallbackDeadDestinations {
+		// Written even when empty, so every position survives.
+		entry := appendDestProto(nil, dest)
+		b = binary.AppendUvarint(b, uint64(taskFieldFallbackDead)<<3|wireLen)
+		b = binary.AppendUvarint(b, uint64(len(entry)))
+		b = append(b, entry...)
+	}
+	b = appendString(b, taskFieldLastErrorCode, dto.LastErrorCode)
+	b = appendVarint(b, taskFieldBaseDelayMs, uint64(dto.BaseDelayMs))
+	b = appendVarint(b, taskFieldRedrives, uint64(dto.Redrives))
+	b = appendString(b, taskFieldCorrelationID, dto.CorrelationID)
+	if dto.AttemptImmediately {
+		b = appendVarint(b, taskFieldAttemptNow, 1)
+	}
+	b = appendString(b, taskFieldJitter, dto.Jitter)
+	b = appendVarint(b, taskFieldLastRetryDelayMs, uint64(dto.LastRetryDelayMs))
+	b = appendVarint(b, taskFieldMaxDelayMs, uint64(dto.MaxDelayMs))
+	b = appendBytes(b, taskFieldRetryDelaysMs, appendPacked(nil, dto.RetryDelaysMs))
+	b = appendString(b, taskFieldQueue, dto.Queue)
+	return b
+}
+
+// appendPacked writes vs as consecutive varints, the payload of a packed
+// repeated field. Zeros are kept, so every position survives.
+func appendPacked(b []byte, vs []int64) []byte {
+	for _, v := range vs {
+		b = binary.AppendUvarint(b, uint64(v))
+	}
+	return b
+}
+
+// appendMap writes one entry per key, sorted so encoding is deterministic.
+func appendMap(b []byte, field int, m map[string]string) []byte {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		entry := appendString(nil, mapEntryFieldKey, k)
+		entry = appendString(entry, mapEntryFieldValue, m[k])
+		// Written even when empty, so an empty key and value survive.
+		b = binary.AppendUvarint(b, uint64(field)<<3|wireLen)
+		b = binary.AppendUvarint(b, uint64(len(entry)))
+		b = append(b, entry...)
+	}
+	return b
+}
+
+func appendDestProto(b []byte, dest destDTO) []byte {
+	b = appendString(b, destFieldHost, dest.Host)
+	b = appendString(b, destFieldPort, dest.Port)
+	b = appendString(b, destFieldTopic, dest.Topic)
+	b = appendString(b, destFieldURL, dest.URL)
+	b = appendString(b, destFieldFormat, dest.Format)
+	return b
+}
+
+// appendVarint omits zero values, as proto3 does.
+func appendVarint(b []byte, field int, v uint64) []byte {
+	if v == 0 {
+		return b
+	}
+	b = binary.AppendUvarint(b, uint64(field)<<3|wireVarint)
+	return binary.AppendUvarint(b, v)
+}
+
+func appendString(b []byte, field int, s string) []byte {
+	return appendBytes(b, field, []byte(s))
+}
+
+func appendBytes(b []byte, field int, v []byte) []byte {
+	if len(v) == 0 {
+		return b
+	}
+	b = binary.AppendUvarint(b, uint64(field)<<3|wireLen)
+	b = binary.AppendUvarint(b, uint64(len(v)))
+	return append(b, v...)
+}
+
+func appendTime(b []byte, field int, t *time.Time) []byte {
+	if t == nil {
+		return b
+	}
+	return appendVarint(b, field, uint64(t.UnixNano()))
+}
+
+func decodeTaskProto(b []byte) (taskDTO, error) {
+	var dto taskDTO
+	err := walkProto(b, func(field int, v uint64, data []byte) error {
+		var err error
+		switch field {
+		case taskFieldID:
+			dto.ID = string(data)
+		case taskFieldAttempt:
+			dto.Attempt = int(v)
+		case taskFieldSource:
+			dto.Source = string(data)
+		case taskFieldDestination:
+			dto.Destination, err = decodeDestProto(data)
+		case taskFieldDeadDestination:
+			dto.DeadDestination, err = decodeDestProto(data)
+		case taskFieldMaxRetries:
+			dto.MaxRetries = int(v)
+		case taskFieldBaseDelay:
+			dto.BaseDelay = int(v)
+		case taskFieldClientID:
+			dto.ClientID = string(data)
+		case taskFieldIsPriority:
+			dto.IsPriority = v != 0
+		case taskFieldMessageData:
+			dto.MessageData = string(data)
+		case taskFieldDestinationType:
+			dto.DestinationType = string(data)
+		case taskFieldOrderingKey:
+			dto.OrderingKey = string(data)
+		case taskFieldCallbackURL:
+			dto.CallbackURL = string(data)
+		case taskFieldCreatedAt:
+			dto.CreatedAt = unixNanoPtr(v)
+		case taskFieldFirstAttemptAt:
+			dto.FirstAttemptAt = unixNanoPtr(v)
+		case taskFieldLastAttemptAt:
+			dto.LastAttemptAt = unixNanoPtr(v)
+		case taskFieldLastError:
+			dto.LastError = string(data)
+		case taskFieldRepeatedFailures:
+			dto.RepeatedFailures = int(v)
+		case taskFieldSchemaVersion:
+			dto.SchemaVersion = int(v)
+		case taskFieldMetadata:
+			if dto.Metadata == nil {
+				dto.Metadata = make(map[string]string)
+			}
+			err = decodeMapEntry(data, dto.Metadata)
+		case taskFieldFallbackDead:
+			var dest destDTO
+			dest, err = decodeDestProto(data)
+			dto.FallbackDeadDestinations = append(dto.FallbackDeadDestinations, dest)
+		case taskFieldLastErrorCode:
+			dto.LastErrorCode = string(data)
+		case taskFieldBaseDelayMs:
+			dto.BaseDelayMs = int64(v)
+		case taskFieldRedrives:
+			dto.Redrives = int(v)
+		case taskFieldCorrelationID:
+			dto.CorrelationID = string(data)
+		case taskFieldAttemptNow:
+			dto.AttemptImmediately = v != 0
+		case taskFieldJitter:
+			dto.Jitter = string(data)
+		case taskFieldLastRetryDelayMs:
+			dto.LastRetryDelayMs = int64(v)
+		case taskFieldMaxDelayMs:
+			dto.MaxDelayMs = int64(v)
+		case taskFieldRetryDelaysMs:
+			dto.RetryDelaysMs, err = decodePacked(data, dto.RetryDelaysMs)
+		case taskFieldQueue:
+			dto.Queue = string(data)
+		}
+		return err
+	})
+	return dto, err
+}
+
+// decodePacked appends the varints of a packed repeated field to vs.
+func decodePacked(b []byte, vs []int64) ([]int64, error) {
+	for len(b) > 0 {
+		v, n := binary.Uvarint(b)
+		if n <= 0 {
+			return vs, errTruncatedTask
+		}
+		b = b[n:]
+		vs = append(vs, int64(v))
+	}
+	return vs, nil
+}
+
+func decodeDestProto(b []byte) (destDTO, error) {
+	var dest destDTO
+	err := walkProto(b, func(field int, _ uint64, data []byte) error {
+		switch field {
+		case destFieldHost:
+			dest.Host = string(data)
+		case destFieldPort:
+			dest.Port = string(data)
+		case destFieldTopic:
+			dest.Topic = string(data)
+		case destFieldURL:
+			dest.URL = string(data)
+		case destFieldFormat:
+			dest.Format = string(data)
+		}
+		return nil
+	})
+	return dest, err
+}
+
+func decodeMapEntry(b []byte, m map[string]string) error {
+	var key, value string
+	err := walkProto(b, func(field int, _ uint64, data []byte) error {
+		switch field {
+		case mapEntryFieldKey:
+			key = string(data)
+		case mapEntryFieldValue:
+			value = string(data)
+		}
+		return nil
+	})
+	m[key] = value
+	return err
+}
+
+// unixNanoPtr maps zero to nil, since appendTime never writes a zero value.
+func unixNanoPtr(v uint64) *time.Time {
+	if v == 0 {
+		return nil
+	}
+	t := time.Unix(0, int64(v)).UTC()
+	return &t
+}
+
+// walkProto calls fn for each field in b with its varint value or
+// length-delimited bytes. Fixed-width fields are unused by taskDTO and
+// skipped, so fields from newer versions do not fail decoding.
+func walkProto(b []byte, fn func(field int, v uint64, data []byte) error) error {
+	for len(b) > 0 {
+		tag, n := binary.Uvarint(b)
+		if n <= 0 {
+			return errTruncatedTask
+		}
+		b = b[n:]
+		field := int(tag >> 3)
+
+		switch tag & 7 {
+		case wireVarint:
+			v, n := binary.Uvarint(b)
+			if n <= 0 {
+				return errTruncatedTask
+			}
+			b = b[n:]
+			if err := fn(field, v, nil); err != nil {
+				return err
+			}
+		case wireLen:
+			l, n := binary.Uvarint(b)
+			if n <= 0 || uint64(len(b)-n) < l {
+				return errTruncatedTask
+			}
+			data := b[n : n+int(l)]
+			b = b[n+int(l):]
+			if err := fn(field, 0, data); err != nil {
+				return err
+			}
+		case wireI64:
+			if len(b) < 8 {
+				return errTruncatedTask
+			}
+			b = b[8:]
+		case wireI32:
+			if len(b) < 4 {
+				return errTruncatedTask
+			}
+			b = b[4:]
+		default:
+			return fmt.Errorf("unsupported protobuf wire type %d", tag&7)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+package redisstore
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+func TestTaskCodec_roundTrip(t *testing.T) {
+	created := time.Date(2024, 3, 1, 12, 0, 0, 123, time.UTC)
+	dto := taskDTO{
+		SchemaVersion:   currentSchemaVersion(),
+		ID:              "task-1",
+		Attempt:         2,
+		Source:          "billing",
+		Destination:     destDTO{Host: "localhost", Port: "9092", Topic: "invoices"},
+		DeadDestination: destDTO{URL: "http://localhost/dead", Format: "slack"},
+		MaxRetries:      5,
+		BaseDelay:       1,
+		BaseDelayMs:     500,
+		ClientID:        "client-1",
+		IsPriority:      true,
+		MessageData:     `{"amount":42}`,
+		DestinationType: "kafka",
+		OrderingKey:     "customer-42",
+		Metadata:        map[string]string{"correlation-id": "abc-123", "tenant": ""},
+		CorrelationID:   "txn-42",
+
+		AttemptImmediately: true,
+		Jitter:             "decorrelated",
+		MaxDelayMs:         3600000,
+
+		RetryDelaysMs: []int64{10000, 0, 600000},
+
+		FallbackDeadDestinations: []destDTO{
+			{Host: "localhost", Port: "9092", Topic: "audit"},
+			{URL: "http://localhost/audit"},
+		},
+		CreatedAt:        &created,
+		LastError:        "connection refused",
+		LastErrorCode:    "CONN_REFUSED",
+		RepeatedFailures: 1,
+		Redrives:         2,
+		LastRetryDelayMs: 4000,
+	}
+
+	for _, encoding := range []TaskEncoding{TaskEncodingJSON, TaskEncodingProtobuf} {
+		t.Run(string(encoding), func(t *testing.T) {
+			data, err := encodeTask(dto, encoding)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got, err := decodeTask(data)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, dto) {
+				t.Fatalf("round trip mismatch:\n got %+v\nwant %+v", got, dto)
+			}
+		})
+	}
+}
+
+func TestTaskDTO_baseDelay(t *testing.T) {
+	task := toEntity(toDTO(&entity.Task{ID: "task-1", BaseDelay: 1500 * time.Millisecond}))
+	if task.BaseDelay != 1500*time.Millisecond {
+		t.Fatalf("expected 1.5s, got %s", task.BaseDelay)
+	}
+
+	// Tasks stored before sub-second delays only carry whole seconds.
+	legacy := toEntity(taskDTO{ID: "task-1", BaseDelay: 3})
+	if legacy.BaseDelay != 3*time.Second {
+		t.Fatalf("expected 3s, got %s", legacy.BaseDelay)
+	}
+}
+
+func TestTaskCodec_protobufIsSmaller(t *testing.T) {
+	dto := taskDTO{ID: "task-1", MaxRetries: 3, BaseDelay: 2, MessageData: "x", DestinationType: "http",
+		Destination: destDTO{URL: "http://localhost/hook"}}
+
+	jsonData, _ := encodeTask(dto, TaskEncodingJSON)
+	protoData, _ := encodeTask(dto, TaskEncodingProtobuf)
+	if len(protoData)*2 > len(jsonData) {
+		t.Fatalf("expected protobuf to be under half the JSON size, got %d vs %d bytes", len(protoData), len(jsonData))
+	}
+}
+
+func TestTaskCodec_truncated(t *testing.T) {
+	data, _ := encodeTask(taskDTO{ID: "task-1", MessageData: "payload"}, TaskEncodingProtobuf)
+	if _, err := decodeTask(data[:len(data)-2]); err == nil {
+		t.Fatal("expected an error for a truncated task")
+	}
+}
+
+func benchTaskDTO() taskDTO {
+	created := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	return taskDTO{
+		SchemaVersion:   currentSchemaVersion(),
+		ID:              "task-1",
+		Attempt:         2,
+		Source:          "billing",
+		Destination:     destDTO{Host: "localhost", Port: "9092", Topic: "invoices"},
+		DeadDestination: destDTO{Host: "localhost", Port: "9092", Topic: "invoices-dlq"},
+		MaxRetries:      5,
+		BaseDelay:       10,
+		ClientID:        "client-1",
+		MessageData:     `{"invoice_id":"inv-42","amount":42,"currency":"EUR"}`,
+		DestinationType: "kafka",
+		Metadata:        map[string]string{"correlation-id": "abc-123"},
+		CreatedAt:       &created,
+	}
+}
+
+func BenchmarkEncodeTask(b *testing.B) {
+	dto := benchTaskDTO()
+	for _, encoding := range []TaskEncoding{TaskEncodingJSON, TaskEncodingProtobuf} {
+		b.Run(string(encoding), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := encodeTask(dto, encoding); err != nil {
+					b.Fatalf("unexpected error: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkDecodeTask(b *testing.B) {
+	dto := benchTaskDTO()
+	for _, encoding := range []TaskEncoding{TaskEncodingJSON, TaskEncodingProtobuf} {
+		b.Run(string(encoding), func(b *testing.B) {
+			data, err := encodeTask(dto, encoding)
+			if err != nil {
+				b.Fatalf("unexpected error: %v", err)
+			}
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := decodeTask(data); err != nil {
+					b.Fatalf("unexpected error: %v", err)
+				}
+			}
+		})
+	}
+}
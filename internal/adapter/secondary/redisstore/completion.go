@@ -0,0 +1,45 @@
+package redisstore
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+	"github.com/ruudy-sib/rebound/internal/port/secondary"
+)
+
+// CompletionStore implements secondary.CompletionStore with one expiring
+// key per delivered task.
+type CompletionStore struct {
+	client redis.UniversalClient
+	prefix string
+}
+
+// NewCompletionStore creates a Redis-backed completion marker store.
+func NewCompletionStore(client redis.UniversalClient) secondary.CompletionStore {
+	return &CompletionStore{
+		client: client,
+		prefix: domain.RedisCompletedKeyPrefix,
+	}
+}
+
+// MarkCompleted sets the marker if it does not exist yet. An existing marker
+// is left untouched so its original expiry is kept.
+func (c *CompletionStore) MarkCompleted(ctx context.Context, taskID string, ttl time.Duration) error {
+	if err := c.client.SetNX(ctx, c.prefix+taskID, time.Now().Unix(), ttl).Err(); err != nil {
+		return fmt.Errorf("setting completion marker in redis: %w", err)
+	}
+	return nil
+}
+
+// IsCompleted reports whether the marker exists.
+func (c *CompletionStore) IsCompleted(ctx context.Context, taskID string) (bool, error) {
+	n, err := c.client.Exists(ctx, c.prefix+taskID).Result()
+	if err != nil {
+		return false, fmt.Errorf("checking completion marker in redis: %w", err)
+	}
+	return n > 0, nil
+}
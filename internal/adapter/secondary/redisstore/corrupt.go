@@ -0,0 +1,114 @@
+package redisstore
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+// corruptDTO is the Redis representation of a corrupt member.
+type corruptDTO struct {
+	Raw     string    `json:"raw"`
+	Error   string    `json:"error"`
+	DueAt   time.Time `json:"due_at"`
+	FoundAt time.Time `json:"found_at"`
+}
+
+// corruptID derives a stable ID for a raw member, so the same member found
+// twice is stored once.
+func corruptID(raw string) string {
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:8])
+}
+
+// quarantineCorrupt moves a member that failed to decode into the corrupt
+// hash. The member has already been removed from the schedule, so if this
+// fails it is logged in full as the last trace of it. Otherwise only its ID
+// is logged, keeping the payload out of log storage.
+func (s *Scheduler) quarantineCorrupt(ctx context.Context, member string, score float64, decodeErr error) {
+	logger := s.logger.With(zap.NamedError("decode_error", decodeErr))
+
+	data, err := json.Marshal(corruptDTO{
+		Raw:     member,
+		Error:   decodeErr.Error(),
+		DueAt:   scoreTime(score).UTC(),
+		FoundAt: time.Now().UTC(),
+	})
+	if err == nil {
+		_, err = s.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.HSet(ctx, domain.RedisCorruptKey, corruptID(member), data)
+			pipe.Incr(ctx, domain.RedisCorruptCountKey)
+			return nil
+		})
+	}
+	if err != nil {
+		logger.Error("failed to quarantine corrupt task data, member lost", zap.Error(err), zap.String("raw", member))
+		return
+	}
+	logger.Warn("invalid task data in redis, moved to corrupt queue", zap.String("corrupt_id", corruptID(member)))
+}
+
+// ListCorrupt reads the corrupt hash and returns the newest members. The
+// hash is expected to stay small, so it is read whole.
+func (q *QueueStore) ListCorrupt(ctx context.Context, limit int) ([]entity.CorruptMember, error) {
+	entries, err := q.client.HGetAll(ctx, domain.RedisCorruptKey).Result()
+	if err != nil {
+		return nil, fmt.Errorf("reading corrupt members from redis: %w", err)
+	}
+
+	members := make([]entity.CorruptMember, 0, len(entries))
+	for id, data := range entries {
+		var dto corruptDTO
+		if err := json.Unmarshal([]byte(data), &dto); err != nil {
+			dto.Raw = data
+			dto.Error = fmt.Sprintf("unreadable corrupt entry: %v", err)
+		}
+		members = append(members, entity.CorruptMember{
+			ID:      id,
+			Raw:     dto.Raw,
+			Error:   dto.Error,
+			DueAt:   dto.DueAt,
+			FoundAt: dto.FoundAt,
+		})
+	}
+
+	sort.Slice(members, func(i, j int) bool {
+		if !members[i].FoundAt.Equal(members[j].FoundAt) {
+			return members[i].FoundAt.After(members[j].FoundAt)
+		}
+		return members[i].ID < members[j].ID
+	})
+	if len(members) > limit {
+		members = members[:limit]
+	}
+	return members, nil
+}
+
+// CorruptTotal reads the corrupt member counter.
+func (q *QueueStore) CorruptTotal(ctx context.Context) (int64, error) {
+	total, err := q.client.Get(ctx, domain.RedisCorruptCountKey).Int64()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return 0, fmt.Errorf("reading corrupt member count from redis: %w", err)
+	}
+	return total, nil
+}
+
+// DeleteCorrupt removes one corrupt member.
+func (q *QueueStore) DeleteCorrupt(ctx context.Context, id string) (bool, error) {
+	removed, err := q.client.HDel(ctx, domain.RedisCorruptKey, id).Result()
+	if err != nil {
+		return false, fmt.Errorf("deleting corrupt member from redis: %w", err)
+	}
+	return removed > 0, nil
+}
@@ -0,0 +1,15 @@
+package redisstore
+
+import "testing"
+
+func TestCorruptID(t *testing.T) {
+	if corruptID("{bad") != corruptID("{bad") {
+		t.Fatal("expected the same member to get the same id")
+	}
+	if corruptID("{bad") == corruptID("{worse") {
+		t.Fatal("expected different members to get different ids")
+	}
+	if got := len(corruptID("{bad")); got != 16 {
+		t.Fatalf("expected a 16 character id, got %d", got)
+	}
+}
@@ -0,0 +1,100 @@
+package redisstore
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+// cursorMemberBytes is how much of a member a cursor keeps. Schedule
+// members embed the whole task, payload included, so a cursor only keeps
+// their start, which holds the task ID. Truncating keeps the member order:
+// two members sharing a score and a cursorMemberBytes prefix are the only
+// ones a cursor cannot tell apart.
+const cursorMemberBytes = 128
+
+// cursorMember truncates a member for a cursor.
+func cursorMember(member string) string {
+	if len(member) > cursorMemberBytes {
+		return member[:cursorMemberBytes]
+	}
+	return member
+}
+
+// rangeAfter reads up to limit members of a sorted set that come after the
+// cursor, in ascending order or, if reverse, descending. next is the
+// cursor of the last member returned, or zero if none are left.
+func rangeAfter(ctx context.Context, client redis.UniversalClient, key string, after entity.Cursor, limit int, reverse bool) (page []redis.Z, next entity.Cursor, err error) {
+	want := limit + 1 // one more, to tell whether there is a next page
+	if after.IsZero() {
+		if reverse {
+			page, err = client.ZRevRangeWithScores(ctx, key, 0, int64(want)-1).Result()
+		} else {
+			page, err = client.ZRangeWithScores(ctx, key, 0, int64(want)-1).Result()
+		}
+		if err != nil {
+			return nil, entity.Cursor{}, err
+		}
+	} else {
+		page, err = rangeFromCursor(ctx, client, key, after, want, reverse)
+		if err != nil {
+			return nil, entity.Cursor{}, err
+		}
+	}
+
+	if len(page) <= limit {
+		return page, entity.Cursor{}, nil
+	}
+	page = page[:limit]
+	last := page[limit-1]
+	member, _ := last.Member.(string)
+	return page, entity.Cursor{Score: last.Score, Member: cursorMember(member)}, nil
+}
+
+// rangeFromCursor reads members from the cursor's score on, skipping those
+// sharing its score that sort at or before its member.
+func rangeFromCursor(ctx context.Context, client redis.UniversalClient, key string, after entity.Cursor, want int, reverse bool) ([]redis.Z, error) {
+	bound := strconv.FormatFloat(after.Score, 'f', -1, 64)
+	batch := int64(max(want, 100))
+
+	var page []redis.Z
+	for offset := int64(0); len(page) < want; offset += batch {
+		by := &redis.ZRangeBy{Min: bound, Max: "+inf", Offset: offset, Count: batch}
+		cmd := client.ZRangeByScoreWithScores
+		if reverse {
+			by = &redis.ZRangeBy{Min: "-inf", Max: bound, Offset: offset, Count: batch}
+			cmd = client.ZRevRangeByScoreWithScores
+		}
+		results, err := cmd(ctx, key, by).Result()
+		if err != nil {
+			return nil, err
+		}
+		for _, z := range results {
+			if z.Score == after.Score && !pastCursor(z, after, reverse) {
+				continue
+			}
+			page = append(page, z)
+			if len(page) == want {
+				break
+			}
+		}
+		if int64(len(results)) < batch {
+			break
+		}
+	}
+	return page, nil
+}
+
+// pastCursor reports whether a member sharing the cursor's score comes
+// after it in the listing order.
+func pastCursor(z redis.Z, after entity.Cursor, reverse bool) bool {
+	member, _ := z.Member.(string)
+	member = cursorMember(member)
+	if reverse {
+		return member < after.Member
+	}
+	return member > after.Member
+}
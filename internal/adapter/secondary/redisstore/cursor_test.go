@@ -0,0 +1,47 @@
+package redisstore
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+func TestCursorMember(t *testing.T) {
+	short := `{"id":"task-1"}`
+	if got := cursorMember(short); got != short {
+		t.Fatalf("expected short member kept whole, got %q", got)
+	}
+	long := `{"id":"task-1","message_data":"` + strings.Repeat("x", 1000) + `"}`
+	if got := cursorMember(long); len(got) != cursorMemberBytes || !strings.HasPrefix(long, got) {
+		t.Fatalf("expected the first %d bytes, got %q", cursorMemberBytes, got)
+	}
+}
+
+func TestPastCursor(t *testing.T) {
+	after := entity.Cursor{Score: 100, Member: "task-b"}
+
+	tests := []struct {
+		name    string
+		member  string
+		reverse bool
+		want    bool
+	}{
+		{name: "the cursor member itself", member: "task-b", want: false},
+		{name: "earlier member", member: "task-a", want: false},
+		{name: "later member", member: "task-c", want: true},
+		{name: "reverse: the cursor member itself", member: "task-b", reverse: true, want: false},
+		{name: "reverse: earlier member", member: "task-a", reverse: true, want: true},
+		{name: "reverse: later member", member: "task-c", reverse: true, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			z := redis.Z{Score: after.Score, Member: tt.member}
+			if got := pastCursor(z, after, tt.reverse); got != tt.want {
+				t.Fatalf("pastCursor(%q) = %v, want %v", tt.member, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package redisstore
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+	"github.com/ruudy-sib/rebound/internal/port/secondary"
+)
+
+// deadLetterDTO is the Redis representation of a dead-lettered task.
+type deadLetterDTO struct {
+	Task     taskDTO   `json:"task"`
+	Reason   string    `json:"reason"`
+	StoredAt time.Time `json:"stored_at"`
+}
+
+// DeadLetterStore implements secondary.DeadLetterStore. Task payloads are kept
+// in a hash keyed by task ID and indexed by a sorted set scored by store time,
+// so entries can be listed in order and expired by age.
+type DeadLetterStore struct {
+	client  redis.UniversalClient
+	key     string
+	dataKey string
+	logger  *zap.Logger
+}
+
+// NewDeadLetterStore creates a Redis-backed dead-letter store.
+func NewDeadLetterStore(client redis.UniversalClient, logger *zap.Logger) secondary.DeadLetterStore {
+	return &DeadLetterStore{
+		client:  client,
+		key:     domain.RedisDeadLetterKey,
+		dataKey: domain.RedisDeadLetterDataKey,
+		logger:  logger.Named("redis-dead-letter"),
+	}
+}
+
+// Store persists the task under its ID and indexes it by the current time.
+func (d *DeadLetterStore) Store(ctx context.Context, task *entity.Task, reason string) error {
+	now := time.Now().UTC()
+	data, err := json.Marshal(deadLetterDTO{
+		Task:     toDTO(task),
+		Reason:   reason,
+		StoredAt: now,
+	})
+	if err != nil {
+		return fmt.Errorf("marshaling dead-letter task: %w", err)
+	}
+
+	// Plain pipeline rather than MULTI: the two keys may live on different
+	// cluster slots.
+	_, err = d.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, d.dataKey, task.ID, data)
+		pipe.ZAdd(ctx, d.key, redis.Z{Score: float64(now.Unix()), Member: task.ID})
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("storing dead-letter task in redis: %w", err)
+	}
+
+	d.logger.Info("task stored in dead-letter set",
+		zap.String("task_id", task.ID),
+		zap.String("reason", reason),
+	)
+
+	return nil
+}
+
+// List reads the newest entries after the cursor from the index and their
+// payloads from the hash. Index entries without a readable payload are
+// skipped.
+func (d *DeadLetterStore) List(ctx context.Context, after entity.Cursor, limit int) ([]entity.DeadLetter, entity.Cursor, error) {
+	page, next, err := rangeAfter(ctx, d.client, d.key, after, limit, true)
+	if err != nil {
+		return nil, entity.Cursor{}, fmt.Errorf("listing dead-letter tasks in redis: %w", err)
+	}
+	if len(page) == 0 {
+		return nil, entity.Cursor{}, nil
+	}
+
+	ids := make([]string, len(page))
+	for i, z := range page {
+		ids[i], _ = z.Member.(string)
+	}
+	values, err := d.client.HMGet(ctx, d.dataKey, ids...).Result()
+	if err != nil {
+		return nil, entity.Cursor{}, fmt.Errorf("reading dead-letter tasks from redis: %w", err)
+	}
+
+	entries := make([]entity.DeadLetter, 0, len(values))
+	for i, v := range values {
+		raw, ok := v.(string)
+		if !ok {
+			continue
+		}
+		entry, err := decodeDeadLetter(raw)
+		if err != nil {
+			d.logger.Warn("invalid dead-letter data in redis", zap.Error(err), zap.String("task_id", ids[i]))
+			continue
+		}
+		entries = append(entries, *entry)
+	}
+	return entries, next, nil
+}
+
+// Take deletes the entry from the hash first; only the caller whose HDEL
+// succeeds gets the task, so concurrent takes cannot both replay it.
+func (d *DeadLetterStore) Take(ctx context.Context, taskID string) (*entity.DeadLetter, error) {
+	raw, err := d.client.HGet(ctx, d.dataKey, taskID).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, domain.ErrTaskNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading dead-letter task from redis: %w", err)
+	}
+
+	entry, err := decodeDeadLetter(raw)
+	if err != nil {
+		return nil, fmt.Errorf("decoding dead-letter task %s: %w", taskID, err)
+	}
+
+	removed, err := d.client.HDel(ctx, d.dataKey, taskID).Result()
+	if err != nil {
+		return nil, fmt.Errorf("removing dead-letter task from redis: %w", err)
+	}
+	if removed == 0 {
+		return nil, domain.ErrTaskNotFound
+	}
+	if err := d.client.ZRem(ctx, d.key, taskID).Err(); err != nil {
+		d.logger.Warn("failed to remove dead-letter index entry", zap.Error(err), zap.String("task_id", taskID))
+	}
+	return entry, nil
+}
+
+func decodeDeadLetter(raw string) (*entity.DeadLetter, error) {
+	var dto deadLetterDTO
+	if err := json.Unmarshal([]byte(raw), &dto); err != nil {
+		return nil, err
+	}
+	return &entity.DeadLetter{
+		Task:     toEntity(dto.Task),
+		Reason:   dto.Reason,
+		StoredAt: dto.StoredAt,
+	}, nil
+}
@@ -0,0 +1,98 @@
+package redisstore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+	"github.com/ruudy-sib/rebound/internal/port/secondary"
+)
+
+// deliveryResultDTO is the Redis representation of a delivery result.
+type deliveryResultDTO struct {
+	TaskID          string    `json:"task_id"`
+	Source          string    `json:"source,omitempty"`
+	DestinationType string    `json:"destination_type"`
+	Destination     string    `json:"destination"`
+	Attempts        int       `json:"attempts"`
+	DeliveredAt     time.Time `json:"delivered_at"`
+	HTTPStatus      int       `json:"http_status,omitempty"`
+	ResponseBody    string    `json:"response_body,omitempty"`
+	Partition       int       `json:"partition"`
+	Offset          int64     `json:"offset"`
+}
+
+// DeliveryResultStore implements secondary.DeliveryResultStore with one
+// expiring key per delivered task.
+type DeliveryResultStore struct {
+	client redis.UniversalClient
+	prefix string
+}
+
+// NewDeliveryResultStore creates a Redis-backed delivery result store.
+func NewDeliveryResultStore(client redis.UniversalClient) secondary.DeliveryResultStore {
+	return &DeliveryResultStore{
+		client: client,
+		prefix: domain.RedisDeliveryResultKeyPrefix,
+	}
+}
+
+// Save stores the result under its task ID with the given TTL.
+func (d *DeliveryResultStore) Save(ctx context.Context, result entity.DeliveryResult, ttl time.Duration) error {
+	data, err := json.Marshal(deliveryResultDTO{
+		TaskID:          result.TaskID,
+		Source:          result.Source,
+		DestinationType: string(result.DestinationType),
+		Destination:     result.Destination,
+		Attempts:        result.Attempts,
+		DeliveredAt:     result.DeliveredAt.UTC(),
+		HTTPStatus:      result.Receipt.HTTPStatus,
+		ResponseBody:    result.Receipt.ResponseBody,
+		Partition:       result.Receipt.Partition,
+		Offset:          result.Receipt.Offset,
+	})
+	if err != nil {
+		return fmt.Errorf("marshaling delivery result: %w", err)
+	}
+
+	if err := d.client.Set(ctx, d.prefix+result.TaskID, data, ttl).Err(); err != nil {
+		return fmt.Errorf("saving delivery result in redis: %w", err)
+	}
+	return nil
+}
+
+// Get reads the result stored for the task.
+func (d *DeliveryResultStore) Get(ctx context.Context, taskID string) (*entity.DeliveryResult, error) {
+	raw, err := d.client.Get(ctx, d.prefix+taskID).Result()
+	if err == redis.Nil {
+		return nil, domain.ErrTaskNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading delivery result from redis: %w", err)
+	}
+
+	var dto deliveryResultDTO
+	if err := json.Unmarshal([]byte(raw), &dto); err != nil {
+		return nil, fmt.Errorf("invalid delivery result data in redis: %w", err)
+	}
+
+	return &entity.DeliveryResult{
+		TaskID:          dto.TaskID,
+		Source:          dto.Source,
+		DestinationType: entity.DestinationType(dto.DestinationType),
+		Destination:     dto.Destination,
+		Attempts:        dto.Attempts,
+		DeliveredAt:     dto.DeliveredAt,
+		Receipt: entity.DeliveryReceipt{
+			HTTPStatus:   dto.HTTPStatus,
+			ResponseBody: dto.ResponseBody,
+			Partition:    dto.Partition,
+			Offset:       dto.Offset,
+		},
+	}, nil
+}
@@ -0,0 +1,237 @@
+package redisstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+	"github.com/ruudy-sib/rebound/internal/port/secondary"
+)
+
+// Heartbeat implements secondary.HeartbeatStore and secondary.HealthChecker
+// with one expiring key per instance. The check fails once the worker has
+// not beaten within the heartbeat TTL. Every beat is also recorded in a
+// shared registry so WorkersCheck can spot replicas that stopped polling,
+// and each poll's counts are added to a per-instance stats hash.
+type Heartbeat struct {
+	client   redis.UniversalClient
+	key      string
+	statsKey string
+	registry string
+	instance string
+}
+
+var (
+	_ secondary.HeartbeatStore = (*Heartbeat)(nil)
+	_ secondary.HealthChecker  = (*Heartbeat)(nil)
+)
+
+// NewHeartbeat creates a Redis-backed heartbeat for the named instance.
+func NewHeartbeat(client redis.UniversalClient, instance string) *Heartbeat {
+	return &Heartbeat{
+		client:   client,
+		key:      domain.RedisHeartbeatKeyPrefix + instance,
+		statsKey: domain.RedisWorkerStatsKeyPrefix + instance,
+		registry: domain.RedisWorkersKey,
+		instance: instance,
+	}
+}
+
+// Beat stores the current time under the instance key with an expiry and
+// in the worker registry.
+func (h *Heartbeat) Beat(ctx context.Context, ttl time.Duration) error {
+	now := time.Now().Unix()
+	// Plain pipeline rather than MULTI: the keys may live on different
+	// cluster slots.
+	if _, err := h.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, h.key, now, ttl)
+		pipe.HSet(ctx, h.registry, h.instance, now)
+		return nil
+	}); err != nil {
+		return fmt.Errorf("setting worker heartbeat in redis: %w", err)
+	}
+	return nil
+}
+
+// RecordPoll adds a poll's counts to the instance's stats hash, noting when
+// the instance started on its first poll. The hash expires once the
+// instance has been silent for domain.WorkerForgetAfter.
+func (h *Heartbeat) RecordPoll(ctx context.Context, processed, errors int) error {
+	if _, err := h.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSetNX(ctx, h.statsKey, workerStatsStarted, time.Now().Unix())
+		pipe.HIncrBy(ctx, h.statsKey, workerStatsProcessed, int64(processed))
+		pipe.HIncrBy(ctx, h.statsKey, workerStatsErrors, int64(errors))
+		pipe.Expire(ctx, h.statsKey, domain.WorkerForgetAfter)
+		return nil
+	}); err != nil {
+		return fmt.Errorf("recording worker stats in redis: %w", err)
+	}
+	return nil
+}
+
+// Forget removes the instance from the worker registry, so an instance
+// that shuts down cleanly is not reported as stalled, and drops its stats.
+func (h *Heartbeat) Forget(ctx context.Context) error {
+	if _, err := h.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, h.key, h.statsKey)
+		pipe.HDel(ctx, h.registry, h.instance)
+		return nil
+	}); err != nil {
+		return fmt.Errorf("removing worker heartbeat from redis: %w", err)
+	}
+	return nil
+}
+
+// Name returns the name of this health check.
+func (h *Heartbeat) Name() string {
+	return "worker"
+}
+
+// Check reports an error unless the worker has beaten recently.
+func (h *Heartbeat) Check(ctx context.Context) error {
+	err := h.client.Get(ctx, h.key).Err()
+	if errors.Is(err, redis.Nil) {
+		return errors.New("no recent worker heartbeat")
+	}
+	return err
+}
+
+// WorkersCheck implements secondary.HealthChecker over the worker registry.
+// It fails while any registered instance has not beaten within staleAfter,
+// and drops instances silent for longer than domain.WorkerForgetAfter.
+type WorkersCheck struct {
+	client     redis.UniversalClient
+	registry   string
+	staleAfter time.Duration
+}
+
+// NewWorkersCheck creates a check reporting instances whose last heartbeat
+// is older than staleAfter.
+func NewWorkersCheck(client redis.UniversalClient, staleAfter time.Duration) secondary.HealthChecker {
+	return &WorkersCheck{
+		client:     client,
+		registry:   domain.RedisWorkersKey,
+		staleAfter: staleAfter,
+	}
+}
+
+// Name returns the name of this health check.
+func (w *WorkersCheck) Name() string {
+	return "workers"
+}
+
+// Check lists stalled instances with the age of their last heartbeat.
+func (w *WorkersCheck) Check(ctx context.Context) error {
+	beats, err := w.client.HGetAll(ctx, w.registry).Result()
+	if err != nil {
+		return fmt.Errorf("reading worker registry from redis: %w", err)
+	}
+
+	stale, forgotten := staleWorkers(beats, time.Now(), w.staleAfter, domain.WorkerForgetAfter)
+	if len(forgotten) > 0 {
+		if err := w.client.HDel(ctx, w.registry, forgotten...).Err(); err != nil {
+			return fmt.Errorf("pruning worker registry in redis: %w", err)
+		}
+	}
+	if len(stale) > 0 {
+		return fmt.Errorf("stale workers: %s", strings.Join(stale, ", "))
+	}
+	return nil
+}
+
+// staleWorkers splits registry entries into stalled instances, described
+// with the age of their last beat, and instances to forget. Unparseable
+// entries are forgotten.
+func staleWorkers(beats map[string]string, now time.Time, staleAfter, forgetAfter time.Duration) (stale, forgotten []string) {
+	for instance, raw := range beats {
+		unix, err := strconv.ParseInt(raw, 10, 64)
+		age := now.Sub(time.Unix(unix, 0))
+		switch {
+		case err != nil || age > forgetAfter:
+			forgotten = append(forgotten, instance)
+		case age > staleAfter:
+			stale = append(stale, fmt.Sprintf("%s (last beat %s ago)", instance, age.Truncate(time.Second)))
+		}
+	}
+	sort.Strings(stale)
+	sort.Strings(forgotten)
+	return stale, forgotten
+}
+
+// Fields of a worker's stats hash.
+const (
+	workerStatsStarted   = "started"
+	workerStatsProcessed = "processed"
+	workerStatsErrors    = "errors"
+	workerStatsShards    = "shards"
+)
+
+// WorkerRegistry implements secondary.WorkerRegistry over the worker
+// registry and the per-instance stats hashes.
+type WorkerRegistry struct {
+	client redis.UniversalClient
+}
+
+var _ secondary.WorkerRegistry = (*WorkerRegistry)(nil)
+
+// NewWorkerRegistry creates a reader of the worker registry.
+func NewWorkerRegistry(client redis.UniversalClient) *WorkerRegistry {
+	return &WorkerRegistry{client: client}
+}
+
+// Workers returns every registered instance, ordered by ID.
+func (r *WorkerRegistry) Workers(ctx context.Context) ([]entity.WorkerInstance, error) {
+	beats, err := r.client.HGetAll(ctx, domain.RedisWorkersKey).Result()
+	if err != nil {
+		return nil, fmt.Errorf("reading worker registry from redis: %w", err)
+	}
+
+	cmds := make(map[string]*redis.MapStringStringCmd, len(beats))
+	if _, err := r.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for instance := range beats {
+			cmds[instance] = pipe.HGetAll(ctx, domain.RedisWorkerStatsKeyPrefix+instance)
+		}
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("reading worker stats from redis: %w", err)
+	}
+
+	stats := make(map[string]map[string]string, len(cmds))
+	for instance, cmd := range cmds {
+		stats[instance] = cmd.Val()
+	}
+	return workerInstances(beats, stats), nil
+}
+
+// workerInstances combines registry entries with the instances' stats
+// hashes. Entries with an unparseable heartbeat are skipped, as
+// WorkersCheck forgets them; unparseable counters read as zero.
+func workerInstances(beats map[string]string, stats map[string]map[string]string) []entity.WorkerInstance {
+	instances := make([]entity.WorkerInstance, 0, len(beats))
+	for instance, raw := range beats {
+		beat, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			continue
+		}
+		w := entity.WorkerInstance{ID: instance, LastBeat: time.Unix(beat, 0)}
+
+		fields := stats[instance]
+		if started, err := strconv.ParseInt(fields[workerStatsStarted], 10, 64); err == nil {
+			w.StartedAt = time.Unix(started, 0)
+		}
+		w.Processed, _ = strconv.ParseInt(fields[workerStatsProcessed], 10, 64)
+		w.Errors, _ = strconv.ParseInt(fields[workerStatsErrors], 10, 64)
+		w.Shards = parseShards(fields[workerStatsShards])
+		instances = append(instances, w)
+	}
+	sort.Slice(instances, func(i, j int) bool { return instances[i].ID < instances[j].ID })
+	return instances
+}
@@ -0,0 +1,58 @@
+package redisstore
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestStaleWorkers(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	ago := func(d time.Duration) string {
+		return strconv.FormatInt(now.Add(-d).Unix(), 10)
+	}
+
+	beats := map[string]string{
+		"pod-a": ago(2 * time.Second),
+		"pod-b": ago(90 * time.Second),
+		"pod-c": ago(2 * time.Hour),
+		"pod-d": "garbage",
+	}
+
+	stale, forgotten := staleWorkers(beats, now, 15*time.Second, time.Hour)
+
+	if want := []string{"pod-b (last beat 1m30s ago)"}; !reflect.DeepEqual(stale, want) {
+		t.Fatalf("stale = %v, want %v", stale, want)
+	}
+	if want := []string{"pod-c", "pod-d"}; !reflect.DeepEqual(forgotten, want) {
+		t.Fatalf("forgotten = %v, want %v", forgotten, want)
+	}
+}
+
+func TestWorkerInstances(t *testing.T) {
+	beats := map[string]string{
+		"pod-b": "1700000000",
+		"pod-a": "1700000010",
+		"pod-c": "garbage",
+	}
+	stats := map[string]map[string]string{
+		"pod-a": {"started": "1699990000", "processed": "120", "errors": "4", "shards": "1,5"},
+	}
+
+	instances := workerInstances(beats, stats)
+
+	if len(instances) != 2 {
+		t.Fatalf("expected 2 instances, got %+v", instances)
+	}
+	a, b := instances[0], instances[1]
+	if a.ID != "pod-a" || !a.LastBeat.Equal(time.Unix(1_700_000_010, 0)) || !a.StartedAt.Equal(time.Unix(1_699_990_000, 0)) {
+		t.Fatalf("unexpected pod-a: %+v", a)
+	}
+	if a.Processed != 120 || a.Errors != 4 || !reflect.DeepEqual(a.Shards, []int{1, 5}) {
+		t.Fatalf("unexpected pod-a counters: %+v", a)
+	}
+	if b.ID != "pod-b" || !b.StartedAt.IsZero() || b.Processed != 0 || b.Shards != nil {
+		t.Fatalf("expected pod-b without stats, got %+v", b)
+	}
+}
@@ -0,0 +1,153 @@
+package redisstore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+	"github.com/ruudy-sib/rebound/internal/port/secondary"
+)
+
+// InFlightStore implements secondary.InFlightStore with a sorted set of
+// task IDs scored by their deadline, like the schedule, and a hash of their
+// payloads.
+type InFlightStore struct {
+	client  redis.UniversalClient
+	key     string
+	dataKey string
+	logger  *zap.Logger
+}
+
+// NewInFlightStore creates a Redis-backed record of in-flight tasks.
+func NewInFlightStore(client redis.UniversalClient, logger *zap.Logger) secondary.InFlightStore {
+	return &InFlightStore{
+		client:  client,
+		key:     domain.RedisInFlightKey,
+		dataKey: domain.RedisInFlightDataKey,
+		logger:  logger.Named("redis-in-flight"),
+	}
+}
+
+// Track stores the payloads before indexing them, so a reclaimer never
+// finds an ID without its task.
+func (f *InFlightStore) Track(ctx context.Context, tasks []*entity.Task, deadline time.Time) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	data := make([]any, 0, 2*len(tasks))
+	members := make([]redis.Z, 0, len(tasks))
+	for _, task := range tasks {
+		payload, err := json.Marshal(toDTO(task))
+		if err != nil {
+			return fmt.Errorf("marshaling in-flight task: %w", err)
+		}
+		data = append(data, task.ID, payload)
+		members = append(members, redis.Z{Score: scoreOf(deadline), Member: task.ID})
+	}
+
+	if err := f.client.HSet(ctx, f.dataKey, data...).Err(); err != nil {
+		return fmt.Errorf("storing in-flight tasks in redis: %w", err)
+	}
+	if err := f.client.ZAdd(ctx, f.key, members...).Err(); err != nil {
+		return fmt.Errorf("tracking in-flight tasks in redis: %w", err)
+	}
+	return nil
+}
+
+// Extend rescores the ID with ZADD XX GT, so it neither re-adds a reclaimed
+// task nor shortens a deadline.
+func (f *InFlightStore) Extend(ctx context.Context, taskID string, deadline time.Time) error {
+	changed, err := f.client.ZAddArgs(ctx, f.key, redis.ZAddArgs{
+		XX:      true,
+		GT:      true,
+		Ch:      true,
+		Members: []redis.Z{{Score: scoreOf(deadline), Member: taskID}},
+	}).Result()
+	if err != nil {
+		return fmt.Errorf("extending in-flight task in redis: %w", err)
+	}
+	if changed > 0 {
+		return nil
+	}
+
+	// Unchanged: either the deadline was already later or the task is gone.
+	err = f.client.ZScore(ctx, f.key, taskID).Err()
+	if err == redis.Nil {
+		return domain.ErrTaskNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("reading in-flight task from redis: %w", err)
+	}
+	return nil
+}
+
+// Release unindexes the IDs before deleting their payloads, the reverse of
+// Track.
+func (f *InFlightStore) Release(ctx context.Context, taskIDs []string) error {
+	if len(taskIDs) == 0 {
+		return nil
+	}
+
+	ids := make([]any, len(taskIDs))
+	for i, id := range taskIDs {
+		ids[i] = id
+	}
+	if err := f.client.ZRem(ctx, f.key, ids...).Err(); err != nil {
+		return fmt.Errorf("releasing in-flight tasks in redis: %w", err)
+	}
+	if err := f.client.HDel(ctx, f.dataKey, taskIDs...).Err(); err != nil {
+		return fmt.Errorf("deleting in-flight tasks from redis: %w", err)
+	}
+	return nil
+}
+
+// Reclaim claims each overdue ID with ZREM, which only one caller can win,
+// and then takes its payload. An ID whose payload is already gone was
+// released concurrently and is skipped.
+func (f *InFlightStore) Reclaim(ctx context.Context, now time.Time, limit int) ([]*entity.Task, error) {
+	ids, err := f.client.ZRangeByScore(ctx, f.key, &redis.ZRangeBy{
+		Min:   "-inf",
+		Max:   scoreArg(now),
+		Count: int64(limit),
+	}).Result()
+	if err != nil {
+		return nil, fmt.Errorf("fetching overdue in-flight tasks from redis: %w", err)
+	}
+
+	var tasks []*entity.Task
+	for _, id := range ids {
+		removed, err := f.client.ZRem(ctx, f.key, id).Result()
+		if err != nil {
+			return tasks, fmt.Errorf("claiming in-flight task in redis: %w", err)
+		}
+		if removed == 0 {
+			continue
+		}
+
+		raw, err := f.client.HGet(ctx, f.dataKey, id).Result()
+		if err == redis.Nil {
+			continue
+		}
+		if err != nil {
+			return tasks, fmt.Errorf("reading in-flight task from redis: %w", err)
+		}
+		if err := f.client.HDel(ctx, f.dataKey, id).Err(); err != nil {
+			f.logger.Warn("failed to delete reclaimed task payload", zap.Error(err), zap.String("task_id", id))
+		}
+
+		var dto taskDTO
+		if err := json.Unmarshal([]byte(raw), &dto); err != nil {
+			f.logger.Error("dropping undecodable in-flight task", zap.Error(err), zap.String("task_id", id))
+			continue
+		}
+		tasks = append(tasks, toEntity(dto))
+	}
+	return tasks, nil
+}
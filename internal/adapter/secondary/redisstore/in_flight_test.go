@@ -0,0 +1,34 @@
+package redisstore
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+func TestInFlightStore_Reclaim_subsecond(t *testing.T) {
+	ctx := context.Background()
+	_, client := newTestRedis(t)
+	store := NewInFlightStore(client, zap.NewNop())
+
+	second := time.Date(2030, 1, 1, 12, 0, 0, 0, time.UTC)
+	task := &entity.Task{ID: "task-1", Source: "test"}
+	if err := store.Track(ctx, []*entity.Task{task}, second.Add(500*time.Millisecond)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Not reclaimed early within the second of its deadline.
+	tasks, err := store.Reclaim(ctx, second.Add(100*time.Millisecond), 10)
+	if err != nil || len(tasks) != 0 {
+		t.Fatalf("expected nothing reclaimed before the deadline, got %d (%v)", len(tasks), err)
+	}
+
+	tasks, err = store.Reclaim(ctx, second.Add(600*time.Millisecond), 10)
+	if err != nil || len(tasks) != 1 || tasks[0].ID != task.ID {
+		t.Fatalf("expected the task reclaimed after the deadline, got %+v (%v)", tasks, err)
+	}
+}
@@ -0,0 +1,393 @@
+package redisstore
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+	"github.com/ruudy-sib/rebound/internal/port/secondary"
+)
+
+// reconcileBatchSize bounds the entries checked per Redis round trip during
+// a janitor pass.
+const reconcileBatchSize = 500
+
+// Reconciler implements secondary.StateReconciler. Each repair reads
+// entries in batches and checks them against the data they describe with
+// one pipeline per batch; keys may live on different cluster slots, so no
+// repair is transactional. Entries written between the read and the check
+// can be misjudged, but every repair is idempotent and the next pass
+// corrects it.
+type Reconciler struct {
+	client    redis.UniversalClient
+	retention Retention
+	logger    *zap.Logger
+}
+
+// Retention bounds how long terminal task records are kept. Records older
+// than their retention are deleted by the janitor; a zero value keeps them
+// until they are taken. Delivered tasks are only remembered through their
+// completion markers, which expire on their own TTL.
+type Retention struct {
+	DeadLetters time.Duration
+	Quarantined time.Duration
+}
+
+// NewReconciler creates a Redis-backed state reconciler that also enforces
+// retention on terminal task records.
+func NewReconciler(client redis.UniversalClient, retention Retention, logger *zap.Logger) secondary.StateReconciler {
+	return &Reconciler{
+		client:    client,
+		retention: retention,
+		logger:    logger.Named("redis-reconciler"),
+	}
+}
+
+// Claim takes the janitor lock with SET NX, letting it expire after lease.
+func (r *Reconciler) Claim(ctx context.Context, lease time.Duration) (bool, error) {
+	ok, err := r.client.SetNX(ctx, domain.RedisJanitorLockKey, time.Now().Unix(), lease).Result()
+	if err != nil {
+		return false, fmt.Errorf("taking janitor lock in redis: %w", err)
+	}
+	return ok, nil
+}
+
+// Reconcile runs every repair, continuing past failures so one broken key
+// does not block the others.
+func (r *Reconciler) Reconcile(ctx context.Context) (entity.RepairReport, error) {
+	var report entity.RepairReport
+	var errs []error
+	for _, repair := range []func(context.Context, *entity.RepairReport) error{
+		r.pruneDestinationIndexes,
+		r.indexSchedule,
+		r.reconcileDeadLetters,
+		r.pruneSLAGroups,
+		r.forgetWorkers,
+		r.expireDeadLetters,
+		r.expireQuarantined,
+	} {
+		if err := repair(ctx, &report); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return report, errors.Join(errs...)
+}
+
+// pruneDestinationIndexes drops index entries whose member has left the
+// schedule.
+func (r *Reconciler) pruneDestinationIndexes(ctx context.Context, report *entity.RepairReport) error {
+	var removed atomic.Int64
+	err := scanKeys(ctx, r.client, domain.RedisDestinationIndexKeyPrefix+"*", func(node redis.UniversalClient, key string) error {
+		return scanBatches(ctx, node.ZScan(ctx, key, 0, "", reconcileBatchSize).Iterator(), func(members, _ []string) error {
+			scores := make([]*redis.FloatCmd, len(members))
+			if _, err := r.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+				for i, member := range members {
+					scores[i] = pipe.ZScore(ctx, domain.RedisRetryKey, member)
+				}
+				return nil
+			}); err != nil && !errors.Is(err, redis.Nil) {
+				return err
+			}
+
+			var stale []interface{}
+			for i, cmd := range scores {
+				if errors.Is(cmd.Err(), redis.Nil) {
+					stale = append(stale, members[i])
+				}
+			}
+			if len(stale) == 0 {
+				return nil
+			}
+			n, err := node.ZRem(ctx, key, stale...).Result()
+			removed.Add(n)
+			return err
+		})
+	})
+	report.IndexEntriesRemoved = int(removed.Load())
+	if err != nil {
+		return fmt.Errorf("pruning destination indexes in redis: %w", err)
+	}
+	return nil
+}
+
+// indexSchedule adds scheduled members missing from their destination
+// index, such as tasks scheduled before the index existed.
+func (r *Reconciler) indexSchedule(ctx context.Context, report *entity.RepairReport) error {
+	iter := r.client.ZScan(ctx, domain.RedisRetryKey, 0, "", reconcileBatchSize).Iterator()
+	err := scanBatches(ctx, iter, func(members, scores []string) error {
+		var adds []*redis.IntCmd
+		if _, err := r.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+			for i, member := range members {
+				dto, err := decodeTask([]byte(member))
+				if err != nil {
+					continue
+				}
+				score, err := strconv.ParseFloat(scores[i], 64)
+				if err != nil {
+					continue
+				}
+				key := destinationIndexKey(toEntity(dto).Destination.Name())
+				adds = append(adds, pipe.ZAddNX(ctx, key, redis.Z{Score: score, Member: member}))
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+		for _, cmd := range adds {
+			report.IndexEntriesAdded += int(cmd.Val())
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("indexing scheduled tasks in redis: %w", err)
+	}
+	return nil
+}
+
+// reconcileDeadLetters drops index entries without a payload and indexes
+// payloads missing from the index at the time they were stored.
+func (r *Reconciler) reconcileDeadLetters(ctx context.Context, report *entity.RepairReport) error {
+	iter := r.client.ZScan(ctx, domain.RedisDeadLetterKey, 0, "", reconcileBatchSize).Iterator()
+	err := scanBatches(ctx, iter, func(ids, _ []string) error {
+		exists := make([]*redis.BoolCmd, len(ids))
+		if _, err := r.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+			for i, id := range ids {
+				exists[i] = pipe.HExists(ctx, domain.RedisDeadLetterDataKey, id)
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		var orphaned []interface{}
+		for i, cmd := range exists {
+			if !cmd.Val() {
+				orphaned = append(orphaned, ids[i])
+			}
+		}
+		if len(orphaned) == 0 {
+			return nil
+		}
+		n, err := r.client.ZRem(ctx, domain.RedisDeadLetterKey, orphaned...).Result()
+		report.DeadLettersRemoved += int(n)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("pruning dead-letter index in redis: %w", err)
+	}
+
+	iter = r.client.HScan(ctx, domain.RedisDeadLetterDataKey, 0, "", reconcileBatchSize).Iterator()
+	err = scanBatches(ctx, iter, func(ids, payloads []string) error {
+		scores := make([]*redis.FloatCmd, len(ids))
+		if _, err := r.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+			for i, id := range ids {
+				scores[i] = pipe.ZScore(ctx, domain.RedisDeadLetterKey, id)
+			}
+			return nil
+		}); err != nil && !errors.Is(err, redis.Nil) {
+			return err
+		}
+
+		var missing []redis.Z
+		for i, cmd := range scores {
+			if !errors.Is(cmd.Err(), redis.Nil) {
+				continue
+			}
+			storedAt := time.Now()
+			if entry, err := decodeDeadLetter(payloads[i]); err == nil && !entry.StoredAt.IsZero() {
+				storedAt = entry.StoredAt
+			}
+			missing = append(missing, redis.Z{Score: float64(storedAt.Unix()), Member: ids[i]})
+		}
+		if len(missing) == 0 {
+			return nil
+		}
+		n, err := r.client.ZAddNX(ctx, domain.RedisDeadLetterKey, missing...).Result()
+		report.DeadLettersIndexed += int(n)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("indexing dead-letter payloads in redis: %w", err)
+	}
+	return nil
+}
+
+// pruneSLAGroups drops SLA group entries whose sample list no longer
+// exists.
+func (r *Reconciler) pruneSLAGroups(ctx context.Context, report *entity.RepairReport) error {
+	ids, err := r.client.HKeys(ctx, domain.RedisSLAGroupsKey).Result()
+	if err != nil {
+		return fmt.Errorf("listing sla groups from redis: %w", err)
+	}
+
+	for start := 0; start < len(ids); start += reconcileBatchSize {
+		batch := ids[start:min(start+reconcileBatchSize, len(ids))]
+		exists := make([]*redis.IntCmd, len(batch))
+		if _, err := r.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+			for i, id := range batch {
+				exists[i] = pipe.Exists(ctx, domain.RedisSLASamplesKeyPrefix+id)
+			}
+			return nil
+		}); err != nil {
+			return fmt.Errorf("checking sla sample lists in redis: %w", err)
+		}
+
+		var orphaned []string
+		for i, cmd := range exists {
+			if cmd.Val() == 0 {
+				orphaned = append(orphaned, batch[i])
+			}
+		}
+		if len(orphaned) == 0 {
+			continue
+		}
+		n, err := r.client.HDel(ctx, domain.RedisSLAGroupsKey, orphaned...).Result()
+		report.SLAGroupsRemoved += int(n)
+		if err != nil {
+			return fmt.Errorf("pruning sla groups in redis: %w", err)
+		}
+	}
+	return nil
+}
+
+// forgetWorkers drops instances silent for longer than
+// domain.WorkerForgetAfter, which WorkersCheck otherwise only does when
+// /health is polled.
+func (r *Reconciler) forgetWorkers(ctx context.Context, report *entity.RepairReport) error {
+	beats, err := r.client.HGetAll(ctx, domain.RedisWorkersKey).Result()
+	if err != nil {
+		return fmt.Errorf("reading worker registry from redis: %w", err)
+	}
+
+	_, forgotten := staleWorkers(beats, time.Now(), domain.WorkerForgetAfter, domain.WorkerForgetAfter)
+	if len(forgotten) == 0 {
+		return nil
+	}
+	n, err := r.client.HDel(ctx, domain.RedisWorkersKey, forgotten...).Result()
+	report.WorkersForgotten = int(n)
+	if err != nil {
+		return fmt.Errorf("pruning worker registry in redis: %w", err)
+	}
+	return nil
+}
+
+// expireDeadLetters deletes dead letters stored longer ago than their
+// retention. The payload goes first, so an interrupted pass leaves an index
+// entry for reconcileDeadLetters to prune rather than an unindexed payload.
+func (r *Reconciler) expireDeadLetters(ctx context.Context, report *entity.RepairReport) error {
+	if r.retention.DeadLetters <= 0 {
+		return nil
+	}
+
+	cutoff := strconv.FormatInt(time.Now().Add(-r.retention.DeadLetters).Unix(), 10)
+	for {
+		ids, err := r.client.ZRangeByScore(ctx, domain.RedisDeadLetterKey, &redis.ZRangeBy{
+			Min:   "-inf",
+			Max:   "(" + cutoff,
+			Count: reconcileBatchSize,
+		}).Result()
+		if err != nil {
+			return fmt.Errorf("listing expired dead letters in redis: %w", err)
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+
+		if err := r.client.HDel(ctx, domain.RedisDeadLetterDataKey, ids...).Err(); err != nil {
+			return fmt.Errorf("deleting expired dead letters in redis: %w", err)
+		}
+		members := make([]interface{}, len(ids))
+		for i, id := range ids {
+			members[i] = id
+		}
+		n, err := r.client.ZRem(ctx, domain.RedisDeadLetterKey, members...).Result()
+		report.DeadLettersExpired += int(n)
+		if err != nil {
+			return fmt.Errorf("unindexing expired dead letters in redis: %w", err)
+		}
+	}
+}
+
+// expireQuarantined deletes tasks quarantined longer ago than their
+// retention. The quarantine hash has no time index, so every entry is
+// decoded; entries that cannot be decoded are kept for inspection.
+func (r *Reconciler) expireQuarantined(ctx context.Context, report *entity.RepairReport) error {
+	if r.retention.Quarantined <= 0 {
+		return nil
+	}
+
+	cutoff := time.Now().Add(-r.retention.Quarantined)
+	iter := r.client.HScan(ctx, domain.RedisQuarantineKey, 0, "", reconcileBatchSize).Iterator()
+	err := scanBatches(ctx, iter, func(ids, payloads []string) error {
+		expired := expiredQuarantine(ids, payloads, cutoff)
+		if len(expired) == 0 {
+			return nil
+		}
+		n, err := r.client.HDel(ctx, domain.RedisQuarantineKey, expired...).Result()
+		report.QuarantinedExpired += int(n)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("expiring quarantined tasks in redis: %w", err)
+	}
+	return nil
+}
+
+// expiredQuarantine returns the IDs of the quarantine payloads recorded
+// before cutoff.
+func expiredQuarantine(ids, payloads []string, cutoff time.Time) []string {
+	var expired []string
+	for i, raw := range payloads {
+		var dto quarantineDTO
+		if err := json.Unmarshal([]byte(raw), &dto); err != nil || dto.QuarantinedAt.IsZero() {
+			continue
+		}
+		if dto.QuarantinedAt.Before(cutoff) {
+			expired = append(expired, ids[i])
+		}
+	}
+	return expired
+}
+
+// scanBatches collects the alternating key/value pairs yielded by a ZSCAN
+// or HSCAN iterator, member and score or field and value, and passes them
+// to fn up to reconcileBatchSize at a time.
+func scanBatches(ctx context.Context, iter *redis.ScanIterator, fn func(keys, values []string) error) error {
+	keys := make([]string, 0, reconcileBatchSize)
+	values := make([]string, 0, reconcileBatchSize)
+	flush := func() error {
+		if len(keys) == 0 {
+			return nil
+		}
+		err := fn(keys, values)
+		keys, values = keys[:0], values[:0]
+		return err
+	}
+
+	for iter.Next(ctx) {
+		key := iter.Val()
+		if !iter.Next(ctx) {
+			break
+		}
+		keys = append(keys, key)
+		values = append(values, iter.Val())
+		if len(keys) == reconcileBatchSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return err
+	}
+	return flush()
+}
@@ -0,0 +1,31 @@
+package redisstore
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestExpiredQuarantine(t *testing.T) {
+	cutoff := time.Unix(1_700_000_000, 0).UTC()
+	payload := func(at time.Time) string {
+		data, err := json.Marshal(quarantineDTO{Reason: "poison", QuarantinedAt: at})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(data)
+	}
+
+	ids := []string{"old", "new", "garbage", "undated"}
+	payloads := []string{
+		payload(cutoff.Add(-time.Hour)),
+		payload(cutoff.Add(time.Hour)),
+		"{not json",
+		payload(time.Time{}),
+	}
+
+	if got, want := expiredQuarantine(ids, payloads, cutoff), []string{"old"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("expired = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,219 @@
+package redisstore
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+// legacyTaskDTO is the task format written by the original top-level
+// main.go: Go-style field names ("ClientId") and destinations encoded as
+// plain strings, either an HTTP URL or "host:port/topic". JSON key matching
+// is case-insensitive, so "Id" also matches "id" and "ID".
+type legacyTaskDTO struct {
+	ID              string          `json:"Id"`
+	Attempt         int             `json:"Attempt"`
+	Source          string          `json:"Source"`
+	Destination     json.RawMessage `json:"Destination"`
+	DeadDestination json.RawMessage `json:"DeadDestination"`
+	MaxRetries      int             `json:"MaxRetries"`
+	BaseDelay       int             `json:"BaseDelay"`
+	ClientID        string          `json:"ClientId"`
+	IsPriority      bool            `json:"IsPriority"`
+	MessageData     string          `json:"MessageData"`
+	DestinationType string          `json:"DestinationType"`
+}
+
+// MigrationReport summarizes a legacy migration run.
+type MigrationReport struct {
+	Scanned  int // members inspected
+	Current  int // already in the current schema
+	Migrated int // rewritten (or, in a dry run, that would be)
+	Failed   int // unreadable in either schema, left untouched
+}
+
+// replaceMemberScript swaps ARGV[1] for ARGV[2] at the same score, but only
+// if ARGV[1] is still scheduled, so a member the worker already fetched is
+// not resurrected. KEYS[1] = schedule.
+var replaceMemberScript = redis.NewScript(`
+local score = redis.call('ZSCORE', KEYS[1], ARGV[1])
+if not score then
+	return 0
+end
+redis.call('ZREM', KEYS[1], ARGV[1])
+redis.call('ZADD', KEYS[1], score, ARGV[2])
+return 1
+`)
+
+// LegacyMigrator rewrites legacy entries in the schedule into the current
+// task schema. Workers should be stopped while it runs: they cannot decode
+// legacy entries and drop them.
+type LegacyMigrator struct {
+	client redis.UniversalClient
+	key    string
+	logger *zap.Logger
+}
+
+// NewLegacyMigrator creates a migrator for the schedule sorted set.
+func NewLegacyMigrator(client redis.UniversalClient, logger *zap.Logger) *LegacyMigrator {
+	return &LegacyMigrator{
+		client: client,
+		key:    domain.RedisRetryKey,
+		logger: logger.Named("legacy-migrator"),
+	}
+}
+
+// Migrate scans the schedule and rewrites every legacy member in place,
+// keeping its score. With dryRun set, nothing is written.
+func (m *LegacyMigrator) Migrate(ctx context.Context, dryRun bool) (MigrationReport, error) {
+	var report MigrationReport
+
+	iter := m.client.ZScan(ctx, m.key, 0, "", 100).Iterator()
+	for iter.Next(ctx) {
+		member := iter.Val()
+		if !iter.Next(ctx) { // skip the score
+			break
+		}
+		report.Scanned++
+
+		dto, legacy, err := decodeLegacyTask([]byte(member))
+		if err != nil {
+			report.Failed++
+			m.logger.Warn("unreadable task in schedule", zap.Error(err), zap.String("raw", member))
+			continue
+		}
+		if !legacy {
+			report.Current++
+			continue
+		}
+
+		if dryRun {
+			report.Migrated++
+			continue
+		}
+
+		data, err := json.Marshal(dto)
+		if err != nil {
+			report.Failed++
+			m.logger.Warn("failed to encode migrated task", zap.Error(err), zap.String("task_id", dto.ID))
+			continue
+		}
+
+		replaced, err := replaceMemberScript.Run(ctx, m.client, []string{m.key}, member, data).Int()
+		if err != nil {
+			return report, fmt.Errorf("rewriting task %s in redis: %w", dto.ID, err)
+		}
+		if replaced == 1 {
+			report.Migrated++
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return report, fmt.Errorf("scanning schedule in redis: %w", err)
+	}
+
+	m.logger.Info("legacy migration finished",
+		zap.Bool("dry_run", dryRun),
+		zap.Int("scanned", report.Scanned),
+		zap.Int("current", report.Current),
+		zap.Int("migrated", report.Migrated),
+		zap.Int("failed", report.Failed),
+	)
+	return report, nil
+}
+
+// decodeLegacyTask reports whether raw is a legacy entry and, if so,
+// returns its current-schema equivalent.
+func decodeLegacyTask(raw []byte) (taskDTO, bool, error) {
+	if len(raw) > 0 && raw[0] == protobufTaskVersion {
+		// The legacy service only wrote JSON.
+		return taskDTO{}, false, nil
+	}
+
+	var legacy legacyTaskDTO
+	if err := json.Unmarshal(raw, &legacy); err != nil {
+		return taskDTO{}, false, fmt.Errorf("decoding task: %w", err)
+	}
+
+	stringDest := isJSONString(legacy.Destination)
+	if !stringDest && legacy.ClientID == "" {
+		// Object destination and snake_case client_id: current schema.
+		return taskDTO{}, false, nil
+	}
+
+	dest, err := legacyDestination(legacy.Destination)
+	if err != nil {
+		return taskDTO{}, false, fmt.Errorf("decoding destination: %w", err)
+	}
+	dead, err := legacyDestination(legacy.DeadDestination)
+	if err != nil {
+		return taskDTO{}, false, fmt.Errorf("decoding dead destination: %w", err)
+	}
+
+	destType := legacy.DestinationType
+	if destType == "" {
+		destType = string(entity.Destination{Topic: dest.Topic, URL: dest.URL}.Type())
+	}
+
+	return taskDTO{
+		SchemaVersion:   currentSchemaVersion(),
+		ID:              legacy.ID,
+		Attempt:         legacy.Attempt,
+		Source:          legacy.Source,
+		Destination:     dest,
+		DeadDestination: dead,
+		MaxRetries:      legacy.MaxRetries,
+		BaseDelay:       legacy.BaseDelay,
+		ClientID:        legacy.ClientID,
+		IsPriority:      legacy.IsPriority,
+		MessageData:     legacy.MessageData,
+		DestinationType: destType,
+	}, true, nil
+}
+
+// legacyDestination accepts either the legacy string form or an object in
+// the current form.
+func legacyDestination(raw json.RawMessage) (destDTO, error) {
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+		return destDTO{}, nil
+	}
+	if !isJSONString(raw) {
+		var dest destDTO
+		err := json.Unmarshal(raw, &dest)
+		return dest, err
+	}
+
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return destDTO{}, err
+	}
+	return parseLegacyDestination(s), nil
+}
+
+// parseLegacyDestination maps "https://host/path" to an HTTP destination and
+// "host:port/topic" (or a bare topic) to a Kafka destination.
+func parseLegacyDestination(s string) destDTO {
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, "http://") || strings.HasPrefix(s, "https://") {
+		return destDTO{URL: s}
+	}
+
+	addr, topic, ok := strings.Cut(s, "/")
+	if !ok {
+		return destDTO{Topic: s}
+	}
+	host, port, _ := strings.Cut(addr, ":")
+	return destDTO{Host: host, Port: port, Topic: topic}
+}
+
+func isJSONString(raw json.RawMessage) bool {
+	trimmed := bytes.TrimSpace(raw)
+	return len(trimmed) > 0 && trimmed[0] == '"'
+}
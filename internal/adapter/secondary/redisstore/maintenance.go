@@ -0,0 +1,108 @@
+package redisstore
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+	"github.com/ruudy-sib/rebound/internal/port/secondary"
+)
+
+// MaintenanceStore implements secondary.MaintenanceStore using a Redis hash
+// mapping destination names to the RFC 3339 end time of their window.
+// Expired entries are removed lazily when they are read.
+type MaintenanceStore struct {
+	client redis.UniversalClient
+	key    string
+	logger *zap.Logger
+}
+
+// NewMaintenanceStore creates a Redis-backed maintenance window store.
+func NewMaintenanceStore(client redis.UniversalClient, logger *zap.Logger) secondary.MaintenanceStore {
+	return &MaintenanceStore{
+		client: client,
+		key:    domain.RedisMaintenanceKey,
+		logger: logger.Named("redis-maintenance"),
+	}
+}
+
+// Set stores the window, replacing any existing one for the destination.
+func (m *MaintenanceStore) Set(ctx context.Context, window entity.MaintenanceWindow) error {
+	until := window.Until.UTC().Format(time.RFC3339Nano)
+	if err := m.client.HSet(ctx, m.key, window.Destination, until).Err(); err != nil {
+		return fmt.Errorf("setting maintenance window in redis: %w", err)
+	}
+	return nil
+}
+
+// Clear removes the window for destination.
+func (m *MaintenanceStore) Clear(ctx context.Context, destination string) error {
+	if err := m.client.HDel(ctx, m.key, destination).Err(); err != nil {
+		return fmt.Errorf("clearing maintenance window in redis: %w", err)
+	}
+	return nil
+}
+
+// Get returns the active window for destination, or nil.
+func (m *MaintenanceStore) Get(ctx context.Context, destination string) (*entity.MaintenanceWindow, error) {
+	raw, err := m.client.HGet(ctx, m.key, destination).Result()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading maintenance window from redis: %w", err)
+	}
+
+	window, ok := m.parse(ctx, destination, raw, time.Now())
+	if !ok {
+		return nil, nil
+	}
+	return &window, nil
+}
+
+// List returns all active windows.
+func (m *MaintenanceStore) List(ctx context.Context) ([]entity.MaintenanceWindow, error) {
+	entries, err := m.client.HGetAll(ctx, m.key).Result()
+	if err != nil {
+		return nil, fmt.Errorf("listing maintenance windows from redis: %w", err)
+	}
+
+	now := time.Now()
+	windows := make([]entity.MaintenanceWindow, 0, len(entries))
+	for destination, raw := range entries {
+		if window, ok := m.parse(ctx, destination, raw, now); ok {
+			windows = append(windows, window)
+		}
+	}
+	return windows, nil
+}
+
+// parse decodes a stored window, deleting it when it is expired or unreadable.
+func (m *MaintenanceStore) parse(ctx context.Context, destination, raw string, now time.Time) (entity.MaintenanceWindow, bool) {
+	until, err := time.Parse(time.RFC3339Nano, raw)
+	if err != nil {
+		m.logger.Warn("invalid maintenance window in redis",
+			zap.Error(err),
+			zap.String("destination", destination),
+			zap.String("raw", raw),
+		)
+	}
+
+	window := entity.MaintenanceWindow{Destination: destination, Until: until}
+	if err == nil && window.Active(now) {
+		return window, true
+	}
+
+	if err := m.client.HDel(ctx, m.key, destination).Err(); err != nil {
+		m.logger.Warn("failed to remove expired maintenance window",
+			zap.Error(err),
+			zap.String("destination", destination),
+		)
+	}
+	return entity.MaintenanceWindow{}, false
+}
@@ -0,0 +1,180 @@
+package redisstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+)
+
+// CopyReport counts what a Migrator copy did.
+type CopyReport struct {
+	Copied int // scheduled tasks written to the target
+	Gone   int // copied tasks removed again because the source no longer had them
+}
+
+// Migrator copies the schedule of a source Redis to a target one, e.g. to
+// move from standalone to cluster without downtime. It only backfills what
+// is already scheduled: run it alongside its Replicator so writes made
+// during and after the copy reach the target too. Once the copy is complete
+// and the replication status shows the target caught up, instances can be
+// pointed at the target.
+type Migrator struct {
+	source redis.UniversalClient
+	target redis.UniversalClient
+	key    string
+	logger *zap.Logger
+}
+
+// NewMigrator creates a migrator from source to target.
+func NewMigrator(source, target redis.UniversalClient, logger *zap.Logger) *Migrator {
+	return &Migrator{
+		source: source,
+		target: target,
+		key:    domain.RedisRetryKey,
+		logger: logger.Named("redis-migrator"),
+	}
+}
+
+// Replicator returns a replicator that dual-writes schedule changes to the
+// target. Closing it closes the target client.
+func (m *Migrator) Replicator() *Replicator {
+	return NewReplicator(m.source, m.target, m.logger)
+}
+
+// Run copies the schedule and logs the outcome. It is meant to be started
+// once per migration in the background.
+func (m *Migrator) Run(ctx context.Context) {
+	m.logger.Info("migration copy started")
+	report, err := m.Copy(ctx)
+	if err != nil {
+		m.logger.Error("migration copy failed",
+			zap.Int("copied", report.Copied),
+			zap.Error(err),
+		)
+		return
+	}
+	m.logger.Info("migration copy complete",
+		zap.Int("copied", report.Copied),
+		zap.Int("gone", report.Gone),
+	)
+}
+
+// Copy walks the source schedule page by page and adds every member, with
+// its score and destination index entry, to the target. Members a worker
+// fetched from the source while their page was being copied would be
+// resurrected on the target, so each page is checked against the source
+// afterwards and such members are removed again.
+func (m *Migrator) Copy(ctx context.Context) (CopyReport, error) {
+	var report CopyReport
+	var cursor uint64
+	for {
+		page, next, err := m.source.ZScan(ctx, m.key, cursor, "", domain.MigrationPageSize).Result()
+		if err != nil {
+			return report, fmt.Errorf("scanning source schedule: %w", err)
+		}
+
+		members := m.parsePage(page)
+		if len(members) > 0 {
+			if err := m.write(ctx, members); err != nil {
+				return report, err
+			}
+			gone, err := m.dropGone(ctx, members)
+			if err != nil {
+				return report, err
+			}
+			report.Copied += len(members) - gone
+			report.Gone += gone
+		}
+
+		cursor = next
+		if cursor == 0 {
+			return report, nil
+		}
+	}
+}
+
+// migratedMember is one schedule member with its destination index key.
+type migratedMember struct {
+	z        redis.Z
+	indexKey string
+}
+
+// parsePage pairs the member and score entries ZSCAN returns. Members that
+// cannot be decoded are copied without an index entry.
+func (m *Migrator) parsePage(page []string) []migratedMember {
+	members := make([]migratedMember, 0, len(page)/2)
+	for i := 0; i+1 < len(page); i += 2 {
+		score, err := strconv.ParseFloat(page[i+1], 64)
+		if err != nil {
+			continue
+		}
+		mm := migratedMember{z: redis.Z{Score: score, Member: page[i]}}
+		if dto, err := decodeTask([]byte(page[i])); err == nil {
+			mm.indexKey = destinationIndexKey(toEntity(dto).Destination.Name())
+		}
+		members = append(members, mm)
+	}
+	return members
+}
+
+// write adds the members to the target schedule and indexes.
+func (m *Migrator) write(ctx context.Context, members []migratedMember) error {
+	_, err := m.target.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, mm := range members {
+			pipe.ZAdd(ctx, m.key, mm.z)
+			if mm.indexKey != "" {
+				pipe.ZAdd(ctx, mm.indexKey, mm.z)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("writing schedule page to target: %w", err)
+	}
+	return nil
+}
+
+// dropGone removes from the target the members the source no longer has
+// and reports how many there were.
+func (m *Migrator) dropGone(ctx context.Context, members []migratedMember) (int, error) {
+	scores := make([]*redis.FloatCmd, len(members))
+	_, err := m.source.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, mm := range members {
+			scores[i] = pipe.ZScore(ctx, m.key, mm.z.Member.(string))
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return 0, fmt.Errorf("checking copied page against source: %w", err)
+	}
+
+	var gone []migratedMember
+	for i, cmd := range scores {
+		if errors.Is(cmd.Err(), redis.Nil) {
+			gone = append(gone, members[i])
+		}
+	}
+	if len(gone) == 0 {
+		return 0, nil
+	}
+
+	_, err = m.target.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, mm := range gone {
+			pipe.ZRem(ctx, m.key, mm.z.Member)
+			if mm.indexKey != "" {
+				pipe.ZRem(ctx, mm.indexKey, mm.z.Member)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("removing fetched tasks from target: %w", err)
+	}
+	return len(gone), nil
+}
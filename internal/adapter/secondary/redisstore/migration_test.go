@@ -0,0 +1,36 @@
+package redisstore
+
+import (
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestMigrator_parsePage(t *testing.T) {
+	dto := taskDTO{ID: "task-1", MaxRetries: 3, BaseDelay: 1, DestinationType: "kafka",
+		Destination: destDTO{Host: "localhost", Port: "9092", Topic: "orders"}}
+	data, err := encodeTask(dto, TaskEncodingJSON)
+	if err != nil {
+		t.Fatalf("encoding task: %v", err)
+	}
+
+	m := NewMigrator(nil, nil, zap.NewNop())
+	members := m.parsePage([]string{
+		string(data), "1700000000",
+		"not-a-task", "1700000001",
+		"bad-score", "soon",
+	})
+
+	if len(members) != 2 {
+		t.Fatalf("expected 2 members, got %d", len(members))
+	}
+	if members[0].z.Score != 1700000000 {
+		t.Errorf("expected score 1700000000, got %v", members[0].z.Score)
+	}
+	if want := destinationIndexKey(toEntity(dto).Destination.Name()); members[0].indexKey != want {
+		t.Errorf("expected index key %q, got %q", want, members[0].indexKey)
+	}
+	if members[1].indexKey != "" {
+		t.Errorf("expected no index key for an undecodable member, got %q", members[1].indexKey)
+	}
+}
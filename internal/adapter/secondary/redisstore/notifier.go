@@ -0,0 +1,59 @@
+package redisstore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+	"github.com/ruudy-sib/rebound/internal/port/secondary"
+)
+
+// taskNotification is published for each task that reached a terminal
+// state. It is kept small for subscribers that only need to react.
+type taskNotification struct {
+	TaskID   string `json:"task_id"`
+	State    string `json:"state"`
+	ClientID string `json:"client_id,omitempty"`
+}
+
+// Notifier implements secondary.TaskNotifier by publishing on a Redis
+// Pub/Sub channel. Pub/Sub keeps no history: only subscribers connected at
+// the time receive a notification.
+type Notifier struct {
+	client  redis.UniversalClient
+	channel string
+}
+
+// NewNotifier creates a notifier publishing on channel.
+func NewNotifier(client redis.UniversalClient, channel string) secondary.TaskNotifier {
+	return &Notifier{client: client, channel: channel}
+}
+
+// Notify publishes one notification per outcome in a single round trip.
+func (n *Notifier) Notify(ctx context.Context, outcomes []entity.TaskOutcome) error {
+	if len(outcomes) == 0 {
+		return nil
+	}
+
+	messages := make([][]byte, len(outcomes))
+	for i, o := range outcomes {
+		data, err := json.Marshal(taskNotification{TaskID: o.TaskID, State: string(o.Outcome), ClientID: o.ClientID})
+		if err != nil {
+			return fmt.Errorf("encoding task notification: %w", err)
+		}
+		messages[i] = data
+	}
+
+	if _, err := n.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, m := range messages {
+			pipe.Publish(ctx, n.channel, m)
+		}
+		return nil
+	}); err != nil {
+		return fmt.Errorf("publishing task notifications to redis: %w", err)
+	}
+	return nil
+}
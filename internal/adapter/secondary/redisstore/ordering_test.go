@@ -0,0 +1,86 @@
+package redisstore
+
+import (
+	"context"
+	"testing"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+// orderingTag prefixes the keys of the ordering key the tests use.
+const orderingTag = domain.RedisOrderingKeyPrefix + "{order-1}"
+
+func TestOrderingStore(t *testing.T) {
+	for _, encoding := range []TaskEncoding{TaskEncodingJSON, TaskEncodingProtobuf} {
+		t.Run(string(encoding), func(t *testing.T) {
+			ctx := context.Background()
+			mr, client := newTestRedis(t)
+			store := NewOrderingStore(client, zap.NewNop(), WithTaskEncoding(encoding))
+
+			task := func(id string) *entity.Task {
+				return &entity.Task{ID: id, Source: "test", OrderingKey: "order-1", MessageData: `{"n":"` + id + `"}`}
+			}
+			for i, id := range []string{"task-1", "task-2", "task-3"} {
+				active, err := store.Enqueue(ctx, task(id))
+				if err != nil {
+					t.Fatalf("enqueue %s: %v", id, err)
+				}
+				if active != (i == 0) {
+					t.Fatalf("enqueue %s: expected active=%v", id, i == 0)
+				}
+			}
+
+			for _, want := range []string{"task-2", "task-3"} {
+				next, err := store.Advance(ctx, "order-1")
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if next == nil || next.ID != want || next.MessageData != `{"n":"`+want+`"}` {
+					t.Fatalf("expected %s promoted, got %+v", want, next)
+				}
+				if active, _ := mr.Get(orderingTag + ":active"); active != want {
+					t.Fatalf("expected %s marked active, got %q", want, active)
+				}
+			}
+
+			next, err := store.Advance(ctx, "order-1")
+			if err != nil || next != nil {
+				t.Fatalf("expected the key released, got %+v, %v", next, err)
+			}
+			if len(mr.Keys()) != 0 {
+				t.Fatalf("expected no ordering state left, got %v", mr.Keys())
+			}
+
+			// The key is free again.
+			if active, err := store.Enqueue(ctx, task("task-4")); err != nil || !active {
+				t.Fatalf("expected task-4 active, got %v, %v", active, err)
+			}
+		})
+	}
+}
+
+func TestOrderingStore_Advance_legacyEntry(t *testing.T) {
+	ctx := context.Background()
+	mr, client := newTestRedis(t)
+	store := NewOrderingStore(client, zap.NewNop())
+
+	// Lists written before tasks were stored by ID hold the JSON task.
+	mr.Set(orderingTag+":active", "task-1")
+	if _, err := mr.Push(orderingTag+":waiting", `{"id":"task-2","source":"test"}`); err != nil {
+		t.Fatalf("seeding: %v", err)
+	}
+
+	next, err := store.Advance(ctx, "order-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if next == nil || next.ID != "task-2" {
+		t.Fatalf("expected task-2 promoted, got %+v", next)
+	}
+	if active, _ := mr.Get(orderingTag + ":active"); active != "task-2" {
+		t.Fatalf("expected task-2 marked active, got %q", active)
+	}
+}
@@ -0,0 +1,78 @@
+package redisstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+	"github.com/ruudy-sib/rebound/internal/port/secondary"
+)
+
+// OverflowStore implements secondary.OverflowStore with a Redis list,
+// appending spilled tasks at the tail and restoring them from the head.
+type OverflowStore struct {
+	client   redis.UniversalClient
+	key      string
+	encoding TaskEncoding
+	logger   *zap.Logger
+}
+
+// NewOverflowStore creates a Redis-backed overflow store.
+func NewOverflowStore(client redis.UniversalClient, logger *zap.Logger, opts ...StoreOption) secondary.OverflowStore {
+	return &OverflowStore{
+		client:   client,
+		key:      domain.RedisOverflowKey,
+		encoding: applyStoreOptions(opts).encoding,
+		logger:   logger.Named("redis-overflow"),
+	}
+}
+
+// Spill appends the tasks in one RPUSH.
+func (o *OverflowStore) Spill(ctx context.Context, tasks ...*entity.Task) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	values := make([]any, len(tasks))
+	for i, task := range tasks {
+		data, err := encodeTask(toDTO(task), o.encoding)
+		if err != nil {
+			return fmt.Errorf("marshaling spilled task: %w", err)
+		}
+		values[i] = data
+	}
+
+	if err := o.client.RPush(ctx, o.key, values...).Err(); err != nil {
+		return fmt.Errorf("spilling tasks to redis: %w", err)
+	}
+	return nil
+}
+
+// Restore pops up to limit tasks from the head of the list. Entries that
+// cannot be decoded are logged and dropped, so one bad entry cannot block
+// the rest.
+func (o *OverflowStore) Restore(ctx context.Context, limit int) ([]*entity.Task, error) {
+	raw, err := o.client.LPopCount(ctx, o.key, limit).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("restoring spilled tasks from redis: %w", err)
+	}
+
+	tasks := make([]*entity.Task, 0, len(raw))
+	for _, r := range raw {
+		dto, err := decodeTask([]byte(r))
+		if err != nil {
+			o.logger.Error("dropping undecodable spilled task", zap.Error(err))
+			continue
+		}
+		tasks = append(tasks, toEntity(dto))
+	}
+	return tasks, nil
+}
@@ -0,0 +1,62 @@
+package redisstore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+	"github.com/ruudy-sib/rebound/internal/port/secondary"
+)
+
+// quarantineDTO is the Redis representation of a quarantined task.
+type quarantineDTO struct {
+	Task          taskDTO   `json:"task"`
+	Reason        string    `json:"reason"`
+	QuarantinedAt time.Time `json:"quarantined_at"`
+}
+
+// QuarantineStore implements secondary.QuarantineStore using a Redis hash
+// keyed by task ID, so each poison task is kept exactly once.
+type QuarantineStore struct {
+	client redis.UniversalClient
+	key    string
+	logger *zap.Logger
+}
+
+// NewQuarantineStore creates a Redis-backed quarantine store.
+func NewQuarantineStore(client redis.UniversalClient, logger *zap.Logger) secondary.QuarantineStore {
+	return &QuarantineStore{
+		client: client,
+		key:    domain.RedisQuarantineKey,
+		logger: logger.Named("redis-quarantine"),
+	}
+}
+
+// Quarantine stores the task and reason under the task ID.
+func (q *QuarantineStore) Quarantine(ctx context.Context, task *entity.Task, reason string) error {
+	data, err := json.Marshal(quarantineDTO{
+		Task:          toDTO(task),
+		Reason:        reason,
+		QuarantinedAt: time.Now().UTC(),
+	})
+	if err != nil {
+		return fmt.Errorf("marshaling quarantined task: %w", err)
+	}
+
+	if err := q.client.HSet(ctx, q.key, task.ID, data).Err(); err != nil {
+		return fmt.Errorf("quarantining task in redis: %w", err)
+	}
+
+	q.logger.Info("task quarantined",
+		zap.String("task_id", task.ID),
+		zap.String("reason", reason),
+	)
+
+	return nil
+}
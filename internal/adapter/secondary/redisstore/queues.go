@@ -0,0 +1,267 @@
+package redisstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+	"github.com/ruudy-sib/rebound/internal/port/secondary"
+)
+
+// QueueStore implements secondary.QueueStore over the keys used by the
+// scheduler, ordering, dead-letter and quarantine adapters.
+type QueueStore struct {
+	client      redis.UniversalClient
+	tokenPrefix string
+}
+
+// NewQueueStore creates a Redis-backed queue store.
+func NewQueueStore(client redis.UniversalClient) secondary.QueueStore {
+	return &QueueStore{
+		client:      client,
+		tokenPrefix: domain.RedisPurgeTokenKeyPrefix,
+	}
+}
+
+// Count sizes the queue. Scheduled tasks include those waiting behind an
+// ordering key.
+func (q *QueueStore) Count(ctx context.Context, queue entity.Queue) (int64, error) {
+	switch queue {
+	case entity.QueueScheduled:
+		count, err := q.client.ZCard(ctx, domain.RedisRetryKey).Result()
+		if err != nil {
+			return 0, fmt.Errorf("counting scheduled tasks in redis: %w", err)
+		}
+		waiting, err := q.orderingState(ctx, false)
+		return count + waiting, err
+	case entity.QueueDeadLetter:
+		count, err := q.client.ZCard(ctx, domain.RedisDeadLetterKey).Result()
+		if err != nil {
+			return 0, fmt.Errorf("counting dead-letter tasks in redis: %w", err)
+		}
+		return count, nil
+	case entity.QueueQuarantine:
+		count, err := q.client.HLen(ctx, domain.RedisQuarantineKey).Result()
+		if err != nil {
+			return 0, fmt.Errorf("counting quarantined tasks in redis: %w", err)
+		}
+		return count, nil
+	case entity.QueueOverflow:
+		count, err := q.client.LLen(ctx, domain.RedisOverflowKey).Result()
+		if err != nil {
+			return 0, fmt.Errorf("counting spilled tasks in redis: %w", err)
+		}
+		return count, nil
+	case entity.QueueCorrupt:
+		count, err := q.client.HLen(ctx, domain.RedisCorruptKey).Result()
+		if err != nil {
+			return 0, fmt.Errorf("counting corrupt members in redis: %w", err)
+		}
+		return count, nil
+	}
+	return 0, fmt.Errorf("%w: %q", domain.ErrUnknownQueue, queue)
+}
+
+// memorySamples is how many nested elements MEMORY USAGE samples per key,
+// keeping Stats cheap on large queues.
+const memorySamples = 64
+
+// Stats sizes the queue with ZCARD-style counts and sampled MEMORY USAGE.
+// Unlike Count it does not scan ordering state, so tasks waiting behind an
+// ordering key are left out.
+func (q *QueueStore) Stats(ctx context.Context, queue entity.Queue, now time.Time) (entity.QueueStats, error) {
+	stats := entity.QueueStats{Queue: queue}
+	var sizeKeys []string
+
+	switch queue {
+	case entity.QueueScheduled:
+		var due, total *redis.IntCmd
+		if _, err := q.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+			due = pipe.ZCount(ctx, domain.RedisRetryKey, "-inf", scoreArg(now))
+			total = pipe.ZCard(ctx, domain.RedisRetryKey)
+			return nil
+		}); err != nil {
+			return stats, fmt.Errorf("counting scheduled tasks in redis: %w", err)
+		}
+		stats.Due = due.Val()
+		stats.Pending = total.Val() - stats.Due
+		sizeKeys = []string{domain.RedisRetryKey}
+	case entity.QueueDeadLetter, entity.QueueQuarantine, entity.QueueOverflow, entity.QueueCorrupt:
+		count, err := q.Count(ctx, queue)
+		if err != nil {
+			return stats, err
+		}
+		stats.Pending = count
+		switch queue {
+		case entity.QueueDeadLetter:
+			sizeKeys = []string{domain.RedisDeadLetterKey, domain.RedisDeadLetterDataKey}
+		case entity.QueueQuarantine:
+			sizeKeys = []string{domain.RedisQuarantineKey}
+		case entity.QueueOverflow:
+			sizeKeys = []string{domain.RedisOverflowKey}
+		case entity.QueueCorrupt:
+			sizeKeys = []string{domain.RedisCorruptKey}
+		}
+	default:
+		return stats, fmt.Errorf("%w: %q", domain.ErrUnknownQueue, queue)
+	}
+
+	for _, key := range sizeKeys {
+		n, err := q.client.MemoryUsage(ctx, key, memorySamples).Result()
+		if err != nil && !errors.Is(err, redis.Nil) {
+			return stats, fmt.Errorf("reading memory usage of %s from redis: %w", key, err)
+		}
+		stats.MemoryBytes += n
+	}
+	return stats, nil
+}
+
+// Purge deletes the queue's keys. Purging the schedule also clears all
+// ordering state, so no ordering key stays held by a task that is gone,
+// and the destination indexes.
+func (q *QueueStore) Purge(ctx context.Context, queue entity.Queue) (int64, error) {
+	switch queue {
+	case entity.QueueScheduled:
+		var card *redis.IntCmd
+		if _, err := q.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			card = pipe.ZCard(ctx, domain.RedisRetryKey)
+			pipe.Del(ctx, domain.RedisRetryKey)
+			return nil
+		}); err != nil {
+			return 0, fmt.Errorf("purging scheduled tasks in redis: %w", err)
+		}
+		if err := scanKeys(ctx, q.client, domain.RedisDestinationIndexKeyPrefix+"*", func(node redis.UniversalClient, key string) error {
+			return node.Del(ctx, key).Err()
+		}); err != nil {
+			return card.Val(), fmt.Errorf("purging destination indexes in redis: %w", err)
+		}
+		waiting, err := q.orderingState(ctx, true)
+		return card.Val() + waiting, err
+	case entity.QueueDeadLetter:
+		var card *redis.IntCmd
+		// Plain pipeline rather than MULTI: the index and payload keys may
+		// live on different cluster slots.
+		if _, err := q.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+			card = pipe.ZCard(ctx, domain.RedisDeadLetterKey)
+			pipe.Del(ctx, domain.RedisDeadLetterKey)
+			pipe.Del(ctx, domain.RedisDeadLetterDataKey)
+			return nil
+		}); err != nil {
+			return 0, fmt.Errorf("purging dead-letter tasks in redis: %w", err)
+		}
+		return card.Val(), nil
+	case entity.QueueQuarantine:
+		var hlen *redis.IntCmd
+		if _, err := q.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			hlen = pipe.HLen(ctx, domain.RedisQuarantineKey)
+			pipe.Del(ctx, domain.RedisQuarantineKey)
+			return nil
+		}); err != nil {
+			return 0, fmt.Errorf("purging quarantined tasks in redis: %w", err)
+		}
+		return hlen.Val(), nil
+	case entity.QueueOverflow:
+		var llen *redis.IntCmd
+		if _, err := q.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			llen = pipe.LLen(ctx, domain.RedisOverflowKey)
+			pipe.Del(ctx, domain.RedisOverflowKey)
+			return nil
+		}); err != nil {
+			return 0, fmt.Errorf("purging spilled tasks in redis: %w", err)
+		}
+		return llen.Val(), nil
+	case entity.QueueCorrupt:
+		var hlen *redis.IntCmd
+		if _, err := q.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			hlen = pipe.HLen(ctx, domain.RedisCorruptKey)
+			pipe.Del(ctx, domain.RedisCorruptKey)
+			return nil
+		}); err != nil {
+			return 0, fmt.Errorf("purging corrupt members in redis: %w", err)
+		}
+		return hlen.Val(), nil
+	}
+	return 0, fmt.Errorf("%w: %q", domain.ErrUnknownQueue, queue)
+}
+
+// orderingState counts the tasks waiting behind ordering keys and, with
+// purge set, deletes every active marker, waiting list and task hash.
+func (q *QueueStore) orderingState(ctx context.Context, purge bool) (int64, error) {
+	var waiting atomic.Int64
+	err := scanKeys(ctx, q.client, domain.RedisOrderingKeyPrefix+"*", func(node redis.UniversalClient, key string) error {
+		isList := strings.HasSuffix(key, ":waiting")
+		if !purge {
+			if !isList {
+				return nil
+			}
+			n, err := node.LLen(ctx, key).Result()
+			waiting.Add(n)
+			return err
+		}
+
+		if !isList {
+			return node.Del(ctx, key).Err()
+		}
+		var llen *redis.IntCmd
+		_, err := node.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			llen = pipe.LLen(ctx, key)
+			pipe.Del(ctx, key)
+			return nil
+		})
+		waiting.Add(llen.Val())
+		return err
+	})
+	if err != nil {
+		return waiting.Load(), fmt.Errorf("scanning ordering state in redis: %w", err)
+	}
+	return waiting.Load(), nil
+}
+
+// SavePurgeToken stores the token with an expiry.
+func (q *QueueStore) SavePurgeToken(ctx context.Context, queue entity.Queue, token string, ttl time.Duration) error {
+	if err := q.client.Set(ctx, q.tokenPrefix+string(queue), token, ttl).Err(); err != nil {
+		return fmt.Errorf("saving purge token in redis: %w", err)
+	}
+	return nil
+}
+
+// TakePurgeToken reads and deletes the pending token in one GETDEL.
+func (q *QueueStore) TakePurgeToken(ctx context.Context, queue entity.Queue, token string) (bool, error) {
+	saved, err := q.client.GetDel(ctx, q.tokenPrefix+string(queue)).Result()
+	if errors.Is(err, redis.Nil) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("reading purge token from redis: %w", err)
+	}
+	return saved == token, nil
+}
+
+// scanKeys calls fn for every key matching pattern. On Redis Cluster every
+// master is scanned concurrently, and fn receives the client for that
+// master.
+func scanKeys(ctx context.Context, client redis.UniversalClient, pattern string, fn func(redis.UniversalClient, string) error) error {
+	scan := func(ctx context.Context, node redis.UniversalClient) error {
+		iter := node.Scan(ctx, 0, pattern, 100).Iterator()
+		for iter.Next(ctx) {
+			if err := fn(node, iter.Val()); err != nil {
+				return err
+			}
+		}
+		return iter.Err()
+	}
+
+	if cluster, ok := client.(*redis.ClusterClient); ok {
+		return cluster.ForEachMaster(ctx, func(ctx context.Context, node *redis.Client) error {
+			return scan(ctx, node)
+		})
+	}
+	return scan(ctx, client)
+}
@@ -0,0 +1,220 @@
+package redisstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+)
+
+// replicationOp is one schedule write to mirror on the standby.
+type replicationOp struct {
+	key     string
+	members []redis.Z
+	// remove deletes members instead of adding them; existing only updates
+	// the scores of members the standby already has.
+	remove   bool
+	existing bool
+}
+
+// appliedScript raises the applied sequence on the standby, never lowering
+// it, as batches from several instances may land out of order. It is sent
+// with EVAL, as a pipeline cannot fall back from EVALSHA.
+// KEYS[1] = applied key, ARGV[1] = sequence.
+const appliedScript = `
+local current = tonumber(redis.call('GET', KEYS[1]) or '0')
+if tonumber(ARGV[1]) > current then
+	redis.call('SET', KEYS[1], ARGV[1])
+end
+return 0
+`
+
+// ReplicationStatus compares the writes sequenced on the primary with those
+// applied on the standby. Queued writes are still in this instance's
+// buffer; Dropped ones overflowed it or failed every attempt and are
+// missing from the standby until the task is written again.
+type ReplicationStatus struct {
+	Sequenced int64
+	Applied   int64
+	Queued    int
+	Dropped   int64
+}
+
+// Replicator mirrors schedule writes to a standby Redis, typically in
+// another region, so a regional Redis loss does not lose the retry backlog.
+// Writes are buffered and applied in batches off the hot path; each batch
+// takes a range of the primary's sequence, and the standby records the
+// highest sequence it has applied.
+type Replicator struct {
+	primary redis.UniversalClient
+	standby redis.UniversalClient
+	ops     chan replicationOp
+	logger  *zap.Logger
+
+	dropped atomic.Int64
+}
+
+// NewReplicator creates a replicator from primary to standby. Call Run to
+// start applying writes.
+func NewReplicator(primary, standby redis.UniversalClient, logger *zap.Logger) *Replicator {
+	return &Replicator{
+		primary: primary,
+		standby: standby,
+		ops:     make(chan replicationOp, domain.DefaultReplicationBuffer),
+		logger:  logger.Named("redis-replicator"),
+	}
+}
+
+// Name identifies the standby in health checks.
+func (r *Replicator) Name() string {
+	return "redis-replica"
+}
+
+// Check pings the standby.
+func (r *Replicator) Check(ctx context.Context) error {
+	if err := r.standby.Ping(ctx).Err(); err != nil {
+		return fmt.Errorf("standby redis ping: %w", err)
+	}
+	return nil
+}
+
+// Run applies buffered writes until ctx is cancelled, then flushes what is
+// left with a short grace period.
+func (r *Replicator) Run(ctx context.Context) {
+	r.logger.Info("replication started")
+	for {
+		select {
+		case <-ctx.Done():
+			flushCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), domain.ReplicationFlushTimeout)
+			for len(r.ops) > 0 && flushCtx.Err() == nil {
+				r.apply(flushCtx, r.drain(<-r.ops))
+			}
+			cancel()
+			r.logger.Info("replication stopped", zap.Int("unflushed", len(r.ops)))
+			return
+		case op := <-r.ops:
+			r.apply(ctx, r.drain(op))
+		}
+	}
+}
+
+// drain collects first and whatever else is buffered, up to a batch.
+func (r *Replicator) drain(first replicationOp) []replicationOp {
+	batch := []replicationOp{first}
+	for len(batch) < domain.ReplicationBatchSize {
+		select {
+		case op := <-r.ops:
+			batch = append(batch, op)
+		default:
+			return batch
+		}
+	}
+	return batch
+}
+
+// apply sequences the batch on the primary and writes it to the standby,
+// retrying a few times before dropping it.
+func (r *Replicator) apply(ctx context.Context, batch []replicationOp) {
+	seq, err := r.primary.IncrBy(ctx, domain.RedisReplicationSeqKey, int64(len(batch))).Result()
+	if err != nil {
+		r.logger.Warn("failed to sequence replication batch", zap.Error(err))
+	}
+
+	backoff := domain.ReplicationRetryBackoff
+	for attempt := 1; ; attempt++ {
+		_, err = r.standby.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+			for _, op := range batch {
+				switch {
+				case op.remove:
+					members := make([]any, len(op.members))
+					for i, z := range op.members {
+						members[i] = z.Member
+					}
+					pipe.ZRem(ctx, op.key, members...)
+				default:
+					pipe.ZAddArgs(ctx, op.key, redis.ZAddArgs{XX: op.existing, Members: op.members})
+				}
+			}
+			if seq > 0 {
+				pipe.Eval(ctx, appliedScript, []string{domain.RedisReplicationAppliedKey}, seq)
+			}
+			return nil
+		})
+		if err == nil {
+			return
+		}
+		if attempt == domain.ReplicationMaxAttempts || ctx.Err() != nil {
+			break
+		}
+		select {
+		case <-ctx.Done():
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+
+	r.dropped.Add(int64(len(batch)))
+	r.logger.Error("replication batch dropped",
+		zap.Int("writes", len(batch)),
+		zap.Int64("sequence", seq),
+		zap.Error(err),
+	)
+}
+
+// Close closes the standby client. Call it after Run has returned.
+func (r *Replicator) Close() error {
+	return r.standby.Close()
+}
+
+// Status reads the sequence counters from both sides.
+func (r *Replicator) Status(ctx context.Context) (ReplicationStatus, error) {
+	status := ReplicationStatus{Queued: len(r.ops), Dropped: r.dropped.Load()}
+
+	seq, err := r.primary.Get(ctx, domain.RedisReplicationSeqKey).Int64()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return status, fmt.Errorf("reading replication sequence from redis: %w", err)
+	}
+	applied, err := r.standby.Get(ctx, domain.RedisReplicationAppliedKey).Int64()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return status, fmt.Errorf("reading applied sequence from standby redis: %w", err)
+	}
+	status.Sequenced = seq
+	status.Applied = applied
+	return status, nil
+}
+
+// add mirrors a ZADD of members to key.
+func (r *Replicator) add(key string, members ...redis.Z) {
+	r.enqueue(replicationOp{key: key, members: members})
+}
+
+// update mirrors a ZADD XX of members to key.
+func (r *Replicator) update(key string, members ...redis.Z) {
+	r.enqueue(replicationOp{key: key, members: members, existing: true})
+}
+
+// remove mirrors a ZREM of member from key.
+func (r *Replicator) remove(key string, member string) {
+	r.enqueue(replicationOp{key: key, members: []redis.Z{{Member: member}}, remove: true})
+}
+
+// enqueue buffers op without blocking the caller. A nil Replicator ignores
+// it, so stores can call it unconditionally.
+func (r *Replicator) enqueue(op replicationOp) {
+	if r == nil {
+		return
+	}
+	select {
+	case r.ops <- op:
+	default:
+		if r.dropped.Add(1)%domain.ReplicationBatchSize == 1 {
+			r.logger.Warn("replication buffer full, dropping writes", zap.Int64("dropped", r.dropped.Load()))
+		}
+	}
+}
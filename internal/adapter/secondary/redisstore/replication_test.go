@@ -0,0 +1,30 @@
+package redisstore
+
+import (
+	"testing"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+)
+
+func TestReplicator_enqueue(t *testing.T) {
+	var nilReplicator *Replicator
+	nilReplicator.remove("key", "member") // must not panic
+
+	r := NewReplicator(nil, nil, zap.NewNop())
+	for i := 0; i < domain.DefaultReplicationBuffer+5; i++ {
+		r.remove("key", "member")
+	}
+	if got := r.dropped.Load(); got != 5 {
+		t.Fatalf("expected 5 writes dropped once the buffer is full, got %d", got)
+	}
+
+	batch := r.drain(<-r.ops)
+	if len(batch) != domain.ReplicationBatchSize {
+		t.Fatalf("expected a batch of %d, got %d", domain.ReplicationBatchSize, len(batch))
+	}
+	if len(r.ops) != domain.DefaultReplicationBuffer-domain.ReplicationBatchSize {
+		t.Fatalf("expected %d writes left, got %d", domain.DefaultReplicationBuffer-domain.ReplicationBatchSize, len(r.ops))
+	}
+}
@@ -3,7 +3,12 @@ package redisstore
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -15,19 +20,58 @@ import (
 )
 
 // taskDTO is the Redis-specific representation of a task.
-// It translates between domain entities and JSON stored in Redis.
+// It translates between domain entities and JSON stored in Redis. New
+// fields also need a protobuf field number in codec.go; changes to existing
+// fields need an upgrade in schema.go.
+//
+// BaseDelayMs carries the base delay at millisecond precision. BaseDelay
+// keeps it in whole seconds, rounded up, for records read by releases that
+// predate BaseDelayMs; it is only read when BaseDelayMs is absent.
 type taskDTO struct {
-	ID              string `json:"id"`
-	Attempt         int    `json:"attempt"`
-	Source          string `json:"source"`
+	SchemaVersion   int     `json:"schema_version"`
+	ID              string  `json:"id"`
+	Attempt         int     `json:"attempt"`
+	Source          string  `json:"source"`
 	Destination     destDTO `json:"destination"`
 	DeadDestination destDTO `json:"dead_destination"`
-	MaxRetries      int    `json:"max_retries"`
-	BaseDelay       int    `json:"base_delay"`
-	ClientID        string `json:"client_id"`
-	IsPriority      bool   `json:"is_priority"`
-	MessageData     string `json:"message_data"`
-	DestinationType string `json:"destination_type"`
+	MaxRetries      int     `json:"max_retries"`
+	BaseDelay       int     `json:"base_delay"`
+	BaseDelayMs     int64   `json:"base_delay_ms,omitempty"`
+	ClientID        string  `json:"client_id"`
+	IsPriority      bool    `json:"is_priority"`
+	MessageData     string  `json:"message_data"`
+	DestinationType string  `json:"destination_type"`
+	OrderingKey     string  `json:"ordering_key,omitempty"`
+	Queue           string  `json:"queue,omitempty"`
+	CallbackURL     string  `json:"callback_url,omitempty"`
+
+	AttemptImmediately bool   `json:"attempt_immediately,omitempty"`
+	Jitter             string `json:"jitter,omitempty"`
+	MaxDelayMs         int64  `json:"max_delay_ms,omitempty"`
+
+	RetryDelaysMs []int64 `json:"retry_delays_ms,omitempty"`
+
+	FallbackDeadDestinations []destDTO `json:"fallback_dead_destinations,omitempty"`
+
+	Metadata      map[string]string `json:"metadata,omitempty"`
+	CorrelationID string            `json:"correlation_id,omitempty"`
+
+	CreatedAt        *time.Time `json:"created_at,omitempty"`
+	FirstAttemptAt   *time.Time `json:"first_attempt_at,omitempty"`
+	LastAttemptAt    *time.Time `json:"last_attempt_at,omitempty"`
+	LastError        string     `json:"last_error,omitempty"`
+	LastErrorCode    string     `json:"last_error_code,omitempty"`
+	RepeatedFailures int        `json:"repeated_failures,omitempty"`
+	Redrives         int        `json:"redrives,omitempty"`
+	LastRetryDelayMs int64      `json:"last_retry_delay_ms,omitempty"`
+}
+
+// baseDelay prefers the millisecond field and falls back to whole seconds.
+func (d taskDTO) baseDelay() time.Duration {
+	if d.BaseDelayMs > 0 {
+		return time.Duration(d.BaseDelayMs) * time.Millisecond
+	}
+	return time.Duration(d.BaseDelay) * time.Second
 }
 
 type destDTO struct {
@@ -35,31 +79,61 @@ type destDTO struct {
 	Port  string `json:"port"`
 	Topic string `json:"topic"`
 	URL   string `json:"url"`
+
+	Format string `json:"format,omitempty"`
 }
 
 func toDTO(task *entity.Task) taskDTO {
 	return taskDTO{
-		ID:      task.ID,
-		Attempt: task.Attempt,
-		Source:  task.Source,
+		SchemaVersion: currentSchemaVersion(),
+		ID:            task.ID,
+		Attempt:       task.Attempt,
+		Source:        task.Source,
 		Destination: destDTO{
 			Host:  task.Destination.Host,
 			Port:  task.Destination.Port,
 			Topic: task.Destination.Topic,
 			URL:   task.Destination.URL,
+
+			Format: string(task.Destination.Format),
 		},
 		DeadDestination: destDTO{
 			Host:  task.DeadDestination.Host,
 			Port:  task.DeadDestination.Port,
 			Topic: task.DeadDestination.Topic,
 			URL:   task.DeadDestination.URL,
+
+			Format: string(task.DeadDestination.Format),
 		},
 		MaxRetries:      task.MaxRetries,
-		BaseDelay:       task.BaseDelay,
+		BaseDelay:       int((task.BaseDelay + time.Second - 1) / time.Second),
+		BaseDelayMs:     task.BaseDelay.Milliseconds(),
 		ClientID:        task.ClientID,
 		IsPriority:      task.IsPriority,
 		MessageData:     task.MessageData,
 		DestinationType: string(task.DestinationType),
+		OrderingKey:     task.OrderingKey,
+		Queue:           task.Queue,
+		CallbackURL:     task.CallbackURL,
+		Metadata:        task.Metadata,
+
+		AttemptImmediately: task.AttemptImmediately,
+		Jitter:             string(task.Jitter),
+		MaxDelayMs:         task.MaxDelay.Milliseconds(),
+		CorrelationID:      task.CorrelationID,
+
+		RetryDelaysMs: delaysToMs(task.RetryDelays),
+
+		FallbackDeadDestinations: fallbacksToDTO(task.FallbackDeadDestinations),
+
+		CreatedAt:        timePtr(task.CreatedAt),
+		FirstAttemptAt:   timePtr(task.FirstAttemptAt),
+		LastAttemptAt:    timePtr(task.LastAttemptAt),
+		LastError:        task.LastError,
+		LastErrorCode:    string(task.LastErrorCode),
+		RepeatedFailures: task.RepeatedFailures,
+		Redrives:         task.Redrives,
+		LastRetryDelayMs: task.LastRetryDelay.Milliseconds(),
 	}
 }
 
@@ -73,54 +147,183 @@ func toEntity(dto taskDTO) *entity.Task {
 			Port:  dto.Destination.Port,
 			Topic: dto.Destination.Topic,
 			URL:   dto.Destination.URL,
+
+			Format: entity.PayloadFormat(dto.Destination.Format),
 		},
 		DeadDestination: entity.Destination{
 			Host:  dto.DeadDestination.Host,
 			Port:  dto.DeadDestination.Port,
 			Topic: dto.DeadDestination.Topic,
 			URL:   dto.DeadDestination.URL,
+
+			Format: entity.PayloadFormat(dto.DeadDestination.Format),
 		},
 		MaxRetries:      dto.MaxRetries,
-		BaseDelay:       dto.BaseDelay,
+		BaseDelay:       dto.baseDelay(),
 		ClientID:        dto.ClientID,
 		IsPriority:      dto.IsPriority,
 		MessageData:     dto.MessageData,
 		DestinationType: entity.DestinationType(dto.DestinationType),
+		OrderingKey:     dto.OrderingKey,
+		Queue:           dto.Queue,
+		CallbackURL:     dto.CallbackURL,
+		Metadata:        dto.Metadata,
+
+		AttemptImmediately: dto.AttemptImmediately,
+		Jitter:             entity.JitterMode(dto.Jitter),
+		MaxDelay:           time.Duration(dto.MaxDelayMs) * time.Millisecond,
+		CorrelationID:      dto.CorrelationID,
+
+		RetryDelays: delaysFromMs(dto.RetryDelaysMs),
+
+		FallbackDeadDestinations: fallbacksToEntity(dto.FallbackDeadDestinations),
+
+		CreatedAt:        timeValue(dto.CreatedAt),
+		FirstAttemptAt:   timeValue(dto.FirstAttemptAt),
+		LastAttemptAt:    timeValue(dto.LastAttemptAt),
+		LastError:        dto.LastError,
+		LastErrorCode:    entity.ErrorCode(dto.LastErrorCode),
+		RepeatedFailures: dto.RepeatedFailures,
+		Redrives:         dto.Redrives,
+		LastRetryDelay:   time.Duration(dto.LastRetryDelayMs) * time.Millisecond,
 	}
 }
 
+func delaysToMs(delays []time.Duration) []int64 {
+	if len(delays) == 0 {
+		return nil
+	}
+	ms := make([]int64, len(delays))
+	for i, d := range delays {
+		ms[i] = d.Milliseconds()
+	}
+	return ms
+}
+
+func delaysFromMs(ms []int64) []time.Duration {
+	if len(ms) == 0 {
+		return nil
+	}
+	delays := make([]time.Duration, len(ms))
+	for i, v := range ms {
+		delays[i] = time.Duration(v) * time.Millisecond
+	}
+	return delays
+}
+
+func fallbacksToDTO(dests []entity.Destination) []destDTO {
+	if len(dests) == 0 {
+		return nil
+	}
+	dtos := make([]destDTO, len(dests))
+	for i, d := range dests {
+		dtos[i] = destDTO{Host: d.Host, Port: d.Port, Topic: d.Topic, URL: d.URL, Format: string(d.Format)}
+	}
+	return dtos
+}
+
+func fallbacksToEntity(dtos []destDTO) []entity.Destination {
+	if len(dtos) == 0 {
+		return nil
+	}
+	dests := make([]entity.Destination, len(dtos))
+	for i, d := range dtos {
+		dests[i] = entity.Destination{Host: d.Host, Port: d.Port, Topic: d.Topic, URL: d.URL, Format: entity.PayloadFormat(d.Format)}
+	}
+	return dests
+}
+
+// timePtr maps a zero time to nil so it is omitted from the stored JSON.
+func timePtr(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+func timeValue(t *time.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return *t
+}
+
 // Scheduler implements secondary.TaskScheduler using a Redis sorted set.
-// Tasks are scored by their scheduled execution time (Unix timestamp).
+// Tasks are scored by their scheduled execution time, in Unix seconds with
+// millisecond fractions.
 type Scheduler struct {
-	client redis.UniversalClient
-	key    string
-	logger *zap.Logger
+	client     redis.UniversalClient
+	key        string
+	encoding   TaskEncoding
+	replica    *Replicator
+	lowLatency bool
+	shards     *ShardRing
+	logger     *zap.Logger
+
+	priorityShare int
+	namedQueues   bool
+}
+
+// scoreOf converts a time to a schedule score.
+func scoreOf(t time.Time) float64 {
+	return float64(t.UnixMilli()) / 1e3
+}
+
+// scoreArg formats a time as a score bound for range commands, keeping the
+// millisecond fraction so members due within the current second are in
+// range.
+func scoreArg(t time.Time) string {
+	return strconv.FormatFloat(scoreOf(t), 'f', 3, 64)
+}
+
+// scoreTime converts a schedule score back to a time. Scores written before
+// millisecond fractions were kept are whole seconds and read the same way.
+func scoreTime(score float64) time.Time {
+	return time.UnixMilli(int64(math.Round(score * 1e3)))
 }
 
 // NewScheduler creates a Redis-backed task scheduler.
-func NewScheduler(client redis.UniversalClient, logger *zap.Logger) secondary.TaskScheduler {
+func NewScheduler(client redis.UniversalClient, logger *zap.Logger, opts ...StoreOption) secondary.TaskScheduler {
+	o := applyStoreOptions(opts)
 	return &Scheduler{
-		client: client,
-		key:    domain.RedisRetryKey,
-		logger: logger.Named("redis-scheduler"),
+		client:     client,
+		key:        domain.RedisRetryKey,
+		encoding:   o.encoding,
+		replica:    o.replicator,
+		lowLatency: o.lowLatency,
+		shards:     o.shards,
+		logger:     logger.Named("redis-scheduler"),
+
+		priorityShare: o.priorityShare,
+		namedQueues:   o.namedQueues,
 	}
 }
 
 // Schedule adds a task to the sorted set with score = now + delay.
 func (s *Scheduler) Schedule(ctx context.Context, task *entity.Task, delay time.Duration) error {
-	dto := toDTO(task)
-	data, err := json.Marshal(dto)
+	data, err := encodeTask(toDTO(task), s.encoding)
 	if err != nil {
 		return fmt.Errorf("marshaling task: %w", err)
 	}
 
-	score := float64(time.Now().Add(delay).Unix())
-	if err := s.client.ZAdd(ctx, s.key, redis.Z{
-		Score:  score,
-		Member: data,
-	}).Err(); err != nil {
-		return fmt.Errorf("scheduling task in redis: %w", err)
+	score := scoreOf(time.Now().Add(delay))
+	z := redis.Z{Score: score, Member: data}
+	// Plain pipeline rather than MULTI: the schedule and the destination
+	// index may live on different cluster slots.
+	var add *redis.IntCmd
+	_, err = s.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		add = pipe.ZAdd(ctx, s.key, z)
+		pipe.ZAdd(ctx, destinationIndexKey(task.Destination.Name()), z)
+		return nil
+	})
+	if addErr := add.Err(); addErr != nil {
+		return fmt.Errorf("scheduling task in redis: %w", addErr)
+	}
+	if err != nil {
+		s.logger.Warn("failed to index task by destination", zap.Error(err), zap.String("task_id", task.ID))
 	}
+	s.replica.add(s.key, z)
+	s.replica.add(destinationIndexKey(task.Destination.Name()), z)
 
 	s.logger.Info("task saved to redis",
 		zap.String("task_id", task.ID),
@@ -135,14 +338,56 @@ func (s *Scheduler) Schedule(ctx context.Context, task *entity.Task, delay time.
 	return nil
 }
 
-// FetchDue retrieves tasks whose score (scheduled time) is <= now,
-// removes them from the sorted set atomically, and returns them.
-func (s *Scheduler) FetchDue(ctx context.Context, limit int) ([]*entity.Task, error) {
-	now := fmt.Sprintf("%f", float64(time.Now().Unix()))
+// FetchDue retrieves tasks of queue whose score (scheduled time) is <= now,
+// removes them from the sorted set atomically, and returns them. Without
+// WithNamedQueues every task is in the default queue and queue is ignored.
+func (s *Scheduler) FetchDue(ctx context.Context, queue string, limit int) ([]*entity.Task, error) {
+	var claimed []redis.Z
+	var err error
+	switch {
+	case s.shards != nil || s.priorityShare > 0 || s.namedQueues:
+		claimed, err = s.claimScanned(ctx, queue, limit)
+	case s.lowLatency:
+		claimed, err = s.popDue(ctx, limit)
+	default:
+		claimed, err = s.claimDue(ctx, limit)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := make([]*entity.Task, 0, len(claimed))
+	for _, z := range claimed {
+		member := z.Member.(string)
+		s.replica.remove(s.key, member)
+
+		dto, err := decodeTask([]byte(member))
+		if err != nil {
+			s.quarantineCorrupt(ctx, member, z.Score, err)
+			continue
+		}
+
+		t := toEntity(dto)
+		s.unindex(ctx, t, member)
+		s.logger.Info("task fetched from redis",
+			zap.String("task_id", t.ID),
+			zap.String("destination_type", string(t.DestinationType)),
+			zap.String("destination_url", t.Destination.URL),
+			zap.String("destination_topic", t.Destination.Topic),
+			zap.Int("attempt", t.Attempt),
+		)
+		tasks = append(tasks, t)
+	}
+
+	return tasks, nil
+}
 
+// claimDue reads up to limit due members and removes them one by one. A
+// member another worker removed first is skipped.
+func (s *Scheduler) claimDue(ctx context.Context, limit int) ([]redis.Z, error) {
 	results, err := s.client.ZRangeByScoreWithScores(ctx, s.key, &redis.ZRangeBy{
 		Min:    "0",
-		Max:    now,
+		Max:    scoreArg(time.Now()),
 		Offset: 0,
 		Count:  int64(limit),
 	}).Result()
@@ -150,7 +395,7 @@ func (s *Scheduler) FetchDue(ctx context.Context, limit int) ([]*entity.Task, er
 		return nil, fmt.Errorf("fetching due tasks from redis: %w", err)
 	}
 
-	tasks := make([]*entity.Task, 0, len(results))
+	claimed := results[:0]
 	for _, z := range results {
 		member, ok := z.Member.(string)
 		if !ok {
@@ -159,38 +404,523 @@ func (s *Scheduler) FetchDue(ctx context.Context, limit int) ([]*entity.Task, er
 		}
 
 		// Remove the task from the queue before processing.
-		if err := s.client.ZRem(ctx, s.key, z.Member).Err(); err != nil {
+		removed, err := s.client.ZRem(ctx, s.key, member).Result()
+		if err != nil {
 			s.logger.Error("failed to remove task from queue",
 				zap.Error(err),
 				zap.String("member", member),
 			)
 			continue
 		}
+		if removed == 0 {
+			continue
+		}
+		claimed = append(claimed, z)
+	}
+	return claimed, nil
+}
 
-		var dto taskDTO
-		if err := json.Unmarshal([]byte(member), &dto); err != nil {
-			s.logger.Warn("invalid task data in redis",
+// claimScanned is the selective claim, used when the scheduler is sharded,
+// has a priority share or polls named queues. It reads due members,
+// earliest first, a page at a time up to domain.ClaimScanLimit, skipping
+// clients of shards this instance does not own and, with named queues,
+// tasks of other queues, and removes the batch composeBatch picks from
+// them. Members that cannot be decoded are claimed by whoever reads them,
+// so they get quarantined.
+func (s *Scheduler) claimScanned(ctx context.Context, queue string, limit int) ([]redis.Z, error) {
+	owns := func(string) bool { return true }
+	if s.shards != nil {
+		var err error
+		if owns, err = s.shards.Owner(ctx); err != nil {
+			return nil, err
+		}
+	}
+	priorityQuota := 0
+	if s.priorityShare > 0 {
+		priorityQuota = max(1, limit*s.priorityShare/100)
+	}
+	dueBy := scoreArg(time.Now())
+	pageSize := int64(max(limit, 100))
+
+	// Corrupt members are claimed on top of the batch.
+	var priority, normal, corrupt []redis.Z
+	for offset := int64(0); offset < domain.ClaimScanLimit; offset += pageSize {
+		page, err := s.client.ZRangeByScoreWithScores(ctx, s.key, &redis.ZRangeBy{
+			Min:    "0",
+			Max:    dueBy,
+			Offset: offset,
+			Count:  pageSize,
+		}).Result()
+		if err != nil {
+			return nil, fmt.Errorf("fetching due tasks from redis: %w", err)
+		}
+
+		for _, z := range page {
+			member, ok := z.Member.(string)
+			if !ok {
+				s.logger.Warn("unexpected member type in sorted set")
+				continue
+			}
+			dto, err := decodeTask([]byte(member))
+			switch {
+			case err != nil:
+				corrupt = append(corrupt, z)
+			case !owns(dto.ClientID):
+			case s.namedQueues && dto.Queue != queue:
+			case dto.IsPriority && priorityQuota > 0:
+				if len(priority) < limit {
+					priority = append(priority, z)
+				}
+			default:
+				if len(normal) < limit {
+					normal = append(normal, z)
+				}
+			}
+		}
+		if len(priority) >= priorityQuota && len(priority)+len(normal) >= limit {
+			break
+		}
+		if int64(len(page)) < pageSize {
+			break
+		}
+	}
+
+	claimed := make([]redis.Z, 0, limit+len(corrupt))
+	for _, z := range append(corrupt, composeBatch(priority, normal, limit, priorityQuota)...) {
+		member := z.Member.(string)
+		removed, err := s.client.ZRem(ctx, s.key, member).Result()
+		if err != nil {
+			s.logger.Error("failed to remove task from queue",
 				zap.Error(err),
-				zap.String("raw", member),
+				zap.String("member", member),
 			)
 			continue
 		}
+		if removed == 1 {
+			claimed = append(claimed, z)
+		}
+	}
+	return claimed, nil
+}
 
-		t := toEntity(dto)
-		s.logger.Info("task fetched from redis",
-			zap.String("task_id", t.ID),
-			zap.String("destination_type", string(t.DestinationType)),
-			zap.String("destination_url", t.Destination.URL),
-			zap.String("destination_topic", t.Destination.Topic),
-			zap.Int("attempt", t.Attempt),
-		)
-		tasks = append(tasks, t)
+// composeBatch picks up to limit members, at most priorityQuota of them
+// priority ones unless too few normal ones are due, and returns them
+// earliest due first. Both inputs are in due order.
+func composeBatch(priority, normal []redis.Z, limit, priorityQuota int) []redis.Z {
+	takeNormal := min(len(normal), limit-min(len(priority), priorityQuota))
+	takePriority := min(len(priority), limit-takeNormal)
+
+	batch := append(append([]redis.Z(nil), priority[:takePriority]...), normal[:takeNormal]...)
+	sort.SliceStable(batch, func(i, j int) bool { return batch[i].Score < batch[j].Score })
+	return batch
+}
+
+// claimDueScript removes and returns up to ARGV[2] members due by ARGV[1],
+// earliest first, each followed by its score. KEYS[1] = schedule.
+var claimDueScript = redis.NewScript(`
+local due = redis.call('ZRANGEBYSCORE', KEYS[1], '-inf', ARGV[1], 'WITHSCORES', 'LIMIT', 0, ARGV[2])
+for i = 1, #due, 2 do
+	redis.call('ZREM', KEYS[1], due[i])
+end
+return due
+`)
+
+// popDue is the low-latency claim: a poll costs one round trip, due tasks
+// or not, and claims the batch atomically, so members that are not due are
+// never taken out of the schedule and a member is never seen by two
+// workers.
+func (s *Scheduler) popDue(ctx context.Context, limit int) ([]redis.Z, error) {
+	now := scoreArg(time.Now())
+	reply, err := claimDueScript.Run(ctx, s.client, []string{s.key}, now, limit).StringSlice()
+	if err != nil {
+		return nil, fmt.Errorf("fetching due tasks from redis: %w", err)
 	}
 
-	return tasks, nil
+	claimed := make([]redis.Z, 0, len(reply)/2)
+	for i := 0; i+1 < len(reply); i += 2 {
+		// Redis replies with the score as it formats any float.
+		score, _ := strconv.ParseFloat(reply[i+1], 64)
+		claimed = append(claimed, redis.Z{Score: score, Member: reply[i]})
+	}
+	return claimed, nil
+}
+
+// Get decodes the task's member without removing it.
+func (s *Scheduler) Get(ctx context.Context, taskID string) (*entity.Task, error) {
+	_, dto, _, err := s.findMember(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	return toEntity(dto), nil
+}
+
+// Dequeue removes the task's member from the sorted set. A member removed
+// concurrently by FetchDue is reported as not found.
+func (s *Scheduler) Dequeue(ctx context.Context, taskID string) (*entity.Task, time.Time, error) {
+	member, dto, score, err := s.findMember(ctx, taskID)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	removed, err := s.client.ZRem(ctx, s.key, member).Result()
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("removing task from redis: %w", err)
+	}
+	if removed == 0 {
+		return nil, time.Time{}, domain.ErrTaskNotFound
+	}
+	s.replica.remove(s.key, member)
+	task := toEntity(dto)
+	s.unindex(ctx, task, member)
+	return task, scoreTime(score), nil
+}
+
+// Reschedule updates the score of the task's member in place with ZADD XX,
+// so the task never leaves the schedule, and returns its old and new due
+// times. A shift is applied with INCR, relative to the score at the time
+// of the write. A member removed concurrently by FetchDue is reported as
+// not found.
+func (s *Scheduler) Reschedule(ctx context.Context, taskID string, change entity.Reschedule) (time.Time, time.Time, error) {
+	member, dto, score, err := s.findMember(ctx, taskID)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	var next float64
+	if change.At.IsZero() {
+		next, err = s.client.ZAddArgsIncr(ctx, s.key, redis.ZAddArgs{
+			XX:      true,
+			Members: []redis.Z{{Score: change.By.Seconds(), Member: member}},
+		}).Result()
+		if errors.Is(err, redis.Nil) {
+			return time.Time{}, time.Time{}, domain.ErrTaskNotFound
+		}
+		score = next - change.By.Seconds()
+	} else {
+		next = scoreOf(change.At)
+		var changed int64
+		changed, err = s.client.ZAddArgs(ctx, s.key, redis.ZAddArgs{
+			XX:      true,
+			Ch:      true,
+			Members: []redis.Z{{Score: next, Member: member}},
+		}).Result()
+		if err == nil && changed == 0 && next != score {
+			return time.Time{}, time.Time{}, domain.ErrTaskNotFound
+		}
+	}
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("rescheduling task in redis: %w", err)
+	}
+
+	z := redis.Z{Score: next, Member: member}
+	s.replica.update(s.key, z)
+	s.reindex(ctx, []redis.Z{z}, map[string]string{member: destinationIndexKey(toEntity(dto).Destination.Name())})
+
+	s.logger.Info("task rescheduled",
+		zap.String("task_id", taskID),
+		zap.Float64("previous_score", score),
+		zap.Float64("score", next),
+	)
+	return scoreTime(score), scoreTime(next), nil
+}
+
+// findMember scans the sorted set for the task's member. Members are
+// matched on their encoded ID first, in both task encodings, and confirmed
+// after decoding.
+func (s *Scheduler) findMember(ctx context.Context, taskID string) (string, taskDTO, float64, error) {
+	quotedID, err := json.Marshal(taskID)
+	if err != nil {
+		return "", taskDTO{}, 0, fmt.Errorf("encoding task id: %w", err)
+	}
+	protoPrefix := appendString([]byte{protobufTaskVersion}, taskFieldID, taskID)
+
+	for _, pattern := range []string{
+		`*"id":` + globEscape(string(quotedID)) + `*`,
+		globEscape(string(protoPrefix)) + `*`,
+	} {
+		iter := s.client.ZScan(ctx, s.key, 0, pattern, 100).Iterator()
+		for iter.Next(ctx) {
+			member := iter.Val()
+			if !iter.Next(ctx) {
+				break
+			}
+			score, err := strconv.ParseFloat(iter.Val(), 64)
+			if err != nil {
+				continue
+			}
+
+			dto, err := decodeTask([]byte(member))
+			if err != nil || dto.ID != taskID {
+				continue
+			}
+			return member, dto, score, nil
+		}
+		if err := iter.Err(); err != nil {
+			return "", taskDTO{}, 0, fmt.Errorf("scanning scheduled tasks in redis: %w", err)
+		}
+	}
+
+	return "", taskDTO{}, 0, domain.ErrTaskNotFound
+}
+
+// Peek reads the lowest-scored members after the cursor. Undecodable
+// members are skipped.
+func (s *Scheduler) Peek(ctx context.Context, after entity.Cursor, limit int) ([]entity.ScheduledTask, entity.Cursor, error) {
+	results, next, err := rangeAfter(ctx, s.client, s.key, after, limit, false)
+	if err != nil {
+		return nil, entity.Cursor{}, fmt.Errorf("reading scheduled tasks from redis: %w", err)
+	}
+
+	scheduled := make([]entity.ScheduledTask, 0, len(results))
+	for _, z := range results {
+		member, ok := z.Member.(string)
+		if !ok {
+			continue
+		}
+		dto, err := decodeTask([]byte(member))
+		if err != nil {
+			continue
+		}
+		scheduled = append(scheduled, entity.ScheduledTask{
+			Task:  toEntity(dto),
+			DueAt: scoreTime(z.Score),
+		})
+	}
+	return scheduled, next, nil
+}
+
+// CountDueBy issues one ZCOUNT per time in a single pipeline.
+func (s *Scheduler) CountDueBy(ctx context.Context, times []time.Time) ([]int64, error) {
+	cmds := make([]*redis.IntCmd, len(times))
+	_, err := s.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, t := range times {
+			cmds[i] = pipe.ZCount(ctx, s.key, "-inf", scoreArg(t))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("counting due tasks in redis: %w", err)
+	}
+
+	counts := make([]int64, len(cmds))
+	for i, cmd := range cmds {
+		counts[i] = cmd.Val()
+	}
+	return counts, nil
+}
+
+// shiftBatchSize bounds the members read or updated per Redis round trip
+// during a Shift.
+const shiftBatchSize = 500
+
+// Shift collects matching members within the filter's due range first and
+// then updates their scores with batched ZADD XX calls, so moved members
+// are not revisited and members fetched meanwhile are not re-added.
+func (s *Scheduler) Shift(ctx context.Context, filter entity.TaskFilter, by time.Duration) (int, error) {
+	minScore, maxScore := "-inf", "+inf"
+	if !filter.DueAfter.IsZero() {
+		minScore = scoreArg(filter.DueAfter)
+	}
+	if !filter.DueBefore.IsZero() {
+		maxScore = scoreArg(filter.DueBefore)
+	}
+
+	var matched []redis.Z
+	// indexed maps each matched member's destination index key.
+	indexed := make(map[string]string)
+	for offset := int64(0); ; offset += shiftBatchSize {
+		page, err := s.client.ZRangeByScoreWithScores(ctx, s.key, &redis.ZRangeBy{
+			Min:    minScore,
+			Max:    maxScore,
+			Offset: offset,
+			Count:  shiftBatchSize,
+		}).Result()
+		if err != nil {
+			return 0, fmt.Errorf("reading tasks to shift from redis: %w", err)
+		}
+
+		for _, z := range page {
+			member, ok := z.Member.(string)
+			if !ok {
+				continue
+			}
+			dto, err := decodeTask([]byte(member))
+			if err != nil {
+				continue
+			}
+			if task := toEntity(dto); filter.MatchesTask(task) {
+				matched = append(matched, redis.Z{Score: z.Score + by.Seconds(), Member: member})
+				indexed[member] = destinationIndexKey(task.Destination.Name())
+			}
+		}
+		if len(page) < shiftBatchSize {
+			break
+		}
+	}
+
+	shifted := 0
+	for start := 0; start < len(matched); start += shiftBatchSize {
+		end := min(start+shiftBatchSize, len(matched))
+		// ZADD XX CH only touches members that still exist and counts
+		// the ones whose score changed.
+		n, err := s.client.ZAddArgs(ctx, s.key, redis.ZAddArgs{
+			XX:      true,
+			Ch:      true,
+			Members: matched[start:end],
+		}).Result()
+		if err != nil {
+			return shifted, fmt.Errorf("shifting tasks in redis: %w", err)
+		}
+		shifted += int(n)
+		s.replica.update(s.key, matched[start:end]...)
+		s.reindex(ctx, matched[start:end], indexed)
+	}
+
+	s.logger.Info("tasks shifted",
+		zap.String("client_id", filter.ClientID),
+		zap.String("destination", filter.Destination),
+		zap.Duration("by", by),
+		zap.Int("count", shifted),
+	)
+	return shifted, nil
+}
+
+// globEscape escapes the Redis glob metacharacters in s. It works on bytes
+// so binary-encoded members are escaped correctly too.
+func globEscape(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '*', '?', '[', ']', '\\':
+			b.WriteByte('\\')
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
 }
 
 // Remove deletes a specific member from the sorted set.
 func (s *Scheduler) Remove(ctx context.Context, rawMember string) error {
-	return s.client.ZRem(ctx, s.key, rawMember).Err()
+	if err := s.client.ZRem(ctx, s.key, rawMember).Err(); err != nil {
+		return err
+	}
+	s.replica.remove(s.key, rawMember)
+	if dto, err := decodeTask([]byte(rawMember)); err == nil {
+		s.unindex(ctx, toEntity(dto), rawMember)
+	}
+	return nil
+}
+
+// FindByDestination reads the destination's index page by page, keeping
+// the members still in the schedule and dropping stale ones from the index.
+func (s *Scheduler) FindByDestination(ctx context.Context, destination string, limit int) ([]entity.ScheduledTask, error) {
+	indexKey := destinationIndexKey(destination)
+	pageSize := int64(max(limit, 100))
+
+	scheduled := make([]entity.ScheduledTask, 0, limit)
+	for offset := int64(0); len(scheduled) < limit; offset += pageSize {
+		page, err := s.client.ZRange(ctx, indexKey, offset, offset+pageSize-1).Result()
+		if err != nil {
+			return nil, fmt.Errorf("reading destination index from redis: %w", err)
+		}
+
+		scores := make([]*redis.FloatCmd, len(page))
+		if _, err := s.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+			for i, member := range page {
+				scores[i] = pipe.ZScore(ctx, s.key, member)
+			}
+			return nil
+		}); err != nil && !errors.Is(err, redis.Nil) {
+			return nil, fmt.Errorf("checking indexed tasks in redis: %w", err)
+		}
+
+		var stale []interface{}
+		for i, member := range page {
+			score, err := scores[i].Result()
+			if errors.Is(err, redis.Nil) {
+				stale = append(stale, member)
+				continue
+			}
+			dto, err := decodeTask([]byte(member))
+			if err != nil {
+				continue
+			}
+			scheduled = append(scheduled, entity.ScheduledTask{
+				Task:  toEntity(dto),
+				DueAt: scoreTime(score),
+			})
+			if len(scheduled) == limit {
+				break
+			}
+		}
+
+		if len(stale) > 0 {
+			if err := s.client.ZRem(ctx, indexKey, stale...).Err(); err != nil {
+				s.logger.Warn("failed to drop stale destination index entries", zap.Error(err))
+			}
+			// The removed entries shifted the rest of the index down.
+			offset -= int64(len(stale))
+		}
+		if int64(len(page)) < pageSize {
+			break
+		}
+	}
+	return scheduled, nil
+}
+
+// Count returns the size of the scheduled set. Tasks waiting behind an
+// ordering key are not in it and so are not counted.
+func (s *Scheduler) Count(ctx context.Context) (int64, error) {
+	n, err := s.client.ZCard(ctx, domain.RedisRetryKey).Result()
+	if err != nil {
+		return 0, fmt.Errorf("counting scheduled tasks in redis: %w", err)
+	}
+	return n, nil
+}
+
+// CountByDestination returns the size of the destination's index. It may
+// include stale entries not yet dropped by a lookup or the janitor.
+func (s *Scheduler) CountByDestination(ctx context.Context, destination string) (int64, error) {
+	n, err := s.client.ZCard(ctx, destinationIndexKey(destination)).Result()
+	if err != nil {
+		return 0, fmt.Errorf("counting destination index in redis: %w", err)
+	}
+	return n, nil
+}
+
+// destinationIndexKey returns the index key for a destination URL or topic.
+func destinationIndexKey(destination string) string {
+	return domain.RedisDestinationIndexKeyPrefix + destination
+}
+
+// unindex drops member from the task's destination index. A failure only
+// leaves a stale entry, which is cleaned up when the index is read.
+func (s *Scheduler) unindex(ctx context.Context, task *entity.Task, member string) {
+	key := destinationIndexKey(task.Destination.Name())
+	if err := s.client.ZRem(ctx, key, member).Err(); err != nil {
+		s.logger.Warn("failed to remove task from destination index",
+			zap.Error(err),
+			zap.String("task_id", task.ID),
+		)
+	}
+	s.replica.remove(key, member)
+}
+
+// reindex updates the index scores of shifted members. ZADD XX leaves
+// members that have since been fetched out of the index.
+func (s *Scheduler) reindex(ctx context.Context, members []redis.Z, indexed map[string]string) {
+	byKey := make(map[string][]redis.Z)
+	for _, z := range members {
+		key := indexed[z.Member.(string)]
+		byKey[key] = append(byKey[key], z)
+	}
+	if _, err := s.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for key, zs := range byKey {
+			pipe.ZAddArgs(ctx, key, redis.ZAddArgs{XX: true, Members: zs})
+			s.replica.update(key, zs...)
+		}
+		return nil
+	}); err != nil {
+		s.logger.Warn("failed to update destination index after rescheduling", zap.Error(err))
+	}
 }
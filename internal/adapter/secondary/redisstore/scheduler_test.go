@@ -0,0 +1,239 @@
+package redisstore
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+// newTestRedis starts an in-memory Redis for the test.
+func newTestRedis(t *testing.T) (*miniredis.Miniredis, redis.UniversalClient) {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return mr, client
+}
+
+func TestScoreTime(t *testing.T) {
+	due := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC).Add(1250 * time.Millisecond)
+	if got := scoreTime(scoreOf(due)); !got.Equal(due) {
+		t.Fatalf("expected %s, got %s", due, got)
+	}
+
+	// Scores written before millisecond fractions are whole seconds.
+	if got := scoreTime(1700000000); !got.Equal(time.Unix(1700000000, 0)) {
+		t.Fatalf("expected %s, got %s", time.Unix(1700000000, 0), got)
+	}
+}
+
+func TestTaskDTO_httpDestination(t *testing.T) {
+	task := &entity.Task{
+		ID:              "task-1",
+		Attempt:         2,
+		DestinationType: entity.DestinationTypeHTTP,
+		Destination:     entity.Destination{URL: "https://partner.example.com/hooks?id=1"},
+		DeadDestination: entity.Destination{URL: "https://hooks.slack.com/services/T0/B0/X", Format: entity.PayloadFormatSlack},
+		FallbackDeadDestinations: []entity.Destination{
+			{URL: "https://audit.example.com/dead"},
+		},
+		MaxRetries: 5,
+		BaseDelay:  time.Second,
+	}
+
+	for _, encoding := range []TaskEncoding{TaskEncodingJSON, TaskEncodingProtobuf} {
+		t.Run(string(encoding), func(t *testing.T) {
+			data, err := encodeTask(toDTO(task), encoding)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			dto, err := decodeTask(data)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			got := toEntity(dto)
+			if got.Destination != task.Destination || got.DeadDestination != task.DeadDestination {
+				t.Fatalf("destinations lost: got %+v and %+v", got.Destination, got.DeadDestination)
+			}
+			if len(got.FallbackDeadDestinations) != 1 || got.FallbackDeadDestinations[0] != task.FallbackDeadDestinations[0] {
+				t.Fatalf("fallback destinations lost: %+v", got.FallbackDeadDestinations)
+			}
+			if got.Destination.Type() != entity.DestinationTypeHTTP {
+				t.Fatalf("expected an HTTP destination, got %q", got.Destination.Type())
+			}
+		})
+	}
+
+	// Records written before schema versioning carry the URL too.
+	dto, err := decodeTask([]byte(`{"id":"task-1","destination":{"host":"","port":"","topic":"","url":"http://localhost/hook"},"destination_type":"http"}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := toEntity(dto).Destination.URL; got != "http://localhost/hook" {
+		t.Fatalf("expected the unversioned record's URL, got %q", got)
+	}
+}
+
+func TestComposeBatch(t *testing.T) {
+	members := func(prefix string, scores ...float64) []redis.Z {
+		zs := make([]redis.Z, len(scores))
+		for i, score := range scores {
+			zs[i] = redis.Z{Score: score, Member: prefix + strconv.Itoa(i)}
+		}
+		return zs
+	}
+
+	tests := []struct {
+		name     string
+		priority []redis.Z
+		normal   []redis.Z
+		quota    int
+		want     []string
+	}{
+		{
+			name:     "priority flood keeps the normal share",
+			priority: members("p", 1, 2, 3, 4),
+			normal:   members("n", 5, 6, 7, 8),
+			quota:    1,
+			want:     []string{"p0", "n0", "n1", "n2"},
+		},
+		{
+			name:     "priority fills in for missing normal tasks",
+			priority: members("p", 1, 2, 3, 4),
+			normal:   members("n", 5),
+			quota:    1,
+			want:     []string{"p0", "p1", "p2", "n0"},
+		},
+		{
+			name:     "normal fills in for missing priority tasks",
+			priority: members("p", 9),
+			normal:   members("n", 1, 2, 3, 4, 5),
+			quota:    2,
+			want:     []string{"n0", "n1", "n2", "p0"},
+		},
+		{
+			name:   "no share takes normal tasks by due time",
+			normal: members("n", 1, 2, 3, 4, 5),
+			want:   []string{"n0", "n1", "n2", "n3"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			batch := composeBatch(tt.priority, tt.normal, 4, tt.quota)
+
+			got := make([]string, len(batch))
+			for i, z := range batch {
+				got[i] = z.Member.(string)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestScheduler_FetchDue_lowLatency(t *testing.T) {
+	ctx := context.Background()
+	mr, client := newTestRedis(t)
+	scheduler := NewScheduler(client, zap.NewNop(), WithLowLatency(true))
+
+	for i, delay := range []time.Duration{-time.Second, -time.Millisecond, time.Hour} {
+		task := &entity.Task{
+			ID:              "task-" + strconv.Itoa(i),
+			Source:          "test",
+			DestinationType: entity.DestinationTypeHTTP,
+			Destination:     entity.Destination{URL: "http://localhost/hook"},
+		}
+		if err := scheduler.Schedule(ctx, task, delay); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	// A failed claim takes nothing out of the schedule.
+	mr.SetError("ERR unavailable")
+	if _, err := scheduler.FetchDue(ctx, "", 10); err == nil {
+		t.Fatal("expected an error")
+	}
+	mr.SetError("")
+	if count, err := scheduler.Count(ctx); err != nil || count != 3 {
+		t.Fatalf("expected 3 scheduled tasks, got %d (%v)", count, err)
+	}
+
+	tasks, err := scheduler.FetchDue(ctx, "", 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(tasks) != 2 || tasks[0].ID != "task-0" || tasks[1].ID != "task-1" {
+		t.Fatalf("expected the two due tasks in due order, got %+v", tasks)
+	}
+	if count, err := scheduler.Count(ctx); err != nil || count != 1 {
+		t.Fatalf("expected the task not due to stay scheduled, got %d (%v)", count, err)
+	}
+}
+
+func TestScheduler_FetchDue_namedQueues(t *testing.T) {
+	ctx := context.Background()
+	_, client := newTestRedis(t)
+	scheduler := NewScheduler(client, zap.NewNop(), WithNamedQueues(true), WithTaskEncoding(TaskEncodingProtobuf))
+
+	for i, queue := range []string{"", "payments", "newsletter", "payments"} {
+		task := &entity.Task{
+			ID:              "task-" + strconv.Itoa(i),
+			Source:          "test",
+			DestinationType: entity.DestinationTypeHTTP,
+			Destination:     entity.Destination{URL: "http://localhost/hook"},
+			Queue:           queue,
+		}
+		if err := scheduler.Schedule(ctx, task, -time.Duration(4-i)*time.Second); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	tasks, err := scheduler.FetchDue(ctx, "payments", 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(tasks) != 2 || tasks[0].ID != "task-1" || tasks[1].ID != "task-3" || tasks[0].Queue != "payments" {
+		t.Fatalf("expected the two payments tasks, got %+v", tasks)
+	}
+
+	tasks, err = scheduler.FetchDue(ctx, "", 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(tasks) != 1 || tasks[0].ID != "task-0" {
+		t.Fatalf("expected only the default queue task, got %+v", tasks)
+	}
+	if count, err := scheduler.Count(ctx); err != nil || count != 1 {
+		t.Fatalf("expected the newsletter task to stay scheduled, got %d (%v)", count, err)
+	}
+}
+
+func TestScheduler_CountDueBy_subsecond(t *testing.T) {
+	ctx := context.Background()
+	_, client := newTestRedis(t)
+	scheduler := NewScheduler(client, zap.NewNop())
+
+	second := time.Date(2030, 1, 1, 12, 0, 0, 0, time.UTC)
+	if err := client.ZAdd(ctx, scheduler.(*Scheduler).key, redis.Z{Score: scoreOf(second.Add(300 * time.Millisecond)), Member: "{}"}).Err(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	counts, err := scheduler.CountDueBy(ctx, []time.Time{second.Add(200 * time.Millisecond), second.Add(500 * time.Millisecond)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if counts[0] != 0 || counts[1] != 1 {
+		t.Fatalf("expected the task due between the two times, got %v", counts)
+	}
+}
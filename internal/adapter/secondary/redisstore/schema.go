@@ -0,0 +1,83 @@
+package redisstore
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// taskUpgrades[v] upgrades a JSON task record from schema version v to
+// v+1, so the current version is len(taskUpgrades). Records are upgraded
+// in memory when read and written back at the current version the next
+// time they are scheduled, so queues never need draining for a schema
+// change. To evolve the task shape, bump the DTO and append an upgrade;
+// never edit a released one.
+//
+// Protobuf records are decoded by field number, so renames need no upgrade
+// there; an upgrade that derives new values must also handle them.
+var taskUpgrades = []func(record map[string]json.RawMessage) error{
+	// 0 -> 1: records written before versioning have the same shape.
+	func(map[string]json.RawMessage) error { return nil },
+}
+
+func currentSchemaVersion() int {
+	return len(taskUpgrades)
+}
+
+// UnmarshalJSON decodes a task record at any schema version, upgrading older
+// records to the current shape. This applies wherever a task is embedded,
+// including quarantine, dead-letter and cancelled entries.
+func (d *taskDTO) UnmarshalJSON(data []byte) error {
+	type plain taskDTO
+
+	err := json.Unmarshal(data, (*plain)(d))
+	if err == nil && d.SchemaVersion >= currentSchemaVersion() {
+		return nil
+	}
+
+	// Older records may not fit the current types, so read the version on
+	// its own before upgrading.
+	var header struct {
+		SchemaVersion int `json:"schema_version"`
+	}
+	if json.Unmarshal(data, &header) != nil || header.SchemaVersion >= currentSchemaVersion() {
+		return err
+	}
+
+	upgraded, err := upgradeTaskRecord(data, header.SchemaVersion)
+	if err != nil {
+		return err
+	}
+	*d = taskDTO{}
+	return json.Unmarshal(upgraded, (*plain)(d))
+}
+
+// upgradeTaskRecord applies every upgrade from version onwards to a JSON
+// task record.
+func upgradeTaskRecord(data []byte, version int) ([]byte, error) {
+	var record map[string]json.RawMessage
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, fmt.Errorf("decoding task record: %w", err)
+	}
+
+	for v := version; v < currentSchemaVersion(); v++ {
+		if err := taskUpgrades[v](record); err != nil {
+			return nil, fmt.Errorf("upgrading task record from schema version %d: %w", v, err)
+		}
+	}
+
+	record["schema_version"], _ = json.Marshal(currentSchemaVersion())
+	return json.Marshal(record)
+}
+
+// renameField moves a record field to a new name, keeping an existing value
+// under the new name. It is a helper for upgrades.
+func renameField(record map[string]json.RawMessage, from, to string) {
+	value, ok := record[from]
+	if !ok {
+		return
+	}
+	delete(record, from)
+	if _, exists := record[to]; !exists {
+		record[to] = value
+	}
+}
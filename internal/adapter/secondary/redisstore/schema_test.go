@@ -0,0 +1,52 @@
+package redisstore
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestTaskDTO_upgradesOlderRecords(t *testing.T) {
+	saved := taskUpgrades
+	defer func() { taskUpgrades = saved }()
+
+	// Version 2 renames the hypothetical "payload" field to "message_data".
+	taskUpgrades = append(append([]func(map[string]json.RawMessage) error{}, saved...),
+		func(record map[string]json.RawMessage) error {
+			renameField(record, "payload", "message_data")
+			return nil
+		})
+
+	tests := []struct {
+		name string
+		raw  string
+	}{
+		{"unversioned record", `{"id":"task-1","payload":"hello"}`},
+		{"version 1 record", `{"schema_version":1,"id":"task-1","payload":"hello"}`},
+		{"current record", `{"schema_version":2,"id":"task-1","message_data":"hello"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dto, err := decodeTask([]byte(tt.raw))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if dto.SchemaVersion != 2 {
+				t.Fatalf("expected schema version 2, got %d", dto.SchemaVersion)
+			}
+			if dto.ID != "task-1" || dto.MessageData != "hello" {
+				t.Fatalf("unexpected task after upgrade: %+v", dto)
+			}
+		})
+	}
+}
+
+func TestTaskDTO_upgradesEmbeddedRecords(t *testing.T) {
+	var entry cancelledDTO
+	if err := json.Unmarshal([]byte(`{"task":{"id":"task-1"}}`), &entry); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if entry.Task.SchemaVersion != currentSchemaVersion() {
+		t.Fatalf("expected schema version %d, got %d", currentSchemaVersion(), entry.Task.SchemaVersion)
+	}
+}
@@ -0,0 +1,117 @@
+package redisstore
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+// ShardRing splits client-hash shards among the live worker instances by
+// rendezvous hashing. Membership is the worker registry: an instance is
+// live while its last beat is within liveFor. Each instance records the
+// shards it owns in its stats hash, so /admin/workers shows the split.
+//
+// Instances see membership changes one poll apart, so while an instance
+// joins or leaves two of them may briefly both claim a shard. Claims stay
+// exclusive, since each task is still removed from the schedule once.
+type ShardRing struct {
+	client   redis.UniversalClient
+	instance string
+	statsKey string
+	shards   int
+	liveFor  time.Duration
+	logger   *zap.Logger
+	owned    []int // last computed, to log changes
+}
+
+// NewShardRing creates the shard ring for the named instance.
+func NewShardRing(client redis.UniversalClient, instance string, shards int, liveFor time.Duration, logger *zap.Logger) *ShardRing {
+	return &ShardRing{
+		client:   client,
+		instance: instance,
+		statsKey: domain.RedisWorkerStatsKeyPrefix + instance,
+		shards:   shards,
+		liveFor:  liveFor,
+		logger:   logger.Named("shard-ring"),
+	}
+}
+
+// Owner returns a function reporting whether a client's tasks belong to
+// the shards this instance currently owns.
+func (r *ShardRing) Owner(ctx context.Context) (func(clientID string) bool, error) {
+	beats, err := r.client.HGetAll(ctx, domain.RedisWorkersKey).Result()
+	if err != nil {
+		return nil, fmt.Errorf("reading worker registry from redis: %w", err)
+	}
+
+	owned := entity.OwnedShards(r.instance, liveMembers(beats, r.instance, time.Now(), r.liveFor), r.shards)
+	if !slices.Equal(owned, r.owned) {
+		r.logger.Info("shard assignment changed", zap.Ints("shards", owned))
+		r.owned = owned
+	}
+	if _, err := r.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, r.statsKey, workerStatsShards, formatShards(owned))
+		pipe.Expire(ctx, r.statsKey, domain.WorkerForgetAfter)
+		return nil
+	}); err != nil {
+		r.logger.Warn("failed to record shard assignment", zap.Error(err))
+	}
+
+	set := make(map[int]bool, len(owned))
+	for _, shard := range owned {
+		set[shard] = true
+	}
+	return func(clientID string) bool {
+		return set[entity.ShardOf(clientID, r.shards)]
+	}, nil
+}
+
+// liveMembers returns the instances that beat within liveFor, plus self,
+// which may not have beaten yet. Unparseable entries are left out.
+func liveMembers(beats map[string]string, self string, now time.Time, liveFor time.Duration) []string {
+	members := []string{self}
+	for instance, raw := range beats {
+		if instance == self {
+			continue
+		}
+		unix, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || now.Sub(time.Unix(unix, 0)) > liveFor {
+			continue
+		}
+		members = append(members, instance)
+	}
+	sort.Strings(members)
+	return members
+}
+
+// formatShards and parseShards convert the shards recorded in a stats hash.
+func formatShards(shards []int) string {
+	parts := make([]string, len(shards))
+	for i, shard := range shards {
+		parts[i] = strconv.Itoa(shard)
+	}
+	return strings.Join(parts, ",")
+}
+
+func parseShards(raw string) []int {
+	if raw == "" {
+		return nil
+	}
+	var shards []int
+	for _, part := range strings.Split(raw, ",") {
+		if shard, err := strconv.Atoi(part); err == nil {
+			shards = append(shards, shard)
+		}
+	}
+	return shards
+}
@@ -0,0 +1,36 @@
+package redisstore
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestLiveMembers(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	ago := func(d time.Duration) string {
+		return strconv.FormatInt(now.Add(-d).Unix(), 10)
+	}
+
+	beats := map[string]string{
+		"pod-c": ago(2 * time.Second),
+		"pod-a": ago(time.Minute),
+		"pod-b": ago(5 * time.Second),
+		"pod-d": "garbage",
+	}
+
+	members := liveMembers(beats, "pod-a", now, 15*time.Second)
+
+	if want := []string{"pod-a", "pod-b", "pod-c"}; !reflect.DeepEqual(members, want) {
+		t.Fatalf("members = %v, want %v", members, want)
+	}
+}
+
+func TestShardsRoundTrip(t *testing.T) {
+	for _, shards := range [][]int{nil, {0}, {2, 7, 11}} {
+		if got := parseShards(formatShards(shards)); !reflect.DeepEqual(got, shards) {
+			t.Fatalf("round trip of %v gave %v", shards, got)
+		}
+	}
+}
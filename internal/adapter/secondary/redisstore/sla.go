@@ -0,0 +1,109 @@
+package redisstore
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+	"github.com/ruudy-sib/rebound/internal/port/secondary"
+)
+
+// slaGroupDTO is the Redis representation of a client and destination pair.
+type slaGroupDTO struct {
+	ClientID    string `json:"client_id"`
+	Destination string `json:"destination"`
+}
+
+// SLAStore implements secondary.SLAStore with one capped list of
+// millisecond samples per client and destination, indexed by a hash.
+type SLAStore struct {
+	client    redis.UniversalClient
+	groupsKey string
+	prefix    string
+	size      int64
+	logger    *zap.Logger
+}
+
+// NewSLAStore creates a Redis-backed time-to-success sample store.
+func NewSLAStore(client redis.UniversalClient, logger *zap.Logger) secondary.SLAStore {
+	return &SLAStore{
+		client:    client,
+		groupsKey: domain.RedisSLAGroupsKey,
+		prefix:    domain.RedisSLASamplesKeyPrefix,
+		size:      domain.DefaultSLASampleSize,
+		logger:    logger.Named("redis-sla"),
+	}
+}
+
+// Record pushes the sample and trims the list to the retained size.
+func (s *SLAStore) Record(ctx context.Context, clientID, destination string, timeToSuccess time.Duration) error {
+	group, err := json.Marshal(slaGroupDTO{ClientID: clientID, Destination: destination})
+	if err != nil {
+		return fmt.Errorf("marshaling sla group: %w", err)
+	}
+	id := slaGroupID(group)
+
+	// The index and the list live in different slots, so no MULTI.
+	_, err = s.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, s.groupsKey, id, group)
+		pipe.LPush(ctx, s.prefix+id, timeToSuccess.Milliseconds())
+		pipe.LTrim(ctx, s.prefix+id, 0, s.size-1)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("recording time to success in redis: %w", err)
+	}
+	return nil
+}
+
+// Samples reads every indexed group's list.
+func (s *SLAStore) Samples(ctx context.Context) ([]secondary.TimeToSuccessSamples, error) {
+	groups, err := s.client.HGetAll(ctx, s.groupsKey).Result()
+	if err != nil {
+		return nil, fmt.Errorf("listing sla groups from redis: %w", err)
+	}
+
+	result := make([]secondary.TimeToSuccessSamples, 0, len(groups))
+	for id, raw := range groups {
+		var group slaGroupDTO
+		if err := json.Unmarshal([]byte(raw), &group); err != nil {
+			s.logger.Warn("invalid sla group in redis", zap.Error(err), zap.String("raw", raw))
+			continue
+		}
+
+		values, err := s.client.LRange(ctx, s.prefix+id, 0, -1).Result()
+		if err != nil {
+			return nil, fmt.Errorf("reading sla samples from redis: %w", err)
+		}
+
+		samples := make([]time.Duration, 0, len(values))
+		for _, v := range values {
+			ms, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				continue
+			}
+			samples = append(samples, time.Duration(ms)*time.Millisecond)
+		}
+
+		result = append(result, secondary.TimeToSuccessSamples{
+			ClientID:    group.ClientID,
+			Destination: group.Destination,
+			Samples:     samples,
+		})
+	}
+	return result, nil
+}
+
+// slaGroupID derives a key-safe identifier from the encoded group.
+func slaGroupID(group []byte) string {
+	sum := sha1.Sum(group)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,112 @@
+package redisstore
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+	"github.com/ruudy-sib/rebound/internal/port/secondary"
+)
+
+const (
+	usageDelivered = "delivered"
+	usageRetried   = "retried"
+)
+
+// UsageStore implements secondary.UsageStore with one hash per billing
+// period, holding a delivered and a retried counter per client.
+type UsageStore struct {
+	client redis.UniversalClient
+	prefix string
+	logger *zap.Logger
+}
+
+// NewUsageStore creates a Redis-backed usage store.
+func NewUsageStore(client redis.UniversalClient, logger *zap.Logger) secondary.UsageStore {
+	return &UsageStore{
+		client: client,
+		prefix: domain.RedisUsageKeyPrefix,
+		logger: logger.Named("redis-usage"),
+	}
+}
+
+// Add increments the counters and extends the retention of every period
+// touched.
+func (s *UsageStore) Add(ctx context.Context, usage []entity.ClientUsage) error {
+	if len(usage) == 0 {
+		return nil
+	}
+
+	// Periods live in different slots, so no MULTI.
+	_, err := s.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, u := range usage {
+			key := s.prefix + u.Period
+			if u.Delivered > 0 {
+				pipe.HIncrBy(ctx, key, usageField(u.ClientID, usageDelivered), u.Delivered)
+			}
+			if u.Retried > 0 {
+				pipe.HIncrBy(ctx, key, usageField(u.ClientID, usageRetried), u.Retried)
+			}
+			pipe.Expire(ctx, key, domain.UsageRetention)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("recording usage in redis: %w", err)
+	}
+	return nil
+}
+
+// Usage reads the period's hash.
+func (s *UsageStore) Usage(ctx context.Context, period string) ([]entity.ClientUsage, error) {
+	fields, err := s.client.HGetAll(ctx, s.prefix+period).Result()
+	if err != nil {
+		return nil, fmt.Errorf("reading usage from redis: %w", err)
+	}
+	return s.clientUsage(period, fields), nil
+}
+
+// clientUsage groups the counters of a period's hash by client. Malformed
+// fields are skipped.
+func (s *UsageStore) clientUsage(period string, fields map[string]string) []entity.ClientUsage {
+	byClient := make(map[string]*entity.ClientUsage)
+	for field, raw := range fields {
+		// Client IDs may contain colons; the counter name never does.
+		i := strings.LastIndexByte(field, ':')
+		count, err := strconv.ParseInt(raw, 10, 64)
+		if i < 0 || err != nil {
+			s.logger.Warn("invalid usage counter in redis", zap.String("field", field), zap.String("raw", raw))
+			continue
+		}
+
+		clientID := field[:i]
+		u, ok := byClient[clientID]
+		if !ok {
+			u = &entity.ClientUsage{ClientID: clientID, Period: period}
+			byClient[clientID] = u
+		}
+		switch field[i+1:] {
+		case usageDelivered:
+			u.Delivered = count
+		case usageRetried:
+			u.Retried = count
+		}
+	}
+
+	usage := make([]entity.ClientUsage, 0, len(byClient))
+	for _, u := range byClient {
+		usage = append(usage, *u)
+	}
+	return usage
+}
+
+// usageField names a client's counter in a period's hash.
+func usageField(clientID, counter string) string {
+	return clientID + ":" + counter
+}
@@ -0,0 +1,38 @@
+package redisstore
+
+import (
+	"sort"
+	"testing"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+func TestUsageStore_clientUsage(t *testing.T) {
+	s := &UsageStore{logger: zap.NewNop()}
+
+	usage := s.clientUsage("2024-05", map[string]string{
+		"billing:delivered":      "40",
+		"billing:retried":        "3",
+		"tenant:42:delivered":    "7",
+		"notifier:retried":       "1",
+		"malformed":              "5",
+		"billing:delivered:nope": "x",
+	})
+	sort.Slice(usage, func(i, j int) bool { return usage[i].ClientID < usage[j].ClientID })
+
+	want := []entity.ClientUsage{
+		{ClientID: "billing", Period: "2024-05", Delivered: 40, Retried: 3},
+		{ClientID: "notifier", Period: "2024-05", Retried: 1},
+		{ClientID: "tenant:42", Period: "2024-05", Delivered: 7},
+	}
+	if len(usage) != len(want) {
+		t.Fatalf("expected %d clients, got %+v", len(want), usage)
+	}
+	for i := range want {
+		if usage[i] != want[i] {
+			t.Fatalf("client %d: expected %+v, got %+v", i, want[i], usage[i])
+		}
+	}
+}
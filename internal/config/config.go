@@ -2,12 +2,40 @@ package config
 
 import (
 	"os"
+	"strconv"
 	"strings"
 	"time"
 )
 
+// RedrivePolicy re-schedules tasks that exhausted their retries after
+// Cooldown with MaxRetries more retries, at most MaxRedrives times.
+type RedrivePolicy struct {
+	Cooldown    time.Duration
+	MaxRetries  int
+	MaxRedrives int
+}
+
+// QueuePolicy polls a named queue every PollInterval, fetching up to
+// BatchSize due tasks, with a share of the delivery concurrency set by
+// Weight relative to the default queue's 1.
+type QueuePolicy struct {
+	PollInterval time.Duration
+	BatchSize    int
+	Weight       int
+}
+
+// Run modes select which parts of the service a process runs.
+const (
+	RunModeAll    = "all"    // the HTTP API and the worker
+	RunModeAPI    = "api"    // the HTTP API only; due tasks are not polled
+	RunModeWorker = "worker" // the worker only; HTTP serves health and stats endpoints
+)
+
 // Config holds all application configuration values.
 type Config struct {
+	// Process role
+	RunMode string // "all" (default), "api" or "worker"
+
 	// HTTP server
 	HTTPAddr string
 
@@ -19,13 +47,142 @@ type Config struct {
 	RedisMasterName    string   // sentinel: master name
 	RedisSentinelAddrs []string // sentinel: sentinel node addresses
 	RedisClusterAddrs  []string // cluster: cluster node addresses
+	TaskEncoding       string   // "json" (default) or "protobuf" for newly stored tasks
+
+	// Cross-region replication of the schedule
+	ReplicaRedisAddr     string // standby Redis (standalone) the schedule is mirrored to; empty disables
+	ReplicaRedisPassword string
+
+	// Online migration of the schedule to another Redis deployment
+	MigrationTargetRedisAddr         string   // target Redis (standalone); empty disables unless cluster addrs are set
+	MigrationTargetRedisClusterAddrs []string // target Redis Cluster node addresses
+	MigrationTargetRedisPassword     string
 
 	// Kafka
 	KafkaBrokers []string
 
+	// Task creation requests consumed from Kafka
+	IngestTopic   string // topic of JSON task requests to schedule; empty disables ingestion
+	IngestGroupID string // consumer group of the ingesters
+
 	// Worker
-	PollInterval time.Duration
-	BatchSize    int
+	PollInterval  time.Duration // how long the worker waits between polls
+	BatchSize     int           // due tasks fetched per poll
+	PriorityShare int           // percent of each batch priority tasks may take while normal tasks are due; 0 disables
+	InstanceID    string        // identifies this replica's worker heartbeat; defaults to the hostname
+	ShardCount    int           // client-hash shards split among live workers; 0 disables sharding
+	DrainTimeout  time.Duration // how long deliveries in progress at shutdown may finish
+
+	// Named queues polled on their own schedule, keyed by queue name
+	Queues map[string]QueuePolicy
+
+	DeliveryConcurrency  int           // due tasks (or HTTP batches) delivered at once per poll
+	DeliveryTimeout      time.Duration // bounds each delivery attempt
+	ReclaimAfterTimeouts int           // delivery timeouts a task may stay in flight before it is reclaimed
+
+	// Task validation
+	MinBaseDelay  time.Duration // smallest base_delay new tasks may use
+	MaxBaseDelay  time.Duration // largest base_delay new tasks may use
+	MaxRetryLimit int           // largest max_retries new tasks may use
+
+	// Generated task IDs
+	IDStrategy string // "uuidv7", "ulid" or "snowflake" to generate missing task IDs; empty requires an ID
+	IDNodeID   int    // this instance's node ID in snowflake IDs, 0 to 1023
+
+	// Retries
+	RetryJitter   string        // "none" (default), "full", "equal" or "decorrelated"
+	MaxRetryDelay time.Duration // caps the retry delays of tasks without their own max_delay; 0 disables
+
+	// Backpressure
+	MaxPendingTasks int64  // scheduled tasks above which new tasks overflow; 0 disables
+	OverflowPolicy  string // "reject" (default), "drop-oldest" or "spill"
+
+	// State repair
+	JanitorInterval time.Duration // how often indexes and registries are reconciled
+
+	// Poison message detection
+	PoisonThreshold     int           // consecutive identical fast failures before quarantine; 0 disables
+	PoisonFailureWindow time.Duration // attempts failing faster than this count as instant failures
+
+	// Dead-letter delivery
+	DeadLetterMaxAttempts int           // produce attempts before falling back to the Redis dead-letter store
+	DeadLetterBackoff     time.Duration // initial wait between dead-letter produce attempts
+	DeadLetterMode        string        // "produce" (default), "store" or "both"
+
+	// Automatic re-drive of exhausted tasks, keyed by dead destination (URL or topic)
+	DeadLetterRedrive map[string]RedrivePolicy
+
+	// Duplicate delivery protection
+	CompletionMarkerTTL time.Duration // how long delivered task IDs are remembered
+
+	// Proof of delivery
+	DeliveryResultTTL time.Duration // how long results of successful deliveries are kept; 0 disables recording
+
+	// Retention of terminal task records, enforced by the janitor; 0 keeps them
+	DeadLetterRetention time.Duration // how long tasks in the Redis dead-letter store are kept
+	QuarantineRetention time.Duration // how long quarantined poison tasks are kept
+
+	// Cancellation
+	CancelledTaskTTL time.Duration // how long cancelled tasks can be restored
+
+	// HTTP delivery
+	HTTPBatchSize int // max due tasks per URL coalesced into one request; 0 or 1 disables batching
+
+	// HTTP statuses worth retrying, e.g. "408", "429" or "5xx"; failures with
+	// any other status are dead-lettered at once. Empty retries every status.
+	HTTPRetryableStatuses []string
+
+	// HTTP delivery size limits
+	HTTPMaxRequestBytes  int // largest request body an HTTP delivery sends
+	HTTPMaxResponseBytes int // bytes of a destination's response body read
+
+	// HTTP delivery name resolution
+	HTTPDNSCacheTTL   time.Duration     // how long DNS answers for HTTP destinations are cached; 0 disables
+	HTTPHostOverrides map[string]string // destination hostname -> hostname or IP dialed instead
+
+	// HTTP delivery egress policy, also applied to health probes
+	HTTPEgressDenyPrivate bool     // refuse private, loopback and other non-public addresses
+	HTTPEgressAllow       []string // CIDRs, IPs, hostnames and *.domain wildcards; when set, nothing else is dialed
+
+	// Destination health probing
+	ProbeBacklogThreshold int           // scheduled tasks for a destination above which it is probed; 0 disables
+	ProbeInterval         time.Duration // how often such a destination is probed
+	ProbeTimeout          time.Duration // each probe fails after this
+	ProbeReleaseRate      int           // due tasks delivered in the first poll after a destination recovers
+
+	// Retry storm throttling
+	RetryStormThreshold  int           // retries scheduled for a destination within the window that throttle it; 0 disables
+	RetryStormWindow     time.Duration // window the retries are counted over
+	RetryStormMultiplier int           // how many times longer retries to a throttled destination wait
+
+	// Delivery SLA
+	SLAThreshold time.Duration // time from creation to delivery above which a breach is reported; 0 disables
+	SLABreachURL string        // optional webhook receiving SLA breach events
+
+	// Usage tracking for billing
+	UsageEventsTopic string // Kafka topic receiving per-client usage events; empty disables them
+
+	// Terminal state notifications
+	NotifyChannel string // Redis Pub/Sub channel announcing delivered, dead-lettered and quarantined tasks; empty disables it
+
+	// Lifecycle events
+	LifecycleWebhookURLs []string // subscribers receiving dead-letter, quarantine and queue alarm events
+
+	// Health checks
+	HealthCheckTimeout  time.Duration // each /health and /readyz check fails after this
+	HealthCheckCacheTTL time.Duration // how long a health check result is reused; 0 disables caching
+
+	// Keeping payloads out of log storage
+	RedactFields                []string // JSONPath expressions of payload fields masked in dead-letter messages
+	DeliveryLogSampleFirst      int      // per-task logs with the same message logged each second before sampling; 0 disables sampling
+	DeliveryLogSampleThereafter int      // after that, every Nth such log is kept
+
+	// API authorization
+	SourceAllowlist string // API keys and the Source values each may use, as "key-a:billing,invoices;key-b:notifier"; empty disables API key checks
+
+	// Request signing
+	RequestSigningSecrets     map[string]string // caller ID -> HMAC secret; empty disables request signing
+	RequestSignatureTolerance time.Duration     // how far a signed request's timestamp may be from the server clock
 
 	// Application
 	Environment string
@@ -35,16 +192,107 @@ type Config struct {
 // New creates a Config populated from environment variables with sensible defaults.
 func New() *Config {
 	cfg := &Config{
+		RunMode:       getEnv("RUN_MODE", RunModeAll),
 		HTTPAddr:      getEnv("HTTP_ADDR", ":8080"),
 		RedisMode:     getEnv("REDIS_MODE", "standalone"),
 		RedisAddr:     getEnv("REDIS_HOST", "localhost") + ":" + getEnv("REDIS_PORT", "6379"),
 		RedisPassword: getEnv("REDIS_PASSWORD", ""),
 		RedisDB:       0,
+		TaskEncoding:  getEnv("TASK_ENCODING", "json"),
 		KafkaBrokers:  strings.Split(getEnv("KAFKA_BROKERS", "localhost:9092"), ","),
-		PollInterval:  1 * time.Second,
-		BatchSize:     10,
+		PollInterval:  getEnvDuration("POLL_INTERVAL", 1*time.Second),
+		BatchSize:     getEnvInt("BATCH_SIZE", 10),
+		PriorityShare: getEnvInt("PRIORITY_SHARE", 0),
+		InstanceID:    getEnv("INSTANCE_ID", hostname()),
+		ShardCount:    getEnvInt("SHARD_COUNT", 0),
+		DrainTimeout:  getEnvDuration("DRAIN_TIMEOUT", 10*time.Second),
 		Environment:   getEnv("ENVIRONMENT", "local"),
 		LogLevel:      getEnv("LOG_LEVEL", "info"),
+
+		Queues: parseQueues(getEnv("QUEUES", "")),
+
+		DeliveryConcurrency:  getEnvInt("DELIVERY_CONCURRENCY", 10),
+		DeliveryTimeout:      getEnvDuration("DELIVERY_TIMEOUT", 30*time.Second),
+		ReclaimAfterTimeouts: getEnvInt("RECLAIM_AFTER_TIMEOUTS", 3),
+
+		ReplicaRedisAddr:     getEnv("REPLICA_REDIS_ADDR", ""),
+		ReplicaRedisPassword: getEnv("REPLICA_REDIS_PASSWORD", ""),
+
+		MigrationTargetRedisAddr:     getEnv("MIGRATION_TARGET_REDIS_ADDR", ""),
+		MigrationTargetRedisPassword: getEnv("MIGRATION_TARGET_REDIS_PASSWORD", ""),
+
+		MinBaseDelay:  getEnvDelay("MIN_BASE_DELAY", 100*time.Millisecond),
+		MaxBaseDelay:  getEnvDelay("MAX_BASE_DELAY", time.Hour),
+		MaxRetryLimit: getEnvInt("MAX_RETRY_LIMIT", 100),
+
+		IDStrategy: getEnv("ID_STRATEGY", ""),
+		IDNodeID:   getEnvInt("ID_NODE_ID", 0),
+
+		RetryJitter:   getEnv("RETRY_JITTER", "none"),
+		MaxRetryDelay: getEnvDelay("MAX_RETRY_DELAY", 0),
+
+		MaxPendingTasks: int64(getEnvInt("MAX_PENDING_TASKS", 0)),
+		OverflowPolicy:  getEnv("OVERFLOW_POLICY", "reject"),
+
+		JanitorInterval: getEnvDuration("JANITOR_INTERVAL", 5*time.Minute),
+
+		PoisonThreshold:     getEnvInt("POISON_THRESHOLD", 0),
+		PoisonFailureWindow: getEnvDuration("POISON_FAILURE_WINDOW", 1*time.Second),
+
+		DeadLetterMaxAttempts: getEnvInt("DEAD_LETTER_MAX_ATTEMPTS", 3),
+		DeadLetterBackoff:     getEnvDuration("DEAD_LETTER_BACKOFF", 200*time.Millisecond),
+		DeadLetterMode:        getEnv("DEAD_LETTER_MODE", "produce"),
+
+		DeadLetterRedrive: parseRedrivePolicies(getEnv("DEAD_LETTER_REDRIVE", "")),
+
+		CompletionMarkerTTL: getEnvDuration("COMPLETION_MARKER_TTL", 24*time.Hour),
+
+		DeliveryResultTTL: getEnvDuration("DELIVERY_RESULT_TTL", 0),
+
+		DeadLetterRetention: getEnvDuration("DEAD_LETTER_RETENTION", 7*24*time.Hour),
+		QuarantineRetention: getEnvDuration("QUARANTINE_RETENTION", 7*24*time.Hour),
+
+		CancelledTaskTTL: getEnvDuration("CANCELLED_TASK_TTL", 7*24*time.Hour),
+
+		HTTPBatchSize: getEnvInt("HTTP_BATCH_SIZE", 0),
+
+		HTTPMaxRequestBytes:  getEnvInt("HTTP_MAX_REQUEST_BYTES", 10<<20),
+		HTTPMaxResponseBytes: getEnvInt("HTTP_MAX_RESPONSE_BYTES", 64<<10),
+
+		HTTPDNSCacheTTL:   getEnvDuration("HTTP_DNS_CACHE_TTL", 0),
+		HTTPHostOverrides: parseHostOverrides(getEnv("HTTP_HOST_OVERRIDES", "")),
+
+		HTTPEgressDenyPrivate: getEnvBool("HTTP_EGRESS_DENY_PRIVATE", false),
+
+		ProbeBacklogThreshold: getEnvInt("PROBE_BACKLOG_THRESHOLD", 0),
+		ProbeInterval:         getEnvDuration("PROBE_INTERVAL", 10*time.Second),
+		ProbeTimeout:          getEnvDuration("PROBE_TIMEOUT", 2*time.Second),
+		ProbeReleaseRate:      getEnvInt("PROBE_RELEASE_RATE", 1),
+
+		RetryStormThreshold:  getEnvInt("RETRY_STORM_THRESHOLD", 0),
+		RetryStormWindow:     getEnvDuration("RETRY_STORM_WINDOW", time.Minute),
+		RetryStormMultiplier: getEnvInt("RETRY_STORM_MULTIPLIER", 4),
+
+		SLAThreshold: getEnvDuration("SLA_THRESHOLD", 0),
+		SLABreachURL: getEnv("SLA_BREACH_URL", ""),
+
+		UsageEventsTopic: getEnv("USAGE_EVENTS_TOPIC", ""),
+
+		NotifyChannel: getEnv("NOTIFY_CHANNEL", ""),
+
+		IngestTopic:   getEnv("INGEST_TOPIC", ""),
+		IngestGroupID: getEnv("INGEST_GROUP_ID", "rebound-ingest"),
+
+		HealthCheckTimeout:  getEnvDuration("HEALTH_CHECK_TIMEOUT", 2*time.Second),
+		HealthCheckCacheTTL: getEnvDuration("HEALTH_CHECK_CACHE_TTL", 1*time.Second),
+
+		DeliveryLogSampleFirst:      getEnvInt("DELIVERY_LOG_SAMPLE_FIRST", 0),
+		DeliveryLogSampleThereafter: getEnvInt("DELIVERY_LOG_SAMPLE_THEREAFTER", 100),
+
+		SourceAllowlist: getEnv("SOURCE_ALLOWLIST", ""),
+
+		RequestSigningSecrets:     parseSigningSecrets(getEnv("REQUEST_SIGNING_SECRETS", "")),
+		RequestSignatureTolerance: getEnvDuration("REQUEST_SIGNATURE_TOLERANCE", 5*time.Minute),
 	}
 
 	if v := getEnv("REDIS_MASTER_NAME", ""); v != "" {
@@ -56,13 +304,193 @@ func New() *Config {
 	if v := getEnv("REDIS_CLUSTER_ADDRS", ""); v != "" {
 		cfg.RedisClusterAddrs = strings.Split(v, ",")
 	}
+	if v := getEnv("MIGRATION_TARGET_REDIS_CLUSTER_ADDRS", ""); v != "" {
+		cfg.MigrationTargetRedisClusterAddrs = strings.Split(v, ",")
+	}
+
+	if v := getEnv("LIFECYCLE_WEBHOOK_URLS", ""); v != "" {
+		cfg.LifecycleWebhookURLs = strings.Split(v, ",")
+	}
+	if v := getEnv("REDACT_FIELDS", ""); v != "" {
+		cfg.RedactFields = strings.Split(v, ",")
+	}
+	if v := getEnv("HTTP_EGRESS_ALLOW", ""); v != "" {
+		cfg.HTTPEgressAllow = strings.Split(v, ",")
+	}
+	if v := getEnv("HTTP_RETRYABLE_STATUSES", ""); v != "" {
+		cfg.HTTPRetryableStatuses = strings.Split(v, ",")
+	}
 
 	return cfg
 }
 
+// RunsAPI reports whether this process serves the task API.
+func (c *Config) RunsAPI() bool {
+	return c.RunMode != RunModeWorker
+}
+
+// RunsWorker reports whether this process polls and delivers due tasks.
+func (c *Config) RunsWorker() bool {
+	return c.RunMode != RunModeAPI
+}
+
+// parseSigningSecrets parses "caller-a:secret-a;caller-b:secret-b" into a
+// map of caller ID to secret. Malformed entries are skipped.
+func parseSigningSecrets(value string) map[string]string {
+	secrets := make(map[string]string)
+	for _, entry := range strings.Split(value, ";") {
+		caller, secret, ok := strings.Cut(strings.TrimSpace(entry), ":")
+		caller = strings.TrimSpace(caller)
+		if !ok || caller == "" || secret == "" {
+			continue
+		}
+		secrets[caller] = secret
+	}
+	return secrets
+}
+
+// parseHostOverrides parses
+// "api.partner.com=10.0.0.5;hooks.example.com=hooks.staging.internal" into a
+// map of hostname to the hostname or IP dialed instead. Malformed entries
+// are skipped.
+func parseHostOverrides(value string) map[string]string {
+	overrides := make(map[string]string)
+	for _, entry := range strings.Split(value, ";") {
+		host, target, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		host, target = strings.TrimSpace(host), strings.TrimSpace(target)
+		if !ok || host == "" || target == "" {
+			continue
+		}
+		overrides[strings.ToLower(host)] = target
+	}
+	return overrides
+}
+
+// parseRedrivePolicies parses
+// "orders-dlq=6h,2,3;https://example.com/dead=1h,1" into a map of dead
+// destination to re-drive policy. Each policy is cooldown, max retries and
+// optionally max re-drives. Malformed entries are skipped.
+func parseRedrivePolicies(value string) map[string]RedrivePolicy {
+	policies := make(map[string]RedrivePolicy)
+	for _, entry := range strings.Split(value, ";") {
+		entry = strings.TrimSpace(entry)
+		i := strings.LastIndex(entry, "=")
+		if i <= 0 {
+			continue
+		}
+		dest := strings.TrimSpace(entry[:i])
+		fields := strings.Split(entry[i+1:], ",")
+		if len(fields) < 2 || len(fields) > 3 {
+			continue
+		}
+
+		cooldown, err := time.ParseDuration(strings.TrimSpace(fields[0]))
+		if err != nil || cooldown < 0 {
+			continue
+		}
+		retries, err := strconv.Atoi(strings.TrimSpace(fields[1]))
+		if err != nil || retries < 0 {
+			continue
+		}
+		redrives := 1
+		if len(fields) == 3 {
+			if redrives, err = strconv.Atoi(strings.TrimSpace(fields[2])); err != nil || redrives < 1 {
+				continue
+			}
+		}
+		policies[dest] = RedrivePolicy{Cooldown: cooldown, MaxRetries: retries, MaxRedrives: redrives}
+	}
+	return policies
+}
+
+// parseQueues parses "payments=100ms,50,3;newsletter=5s,500" into a map
+// of queue name to poll policy. Each policy is poll interval, batch size
+// and optionally weight, which defaults to 1. Malformed entries are
+// skipped.
+func parseQueues(value string) map[string]QueuePolicy {
+	queues := make(map[string]QueuePolicy)
+	for _, entry := range strings.Split(value, ";") {
+		name, policy, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			continue
+		}
+		fields := strings.Split(policy, ",")
+		if len(fields) < 2 || len(fields) > 3 {
+			continue
+		}
+
+		interval, err := time.ParseDuration(strings.TrimSpace(fields[0]))
+		if err != nil || interval <= 0 {
+			continue
+		}
+		batchSize, err := strconv.Atoi(strings.TrimSpace(fields[1]))
+		if err != nil || batchSize < 1 {
+			continue
+		}
+		weight := 1
+		if len(fields) == 3 {
+			if weight, err = strconv.Atoi(strings.TrimSpace(fields[2])); err != nil || weight < 1 {
+				continue
+			}
+		}
+		queues[name] = QueuePolicy{PollInterval: interval, BatchSize: batchSize, Weight: weight}
+	}
+	return queues
+}
+
+func hostname() string {
+	name, err := os.Hostname()
+	if err != nil {
+		return "unknown"
+	}
+	return name
+}
+
 func getEnv(key, fallback string) string {
 	if value, ok := os.LookupEnv(key); ok {
 		return value
 	}
 	return fallback
 }
+
+func getEnvInt(key string, fallback int) int {
+	if value, ok := os.LookupEnv(key); ok {
+		if n, err := strconv.Atoi(value); err == nil {
+			return n
+		}
+	}
+	return fallback
+}
+
+func getEnvBool(key string, fallback bool) bool {
+	if value, ok := os.LookupEnv(key); ok {
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b
+		}
+	}
+	return fallback
+}
+
+func getEnvDuration(key string, fallback time.Duration) time.Duration {
+	if value, ok := os.LookupEnv(key); ok {
+		if d, err := time.ParseDuration(value); err == nil {
+			return d
+		}
+	}
+	return fallback
+}
+
+// getEnvDelay reads a duration string, or a bare number of seconds as these
+// settings took before duration strings were accepted.
+func getEnvDelay(key string, fallback time.Duration) time.Duration {
+	if value, ok := os.LookupEnv(key); ok {
+		if n, err := strconv.Atoi(value); err == nil {
+			return time.Duration(n) * time.Second
+		}
+		if d, err := time.ParseDuration(value); err == nil {
+			return d
+		}
+	}
+	return fallback
+}
@@ -8,7 +8,7 @@ import (
 
 func TestNew_defaults(t *testing.T) {
 	// Clear environment to test defaults
-	envKeys := []string{"HTTP_ADDR", "REDIS_HOST", "REDIS_PORT", "REDIS_PASSWORD", "KAFKA_BROKERS", "ENVIRONMENT", "LOG_LEVEL"}
+	envKeys := []string{"HTTP_ADDR", "REDIS_HOST", "REDIS_PORT", "REDIS_PASSWORD", "KAFKA_BROKERS", "ENVIRONMENT", "LOG_LEVEL", "TASK_ENCODING", "INSTANCE_ID", "HEALTH_CHECK_TIMEOUT", "HEALTH_CHECK_CACHE_TTL", "DRAIN_TIMEOUT", "RUN_MODE"}
 	for _, key := range envKeys {
 		os.Unsetenv(key)
 	}
@@ -20,14 +20,20 @@ func TestNew_defaults(t *testing.T) {
 		got  interface{}
 		want interface{}
 	}{
+		{"RunMode", cfg.RunMode, RunModeAll},
 		{"HTTPAddr", cfg.HTTPAddr, ":8080"},
 		{"RedisAddr", cfg.RedisAddr, "localhost:6379"},
 		{"RedisPassword", cfg.RedisPassword, ""},
 		{"RedisDB", cfg.RedisDB, 0},
+		{"TaskEncoding", cfg.TaskEncoding, "json"},
 		{"Environment", cfg.Environment, "local"},
 		{"LogLevel", cfg.LogLevel, "info"},
 		{"PollInterval", cfg.PollInterval, 1 * time.Second},
 		{"BatchSize", cfg.BatchSize, 10},
+		{"InstanceID", cfg.InstanceID, hostname()},
+		{"DrainTimeout", cfg.DrainTimeout, 10 * time.Second},
+		{"HealthCheckTimeout", cfg.HealthCheckTimeout, 2 * time.Second},
+		{"HealthCheckCacheTTL", cfg.HealthCheckCacheTTL, 1 * time.Second},
 	}
 
 	for _, tt := range tests {
@@ -52,6 +58,15 @@ func TestNew_fromEnvironment(t *testing.T) {
 	t.Setenv("KAFKA_BROKERS", "broker1:9092,broker2:9092")
 	t.Setenv("ENVIRONMENT", "production")
 	t.Setenv("LOG_LEVEL", "debug")
+	t.Setenv("POLL_INTERVAL", "250ms")
+	t.Setenv("BATCH_SIZE", "50")
+	t.Setenv("MIN_BASE_DELAY", "250ms")
+	t.Setenv("MAX_BASE_DELAY", "7200")
+	t.Setenv("HTTP_EGRESS_DENY_PRIVATE", "true")
+	t.Setenv("HTTP_EGRESS_ALLOW", "10.0.0.0/8,*.partner.com")
+	t.Setenv("HTTP_RETRYABLE_STATUSES", "408,429,5xx")
+	t.Setenv("ID_STRATEGY", "snowflake")
+	t.Setenv("ID_NODE_ID", "12")
 
 	cfg := New()
 
@@ -70,10 +85,114 @@ func TestNew_fromEnvironment(t *testing.T) {
 	if cfg.LogLevel != "debug" {
 		t.Fatalf("expected debug, got %s", cfg.LogLevel)
 	}
+	if cfg.PollInterval != 250*time.Millisecond {
+		t.Fatalf("expected 250ms, got %s", cfg.PollInterval)
+	}
+	if cfg.BatchSize != 50 {
+		t.Fatalf("expected 50, got %d", cfg.BatchSize)
+	}
+	if cfg.MinBaseDelay != 250*time.Millisecond {
+		t.Fatalf("expected 250ms, got %s", cfg.MinBaseDelay)
+	}
+	if cfg.MaxBaseDelay != 2*time.Hour {
+		t.Fatalf("expected 2h, got %s", cfg.MaxBaseDelay)
+	}
 	if len(cfg.KafkaBrokers) != 2 {
 		t.Fatalf("expected 2 brokers, got %d", len(cfg.KafkaBrokers))
 	}
 	if cfg.KafkaBrokers[0] != "broker1:9092" || cfg.KafkaBrokers[1] != "broker2:9092" {
 		t.Fatalf("unexpected brokers: %v", cfg.KafkaBrokers)
 	}
+	if !cfg.HTTPEgressDenyPrivate {
+		t.Fatal("expected private egress to be denied")
+	}
+	if len(cfg.HTTPEgressAllow) != 2 || cfg.HTTPEgressAllow[1] != "*.partner.com" {
+		t.Fatalf("unexpected egress allowlist: %v", cfg.HTTPEgressAllow)
+	}
+	if len(cfg.HTTPRetryableStatuses) != 3 || cfg.HTTPRetryableStatuses[2] != "5xx" {
+		t.Fatalf("unexpected retryable statuses: %v", cfg.HTTPRetryableStatuses)
+	}
+	if cfg.IDStrategy != "snowflake" || cfg.IDNodeID != 12 {
+		t.Fatalf("unexpected ID strategy %q with node %d", cfg.IDStrategy, cfg.IDNodeID)
+	}
+}
+
+func TestConfig_runMode(t *testing.T) {
+	tests := []struct {
+		mode       string
+		runsAPI    bool
+		runsWorker bool
+	}{
+		{RunModeAll, true, true},
+		{RunModeAPI, true, false},
+		{RunModeWorker, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			t.Setenv("RUN_MODE", tt.mode)
+			cfg := New()
+			if cfg.RunsAPI() != tt.runsAPI || cfg.RunsWorker() != tt.runsWorker {
+				t.Fatalf("got api=%v worker=%v, want api=%v worker=%v", cfg.RunsAPI(), cfg.RunsWorker(), tt.runsAPI, tt.runsWorker)
+			}
+		})
+	}
+}
+
+func TestParseSigningSecrets(t *testing.T) {
+	got := parseSigningSecrets("caller-a:s3cr:et; caller-b:other;no-colon;:orphan;caller-c:")
+	want := map[string]string{"caller-a": "s3cr:et", "caller-b": "other"}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for caller, secret := range want {
+		if got[caller] != secret {
+			t.Fatalf("caller %q: got %q, want %q", caller, got[caller], secret)
+		}
+	}
+}
+
+func TestParseHostOverrides(t *testing.T) {
+	got := parseHostOverrides("API.partner.com=10.0.0.5; hooks.example.com = hooks.staging.internal;no-equals;=orphan;empty=")
+	want := map[string]string{"api.partner.com": "10.0.0.5", "hooks.example.com": "hooks.staging.internal"}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for host, target := range want {
+		if got[host] != target {
+			t.Fatalf("host %q: got %q, want %q", host, got[host], target)
+		}
+	}
+}
+
+func TestParseRedrivePolicies(t *testing.T) {
+	got := parseRedrivePolicies("orders-dlq=6h,2,3; https://example.com/dead?a=b=1h,1;bad=6h;neg=-1h,1;=1h,1;zero=1h,1,0")
+	want := map[string]RedrivePolicy{
+		"orders-dlq":                   {Cooldown: 6 * time.Hour, MaxRetries: 2, MaxRedrives: 3},
+		"https://example.com/dead?a=b": {Cooldown: time.Hour, MaxRetries: 1, MaxRedrives: 1},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for dest, policy := range want {
+		if got[dest] != policy {
+			t.Fatalf("dest %q: got %+v, want %+v", dest, got[dest], policy)
+		}
+	}
+}
+
+func TestParseQueues(t *testing.T) {
+	got := parseQueues("payments=100ms,50,3; newsletter=5s,500;bad=1s;slow=0s,10;empty=1s,0;light=1s,10,0;=1s,10")
+	want := map[string]QueuePolicy{
+		"payments":   {PollInterval: 100 * time.Millisecond, BatchSize: 50, Weight: 3},
+		"newsletter": {PollInterval: 5 * time.Second, BatchSize: 500, Weight: 1},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for name, policy := range want {
+		if got[name] != policy {
+			t.Fatalf("queue %q: got %+v, want %+v", name, got[name], policy)
+		}
+	}
 }
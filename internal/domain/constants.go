@@ -6,18 +6,291 @@ const (
 	// RedisRetryKey is the sorted set key used for scheduling tasks.
 	RedisRetryKey = "retry:schedule:"
 
+	// RedisDestinationIndexKeyPrefix prefixes the per-destination sorted
+	// sets mirroring the schedule's members and scores, used to find the
+	// backlog for one URL or topic. Entries whose member has left the
+	// schedule are stale and dropped when read.
+	RedisDestinationIndexKeyPrefix = "retry:by-destination:"
+
+	// RedisQuarantineKey is the hash key holding quarantined poison tasks.
+	RedisQuarantineKey = "retry:quarantine"
+
+	// RedisCorruptKey is the hash holding scheduled members that could not
+	// be decoded, keyed by a hash of the member; RedisCorruptCountKey counts
+	// every member ever moved there.
+	RedisCorruptKey      = "retry:corrupt"
+	RedisCorruptCountKey = "retry:corrupt:count"
+
+	// RedisDeadLetterKey is the sorted set indexing dead-lettered task IDs by
+	// the time they were stored; RedisDeadLetterDataKey holds their payloads.
+	RedisDeadLetterKey     = "retry:dead"
+	RedisDeadLetterDataKey = "retry:dead:data"
+
+	// RedisOrderingKeyPrefix prefixes the per-ordering-key active marker
+	// and waiting list used for strict FIFO delivery.
+	RedisOrderingKeyPrefix = "retry:ordering:"
+
+	// RedisCompletedKeyPrefix prefixes the per-task completion markers used
+	// to suppress duplicate deliveries.
+	RedisCompletedKeyPrefix = "retry:completed:"
+
+	// RedisDeliveryResultKeyPrefix prefixes the per-task keys holding the
+	// result of a successful delivery.
+	RedisDeliveryResultKeyPrefix = "retry:result:"
+
+	// RedisCancelledKeyPrefix prefixes the per-task keys holding cancelled
+	// tasks until they are restored or expire.
+	RedisCancelledKeyPrefix = "retry:cancelled:"
+
+	// RedisMaintenanceKey is the hash mapping destination names to the end
+	// of their maintenance window.
+	RedisMaintenanceKey = "retry:maintenance"
+
+	// RedisSLAGroupsKey is the hash indexing the client and destination of
+	// every time-to-success sample list; RedisSLASamplesKeyPrefix prefixes
+	// the lists themselves.
+	RedisSLAGroupsKey        = "retry:sla:groups"
+	RedisSLASamplesKeyPrefix = "retry:sla:samples:"
+
+	// RedisUsageKeyPrefix prefixes the per-period hashes counting delivered
+	// and retried attempts per client, with fields "<client>:delivered" and
+	// "<client>:retried".
+	RedisUsageKeyPrefix = "retry:usage:"
+
+	// RedisPurgeTokenKeyPrefix prefixes the per-queue confirmation token
+	// issued by a purge dry run.
+	RedisPurgeTokenKeyPrefix = "retry:purge:"
+
+	// RedisHeartbeatKeyPrefix prefixes the per-instance worker heartbeat
+	// keys read by the readiness check.
+	RedisHeartbeatKeyPrefix = "retry:heartbeat:"
+
+	// RedisWorkersKey is the hash mapping every instance ID to the Unix time
+	// of its last worker heartbeat, used to detect stalled replicas.
+	RedisWorkersKey = "retry:workers"
+
+	// RedisWorkerStatsKeyPrefix prefixes the per-instance hash holding a
+	// worker's start time and processed and error counters.
+	RedisWorkerStatsKeyPrefix = "retry:workerstats:"
+
+	// RedisOverflowKey is the list holding tasks spilled while the schedule
+	// was full, oldest first.
+	RedisOverflowKey = "retry:overflow"
+
+	// RedisReplicationSeqKey counts the schedule writes sequenced for
+	// replication on the primary; RedisReplicationAppliedKey holds, on the
+	// standby, the highest sequence applied there.
+	RedisReplicationSeqKey     = "retry:replication:seq"
+	RedisReplicationAppliedKey = "retry:replication:applied"
+
+	// RedisJanitorLockKey is held by the instance running the current
+	// janitor pass, so replicas do not repeat each other's work.
+	RedisJanitorLockKey = "retry:janitor:lock"
+
+	// RedisInFlightKey is the sorted set of fetched but unsettled task IDs,
+	// scored by the Unix time after which they are reclaimed;
+	// RedisInFlightDataKey holds their payloads.
+	RedisInFlightKey     = "retry:inflight"
+	RedisInFlightDataKey = "retry:inflight:data"
+
 	// DefaultPollInterval is the interval between worker polling cycles.
 	DefaultPollInterval = 1 * time.Second
 
+	// LowLatencyPollInterval is the poll interval below which the scheduler
+	// claims due tasks with one script, trading extra Redis reads for first
+	// retries within about one poll of their due time.
+	LowLatencyPollInterval = 1 * time.Second
+
+	// ClaimScanLimit is how many due tasks a worker reads per poll looking
+	// for the ones to claim when it is sharded, or composes batches from a
+	// priority share. Tasks of other shards are left for their owners.
+	ClaimScanLimit = 1000
+
+	// DefaultIngestBackoff is how long the Kafka ingester waits before
+	// retrying a task request that could not be scheduled, e.g. while the
+	// queue is full or Redis is unavailable.
+	DefaultIngestBackoff = 1 * time.Second
+
 	// DefaultBatchSize is the maximum number of tasks fetched per poll cycle.
 	DefaultBatchSize = 10
 
-	// MaxBaseDelay caps the base delay to prevent excessively long waits.
-	MaxBaseDelay = 3600
+	// OverflowDropScanLimit is how many of the earliest scheduled tasks are
+	// searched for non-priority tasks to drop when the schedule is full.
+	OverflowDropScanLimit = 100
+
+	// DefaultReplicationBuffer is how many schedule writes may wait to be
+	// replicated before new ones are dropped.
+	DefaultReplicationBuffer = 10000
+
+	// ReplicationBatchSize bounds the writes applied to the standby per
+	// round trip.
+	ReplicationBatchSize = 100
 
-	// MinBaseDelay ensures a minimum delay between retries.
-	MinBaseDelay = 1
+	// ReplicationMaxAttempts and ReplicationRetryBackoff bound the retries
+	// of a batch the standby rejects.
+	ReplicationMaxAttempts  = 3
+	ReplicationRetryBackoff = 200 * time.Millisecond
 
-	// MaxRetryLimit caps the maximum number of retries allowed.
+	// ReplicationFlushTimeout is how long buffered writes may still be
+	// replicated at shutdown.
+	ReplicationFlushTimeout = 5 * time.Second
+
+	// MigrationPageSize is the ZSCAN count hint used when copying the
+	// schedule to a migration target.
+	MigrationPageSize = 500
+
+	// DefaultDeliveryConcurrency is how many due tasks (or HTTP batches)
+	// one poll cycle delivers at once.
+	DefaultDeliveryConcurrency = 10
+
+	// MaxBaseDelay caps the base delay to prevent excessively long waits,
+	// unless overridden with service.WithValidationBounds.
+	MaxBaseDelay = 1 * time.Hour
+
+	// MinBaseDelay ensures a minimum delay between retries, unless
+	// overridden with service.WithValidationBounds.
+	MinBaseDelay = 100 * time.Millisecond
+
+	// MaxRetryLimit caps the maximum number of retries allowed, unless
+	// overridden with service.WithValidationBounds.
 	MaxRetryLimit = 100
+
+	// MaxMetadataEntries and MaxMetadataSize bound task metadata, the
+	// latter counting the bytes of all keys and values together.
+	MaxMetadataEntries = 32
+	MaxMetadataSize    = 4096
+
+	// MaxFallbackDeadDestinations caps the fallback dead destinations of a task.
+	MaxFallbackDeadDestinations = 5
+
+	// MaxFanOutDestinations caps the fan-out destinations of a task.
+	MaxFanOutDestinations = 10
+
+	// DefaultPoisonFailureWindow is the attempt duration below which a
+	// failure counts as "instant" for poison message detection.
+	DefaultPoisonFailureWindow = 1 * time.Second
+
+	// DefaultDeadLetterMaxAttempts is how many times the dead-letter produce
+	// is attempted before falling back to the Redis dead-letter store.
+	DefaultDeadLetterMaxAttempts = 3
+
+	// DefaultDeadLetterBackoff is the initial wait between dead-letter
+	// produce attempts; it doubles after every failure.
+	DefaultDeadLetterBackoff = 200 * time.Millisecond
+
+	// DefaultDrainTimeout is how long deliveries in progress at shutdown may
+	// run before they are cancelled.
+	DefaultDrainTimeout = 10 * time.Second
+
+	// DefaultHeartbeatTTL is how long a worker heartbeat stays valid. An
+	// instance whose worker has not polled successfully for this long is
+	// reported as not ready.
+	DefaultHeartbeatTTL = 15 * time.Second
+
+	// WorkerForgetAfter is how long a silent instance is reported as stalled
+	// before it is dropped from the worker registry.
+	WorkerForgetAfter = 1 * time.Hour
+
+	// DefaultJanitorInterval is how often indexes and registries are
+	// reconciled against the data they describe.
+	DefaultJanitorInterval = 5 * time.Minute
+
+	// DefaultProbeInterval is how often a destination with a large backlog
+	// is health probed, and how long its tasks are held while it fails.
+	DefaultProbeInterval = 10 * time.Second
+
+	// DefaultProbeTimeout bounds each destination health probe.
+	DefaultProbeTimeout = 2 * time.Second
+
+	// DefaultProbeReleaseRate is how many due tasks of a recovered
+	// destination are delivered in the first poll after it recovers.
+	DefaultProbeReleaseRate = 1
+
+	// ProbeReleaseStep is how long tasks beyond a recovering destination's
+	// release allowance wait before they are due again.
+	ProbeReleaseStep = 1 * time.Second
+
+	// DefaultRetryStormWindow is the window over which retries scheduled for
+	// a destination are counted to detect a retry storm.
+	DefaultRetryStormWindow = 1 * time.Minute
+
+	// DefaultRetryStormMultiplier is how many times longer retries to a
+	// destination in a retry storm wait.
+	DefaultRetryStormMultiplier = 4
+
+	// DefaultDeliveryTimeout bounds a single delivery attempt, and its attempt
+	// hooks unless they are given their own timeout.
+	DefaultDeliveryTimeout = 30 * time.Second
+
+	// DefaultReclaimAfterTimeouts is how many delivery timeouts a task may
+	// stay in flight before it is presumed lost with its worker and
+	// reclaimed.
+	DefaultReclaimAfterTimeouts = 3
+
+	// DefaultCompletionMarkerTTL is how long a delivered task ID is remembered.
+	DefaultCompletionMarkerTTL = 24 * time.Hour
+
+	// CorrelationIDHeader carries a task's correlation ID, as an HTTP
+	// header or a Kafka record header.
+	CorrelationIDHeader = "X-Correlation-ID"
+
+	// DeliveryResultBodyLimit is how many bytes of an HTTP response body a
+	// delivery result keeps.
+	DeliveryResultBodyLimit = 1024
+
+	// DefaultHTTPMaxRequestBytes is the largest body an HTTP delivery sends.
+	DefaultHTTPMaxRequestBytes = 10 << 20
+
+	// DefaultHTTPMaxResponseBytes is how much of an HTTP destination's
+	// response body is read; the rest is discarded unread.
+	DefaultHTTPMaxResponseBytes = 64 << 10
+
+	// MaxRetryAfter caps the Retry-After an HTTP destination may ask for,
+	// so a bogus header cannot park a task for days.
+	MaxRetryAfter = 1 * time.Hour
+
+	// ForecastBucket is the resolution of schedule forecasts, and
+	// MaxForecastWindow the longest window one may cover.
+	ForecastBucket    = time.Minute
+	MaxForecastWindow = 24 * time.Hour
+
+	// MaxListLimit caps the entries returned by one admin listing, such as
+	// upcoming tasks or dead letters.
+	MaxListLimit = 500
+
+	// PurgeTokenTTL is how long a purge confirmation token stays valid.
+	PurgeTokenTTL = 5 * time.Minute
+
+	// DefaultCancelledTaskTTL is how long a cancelled task can be restored.
+	DefaultCancelledTaskTTL = 7 * 24 * time.Hour
+
+	// DefaultSLASampleSize is how many recent time-to-success samples are
+	// kept per client and destination for percentile calculation.
+	DefaultSLASampleSize = 1000
+
+	// UsageRetention is how long the usage counters of a billing period are
+	// kept after its last update, leaving time to bill a closed period.
+	UsageRetention = 90 * 24 * time.Hour
+
+	// ProducerLatencySamples is how many recent write latencies are kept
+	// per destination for percentile calculation, and
+	// ProducerMetricsMaxDestinations how many destinations are tracked
+	// before further ones are counted under ProducerMetricsOverflow.
+	ProducerLatencySamples         = 1000
+	ProducerMetricsMaxDestinations = 1000
+	ProducerMetricsOverflow        = "(other)"
+
+	// LifecycleEventSource is the Source of the tasks delivering rebound's
+	// own lifecycle events to subscribers. Such tasks raise no events of
+	// their own, so a failing subscriber cannot cause an event loop.
+	LifecycleEventSource = "rebound.lifecycle"
+
+	// LifecycleEventMaxRetries and LifecycleEventBaseDelay are the retry
+	// policy of lifecycle event deliveries.
+	LifecycleEventMaxRetries = 5
+	LifecycleEventBaseDelay  = 5 * time.Second
+
+	// QueueAlarmInterval is the least time between two queue alarm events
+	// raised by one process.
+	QueueAlarmInterval = time.Minute
 )
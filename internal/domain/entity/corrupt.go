@@ -0,0 +1,21 @@
+package entity
+
+import "time"
+
+// CorruptMember is a scheduled member that could not be decoded into a
+// task. The scheduler moves such members aside rather than dropping them,
+// so they can be inspected and repaired or deleted by hand.
+type CorruptMember struct {
+	ID      string // stable hash of Raw
+	Raw     string
+	Error   string // why decoding failed
+	DueAt   time.Time
+	FoundAt time.Time
+}
+
+// CorruptReport lists corrupt members. Total counts every member ever moved
+// aside, including those since deleted.
+type CorruptReport struct {
+	Total   int64
+	Members []CorruptMember
+}
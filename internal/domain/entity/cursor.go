@@ -0,0 +1,16 @@
+package entity
+
+// Cursor is a position in a listing ordered by score and then member, such
+// as a Redis sorted set: the score and member of the last item returned.
+// Unlike an offset it still points at the right place after items before
+// it are added or removed, so paging through a queue that is mutating under
+// load neither skips nor repeats items. The zero Cursor is the start.
+type Cursor struct {
+	Score  float64
+	Member string
+}
+
+// IsZero reports whether c is the start of the listing.
+func (c Cursor) IsZero() bool {
+	return c == Cursor{}
+}
@@ -0,0 +1,40 @@
+package entity
+
+import "time"
+
+// DeadLetter is an exhausted task kept in the dead-letter store because it
+// could not be delivered to its dead-letter destination.
+type DeadLetter struct {
+	Task     *Task
+	Reason   string
+	StoredAt time.Time
+}
+
+// DeadLetterMode is where tasks that exhausted their retries are kept.
+type DeadLetterMode string
+
+const (
+	// DeadLetterProduce produces tasks to their dead destination, falling
+	// back to the dead-letter store only when that fails.
+	DeadLetterProduce DeadLetterMode = "produce"
+	// DeadLetterStoreOnly keeps tasks in the dead-letter store without
+	// producing them anywhere, for deployments without a dead-letter queue.
+	DeadLetterStoreOnly DeadLetterMode = "store"
+	// DeadLetterBoth keeps tasks in the dead-letter store and also produces
+	// them to their dead destination.
+	DeadLetterBoth DeadLetterMode = "both"
+)
+
+// Valid reports whether m is a known dead-letter mode.
+func (m DeadLetterMode) Valid() bool {
+	switch m {
+	case DeadLetterProduce, DeadLetterStoreOnly, DeadLetterBoth:
+		return true
+	}
+	return false
+}
+
+// Stores reports whether m keeps every dead-lettered task in the store.
+func (m DeadLetterMode) Stores() bool {
+	return m == DeadLetterStoreOnly || m == DeadLetterBoth
+}
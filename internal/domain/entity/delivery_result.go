@@ -0,0 +1,27 @@
+package entity
+
+import "time"
+
+// DeliveryReceipt is what a destination answered to a successful delivery:
+// the HTTP status and the start of the response body, or the Kafka
+// partition and offset the message was written to. Partition and Offset are
+// -1 when the producer could not learn them.
+type DeliveryReceipt struct {
+	HTTPStatus   int
+	ResponseBody string
+	Partition    int
+	Offset       int64
+}
+
+// DeliveryResult records a task's successful delivery, so it can be proven
+// long after the task left the schedule. Source is the task's, so access to
+// the result can be limited to the service that created the task.
+type DeliveryResult struct {
+	TaskID          string
+	Source          string
+	DestinationType DestinationType
+	Destination     string
+	Attempts        int
+	DeliveredAt     time.Time
+	Receipt         DeliveryReceipt
+}
@@ -1,5 +1,124 @@
 package entity
 
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// DestinationType defines the type of message destination.
+type DestinationType string
+
+const (
+	DestinationTypeKafka DestinationType = "kafka"
+	DestinationTypeHTTP  DestinationType = "http"
+)
+
+// destinationTypes is the registry of supported destination types, with
+// the Destination field each one delivers to. Validation, delivery and
+// dead-letter routing all consult it. The order decides which type Type
+// infers when several fields are set.
+var destinationTypes = []struct {
+	destinationType DestinationType
+	field           string
+	addressed       func(Destination) bool
+	validAddress    func(Destination) error
+}{
+	{DestinationTypeHTTP, "URL", func(d Destination) bool { return d.URL != "" }, validHTTPAddress},
+	{DestinationTypeKafka, "topic", func(d Destination) bool { return d.Topic != "" }, validKafkaAddress},
+}
+
+// Validate checks that t is supported, that d has the field t delivers
+// to: a URL for HTTP, a topic for Kafka, and that d's payload format, if
+// any, is known and used with HTTP.
+func (t DestinationType) Validate(d Destination) error {
+	for _, dt := range destinationTypes {
+		if dt.destinationType != t {
+			continue
+		}
+		if !dt.addressed(d) {
+			return fmt.Errorf("destination %s is required", dt.field)
+		}
+		return d.Format.validate(t)
+	}
+	return fmt.Errorf("unsupported destination type %q", t)
+}
+
+// ValidateAddress checks that d's address is well-formed for the type it
+// infers, so a typo is rejected when the task is created rather than at
+// its first delivery: an absolute http or https URL, or a Kafka broker
+// host and port. It returns a *FieldError naming the offending field.
+func (d Destination) ValidateAddress() error {
+	for _, dt := range destinationTypes {
+		if dt.addressed(d) {
+			return dt.validAddress(d)
+		}
+	}
+	return nil
+}
+
+func validHTTPAddress(d Destination) error {
+	u, err := url.Parse(d.URL)
+	if err != nil {
+		return &FieldError{Field: "url", Err: errors.New("must be a valid URL")}
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return &FieldError{Field: "url", Err: errors.New("must be an absolute http or https URL")}
+	}
+	if u.Hostname() == "" {
+		return &FieldError{Field: "url", Err: errors.New("must include a host")}
+	}
+	if port := u.Port(); port != "" && !validPort(port) {
+		return &FieldError{Field: "url", Err: fmt.Errorf("port %q must be between 1 and 65535", port)}
+	}
+	return nil
+}
+
+func validKafkaAddress(d Destination) error {
+	if d.Host == "" {
+		return &FieldError{Field: "host", Err: errors.New("is required for Kafka")}
+	}
+	if !validHost(d.Host) {
+		return &FieldError{Field: "host", Err: errors.New("must be a hostname or IP address, without scheme or port")}
+	}
+	if d.Port == "" {
+		return &FieldError{Field: "port", Err: errors.New("is required for Kafka")}
+	}
+	if !validPort(d.Port) {
+		return &FieldError{Field: "port", Err: errors.New("must be a number between 1 and 65535")}
+	}
+	return nil
+}
+
+// validHost reports whether host is an IP address or a DNS hostname.
+func validHost(host string) bool {
+	if net.ParseIP(host) != nil {
+		return true
+	}
+	if len(host) > 253 {
+		return false
+	}
+	for _, label := range strings.Split(strings.TrimSuffix(host, "."), ".") {
+		if label == "" || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+		for _, r := range label {
+			if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_') {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func validPort(port string) bool {
+	n, err := strconv.Atoi(port)
+	return err == nil && n >= 1 && n <= 65535
+}
+
 // Destination represents a target endpoint where messages are delivered.
 // For Kafka: use Host, Port, and Topic.
 // For HTTP: use URL.
@@ -8,9 +127,32 @@ type Destination struct {
 	Port  string // Kafka broker port
 	Topic string // Kafka topic name
 	URL   string // HTTP endpoint URL (for HTTP destinations)
+
+	Format PayloadFormat // how HTTP deliveries shape the message; empty sends it as is
 }
 
 // Address returns the host:port combination for connection.
 func (d Destination) Address() string {
 	return d.Host + ":" + d.Port
 }
+
+// Type infers the delivery mechanism from the fields that are set: a URL
+// means HTTP, a Topic means Kafka. It returns an empty type when neither is
+// set, which callers treat as "no destination configured".
+func (d Destination) Type() DestinationType {
+	for _, dt := range destinationTypes {
+		if dt.addressed(d) {
+			return dt.destinationType
+		}
+	}
+	return ""
+}
+
+// Name identifies the destination in operator-facing APIs such as
+// maintenance windows: the URL for HTTP, the topic for Kafka.
+func (d Destination) Name() string {
+	if d.URL != "" {
+		return d.URL
+	}
+	return d.Topic
+}
@@ -0,0 +1,48 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDestination_ValidateAddress(t *testing.T) {
+	tests := []struct {
+		name      string
+		dest      Destination
+		wantField string // empty when valid
+	}{
+		{name: "https URL", dest: Destination{URL: "https://partner.example.com/hook?x=1"}},
+		{name: "http URL with port", dest: Destination{URL: "http://10.0.0.5:8080/hook"}},
+		{name: "ipv6 URL", dest: Destination{URL: "http://[2001:db8::1]:8080/hook"}},
+		{name: "relative URL", dest: Destination{URL: "partner.example.com/hook"}, wantField: "url"},
+		{name: "other scheme", dest: Destination{URL: "ftp://partner.example.com/hook"}, wantField: "url"},
+		{name: "no host", dest: Destination{URL: "https:///hook"}, wantField: "url"},
+		{name: "unparsable URL", dest: Destination{URL: "http://partner example.com/%zz"}, wantField: "url"},
+		{name: "URL port out of range", dest: Destination{URL: "http://partner.example.com:70000/hook"}, wantField: "url"},
+		{name: "kafka broker", dest: Destination{Host: "kafka-1.internal", Port: "9092", Topic: "orders"}},
+		{name: "kafka ip broker", dest: Destination{Host: "::1", Port: "9092", Topic: "orders"}},
+		{name: "kafka without host", dest: Destination{Port: "9092", Topic: "orders"}, wantField: "host"},
+		{name: "kafka host with port", dest: Destination{Host: "kafka:9092", Port: "9092", Topic: "orders"}, wantField: "host"},
+		{name: "kafka host with scheme", dest: Destination{Host: "tcp://kafka", Port: "9092", Topic: "orders"}, wantField: "host"},
+		{name: "kafka without port", dest: Destination{Host: "kafka", Topic: "orders"}, wantField: "port"},
+		{name: "kafka port not a number", dest: Destination{Host: "kafka", Port: "ninety", Topic: "orders"}, wantField: "port"},
+		{name: "kafka port out of range", dest: Destination{Host: "kafka", Port: "0", Topic: "orders"}, wantField: "port"},
+		{name: "no destination", dest: Destination{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.dest.ValidateAddress()
+			if tt.wantField == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			var fieldErr *FieldError
+			if !errors.As(err, &fieldErr) || fieldErr.Field != tt.wantField {
+				t.Fatalf("expected an error for field %q, got %v", tt.wantField, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package entity
+
+import "time"
+
+// DrainStatus reports a worker's progress towards a clean shutdown.
+type DrainStatus struct {
+	Draining bool      // no new polls start
+	Since    time.Time // when draining was requested; zero unless Draining
+	Polling  bool      // a poll is still delivering tasks
+}
+
+// Drained reports whether draining was requested and no delivery is left
+// in flight, so the instance can be stopped without cutting one short.
+func (s DrainStatus) Drained() bool {
+	return s.Draining && !s.Polling
+}
@@ -0,0 +1,67 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+)
+
+// ErrorCode classifies why a delivery attempt failed in terms stable enough
+// to group failures by, unlike the free-form error message.
+type ErrorCode string
+
+const (
+	// ErrorCodeConnRefused means the destination refused the connection.
+	ErrorCodeConnRefused ErrorCode = "CONN_REFUSED"
+	// ErrorCodeTimeout means the attempt did not complete in time.
+	ErrorCodeTimeout ErrorCode = "TIMEOUT"
+	// ErrorCodeHTTP4xx means an HTTP destination rejected the request with
+	// a 4xx status other than 401 and 403.
+	ErrorCodeHTTP4xx ErrorCode = "HTTP_4XX"
+	// ErrorCodeHTTP5xx means an HTTP destination answered with a 5xx status.
+	ErrorCodeHTTP5xx ErrorCode = "HTTP_5XX"
+	// ErrorCodeKafkaUnreachable means no Kafka broker could be reached.
+	ErrorCodeKafkaUnreachable ErrorCode = "KAFKA_UNREACHABLE"
+	// ErrorCodeAuthFailed means the destination rejected the credentials:
+	// HTTP 401 or 403, or a Kafka authentication or authorization error.
+	ErrorCodeAuthFailed ErrorCode = "AUTH_FAILED"
+	// ErrorCodePayloadTooLarge means the message was not sent because it
+	// exceeds the producer's size limit.
+	ErrorCodePayloadTooLarge ErrorCode = "PAYLOAD_TOO_LARGE"
+	// ErrorCodeEgressDenied means the connection was refused because the
+	// egress policy does not allow the destination's address.
+	ErrorCodeEgressDenied ErrorCode = "EGRESS_DENIED"
+	// ErrorCodeUnknown is any other failure.
+	ErrorCodeUnknown ErrorCode = "UNKNOWN"
+)
+
+// DeliveryError tags a delivery failure with its ErrorCode. Producers return
+// it where they know the cause; failures without one are classified from
+// the underlying error.
+type DeliveryError struct {
+	Code ErrorCode
+	Err  error
+
+	// RetryAfter is how long the destination asked to be left alone, e.g.
+	// by an HTTP Retry-After header. The next attempt waits at least this
+	// long. Zero means no hint.
+	RetryAfter time.Duration
+}
+
+func (e *DeliveryError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *DeliveryError) Unwrap() error {
+	return e.Err
+}
+
+// StatusError is a non-2xx response from an HTTP destination. Producers
+// wrap it in a DeliveryError.
+type StatusError struct {
+	StatusCode int
+	Body       string // the response body, up to the producer's read limit
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("http request failed with status %d: %s", e.StatusCode, e.Body)
+}
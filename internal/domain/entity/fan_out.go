@@ -0,0 +1,34 @@
+package entity
+
+import (
+	"maps"
+	"strconv"
+)
+
+// FanOutTaskID returns the ID of the task delivering to the n-th fan-out
+// destination of the task with the given ID, counting from 1.
+func FanOutTaskID(id string, n int) string {
+	return id + "#" + strconv.Itoa(n)
+}
+
+// FanOut splits the task into one task per destination: t itself, left
+// delivering to Destination, followed by a copy for each of its
+// FanOutDestinations with the ID FanOutTaskID(t.ID, n) and the destination
+// type inferred from the destination. FanOutDestinations is cleared on all
+// of them. A task without fan-out destinations is returned alone.
+func (t *Task) FanOut() []*Task {
+	destinations := t.FanOutDestinations
+	t.FanOutDestinations = nil
+
+	tasks := make([]*Task, 0, 1+len(destinations))
+	tasks = append(tasks, t)
+	for i, dest := range destinations {
+		c := *t
+		c.ID = FanOutTaskID(t.ID, i+1)
+		c.Destination = dest
+		c.DestinationType = dest.Type()
+		c.Metadata = maps.Clone(t.Metadata)
+		tasks = append(tasks, &c)
+	}
+	return tasks
+}
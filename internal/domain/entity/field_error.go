@@ -0,0 +1,23 @@
+package entity
+
+// FieldError is a validation failure of one field of a task, named by its
+// path in the task API, such as "destination.url", so clients can point at
+// the offending input.
+type FieldError struct {
+	Field string
+	Err   error
+}
+
+func (e *FieldError) Error() string {
+	return e.Field + ": " + e.Err.Error()
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// Within returns the error for the same field nested under parent, e.g.
+// "url" within "destination" is "destination.url".
+func (e *FieldError) Within(parent string) *FieldError {
+	return &FieldError{Field: parent + "." + e.Field, Err: e.Err}
+}
@@ -0,0 +1,29 @@
+package entity
+
+import "time"
+
+// TaskFilter selects scheduled tasks for bulk operations. Empty fields match
+// everything; DueAfter and DueBefore bound the due time inclusively.
+type TaskFilter struct {
+	ClientID    string
+	Destination string // Destination.Name()
+	DueAfter    time.Time
+	DueBefore   time.Time
+}
+
+// IsEmpty reports whether the filter would match every task.
+func (f TaskFilter) IsEmpty() bool {
+	return f.ClientID == "" && f.Destination == "" && f.DueAfter.IsZero() && f.DueBefore.IsZero()
+}
+
+// MatchesTask reports whether task satisfies the client and destination
+// criteria. The due time bounds are applied by the scheduler.
+func (f TaskFilter) MatchesTask(task *Task) bool {
+	if f.ClientID != "" && task.ClientID != f.ClientID {
+		return false
+	}
+	if f.Destination != "" && task.Destination.Name() != f.Destination {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,40 @@
+package entity
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTaskFilter_MatchesTask(t *testing.T) {
+	task := &Task{ClientID: "client-1", Destination: Destination{URL: "http://partner/hook"}}
+
+	tests := []struct {
+		name   string
+		filter TaskFilter
+		want   bool
+	}{
+		{name: "empty filter", filter: TaskFilter{}, want: true},
+		{name: "matching client", filter: TaskFilter{ClientID: "client-1"}, want: true},
+		{name: "other client", filter: TaskFilter{ClientID: "client-2"}, want: false},
+		{name: "matching destination", filter: TaskFilter{Destination: "http://partner/hook"}, want: true},
+		{name: "client and other destination", filter: TaskFilter{ClientID: "client-1", Destination: "orders"}, want: false},
+		{name: "due range is not checked here", filter: TaskFilter{DueBefore: time.Unix(0, 0)}, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.MatchesTask(task); got != tt.want {
+				t.Fatalf("MatchesTask() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTaskFilter_IsEmpty(t *testing.T) {
+	if !(TaskFilter{}).IsEmpty() {
+		t.Fatal("zero filter should be empty")
+	}
+	if (TaskFilter{DueAfter: time.Now()}).IsEmpty() {
+		t.Fatal("filter with a due bound should not be empty")
+	}
+}
@@ -0,0 +1,12 @@
+package entity
+
+import "time"
+
+// ScheduleForecast counts the tasks becoming due in consecutive buckets
+// starting at From.
+type ScheduleForecast struct {
+	From    time.Time
+	Bucket  time.Duration
+	Overdue int64 // already due at From but not yet picked up
+	Counts  []int64
+}
@@ -0,0 +1,54 @@
+package entity
+
+import "time"
+
+// JitterMode randomizes retry delays so tasks that failed together, e.g.
+// during a destination outage, do not all retry at the same moment. An
+// empty mode defers to the deployment default.
+type JitterMode string
+
+const (
+	// JitterNone waits the exponential delay exactly.
+	JitterNone JitterMode = "none"
+	// JitterFull waits a random time between zero and the exponential delay.
+	JitterFull JitterMode = "full"
+	// JitterEqual waits half the exponential delay plus a random time up
+	// to the other half.
+	JitterEqual JitterMode = "equal"
+	// JitterDecorrelated waits a random time between BaseDelay and three
+	// times the previous delay, so delays grow without tracking the attempt.
+	JitterDecorrelated JitterMode = "decorrelated"
+)
+
+// Valid reports whether m is empty or a known mode.
+func (m JitterMode) Valid() bool {
+	switch m {
+	case "", JitterNone, JitterFull, JitterEqual, JitterDecorrelated:
+		return true
+	}
+	return false
+}
+
+// RetryDelay returns the delay before the current attempt with jitter
+// applied according to mode, given random, a uniform value in [0, 1). It
+// never exceeds MaxDelay when that is set.
+func (t *Task) RetryDelay(mode JitterMode, random float64) time.Duration {
+	delay := t.NextRetryDelay()
+	switch mode {
+	case JitterFull:
+		return time.Duration(random * float64(delay))
+	case JitterEqual:
+		return delay/2 + time.Duration(random*float64(delay-delay/2))
+	case JitterDecorrelated:
+		if len(t.RetryDelays) > 0 {
+			return delay
+		}
+		previous := max(t.LastRetryDelay, t.BaseDelay)
+		delay := t.BaseDelay + time.Duration(random*float64(3*previous-t.BaseDelay))
+		if t.MaxDelay > 0 {
+			return min(delay, t.MaxDelay)
+		}
+		return delay
+	}
+	return delay
+}
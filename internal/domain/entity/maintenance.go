@@ -0,0 +1,15 @@
+package entity
+
+import "time"
+
+// MaintenanceWindow declares a destination unavailable until a point in
+// time. Tasks due for it are held rather than attempted while it is active.
+type MaintenanceWindow struct {
+	Destination string    // Destination.Name() of the affected destination
+	Until       time.Time // Held tasks are released at this time
+}
+
+// Active reports whether the window still covers now.
+func (w MaintenanceWindow) Active(now time.Time) bool {
+	return now.Before(w.Until)
+}
@@ -0,0 +1,34 @@
+package entity
+
+// OverflowAction is what happens to new tasks once the schedule holds the
+// maximum number of pending tasks.
+type OverflowAction string
+
+const (
+	// OverflowReject rejects new tasks with domain.ErrQueueFull.
+	OverflowReject OverflowAction = "reject"
+	// OverflowDropOldest drops the earliest scheduled non-priority tasks to
+	// make room, rejecting new tasks only if there are none to drop.
+	OverflowDropOldest OverflowAction = "drop-oldest"
+	// OverflowSpill parks new tasks in a secondary store and schedules them
+	// once the schedule has room again.
+	OverflowSpill OverflowAction = "spill"
+)
+
+// Valid reports whether a is a known overflow action.
+func (a OverflowAction) Valid() bool {
+	switch a {
+	case OverflowReject, OverflowDropOldest, OverflowSpill:
+		return true
+	}
+	return false
+}
+
+// OverflowStats counts what the overflow policy has done since the service
+// started. Restored counts spilled tasks later scheduled.
+type OverflowStats struct {
+	Rejected int64
+	Dropped  int64
+	Spilled  int64
+	Restored int64
+}
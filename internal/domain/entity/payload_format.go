@@ -0,0 +1,32 @@
+package entity
+
+import "fmt"
+
+// PayloadFormat names a chat platform schema HTTP deliveries wrap the
+// message in, so an incoming webhook can be the destination directly.
+type PayloadFormat string
+
+const (
+	// PayloadFormatSlack posts the message as a Slack incoming webhook
+	// text message.
+	PayloadFormatSlack PayloadFormat = "slack"
+
+	// PayloadFormatTeams posts the message as a Microsoft Teams webhook
+	// message holding an Adaptive Card.
+	PayloadFormatTeams PayloadFormat = "teams"
+)
+
+// validate checks that f is empty or known, and only set on HTTP
+// destinations.
+func (f PayloadFormat) validate(t DestinationType) error {
+	switch f {
+	case "":
+		return nil
+	case PayloadFormatSlack, PayloadFormatTeams:
+		if t != DestinationTypeHTTP {
+			return fmt.Errorf("payload format %q requires an HTTP destination", f)
+		}
+		return nil
+	}
+	return fmt.Errorf("unsupported payload format %q", f)
+}
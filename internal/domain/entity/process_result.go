@@ -0,0 +1,75 @@
+package entity
+
+// Outcome is what one poll did with a due task.
+type Outcome string
+
+const (
+	// OutcomeDelivered means the attempt succeeded.
+	OutcomeDelivered Outcome = "delivered"
+	// OutcomeRescheduled means the attempt failed and a retry was scheduled.
+	OutcomeRescheduled Outcome = "rescheduled"
+	// OutcomeDead means the attempt failed with no retries left and the
+	// task was dead-lettered.
+	OutcomeDead Outcome = "dead"
+	// OutcomeQuarantined means the task was isolated as a poison message.
+	OutcomeQuarantined Outcome = "quarantined"
+	// OutcomeHeld means the task was deferred without an attempt, e.g. for
+	// a maintenance window or an exhausted rate limit.
+	OutcomeHeld Outcome = "held"
+	// OutcomeRequeued means processing failed unexpectedly, e.g. with a
+	// panic, and the task was put back in the schedule as it was.
+	OutcomeRequeued Outcome = "requeued"
+	// OutcomeSkipped means the task had already been delivered.
+	OutcomeSkipped Outcome = "skipped"
+	// OutcomeErrored means the task could not be settled, e.g. because its
+	// retry could not be scheduled.
+	OutcomeErrored Outcome = "errored"
+)
+
+// Terminal reports whether a task with this outcome is settled for good:
+// delivered, dead-lettered or quarantined.
+func (o Outcome) Terminal() bool {
+	return o == OutcomeDelivered || o == OutcomeDead || o == OutcomeQuarantined
+}
+
+// TaskOutcome reports what happened to one due task.
+type TaskOutcome struct {
+	TaskID   string
+	ClientID string
+	Attempt  int // the attempt made; unchanged for held and skipped tasks
+	Outcome  Outcome
+	Error    string // the delivery error, if the attempt failed
+	// ErrorCode classifies Error.
+	ErrorCode ErrorCode
+}
+
+// ProcessResult reports what one poll did with the tasks it fetched.
+type ProcessResult struct {
+	Tasks []TaskOutcome
+
+	// Reclaimed counts the tasks found stuck in flight after their worker
+	// died, and retried or dead-lettered, during the poll.
+	Reclaimed int
+}
+
+// Count returns how many tasks ended with the given outcome.
+func (r ProcessResult) Count(outcome Outcome) int {
+	n := 0
+	for _, t := range r.Tasks {
+		if t.Outcome == outcome {
+			n++
+		}
+	}
+	return n
+}
+
+// ErrorCodes counts the failed attempts by error code.
+func (r ProcessResult) ErrorCodes() map[ErrorCode]int {
+	codes := make(map[ErrorCode]int)
+	for _, t := range r.Tasks {
+		if t.ErrorCode != "" {
+			codes[t.ErrorCode]++
+		}
+	}
+	return codes
+}
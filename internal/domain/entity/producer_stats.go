@@ -0,0 +1,65 @@
+package entity
+
+import (
+	"sort"
+	"time"
+)
+
+// ProducerWrite describes one write a producer made to a destination: a
+// single message, or a batch of Messages sent in one request.
+type ProducerWrite struct {
+	DestinationType DestinationType
+	Destination     string // Destination.Name()
+	Messages        int
+	Latency         time.Duration
+	HTTPStatus      int // 0 for Kafka and when no response was received
+	Failed          bool
+
+	// Oversized is set when the request was not sent because its body
+	// exceeds the size limit, Truncated when the response body was cut
+	// off at the limit.
+	Oversized bool
+	Truncated bool
+}
+
+// ProducerStats summarizes the writes producers made to one destination
+// since the service started. Latency percentiles cover recent writes only,
+// so they follow the destination as it speeds up or slows down.
+type ProducerStats struct {
+	DestinationType DestinationType
+	Destination     string
+	Writes          int64
+	Messages        int64
+	Errors          int64
+	MaxBatchSize    int
+	StatusCodes     map[int]int64
+	Oversized       int64 // requests not sent for exceeding the size limit
+	Truncated       int64 // responses cut off at the size limit
+	P50             time.Duration
+	P90             time.Duration
+	P99             time.Duration
+	Max             time.Duration
+}
+
+// ErrorRate returns the fraction of writes that failed.
+func (s ProducerStats) ErrorRate() float64 {
+	if s.Writes == 0 {
+		return 0
+	}
+	return float64(s.Errors) / float64(s.Writes)
+}
+
+// SetLatencies computes nearest-rank latency percentiles over samples.
+func (s *ProducerStats) SetLatencies(samples []time.Duration) {
+	if len(samples) == 0 {
+		return
+	}
+
+	sorted := append([]time.Duration(nil), samples...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	s.P50 = percentile(sorted, 50)
+	s.P90 = percentile(sorted, 90)
+	s.P99 = percentile(sorted, 99)
+	s.Max = sorted[len(sorted)-1]
+}
@@ -0,0 +1,52 @@
+package entity
+
+import "time"
+
+// Queue names one of the stored task collections an operator can inspect
+// and purge.
+type Queue string
+
+const (
+	// QueueScheduled holds tasks waiting for their next attempt.
+	QueueScheduled Queue = "scheduled"
+	// QueueDeadLetter holds exhausted tasks whose dead-letter delivery failed.
+	QueueDeadLetter Queue = "dead-letter"
+	// QueueQuarantine holds tasks quarantined as poison messages.
+	QueueQuarantine Queue = "quarantine"
+	// QueueOverflow holds new tasks spilled while the schedule was full.
+	QueueOverflow Queue = "overflow"
+	// QueueCorrupt holds scheduled members that could not be decoded.
+	QueueCorrupt Queue = "corrupt"
+)
+
+// Queues lists every queue, in the order they are reported.
+var Queues = []Queue{QueueScheduled, QueueDeadLetter, QueueQuarantine, QueueOverflow, QueueCorrupt}
+
+// Valid reports whether q is a known queue.
+func (q Queue) Valid() bool {
+	switch q {
+	case QueueScheduled, QueueDeadLetter, QueueQuarantine, QueueOverflow, QueueCorrupt:
+		return true
+	}
+	return false
+}
+
+// QueueStats is a cheap snapshot of a queue's size. Pending counts tasks
+// not yet due and Due counts tasks whose time has come but that no worker
+// has picked up yet; queues without due times report everything as
+// Pending. MemoryBytes is Redis's sampled estimate for the queue's keys.
+type QueueStats struct {
+	Queue       Queue
+	Pending     int64
+	Due         int64
+	MemoryBytes int64
+}
+
+// PurgePlan is the result of a purge dry run: how many tasks a purge would
+// drop and the token that confirms it until ExpiresAt.
+type PurgePlan struct {
+	Queue     Queue
+	Count     int64
+	Token     string
+	ExpiresAt time.Time
+}
@@ -0,0 +1,35 @@
+package entity
+
+// RepairReport counts the inconsistencies fixed by one janitor pass.
+type RepairReport struct {
+	// IndexEntriesRemoved are destination index entries whose task had
+	// left the schedule; IndexEntriesAdded are scheduled tasks that were
+	// missing from their destination index.
+	IndexEntriesRemoved int
+	IndexEntriesAdded   int
+
+	// DeadLettersRemoved are dead-letter index entries without a payload;
+	// DeadLettersIndexed are payloads that were missing from the index.
+	DeadLettersRemoved int
+	DeadLettersIndexed int
+
+	// SLAGroupsRemoved are SLA group entries whose sample list is gone.
+	SLAGroupsRemoved int
+
+	// WorkersForgotten are worker registry entries silent for longer than
+	// domain.WorkerForgetAfter.
+	WorkersForgotten int
+
+	// DeadLettersExpired and QuarantinedExpired are terminal task records
+	// deleted because they outlived their retention.
+	DeadLettersExpired int
+	QuarantinedExpired int
+}
+
+// Total returns the number of repairs of any kind.
+func (r RepairReport) Total() int {
+	return r.IndexEntriesRemoved + r.IndexEntriesAdded +
+		r.DeadLettersRemoved + r.DeadLettersIndexed +
+		r.SLAGroupsRemoved + r.WorkersForgotten +
+		r.DeadLettersExpired + r.QuarantinedExpired
+}
@@ -0,0 +1,17 @@
+package entity
+
+import "time"
+
+// ScheduledTask is a task waiting in the schedule with the time its next
+// attempt is due.
+type ScheduledTask struct {
+	Task  *Task
+	DueAt time.Time
+}
+
+// Reschedule moves one scheduled task's next attempt: to At, or when At is
+// zero, by By from its current due time.
+type Reschedule struct {
+	At time.Time
+	By time.Duration
+}
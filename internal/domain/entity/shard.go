@@ -0,0 +1,54 @@
+package entity
+
+import (
+	"hash/fnv"
+	"strconv"
+)
+
+// ShardOf returns which of shards client-hash shards the client's tasks
+// belong to. Tasks without a client ID share one shard.
+func ShardOf(clientID string, shards int) int {
+	h := fnv.New32a()
+	h.Write([]byte(clientID))
+	return int(h.Sum32() % uint32(shards))
+}
+
+// OwnedShards returns the shards assigned to instance when they are split
+// among members by rendezvous hashing: each shard goes to the member with
+// the highest weight for it. Every member computing this over the same
+// member list gets disjoint assignments covering all shards, and a member
+// joining or leaving only moves the shards it gains or gives up.
+func OwnedShards(instance string, members []string, shards int) []int {
+	var owned []int
+	for shard := 0; shard < shards; shard++ {
+		var owner string
+		var best uint64
+		for _, m := range members {
+			w := shardWeight(m, shard)
+			if owner == "" || w > best || (w == best && m < owner) {
+				owner, best = m, w
+			}
+		}
+		if owner == instance {
+			owned = append(owned, shard)
+		}
+	}
+	return owned
+}
+
+// shardWeight hashes member and shard together. FNV alone barely changes
+// its high bits between neighbouring shard numbers, so the result is put
+// through the murmur3 finalizer to spread shards evenly across members.
+func shardWeight(member string, shard int) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(member))
+	h.Write([]byte{0})
+	h.Write([]byte(strconv.Itoa(shard)))
+	x := h.Sum64()
+	x ^= x >> 33
+	x *= 0xff51afd7ed558ccd
+	x ^= x >> 33
+	x *= 0xc4ceb9fe1a85ec53
+	x ^= x >> 33
+	return x
+}
@@ -0,0 +1,60 @@
+package entity
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestShardOf(t *testing.T) {
+	for _, client := range []string{"", "client-1", "client-2"} {
+		shard := ShardOf(client, 16)
+		if shard < 0 || shard >= 16 {
+			t.Fatalf("shard %d out of range for %q", shard, client)
+		}
+		if again := ShardOf(client, 16); again != shard {
+			t.Fatalf("expected stable shard for %q, got %d then %d", client, shard, again)
+		}
+	}
+}
+
+func TestOwnedShards(t *testing.T) {
+	members := []string{"worker-a", "worker-b", "worker-c"}
+	const shards = 64
+
+	owners := make(map[int]string)
+	for _, m := range members {
+		owned := OwnedShards(m, members, shards)
+		if len(owned) == 0 {
+			t.Fatalf("expected %s to own some shards", m)
+		}
+		for _, shard := range owned {
+			if other, ok := owners[shard]; ok {
+				t.Fatalf("shard %d owned by both %s and %s", shard, other, m)
+			}
+			owners[shard] = m
+		}
+	}
+	if len(owners) != shards {
+		t.Fatalf("expected all %d shards owned, got %d", shards, len(owners))
+	}
+
+	// Removing a member only moves the shards it owned.
+	remaining := members[:2]
+	for _, m := range remaining {
+		for _, shard := range OwnedShards(m, remaining, shards) {
+			if prev := owners[shard]; prev != m && prev != "worker-c" {
+				t.Fatalf("shard %d moved from %s to %s", shard, prev, m)
+			}
+		}
+	}
+}
+
+func TestOwnedShards_notMember(t *testing.T) {
+	members := make([]string, 4)
+	for i := range members {
+		members[i] = fmt.Sprintf("worker-%d", i)
+	}
+	if owned := OwnedShards("worker-x", members, 8); len(owned) != 0 {
+		t.Fatalf("expected no shards for a non-member, got %v", owned)
+	}
+}
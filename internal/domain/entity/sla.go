@@ -0,0 +1,44 @@
+package entity
+
+import (
+	"sort"
+	"time"
+)
+
+// TimeToSuccessStats summarizes how long tasks for one client and
+// destination took from creation to successful delivery.
+type TimeToSuccessStats struct {
+	ClientID    string
+	Destination string // Destination.Name()
+	Count       int
+	P50         time.Duration
+	P90         time.Duration
+	P99         time.Duration
+	Max         time.Duration
+}
+
+// NewTimeToSuccessStats computes nearest-rank percentiles over samples.
+func NewTimeToSuccessStats(clientID, destination string, samples []time.Duration) TimeToSuccessStats {
+	stats := TimeToSuccessStats{ClientID: clientID, Destination: destination, Count: len(samples)}
+	if len(samples) == 0 {
+		return stats
+	}
+
+	sorted := append([]time.Duration(nil), samples...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	stats.P50 = percentile(sorted, 50)
+	stats.P90 = percentile(sorted, 90)
+	stats.P99 = percentile(sorted, 99)
+	stats.Max = sorted[len(sorted)-1]
+	return stats
+}
+
+// percentile returns the nearest-rank p-th percentile of sorted samples.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	rank := (p*len(sorted) + 99) / 100 // ceil(p/100 * n)
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
@@ -0,0 +1,50 @@
+package entity
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewTimeToSuccessStats(t *testing.T) {
+	samples := make([]time.Duration, 0, 100)
+	for i := 100; i >= 1; i-- {
+		samples = append(samples, time.Duration(i)*time.Second)
+	}
+
+	stats := NewTimeToSuccessStats("client-1", "orders", samples)
+
+	tests := []struct {
+		name string
+		got  interface{}
+		want interface{}
+	}{
+		{"Count", stats.Count, 100},
+		{"P50", stats.P50, 50 * time.Second},
+		{"P90", stats.P90, 90 * time.Second},
+		{"P99", stats.P99, 99 * time.Second},
+		{"Max", stats.Max, 100 * time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Fatalf("got %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+
+	if samples[0] != 100*time.Second {
+		t.Fatalf("input samples must not be reordered")
+	}
+}
+
+func TestNewTimeToSuccessStats_singleAndEmpty(t *testing.T) {
+	single := NewTimeToSuccessStats("c", "d", []time.Duration{3 * time.Second})
+	if single.P50 != 3*time.Second || single.P99 != 3*time.Second || single.Max != 3*time.Second {
+		t.Fatalf("single sample should fill every percentile, got %+v", single)
+	}
+
+	empty := NewTimeToSuccessStats("c", "d", nil)
+	if empty.Count != 0 || empty.Max != 0 {
+		t.Fatalf("expected zero stats, got %+v", empty)
+	}
+}
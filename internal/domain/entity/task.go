@@ -5,12 +5,16 @@ import (
 	"time"
 )
 
-// DestinationType defines the type of message destination.
-type DestinationType string
+// TaskState describes where a task is in its lifecycle.
+type TaskState string
 
 const (
-	DestinationTypeKafka DestinationType = "kafka"
-	DestinationTypeHTTP  DestinationType = "http"
+	// StateDelivered means the message reached its destination.
+	StateDelivered TaskState = "delivered"
+	// StateDead means retries were exhausted and the task was dead-lettered.
+	StateDead TaskState = "dead"
+	// StateQuarantined means the task was isolated as a poison message.
+	StateQuarantined TaskState = "quarantined"
 )
 
 // Task represents a retryable unit of work that delivers a message
@@ -22,11 +26,92 @@ type Task struct {
 	Destination     Destination
 	DeadDestination Destination
 	MaxRetries      int
-	BaseDelay       int
+	BaseDelay       time.Duration
 	ClientID        string
 	IsPriority      bool
 	MessageData     string
 	DestinationType DestinationType
+
+	// FallbackDeadDestinations are tried in order when producing to
+	// DeadDestination keeps failing. The Redis dead-letter store remains the
+	// last resort after all of them.
+	FallbackDeadDestinations []Destination
+
+	// FanOutDestinations receive the same message as Destination. A task
+	// declaring them is split on creation into one task per destination,
+	// each retried and dead-lettered independently; see FanOut.
+	FanOutDestinations []Destination
+
+	// AttemptImmediately makes the first attempt due as soon as the task is
+	// created instead of BaseDelay later. Retries back off from BaseDelay
+	// either way.
+	AttemptImmediately bool
+
+	// Jitter randomizes the delay before each retry; empty uses the
+	// deployment default. See RetryDelay.
+	Jitter JitterMode
+
+	// MaxDelay caps the delay before a retry, so long retry chains plateau
+	// instead of growing without bound. Zero leaves it uncapped.
+	MaxDelay time.Duration
+
+	// RetryDelays, when set, replaces exponential backoff with an explicit
+	// schedule: retry n waits RetryDelays[n-1], and the task is dead-lettered
+	// once the schedule is used up. It overrides MaxRetries.
+	RetryDelays []time.Duration
+
+	// OrderingKey, when set, enforces strict FIFO delivery among tasks that
+	// share it: a task is not attempted until the previous one is settled.
+	OrderingKey string
+
+	// Queue names the queue the task is polled from, so a worker can poll
+	// latency-sensitive queues more often than bulk ones. Empty is the
+	// default queue.
+	Queue string
+
+	// Metadata is caller-defined context such as correlation IDs. It is
+	// stored with the task and sent with every delivery as headers.
+	Metadata map[string]string
+
+	// CorrelationID ties the task to the transaction that created it. It is
+	// sent as the X-Correlation-ID header with every delivery attempt and
+	// dead-letter message.
+	CorrelationID string
+
+	// CallbackURL, when set, receives a POST describing the final outcome
+	// once the task reaches a terminal state.
+	CallbackURL string
+
+	// CreatedAt is when the task was accepted by CreateTask.
+	CreatedAt time.Time
+
+	// FirstAttemptAt and LastAttemptAt record when delivery was first and
+	// most recently attempted. Both are zero until the first attempt.
+	FirstAttemptAt time.Time
+	LastAttemptAt  time.Time
+
+	// LastError is the error message of the most recent failed attempt.
+	LastError string
+	// LastErrorCode classifies LastError.
+	LastErrorCode ErrorCode
+	// RepeatedFailures counts consecutive fast failures that returned LastError.
+	RepeatedFailures int
+
+	// Redrives counts how often the task was re-driven after exhausting its
+	// retries instead of being dead-lettered.
+	Redrives int
+
+	// LastRetryDelay is the delay the current attempt was scheduled with,
+	// which decorrelated jitter grows from.
+	LastRetryDelay time.Duration
+}
+
+// MarkAttempted records a delivery attempt started at the given time.
+func (t *Task) MarkAttempted(at time.Time) {
+	if t.FirstAttemptAt.IsZero() {
+		t.FirstAttemptAt = at
+	}
+	t.LastAttemptAt = at
 }
 
 // IncrementAttempt advances the attempt counter by one.
@@ -40,14 +125,91 @@ func (t *Task) HasRetriesLeft() bool {
 }
 
 // NextRetryDelay calculates the exponential backoff delay for the current attempt.
-// Formula: baseDelay * 2^(attempt-1)
+// Formula: baseDelay * 2^(attempt-1), capped at MaxDelay when it is set.
+// Tasks with RetryDelays take the scheduled delay instead, repeating the
+// last one for attempts past the end of the schedule.
 func (t *Task) NextRetryDelay() time.Duration {
+	if n := len(t.RetryDelays); n > 0 {
+		delay := t.RetryDelays[min(max(t.Attempt-1, 0), n-1)]
+		if t.MaxDelay > 0 && delay > t.MaxDelay {
+			return t.MaxDelay
+		}
+		return delay
+	}
 	exponent := float64(t.Attempt - 1)
 	if exponent < 0 {
 		exponent = 0
 	}
 	multiplier := math.Pow(2, exponent)
-	return time.Duration(float64(t.BaseDelay)*multiplier) * time.Second
+	delay := float64(t.BaseDelay) * multiplier
+	if t.MaxDelay > 0 && delay > float64(t.MaxDelay) {
+		return t.MaxDelay
+	}
+	return time.Duration(delay)
+}
+
+// FirstAttemptDelay returns how long after creation the first attempt is
+// due: BaseDelay, or zero when AttemptImmediately is set.
+func (t *Task) FirstAttemptDelay() time.Duration {
+	if t.AttemptImmediately {
+		return 0
+	}
+	return t.BaseDelay
+}
+
+// RetrySchedule returns when each attempt of the task would be due if it
+// were created at created and every attempt failed: the first attempt
+// FirstAttemptDelay after created, then one per retry, each RetryDelay
+// after the previous one with jitter applied according to mode, drawing
+// random every time. Random 0 gives the earliest schedule the mode allows
+// and random 1 the latest. Time spent delivering is not included.
+func (t *Task) RetrySchedule(created time.Time, mode JitterMode, random float64) []time.Time {
+	retries := t.MaxRetries
+	if len(t.RetryDelays) > 0 {
+		retries = len(t.RetryDelays)
+	}
+	schedule := make([]time.Time, 0, max(retries, 0)+1)
+	at := created.Add(t.FirstAttemptDelay())
+	schedule = append(schedule, at)
+
+	retry := Task{BaseDelay: t.BaseDelay, MaxDelay: t.MaxDelay, RetryDelays: t.RetryDelays}
+	for retry.Attempt = 1; retry.Attempt <= retries; retry.Attempt++ {
+		retry.LastRetryDelay = retry.RetryDelay(mode, random)
+		at = at.Add(retry.LastRetryDelay)
+		schedule = append(schedule, at)
+	}
+	return schedule
+}
+
+// RecordFailure tracks the outcome of a failed attempt. Failures that repeat
+// the previous error message extend the streak; any other failure restarts it.
+// Slow failures (fast == false) reset the streak, since they usually point at
+// an unhealthy destination rather than a malformed message.
+func (t *Task) RecordFailure(errMsg string, fast bool) {
+	switch {
+	case !fast:
+		t.RepeatedFailures = 0
+	case errMsg == t.LastError:
+		t.RepeatedFailures++
+	default:
+		t.RepeatedFailures = 1
+	}
+	t.LastError = errMsg
+}
+
+// IsPoison reports whether the task has failed fast with the same error
+// at least threshold times in a row. A threshold of 0 disables detection.
+func (t *Task) IsPoison(threshold int) bool {
+	return threshold > 0 && t.RepeatedFailures >= threshold
+}
+
+// Redrive gives a task that exhausted its retries a fresh budget of
+// maxRetries retries and counts the re-drive.
+func (t *Task) Redrive(maxRetries int) {
+	t.Attempt = 0
+	t.MaxRetries = maxRetries
+	t.RepeatedFailures = 0
+	t.Redrives++
 }
 
 // ShouldSendToDeadDestination reports whether the task has exhausted
@@ -64,46 +64,97 @@ func TestTask_NextRetryDelay(t *testing.T) {
 	tests := []struct {
 		name      string
 		attempt   int
-		baseDelay int
+		baseDelay time.Duration
+		maxDelay  time.Duration
+		schedule  []time.Duration
 		want      time.Duration
 	}{
 		{
 			name:      "first retry",
 			attempt:   1,
-			baseDelay: 2,
+			baseDelay: 2 * time.Second,
 			want:      2 * time.Second,
 		},
 		{
 			name:      "second retry",
 			attempt:   2,
-			baseDelay: 2,
+			baseDelay: 2 * time.Second,
 			want:      4 * time.Second,
 		},
 		{
 			name:      "third retry",
 			attempt:   3,
-			baseDelay: 2,
+			baseDelay: 2 * time.Second,
 			want:      8 * time.Second,
 		},
 		{
 			name:      "zero attempt uses exponent 0",
 			attempt:   0,
-			baseDelay: 1,
+			baseDelay: 1 * time.Second,
 			want:      1 * time.Second,
 		},
 		{
 			name:      "base delay of 1",
 			attempt:   4,
-			baseDelay: 1,
+			baseDelay: 1 * time.Second,
 			want:      8 * time.Second,
 		},
+		{
+			name:      "below max delay",
+			attempt:   3,
+			baseDelay: 2 * time.Second,
+			maxDelay:  10 * time.Second,
+			want:      8 * time.Second,
+		},
+		{
+			name:      "capped at max delay",
+			attempt:   4,
+			baseDelay: 2 * time.Second,
+			maxDelay:  10 * time.Second,
+			want:      10 * time.Second,
+		},
+		{
+			name:      "long chain plateaus",
+			attempt:   100,
+			baseDelay: time.Minute,
+			maxDelay:  time.Hour,
+			want:      time.Hour,
+		},
+		{
+			name:     "schedule picks the delay for the attempt",
+			attempt:  2,
+			schedule: []time.Duration{10 * time.Second, time.Minute, 10 * time.Minute},
+			want:     time.Minute,
+		},
+		{
+			name:      "schedule ignores the base delay",
+			attempt:   1,
+			baseDelay: time.Hour,
+			schedule:  []time.Duration{10 * time.Second, time.Minute},
+			want:      10 * time.Second,
+		},
+		{
+			name:     "schedule repeats its last delay",
+			attempt:  5,
+			schedule: []time.Duration{10 * time.Second, time.Minute},
+			want:     time.Minute,
+		},
+		{
+			name:     "schedule capped at max delay",
+			attempt:  2,
+			maxDelay: 30 * time.Second,
+			schedule: []time.Duration{10 * time.Second, time.Minute},
+			want:     30 * time.Second,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			task := &Task{
-				Attempt:   tt.attempt,
-				BaseDelay: tt.baseDelay,
+				Attempt:     tt.attempt,
+				BaseDelay:   tt.baseDelay,
+				MaxDelay:    tt.maxDelay,
+				RetryDelays: tt.schedule,
 			}
 			got := task.NextRetryDelay()
 			if got != tt.want {
@@ -113,6 +164,133 @@ func TestTask_NextRetryDelay(t *testing.T) {
 	}
 }
 
+func TestTask_RetryDelay(t *testing.T) {
+	tests := []struct {
+		name     string
+		mode     JitterMode
+		maxDelay time.Duration
+		last     time.Duration
+		schedule []time.Duration
+		random   float64
+		want     time.Duration
+	}{
+		{name: "default waits the exponential delay", random: 0.5, want: 8 * time.Second},
+		{name: "none waits the exponential delay", mode: JitterNone, random: 0.5, want: 8 * time.Second},
+		{name: "full scales the whole delay", mode: JitterFull, random: 0.25, want: 2 * time.Second},
+		{name: "full can retry at once", mode: JitterFull, random: 0, want: 0},
+		{name: "equal keeps half the delay", mode: JitterEqual, random: 0, want: 4 * time.Second},
+		{name: "equal scales the other half", mode: JitterEqual, random: 0.5, want: 6 * time.Second},
+		{name: "decorrelated starts from the base delay", mode: JitterDecorrelated, random: 0.5, want: 4 * time.Second},
+		{name: "decorrelated grows from the last delay", mode: JitterDecorrelated, last: 10 * time.Second, random: 0.5, want: 16 * time.Second},
+		{name: "decorrelated never waits less than the base delay", mode: JitterDecorrelated, last: 10 * time.Second, want: 2 * time.Second},
+		{name: "full jitters the capped delay", mode: JitterFull, maxDelay: 6 * time.Second, random: 0.5, want: 3 * time.Second},
+		{name: "decorrelated is capped", mode: JitterDecorrelated, maxDelay: 12 * time.Second, last: 10 * time.Second, random: 0.5, want: 12 * time.Second},
+		{name: "full jitters the scheduled delay", mode: JitterFull, schedule: []time.Duration{time.Second, time.Second, 20 * time.Second}, random: 0.5, want: 10 * time.Second},
+		{name: "decorrelated keeps the scheduled delay", mode: JitterDecorrelated, schedule: []time.Duration{20 * time.Second}, last: 10 * time.Second, random: 0.5, want: 20 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &Task{Attempt: 3, BaseDelay: 2 * time.Second, MaxDelay: tt.maxDelay, RetryDelays: tt.schedule, LastRetryDelay: tt.last}
+			if got := task.RetryDelay(tt.mode, tt.random); got != tt.want {
+				t.Fatalf("RetryDelay() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTask_RetrySchedule(t *testing.T) {
+	created := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		maxRetries int
+		immediate  bool
+		maxDelay   time.Duration
+		schedule   []time.Duration
+		jitter     JitterMode
+		random     float64
+		want       []time.Duration // after created
+	}{
+		{
+			name:       "first attempt then each retry",
+			maxRetries: 3,
+			want:       []time.Duration{2 * time.Second, 4 * time.Second, 8 * time.Second, 16 * time.Second},
+		},
+		{
+			name:       "no retries",
+			maxRetries: 0,
+			want:       []time.Duration{2 * time.Second},
+		},
+		{
+			name:       "immediate first attempt",
+			maxRetries: 2,
+			immediate:  true,
+			want:       []time.Duration{0, 2 * time.Second, 6 * time.Second},
+		},
+		{
+			name:       "capped retries",
+			maxRetries: 3,
+			maxDelay:   5 * time.Second,
+			want:       []time.Duration{2 * time.Second, 4 * time.Second, 8 * time.Second, 13 * time.Second},
+		},
+		{
+			name:       "explicit schedule overrides max retries",
+			maxRetries: 5,
+			schedule:   []time.Duration{10 * time.Second, time.Minute},
+			want:       []time.Duration{2 * time.Second, 12 * time.Second, 72 * time.Second},
+		},
+		{
+			name:       "earliest with full jitter",
+			maxRetries: 3,
+			jitter:     JitterFull,
+			want:       []time.Duration{2 * time.Second, 2 * time.Second, 2 * time.Second, 2 * time.Second},
+		},
+		{
+			name:       "latest with full jitter",
+			maxRetries: 3,
+			jitter:     JitterFull,
+			random:     1,
+			want:       []time.Duration{2 * time.Second, 4 * time.Second, 8 * time.Second, 16 * time.Second},
+		},
+		{
+			name:       "earliest with equal jitter",
+			maxRetries: 3,
+			jitter:     JitterEqual,
+			want:       []time.Duration{2 * time.Second, 3 * time.Second, 5 * time.Second, 9 * time.Second},
+		},
+		{
+			name:       "earliest with decorrelated jitter",
+			maxRetries: 3,
+			jitter:     JitterDecorrelated,
+			want:       []time.Duration{2 * time.Second, 4 * time.Second, 6 * time.Second, 8 * time.Second},
+		},
+		{
+			name:       "latest with decorrelated jitter grows from the previous delay",
+			maxRetries: 3,
+			jitter:     JitterDecorrelated,
+			random:     1,
+			want:       []time.Duration{2 * time.Second, 8 * time.Second, 26 * time.Second, 80 * time.Second},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &Task{MaxRetries: tt.maxRetries, BaseDelay: 2 * time.Second, MaxDelay: tt.maxDelay, RetryDelays: tt.schedule, AttemptImmediately: tt.immediate}
+
+			got := task.RetrySchedule(created, tt.jitter, tt.random)
+			if len(got) != len(tt.want) {
+				t.Fatalf("expected %d attempts, got %v", len(tt.want), got)
+			}
+			for i, offset := range tt.want {
+				if !got[i].Equal(created.Add(offset)) {
+					t.Fatalf("attempt %d: expected %v, got %v", i, created.Add(offset), got[i])
+				}
+			}
+		})
+	}
+}
+
 func TestTask_ShouldSendToDeadDestination(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -155,3 +333,199 @@ func TestDestination_Address(t *testing.T) {
 		t.Fatalf("Address() = %q, want %q", got, want)
 	}
 }
+
+func TestTask_RecordFailure(t *testing.T) {
+	tests := []struct {
+		name       string
+		lastError  string
+		repeated   int
+		errMsg     string
+		fast       bool
+		wantRepeat int
+	}{
+		{
+			name:       "first failure starts streak",
+			errMsg:     "status 400",
+			fast:       true,
+			wantRepeat: 1,
+		},
+		{
+			name:       "same fast failure extends streak",
+			lastError:  "status 400",
+			repeated:   2,
+			errMsg:     "status 400",
+			fast:       true,
+			wantRepeat: 3,
+		},
+		{
+			name:       "different error restarts streak",
+			lastError:  "status 400",
+			repeated:   2,
+			errMsg:     "status 422",
+			fast:       true,
+			wantRepeat: 1,
+		},
+		{
+			name:       "slow failure resets streak",
+			lastError:  "status 400",
+			repeated:   2,
+			errMsg:     "status 400",
+			fast:       false,
+			wantRepeat: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &Task{LastError: tt.lastError, RepeatedFailures: tt.repeated}
+			task.RecordFailure(tt.errMsg, tt.fast)
+			if task.RepeatedFailures != tt.wantRepeat {
+				t.Fatalf("RepeatedFailures = %d, want %d", task.RepeatedFailures, tt.wantRepeat)
+			}
+			if task.LastError != tt.errMsg {
+				t.Fatalf("LastError = %q, want %q", task.LastError, tt.errMsg)
+			}
+		})
+	}
+}
+
+func TestTask_IsPoison(t *testing.T) {
+	tests := []struct {
+		name      string
+		repeated  int
+		threshold int
+		want      bool
+	}{
+		{name: "detection disabled", repeated: 10, threshold: 0, want: false},
+		{name: "below threshold", repeated: 2, threshold: 3, want: false},
+		{name: "at threshold", repeated: 3, threshold: 3, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &Task{RepeatedFailures: tt.repeated}
+			if got := task.IsPoison(tt.threshold); got != tt.want {
+				t.Fatalf("IsPoison() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTask_Redrive(t *testing.T) {
+	task := &Task{Attempt: 6, MaxRetries: 5, RepeatedFailures: 2, Redrives: 1}
+	task.Redrive(2)
+
+	if task.Attempt != 0 || task.MaxRetries != 2 || task.RepeatedFailures != 0 {
+		t.Fatalf("retry state not reset: %+v", task)
+	}
+	if task.Redrives != 2 {
+		t.Fatalf("expected redrives 2, got %d", task.Redrives)
+	}
+	if !task.HasRetriesLeft() {
+		t.Fatal("expected a re-driven task to have retries left")
+	}
+}
+
+func TestDestination_Type(t *testing.T) {
+	tests := []struct {
+		name string
+		dest Destination
+		want DestinationType
+	}{
+		{name: "url means http", dest: Destination{URL: "http://localhost/dead"}, want: DestinationTypeHTTP},
+		{name: "topic means kafka", dest: Destination{Host: "localhost", Port: "9092", Topic: "dead"}, want: DestinationTypeKafka},
+		{name: "url wins over topic", dest: Destination{Topic: "dead", URL: "http://localhost/dead"}, want: DestinationTypeHTTP},
+		{name: "empty destination", dest: Destination{}, want: ""},
+		{name: "host without topic", dest: Destination{Host: "localhost", Port: "9092"}, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.dest.Type(); got != tt.want {
+				t.Fatalf("Type() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDestinationType_Validate(t *testing.T) {
+	tests := []struct {
+		name            string
+		destinationType DestinationType
+		dest            Destination
+		wantErr         string
+	}{
+		{name: "http with url", destinationType: DestinationTypeHTTP, dest: Destination{URL: "http://localhost/hook"}},
+		{name: "kafka with topic", destinationType: DestinationTypeKafka, dest: Destination{Topic: "orders"}},
+		{name: "http without url", destinationType: DestinationTypeHTTP, dest: Destination{Topic: "orders"}, wantErr: "destination URL is required"},
+		{name: "kafka without topic", destinationType: DestinationTypeKafka, dest: Destination{URL: "http://localhost/hook"}, wantErr: "destination topic is required"},
+		{name: "unsupported type", destinationType: "sqs", dest: Destination{Topic: "orders"}, wantErr: `unsupported destination type "sqs"`},
+		{name: "http with slack format", destinationType: DestinationTypeHTTP, dest: Destination{URL: "https://hooks.slack.com/x", Format: PayloadFormatSlack}},
+		{name: "kafka with format", destinationType: DestinationTypeKafka, dest: Destination{Topic: "orders", Format: PayloadFormatTeams}, wantErr: `payload format "teams" requires an HTTP destination`},
+		{name: "unsupported format", destinationType: DestinationTypeHTTP, dest: Destination{URL: "http://localhost/hook", Format: "discord"}, wantErr: `unsupported payload format "discord"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.destinationType.Validate(tt.dest)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Fatalf("expected error %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func BenchmarkTask_NextRetryDelay(b *testing.B) {
+	task := &Task{BaseDelay: 5, MaxRetries: 10}
+	for i := 0; i < b.N; i++ {
+		task.Attempt = i%task.MaxRetries + 1
+		_ = task.NextRetryDelay()
+	}
+}
+
+func TestTask_FanOut(t *testing.T) {
+	task := &Task{
+		ID:              "evt-1",
+		Destination:     Destination{Topic: "orders"},
+		DestinationType: DestinationTypeKafka,
+		Metadata:        map[string]string{"tenant": "acme"},
+		FanOutDestinations: []Destination{
+			{URL: "https://partner/hook"},
+			{Topic: "audit"},
+		},
+	}
+
+	tasks := task.FanOut()
+	if len(tasks) != 3 {
+		t.Fatalf("expected 3 tasks, got %d", len(tasks))
+	}
+	if tasks[0] != task || task.FanOutDestinations != nil {
+		t.Fatalf("expected the original task first with fan-out cleared, got %+v", tasks[0])
+	}
+
+	hook := tasks[1]
+	if hook.ID != "evt-1#1" || hook.Destination.URL != "https://partner/hook" || hook.DestinationType != DestinationTypeHTTP {
+		t.Fatalf("unexpected http copy: %+v", hook)
+	}
+	if tasks[2].ID != "evt-1#2" || tasks[2].DestinationType != DestinationTypeKafka {
+		t.Fatalf("unexpected kafka copy: %+v", tasks[2])
+	}
+
+	hook.Metadata["tenant"] = "other"
+	if task.Metadata["tenant"] != "acme" {
+		t.Fatal("expected copies not to share metadata")
+	}
+}
+
+func TestTask_FanOut_none(t *testing.T) {
+	task := &Task{ID: "evt-1"}
+	if tasks := task.FanOut(); len(tasks) != 1 || tasks[0] != task {
+		t.Fatalf("expected the task alone, got %v", tasks)
+	}
+}
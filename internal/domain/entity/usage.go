@@ -0,0 +1,28 @@
+package entity
+
+import "time"
+
+// UsagePeriodLayout formats billing periods: calendar months in UTC.
+const UsagePeriodLayout = "2006-01"
+
+// ClientUsage counts the attempts made for one client's tasks during a
+// billing period. Delivered counts successful attempts and Retried counts
+// failed attempts that were rescheduled.
+type ClientUsage struct {
+	ClientID  string
+	Period    string
+	Delivered int64
+	Retried   int64
+}
+
+// UsagePeriod returns the billing period containing t.
+func UsagePeriod(t time.Time) string {
+	return t.UTC().Format(UsagePeriodLayout)
+}
+
+// ValidUsagePeriod reports whether period is a billing period such as
+// "2024-05".
+func ValidUsagePeriod(period string) bool {
+	_, err := time.Parse(UsagePeriodLayout, period)
+	return err == nil
+}
@@ -0,0 +1,33 @@
+package entity
+
+import (
+	"testing"
+	"time"
+)
+
+func TestUsagePeriod(t *testing.T) {
+	// Late on the last day of the month in UTC-5 is already the next month in UTC.
+	at := time.Date(2024, 5, 31, 22, 0, 0, 0, time.FixedZone("EST", -5*3600))
+	if got := UsagePeriod(at); got != "2024-06" {
+		t.Fatalf("expected 2024-06, got %s", got)
+	}
+}
+
+func TestValidUsagePeriod(t *testing.T) {
+	tests := []struct {
+		period string
+		want   bool
+	}{
+		{"2024-05", true},
+		{"2024-13", false},
+		{"2024-5", false},
+		{"May 2024", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if got := ValidUsagePeriod(tt.period); got != tt.want {
+			t.Fatalf("ValidUsagePeriod(%q) = %v, want %v", tt.period, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package entity
+
+import "time"
+
+// WorkerInstance describes one worker replica known to the worker
+// registry. Processed counts the tasks its polls attempted, and Errors the
+// attempts that failed, since StartedAt.
+type WorkerInstance struct {
+	ID        string
+	LastBeat  time.Time
+	StartedAt time.Time // zero if the instance has not finished a poll
+	Processed int64
+	Errors    int64
+	Shards    []int // client-hash shards owned at the last poll; nil unless sharding is enabled
+	Stale     bool  // the instance has not beaten within the heartbeat TTL
+}
+
+// HeartbeatAge returns how long ago the instance last beat.
+func (w WorkerInstance) HeartbeatAge(now time.Time) time.Duration {
+	return now.Sub(w.LastBeat)
+}
+
+// Throughput returns the tasks processed per minute since StartedAt.
+func (w WorkerInstance) Throughput(now time.Time) float64 {
+	elapsed := now.Sub(w.StartedAt)
+	if w.StartedAt.IsZero() || elapsed <= 0 {
+		return 0
+	}
+	return float64(w.Processed) / elapsed.Minutes()
+}
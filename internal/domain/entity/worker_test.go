@@ -0,0 +1,26 @@
+package entity
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWorkerInstance_Throughput(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name   string
+		worker WorkerInstance
+		want   float64
+	}{
+		{name: "per minute since start", worker: WorkerInstance{StartedAt: now.Add(-10 * time.Minute), Processed: 300}, want: 30},
+		{name: "not started", worker: WorkerInstance{Processed: 300}, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.worker.Throughput(now); got != tt.want {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
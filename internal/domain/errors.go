@@ -9,6 +9,10 @@ var (
 	// ErrInvalidTask indicates the task failed validation.
 	ErrInvalidTask = errors.New("invalid task")
 
+	// ErrQueueFull indicates a new task was rejected because the number of
+	// scheduled tasks has reached the configured maximum.
+	ErrQueueFull = errors.New("queue full")
+
 	// ErrScheduleFailed indicates a failure when scheduling a task for retry.
 	ErrScheduleFailed = errors.New("failed to schedule task")
 
@@ -17,4 +21,36 @@ var (
 
 	// ErrMaxRetriesExceeded indicates the task exhausted all retry attempts.
 	ErrMaxRetriesExceeded = errors.New("max retries exceeded")
+
+	// ErrInvalidMaintenanceWindow indicates a maintenance window failed validation.
+	ErrInvalidMaintenanceWindow = errors.New("invalid maintenance window")
+
+	// ErrInvalidQuery indicates an admin or reporting query failed validation.
+	ErrInvalidQuery = errors.New("invalid query")
+
+	// ErrUnknownQueue indicates an admin operation named a queue that does not exist.
+	ErrUnknownQueue = errors.New("unknown queue")
+
+	// ErrConfirmationRequired indicates a destructive operation was attempted
+	// without a valid confirmation token.
+	ErrConfirmationRequired = errors.New("confirmation required")
+
+	// ErrPoisonMessage indicates the task keeps failing instantly with the same error.
+	ErrPoisonMessage = errors.New("poison message")
+
+	// ErrNoLease indicates a lease extension was requested with a context
+	// that does not belong to a tracked delivery attempt.
+	ErrNoLease = errors.New("no delivery lease in context")
+
+	// ErrRequestTooLarge indicates an HTTP delivery was not sent because its
+	// body exceeds the configured request size limit.
+	ErrRequestTooLarge = errors.New("request body too large")
+
+	// ErrEgressDenied indicates an HTTP connection was refused because the
+	// egress policy does not allow the destination's address.
+	ErrEgressDenied = errors.New("egress denied")
+
+	// ErrReconcileInProgress indicates an on-demand repair pass was requested
+	// while another was still running.
+	ErrReconcileInProgress = errors.New("reconcile in progress")
 )
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+	"github.com/ruudy-sib/rebound/internal/port/secondary"
+)
+
+// AttemptHook runs right before a delivery attempt and may rewrite the
+// message about to be sent, e.g. to refresh an expiring auth token or
+// re-sign the payload. A hook that runs for long can keep the task from
+// being reclaimed with ExtendLease. Changes apply to that attempt only; the
+// stored task is left untouched and must not be modified by the hook. An
+// error fails the attempt as a delivery error would.
+type AttemptHook func(ctx context.Context, task *entity.Task, msg *secondary.Message) error
+
+// attemptMessage builds the message for the task's current attempt and runs
+// the attempt hooks registered for every task and then those registered for
+// its destination, together bounded by the attempt hook timeout.
+func (s *TaskService) attemptMessage(ctx context.Context, task *entity.Task) (msg secondary.Message, err error) {
+	defer recoverDelivery(&err)
+
+	msg = secondary.Message{
+		Key:              []byte(fmt.Sprintf("%s|%d", task.ID, task.Attempt)),
+		Value:            []byte(task.MessageData),
+		Headers:          task.Metadata,
+		TransportHeaders: correlationHeaders(task),
+		Priority:         task.IsPriority,
+	}
+	if len(s.attemptHooks) == 0 {
+		return msg, nil
+	}
+
+	// Hooks may edit the headers in place; keep the task's metadata intact.
+	msg.Headers = maps.Clone(task.Metadata)
+
+	ctx, cancel := context.WithTimeout(s.withLease(ctx, task), s.hookTimeout)
+	defer cancel()
+
+	hooks := slices.Concat(s.attemptHooks[""], s.attemptHooks[destinationKey(task)])
+	for _, hook := range hooks {
+		if err := hook(ctx, task, &msg); err != nil {
+			return msg, fmt.Errorf("%w: attempt hook: %v", domain.ErrDeliveryFailed, err)
+		}
+	}
+	return msg, nil
+}
+
+// correlationHeaders returns the transport header carrying the task's
+// correlation ID, or nil if it has none.
+func correlationHeaders(task *entity.Task) map[string]string {
+	if task.CorrelationID == "" {
+		return nil
+	}
+	return map[string]string{domain.CorrelationIDHeader: task.CorrelationID}
+}
+
+// destinationKey identifies the task's destination for attempt hooks: its
+// URL for HTTP tasks and its topic for Kafka tasks.
+func destinationKey(task *entity.Task) string {
+	if task.DestinationType == entity.DestinationTypeHTTP {
+		return task.Destination.URL
+	}
+	return task.Destination.Topic
+}
@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+	"github.com/ruudy-sib/rebound/internal/port/secondary"
+)
+
+func TestTaskService_ProcessDueTasks_attemptHooks(t *testing.T) {
+	task := testHTTPTask()
+	task.Metadata = map[string]string{"tenant-id": "acme"}
+	scheduler := &mockScheduler{
+		fetchDueFunc: func(_ context.Context, _ int) ([]*entity.Task, error) {
+			return []*entity.Task{task}, nil
+		},
+	}
+	producer := &mockProducer{}
+
+	var order []string
+	svc := NewTaskService(scheduler, producer, zap.NewNop(),
+		WithAttemptHook(task.Destination.URL, func(_ context.Context, _ *entity.Task, msg *secondary.Message) error {
+			order = append(order, "destination")
+			msg.Value = []byte("signed:" + string(msg.Value))
+			msg.TransportHeaders = map[string]string{"Authorization": "Bearer fresh"}
+			return nil
+		}),
+		WithAttemptHook("", func(_ context.Context, _ *entity.Task, msg *secondary.Message) error {
+			order = append(order, "global")
+			msg.Headers["tenant-id"] = "rewritten"
+			return nil
+		}),
+		WithAttemptHook("http://elsewhere/hook", func(context.Context, *entity.Task, *secondary.Message) error {
+			order = append(order, "other")
+			return nil
+		}),
+	)
+
+	if _, err := svc.ProcessDueTasks(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(producer.produceCalls) != 1 {
+		t.Fatalf("expected 1 produce call, got %d", len(producer.produceCalls))
+	}
+
+	call := producer.produceCalls[0]
+	if len(order) != 2 || order[0] != "global" || order[1] != "destination" {
+		t.Fatalf("expected global then destination hook, got %v", order)
+	}
+	if string(call.Value) != "signed:test message data" {
+		t.Fatalf("expected rewritten payload, got %q", call.Value)
+	}
+	if call.Transport["Authorization"] != "Bearer fresh" {
+		t.Fatalf("expected transport header, got %v", call.Transport)
+	}
+	if call.Headers["tenant-id"] != "rewritten" {
+		t.Fatalf("expected rewritten metadata, got %v", call.Headers)
+	}
+	if task.Metadata["tenant-id"] != "acme" || task.MessageData != "test message data" {
+		t.Fatalf("hooks must not change the stored task, got %v %q", task.Metadata, task.MessageData)
+	}
+}
+
+func TestTaskService_ProcessDueTasks_attemptHookError(t *testing.T) {
+	task := testTask()
+	scheduler := &mockScheduler{
+		fetchDueFunc: func(_ context.Context, _ int) ([]*entity.Task, error) {
+			return []*entity.Task{task}, nil
+		},
+	}
+	producer := &mockProducer{}
+	svc := NewTaskService(scheduler, producer, zap.NewNop(),
+		WithAttemptHook("", func(context.Context, *entity.Task, *secondary.Message) error {
+			return errors.New("token endpoint unavailable")
+		}),
+	)
+
+	if _, err := svc.ProcessDueTasks(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(producer.produceCalls) != 0 {
+		t.Fatalf("expected no produce call, got %d", len(producer.produceCalls))
+	}
+	if len(scheduler.scheduledTasks) != 1 {
+		t.Fatalf("expected the failed attempt to be retried, got %d schedules", len(scheduler.scheduledTasks))
+	}
+	if task.Attempt != 1 {
+		t.Fatalf("expected attempt 1, got %d", task.Attempt)
+	}
+}
+
+func TestTaskService_ProcessDueTasks_attemptHookTimeout(t *testing.T) {
+	task := testTask()
+	scheduler := &mockScheduler{
+		fetchDueFunc: func(_ context.Context, _ int) ([]*entity.Task, error) {
+			return []*entity.Task{task}, nil
+		},
+	}
+
+	var hookDeadline, deliveryDeadline time.Time
+	producer := &mockProducer{
+		produceFunc: func(ctx context.Context, _ entity.Destination, _, _ []byte) error {
+			deliveryDeadline, _ = ctx.Deadline()
+			return nil
+		},
+	}
+	svc := NewTaskService(scheduler, producer, zap.NewNop(),
+		WithDeliveryTimeout(time.Second),
+		WithAttemptHookTimeout(time.Hour),
+		WithAttemptHook("", func(ctx context.Context, _ *entity.Task, _ *secondary.Message) error {
+			hookDeadline, _ = ctx.Deadline()
+			return nil
+		}),
+	)
+
+	start := time.Now()
+	if _, err := svc.ProcessDueTasks(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The hook may outlive the delivery timeout; the delivery may not.
+	if hookDeadline.Before(start.Add(59 * time.Minute)) {
+		t.Fatalf("expected the hook to get an hour, got deadline %v", hookDeadline.Sub(start))
+	}
+	if deliveryDeadline.IsZero() || deliveryDeadline.After(start.Add(2*time.Second)) {
+		t.Fatalf("expected the delivery bounded by 1s, got deadline %v", deliveryDeadline.Sub(start))
+	}
+}
@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+// overflowCounters backs entity.OverflowStats.
+type overflowCounters struct {
+	rejected atomic.Int64
+	dropped  atomic.Int64
+	spilled  atomic.Int64
+	restored atomic.Int64
+}
+
+// OverflowStats reports what the overflow policy has done since the
+// service was created.
+func (s *TaskService) OverflowStats() entity.OverflowStats {
+	return entity.OverflowStats{
+		Rejected: s.overflowCount.rejected.Load(),
+		Dropped:  s.overflowCount.dropped.Load(),
+		Spilled:  s.overflowCount.spilled.Load(),
+		Restored: s.overflowCount.restored.Load(),
+	}
+}
+
+// admit makes room for new tasks when scheduling them would take the
+// scheduled set past the configured maximum, as the overflow action says.
+// It reports false if the tasks were spilled and so must not be scheduled.
+// The count is read before scheduling, so concurrent creations may
+// overshoot the maximum slightly.
+func (s *TaskService) admit(ctx context.Context, tasks []*entity.Task) (bool, error) {
+	if s.maxPending <= 0 {
+		return true, nil
+	}
+
+	pending, err := s.scheduler.Count(ctx)
+	if err != nil {
+		return false, fmt.Errorf("%w: counting scheduled tasks: %v", domain.ErrScheduleFailed, err)
+	}
+	over := pending + int64(len(tasks)) - s.maxPending
+	if over <= 0 {
+		return true, nil
+	}
+	s.queueFullAlarm(ctx, pending)
+
+	switch s.overflowAction {
+	case entity.OverflowDropOldest:
+		if s.dropOldest(ctx, int(over)) {
+			return true, nil
+		}
+	case entity.OverflowSpill:
+		if s.overflowStore == nil {
+			break
+		}
+		if err := s.overflowStore.Spill(ctx, tasks...); err != nil {
+			return false, fmt.Errorf("%w: spilling tasks: %v", domain.ErrScheduleFailed, err)
+		}
+		s.overflowCount.spilled.Add(int64(len(tasks)))
+		s.logger.Warn("queue full, tasks spilled",
+			zap.Int64("pending", pending),
+			zap.Int64("max_pending", s.maxPending),
+			zap.Int("tasks", len(tasks)),
+		)
+		return false, nil
+	}
+
+	s.overflowCount.rejected.Add(int64(len(tasks)))
+	s.logger.Warn("task rejected, queue full",
+		zap.Int64("pending", pending),
+		zap.Int64("max_pending", s.maxPending),
+		zap.Int("tasks", len(tasks)),
+	)
+	return false, fmt.Errorf("%w: %d tasks scheduled, maximum is %d", domain.ErrQueueFull, pending, s.maxPending)
+}
+
+// dropOldest removes n of the earliest scheduled non-priority tasks. It
+// drops nothing and reports false if fewer than n are found among the
+// first domain.OverflowDropScanLimit scheduled tasks.
+func (s *TaskService) dropOldest(ctx context.Context, n int) bool {
+	scheduled, _, err := s.scheduler.Peek(ctx, entity.Cursor{}, domain.OverflowDropScanLimit)
+	if err != nil {
+		s.logger.Error("failed to find tasks to drop", zap.Error(err))
+		return false
+	}
+
+	var victims []string
+	for _, st := range scheduled {
+		if !st.Task.IsPriority {
+			victims = append(victims, st.Task.ID)
+		}
+	}
+	if len(victims) < n {
+		return false
+	}
+
+	dropped := 0
+	for _, id := range victims {
+		if dropped == n {
+			break
+		}
+		task, _, err := s.scheduler.Dequeue(ctx, id)
+		if errors.Is(err, domain.ErrTaskNotFound) {
+			// Picked up by a worker in the meantime, which makes room too.
+			dropped++
+			continue
+		}
+		if err != nil {
+			s.logger.Error("failed to drop task", zap.String("task_id", id), zap.Error(err))
+			continue
+		}
+		s.releaseOrdering(ctx, task, s.logger)
+		s.overflowCount.dropped.Add(1)
+		s.logger.Warn("queue full, oldest task dropped",
+			zap.String("task_id", task.ID),
+			zap.String("source", task.Source),
+			zap.String("client_id", task.ClientID),
+		)
+		dropped++
+	}
+	return dropped == n
+}
+
+// restoreSpilled schedules spilled tasks while the scheduled set has room,
+// at most a batch per poll. A task that fails to schedule is spilled
+// again, with the ones after it, at the back of the store.
+func (s *TaskService) restoreSpilled(ctx context.Context) {
+	if s.overflowAction != entity.OverflowSpill || s.overflowStore == nil || s.maxPending <= 0 {
+		return
+	}
+
+	pending, err := s.scheduler.Count(ctx)
+	if err != nil {
+		s.logger.Warn("failed to count scheduled tasks for restore", zap.Error(err))
+		return
+	}
+	room := min(s.maxPending-pending, int64(s.batchSize))
+	if room <= 0 {
+		return
+	}
+
+	tasks, err := s.overflowStore.Restore(ctx, int(room))
+	if err != nil {
+		s.logger.Error("failed to restore spilled tasks", zap.Error(err))
+		return
+	}
+	for i, task := range tasks {
+		if err := s.scheduleNew(ctx, task, 0); err != nil {
+			s.logger.Error("failed to schedule spilled task", zap.String("task_id", task.ID), zap.Error(err))
+			if err := s.overflowStore.Spill(ctx, tasks[i:]...); err != nil {
+				s.logger.Error("spilled tasks lost", zap.Int("tasks", len(tasks)-i), zap.Error(err))
+			}
+			return
+		}
+		s.overflowCount.restored.Add(1)
+	}
+}
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+func TestTaskService_CreateTask_maxPending(t *testing.T) {
+	tests := []struct {
+		name       string
+		maxPending int64
+		count      int64
+		wantErr    error
+	}{
+		{name: "cap disabled", maxPending: 0, count: 1000},
+		{name: "below cap", maxPending: 10, count: 9},
+		{name: "at cap", maxPending: 10, count: 10, wantErr: domain.ErrQueueFull},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheduler := &mockScheduler{count: tt.count}
+			svc := NewTaskService(scheduler, &mockProducer{}, zap.NewNop(), WithMaxPending(tt.maxPending))
+
+			err := svc.CreateTask(context.Background(), testTask())
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			wantScheduled := 1
+			if tt.wantErr != nil {
+				wantScheduled = 0
+			}
+			if len(scheduler.scheduledTasks) != wantScheduled {
+				t.Fatalf("expected %d scheduled tasks, got %d", wantScheduled, len(scheduler.scheduledTasks))
+			}
+		})
+	}
+}
+
+func TestTaskService_CreateTasksPaced_maxPending(t *testing.T) {
+	scheduler := &mockScheduler{count: 8}
+	svc := NewTaskService(scheduler, &mockProducer{}, zap.NewNop(), WithMaxPending(10))
+
+	tasks := []*entity.Task{testTask(), testTask(), testTask()}
+	err := svc.CreateTasksPaced(context.Background(), tasks, time.Minute)
+	if !errors.Is(err, domain.ErrQueueFull) {
+		t.Fatalf("expected ErrQueueFull, got %v", err)
+	}
+	if len(scheduler.scheduledTasks) != 0 {
+		t.Fatalf("expected no task scheduled, got %d", len(scheduler.scheduledTasks))
+	}
+}
+
+func TestTaskService_CreateTask_dropOldest(t *testing.T) {
+	priority := testTask()
+	priority.ID = "task-priority"
+	priority.IsPriority = true
+	oldest := testTask()
+	oldest.ID = "task-oldest"
+
+	tests := []struct {
+		name        string
+		peeked      []*entity.Task
+		wantErr     error
+		wantDropped []string
+	}{
+		{
+			name:        "drops the oldest non-priority task",
+			peeked:      []*entity.Task{priority, oldest},
+			wantDropped: []string{"task-oldest"},
+		},
+		{
+			name:    "rejects when only priority tasks are scheduled",
+			peeked:  []*entity.Task{priority},
+			wantErr: domain.ErrQueueFull,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var dropped []string
+			scheduler := &mockScheduler{
+				count: 10,
+				dequeueFunc: func(_ context.Context, taskID string) (*entity.Task, time.Time, error) {
+					dropped = append(dropped, taskID)
+					return oldest, time.Now(), nil
+				},
+			}
+			for _, task := range tt.peeked {
+				scheduler.peeked = append(scheduler.peeked, entity.ScheduledTask{Task: task})
+			}
+			svc := NewTaskService(scheduler, &mockProducer{}, zap.NewNop(),
+				WithMaxPending(10),
+				WithOverflow(entity.OverflowDropOldest, nil),
+			)
+
+			err := svc.CreateTask(context.Background(), testTask())
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if len(dropped) != len(tt.wantDropped) || (len(dropped) > 0 && dropped[0] != tt.wantDropped[0]) {
+				t.Fatalf("expected %v dropped, got %v", tt.wantDropped, dropped)
+			}
+			if got := svc.OverflowStats().Dropped; got != int64(len(tt.wantDropped)) {
+				t.Fatalf("expected %d dropped in stats, got %d", len(tt.wantDropped), got)
+			}
+		})
+	}
+}
+
+func TestTaskService_CreateTask_spill(t *testing.T) {
+	scheduler := &mockScheduler{count: 10}
+	store := &mockOverflowStore{}
+	svc := NewTaskService(scheduler, &mockProducer{}, zap.NewNop(),
+		WithMaxPending(10),
+		WithOverflow(entity.OverflowSpill, store),
+	)
+
+	if err := svc.CreateTask(context.Background(), testTask()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(store.tasks) != 1 || len(scheduler.scheduledTasks) != 0 {
+		t.Fatalf("expected the task spilled, got %d spilled and %d scheduled", len(store.tasks), len(scheduler.scheduledTasks))
+	}
+
+	// Once the schedule drains, a poll restores the spilled task.
+	scheduler.count = 0
+	if _, err := svc.ProcessDueTasks(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(store.tasks) != 0 || len(scheduler.scheduledTasks) != 1 {
+		t.Fatalf("expected the task restored, got %d spilled and %d scheduled", len(store.tasks), len(scheduler.scheduledTasks))
+	}
+
+	want := entity.OverflowStats{Spilled: 1, Restored: 1}
+	if got := svc.OverflowStats(); got != want {
+		t.Fatalf("expected stats %+v, got %+v", want, got)
+	}
+}
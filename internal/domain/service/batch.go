@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+	"github.com/ruudy-sib/rebound/internal/port/secondary"
+)
+
+// batchGroups splits due tasks into delivery groups. When HTTP batching is
+// enabled and the producer supports it, HTTP tasks sharing a URL are grouped
+// (up to httpBatchSize per group); every other task, including those posting
+// to a chat webhook, is its own group.
+// Groups keep the order in which their first task was fetched.
+func (s *TaskService) batchGroups(tasks []*entity.Task) [][]*entity.Task {
+	_, canBatch := s.producer.(secondary.BatchProducer)
+	if !canBatch || s.httpBatchSize <= 1 {
+		groups := make([][]*entity.Task, len(tasks))
+		for i, task := range tasks {
+			groups[i] = []*entity.Task{task}
+		}
+		return groups
+	}
+
+	var groups [][]*entity.Task
+	open := make(map[string]int) // URL -> index of the group still accepting tasks
+	for _, task := range tasks {
+		if task.DestinationType != entity.DestinationTypeHTTP || task.Destination.Format != "" {
+			groups = append(groups, []*entity.Task{task})
+			continue
+		}
+
+		url := task.Destination.URL
+		if i, ok := open[url]; ok && len(groups[i]) < s.httpBatchSize {
+			groups[i] = append(groups[i], task)
+			continue
+		}
+		open[url] = len(groups)
+		groups = append(groups, []*entity.Task{task})
+	}
+
+	return groups
+}
+
+// processBatch delivers a group of HTTP tasks sharing a destination in a
+// single request. The outcome of the request applies to every task in it.
+// The hooks of each task are bounded one task at a time, and the request by
+// the delivery timeout.
+func (s *TaskService) processBatch(ctx context.Context, tasks []*entity.Task, results *outcomes) {
+	batcher := s.producer.(secondary.BatchProducer)
+
+	pending := make([]*entity.Task, 0, len(tasks))
+	messages := make([]secondary.Message, 0, len(tasks))
+	for _, task := range tasks {
+		if s.alreadyDelivered(ctx, task, s.taskLogger(task)) {
+			s.taskLogger(task).Warn("task already delivered, skipping duplicate delivery")
+			results.add(task, task.Attempt, entity.OutcomeSkipped, nil)
+			continue
+		}
+		msg, err := s.attemptMessage(ctx, task)
+		if err != nil {
+			// Only this task fails; the rest of the batch is still sent.
+			attempt := task.Attempt
+			task.MarkAttempted(s.now())
+			results.add(task, attempt, s.handleResult(ctx, task, err, 0, s.taskLogger(task)), err)
+			continue
+		}
+		pending = append(pending, task)
+		messages = append(messages, msg)
+	}
+	if len(pending) == 0 {
+		return
+	}
+
+	destination := pending[0].Destination
+	s.logger.Info("processing task batch",
+		zap.String("destination_url", destination.URL),
+		zap.Int("batch_size", len(pending)),
+	)
+
+	started := s.now()
+	for _, task := range pending {
+		task.MarkAttempted(started)
+	}
+	attemptCtx, cancel := s.attemptContext(ctx)
+	defer cancel()
+	err := produceBatch(attemptCtx, batcher, destination, messages)
+	elapsed := s.now().Sub(started)
+
+	for _, task := range pending {
+		attempt := task.Attempt
+		logger := s.taskLogger(task)
+		outcome := s.handleResult(ctx, task, err, elapsed, logger)
+		if outcome == entity.OutcomeDelivered {
+			// A batch has one response for all its tasks, so none gets a receipt.
+			s.recordDeliveryResult(ctx, task, entity.DeliveryReceipt{Partition: -1, Offset: -1}, logger)
+		}
+		results.add(task, attempt, outcome, err)
+	}
+}
+
+func produceBatch(ctx context.Context, batcher secondary.BatchProducer, destination entity.Destination, messages []secondary.Message) (err error) {
+	defer recoverDelivery(&err)
+	return batcher.ProduceBatch(ctx, destination, messages)
+}
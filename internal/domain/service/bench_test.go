@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+	"github.com/ruudy-sib/rebound/internal/port/secondary"
+)
+
+// benchScheduler is an in-memory scheduler for benchmarks. Unlike
+// mockScheduler it does not record scheduled tasks, so memory stays flat
+// however many iterations run, and FetchDue hands out fresh copies of due.
+type benchScheduler struct {
+	mockScheduler
+	due       []*entity.Task
+	scheduled atomic.Int64
+}
+
+func (m *benchScheduler) Schedule(_ context.Context, _ *entity.Task, _ time.Duration) error {
+	m.scheduled.Add(1)
+	return nil
+}
+
+func (m *benchScheduler) FetchDue(_ context.Context, _ string, limit int) ([]*entity.Task, error) {
+	tasks := make([]*entity.Task, 0, min(limit, len(m.due)))
+	for _, task := range m.due[:min(limit, len(m.due))] {
+		copied := *task
+		tasks = append(tasks, &copied)
+	}
+	return tasks, nil
+}
+
+func (m *benchScheduler) Count(_ context.Context) (int64, error) {
+	return m.scheduled.Load(), nil
+}
+
+// benchProducer is a producer for benchmarks that records nothing.
+type benchProducer struct {
+	err error
+}
+
+func (p *benchProducer) Produce(_ context.Context, _ entity.Destination, _ secondary.Message) error {
+	return p.err
+}
+
+func (p *benchProducer) Close() error {
+	return nil
+}
+
+func benchDueTasks(n int) []*entity.Task {
+	tasks := make([]*entity.Task, n)
+	for i := range tasks {
+		task := testTask()
+		task.ID = fmt.Sprintf("task-%d", i)
+		task.Attempt = 1
+		tasks[i] = task
+	}
+	return tasks
+}
+
+func BenchmarkTaskService_CreateTask(b *testing.B) {
+	svc := NewTaskService(&benchScheduler{}, &mockProducer{}, zap.NewNop())
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := svc.CreateTask(ctx, testTask()); err != nil {
+			b.Fatalf("unexpected error: %v", err)
+		}
+	}
+}
+
+func BenchmarkTaskService_validateTask(b *testing.B) {
+	svc := NewTaskService(&benchScheduler{}, &mockProducer{}, zap.NewNop(),
+		WithValidator(func(task *entity.Task) error {
+			if task.ClientID == "" {
+				return errors.New("client_id is required")
+			}
+			return nil
+		}),
+	)
+	task := testTask()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := svc.validateTask(task); err != nil {
+			b.Fatalf("unexpected error: %v", err)
+		}
+	}
+}
+
+func BenchmarkTaskService_ProcessDueTasks(b *testing.B) {
+	tests := []struct {
+		name       string
+		produceErr error
+	}{
+		{name: "delivered"},
+		{name: "rescheduled", produceErr: errors.New("connection refused")},
+	}
+
+	for _, tt := range tests {
+		b.Run(tt.name, func(b *testing.B) {
+			scheduler := &benchScheduler{due: benchDueTasks(domain.DefaultBatchSize)}
+			producer := &benchProducer{err: tt.produceErr}
+			svc := NewTaskService(scheduler, producer, zap.NewNop())
+			ctx := context.Background()
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := svc.ProcessDueTasks(ctx); err != nil {
+					b.Fatalf("unexpected error: %v", err)
+				}
+			}
+		})
+	}
+}
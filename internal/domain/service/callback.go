@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+	"github.com/ruudy-sib/rebound/internal/port/secondary"
+)
+
+// outcomeNotification is POSTed to a task's CallbackURL when it reaches a
+// terminal state, so the originating service learns the outcome without
+// consuming the dead-letter queue.
+type outcomeNotification struct {
+	TaskID         string    `json:"task_id"`
+	State          string    `json:"state"`
+	Source         string    `json:"source"`
+	ClientID       string    `json:"client_id"`
+	Attempts       int       `json:"attempts"`
+	FirstAttemptAt time.Time `json:"first_attempt_at"`
+	LastAttemptAt  time.Time `json:"last_attempt_at"`
+	LastError      string    `json:"last_error,omitempty"`
+	LastErrorCode  string    `json:"last_error_code,omitempty"`
+	CorrelationID  string    `json:"correlation_id,omitempty"`
+}
+
+// notifyOutcome delivers the terminal state of a task to its callback URL.
+// Callbacks are best effort: failures are logged and never retried, since
+// the task itself has already been settled.
+func (s *TaskService) notifyOutcome(ctx context.Context, task *entity.Task, state entity.TaskState, logger *zap.Logger) {
+	if task.CallbackURL == "" {
+		return
+	}
+
+	// Delivered and quarantined tasks settle on their last attempt, so they
+	// made Attempt+1 attempts; a dead one has already been incremented past
+	// its last attempt.
+	attempts := task.Attempt
+	if state == entity.StateDelivered || state == entity.StateQuarantined {
+		attempts++
+	}
+
+	value, err := json.Marshal(outcomeNotification{
+		TaskID:         task.ID,
+		State:          string(state),
+		Source:         task.Source,
+		ClientID:       task.ClientID,
+		Attempts:       attempts,
+		FirstAttemptAt: task.FirstAttemptAt.UTC(),
+		LastAttemptAt:  task.LastAttemptAt.UTC(),
+		LastError:      task.LastError,
+		LastErrorCode:  string(task.LastErrorCode),
+		CorrelationID:  task.CorrelationID,
+	})
+	if err != nil {
+		logger.Error("failed to build outcome callback", zap.Error(err))
+		return
+	}
+
+	key := []byte(fmt.Sprintf("%s|callback|%s", task.ID, state))
+	msg := secondary.Message{Key: key, Value: value, Priority: task.IsPriority}
+	if err := s.producer.Produce(ctx, entity.Destination{URL: task.CallbackURL}, msg); err != nil {
+		logger.Warn("outcome callback failed",
+			zap.Error(err),
+			zap.String("callback_url", task.CallbackURL),
+		)
+		return
+	}
+
+	logger.Debug("outcome callback delivered",
+		zap.String("callback_url", task.CallbackURL),
+		zap.String("state", string(state)),
+	)
+}
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+	"github.com/ruudy-sib/rebound/internal/port/secondary"
+)
+
+// errCancellationUnsupported is returned when no CancelledStore is configured.
+var errCancellationUnsupported = errors.New("task cancellation is not supported by this deployment")
+
+// CancelTask removes a scheduled task from the queue and holds it for the
+// cancellation TTL so it can be restored. Tasks still waiting behind an
+// ordering key are not scheduled yet and cannot be cancelled.
+func (s *TaskService) CancelTask(ctx context.Context, taskID string) error {
+	if s.cancelled == nil {
+		return errCancellationUnsupported
+	}
+
+	task, dueAt, err := s.scheduler.Dequeue(ctx, taskID)
+	if err != nil {
+		return fmt.Errorf("cancelling task %s: %w", taskID, err)
+	}
+	logger := s.taskLogger(task)
+
+	held := secondary.CancelledTask{Task: task, DueAt: dueAt, CancelledAt: s.now()}
+	if err := s.cancelled.Hold(ctx, held, s.cancelledTTL); err != nil {
+		// Never turn a failed soft cancel into a hard delete.
+		if rerr := s.scheduler.Schedule(ctx, task, s.untilOrNow(dueAt)); rerr != nil {
+			logger.Error("failed to reschedule task after cancellation failure", zap.Error(rerr))
+		}
+		return fmt.Errorf("cancelling task %s: %w", taskID, err)
+	}
+
+	// A cancelled task no longer blocks its ordering key.
+	s.releaseOrdering(ctx, task, logger)
+
+	logger.Info("task cancelled",
+		zap.Time("due_at", dueAt),
+		zap.Duration("restorable_for", s.cancelledTTL),
+	)
+	return nil
+}
+
+// GetCancelledTask returns a cancelled task that can still be restored.
+func (s *TaskService) GetCancelledTask(ctx context.Context, taskID string) (*entity.Task, error) {
+	if s.cancelled == nil {
+		return nil, errCancellationUnsupported
+	}
+
+	held, err := s.cancelled.Get(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("reading cancelled task %s: %w", taskID, err)
+	}
+	return held.Task, nil
+}
+
+// RestoreTask reschedules a cancelled task at its original due time, or
+// immediately if that time has passed. Ordered tasks rejoin the back of
+// their ordering key's queue.
+func (s *TaskService) RestoreTask(ctx context.Context, taskID string) error {
+	if s.cancelled == nil {
+		return errCancellationUnsupported
+	}
+
+	held, err := s.cancelled.Take(ctx, taskID)
+	if err != nil {
+		return fmt.Errorf("restoring task %s: %w", taskID, err)
+	}
+	task := held.Task
+	logger := s.taskLogger(task)
+
+	if task.OrderingKey != "" && s.ordering != nil {
+		active, err := s.ordering.Enqueue(ctx, task)
+		if err != nil {
+			s.rehold(ctx, *held, logger)
+			return fmt.Errorf("%w: %v", domain.ErrScheduleFailed, err)
+		}
+		if !active {
+			logger.Info("restored task queued behind ordering key",
+				zap.String("ordering_key", task.OrderingKey),
+			)
+			return nil
+		}
+	}
+
+	if err := s.scheduler.Schedule(ctx, task, s.untilOrNow(held.DueAt)); err != nil {
+		s.releaseOrdering(ctx, task, logger)
+		s.rehold(ctx, *held, logger)
+		return fmt.Errorf("%w: %v", domain.ErrScheduleFailed, err)
+	}
+
+	logger.Info("task restored", zap.Time("due_at", held.DueAt))
+	return nil
+}
+
+// rehold puts a task back in the holding area after a failed restore.
+func (s *TaskService) rehold(ctx context.Context, held secondary.CancelledTask, logger *zap.Logger) {
+	if err := s.cancelled.Hold(ctx, held, s.cancelledTTL); err != nil {
+		logger.Error("failed to return task to cancelled set", zap.Error(err))
+	}
+}
+
+// untilOrNow returns the delay until t, or zero if t has passed.
+func (s *TaskService) untilOrNow(t time.Time) time.Duration {
+	if d := t.Sub(s.now()); d > 0 {
+		return d
+	}
+	return 0
+}
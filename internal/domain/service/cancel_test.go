@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+	"github.com/ruudy-sib/rebound/internal/port/secondary"
+)
+
+func TestTaskService_CancelTask(t *testing.T) {
+	dueAt := time.Now().Add(10 * time.Minute)
+
+	tests := []struct {
+		name            string
+		dequeueErr      error
+		holdErr         error
+		wantErr         error
+		wantHeld        bool
+		wantRescheduled bool
+	}{
+		{
+			name:     "scheduled task is held",
+			wantHeld: true,
+		},
+		{
+			name:       "unknown task",
+			dequeueErr: domain.ErrTaskNotFound,
+			wantErr:    domain.ErrTaskNotFound,
+		},
+		{
+			name:            "hold failure puts the task back",
+			holdErr:         errors.New("redis down"),
+			wantRescheduled: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := testTask()
+			scheduler := &mockScheduler{
+				dequeueFunc: func(_ context.Context, _ string) (*entity.Task, time.Time, error) {
+					if tt.dequeueErr != nil {
+						return nil, time.Time{}, tt.dequeueErr
+					}
+					return task, dueAt, nil
+				},
+			}
+			store := newMockCancelledStore()
+			store.holdErr = tt.holdErr
+			svc := NewTaskService(scheduler, &mockProducer{}, zap.NewNop(), WithCancellation(store, time.Hour))
+
+			err := svc.CancelTask(context.Background(), task.ID)
+
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+			if tt.holdErr != nil && err == nil {
+				t.Fatal("expected error when hold fails")
+			}
+			if tt.wantHeld {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				held, ok := store.held[task.ID]
+				if !ok || !held.DueAt.Equal(dueAt) || store.ttl != time.Hour {
+					t.Fatalf("expected task held with due time and ttl, got %+v (ttl %v)", held, store.ttl)
+				}
+			}
+			if tt.wantRescheduled != (len(scheduler.scheduledTasks) == 1) {
+				t.Fatalf("wantRescheduled=%v, got %d schedule calls", tt.wantRescheduled, len(scheduler.scheduledTasks))
+			}
+		})
+	}
+}
+
+func TestTaskService_CancelTask_ReleasesOrderingKey(t *testing.T) {
+	now := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	active := testTask()
+	active.OrderingKey = "order-1"
+	waiting := testTask()
+	waiting.ID = "task-2"
+	waiting.OrderingKey = "order-1"
+	waiting.CreatedAt = now.Add(-time.Second)
+
+	ordering := newMockOrdering()
+	ordering.active["order-1"] = active.ID
+	ordering.waiting["order-1"] = []*entity.Task{waiting}
+
+	scheduler := &mockScheduler{
+		dequeueFunc: func(_ context.Context, _ string) (*entity.Task, time.Time, error) {
+			return active, now.Add(time.Minute), nil
+		},
+	}
+	svc := NewTaskService(scheduler, &mockProducer{}, zap.NewNop(),
+		WithClock(func() time.Time { return now }),
+		WithOrdering(ordering),
+		WithCancellation(newMockCancelledStore(), 0),
+	)
+
+	if err := svc.CancelTask(context.Background(), active.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(scheduler.scheduledTasks) != 1 || scheduler.scheduledTasks[0].Task.ID != "task-2" {
+		t.Fatalf("expected the waiting task to be scheduled, got %+v", scheduler.scheduledTasks)
+	}
+	// The waiting task keeps its first delay, counted from when it was created.
+	if want := waiting.FirstAttemptDelay() - time.Second; scheduler.scheduledTasks[0].Delay != want {
+		t.Fatalf("expected delay %v on the service clock, got %v", want, scheduler.scheduledTasks[0].Delay)
+	}
+}
+
+func TestTaskService_RestoreTask(t *testing.T) {
+	tests := []struct {
+		name      string
+		dueAt     time.Time
+		wantDelay time.Duration
+	}{
+		{name: "future due time is kept", dueAt: time.Now().Add(10 * time.Minute), wantDelay: 10 * time.Minute},
+		{name: "past due time is scheduled now", dueAt: time.Now().Add(-time.Hour), wantDelay: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := testTask()
+			store := newMockCancelledStore()
+			store.held[task.ID] = secondary.CancelledTask{Task: task, DueAt: tt.dueAt, CancelledAt: time.Now()}
+
+			scheduler := &mockScheduler{}
+			svc := NewTaskService(scheduler, &mockProducer{}, zap.NewNop(), WithCancellation(store, 0))
+
+			if err := svc.RestoreTask(context.Background(), task.ID); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(scheduler.scheduledTasks) != 1 {
+				t.Fatalf("expected 1 scheduled task, got %d", len(scheduler.scheduledTasks))
+			}
+			if d := scheduler.scheduledTasks[0].Delay; d > tt.wantDelay || d < tt.wantDelay-time.Second {
+				t.Fatalf("expected delay around %v, got %v", tt.wantDelay, d)
+			}
+			if _, ok := store.held[task.ID]; ok {
+				t.Fatal("restored task should leave the cancelled set")
+			}
+		})
+	}
+}
+
+func TestTaskService_RestoreTask_ScheduleFailureKeepsTaskHeld(t *testing.T) {
+	task := testTask()
+	store := newMockCancelledStore()
+	store.held[task.ID] = secondary.CancelledTask{Task: task, DueAt: time.Now()}
+
+	scheduler := &mockScheduler{
+		scheduleFunc: func(_ context.Context, _ *entity.Task, _ time.Duration) error {
+			return errors.New("redis down")
+		},
+	}
+	svc := NewTaskService(scheduler, &mockProducer{}, zap.NewNop(), WithCancellation(store, 0))
+
+	err := svc.RestoreTask(context.Background(), task.ID)
+	if !errors.Is(err, domain.ErrScheduleFailed) {
+		t.Fatalf("expected ErrScheduleFailed, got %v", err)
+	}
+	if _, ok := store.held[task.ID]; !ok {
+		t.Fatal("task should be held again after a failed restore")
+	}
+	if store.ttl != domain.DefaultCancelledTaskTTL {
+		t.Fatalf("expected default ttl, got %v", store.ttl)
+	}
+}
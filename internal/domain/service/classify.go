@@ -0,0 +1,49 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+// Verdict is what an ErrorClassifier decides about a failed delivery.
+type Verdict int
+
+const (
+	// VerdictRetry retries the task with backoff, as if no classifier were
+	// set.
+	VerdictRetry Verdict = iota
+	// VerdictPermanent dead-letters the task at once, since retrying
+	// cannot succeed, e.g. after a 400 or a payload failing schema checks.
+	VerdictPermanent
+)
+
+// ErrorClassifier decides whether a delivery error is worth retrying.
+type ErrorClassifier func(err error) Verdict
+
+// permanent reports whether err is not worth retrying: an HTTP status
+// outside the retryable statuses, or an error the classifier, if any, marks
+// permanent.
+func (s *TaskService) permanent(err error) bool {
+	var statusErr *entity.StatusError
+	if errors.As(err, &statusErr) && !s.retryableStatuses.Retryable(statusErr.StatusCode) {
+		return true
+	}
+	return s.classifyError != nil && s.classifyError(err) == VerdictPermanent
+}
+
+// failPermanently dead-letters a task whose attempt failed with an error not
+// worth retrying, leaving its remaining retries unused. Re-drive policies do
+// not apply, as a re-driven task would fail the same way.
+func (s *TaskService) failPermanently(ctx context.Context, task *entity.Task, logger *zap.Logger) entity.Outcome {
+	task.IncrementAttempt()
+	logger.Error("permanent failure, sending to dead-letter destination",
+		zap.Int("max_retries", task.MaxRetries),
+		zap.Int("attempts", task.Attempt),
+		zap.String("dead_destination_type", string(task.DeadDestination.Type())),
+	)
+	return s.deadLetter(ctx, task, "permanent failure", logger)
+}
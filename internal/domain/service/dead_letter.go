@@ -0,0 +1,65 @@
+package service
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+	"github.com/ruudy-sib/rebound/internal/domain/valueobject"
+)
+
+// deadLetterMessage is the payload produced to a dead-letter destination.
+// It wraps the original message with enough failure context for DLQ
+// consumers to triage exhausted tasks without looking them up elsewhere.
+type deadLetterMessage struct {
+	TaskID         string                `json:"task_id"`
+	Source         string                `json:"source"`
+	ClientID       string                `json:"client_id"`
+	Attempts       int                   `json:"attempts"`
+	Redrives       int                   `json:"redrives,omitempty"`
+	FirstAttemptAt time.Time             `json:"first_attempt_at"`
+	LastAttemptAt  time.Time             `json:"last_attempt_at"`
+	LastError      string                `json:"last_error"`
+	LastErrorCode  string                `json:"last_error_code,omitempty"`
+	Destination    deadLetterDestination `json:"destination"`
+	MessageData    string                `json:"message_data"`
+	Metadata       map[string]string     `json:"metadata,omitempty"`
+	CorrelationID  string                `json:"correlation_id,omitempty"`
+}
+
+type deadLetterDestination struct {
+	Type  string `json:"type"`
+	Host  string `json:"host,omitempty"`
+	Port  string `json:"port,omitempty"`
+	Topic string `json:"topic,omitempty"`
+	URL   string `json:"url,omitempty"`
+}
+
+// newDeadLetterMessage wraps the task's payload, masked by redaction.
+func newDeadLetterMessage(task *entity.Task, redaction valueobject.Redaction) deadLetterMessage {
+	return deadLetterMessage{
+		TaskID:         task.ID,
+		Source:         task.Source,
+		ClientID:       task.ClientID,
+		Attempts:       task.Attempt,
+		Redrives:       task.Redrives,
+		FirstAttemptAt: task.FirstAttemptAt.UTC(),
+		LastAttemptAt:  task.LastAttemptAt.UTC(),
+		LastError:      task.LastError,
+		LastErrorCode:  string(task.LastErrorCode),
+		Destination: deadLetterDestination{
+			Type:  string(task.DestinationType),
+			Host:  task.Destination.Host,
+			Port:  task.Destination.Port,
+			Topic: task.Destination.Topic,
+			URL:   task.Destination.URL,
+		},
+		MessageData:   redaction.Apply(task.MessageData),
+		Metadata:      task.Metadata,
+		CorrelationID: task.CorrelationID,
+	}
+}
+
+func (m deadLetterMessage) marshal() ([]byte, error) {
+	return json.Marshal(m)
+}
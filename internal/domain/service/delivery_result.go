@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+	"github.com/ruudy-sib/rebound/internal/port/secondary"
+)
+
+// errDeliveryResultsUnsupported is returned when delivery results are not recorded.
+var errDeliveryResultsUnsupported = errors.New("delivery results are not recorded by this deployment")
+
+// DeliveryResult returns the recorded result of the task's successful delivery.
+func (s *TaskService) DeliveryResult(ctx context.Context, taskID string) (*entity.DeliveryResult, error) {
+	if s.results == nil {
+		return nil, errDeliveryResultsUnsupported
+	}
+
+	result, err := s.results.Get(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("reading delivery result of task %s: %w", taskID, err)
+	}
+	return result, nil
+}
+
+// produce delivers msg, asking the producer for the destination's receipt
+// when delivery results are recorded and the producer can report one.
+func (s *TaskService) produce(ctx context.Context, dest entity.Destination, msg secondary.Message) (entity.DeliveryReceipt, error) {
+	if producer, ok := s.producer.(secondary.ReceiptProducer); ok && s.results != nil {
+		return producer.ProduceWithReceipt(ctx, dest, msg)
+	}
+	return entity.DeliveryReceipt{Partition: -1, Offset: -1}, s.producer.Produce(ctx, dest, msg)
+}
+
+// recordDeliveryResult saves the result of a successful delivery. Failing
+// to save it does not affect the delivery.
+func (s *TaskService) recordDeliveryResult(ctx context.Context, task *entity.Task, receipt entity.DeliveryReceipt, logger *zap.Logger) {
+	if s.results == nil {
+		return
+	}
+
+	result := entity.DeliveryResult{
+		TaskID:          task.ID,
+		Source:          task.Source,
+		DestinationType: task.DestinationType,
+		Destination:     task.Destination.Name(),
+		Attempts:        task.Attempt + 1,
+		DeliveredAt:     s.now(),
+		Receipt:         receipt,
+	}
+	if err := s.results.Save(ctx, result, s.resultTTL); err != nil {
+		logger.Error("failed to record delivery result", zap.Error(err))
+	}
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+func TestTaskService_ProcessDueTasks_deliveryResults(t *testing.T) {
+	tests := []struct {
+		name       string
+		produceErr error
+		ttl        time.Duration
+		wantSaved  bool
+	}{
+		{name: "delivered", ttl: time.Hour, wantSaved: true},
+		{name: "failed attempt", produceErr: errors.New("kafka down"), ttl: time.Hour},
+		{name: "recording disabled", ttl: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := testTask()
+			task.Attempt = 1
+			scheduler := &mockScheduler{
+				fetchDueFunc: func(_ context.Context, _ int) ([]*entity.Task, error) {
+					return []*entity.Task{task}, nil
+				},
+			}
+			producer := &receiptProducer{
+				mockProducer: mockProducer{
+					produceFunc: func(_ context.Context, _ entity.Destination, _, _ []byte) error {
+						return tt.produceErr
+					},
+				},
+				receipt: entity.DeliveryReceipt{Partition: 2, Offset: 42},
+			}
+			store := &mockDeliveryResultStore{}
+
+			svc := NewTaskService(scheduler, producer, zap.NewNop(), WithDeliveryResults(store, tt.ttl))
+			if _, err := svc.ProcessDueTasks(context.Background()); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			result, ok := store.saved[task.ID]
+			if ok != tt.wantSaved {
+				t.Fatalf("saved = %v, want %v", ok, tt.wantSaved)
+			}
+			if !ok {
+				return
+			}
+			if result.Receipt != producer.receipt || result.Attempts != 2 || result.Destination != "my-topic" {
+				t.Fatalf("unexpected result: %+v", result)
+			}
+			if store.ttl != tt.ttl {
+				t.Fatalf("expected ttl %v, got %v", tt.ttl, store.ttl)
+			}
+		})
+	}
+}
+
+func TestTaskService_DeliveryResult(t *testing.T) {
+	store := &mockDeliveryResultStore{}
+	svc := NewTaskService(&mockScheduler{}, &mockProducer{}, zap.NewNop(), WithDeliveryResults(store, time.Hour))
+
+	if _, err := svc.DeliveryResult(context.Background(), "task-1"); !errors.Is(err, domain.ErrTaskNotFound) {
+		t.Fatalf("expected ErrTaskNotFound, got %v", err)
+	}
+
+	store.saved = map[string]entity.DeliveryResult{"task-1": {TaskID: "task-1"}}
+	result, err := svc.DeliveryResult(context.Background(), "task-1")
+	if err != nil || result.TaskID != "task-1" {
+		t.Fatalf("unexpected result %+v, err %v", result, err)
+	}
+
+	disabled := NewTaskService(&mockScheduler{}, &mockProducer{}, zap.NewNop())
+	if _, err := disabled.DeliveryResult(context.Background(), "task-1"); !errors.Is(err, errDeliveryResultsUnsupported) {
+		t.Fatalf("expected errDeliveryResultsUnsupported, got %v", err)
+	}
+}
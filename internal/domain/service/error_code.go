@@ -0,0 +1,39 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net"
+	"syscall"
+	"time"
+
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+// errorCode classifies a delivery error, preferring the code a producer
+// attached and falling back to timeouts and refused connections.
+func errorCode(err error) entity.ErrorCode {
+	var deliveryErr *entity.DeliveryError
+	if errors.As(err, &deliveryErr) {
+		return deliveryErr.Code
+	}
+
+	var netErr net.Error
+	switch {
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr) && netErr.Timeout():
+		return entity.ErrorCodeTimeout
+	case errors.Is(err, syscall.ECONNREFUSED):
+		return entity.ErrorCodeConnRefused
+	}
+	return entity.ErrorCodeUnknown
+}
+
+// retryAfter returns how long the destination asked to wait before the next
+// attempt, or zero when err carries no hint.
+func retryAfter(err error) time.Duration {
+	var deliveryErr *entity.DeliveryError
+	if errors.As(err, &deliveryErr) {
+		return deliveryErr.RetryAfter
+	}
+	return 0
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"syscall"
+	"testing"
+
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+func TestErrorCode(t *testing.T) {
+	refused := &net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}
+
+	tests := []struct {
+		name string
+		err  error
+		want entity.ErrorCode
+	}{
+		{
+			name: "code attached by the producer",
+			err:  fmt.Errorf("wrapped: %w", &entity.DeliveryError{Code: entity.ErrorCodeHTTP5xx, Err: errors.New("status 503")}),
+			want: entity.ErrorCodeHTTP5xx,
+		},
+		{
+			name: "context deadline",
+			err:  fmt.Errorf("executing http request: %w", context.DeadlineExceeded),
+			want: entity.ErrorCodeTimeout,
+		},
+		{
+			name: "connection refused",
+			err:  fmt.Errorf("executing http request: %w", refused),
+			want: entity.ErrorCodeConnRefused,
+		},
+		{
+			name: "anything else",
+			err:  errors.New("boom"),
+			want: entity.ErrorCodeUnknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := errorCode(tt.err); got != tt.want {
+				t.Fatalf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
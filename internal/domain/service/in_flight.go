@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+// errWorkerLost is recorded as the failure of a reclaimed attempt.
+var errWorkerLost = errors.New("worker stopped before the delivery was settled")
+
+// trackInFlight records the tasks about to be delivered in groups. They may
+// wait behind the concurrency limit, so each wave of groups adds another
+// allowance of reclaimAfter delivery timeouts to the deadline. Tracking is
+// best effort: an untracked task is only lost if its worker dies.
+func (s *TaskService) trackInFlight(ctx context.Context, tasks []*entity.Task, groups int) {
+	if s.inFlight == nil || len(tasks) == 0 {
+		return
+	}
+
+	waves := (groups + s.concurrency - 1) / s.concurrency
+	deadline := s.now().Add(time.Duration(waves*s.reclaimAfter) * s.deliveryTimeout)
+	if err := s.inFlight.Track(ctx, tasks, deadline); err != nil {
+		s.logger.Warn("failed to track in-flight tasks", zap.Error(err), zap.Int("tasks", len(tasks)))
+	}
+}
+
+// releaseInFlight forgets every task of the poll that was settled. Errored
+// tasks are kept so they are reclaimed. The release outlives cancellation
+// at shutdown, which would otherwise leave settled tasks to be delivered
+// again.
+func (s *TaskService) releaseInFlight(ctx context.Context, result entity.ProcessResult) {
+	if s.inFlight == nil {
+		return
+	}
+
+	var settled []string
+	for _, t := range result.Tasks {
+		if t.Outcome != entity.OutcomeErrored {
+			settled = append(settled, t.TaskID)
+		}
+	}
+	if err := s.inFlight.Release(context.WithoutCancel(ctx), settled); err != nil {
+		s.logger.Warn("failed to release in-flight tasks", zap.Error(err), zap.Int("tasks", len(settled)))
+	}
+}
+
+// reclaimStuck settles, as failed attempts, the tasks left in flight past
+// their deadline by a worker that died mid-delivery: they are retried, or
+// dead-lettered once out of retries. It returns how many were reclaimed.
+func (s *TaskService) reclaimStuck(ctx context.Context) int {
+	if s.inFlight == nil {
+		return 0
+	}
+
+	tasks, err := s.inFlight.Reclaim(ctx, s.now(), s.batchSize)
+	if err != nil {
+		s.logger.Error("failed to reclaim stuck tasks", zap.Error(err))
+	}
+	for _, task := range tasks {
+		logger := s.taskLogger(task)
+		logger.Warn("reclaimed stuck task",
+			zap.String("destination_type", string(task.DestinationType)),
+			zap.Time("last_attempt_at", task.LastAttemptAt),
+		)
+		task.LastErrorCode = entity.ErrorCodeTimeout
+		task.RecordFailure(errWorkerLost.Error(), false)
+		if s.handleFailure(ctx, task, 0, logger) == entity.OutcomeErrored {
+			// Keep the task in flight to try again on a later poll.
+			s.trackInFlight(ctx, []*entity.Task{task}, 1)
+		}
+	}
+	return len(tasks)
+}
+
+type leaseKey struct{}
+
+// leaseExtender pushes back the reclaim deadline of the task it was created
+// for.
+type leaseExtender func(ctx context.Context, d time.Duration) error
+
+// withLease lets code running with ctx during the task's delivery attempt
+// extend its lease through ExtendLease.
+func (s *TaskService) withLease(ctx context.Context, task *entity.Task) context.Context {
+	if s.inFlight == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, leaseKey{}, leaseExtender(func(ctx context.Context, d time.Duration) error {
+		return s.inFlight.Extend(ctx, task.ID, s.now().Add(d))
+	}))
+}
+
+// ExtendLease keeps the task whose delivery attempt ctx belongs to from
+// being reclaimed for at least d from now, like extending an SQS visibility
+// timeout. Call it periodically from an attempt hook that legitimately runs
+// for longer than the reclaim deadline. It returns domain.ErrNoLease if ctx
+// belongs to no tracked attempt, and domain.ErrTaskNotFound if the task was
+// already reclaimed, in which case the attempt should be abandoned.
+func ExtendLease(ctx context.Context, d time.Duration) error {
+	extend, ok := ctx.Value(leaseKey{}).(leaseExtender)
+	if !ok {
+		return domain.ErrNoLease
+	}
+	return extend(ctx, d)
+}
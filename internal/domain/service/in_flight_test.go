@@ -0,0 +1,203 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+	"github.com/ruudy-sib/rebound/internal/port/secondary"
+)
+
+func TestTaskService_ProcessDueTasks_tracksInFlight(t *testing.T) {
+	delivered := testTask()
+	failing := testTask()
+	failing.ID = "task-2"
+	failing.Destination.Topic = "failing-topic"
+
+	scheduler := &mockScheduler{
+		fetchDueFunc: func(_ context.Context, _ int) ([]*entity.Task, error) {
+			return []*entity.Task{delivered, failing}, nil
+		},
+		scheduleFunc: func(_ context.Context, _ *entity.Task, _ time.Duration) error {
+			return errors.New("redis down")
+		},
+	}
+	producer := &mockProducer{
+		produceFunc: func(_ context.Context, destination entity.Destination, _, _ []byte) error {
+			if destination.Topic == "failing-topic" {
+				return errors.New("kafka down")
+			}
+			return nil
+		},
+	}
+	inFlight := &mockInFlightStore{}
+	svc := NewTaskService(scheduler, producer, zap.NewNop(), WithInFlightReclaim(inFlight, 2))
+
+	before := time.Now()
+	if _, err := svc.ProcessDueTasks(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	slices.Sort(inFlight.tracked)
+	if !slices.Equal(inFlight.tracked, []string{"task-1", "task-2"}) {
+		t.Fatalf("expected both tasks tracked, got %v", inFlight.tracked)
+	}
+	if want := before.Add(2 * domain.DefaultDeliveryTimeout); inFlight.deadline.Before(want) {
+		t.Fatalf("expected deadline after %v, got %v", want, inFlight.deadline)
+	}
+	// The failed task could not be rescheduled, so it stays in flight to be
+	// reclaimed.
+	if !slices.Equal(inFlight.released, []string{"task-1"}) {
+		t.Fatalf("expected only the delivered task released, got %v", inFlight.released)
+	}
+}
+
+func TestTaskService_ProcessDueTasks_reclaimsStuckTasks(t *testing.T) {
+	tests := []struct {
+		name         string
+		attempt      int
+		wantAttempt  int
+		wantSchedule bool
+	}{
+		{
+			name:         "retried with an attempt counted",
+			attempt:      1,
+			wantAttempt:  2,
+			wantSchedule: true,
+		},
+		{
+			name:        "dead-lettered once out of retries",
+			attempt:     3,
+			wantAttempt: 4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stuck := testTask()
+			stuck.Attempt = tt.attempt
+
+			scheduler := &mockScheduler{}
+			producer := &mockProducer{}
+			inFlight := &mockInFlightStore{reclaimed: []*entity.Task{stuck}}
+			svc := NewTaskService(scheduler, producer, zap.NewNop(), WithInFlightReclaim(inFlight, 0))
+
+			result, err := svc.ProcessDueTasks(context.Background())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Reclaimed != 1 {
+				t.Fatalf("expected 1 reclaimed task, got %d", result.Reclaimed)
+			}
+			if stuck.Attempt != tt.wantAttempt {
+				t.Fatalf("expected attempt %d, got %d", tt.wantAttempt, stuck.Attempt)
+			}
+			if stuck.LastErrorCode != entity.ErrorCodeTimeout {
+				t.Fatalf("expected error code %s, got %s", entity.ErrorCodeTimeout, stuck.LastErrorCode)
+			}
+			if got := len(scheduler.scheduledTasks) == 1; got != tt.wantSchedule {
+				t.Fatalf("expected rescheduled %v, got %d schedule calls", tt.wantSchedule, len(scheduler.scheduledTasks))
+			}
+			if !tt.wantSchedule && len(producer.produceCalls) != 1 {
+				t.Fatalf("expected a dead-letter produce, got %d calls", len(producer.produceCalls))
+			}
+		})
+	}
+}
+
+func TestExtendLease(t *testing.T) {
+	tests := []struct {
+		name      string
+		inFlight  *mockInFlightStore
+		wantErr   error
+		wantLease bool
+	}{
+		{
+			name:      "extends the task's deadline",
+			inFlight:  &mockInFlightStore{},
+			wantLease: true,
+		},
+		{
+			name:     "reports a reclaimed task",
+			inFlight: &mockInFlightStore{extendErr: domain.ErrTaskNotFound},
+			wantErr:  domain.ErrTaskNotFound,
+		},
+		{
+			name:    "without in-flight tracking there is no lease",
+			wantErr: domain.ErrNoLease,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var hookErr error
+			hook := func(ctx context.Context, _ *entity.Task, _ *secondary.Message) error {
+				hookErr = ExtendLease(ctx, 10*time.Minute)
+				return nil
+			}
+			opts := []Option{WithAttemptHook("", hook)}
+			if tt.inFlight != nil {
+				opts = append(opts, WithInFlightReclaim(tt.inFlight, 0))
+			}
+
+			task := testTask()
+			scheduler := &mockScheduler{
+				fetchDueFunc: func(_ context.Context, _ int) ([]*entity.Task, error) {
+					return []*entity.Task{task}, nil
+				},
+			}
+			svc := NewTaskService(scheduler, &mockProducer{}, zap.NewNop(), opts...)
+
+			before := time.Now()
+			if _, err := svc.ProcessDueTasks(context.Background()); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !errors.Is(hookErr, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, hookErr)
+			}
+			if tt.wantLease {
+				deadline, ok := tt.inFlight.extended[task.ID]
+				if !ok || deadline.Before(before.Add(10*time.Minute)) {
+					t.Fatalf("expected deadline at least 10m out, got %v", deadline)
+				}
+			}
+		})
+	}
+}
+
+func TestExtendLease_clock(t *testing.T) {
+	now := time.Date(2030, 1, 1, 12, 0, 0, 0, time.UTC)
+	inFlight := &mockInFlightStore{}
+	task := testTask()
+	scheduler := &mockScheduler{
+		fetchDueFunc: func(_ context.Context, _ int) ([]*entity.Task, error) {
+			return []*entity.Task{task}, nil
+		},
+	}
+	svc := NewTaskService(scheduler, &mockProducer{}, zap.NewNop(),
+		WithClock(func() time.Time { return now }),
+		WithInFlightReclaim(inFlight, 0),
+		WithAttemptHook("", func(ctx context.Context, _ *entity.Task, _ *secondary.Message) error {
+			return ExtendLease(ctx, 10*time.Minute)
+		}),
+	)
+
+	if _, err := svc.ProcessDueTasks(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if deadline := inFlight.extended[task.ID]; !deadline.Equal(now.Add(10 * time.Minute)) {
+		t.Fatalf("expected deadline %v on the service clock, got %v", now.Add(10*time.Minute), deadline)
+	}
+}
+
+func TestExtendLease_outsideDelivery(t *testing.T) {
+	if err := ExtendLease(context.Background(), time.Minute); !errors.Is(err, domain.ErrNoLease) {
+		t.Fatalf("expected ErrNoLease, got %v", err)
+	}
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+	"github.com/ruudy-sib/rebound/internal/port/secondary"
+)
+
+// JanitorService repairs state left inconsistent by crashed processes.
+type JanitorService struct {
+	reconciler secondary.StateReconciler
+	logger     *zap.Logger
+
+	// running guards against overlapping on-demand passes.
+	running atomic.Bool
+}
+
+// NewJanitorService creates a JanitorService over reconciler.
+func NewJanitorService(reconciler secondary.StateReconciler, logger *zap.Logger) *JanitorService {
+	return &JanitorService{
+		reconciler: reconciler,
+		logger:     logger.Named("janitor-service"),
+	}
+}
+
+// Reconcile claims the pass for this instance and runs it. Repairs are
+// logged with their counts so they can be graphed from the logs.
+func (s *JanitorService) Reconcile(ctx context.Context, lease time.Duration) (*entity.RepairReport, error) {
+	claimed, err := s.reconciler.Claim(ctx, lease)
+	if err != nil {
+		return nil, fmt.Errorf("claiming janitor pass: %w", err)
+	}
+	if !claimed {
+		return nil, nil
+	}
+
+	return s.run(ctx)
+}
+
+// ReconcileNow runs a pass immediately, without waiting for the periodic
+// claim to lapse. Repairs are idempotent, so overlapping a pass on another
+// instance is harmless; an on-demand pass already running on this instance
+// returns domain.ErrReconcileInProgress.
+func (s *JanitorService) ReconcileNow(ctx context.Context) (*entity.RepairReport, error) {
+	if !s.running.CompareAndSwap(false, true) {
+		return nil, domain.ErrReconcileInProgress
+	}
+	defer s.running.Store(false)
+
+	return s.run(ctx)
+}
+
+// run executes one repair pass and logs what it fixed.
+func (s *JanitorService) run(ctx context.Context) (*entity.RepairReport, error) {
+	report, err := s.reconciler.Reconcile(ctx)
+	if report.Total() > 0 {
+		s.logger.Info("janitor repaired state",
+			zap.Int("index_entries_removed", report.IndexEntriesRemoved),
+			zap.Int("index_entries_added", report.IndexEntriesAdded),
+			zap.Int("dead_letters_removed", report.DeadLettersRemoved),
+			zap.Int("dead_letters_indexed", report.DeadLettersIndexed),
+			zap.Int("sla_groups_removed", report.SLAGroupsRemoved),
+			zap.Int("workers_forgotten", report.WorkersForgotten),
+			zap.Int("dead_letters_expired", report.DeadLettersExpired),
+			zap.Int("quarantined_expired", report.QuarantinedExpired),
+		)
+	}
+	if err != nil {
+		return &report, fmt.Errorf("reconciling state: %w", err)
+	}
+	return &report, nil
+}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+func TestJanitorService_Reconcile(t *testing.T) {
+	tests := []struct {
+		name           string
+		reconciler     *mockReconciler
+		wantReport     bool
+		wantErr        bool
+		wantReconciled int
+	}{
+		{
+			name:           "claimed pass runs",
+			reconciler:     &mockReconciler{claimed: true, report: entity.RepairReport{IndexEntriesRemoved: 3}},
+			wantReport:     true,
+			wantReconciled: 1,
+		},
+		{
+			name:       "pass claimed elsewhere is skipped",
+			reconciler: &mockReconciler{claimed: false},
+		},
+		{
+			name: "partial repairs are reported with the error",
+			reconciler: &mockReconciler{
+				claimed: true,
+				report:  entity.RepairReport{WorkersForgotten: 1},
+				err:     errors.New("redis down"),
+			},
+			wantReport:     true,
+			wantErr:        true,
+			wantReconciled: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewJanitorService(tt.reconciler, zap.NewNop())
+
+			report, err := svc.Reconcile(context.Background(), time.Minute)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if (report != nil) != tt.wantReport {
+				t.Fatalf("expected report %v, got %+v", tt.wantReport, report)
+			}
+			if report != nil && *report != tt.reconciler.report {
+				t.Fatalf("expected %+v, got %+v", tt.reconciler.report, *report)
+			}
+			if tt.reconciler.reconciled != tt.wantReconciled {
+				t.Fatalf("expected %d passes, got %d", tt.wantReconciled, tt.reconciler.reconciled)
+			}
+		})
+	}
+}
+
+func TestJanitorService_ReconcileNow(t *testing.T) {
+	t.Run("runs without the periodic claim", func(t *testing.T) {
+		reconciler := &mockReconciler{claimed: false, report: entity.RepairReport{DeadLettersIndexed: 2}}
+		svc := NewJanitorService(reconciler, zap.NewNop())
+
+		report, err := svc.ReconcileNow(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if report == nil || *report != reconciler.report {
+			t.Fatalf("expected %+v, got %+v", reconciler.report, report)
+		}
+	})
+
+	t.Run("rejects an overlapping pass", func(t *testing.T) {
+		reconciler := &mockReconciler{started: make(chan struct{}), release: make(chan struct{})}
+		svc := NewJanitorService(reconciler, zap.NewNop())
+
+		done := make(chan error, 1)
+		go func() {
+			_, err := svc.ReconcileNow(context.Background())
+			done <- err
+		}()
+		<-reconciler.started
+
+		if _, err := svc.ReconcileNow(context.Background()); !errors.Is(err, domain.ErrReconcileInProgress) {
+			t.Fatalf("expected ErrReconcileInProgress, got %v", err)
+		}
+
+		close(reconciler.release)
+		if err := <-done; err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if reconciler.reconciled != 1 {
+			t.Fatalf("expected 1 pass, got %d", reconciler.reconciled)
+		}
+	})
+}
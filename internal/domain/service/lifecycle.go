@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+// Lifecycle event types.
+const (
+	EventTaskDeadLettered = "task.dead_lettered"
+	EventTaskQuarantined  = "task.quarantined"
+	EventQueueFull        = "queue.full"
+
+	EventRetryStormEngaged  = "retry_storm.engaged"
+	EventRetryStormReleased = "retry_storm.released"
+)
+
+// lifecycleEvent is POSTed to every lifecycle subscriber. Task fields are
+// set for task events, queue fields for queue alarms and storm fields, with
+// Destination, for retry storms.
+type lifecycleEvent struct {
+	Type       string    `json:"type"`
+	OccurredAt time.Time `json:"occurred_at"`
+
+	TaskID        string `json:"task_id,omitempty"`
+	Source        string `json:"source,omitempty"`
+	ClientID      string `json:"client_id,omitempty"`
+	Destination   string `json:"destination,omitempty"`
+	Attempts      int    `json:"attempts,omitempty"`
+	LastError     string `json:"last_error,omitempty"`
+	LastErrorCode string `json:"last_error_code,omitempty"`
+
+	Pending    int64 `json:"pending,omitempty"`
+	MaxPending int64 `json:"max_pending,omitempty"`
+
+	Retries         int `json:"retries,omitempty"`
+	DelayMultiplier int `json:"delay_multiplier,omitempty"`
+}
+
+// WithLifecycleWebhooks POSTs rebound's own lifecycle events (tasks
+// dead-lettered or quarantined, the queue filling up, retry storms) to each
+// of urls. The
+// events are scheduled as ordinary HTTP tasks, so they are retried like
+// any other delivery.
+func WithLifecycleWebhooks(urls []string) Option {
+	return func(s *TaskService) {
+		s.lifecycleURLs = urls
+	}
+}
+
+// taskEvent raises a lifecycle event about a task that reached a terminal
+// state. Tasks delivering lifecycle events raise none.
+func (s *TaskService) taskEvent(ctx context.Context, eventType string, task *entity.Task, logger *zap.Logger) {
+	if task.Source == domain.LifecycleEventSource {
+		return
+	}
+	s.raiseEvent(ctx, lifecycleEvent{
+		Type:          eventType,
+		OccurredAt:    s.now().UTC(),
+		TaskID:        task.ID,
+		Source:        task.Source,
+		ClientID:      task.ClientID,
+		Destination:   task.Destination.Name(),
+		Attempts:      task.Attempt,
+		LastError:     task.LastError,
+		LastErrorCode: string(task.LastErrorCode),
+	}, task.ID, logger)
+}
+
+// queueFullAlarm raises a queue.full event, at most once per
+// domain.QueueAlarmInterval.
+func (s *TaskService) queueFullAlarm(ctx context.Context, pending int64) {
+	now := s.now()
+	last := s.lastQueueAlarm.Load()
+	if now.UnixNano()-last < int64(domain.QueueAlarmInterval) || !s.lastQueueAlarm.CompareAndSwap(last, now.UnixNano()) {
+		return
+	}
+	s.raiseEvent(ctx, lifecycleEvent{
+		Type:       EventQueueFull,
+		OccurredAt: now.UTC(),
+		Pending:    pending,
+		MaxPending: s.maxPending,
+	}, "queue", s.logger)
+}
+
+// raiseEvent schedules one delivery of the event per subscriber. The tasks
+// skip admission, so an alarm about a full queue is not itself rejected,
+// and are marked priority so that dropping the oldest tasks spares them.
+func (s *TaskService) raiseEvent(ctx context.Context, event lifecycleEvent, subject string, logger *zap.Logger) {
+	if len(s.lifecycleURLs) == 0 {
+		return
+	}
+
+	value, err := json.Marshal(event)
+	if err != nil {
+		logger.Error("failed to build lifecycle event", zap.Error(err), zap.String("event", event.Type))
+		return
+	}
+
+	for i, url := range s.lifecycleURLs {
+		task := &entity.Task{
+			ID:              fmt.Sprintf("%s|%s|%s|%d|%d", domain.LifecycleEventSource, event.Type, subject, event.OccurredAt.UnixNano(), i),
+			Source:          domain.LifecycleEventSource,
+			DestinationType: entity.DestinationTypeHTTP,
+			Destination:     entity.Destination{URL: url},
+			MaxRetries:      domain.LifecycleEventMaxRetries,
+			BaseDelay:       domain.LifecycleEventBaseDelay,
+			IsPriority:      true,
+			MessageData:     string(value),
+
+			AttemptImmediately: true,
+		}
+		if err := s.scheduleNew(ctx, task, 0); err != nil {
+			logger.Warn("failed to schedule lifecycle event",
+				zap.Error(err),
+				zap.String("event", event.Type),
+				zap.String("subscriber_url", url),
+			)
+		}
+	}
+}
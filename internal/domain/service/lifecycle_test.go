@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+func TestTaskService_lifecycleEvents(t *testing.T) {
+	subscribers := []string{"http://ops.internal/a", "http://ops.internal/b"}
+
+	tests := []struct {
+		name       string
+		task       func() *entity.Task
+		opts       []Option
+		wantEvents int
+		wantType   string
+	}{
+		{
+			name: "dead-lettered task",
+			task: func() *entity.Task {
+				task := testTask()
+				task.Attempt = 3
+				return task
+			},
+			wantEvents: 2,
+			wantType:   EventTaskDeadLettered,
+		},
+		{
+			name: "quarantined task",
+			task: func() *entity.Task {
+				task := testTask()
+				task.RepeatedFailures = 2
+				task.LastError = "kafka down"
+				return task
+			},
+			opts:       []Option{WithQuarantine(&mockQuarantine{}, PoisonPolicy{Threshold: 3, FailureWindow: time.Hour})},
+			wantEvents: 2,
+			wantType:   EventTaskQuarantined,
+		},
+		{
+			name: "retried task",
+			task: testTask,
+		},
+		{
+			name: "lifecycle event delivery dead-lettered",
+			task: func() *entity.Task {
+				task := testTask()
+				task.Source = domain.LifecycleEventSource
+				task.Attempt = 3
+				return task
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := tt.task()
+			scheduler := &mockScheduler{
+				fetchDueFunc: func(context.Context, int) ([]*entity.Task, error) {
+					return []*entity.Task{task}, nil
+				},
+			}
+			producer := &mockProducer{
+				produceFunc: func(context.Context, entity.Destination, []byte, []byte) error {
+					return errors.New("kafka down")
+				},
+			}
+			opts := append([]Option{
+				WithLifecycleWebhooks(subscribers),
+				WithDeadLetterPolicy(DeadLetterPolicy{MaxAttempts: 1}),
+			}, tt.opts...)
+			svc := NewTaskService(scheduler, producer, zap.NewNop(), opts...)
+
+			if _, err := svc.ProcessDueTasks(context.Background()); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var events []*entity.Task
+			for _, call := range scheduler.scheduledTasks {
+				if call.Task.Source == domain.LifecycleEventSource && call.Task != task {
+					events = append(events, call.Task)
+				}
+			}
+			if len(events) != tt.wantEvents {
+				t.Fatalf("expected %d lifecycle events, got %d", tt.wantEvents, len(events))
+			}
+			for i, event := range events {
+				if event.Destination.URL != subscribers[i] || event.DestinationType != entity.DestinationTypeHTTP || !event.IsPriority {
+					t.Fatalf("unexpected event task: %+v", event)
+				}
+				var payload lifecycleEvent
+				if err := json.Unmarshal([]byte(event.MessageData), &payload); err != nil {
+					t.Fatalf("event payload is not valid JSON: %v", err)
+				}
+				if payload.Type != tt.wantType || payload.TaskID != task.ID || payload.LastError != "kafka down" {
+					t.Fatalf("unexpected event payload: %+v", payload)
+				}
+			}
+		})
+	}
+}
+
+func TestTaskService_queueFullAlarm(t *testing.T) {
+	scheduler := &mockScheduler{count: 10}
+	svc := NewTaskService(scheduler, &mockProducer{}, zap.NewNop(),
+		WithMaxPending(10),
+		WithLifecycleWebhooks([]string{"http://ops.internal/alarms"}),
+	)
+
+	for i := 0; i < 3; i++ {
+		if err := svc.CreateTask(context.Background(), testTask()); !errors.Is(err, domain.ErrQueueFull) {
+			t.Fatalf("expected ErrQueueFull, got %v", err)
+		}
+	}
+
+	if len(scheduler.scheduledTasks) != 1 {
+		t.Fatalf("expected one alarm per interval, got %d scheduled tasks", len(scheduler.scheduledTasks))
+	}
+	var payload lifecycleEvent
+	if err := json.Unmarshal([]byte(scheduler.scheduledTasks[0].Task.MessageData), &payload); err != nil {
+		t.Fatalf("event payload is not valid JSON: %v", err)
+	}
+	if payload.Type != EventQueueFull || payload.Pending != 10 || payload.MaxPending != 10 {
+		t.Fatalf("unexpected alarm payload: %+v", payload)
+	}
+}
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// LogSampling thins out the per-task delivery logs, which otherwise grow
+// with throughput. Each second, the first First entries with a given level
+// and message are logged and then every Thereafter-th one. Warnings and
+// errors are never sampled. A zero First disables sampling.
+type LogSampling struct {
+	First      int
+	Thereafter int
+}
+
+// WithDeliveryLogSampling samples the per-task delivery logs as policy says.
+func WithDeliveryLogSampling(policy LogSampling) Option {
+	return func(s *TaskService) {
+		s.logSampling = policy
+	}
+}
+
+// deliveryLogger returns the logger per-task logs derive from: logger,
+// sampled below warning level if the policy asks for it.
+func deliveryLogger(logger *zap.Logger, policy LogSampling) *zap.Logger {
+	if policy.First <= 0 {
+		return logger
+	}
+	return logger.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		sampled := zapcore.NewSamplerWithOptions(core, time.Second, policy.First, policy.Thereafter)
+		return zapcore.NewTee(
+			levelRangeCore{Core: sampled, min: zapcore.DebugLevel, max: zapcore.InfoLevel},
+			levelRangeCore{Core: core, min: zapcore.WarnLevel, max: zapcore.FatalLevel},
+		)
+	}))
+}
+
+// levelRangeCore only passes entries from min to max inclusive.
+type levelRangeCore struct {
+	zapcore.Core
+	min, max zapcore.Level
+}
+
+func (c levelRangeCore) Enabled(level zapcore.Level) bool {
+	return level >= c.min && level <= c.max && c.Core.Enabled(level)
+}
+
+func (c levelRangeCore) With(fields []zapcore.Field) zapcore.Core {
+	return levelRangeCore{Core: c.Core.With(fields), min: c.min, max: c.max}
+}
+
+func (c levelRangeCore) Check(entry zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if entry.Level < c.min || entry.Level > c.max {
+		return ce
+	}
+	return c.Core.Check(entry, ce)
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+	"github.com/ruudy-sib/rebound/internal/domain/valueobject"
+)
+
+func TestTaskService_ProcessDueTasks_redactedDeadLetter(t *testing.T) {
+	task := testTask()
+	task.Attempt = 3
+	task.MessageData = `{"user":{"email":"a@example.com"},"amount":12}`
+
+	calls := 0
+	producer := &mockProducer{
+		produceFunc: func(context.Context, entity.Destination, []byte, []byte) error {
+			calls++
+			if calls == 1 {
+				return errors.New("kafka down")
+			}
+			return nil
+		},
+	}
+	scheduler := &mockScheduler{
+		fetchDueFunc: func(context.Context, int) ([]*entity.Task, error) {
+			return []*entity.Task{task}, nil
+		},
+	}
+	redaction, err := valueobject.NewRedaction([]string{"$.user.email"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	svc := NewTaskService(scheduler, producer, zap.NewNop(), WithRedaction(redaction))
+	if _, err := svc.ProcessDueTasks(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var msg deadLetterMessage
+	if err := json.Unmarshal(producer.produceCalls[1].Value, &msg); err != nil {
+		t.Fatalf("dead-letter payload is not valid JSON: %v", err)
+	}
+	want := `{"amount":12,"user":{"email":"[REDACTED]"}}`
+	if msg.MessageData != want {
+		t.Fatalf("expected message_data %s, got %s", want, msg.MessageData)
+	}
+}
+
+func TestDeliveryLogger_sampling(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	logger := deliveryLogger(zap.New(core), LogSampling{First: 2, Thereafter: 100})
+
+	for i := 0; i < 10; i++ {
+		logger.Info("task delivered")
+		logger.Warn("delivery failed")
+	}
+
+	if got := logs.FilterMessage("task delivered").Len(); got != 2 {
+		t.Fatalf("expected 2 sampled info logs, got %d", got)
+	}
+	if got := logs.FilterMessage("delivery failed").Len(); got != 10 {
+		t.Fatalf("expected every warning to be logged, got %d", got)
+	}
+}
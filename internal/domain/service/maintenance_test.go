@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+func TestMaintenanceService_StartMaintenance(t *testing.T) {
+	now := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name        string
+		destination string
+		until       time.Time
+		wantErr     bool
+	}{
+		{name: "future window", destination: "http://partner/hook", until: now.Add(time.Hour)},
+		{name: "missing destination", until: now.Add(time.Hour), wantErr: true},
+		{name: "window in the past", destination: "orders", until: now.Add(-time.Minute), wantErr: true},
+		{name: "window ending now", destination: "orders", until: now, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newMockMaintenance()
+			svc := NewMaintenanceService(store, zap.NewNop())
+			svc.now = func() time.Time { return now }
+
+			err := svc.StartMaintenance(context.Background(), tt.destination, tt.until)
+			if tt.wantErr {
+				if !errors.Is(err, domain.ErrInvalidMaintenanceWindow) {
+					t.Fatalf("expected ErrInvalidMaintenanceWindow, got %v", err)
+				}
+				if len(store.windows) != 0 {
+					t.Fatalf("expected no window stored, got %d", len(store.windows))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !store.windows[tt.destination].Equal(tt.until) {
+				t.Fatalf("expected window until %v, got %v", tt.until, store.windows[tt.destination])
+			}
+		})
+	}
+}
+
+func TestTaskService_ProcessDueTasks_Maintenance(t *testing.T) {
+	t.Run("tasks for a destination under maintenance are held until the window ends", func(t *testing.T) {
+		until := time.Now().Add(30 * time.Minute)
+		maintenance := newMockMaintenance()
+		maintenance.windows["http://localhost:8090/webhook"] = until
+
+		held1, held2 := testHTTPTask(), testHTTPTask()
+		held2.ID = "task-http-2"
+		other := testTask()
+
+		scheduler := &mockScheduler{
+			fetchDueFunc: func(_ context.Context, _ int) ([]*entity.Task, error) {
+				return []*entity.Task{held1, other, held2}, nil
+			},
+		}
+		producer := &mockProducer{}
+		svc := NewTaskService(scheduler, producer, zap.NewNop(), WithMaintenance(maintenance))
+
+		if _, err := svc.ProcessDueTasks(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(producer.produceCalls) != 1 || producer.produceCalls[0].Destination.Topic != "my-topic" {
+			t.Fatalf("expected only the kafka task to be delivered, got %d calls", len(producer.produceCalls))
+		}
+		if len(scheduler.scheduledTasks) != 2 {
+			t.Fatalf("expected 2 held tasks rescheduled, got %d", len(scheduler.scheduledTasks))
+		}
+		for _, call := range scheduler.scheduledTasks {
+			if call.Task.Attempt != 0 {
+				t.Fatalf("held task should not use an attempt, got attempt %d", call.Task.Attempt)
+			}
+			if call.Delay < 29*time.Minute || call.Delay > 30*time.Minute {
+				t.Fatalf("expected delay up to the window end, got %v", call.Delay)
+			}
+		}
+		if maintenance.gets != 2 {
+			t.Fatalf("expected one lookup per destination, got %d", maintenance.gets)
+		}
+	})
+
+	t.Run("lookup errors fail open", func(t *testing.T) {
+		maintenance := newMockMaintenance()
+		maintenance.getErr = errors.New("redis down")
+
+		scheduler := &mockScheduler{
+			fetchDueFunc: func(_ context.Context, _ int) ([]*entity.Task, error) {
+				return []*entity.Task{testHTTPTask()}, nil
+			},
+		}
+		producer := &mockProducer{}
+		svc := NewTaskService(scheduler, producer, zap.NewNop(), WithMaintenance(maintenance))
+
+		if _, err := svc.ProcessDueTasks(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(producer.produceCalls) != 1 {
+			t.Fatalf("expected delivery despite lookup error, got %d calls", len(producer.produceCalls))
+		}
+	})
+}
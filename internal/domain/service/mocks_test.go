@@ -2,9 +2,12 @@ package service
 
 import (
 	"context"
+	"sync"
 	"time"
 
+	"github.com/ruudy-sib/rebound/internal/domain"
 	"github.com/ruudy-sib/rebound/internal/domain/entity"
+	"github.com/ruudy-sib/rebound/internal/port/secondary"
 )
 
 // mockScheduler implements secondary.TaskScheduler for testing.
@@ -12,8 +15,22 @@ type mockScheduler struct {
 	scheduleFunc func(ctx context.Context, task *entity.Task, delay time.Duration) error
 	fetchDueFunc func(ctx context.Context, limit int) ([]*entity.Task, error)
 	removeFunc   func(ctx context.Context, rawMember string) error
+	dequeueFunc  func(ctx context.Context, taskID string) (*entity.Task, time.Time, error)
+	dueTimes     []time.Time
+	peeked       []entity.ScheduledTask
 
+	count             int64
+	destinationCounts map[string]int64
+
+	shiftFilter entity.TaskFilter
+	shiftBy     time.Duration
+
+	rescheduled  entity.Reschedule
+	rescheduleFn func(ctx context.Context, taskID string, change entity.Reschedule) (time.Time, time.Time, error)
+
+	mu             sync.Mutex
 	scheduledTasks []scheduledCall
+	fetchCalls     []fetchCall
 }
 
 type scheduledCall struct {
@@ -21,15 +38,25 @@ type scheduledCall struct {
 	Delay time.Duration
 }
 
+type fetchCall struct {
+	Queue string
+	Limit int
+}
+
 func (m *mockScheduler) Schedule(ctx context.Context, task *entity.Task, delay time.Duration) error {
+	m.mu.Lock()
 	m.scheduledTasks = append(m.scheduledTasks, scheduledCall{Task: task, Delay: delay})
+	m.mu.Unlock()
 	if m.scheduleFunc != nil {
 		return m.scheduleFunc(ctx, task, delay)
 	}
 	return nil
 }
 
-func (m *mockScheduler) FetchDue(ctx context.Context, limit int) ([]*entity.Task, error) {
+func (m *mockScheduler) FetchDue(ctx context.Context, queue string, limit int) ([]*entity.Task, error) {
+	m.mu.Lock()
+	m.fetchCalls = append(m.fetchCalls, fetchCall{Queue: queue, Limit: limit})
+	m.mu.Unlock()
 	if m.fetchDueFunc != nil {
 		return m.fetchDueFunc(ctx, limit)
 	}
@@ -43,11 +70,85 @@ func (m *mockScheduler) Remove(ctx context.Context, rawMember string) error {
 	return nil
 }
 
+// Get looks the task up the way Dequeue does.
+func (m *mockScheduler) Get(ctx context.Context, taskID string) (*entity.Task, error) {
+	task, _, err := m.Dequeue(ctx, taskID)
+	return task, err
+}
+
+func (m *mockScheduler) Dequeue(ctx context.Context, taskID string) (*entity.Task, time.Time, error) {
+	if m.dequeueFunc != nil {
+		return m.dequeueFunc(ctx, taskID)
+	}
+	return nil, time.Time{}, domain.ErrTaskNotFound
+}
+
+// Peek returns the tasks in peeked, up to limit. Its cursors hold the
+// position in peeked as their score.
+func (m *mockScheduler) Peek(_ context.Context, after entity.Cursor, limit int) ([]entity.ScheduledTask, entity.Cursor, error) {
+	rest := m.peeked[min(int(after.Score), len(m.peeked)):]
+	if len(rest) > limit {
+		return rest[:limit], entity.Cursor{Score: after.Score + float64(limit)}, nil
+	}
+	return rest, entity.Cursor{}, nil
+}
+
+// FindByDestination filters peeked by destination name, up to limit.
+func (m *mockScheduler) FindByDestination(_ context.Context, destination string, limit int) ([]entity.ScheduledTask, error) {
+	var found []entity.ScheduledTask
+	for _, st := range m.peeked {
+		if st.Task.Destination.Name() == destination && len(found) < limit {
+			found = append(found, st)
+		}
+	}
+	return found, nil
+}
+
+// Count returns count plus the number of Schedule calls made.
+func (m *mockScheduler) Count(_ context.Context) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.count + int64(len(m.scheduledTasks)), nil
+}
+
+// CountByDestination returns destinationCounts[destination].
+func (m *mockScheduler) CountByDestination(_ context.Context, destination string) (int64, error) {
+	return m.destinationCounts[destination], nil
+}
+
+// CountDueBy counts dueTimes at or before each time.
+func (m *mockScheduler) CountDueBy(_ context.Context, times []time.Time) ([]int64, error) {
+	counts := make([]int64, len(times))
+	for i, t := range times {
+		for _, due := range m.dueTimes {
+			if !due.After(t) {
+				counts[i]++
+			}
+		}
+	}
+	return counts, nil
+}
+
+func (m *mockScheduler) Shift(_ context.Context, filter entity.TaskFilter, by time.Duration) (int, error) {
+	m.shiftFilter = filter
+	m.shiftBy = by
+	return len(m.dueTimes), nil
+}
+
+func (m *mockScheduler) Reschedule(ctx context.Context, taskID string, change entity.Reschedule) (time.Time, time.Time, error) {
+	m.rescheduled = change
+	if m.rescheduleFn != nil {
+		return m.rescheduleFn(ctx, taskID, change)
+	}
+	return time.Time{}, time.Time{}, domain.ErrTaskNotFound
+}
+
 // mockProducer implements secondary.MessageProducer for testing.
 type mockProducer struct {
 	produceFunc func(ctx context.Context, destination entity.Destination, key, value []byte) error
 	closeFunc   func() error
 
+	mu           sync.Mutex
 	produceCalls []produceCall
 }
 
@@ -55,18 +156,26 @@ type produceCall struct {
 	Destination entity.Destination
 	Key         []byte
 	Value       []byte
+	Headers     map[string]string
+	Transport   map[string]string
+	Priority    bool
 	Err         error
 }
 
-func (m *mockProducer) Produce(ctx context.Context, destination entity.Destination, key, value []byte) error {
+func (m *mockProducer) Produce(ctx context.Context, destination entity.Destination, msg secondary.Message) error {
 	var err error
 	if m.produceFunc != nil {
-		err = m.produceFunc(ctx, destination, key, value)
+		err = m.produceFunc(ctx, destination, msg.Key, msg.Value)
 	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	m.produceCalls = append(m.produceCalls, produceCall{
 		Destination: destination,
-		Key:         key,
-		Value:       value,
+		Key:         msg.Key,
+		Value:       msg.Value,
+		Headers:     msg.Headers,
+		Transport:   msg.TransportHeaders,
+		Priority:    msg.Priority,
 		Err:         err,
 	})
 	return err
@@ -102,7 +211,7 @@ func testHTTPTask() *entity.Task {
 			URL: "http://localhost:8090/dead",
 		},
 		MaxRetries:      3,
-		BaseDelay:       2,
+		BaseDelay:       2 * time.Second,
 		ClientID:        "client-1",
 		IsPriority:      false,
 		MessageData:     "test message data",
@@ -126,10 +235,536 @@ func testTask() *entity.Task {
 			Topic: "dead-topic",
 		},
 		MaxRetries:      3,
-		BaseDelay:       2,
+		BaseDelay:       2 * time.Second,
 		ClientID:        "client-1",
 		IsPriority:      false,
 		MessageData:     "test message data",
 		DestinationType: entity.DestinationTypeKafka,
 	}
 }
+
+// mockQuarantine implements secondary.QuarantineStore for testing.
+type mockQuarantine struct {
+	quarantineFunc func(ctx context.Context, task *entity.Task, reason string) error
+
+	quarantined []*entity.Task
+}
+
+func (m *mockQuarantine) Quarantine(ctx context.Context, task *entity.Task, reason string) error {
+	if m.quarantineFunc != nil {
+		if err := m.quarantineFunc(ctx, task, reason); err != nil {
+			return err
+		}
+	}
+	m.quarantined = append(m.quarantined, task)
+	return nil
+}
+
+// receiptProducer is a mockProducer that also reports a receipt.
+type receiptProducer struct {
+	mockProducer
+	receipt entity.DeliveryReceipt
+}
+
+func (m *receiptProducer) ProduceWithReceipt(ctx context.Context, destination entity.Destination, msg secondary.Message) (entity.DeliveryReceipt, error) {
+	if err := m.Produce(ctx, destination, msg); err != nil {
+		return entity.DeliveryReceipt{}, err
+	}
+	return m.receipt, nil
+}
+
+// mockDeliveryResultStore implements secondary.DeliveryResultStore for testing.
+type mockDeliveryResultStore struct {
+	saveErr error
+
+	saved map[string]entity.DeliveryResult
+	ttl   time.Duration
+}
+
+func (m *mockDeliveryResultStore) Save(_ context.Context, result entity.DeliveryResult, ttl time.Duration) error {
+	if m.saveErr != nil {
+		return m.saveErr
+	}
+	if m.saved == nil {
+		m.saved = make(map[string]entity.DeliveryResult)
+	}
+	m.saved[result.TaskID] = result
+	m.ttl = ttl
+	return nil
+}
+
+func (m *mockDeliveryResultStore) Get(_ context.Context, taskID string) (*entity.DeliveryResult, error) {
+	result, ok := m.saved[taskID]
+	if !ok {
+		return nil, domain.ErrTaskNotFound
+	}
+	return &result, nil
+}
+
+// mockDeadLetterStore implements secondary.DeadLetterStore for testing.
+type mockDeadLetterStore struct {
+	storeErr error
+
+	stored  []*entity.Task
+	reasons []string
+}
+
+func (m *mockDeadLetterStore) Store(_ context.Context, task *entity.Task, reason string) error {
+	if m.storeErr != nil {
+		return m.storeErr
+	}
+	m.stored = append(m.stored, task)
+	m.reasons = append(m.reasons, reason)
+	return nil
+}
+
+// List returns stored tasks, newest first. Its cursors hold the number of
+// entries already listed as their score.
+func (m *mockDeadLetterStore) List(_ context.Context, after entity.Cursor, limit int) ([]entity.DeadLetter, entity.Cursor, error) {
+	var entries []entity.DeadLetter
+	for i := len(m.stored) - 1 - int(after.Score); i >= 0 && len(entries) < limit; i-- {
+		entries = append(entries, entity.DeadLetter{Task: m.stored[i], Reason: m.reasons[i]})
+	}
+	if listed := int(after.Score) + len(entries); listed < len(m.stored) {
+		return entries, entity.Cursor{Score: float64(listed)}, nil
+	}
+	return entries, entity.Cursor{}, nil
+}
+
+func (m *mockDeadLetterStore) Take(_ context.Context, taskID string) (*entity.DeadLetter, error) {
+	for i, task := range m.stored {
+		if task.ID == taskID {
+			entry := &entity.DeadLetter{Task: task, Reason: m.reasons[i]}
+			m.stored = append(m.stored[:i], m.stored[i+1:]...)
+			m.reasons = append(m.reasons[:i], m.reasons[i+1:]...)
+			return entry, nil
+		}
+	}
+	return nil, domain.ErrTaskNotFound
+}
+
+// mockOrdering implements secondary.OrderingStore in memory for testing.
+type mockOrdering struct {
+	active  map[string]string
+	waiting map[string][]*entity.Task
+}
+
+func newMockOrdering() *mockOrdering {
+	return &mockOrdering{
+		active:  make(map[string]string),
+		waiting: make(map[string][]*entity.Task),
+	}
+}
+
+func (m *mockOrdering) Enqueue(_ context.Context, task *entity.Task) (bool, error) {
+	if _, ok := m.active[task.OrderingKey]; !ok {
+		m.active[task.OrderingKey] = task.ID
+		return true, nil
+	}
+	m.waiting[task.OrderingKey] = append(m.waiting[task.OrderingKey], task)
+	return false, nil
+}
+
+func (m *mockOrdering) Advance(_ context.Context, key string) (*entity.Task, error) {
+	queue := m.waiting[key]
+	if len(queue) == 0 {
+		delete(m.active, key)
+		return nil, nil
+	}
+	next := queue[0]
+	m.waiting[key] = queue[1:]
+	m.active[key] = next.ID
+	return next, nil
+}
+
+// mockCompletionStore implements secondary.CompletionStore in memory for testing.
+type mockCompletionStore struct {
+	completed map[string]time.Duration
+	checkErr  error
+}
+
+func newMockCompletionStore() *mockCompletionStore {
+	return &mockCompletionStore{completed: make(map[string]time.Duration)}
+}
+
+func (m *mockCompletionStore) MarkCompleted(_ context.Context, taskID string, ttl time.Duration) error {
+	if _, ok := m.completed[taskID]; !ok {
+		m.completed[taskID] = ttl
+	}
+	return nil
+}
+
+func (m *mockCompletionStore) IsCompleted(_ context.Context, taskID string) (bool, error) {
+	if m.checkErr != nil {
+		return false, m.checkErr
+	}
+	_, ok := m.completed[taskID]
+	return ok, nil
+}
+
+// mockBatchProducer wraps mockProducer and also implements secondary.BatchProducer.
+type mockBatchProducer struct {
+	mockProducer
+	batchErr error
+
+	batches [][]secondary.Message
+}
+
+func (m *mockBatchProducer) ProduceBatch(_ context.Context, _ entity.Destination, messages []secondary.Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.batches = append(m.batches, messages)
+	return m.batchErr
+}
+
+// mockMaintenance implements secondary.MaintenanceStore in memory for testing.
+type mockMaintenance struct {
+	windows map[string]time.Time
+	getErr  error
+
+	gets int
+}
+
+func newMockMaintenance() *mockMaintenance {
+	return &mockMaintenance{windows: make(map[string]time.Time)}
+}
+
+func (m *mockMaintenance) Set(_ context.Context, window entity.MaintenanceWindow) error {
+	m.windows[window.Destination] = window.Until
+	return nil
+}
+
+func (m *mockMaintenance) Clear(_ context.Context, destination string) error {
+	delete(m.windows, destination)
+	return nil
+}
+
+func (m *mockMaintenance) Get(_ context.Context, destination string) (*entity.MaintenanceWindow, error) {
+	m.gets++
+	if m.getErr != nil {
+		return nil, m.getErr
+	}
+	until, ok := m.windows[destination]
+	if !ok || !time.Now().Before(until) {
+		return nil, nil
+	}
+	return &entity.MaintenanceWindow{Destination: destination, Until: until}, nil
+}
+
+func (m *mockMaintenance) List(_ context.Context) ([]entity.MaintenanceWindow, error) {
+	var windows []entity.MaintenanceWindow
+	for destination, until := range m.windows {
+		windows = append(windows, entity.MaintenanceWindow{Destination: destination, Until: until})
+	}
+	return windows, nil
+}
+
+// mockSLAStore implements secondary.SLAStore in memory for testing.
+type mockSLAStore struct {
+	samples map[[2]string][]time.Duration
+}
+
+func newMockSLAStore() *mockSLAStore {
+	return &mockSLAStore{samples: make(map[[2]string][]time.Duration)}
+}
+
+func (m *mockSLAStore) Record(_ context.Context, clientID, destination string, timeToSuccess time.Duration) error {
+	key := [2]string{clientID, destination}
+	m.samples[key] = append(m.samples[key], timeToSuccess)
+	return nil
+}
+
+func (m *mockSLAStore) Samples(_ context.Context) ([]secondary.TimeToSuccessSamples, error) {
+	var result []secondary.TimeToSuccessSamples
+	for key, samples := range m.samples {
+		result = append(result, secondary.TimeToSuccessSamples{ClientID: key[0], Destination: key[1], Samples: samples})
+	}
+	return result, nil
+}
+
+// mockUsageStore implements secondary.UsageStore in memory for testing.
+type mockUsageStore struct {
+	usage map[[2]string]entity.ClientUsage
+}
+
+func newMockUsageStore() *mockUsageStore {
+	return &mockUsageStore{usage: make(map[[2]string]entity.ClientUsage)}
+}
+
+func (m *mockUsageStore) Add(_ context.Context, usage []entity.ClientUsage) error {
+	for _, u := range usage {
+		key := [2]string{u.Period, u.ClientID}
+		total := m.usage[key]
+		total.ClientID, total.Period = u.ClientID, u.Period
+		total.Delivered += u.Delivered
+		total.Retried += u.Retried
+		m.usage[key] = total
+	}
+	return nil
+}
+
+func (m *mockUsageStore) Usage(_ context.Context, period string) ([]entity.ClientUsage, error) {
+	var result []entity.ClientUsage
+	for key, u := range m.usage {
+		if key[0] == period {
+			result = append(result, u)
+		}
+	}
+	return result, nil
+}
+
+// mockCancelledStore implements secondary.CancelledStore in memory for testing.
+type mockCancelledStore struct {
+	held    map[string]secondary.CancelledTask
+	holdErr error
+
+	ttl time.Duration
+}
+
+func newMockCancelledStore() *mockCancelledStore {
+	return &mockCancelledStore{held: make(map[string]secondary.CancelledTask)}
+}
+
+func (m *mockCancelledStore) Hold(_ context.Context, cancelled secondary.CancelledTask, ttl time.Duration) error {
+	if m.holdErr != nil {
+		return m.holdErr
+	}
+	m.held[cancelled.Task.ID] = cancelled
+	m.ttl = ttl
+	return nil
+}
+
+func (m *mockCancelledStore) Get(_ context.Context, taskID string) (*secondary.CancelledTask, error) {
+	cancelled, ok := m.held[taskID]
+	if !ok {
+		return nil, domain.ErrTaskNotFound
+	}
+	return &cancelled, nil
+}
+
+func (m *mockCancelledStore) Take(_ context.Context, taskID string) (*secondary.CancelledTask, error) {
+	cancelled, ok := m.held[taskID]
+	if !ok {
+		return nil, domain.ErrTaskNotFound
+	}
+	delete(m.held, taskID)
+	return &cancelled, nil
+}
+
+// mockQueueStore implements secondary.QueueStore in memory for testing.
+type mockQueueStore struct {
+	counts map[entity.Queue]int64
+	tokens map[entity.Queue]string
+
+	purged []entity.Queue
+
+	corrupt      []entity.CorruptMember
+	corruptTotal int64
+}
+
+func newMockQueueStore() *mockQueueStore {
+	return &mockQueueStore{
+		counts: make(map[entity.Queue]int64),
+		tokens: make(map[entity.Queue]string),
+	}
+}
+
+func (m *mockQueueStore) Count(_ context.Context, queue entity.Queue) (int64, error) {
+	return m.counts[queue], nil
+}
+
+func (m *mockQueueStore) Stats(_ context.Context, queue entity.Queue, _ time.Time) (entity.QueueStats, error) {
+	return entity.QueueStats{Queue: queue, Pending: m.counts[queue]}, nil
+}
+
+func (m *mockQueueStore) Purge(_ context.Context, queue entity.Queue) (int64, error) {
+	m.purged = append(m.purged, queue)
+	n := m.counts[queue]
+	m.counts[queue] = 0
+	return n, nil
+}
+
+func (m *mockQueueStore) SavePurgeToken(_ context.Context, queue entity.Queue, token string, _ time.Duration) error {
+	m.tokens[queue] = token
+	return nil
+}
+
+func (m *mockQueueStore) TakePurgeToken(_ context.Context, queue entity.Queue, token string) (bool, error) {
+	saved, ok := m.tokens[queue]
+	delete(m.tokens, queue)
+	return ok && saved == token, nil
+}
+
+func (m *mockQueueStore) ListCorrupt(_ context.Context, limit int) ([]entity.CorruptMember, error) {
+	if len(m.corrupt) > limit {
+		return m.corrupt[:limit], nil
+	}
+	return m.corrupt, nil
+}
+
+func (m *mockQueueStore) CorruptTotal(_ context.Context) (int64, error) {
+	return m.corruptTotal, nil
+}
+
+// DeleteCorrupt removes the member with id from corrupt.
+func (m *mockQueueStore) DeleteCorrupt(_ context.Context, id string) (bool, error) {
+	for i, member := range m.corrupt {
+		if member.ID == id {
+			m.corrupt = append(m.corrupt[:i], m.corrupt[i+1:]...)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// mockHeartbeat implements secondary.HeartbeatStore for testing.
+type mockHeartbeat struct {
+	beats     []time.Duration
+	processed int
+	errors    int
+}
+
+func (m *mockHeartbeat) Beat(_ context.Context, ttl time.Duration) error {
+	m.beats = append(m.beats, ttl)
+	return nil
+}
+
+func (m *mockHeartbeat) RecordPoll(_ context.Context, processed, errors int) error {
+	m.processed += processed
+	m.errors += errors
+	return nil
+}
+
+// mockWorkerRegistry implements secondary.WorkerRegistry for testing.
+type mockWorkerRegistry struct {
+	workers []entity.WorkerInstance
+	err     error
+}
+
+func (m *mockWorkerRegistry) Workers(_ context.Context) ([]entity.WorkerInstance, error) {
+	return m.workers, m.err
+}
+
+// mockReconciler implements secondary.StateReconciler for testing.
+type mockReconciler struct {
+	claimed    bool
+	report     entity.RepairReport
+	err        error
+	reconciled int
+
+	// started, when set, receives a value as a pass begins; the pass then
+	// waits for release.
+	started chan struct{}
+	release chan struct{}
+}
+
+func (m *mockReconciler) Claim(_ context.Context, _ time.Duration) (bool, error) {
+	return m.claimed, nil
+}
+
+func (m *mockReconciler) Reconcile(_ context.Context) (entity.RepairReport, error) {
+	m.reconciled++
+	if m.started != nil {
+		m.started <- struct{}{}
+		<-m.release
+	}
+	return m.report, m.err
+}
+
+// mockInFlightStore implements secondary.InFlightStore for testing.
+type mockInFlightStore struct {
+	mu        sync.Mutex
+	tracked   []string
+	deadline  time.Time
+	released  []string
+	reclaimed []*entity.Task
+	extended  map[string]time.Time
+	extendErr error
+}
+
+func (m *mockInFlightStore) Track(_ context.Context, tasks []*entity.Task, deadline time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, task := range tasks {
+		m.tracked = append(m.tracked, task.ID)
+	}
+	m.deadline = deadline
+	return nil
+}
+
+func (m *mockInFlightStore) Extend(_ context.Context, taskID string, deadline time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.extendErr != nil {
+		return m.extendErr
+	}
+	if m.extended == nil {
+		m.extended = make(map[string]time.Time)
+	}
+	m.extended[taskID] = deadline
+	return nil
+}
+
+func (m *mockInFlightStore) Release(_ context.Context, taskIDs []string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.released = append(m.released, taskIDs...)
+	return nil
+}
+
+func (m *mockInFlightStore) Reclaim(_ context.Context, _ time.Time, _ int) ([]*entity.Task, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	tasks := m.reclaimed
+	m.reclaimed = nil
+	return tasks, nil
+}
+
+// mockRateLimitedProducer is a mockProducer that also implements
+// secondary.RateLimitedProducer.
+type mockRateLimitedProducer struct {
+	mockProducer
+	wait time.Duration
+}
+
+func (m *mockRateLimitedProducer) RateLimitedFor(entity.Destination) time.Duration {
+	return m.wait
+}
+
+// mockProber implements secondary.HealthProber for testing.
+type mockProber struct {
+	err    error
+	probes int
+}
+
+func (m *mockProber) Probe(context.Context, entity.Destination) error {
+	m.probes++
+	return m.err
+}
+
+// mockOverflowStore implements secondary.OverflowStore for testing.
+type mockOverflowStore struct {
+	tasks []*entity.Task
+}
+
+func (m *mockOverflowStore) Spill(_ context.Context, tasks ...*entity.Task) error {
+	m.tasks = append(m.tasks, tasks...)
+	return nil
+}
+
+func (m *mockOverflowStore) Restore(_ context.Context, limit int) ([]*entity.Task, error) {
+	n := min(limit, len(m.tasks))
+	restored := m.tasks[:n:n]
+	m.tasks = m.tasks[n:]
+	return restored, nil
+}
+
+// mockNotifier records the outcomes it was asked to announce.
+type mockNotifier struct {
+	notified []entity.TaskOutcome
+}
+
+func (m *mockNotifier) Notify(_ context.Context, outcomes []entity.TaskOutcome) error {
+	m.notified = append(m.notified, outcomes...)
+	return nil
+}
@@ -0,0 +1,43 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+// ProcessDueTasksIn fetches due tasks of the named queue and processes
+// them like ProcessDueTasks, with the queue's batch size and its weighted
+// share of the concurrency. The empty name is the default queue.
+func (s *TaskService) ProcessDueTasksIn(ctx context.Context, queue string) (entity.ProcessResult, error) {
+	if queue == "" {
+		return s.ProcessDueTasks(ctx)
+	}
+	policy, ok := s.queues[queue]
+	if !ok {
+		return entity.ProcessResult{}, fmt.Errorf("queue %q is not configured", queue)
+	}
+	batchSize := policy.BatchSize
+	if batchSize <= 0 {
+		batchSize = s.batchSize
+	}
+	return s.processDue(ctx, queue, batchSize)
+}
+
+// queueConcurrency is how many tasks of queue one poll delivers at once:
+// the queue's weighted share of the concurrency, at least one. Without
+// named queues the default queue gets all of it.
+func (s *TaskService) queueConcurrency(queue string) int {
+	if len(s.queues) == 0 {
+		return s.concurrency
+	}
+	weight, total := 1, 1
+	for name, policy := range s.queues {
+		total += policy.Weight
+		if name == queue {
+			weight = policy.Weight
+		}
+	}
+	return max(1, s.concurrency*weight/total)
+}
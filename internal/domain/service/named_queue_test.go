@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+)
+
+func TestTaskService_ProcessDueTasksIn(t *testing.T) {
+	scheduler := &mockScheduler{}
+	svc := NewTaskService(scheduler, &mockProducer{}, zap.NewNop(),
+		WithBatchSize(20),
+		WithQueues(map[string]QueuePolicy{
+			"payments":   {BatchSize: 50, Weight: 3},
+			"newsletter": {},
+		}),
+	)
+
+	ctx := context.Background()
+	for _, queue := range []string{"payments", "newsletter", ""} {
+		if _, err := svc.ProcessDueTasksIn(ctx, queue); err != nil {
+			t.Fatalf("queue %q: unexpected error: %v", queue, err)
+		}
+	}
+	if _, err := svc.ProcessDueTasksIn(ctx, "unknown"); err == nil {
+		t.Fatal("expected an error for a queue that is not configured")
+	}
+
+	want := []fetchCall{{Queue: "payments", Limit: 50}, {Queue: "newsletter", Limit: 20}, {Queue: "", Limit: 20}}
+	if len(scheduler.fetchCalls) != len(want) {
+		t.Fatalf("expected fetches %+v, got %+v", want, scheduler.fetchCalls)
+	}
+	for i := range want {
+		if scheduler.fetchCalls[i] != want[i] {
+			t.Fatalf("expected fetches %+v, got %+v", want, scheduler.fetchCalls)
+		}
+	}
+}
+
+func TestTaskService_queueConcurrency(t *testing.T) {
+	queues := map[string]QueuePolicy{
+		"payments":   {Weight: 3},
+		"newsletter": {},
+	}
+	tests := []struct {
+		name        string
+		concurrency int
+		queues      map[string]QueuePolicy
+		want        map[string]int
+	}{
+		{
+			name:        "default queue only",
+			concurrency: 10,
+			want:        map[string]int{"": 10},
+		},
+		{
+			name:        "split by weight",
+			concurrency: 10,
+			queues:      queues,
+			want:        map[string]int{"": 2, "payments": 6, "newsletter": 2},
+		},
+		{
+			name:        "at least one each",
+			concurrency: 2,
+			queues:      queues,
+			want:        map[string]int{"": 1, "payments": 1, "newsletter": 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewTaskService(&mockScheduler{}, &mockProducer{}, zap.NewNop(),
+				WithConcurrency(tt.concurrency),
+				WithQueues(tt.queues),
+			)
+			for queue, want := range tt.want {
+				if got := svc.queueConcurrency(queue); got != want {
+					t.Fatalf("queue %q: expected %d, got %d", queue, want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestTaskService_CreateTask_queue(t *testing.T) {
+	scheduler := &mockScheduler{}
+	svc := NewTaskService(scheduler, &mockProducer{}, zap.NewNop(),
+		WithQueues(map[string]QueuePolicy{"payments": {}}),
+	)
+
+	task := testTask()
+	task.Queue = "payments"
+	if err := svc.CreateTask(context.Background(), task); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	task = testTask()
+	task.Queue = "newsletter"
+	if err := svc.CreateTask(context.Background(), task); !errors.Is(err, domain.ErrInvalidTask) {
+		t.Fatalf("expected ErrInvalidTask for an unknown queue, got %v", err)
+	}
+	if len(scheduler.scheduledTasks) != 1 || scheduler.scheduledTasks[0].Task.Queue != "payments" {
+		t.Fatalf("expected only the payments task scheduled, got %+v", scheduler.scheduledTasks)
+	}
+}
@@ -0,0 +1,31 @@
+package service
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+// notifyTerminal announces the poll's tasks that reached a terminal state.
+// Like recordUsage, a failure is only logged: the tasks are already
+// settled.
+func (s *TaskService) notifyTerminal(ctx context.Context, result entity.ProcessResult) {
+	if s.notifier == nil {
+		return
+	}
+
+	var terminal []entity.TaskOutcome
+	for _, t := range result.Tasks {
+		if t.Outcome.Terminal() {
+			terminal = append(terminal, t)
+		}
+	}
+	if len(terminal) == 0 {
+		return
+	}
+	if err := s.notifier.Notify(ctx, terminal); err != nil {
+		s.logger.Warn("failed to publish task notifications", zap.Error(err), zap.Int("count", len(terminal)))
+	}
+}
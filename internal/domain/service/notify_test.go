@@ -0,0 +1,57 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+func TestTaskService_ProcessDueTasks_terminalNotifications(t *testing.T) {
+	delivered := testHTTPTask()
+	retrying := testHTTPTask()
+	retrying.ID = "task-http-2"
+	retrying.Destination.URL = "http://localhost:8090/down"
+	dead := testHTTPTask()
+	dead.ID = "task-http-3"
+	dead.ClientID = "client-2"
+	dead.Destination.URL = "http://localhost:8090/down"
+	dead.Attempt = dead.MaxRetries
+
+	scheduler := &mockScheduler{
+		fetchDueFunc: func(_ context.Context, _ int) ([]*entity.Task, error) {
+			return []*entity.Task{delivered, retrying, dead}, nil
+		},
+	}
+	producer := &mockProducer{
+		produceFunc: func(_ context.Context, destination entity.Destination, _, _ []byte) error {
+			if destination.URL == "http://localhost:8090/down" {
+				return errors.New("connection refused")
+			}
+			return nil
+		},
+	}
+	notifier := &mockNotifier{}
+	svc := NewTaskService(scheduler, producer, zap.NewNop(), WithTerminalNotifications(notifier))
+
+	if _, err := svc.ProcessDueTasks(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := make(map[string]entity.TaskOutcome)
+	for _, o := range notifier.notified {
+		got[o.TaskID] = o
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected 2 notifications, got %+v", notifier.notified)
+	}
+	if got[delivered.ID].Outcome != entity.OutcomeDelivered {
+		t.Fatalf("expected %s delivered, got %+v", delivered.ID, got[delivered.ID])
+	}
+	if o := got[dead.ID]; o.Outcome != entity.OutcomeDead || o.ClientID != "client-2" {
+		t.Fatalf("expected %s dead for client-2, got %+v", dead.ID, o)
+	}
+}
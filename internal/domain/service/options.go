@@ -0,0 +1,434 @@
+package service
+
+import (
+	"time"
+
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+	"github.com/ruudy-sib/rebound/internal/domain/valueobject"
+	"github.com/ruudy-sib/rebound/internal/port/secondary"
+)
+
+// Option configures optional TaskService collaborators and policies.
+type Option func(*TaskService)
+
+// PoisonPolicy controls poison message detection. A task is quarantined once
+// it has failed Threshold times in a row with the same error, each attempt
+// failing within FailureWindow. A zero Threshold disables detection.
+type PoisonPolicy struct {
+	Threshold     int
+	FailureWindow time.Duration
+}
+
+// WithQuarantine enables poison message detection, parking matching tasks
+// in store instead of retrying them until their budget is exhausted.
+func WithQuarantine(store secondary.QuarantineStore, policy PoisonPolicy) Option {
+	return func(s *TaskService) {
+		s.quarantine = store
+		s.poisonPolicy = policy
+	}
+}
+
+// DeadLetterPolicy bounds the retries of the dead-letter produce itself.
+// Zero values fall back to the package defaults.
+type DeadLetterPolicy struct {
+	MaxAttempts int
+	Backoff     time.Duration
+}
+
+// WithDeadLetterPolicy overrides how often a failing dead-letter produce is retried.
+func WithDeadLetterPolicy(policy DeadLetterPolicy) Option {
+	return func(s *TaskService) {
+		s.deadLetterPolicy = policy
+	}
+}
+
+// WithDeadLetterMode sets where tasks that exhausted their retries are kept.
+// The modes that store tasks need WithDeadLetterFallback. An empty mode
+// produces them to their dead destination.
+func WithDeadLetterMode(mode entity.DeadLetterMode) Option {
+	return func(s *TaskService) {
+		s.deadLetterMode = mode
+	}
+}
+
+// RedrivePolicy re-drives tasks that exhausted their retries instead of
+// dead-lettering them: after Cooldown, the task gets MaxRetries more retries,
+// usually fewer than its original budget. A task is re-driven at most
+// MaxRedrives times (default 1) before it is dead-lettered for good.
+type RedrivePolicy struct {
+	Cooldown    time.Duration
+	MaxRetries  int
+	MaxRedrives int
+}
+
+// WithRedrivePolicies enables automatic re-drives, keyed by the name of the
+// dead destination (see entity.Destination.Name) the tasks would have been
+// dead-lettered to. Tasks with any other dead destination are unaffected.
+func WithRedrivePolicies(policies map[string]RedrivePolicy) Option {
+	return func(s *TaskService) {
+		s.redrivePolicies = make(map[string]RedrivePolicy, len(policies))
+		for name, policy := range policies {
+			if policy.MaxRedrives <= 0 {
+				policy.MaxRedrives = 1
+			}
+			s.redrivePolicies[name] = policy
+		}
+	}
+}
+
+// WithDeadLetterFallback persists tasks in store when producing to their
+// dead-letter destination keeps failing.
+func WithDeadLetterFallback(store secondary.DeadLetterStore) Option {
+	return func(s *TaskService) {
+		s.deadLetterStore = store
+	}
+}
+
+// WithOrdering enables strict per-key FIFO delivery for tasks that set an
+// OrderingKey. Without it, such tasks are rejected at creation.
+func WithOrdering(store secondary.OrderingStore) Option {
+	return func(s *TaskService) {
+		s.ordering = store
+	}
+}
+
+// WithCompletionMarkers records a marker for every delivered task and skips
+// any later delivery of the same task ID while the marker exists. A zero ttl
+// uses domain.DefaultCompletionMarkerTTL.
+func WithCompletionMarkers(store secondary.CompletionStore, ttl time.Duration) Option {
+	return func(s *TaskService) {
+		s.completions = store
+		s.completionTTL = ttl
+	}
+}
+
+// WithDeliveryResults records the result of every successful delivery in
+// store for ttl, including what the destination answered. A ttl of zero or
+// less disables recording.
+func WithDeliveryResults(store secondary.DeliveryResultStore, ttl time.Duration) Option {
+	return func(s *TaskService) {
+		if ttl <= 0 {
+			return
+		}
+		s.results = store
+		s.resultTTL = ttl
+	}
+}
+
+// WithBatchSize sets how many due tasks one poll fetches. A zero n uses
+// domain.DefaultBatchSize.
+func WithBatchSize(n int) Option {
+	return func(s *TaskService) {
+		s.batchSize = n
+	}
+}
+
+// QueuePolicy configures how a named queue is drained. BatchSize is how
+// many due tasks one poll of the queue fetches; zero uses the service's
+// batch size. Weight is the queue's share of the delivery concurrency
+// relative to the default queue, which weighs 1; zero counts as 1.
+type QueuePolicy struct {
+	BatchSize int
+	Weight    int
+}
+
+// WithQueues accepts tasks for the named queues in policies, which are
+// only drained by ProcessDueTasksIn. Tasks naming any other queue are
+// rejected at creation.
+func WithQueues(policies map[string]QueuePolicy) Option {
+	return func(s *TaskService) {
+		s.queues = make(map[string]QueuePolicy, len(policies))
+		for name, policy := range policies {
+			if policy.Weight <= 0 {
+				policy.Weight = 1
+			}
+			s.queues[name] = policy
+		}
+	}
+}
+
+// WithDeliveryTimeout bounds every delivery attempt, so a stalled Kafka
+// write or HTTP call fails as a timeout instead of holding the worker.
+// Attempt hooks are bounded separately, see WithAttemptHookTimeout. A zero
+// d uses domain.DefaultDeliveryTimeout.
+func WithDeliveryTimeout(d time.Duration) Option {
+	return func(s *TaskService) {
+		s.deliveryTimeout = d
+	}
+}
+
+// WithInFlightReclaim records every task while it is being delivered, and
+// reclaims tasks left in flight for reclaimAfter delivery timeouts by a
+// worker that died mid-delivery. Reclaimed tasks count a failed attempt. A
+// zero reclaimAfter uses domain.DefaultReclaimAfterTimeouts.
+func WithInFlightReclaim(store secondary.InFlightStore, reclaimAfter int) Option {
+	return func(s *TaskService) {
+		s.inFlight = store
+		s.reclaimAfter = reclaimAfter
+	}
+}
+
+// WithHTTPBatching coalesces up to maxSize due HTTP tasks that target the
+// same URL into a single request. It only takes effect when the producer
+// implements secondary.BatchProducer; a maxSize of 1 or less disables it.
+func WithHTTPBatching(maxSize int) Option {
+	return func(s *TaskService) {
+		s.httpBatchSize = maxSize
+	}
+}
+
+// WithMaintenance holds due tasks whose destination has an active
+// maintenance window in store, releasing them when the window ends.
+func WithMaintenance(store secondary.MaintenanceStore) Option {
+	return func(s *TaskService) {
+		s.maintenance = store
+	}
+}
+
+// SLAPolicy configures time-to-success alerting. A task delivered more than
+// Threshold after it was created breaches the SLA; the breach is logged and,
+// when BreachURL is set, POSTed there as an event. A zero Threshold disables
+// breach detection.
+type SLAPolicy struct {
+	Threshold time.Duration
+	BreachURL string
+}
+
+// WithSLATracking records the time from creation to successful delivery of
+// every task in store and applies policy to it.
+func WithSLATracking(store secondary.SLAStore, policy SLAPolicy) Option {
+	return func(s *TaskService) {
+		s.sla = store
+		s.slaPolicy = policy
+	}
+}
+
+// WithUsageTracking counts the delivered and retried attempts of every
+// client's tasks in store, per billing period. When events is set (a Kafka
+// topic or HTTP URL), each poll's counts are also produced there as usage
+// events, one per client, keyed by client ID.
+func WithUsageTracking(store secondary.UsageStore, events entity.Destination) Option {
+	return func(s *TaskService) {
+		s.usage = store
+		s.usageEvents = events
+	}
+}
+
+// WithTerminalNotifications announces every task a poll delivers,
+// dead-letters or quarantines through notifier.
+func WithTerminalNotifications(notifier secondary.TaskNotifier) Option {
+	return func(s *TaskService) {
+		s.notifier = notifier
+	}
+}
+
+// WithHeartbeat records a heartbeat in store every time due tasks are
+// fetched, valid for ttl. A zero ttl uses domain.DefaultHeartbeatTTL.
+func WithHeartbeat(store secondary.HeartbeatStore, ttl time.Duration) Option {
+	return func(s *TaskService) {
+		s.heartbeat = store
+		s.heartbeatTTL = ttl
+	}
+}
+
+// WithCancellation makes cancelled tasks restorable: they are moved to store
+// and kept for ttl before being discarded. A zero ttl uses
+// domain.DefaultCancelledTaskTTL.
+func WithCancellation(store secondary.CancelledStore, ttl time.Duration) Option {
+	return func(s *TaskService) {
+		s.cancelled = store
+		s.cancelledTTL = ttl
+	}
+}
+
+// WithConcurrency sets how many due tasks, or HTTP batches, one poll
+// delivers at once. Zero uses domain.DefaultDeliveryConcurrency; one
+// delivers them one after another.
+func WithConcurrency(n int) Option {
+	return func(s *TaskService) {
+		s.concurrency = n
+	}
+}
+
+// ValidationBounds overrides the limits new tasks are validated against.
+// Zero values fall back to domain.MinBaseDelay, domain.MaxBaseDelay and
+// domain.MaxRetryLimit.
+type ValidationBounds struct {
+	MinBaseDelay  time.Duration
+	MaxBaseDelay  time.Duration
+	MaxRetryLimit int
+}
+
+// WithValidationBounds overrides the base delay and retry limits new tasks
+// must stay within.
+func WithValidationBounds(bounds ValidationBounds) Option {
+	return func(s *TaskService) {
+		s.bounds = bounds
+	}
+}
+
+// Validator checks a new task beyond the built-in rules, e.g. to require a
+// metadata entry. A non-nil error rejects the task with
+// domain.ErrInvalidTask.
+type Validator func(task *entity.Task) error
+
+// WithValidator runs validate on every new task after the built-in checks
+// have passed. Validators run in the order they are registered.
+func WithValidator(validate Validator) Option {
+	return func(s *TaskService) {
+		s.validators = append(s.validators, validate)
+	}
+}
+
+// IDGenerator returns a new unique task ID.
+type IDGenerator func() string
+
+// WithIDGenerator has new tasks created without an ID get one from
+// generate instead of being rejected. Fan-out tasks derive their IDs from
+// the generated one as usual.
+func WithIDGenerator(generate IDGenerator) Option {
+	return func(s *TaskService) {
+		s.newID = generate
+	}
+}
+
+// WithErrorClassifier has classify decide, for every failed delivery,
+// whether the error is worth retrying. Errors it marks VerdictPermanent
+// send the task straight to its dead destination without using up its
+// retries. Without a classifier every failure is retried.
+func WithErrorClassifier(classify ErrorClassifier) Option {
+	return func(s *TaskService) {
+		s.classifyError = classify
+	}
+}
+
+// WithRetryableStatuses limits retries of HTTP deliveries to failures with
+// one of statuses. A task failing with any other status is dead-lettered at
+// once, as with a permanent error. The zero value retries every status.
+func WithRetryableStatuses(statuses valueobject.RetryableStatuses) Option {
+	return func(s *TaskService) {
+		s.retryableStatuses = statuses
+	}
+}
+
+// WithMaxPending caps how many tasks may be scheduled at once. New tasks
+// beyond it are rejected with domain.ErrQueueFull, or handled as set by
+// WithOverflow, instead of letting Redis grow without bound during an
+// incident; retries of existing tasks are never rejected. Zero or less
+// disables the cap.
+func WithMaxPending(max int64) Option {
+	return func(s *TaskService) {
+		s.maxPending = max
+	}
+}
+
+// WithOverflow sets what happens to new tasks beyond the WithMaxPending
+// cap. store receives spilled tasks and is only needed for
+// entity.OverflowSpill; without it, spilling falls back to rejecting. An
+// empty action rejects.
+func WithOverflow(action entity.OverflowAction, store secondary.OverflowStore) Option {
+	return func(s *TaskService) {
+		s.overflowAction = action
+		s.overflowStore = store
+	}
+}
+
+// ProbePolicy controls active health probing. A destination with at least
+// BacklogThreshold scheduled tasks is probed, at most once per Interval,
+// when its tasks fall due. While probes fail its tasks are held without
+// counting an attempt. Once a probe succeeds, ReleaseRate of its due tasks
+// are delivered in the next poll, twice as many in the one after, and so on
+// until BacklogThreshold is reached. Zero Interval and ReleaseRate fall back
+// to the package defaults.
+type ProbePolicy struct {
+	BacklogThreshold int
+	Interval         time.Duration
+	ReleaseRate      int
+}
+
+// WithHealthProbes probes destinations with a large backlog using prober
+// and holds their tasks while they look down. A zero BacklogThreshold
+// disables probing.
+func WithHealthProbes(prober secondary.HealthProber, policy ProbePolicy) Option {
+	return func(s *TaskService) {
+		s.prober = prober
+		s.probePolicy = policy
+	}
+}
+
+// StormPolicy controls retry storm detection. Once Threshold retries are
+// scheduled for one destination within Window, a sign it is down, retries
+// to it wait Multiplier times longer. Throttling is released after a
+// Window in which fewer than half of Threshold retries were scheduled.
+// Zero Window and Multiplier fall back to the package defaults.
+type StormPolicy struct {
+	Threshold  int
+	Window     time.Duration
+	Multiplier int
+}
+
+// WithRetryStormThrottling enables retry storm detection. A zero Threshold
+// disables it.
+func WithRetryStormThrottling(policy StormPolicy) Option {
+	return func(s *TaskService) {
+		s.stormPolicy = policy
+	}
+}
+
+// WithAttemptHook runs hook before every delivery attempt to destination, an
+// HTTP URL or a Kafka topic. An empty destination runs it for every task,
+// ahead of any destination-specific hooks. Hooks run in the order they are
+// registered.
+func WithAttemptHook(destination string, hook AttemptHook) Option {
+	return func(s *TaskService) {
+		if s.attemptHooks == nil {
+			s.attemptHooks = make(map[string][]AttemptHook)
+		}
+		s.attemptHooks[destination] = append(s.attemptHooks[destination], hook)
+	}
+}
+
+// WithAttemptHookTimeout bounds the attempt hooks run before each delivery
+// attempt, apart from the delivery itself. Hooks allowed to run past the
+// in-flight reclaim deadline must extend their lease with ExtendLease. A
+// zero d uses the delivery timeout.
+func WithAttemptHookTimeout(d time.Duration) Option {
+	return func(s *TaskService) {
+		s.hookTimeout = d
+	}
+}
+
+// WithRedaction masks the payload fields redaction names in dead-letter
+// messages. Tasks kept in the Redis dead-letter store keep their full
+// payload so they can be requeued.
+func WithRedaction(redaction valueobject.Redaction) Option {
+	return func(s *TaskService) {
+		s.redaction = redaction
+	}
+}
+
+// WithClock replaces time.Now as the service's source of the current time.
+// Delays handed to the scheduler are computed against it too, so a
+// scheduler reading the same clock lets tests step through retries without
+// waiting.
+func WithClock(now func() time.Time) Option {
+	return func(s *TaskService) {
+		s.now = now
+	}
+}
+
+// WithMaxDelay caps the retry delays of tasks that do not set their own
+// MaxDelay. Zero leaves them uncapped.
+func WithMaxDelay(d time.Duration) Option {
+	return func(s *TaskService) {
+		s.maxDelay = d
+	}
+}
+
+// WithJitter sets the jitter mode for tasks that do not choose their own.
+// Without it retries wait the exponential delay exactly.
+func WithJitter(mode entity.JitterMode) Option {
+	return func(s *TaskService) {
+		s.jitter = mode
+	}
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"sync"
+
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+// outcomes collects the per-task outcomes of one poll from groups processed
+// concurrently.
+type outcomes struct {
+	mu       sync.Mutex
+	tasks    []entity.TaskOutcome
+	recorded map[string]bool
+}
+
+func newOutcomes() *outcomes {
+	return &outcomes{recorded: make(map[string]bool)}
+}
+
+// add records the outcome of the given attempt of task. err is the delivery
+// error of a failed attempt.
+func (o *outcomes) add(task *entity.Task, attempt int, outcome entity.Outcome, err error) {
+	result := entity.TaskOutcome{TaskID: task.ID, ClientID: task.ClientID, Attempt: attempt, Outcome: outcome}
+	if err != nil {
+		result.Error = err.Error()
+		result.ErrorCode = errorCode(err)
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.tasks = append(o.tasks, result)
+	o.recorded[task.ID] = true
+}
+
+// missing returns the tasks of group with no outcome recorded yet.
+func (o *outcomes) missing(group []*entity.Task) []*entity.Task {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	var tasks []*entity.Task
+	for _, task := range group {
+		if !o.recorded[task.ID] {
+			tasks = append(tasks, task)
+		}
+	}
+	return tasks
+}
+
+func (o *outcomes) result() entity.ProcessResult {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return entity.ProcessResult{Tasks: o.tasks}
+}
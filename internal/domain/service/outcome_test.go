@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+func TestTaskService_ProcessDueTasks_result(t *testing.T) {
+	delivered := testTask()
+	retried := testTask()
+	retried.ID = "task-retried"
+	retried.Destination.Topic = "failing-topic"
+	dead := testTask()
+	dead.ID = "task-dead"
+	dead.Destination.Topic = "failing-topic"
+	dead.Attempt = 3
+	held := testHTTPTask()
+
+	maintenance := newMockMaintenance()
+	maintenance.windows[held.Destination.URL] = time.Now().Add(time.Hour)
+	scheduler := &mockScheduler{
+		fetchDueFunc: func(_ context.Context, _ int) ([]*entity.Task, error) {
+			return []*entity.Task{delivered, retried, dead, held}, nil
+		},
+	}
+	producer := &mockProducer{
+		produceFunc: func(_ context.Context, dest entity.Destination, _, _ []byte) error {
+			if dest.Topic == "failing-topic" {
+				return errors.New("kafka down")
+			}
+			return nil
+		},
+	}
+	svc := NewTaskService(scheduler, producer, zap.NewNop(), WithMaintenance(maintenance))
+
+	result, err := svc.ProcessDueTasks(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := map[string]entity.TaskOutcome{
+		delivered.ID: {TaskID: delivered.ID, ClientID: "client-1", Attempt: 0, Outcome: entity.OutcomeDelivered},
+		retried.ID:   {TaskID: retried.ID, ClientID: "client-1", Attempt: 0, Outcome: entity.OutcomeRescheduled, Error: "kafka down", ErrorCode: entity.ErrorCodeUnknown},
+		dead.ID:      {TaskID: dead.ID, ClientID: "client-1", Attempt: 3, Outcome: entity.OutcomeDead, Error: "kafka down", ErrorCode: entity.ErrorCodeUnknown},
+		held.ID:      {TaskID: held.ID, ClientID: "client-1", Attempt: 0, Outcome: entity.OutcomeHeld},
+	}
+	if len(result.Tasks) != len(want) {
+		t.Fatalf("expected %d outcomes, got %+v", len(want), result.Tasks)
+	}
+	for _, got := range result.Tasks {
+		if got != want[got.TaskID] {
+			t.Fatalf("outcome of %s: got %+v, want %+v", got.TaskID, got, want[got.TaskID])
+		}
+	}
+	if result.Count(entity.OutcomeDelivered) != 1 || result.Count(entity.OutcomeErrored) != 0 {
+		t.Fatalf("unexpected counts in %+v", result.Tasks)
+	}
+}
+
+func TestTaskService_ProcessDueTasks_concurrency(t *testing.T) {
+	tests := []struct {
+		name        string
+		concurrency int
+		want        int32
+	}{
+		{name: "sequential", concurrency: 1, want: 1},
+		{name: "bounded", concurrency: 3, want: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tasks := make([]*entity.Task, 6)
+			for i := range tasks {
+				tasks[i] = testTask()
+				tasks[i].ID = string(rune('a' + i))
+			}
+			scheduler := &mockScheduler{
+				fetchDueFunc: func(_ context.Context, _ int) ([]*entity.Task, error) {
+					return tasks, nil
+				},
+			}
+
+			var inFlight, peak atomic.Int32
+			producer := &mockProducer{
+				produceFunc: func(context.Context, entity.Destination, []byte, []byte) error {
+					n := inFlight.Add(1)
+					for {
+						p := peak.Load()
+						if n <= p || peak.CompareAndSwap(p, n) {
+							break
+						}
+					}
+					time.Sleep(20 * time.Millisecond)
+					inFlight.Add(-1)
+					return nil
+				},
+			}
+			svc := NewTaskService(scheduler, producer, zap.NewNop(), WithConcurrency(tt.concurrency))
+
+			result, err := svc.ProcessDueTasks(context.Background())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := result.Count(entity.OutcomeDelivered); got != len(tasks) {
+				t.Fatalf("expected %d delivered, got %d", len(tasks), got)
+			}
+			if got := peak.Load(); got != tt.want {
+				t.Fatalf("expected at most %d deliveries at once, got %d", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+// PacedOffsets returns n offsets spread evenly over window: the i-th task
+// starts i*window/n after the first, so the last one starts just before the
+// window ends. A non-positive window yields all-zero offsets.
+func PacedOffsets(n int, window time.Duration) []time.Duration {
+	offsets := make([]time.Duration, n)
+	if n == 0 || window <= 0 {
+		return offsets
+	}
+
+	step := window / time.Duration(n)
+	for i := range offsets {
+		offsets[i] = step * time.Duration(i)
+	}
+	return offsets
+}
+
+// CreateTasksPaced validates all tasks, then schedules them with their
+// first attempts spread evenly over window instead of all becoming due at
+// once. The tasks a task fans out to share its place in the window. Nothing
+// is scheduled if any task is invalid. If they would not all fit under the
+// maximum set by WithMaxPending, the overflow action applies to all of them;
+// spilled tasks lose their pacing when restored.
+func (s *TaskService) CreateTasksPaced(ctx context.Context, tasks []*entity.Task, window time.Duration) error {
+	fannedOut := make([][]*entity.Task, len(tasks))
+	var all []*entity.Task
+	for i, task := range tasks {
+		split, err := s.fanOut(task)
+		if err != nil {
+			return fmt.Errorf("%w: task %d (%s): %w", domain.ErrInvalidTask, i, task.ID, err)
+		}
+		fannedOut[i] = split
+		all = append(all, split...)
+	}
+	if admitted, err := s.admit(ctx, all); !admitted {
+		return err
+	}
+
+	for i, offset := range PacedOffsets(len(tasks), window) {
+		for _, task := range fannedOut[i] {
+			if err := s.scheduleNew(ctx, task, offset); err != nil {
+				return fmt.Errorf("scheduling task %d of %d: %w", i+1, len(tasks), err)
+			}
+		}
+	}
+
+	s.logger.Info("paced tasks scheduled",
+		zap.Int("count", len(tasks)),
+		zap.Duration("window", window),
+	)
+
+	return nil
+}
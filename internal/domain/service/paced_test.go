@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+func TestPacedOffsets(t *testing.T) {
+	tests := []struct {
+		name   string
+		n      int
+		window time.Duration
+		want   []time.Duration
+	}{
+		{
+			name:   "spread evenly over window",
+			n:      4,
+			window: 10 * time.Minute,
+			want:   []time.Duration{0, 150 * time.Second, 300 * time.Second, 450 * time.Second},
+		},
+		{
+			name:   "zero window schedules all at once",
+			n:      3,
+			window: 0,
+			want:   []time.Duration{0, 0, 0},
+		},
+		{
+			name:   "no tasks",
+			n:      0,
+			window: time.Minute,
+			want:   []time.Duration{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := PacedOffsets(tt.n, tt.window)
+			if len(got) != len(tt.want) {
+				t.Fatalf("expected %d offsets, got %d", len(tt.want), len(got))
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("offset %d = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestTaskService_CreateTasksPaced(t *testing.T) {
+	newTasks := func(n int) []*entity.Task {
+		tasks := make([]*entity.Task, n)
+		for i := range tasks {
+			tasks[i] = testHTTPTask()
+		}
+		return tasks
+	}
+
+	t.Run("delays are base delay plus paced offset", func(t *testing.T) {
+		scheduler := &mockScheduler{}
+		svc := NewTaskService(scheduler, &mockProducer{}, zap.NewNop())
+
+		if err := svc.CreateTasksPaced(context.Background(), newTasks(5), 10*time.Second); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(scheduler.scheduledTasks) != 5 {
+			t.Fatalf("expected 5 scheduled tasks, got %d", len(scheduler.scheduledTasks))
+		}
+		for i, call := range scheduler.scheduledTasks {
+			want := 2*time.Second + time.Duration(i)*2*time.Second
+			if call.Delay != want {
+				t.Fatalf("task %d delay = %v, want %v", i, call.Delay, want)
+			}
+		}
+	})
+
+	t.Run("retry schedule sets max retries", func(t *testing.T) {
+		scheduler := &mockScheduler{}
+		producer := &mockProducer{produceFunc: func(context.Context, entity.Destination, []byte, []byte) error {
+			return errors.New("connection refused")
+		}}
+		svc := NewTaskService(scheduler, producer, zap.NewNop())
+
+		task := testHTTPTask()
+		task.MaxRetries = 0
+		task.RetryDelays = []time.Duration{10 * time.Second, time.Minute}
+		if err := svc.CreateTasksPaced(context.Background(), []*entity.Task{task}, time.Minute); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(scheduler.scheduledTasks) != 1 {
+			t.Fatalf("expected 1 scheduled task, got %d", len(scheduler.scheduledTasks))
+		}
+
+		// The first attempt fails and is retried rather than dead-lettered.
+		scheduled := scheduler.scheduledTasks[0].Task
+		scheduler.scheduledTasks = nil
+		scheduler.fetchDueFunc = func(context.Context, int) ([]*entity.Task, error) {
+			return []*entity.Task{scheduled}, nil
+		}
+		if _, err := svc.ProcessDueTasks(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(scheduler.scheduledTasks) != 1 {
+			t.Fatalf("expected 1 scheduled retry, got %d", len(scheduler.scheduledTasks))
+		}
+		if delay := scheduler.scheduledTasks[0].Delay; delay != 10*time.Second {
+			t.Fatalf("expected retry after 10s, got %v", delay)
+		}
+	})
+
+	t.Run("default max delay applies", func(t *testing.T) {
+		scheduler := &mockScheduler{}
+		svc := NewTaskService(scheduler, &mockProducer{}, zap.NewNop(), WithMaxDelay(time.Hour))
+
+		tasks := newTasks(2)
+		tasks[1].MaxDelay = 10 * time.Minute
+		if err := svc.CreateTasksPaced(context.Background(), tasks, time.Minute); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		for i, want := range []time.Duration{time.Hour, 10 * time.Minute} {
+			if got := scheduler.scheduledTasks[i].Task.MaxDelay; got != want {
+				t.Fatalf("task %d: expected max delay %v, got %v", i, want, got)
+			}
+		}
+	})
+
+	t.Run("invalid task schedules nothing", func(t *testing.T) {
+		scheduler := &mockScheduler{}
+		svc := NewTaskService(scheduler, &mockProducer{}, zap.NewNop())
+
+		tasks := newTasks(3)
+		tasks[2].Source = ""
+
+		err := svc.CreateTasksPaced(context.Background(), tasks, time.Minute)
+		if !errors.Is(err, domain.ErrInvalidTask) {
+			t.Fatalf("expected ErrInvalidTask, got %v", err)
+		}
+		if len(scheduler.scheduledTasks) != 0 {
+			t.Fatalf("expected nothing scheduled, got %d", len(scheduler.scheduledTasks))
+		}
+	})
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+// recoverDelivery turns a panic raised while delivering into a delivery
+// error, so the task follows the normal retry, quarantine and dead-letter
+// path. A producer that panics on every attempt thus ends up dead-lettered,
+// or quarantined when poison detection is on.
+func recoverDelivery(err *error) {
+	if r := recover(); r != nil {
+		*err = fmt.Errorf("%w: panic: %v", domain.ErrDeliveryFailed, r)
+	}
+}
+
+// processGroup processes one group of due tasks. The tasks have already
+// left the schedule, so a panic outside delivery would lose them along with
+// the worker goroutine; instead the tasks of the group not yet settled are
+// requeued. Those the batch already settled, held or rescheduled keep
+// their outcome. A task that cannot be requeued is recorded as errored, so
+// it stays in flight for the reclaimer.
+func (s *TaskService) processGroup(ctx context.Context, group []*entity.Task, results *outcomes) {
+	defer func() {
+		r := recover()
+		if r == nil {
+			return
+		}
+		unsettled := results.missing(group)
+		s.logger.Error("panic while processing tasks, requeueing them",
+			zap.Any("panic", r),
+			zap.Int("tasks", len(unsettled)),
+			zap.Stack("stack"),
+		)
+		err := fmt.Errorf("panic: %v", r)
+		for _, task := range unsettled {
+			if schedErr := s.scheduler.Schedule(ctx, task, task.NextRetryDelay()); schedErr != nil {
+				s.taskLogger(task).Error("failed to requeue task after panic", zap.Error(schedErr))
+				results.add(task, task.Attempt, entity.OutcomeErrored, err)
+				continue
+			}
+			results.add(task, task.Attempt, entity.OutcomeRequeued, err)
+		}
+	}()
+
+	group = s.holdForRateLimit(ctx, group, results)
+	switch len(group) {
+	case 0:
+		return
+	case 1:
+		s.processTask(ctx, group[0], results)
+		return
+	}
+	s.processBatch(ctx, group, results)
+}
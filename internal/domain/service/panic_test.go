@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+// panickingCompletionStore panics on lookup, simulating a bug outside
+// delivery.
+type panickingCompletionStore struct{}
+
+func (panickingCompletionStore) MarkCompleted(context.Context, string, time.Duration) error {
+	return nil
+}
+
+func (panickingCompletionStore) IsCompleted(context.Context, string) (bool, error) {
+	panic("nil map")
+}
+
+func TestTaskService_ProcessDueTasks_recoversProducerPanic(t *testing.T) {
+	task := testTask()
+	scheduler := &mockScheduler{
+		fetchDueFunc: func(_ context.Context, _ int) ([]*entity.Task, error) {
+			return []*entity.Task{task}, nil
+		},
+	}
+	producer := &mockProducer{
+		produceFunc: func(context.Context, entity.Destination, []byte, []byte) error {
+			panic("producer bug")
+		},
+	}
+
+	svc := NewTaskService(scheduler, producer, zap.NewNop())
+	if _, err := svc.ProcessDueTasks(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(scheduler.scheduledTasks) != 1 {
+		t.Fatalf("expected the task to be rescheduled, got %d schedules", len(scheduler.scheduledTasks))
+	}
+	if got := scheduler.scheduledTasks[0].Task; got.Attempt != 1 || !strings.Contains(got.LastError, "panic: producer bug") {
+		t.Fatalf("expected a recorded failed attempt, got attempt %d and error %q", got.Attempt, got.LastError)
+	}
+}
+
+func TestTaskService_ProcessDueTasks_requeuesOnPanic(t *testing.T) {
+	task := testTask()
+	scheduler := &mockScheduler{
+		fetchDueFunc: func(_ context.Context, _ int) ([]*entity.Task, error) {
+			return []*entity.Task{task}, nil
+		},
+	}
+	producer := &mockProducer{}
+
+	svc := NewTaskService(scheduler, producer, zap.NewNop(),
+		WithCompletionMarkers(panickingCompletionStore{}, time.Hour),
+	)
+	if _, err := svc.ProcessDueTasks(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(producer.produceCalls) != 0 {
+		t.Fatalf("expected no delivery, got %d", len(producer.produceCalls))
+	}
+	if len(scheduler.scheduledTasks) != 1 || scheduler.scheduledTasks[0].Task.ID != task.ID {
+		t.Fatalf("expected the task to be requeued, got %+v", scheduler.scheduledTasks)
+	}
+}
+
+// partlyPanickingCompletionStore reports completed as delivered and panics
+// on any other lookup.
+type partlyPanickingCompletionStore struct {
+	completed string
+}
+
+func (partlyPanickingCompletionStore) MarkCompleted(context.Context, string, time.Duration) error {
+	return nil
+}
+
+func (s partlyPanickingCompletionStore) IsCompleted(_ context.Context, taskID string) (bool, error) {
+	if taskID == s.completed {
+		return true, nil
+	}
+	panic("nil map")
+}
+
+func TestTaskService_ProcessDueTasks_requeuesOnlyUnsettledOnPanic(t *testing.T) {
+	delivered, pending := testHTTPTask(), testHTTPTask()
+	pending.ID = "task-http-2"
+	scheduler := &mockScheduler{
+		fetchDueFunc: func(_ context.Context, _ int) ([]*entity.Task, error) {
+			return []*entity.Task{delivered, pending}, nil
+		},
+	}
+	producer := &mockBatchProducer{}
+
+	svc := NewTaskService(scheduler, producer, zap.NewNop(),
+		WithHTTPBatching(10),
+		WithCompletionMarkers(partlyPanickingCompletionStore{completed: delivered.ID}, time.Hour),
+	)
+	result, err := svc.ProcessDueTasks(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(scheduler.scheduledTasks) != 1 || scheduler.scheduledTasks[0].Task.ID != pending.ID {
+		t.Fatalf("expected only the unsettled task requeued, got %+v", scheduler.scheduledTasks)
+	}
+	if result.Count(entity.OutcomeSkipped) != 1 || result.Count(entity.OutcomeRequeued) != 1 {
+		t.Fatalf("expected one skipped and one requeued task, got %+v", result.Tasks)
+	}
+}
+
+// panickingRateLimiter panics when asked for a destination's rate limit,
+// simulating a bug before delivery.
+type panickingRateLimiter struct {
+	mockProducer
+}
+
+func (*panickingRateLimiter) RateLimitedFor(entity.Destination) time.Duration {
+	panic("nil map")
+}
+
+func TestTaskService_ProcessDueTasks_panicReleasesInFlight(t *testing.T) {
+	tests := []struct {
+		name         string
+		scheduleErr  error
+		wantOutcome  entity.Outcome
+		wantReleased bool
+	}{
+		{name: "requeued task is released", wantOutcome: entity.OutcomeRequeued, wantReleased: true},
+		{name: "task that cannot be requeued stays in flight", scheduleErr: errors.New("redis down"), wantOutcome: entity.OutcomeErrored},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := testHTTPTask()
+			scheduler := &mockScheduler{
+				fetchDueFunc: func(_ context.Context, _ int) ([]*entity.Task, error) {
+					return []*entity.Task{task}, nil
+				},
+				scheduleFunc: func(context.Context, *entity.Task, time.Duration) error {
+					return tt.scheduleErr
+				},
+			}
+			inFlight := &mockInFlightStore{}
+
+			svc := NewTaskService(scheduler, &panickingRateLimiter{}, zap.NewNop(),
+				WithInFlightReclaim(inFlight, 3),
+			)
+			result, err := svc.ProcessDueTasks(context.Background())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(scheduler.scheduledTasks) != 1 {
+				t.Fatalf("expected one requeue attempt, got %d", len(scheduler.scheduledTasks))
+			}
+			if len(result.Tasks) != 1 || result.Tasks[0].Outcome != tt.wantOutcome {
+				t.Fatalf("expected outcome %s, got %+v", tt.wantOutcome, result.Tasks)
+			}
+			if len(inFlight.tracked) != 1 {
+				t.Fatalf("expected the task tracked, got %v", inFlight.tracked)
+			}
+			if released := len(inFlight.released) == 1; released != tt.wantReleased {
+				t.Fatalf("expected released=%v, got %v", tt.wantReleased, inFlight.released)
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+)
+
+// PrioritizeTask marks a scheduled task as priority, so dropping the oldest
+// tasks on overflow spares it, and with now set also makes it due
+// immediately. The task keeps its ID, attempt count and retry budget. Tasks
+// still waiting behind an ordering key are not scheduled yet and cannot be
+// prioritized. It returns the task's due time.
+func (s *TaskService) PrioritizeTask(ctx context.Context, taskID string, now bool) (time.Time, error) {
+	task, dueAt, err := s.scheduler.Dequeue(ctx, taskID)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("prioritizing task %s: %w", taskID, err)
+	}
+	logger := s.taskLogger(task)
+
+	task.IsPriority = true
+	if now && dueAt.After(s.now()) {
+		dueAt = s.now()
+	}
+	if err := s.scheduler.Schedule(ctx, task, s.untilOrNow(dueAt)); err != nil {
+		logger.Error("failed to reschedule prioritized task", zap.Error(err), zap.Time("due_at", dueAt))
+		return time.Time{}, fmt.Errorf("%w: %v", domain.ErrScheduleFailed, err)
+	}
+
+	logger.Info("task prioritized", zap.Time("due_at", dueAt), zap.Bool("now", now))
+	return dueAt, nil
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+func TestTaskService_PrioritizeTask(t *testing.T) {
+	tests := []struct {
+		name        string
+		dueIn       time.Duration
+		now         bool
+		dequeueErr  error
+		scheduleErr error
+		wantErr     error
+		wantDelay   time.Duration // upper bound on the rescheduled delay
+	}{
+		{name: "keeps the due time", dueIn: 10 * time.Minute, wantDelay: 10 * time.Minute},
+		{name: "now makes the task due", dueIn: 10 * time.Minute, now: true},
+		{name: "overdue task stays due", dueIn: -time.Minute, now: true},
+		{name: "unknown task", dequeueErr: domain.ErrTaskNotFound, wantErr: domain.ErrTaskNotFound},
+		{name: "schedule failure", dueIn: time.Minute, scheduleErr: errors.New("redis down"), wantErr: domain.ErrScheduleFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := testTask()
+			task.Attempt = 2
+			dueAt := time.Now().Add(tt.dueIn)
+			scheduler := &mockScheduler{
+				dequeueFunc: func(_ context.Context, _ string) (*entity.Task, time.Time, error) {
+					if tt.dequeueErr != nil {
+						return nil, time.Time{}, tt.dequeueErr
+					}
+					return task, dueAt, nil
+				},
+				scheduleFunc: func(_ context.Context, _ *entity.Task, _ time.Duration) error {
+					return tt.scheduleErr
+				},
+			}
+			svc := NewTaskService(scheduler, &mockProducer{}, zap.NewNop())
+
+			_, err := svc.PrioritizeTask(context.Background(), task.ID, tt.now)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("expected %v, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(scheduler.scheduledTasks) != 1 {
+				t.Fatalf("expected the task to be rescheduled once, got %d", len(scheduler.scheduledTasks))
+			}
+			call := scheduler.scheduledTasks[0]
+			if !call.Task.IsPriority {
+				t.Fatal("expected the task to be marked priority")
+			}
+			if call.Task.Attempt != 2 {
+				t.Fatalf("expected the attempt count to be kept, got %d", call.Task.Attempt)
+			}
+			if call.Delay > tt.wantDelay || call.Delay < tt.wantDelay-time.Second {
+				t.Fatalf("expected a delay of about %s, got %s", tt.wantDelay, call.Delay)
+			}
+		})
+	}
+}
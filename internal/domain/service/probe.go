@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+// probeState is what the service knows about one probed destination.
+// A destination absent from the map, or not probed for an interval, is
+// assumed healthy.
+type probeState struct {
+	checkedAt time.Time
+	down      bool
+	allowance int // tasks releasable in the next poll while recovering; 0 when not recovering
+}
+
+// probeVerdict says how many of a destination's due tasks may be delivered
+// in this poll (-1 for all) and how long the rest are held.
+type probeVerdict struct {
+	limit int
+	hold  time.Duration
+}
+
+var deliverAll = probeVerdict{limit: -1}
+
+// holdForProbes holds tasks whose destination failed its health probe, or
+// is releasing its backlog after recovering, without counting an attempt,
+// and returns the tasks that may be delivered now. Tasks that cannot be
+// rescheduled are delivered.
+func (s *TaskService) holdForProbes(ctx context.Context, tasks []*entity.Task, results *outcomes) []*entity.Task {
+	if s.prober == nil || s.probePolicy.BacklogThreshold <= 0 || len(tasks) == 0 {
+		return tasks
+	}
+	s.forgetHealthyProbes(s.now())
+
+	verdicts := make(map[string]probeVerdict)
+	ready := tasks[:0]
+	for _, task := range tasks {
+		name := task.Destination.Name()
+		verdict, seen := verdicts[name]
+		if !seen {
+			verdict = s.probe(ctx, task.Destination)
+		}
+		if verdict.limit != 0 {
+			if verdict.limit > 0 {
+				verdict.limit--
+			}
+			verdicts[name] = verdict
+			ready = append(ready, task)
+			continue
+		}
+		verdicts[name] = verdict
+
+		logger := s.taskLogger(task)
+		if err := s.scheduler.Schedule(ctx, task, verdict.hold); err != nil {
+			logger.Error("failed to hold task for destination health", zap.Error(err))
+			ready = append(ready, task)
+			continue
+		}
+		results.add(task, task.Attempt, entity.OutcomeHeld, nil)
+		logger.Debug("task held for destination health",
+			zap.String("destination", name),
+			zap.Duration("hold", verdict.hold),
+		)
+	}
+
+	return ready
+}
+
+// probe decides how much of the destination's due backlog is delivered in
+// this poll, probing it when it has a large backlog or is known to be down.
+func (s *TaskService) probe(ctx context.Context, destination entity.Destination) probeVerdict {
+	name := destination.Name()
+	logger := s.logger.With(zap.String("destination", name))
+	now := s.now()
+
+	s.probeMu.Lock()
+	state := s.probes[name]
+	s.probeMu.Unlock()
+
+	switch {
+	case state.down:
+		if wait := state.checkedAt.Add(s.probePolicy.Interval).Sub(now); wait > 0 {
+			return probeVerdict{hold: wait}
+		}
+		if err := s.prober.Probe(ctx, destination); err != nil {
+			logger.Warn("destination still failing its health probe", zap.Error(err))
+			s.setProbeState(name, probeState{checkedAt: now, down: true})
+			return probeVerdict{hold: s.probePolicy.Interval}
+		}
+		logger.Info("destination recovered, releasing its backlog gradually")
+		s.setProbeState(name, probeState{checkedAt: now, allowance: s.probePolicy.ReleaseRate * 2})
+		return probeVerdict{limit: s.probePolicy.ReleaseRate, hold: domain.ProbeReleaseStep}
+
+	case state.allowance > 0:
+		next := probeState{checkedAt: now, allowance: state.allowance * 2}
+		if state.allowance >= s.probePolicy.BacklogThreshold {
+			next.allowance = 0
+		}
+		s.setProbeState(name, next)
+		return probeVerdict{limit: state.allowance, hold: domain.ProbeReleaseStep}
+
+	case now.Sub(state.checkedAt) < s.probePolicy.Interval:
+		return deliverAll
+	}
+
+	s.setProbeState(name, probeState{checkedAt: now})
+	backlog, err := s.scheduler.CountByDestination(ctx, name)
+	if err != nil {
+		logger.Warn("failed to count destination backlog", zap.Error(err))
+		return deliverAll
+	}
+	if backlog < int64(s.probePolicy.BacklogThreshold) {
+		return deliverAll
+	}
+
+	if err := s.prober.Probe(ctx, destination); err != nil {
+		logger.Warn("destination failed its health probe, holding its backlog",
+			zap.Error(err),
+			zap.Int64("backlog", backlog),
+		)
+		s.setProbeState(name, probeState{checkedAt: now, down: true})
+		return probeVerdict{hold: s.probePolicy.Interval}
+	}
+	return deliverAll
+}
+
+func (s *TaskService) setProbeState(name string, state probeState) {
+	s.probeMu.Lock()
+	defer s.probeMu.Unlock()
+	s.probes[name] = state
+}
+
+// forgetHealthyProbes drops healthy destinations not checked for an
+// interval, so the map only grows with destinations being watched.
+func (s *TaskService) forgetHealthyProbes(now time.Time) {
+	s.probeMu.Lock()
+	defer s.probeMu.Unlock()
+	for name, state := range s.probes {
+		if !state.down && state.allowance == 0 && now.Sub(state.checkedAt) >= s.probePolicy.Interval {
+			delete(s.probes, name)
+		}
+	}
+}
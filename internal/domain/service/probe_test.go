@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+func TestTaskService_ProcessDueTasks_healthProbes(t *testing.T) {
+	due := func() []*entity.Task {
+		tasks := make([]*entity.Task, 5)
+		for i := range tasks {
+			tasks[i] = testHTTPTask()
+			tasks[i].ID = string(rune('a' + i))
+		}
+		return tasks
+	}
+	url := testHTTPTask().Destination.URL
+
+	scheduler := &mockScheduler{
+		fetchDueFunc: func(_ context.Context, _ int) ([]*entity.Task, error) {
+			return due(), nil
+		},
+		destinationCounts: map[string]int64{url: 100},
+	}
+	producer := &mockProducer{}
+	prober := &mockProber{err: errors.New("connection refused")}
+	svc := NewTaskService(scheduler, producer, zap.NewNop(),
+		WithHealthProbes(prober, ProbePolicy{BacklogThreshold: 50, Interval: time.Hour, ReleaseRate: 2}),
+	)
+
+	// Down: the whole backlog is held without an attempt.
+	if _, err := svc.ProcessDueTasks(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(producer.produceCalls) != 0 || len(scheduler.scheduledTasks) != 5 {
+		t.Fatalf("expected all 5 tasks held, got %d delivered and %d held",
+			len(producer.produceCalls), len(scheduler.scheduledTasks))
+	}
+	if scheduler.scheduledTasks[0].Task.Attempt != 0 {
+		t.Fatalf("holding must not count an attempt")
+	}
+
+	// Still within the interval: held without probing again.
+	if _, err := svc.ProcessDueTasks(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if prober.probes != 1 {
+		t.Fatalf("expected 1 probe within the interval, got %d", prober.probes)
+	}
+
+	// Recovered: the backlog is released at 2, then 4, then in full.
+	prober.err = nil
+	svc.probes[url] = probeState{checkedAt: time.Now().Add(-2 * time.Hour), down: true}
+	for _, want := range []int{2, 4, 5} {
+		producer.produceCalls = nil
+		if _, err := svc.ProcessDueTasks(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(producer.produceCalls) != want {
+			t.Fatalf("expected %d tasks released, got %d", want, len(producer.produceCalls))
+		}
+	}
+}
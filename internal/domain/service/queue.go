@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+	"github.com/ruudy-sib/rebound/internal/port/secondary"
+)
+
+// QueueService implements whole-queue admin operations. Purging is a two
+// step operation: a dry run reports the count and issues a short-lived
+// token, and only a purge presenting that token drops anything.
+type QueueService struct {
+	store  secondary.QueueStore
+	logger *zap.Logger
+	now    func() time.Time
+}
+
+// NewQueueService creates a QueueService over store.
+func NewQueueService(store secondary.QueueStore, logger *zap.Logger) *QueueService {
+	return &QueueService{
+		store:  store,
+		logger: logger.Named("queue-service"),
+		now:    time.Now,
+	}
+}
+
+// Stats returns a size snapshot of every queue, in entity.Queues order.
+func (s *QueueService) Stats(ctx context.Context) ([]entity.QueueStats, error) {
+	now := s.now()
+	stats := make([]entity.QueueStats, 0, len(entity.Queues))
+	for _, queue := range entity.Queues {
+		st, err := s.store.Stats(ctx, queue, now)
+		if err != nil {
+			return nil, fmt.Errorf("reading stats for queue %s: %w", queue, err)
+		}
+		stats = append(stats, st)
+	}
+	return stats, nil
+}
+
+// PreparePurge counts the tasks a purge of queue would drop and issues the
+// token that confirms it for domain.PurgeTokenTTL.
+func (s *QueueService) PreparePurge(ctx context.Context, queue entity.Queue) (*entity.PurgePlan, error) {
+	if !queue.Valid() {
+		return nil, fmt.Errorf("%w: %q", domain.ErrUnknownQueue, queue)
+	}
+
+	count, err := s.store.Count(ctx, queue)
+	if err != nil {
+		return nil, fmt.Errorf("counting queue %s: %w", queue, err)
+	}
+
+	token, err := newPurgeToken()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.store.SavePurgeToken(ctx, queue, token, domain.PurgeTokenTTL); err != nil {
+		return nil, fmt.Errorf("saving purge token for queue %s: %w", queue, err)
+	}
+
+	return &entity.PurgePlan{
+		Queue:     queue,
+		Count:     count,
+		Token:     token,
+		ExpiresAt: s.now().Add(domain.PurgeTokenTTL),
+	}, nil
+}
+
+// Purge drops every task in queue if token matches the one issued by the
+// last PreparePurge. Tokens are single use, and a wrong token cancels the
+// pending confirmation.
+func (s *QueueService) Purge(ctx context.Context, queue entity.Queue, token string) (int64, error) {
+	if !queue.Valid() {
+		return 0, fmt.Errorf("%w: %q", domain.ErrUnknownQueue, queue)
+	}
+	if token == "" {
+		return 0, fmt.Errorf("%w: run a dry run first to obtain a confirmation token", domain.ErrConfirmationRequired)
+	}
+
+	ok, err := s.store.TakePurgeToken(ctx, queue, token)
+	if err != nil {
+		return 0, fmt.Errorf("checking purge token for queue %s: %w", queue, err)
+	}
+	if !ok {
+		return 0, fmt.Errorf("%w: confirmation token is invalid or expired", domain.ErrConfirmationRequired)
+	}
+
+	purged, err := s.store.Purge(ctx, queue)
+	if err != nil {
+		return purged, fmt.Errorf("purging queue %s: %w", queue, err)
+	}
+
+	s.logger.Warn("queue purged",
+		zap.String("queue", string(queue)),
+		zap.Int64("purged", purged),
+	)
+	return purged, nil
+}
+
+// ListCorrupt returns up to limit corrupt members, newest first, with the
+// number ever found.
+func (s *QueueService) ListCorrupt(ctx context.Context, limit int) (*entity.CorruptReport, error) {
+	if limit < 1 || limit > domain.MaxListLimit {
+		return nil, fmt.Errorf("%w: limit must be between 1 and %d", domain.ErrInvalidQuery, domain.MaxListLimit)
+	}
+
+	members, err := s.store.ListCorrupt(ctx, limit)
+	if err != nil {
+		return nil, fmt.Errorf("listing corrupt members: %w", err)
+	}
+	total, err := s.store.CorruptTotal(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("counting corrupt members: %w", err)
+	}
+	return &entity.CorruptReport{Total: total, Members: members}, nil
+}
+
+// DeleteCorrupt removes a corrupt member once an operator has dealt with it.
+func (s *QueueService) DeleteCorrupt(ctx context.Context, id string) error {
+	deleted, err := s.store.DeleteCorrupt(ctx, id)
+	if err != nil {
+		return fmt.Errorf("deleting corrupt member %s: %w", id, err)
+	}
+	if !deleted {
+		return domain.ErrTaskNotFound
+	}
+	s.logger.Info("corrupt member deleted", zap.String("corrupt_id", id))
+	return nil
+}
+
+func newPurgeToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generating purge token: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+func TestQueueService_Purge(t *testing.T) {
+	ctx := context.Background()
+	store := newMockQueueStore()
+	store.counts[entity.QueueScheduled] = 42
+	now := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	svc := NewQueueService(store, zap.NewNop())
+	svc.now = func() time.Time { return now }
+
+	plan, err := svc.PreparePurge(ctx, entity.QueueScheduled)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if plan.Count != 42 || plan.Token == "" || !plan.ExpiresAt.Equal(now.Add(domain.PurgeTokenTTL)) {
+		t.Fatalf("unexpected plan: %+v", plan)
+	}
+	if len(store.purged) != 0 {
+		t.Fatal("expected a dry run to purge nothing")
+	}
+
+	purged, err := svc.Purge(ctx, entity.QueueScheduled, plan.Token)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if purged != 42 {
+		t.Fatalf("expected 42 purged, got %d", purged)
+	}
+
+	// Tokens are single use.
+	if _, err := svc.Purge(ctx, entity.QueueScheduled, plan.Token); !errors.Is(err, domain.ErrConfirmationRequired) {
+		t.Fatalf("expected ErrConfirmationRequired on reuse, got %v", err)
+	}
+}
+
+func TestQueueService_Purge_rejected(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		queue   entity.Queue
+		token   func(plan *entity.PurgePlan) string
+		wantErr error
+	}{
+		{
+			name:    "no token",
+			queue:   entity.QueueDeadLetter,
+			token:   func(*entity.PurgePlan) string { return "" },
+			wantErr: domain.ErrConfirmationRequired,
+		},
+		{
+			name:    "wrong token",
+			queue:   entity.QueueDeadLetter,
+			token:   func(*entity.PurgePlan) string { return "guess" },
+			wantErr: domain.ErrConfirmationRequired,
+		},
+		{
+			name:    "unknown queue",
+			queue:   "everything",
+			token:   func(plan *entity.PurgePlan) string { return plan.Token },
+			wantErr: domain.ErrUnknownQueue,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newMockQueueStore()
+			svc := NewQueueService(store, zap.NewNop())
+			plan, _ := svc.PreparePurge(ctx, entity.QueueDeadLetter)
+
+			_, err := svc.Purge(ctx, tt.queue, tt.token(plan))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+			if len(store.purged) != 0 {
+				t.Fatalf("expected nothing purged, got %v", store.purged)
+			}
+		})
+	}
+}
+
+func TestQueueService_Stats(t *testing.T) {
+	store := newMockQueueStore()
+	store.counts[entity.QueueScheduled] = 7
+	store.counts[entity.QueueQuarantine] = 2
+	svc := NewQueueService(store, zap.NewNop())
+
+	stats, err := svc.Stats(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(stats) != len(entity.Queues) {
+		t.Fatalf("expected %d queues, got %d", len(entity.Queues), len(stats))
+	}
+	for i, queue := range entity.Queues {
+		if stats[i].Queue != queue || stats[i].Pending != store.counts[queue] {
+			t.Fatalf("unexpected stats for %s: %+v", queue, stats[i])
+		}
+	}
+}
+
+func TestQueueService_Corrupt(t *testing.T) {
+	ctx := context.Background()
+	store := newMockQueueStore()
+	store.corrupt = []entity.CorruptMember{{ID: "a1"}, {ID: "b2"}}
+	store.corruptTotal = 5
+	svc := NewQueueService(store, zap.NewNop())
+
+	if _, err := svc.ListCorrupt(ctx, 0); !errors.Is(err, domain.ErrInvalidQuery) {
+		t.Fatalf("expected ErrInvalidQuery for a zero limit, got %v", err)
+	}
+
+	report, err := svc.ListCorrupt(ctx, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.Total != 5 || len(report.Members) != 1 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+
+	if err := svc.DeleteCorrupt(ctx, "a1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := svc.DeleteCorrupt(ctx, "a1"); !errors.Is(err, domain.ErrTaskNotFound) {
+		t.Fatalf("expected ErrTaskNotFound deleting twice, got %v", err)
+	}
+	if len(store.corrupt) != 1 || store.corrupt[0].ID != "b2" {
+		t.Fatalf("unexpected members left: %+v", store.corrupt)
+	}
+}
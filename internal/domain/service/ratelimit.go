@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+	"github.com/ruudy-sib/rebound/internal/port/secondary"
+)
+
+// holdForRateLimit reschedules a group whose destination has reported its
+// rate-limit budget as spent to when the limit resets, without counting an
+// attempt. It returns the tasks that may be delivered now, which includes
+// any that could not be rescheduled.
+func (s *TaskService) holdForRateLimit(ctx context.Context, group []*entity.Task, results *outcomes) []*entity.Task {
+	limiter, ok := s.producer.(secondary.RateLimitedProducer)
+	if !ok || len(group) == 0 {
+		return group
+	}
+
+	destination := group[0].Destination
+	wait := limiter.RateLimitedFor(destination)
+	if wait <= 0 {
+		return group
+	}
+	until := s.now().Add(wait)
+
+	var ready []*entity.Task
+	for _, task := range group {
+		logger := s.taskLogger(task)
+		if err := s.scheduler.Schedule(ctx, task, wait); err != nil {
+			logger.Error("failed to hold task for rate limit", zap.Error(err))
+			ready = append(ready, task)
+			continue
+		}
+		results.add(task, task.Attempt, entity.OutcomeHeld, nil)
+		logger.Info("task held for destination rate limit",
+			zap.String("destination", destination.Name()),
+			zap.Time("until", until),
+		)
+	}
+	return ready
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+func TestTaskService_ProcessDueTasks_rateLimitHold(t *testing.T) {
+	tests := []struct {
+		name      string
+		wait      time.Duration
+		wantHeld  bool
+		wantCalls int
+	}{
+		{name: "not limited", wantCalls: 1},
+		{name: "limited", wait: time.Minute, wantHeld: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := testHTTPTask()
+			scheduler := &mockScheduler{
+				fetchDueFunc: func(_ context.Context, _ int) ([]*entity.Task, error) {
+					return []*entity.Task{task}, nil
+				},
+			}
+			producer := &mockRateLimitedProducer{wait: tt.wait}
+			svc := NewTaskService(scheduler, producer, zap.NewNop())
+
+			if _, err := svc.ProcessDueTasks(context.Background()); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(producer.produceCalls) != tt.wantCalls {
+				t.Fatalf("expected %d produce calls, got %d", tt.wantCalls, len(producer.produceCalls))
+			}
+			if !tt.wantHeld {
+				return
+			}
+			if len(scheduler.scheduledTasks) != 1 {
+				t.Fatalf("expected the task to be held, got %d schedules", len(scheduler.scheduledTasks))
+			}
+			if delay := scheduler.scheduledTasks[0].Delay; delay != time.Minute {
+				t.Fatalf("expected the task held until the reset, got delay %v", delay)
+			}
+			if task.Attempt != 0 {
+				t.Fatalf("holding must not count an attempt, got %d", task.Attempt)
+			}
+		})
+	}
+}
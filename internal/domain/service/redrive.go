@@ -0,0 +1,37 @@
+package service
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+// redrive re-schedules a task that exhausted its retries when a re-drive
+// policy covers its dead destination and re-drives are left. It reports
+// whether the task was re-driven; if not, it must be dead-lettered.
+func (s *TaskService) redrive(ctx context.Context, task *entity.Task, logger *zap.Logger) bool {
+	policy, ok := s.redrivePolicies[task.DeadDestination.Name()]
+	if !ok || task.Redrives >= policy.MaxRedrives {
+		return false
+	}
+
+	exhausted := *task
+	task.Redrive(policy.MaxRetries)
+	if err := s.scheduler.Schedule(ctx, task, policy.Cooldown); err != nil {
+		// Dead-letter the task as it was rather than lose it.
+		logger.Error("failed to schedule re-drive", zap.Error(err))
+		*task = exhausted
+		return false
+	}
+
+	logger.Warn("max retries exceeded, re-driving task after cooldown",
+		zap.Int("attempts", exhausted.Attempt),
+		zap.Duration("cooldown", policy.Cooldown),
+		zap.Int("redrive", task.Redrives),
+		zap.Int("max_redrives", policy.MaxRedrives),
+		zap.String("dead_destination", task.DeadDestination.Name()),
+	)
+	return true
+}
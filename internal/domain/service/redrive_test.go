@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+func TestTaskService_ProcessDueTasks_redrive(t *testing.T) {
+	policies := map[string]RedrivePolicy{
+		"dead-topic": {Cooldown: 6 * time.Hour, MaxRetries: 1, MaxRedrives: 2},
+	}
+
+	tests := []struct {
+		name         string
+		redrives     int
+		deadTopic    string
+		scheduleErr  error
+		wantRedriven bool
+	}{
+		{name: "re-driven after cooldown", redrives: 0, deadTopic: "dead-topic", wantRedriven: true},
+		{name: "last re-drive", redrives: 1, deadTopic: "dead-topic", wantRedriven: true},
+		{name: "re-drives exhausted", redrives: 2, deadTopic: "dead-topic"},
+		{name: "no policy for dead destination", deadTopic: "other-dead-topic"},
+		{name: "scheduling fails", deadTopic: "dead-topic", scheduleErr: errors.New("redis down")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := testTask()
+			task.Attempt = 3
+			task.MaxRetries = 3
+			task.Redrives = tt.redrives
+			task.DeadDestination.Topic = tt.deadTopic
+
+			scheduler := &mockScheduler{
+				fetchDueFunc: func(_ context.Context, _ int) ([]*entity.Task, error) {
+					return []*entity.Task{task}, nil
+				},
+				scheduleFunc: func(_ context.Context, _ *entity.Task, _ time.Duration) error {
+					return tt.scheduleErr
+				},
+			}
+			producer := &mockProducer{
+				produceFunc: func(_ context.Context, dest entity.Destination, _, _ []byte) error {
+					if dest.Topic == "my-topic" {
+						return errors.New("kafka down")
+					}
+					return nil
+				},
+			}
+
+			svc := NewTaskService(scheduler, producer, zap.NewNop(), WithRedrivePolicies(policies))
+			if _, err := svc.ProcessDueTasks(context.Background()); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			deadLettered := len(producer.produceCalls) == 2
+			if deadLettered == tt.wantRedriven {
+				t.Fatalf("dead-lettered = %v, want %v", deadLettered, !tt.wantRedriven)
+			}
+			if !tt.wantRedriven {
+				if task.Redrives != tt.redrives || task.MaxRetries != 3 {
+					t.Fatalf("dead-lettered task was modified: %+v", task)
+				}
+				return
+			}
+
+			if len(scheduler.scheduledTasks) != 1 || scheduler.scheduledTasks[0].Delay != 6*time.Hour {
+				t.Fatalf("expected one re-drive after the cooldown, got %+v", scheduler.scheduledTasks)
+			}
+			if task.Attempt != 0 || task.MaxRetries != 1 || task.Redrives != tt.redrives+1 {
+				t.Fatalf("unexpected re-driven task: attempt %d, max retries %d, redrives %d",
+					task.Attempt, task.MaxRetries, task.Redrives)
+			}
+		})
+	}
+}
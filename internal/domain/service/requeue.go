@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+// errDeadLetterStoreUnsupported is returned when no DeadLetterStore is configured.
+var errDeadLetterStoreUnsupported = errors.New("dead-letter storage is not supported by this deployment")
+
+// ListDeadLetters returns up to limit tasks from the dead-letter store
+// after the cursor, most recently stored first, and the cursor of the next
+// page.
+func (s *TaskService) ListDeadLetters(ctx context.Context, after entity.Cursor, limit int) ([]entity.DeadLetter, entity.Cursor, error) {
+	if s.deadLetterStore == nil {
+		return nil, entity.Cursor{}, errDeadLetterStoreUnsupported
+	}
+	if limit < 1 || limit > domain.MaxListLimit {
+		return nil, entity.Cursor{}, fmt.Errorf("%w: limit must be between 1 and %d", domain.ErrInvalidQuery, domain.MaxListLimit)
+	}
+
+	entries, next, err := s.deadLetterStore.List(ctx, after, limit)
+	if err != nil {
+		return nil, entity.Cursor{}, fmt.Errorf("listing dead letters: %w", err)
+	}
+	return entries, next, nil
+}
+
+// RequeueDeadLetter takes a task out of the dead-letter store and schedules
+// it for immediate delivery with a fresh retry budget. If scheduling fails
+// the task is stored again unchanged.
+func (s *TaskService) RequeueDeadLetter(ctx context.Context, taskID string) error {
+	if s.deadLetterStore == nil {
+		return errDeadLetterStoreUnsupported
+	}
+
+	entry, err := s.deadLetterStore.Take(ctx, taskID)
+	if err != nil {
+		return fmt.Errorf("requeueing task %s: %w", taskID, err)
+	}
+	original := *entry.Task
+	logger := s.taskLogger(entry.Task)
+
+	offset := -entry.Task.FirstAttemptDelay()
+	if err := s.scheduleNew(ctx, entry.Task, offset); err != nil {
+		if serr := s.deadLetterStore.Store(ctx, &original, entry.Reason); serr != nil {
+			logger.Error("failed to return task to dead-letter store", zap.Error(serr))
+		}
+		return fmt.Errorf("requeueing task %s: %w", taskID, err)
+	}
+
+	logger.Info("dead-lettered task requeued", zap.String("reason", entry.Reason))
+	return nil
+}
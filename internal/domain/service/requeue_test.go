@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+func TestTaskService_RequeueDeadLetter(t *testing.T) {
+	tests := []struct {
+		name        string
+		taskID      string
+		scheduleErr error
+		wantErr     error
+		wantStored  int
+	}{
+		{name: "stored task is rescheduled", taskID: "task-1"},
+		{name: "unknown task", taskID: "missing", wantErr: domain.ErrTaskNotFound, wantStored: 1},
+		{name: "schedule failure keeps the task stored", taskID: "task-1", scheduleErr: errors.New("redis down"),
+			wantErr: domain.ErrScheduleFailed, wantStored: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := testTask()
+			task.Attempt = 4
+			task.LastError = "connection refused"
+			store := &mockDeadLetterStore{}
+			_ = store.Store(context.Background(), task, "dead-letter delivery failed")
+
+			scheduler := &mockScheduler{
+				scheduleFunc: func(_ context.Context, _ *entity.Task, _ time.Duration) error {
+					return tt.scheduleErr
+				},
+			}
+			svc := NewTaskService(scheduler, &mockProducer{}, zap.NewNop(), WithDeadLetterFallback(store))
+
+			err := svc.RequeueDeadLetter(context.Background(), tt.taskID)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("expected %v, got %v", tt.wantErr, err)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(store.stored) != tt.wantStored {
+				t.Fatalf("expected %d stored tasks, got %d", tt.wantStored, len(store.stored))
+			}
+			if tt.wantStored == 1 && store.stored[0].LastError != "connection refused" {
+				t.Fatalf("expected the stored task to be unchanged, got %+v", store.stored[0])
+			}
+			if tt.wantErr == nil {
+				call := scheduler.scheduledTasks[0]
+				if call.Delay != 0 {
+					t.Fatalf("expected immediate delivery, got delay %v", call.Delay)
+				}
+				if call.Task.Attempt != 0 || call.Task.LastError != "" {
+					t.Fatalf("expected a fresh retry budget, got attempt %d, last error %q", call.Task.Attempt, call.Task.LastError)
+				}
+			}
+		})
+	}
+}
+
+func TestTaskService_ListDeadLetters(t *testing.T) {
+	store := &mockDeadLetterStore{}
+	for _, id := range []string{"task-1", "task-2", "task-3"} {
+		task := testTask()
+		task.ID = id
+		_ = store.Store(context.Background(), task, "exhausted")
+	}
+	svc := NewTaskService(&mockScheduler{}, &mockProducer{}, zap.NewNop(), WithDeadLetterFallback(store))
+
+	entries, next, err := svc.ListDeadLetters(context.Background(), entity.Cursor{}, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(entries) != 2 || entries[0].Task.ID != "task-3" || next.IsZero() {
+		t.Fatalf("expected the 2 newest entries and a next page, got %+v, %+v", entries, next)
+	}
+
+	entries, next, err = svc.ListDeadLetters(context.Background(), next, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Task.ID != "task-1" || !next.IsZero() {
+		t.Fatalf("expected the oldest entry on the last page, got %+v, %+v", entries, next)
+	}
+
+	if _, _, err := svc.ListDeadLetters(context.Background(), entity.Cursor{}, domain.MaxListLimit+1); !errors.Is(err, domain.ErrInvalidQuery) {
+		t.Fatalf("expected ErrInvalidQuery, got %v", err)
+	}
+}
+
+func TestTaskService_DeadLettersRequireStore(t *testing.T) {
+	svc := NewTaskService(&mockScheduler{}, &mockProducer{}, zap.NewNop())
+
+	if _, _, err := svc.ListDeadLetters(context.Background(), entity.Cursor{}, 10); !errors.Is(err, errDeadLetterStoreUnsupported) {
+		t.Fatalf("expected errDeadLetterStoreUnsupported, got %v", err)
+	}
+	if err := svc.RequeueDeadLetter(context.Background(), "task-1"); !errors.Is(err, errDeadLetterStoreUnsupported) {
+		t.Fatalf("expected errDeadLetterStoreUnsupported, got %v", err)
+	}
+}
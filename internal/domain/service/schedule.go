@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+	"github.com/ruudy-sib/rebound/internal/port/secondary"
+)
+
+// ScheduleService answers operator questions about the schedule as a whole,
+// as opposed to TaskService which acts on individual tasks.
+type ScheduleService struct {
+	scheduler secondary.TaskScheduler
+	logger    *zap.Logger
+	now       func() time.Time
+}
+
+// NewScheduleService creates a ScheduleService over scheduler.
+func NewScheduleService(scheduler secondary.TaskScheduler, logger *zap.Logger) *ScheduleService {
+	return &ScheduleService{
+		scheduler: scheduler,
+		logger:    logger.Named("schedule-service"),
+		now:       time.Now,
+	}
+}
+
+// Forecast counts the tasks becoming due in each minute of the coming
+// window, so capacity planners can see delivery bursts ahead of time.
+func (s *ScheduleService) Forecast(ctx context.Context, window time.Duration) (*entity.ScheduleForecast, error) {
+	if window < domain.ForecastBucket || window > domain.MaxForecastWindow {
+		return nil, fmt.Errorf("%w: window must be between %v and %v",
+			domain.ErrInvalidQuery, domain.ForecastBucket, domain.MaxForecastWindow)
+	}
+
+	from := s.now().Truncate(time.Second)
+	buckets := int((window + domain.ForecastBucket - 1) / domain.ForecastBucket)
+
+	// times[0] yields the overdue count; each later entry closes a bucket.
+	times := make([]time.Time, buckets+1)
+	for i := range times {
+		times[i] = from.Add(time.Duration(i) * domain.ForecastBucket)
+	}
+
+	cumulative, err := s.scheduler.CountDueBy(ctx, times)
+	if err != nil {
+		return nil, fmt.Errorf("forecasting schedule: %w", err)
+	}
+
+	forecast := &entity.ScheduleForecast{
+		From:    from,
+		Bucket:  domain.ForecastBucket,
+		Overdue: cumulative[0],
+		Counts:  make([]int64, buckets),
+	}
+	for i := range forecast.Counts {
+		forecast.Counts[i] = cumulative[i+1] - cumulative[i]
+	}
+	return forecast, nil
+}
+
+// Upcoming returns up to limit scheduled tasks after the cursor, earliest
+// due first, and the cursor of the next page.
+func (s *ScheduleService) Upcoming(ctx context.Context, after entity.Cursor, limit int) ([]entity.ScheduledTask, entity.Cursor, error) {
+	if limit < 1 || limit > domain.MaxListLimit {
+		return nil, entity.Cursor{}, fmt.Errorf("%w: limit must be between 1 and %d", domain.ErrInvalidQuery, domain.MaxListLimit)
+	}
+
+	scheduled, next, err := s.scheduler.Peek(ctx, after, limit)
+	if err != nil {
+		return nil, entity.Cursor{}, fmt.Errorf("listing upcoming tasks: %w", err)
+	}
+	return scheduled, next, nil
+}
+
+// ByDestination returns up to limit scheduled tasks targeting the given URL
+// or topic, earliest due first, e.g. to size the backlog for a partner that
+// reports an outage.
+func (s *ScheduleService) ByDestination(ctx context.Context, destination string, limit int) ([]entity.ScheduledTask, error) {
+	if destination == "" {
+		return nil, fmt.Errorf("%w: destination is required", domain.ErrInvalidQuery)
+	}
+	if limit < 1 || limit > domain.MaxListLimit {
+		return nil, fmt.Errorf("%w: limit must be between 1 and %d", domain.ErrInvalidQuery, domain.MaxListLimit)
+	}
+
+	scheduled, err := s.scheduler.FindByDestination(ctx, destination, limit)
+	if err != nil {
+		return nil, fmt.Errorf("listing tasks for destination: %w", err)
+	}
+	return scheduled, nil
+}
+
+// Shift moves the due time of every scheduled task matching filter by the
+// given amount, e.g. pushing everything for a broken partner out by two
+// hours. An empty filter is rejected so the whole schedule is never moved
+// by accident.
+func (s *ScheduleService) Shift(ctx context.Context, filter entity.TaskFilter, by time.Duration) (int, error) {
+	if filter.IsEmpty() {
+		return 0, fmt.Errorf("%w: at least one of client, destination, or due range is required", domain.ErrInvalidQuery)
+	}
+	if by == 0 {
+		return 0, fmt.Errorf("%w: shift must not be zero", domain.ErrInvalidQuery)
+	}
+	if !filter.DueAfter.IsZero() && !filter.DueBefore.IsZero() && filter.DueBefore.Before(filter.DueAfter) {
+		return 0, fmt.Errorf("%w: due_before must not be earlier than due_after", domain.ErrInvalidQuery)
+	}
+
+	shifted, err := s.scheduler.Shift(ctx, filter, by)
+	if err != nil {
+		return shifted, fmt.Errorf("shifting tasks: %w", err)
+	}
+	return shifted, nil
+}
+
+// Reschedule moves one scheduled task's next attempt to change.At, or by
+// change.By from its current due time, e.g. pulling a far-future retry
+// forward or pushing a noisy one back. The task stays scheduled throughout
+// and keeps its attempt count. It returns the old and new due times.
+func (s *ScheduleService) Reschedule(ctx context.Context, taskID string, change entity.Reschedule) (time.Time, time.Time, error) {
+	if change.At.IsZero() == (change.By == 0) {
+		return time.Time{}, time.Time{}, fmt.Errorf("%w: exactly one of due_at and shift is required", domain.ErrInvalidQuery)
+	}
+
+	previous, next, err := s.scheduler.Reschedule(ctx, taskID, change)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("rescheduling task %s: %w", taskID, err)
+	}
+	return previous, next, nil
+}
@@ -0,0 +1,205 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+func TestScheduleService_Forecast(t *testing.T) {
+	now := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	scheduler := &mockScheduler{dueTimes: []time.Time{
+		now.Add(-time.Hour),                     // overdue
+		now.Add(30 * time.Second),               // minute 0
+		now.Add(45 * time.Second),               // minute 0
+		now.Add(2*time.Minute + 30*time.Second), // minute 2
+		now.Add(2 * time.Hour),                  // beyond window
+	}}
+	svc := NewScheduleService(scheduler, zap.NewNop())
+	svc.now = func() time.Time { return now.Add(500 * time.Millisecond) }
+
+	forecast, err := svc.Forecast(context.Background(), 3*time.Minute)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !forecast.From.Equal(now) {
+		t.Fatalf("expected the forecast from %v, got %v", now, forecast.From)
+	}
+
+	if forecast.Overdue != 1 {
+		t.Fatalf("expected 1 overdue task, got %d", forecast.Overdue)
+	}
+	want := []int64{2, 0, 1}
+	if len(forecast.Counts) != len(want) {
+		t.Fatalf("expected %d buckets, got %d", len(want), len(forecast.Counts))
+	}
+	for i := range want {
+		if forecast.Counts[i] != want[i] {
+			t.Fatalf("bucket %d = %d, want %d", i, forecast.Counts[i], want[i])
+		}
+	}
+}
+
+func TestScheduleService_Forecast_InvalidWindow(t *testing.T) {
+	svc := NewScheduleService(&mockScheduler{}, zap.NewNop())
+
+	for _, window := range []time.Duration{0, 30 * time.Second, 25 * time.Hour} {
+		if _, err := svc.Forecast(context.Background(), window); !errors.Is(err, domain.ErrInvalidQuery) {
+			t.Fatalf("window %v: expected ErrInvalidQuery, got %v", window, err)
+		}
+	}
+}
+
+func TestScheduleService_Shift(t *testing.T) {
+	tests := []struct {
+		name    string
+		filter  entity.TaskFilter
+		by      time.Duration
+		wantErr bool
+	}{
+		{name: "by destination", filter: entity.TaskFilter{Destination: "http://partner/hook"}, by: 2 * time.Hour},
+		{name: "pull in by client", filter: entity.TaskFilter{ClientID: "client-1"}, by: -30 * time.Minute},
+		{name: "empty filter", by: time.Hour, wantErr: true},
+		{name: "zero shift", filter: entity.TaskFilter{ClientID: "client-1"}, wantErr: true},
+		{
+			name: "inverted range",
+			filter: entity.TaskFilter{
+				DueAfter:  time.Now().Add(time.Hour),
+				DueBefore: time.Now(),
+			},
+			by:      time.Hour,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheduler := &mockScheduler{dueTimes: []time.Time{time.Now(), time.Now()}}
+			svc := NewScheduleService(scheduler, zap.NewNop())
+
+			shifted, err := svc.Shift(context.Background(), tt.filter, tt.by)
+			if tt.wantErr {
+				if !errors.Is(err, domain.ErrInvalidQuery) {
+					t.Fatalf("expected ErrInvalidQuery, got %v", err)
+				}
+				if scheduler.shiftBy != 0 {
+					t.Fatal("scheduler should not be called for an invalid shift")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if shifted != 2 || scheduler.shiftBy != tt.by || scheduler.shiftFilter != tt.filter {
+				t.Fatalf("unexpected shift: %d by %v with %+v", shifted, scheduler.shiftBy, scheduler.shiftFilter)
+			}
+		})
+	}
+}
+
+func TestScheduleService_Reschedule(t *testing.T) {
+	at := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name       string
+		change     entity.Reschedule
+		storeErr   error
+		wantErr    error
+		wantCalled bool
+	}{
+		{name: "absolute", change: entity.Reschedule{At: at}, wantCalled: true},
+		{name: "relative", change: entity.Reschedule{By: -30 * time.Minute}, wantCalled: true},
+		{name: "neither", wantErr: domain.ErrInvalidQuery},
+		{name: "both", change: entity.Reschedule{At: at, By: time.Hour}, wantErr: domain.ErrInvalidQuery},
+		{name: "not scheduled", change: entity.Reschedule{At: at}, storeErr: domain.ErrTaskNotFound, wantErr: domain.ErrTaskNotFound, wantCalled: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			scheduler := &mockScheduler{
+				rescheduleFn: func(_ context.Context, _ string, change entity.Reschedule) (time.Time, time.Time, error) {
+					called = true
+					return time.Now(), change.At, tt.storeErr
+				},
+			}
+			svc := NewScheduleService(scheduler, zap.NewNop())
+
+			_, next, err := svc.Reschedule(context.Background(), "task-1", tt.change)
+			if called != tt.wantCalled {
+				t.Fatalf("expected scheduler called=%v, got %v", tt.wantCalled, called)
+			}
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("expected %v, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if scheduler.rescheduled != tt.change || !next.Equal(tt.change.At) {
+				t.Fatalf("expected change %+v to reach the scheduler, got %+v", tt.change, scheduler.rescheduled)
+			}
+		})
+	}
+}
+
+func TestScheduleService_Upcoming(t *testing.T) {
+	scheduler := &mockScheduler{peeked: []entity.ScheduledTask{
+		{Task: testTask(), DueAt: time.Now()},
+		{Task: testHTTPTask(), DueAt: time.Now().Add(time.Minute)},
+	}}
+	svc := NewScheduleService(scheduler, zap.NewNop())
+
+	upcoming, next, err := svc.Upcoming(context.Background(), entity.Cursor{}, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(upcoming) != 1 || upcoming[0].Task.ID != "task-1" || next.IsZero() {
+		t.Fatalf("expected only the earliest task and a next page, got %+v, %+v", upcoming, next)
+	}
+
+	upcoming, next, err = svc.Upcoming(context.Background(), next, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(upcoming) != 1 || upcoming[0].Task.ID != testHTTPTask().ID || !next.IsZero() {
+		t.Fatalf("expected the second task on the last page, got %+v, %+v", upcoming, next)
+	}
+
+	for _, limit := range []int{0, domain.MaxListLimit + 1} {
+		if _, _, err := svc.Upcoming(context.Background(), entity.Cursor{}, limit); !errors.Is(err, domain.ErrInvalidQuery) {
+			t.Fatalf("limit %d: expected ErrInvalidQuery, got %v", limit, err)
+		}
+	}
+}
+
+func TestScheduleService_ByDestination(t *testing.T) {
+	scheduler := &mockScheduler{peeked: []entity.ScheduledTask{
+		{Task: testTask(), DueAt: time.Now()},
+		{Task: testHTTPTask(), DueAt: time.Now().Add(time.Minute)},
+	}}
+	svc := NewScheduleService(scheduler, zap.NewNop())
+
+	found, err := svc.ByDestination(context.Background(), "http://localhost:8090/webhook", 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(found) != 1 || found[0].Task.ID != testHTTPTask().ID {
+		t.Fatalf("expected only the http task, got %+v", found)
+	}
+
+	if _, err := svc.ByDestination(context.Background(), "", 10); !errors.Is(err, domain.ErrInvalidQuery) {
+		t.Fatalf("expected ErrInvalidQuery without a destination, got %v", err)
+	}
+	if _, err := svc.ByDestination(context.Background(), "orders", 0); !errors.Is(err, domain.ErrInvalidQuery) {
+		t.Fatalf("expected ErrInvalidQuery for limit 0, got %v", err)
+	}
+}
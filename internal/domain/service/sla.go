@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+	"github.com/ruudy-sib/rebound/internal/port/secondary"
+)
+
+// slaBreachEvent is POSTed to SLAPolicy.BreachURL when a task is delivered
+// later than the SLA threshold.
+type slaBreachEvent struct {
+	TaskID          string    `json:"task_id"`
+	Source          string    `json:"source"`
+	ClientID        string    `json:"client_id"`
+	Destination     string    `json:"destination"`
+	Attempts        int       `json:"attempts"`
+	CreatedAt       time.Time `json:"created_at"`
+	DeliveredAt     time.Time `json:"delivered_at"`
+	TimeToSuccessMS int64     `json:"time_to_success_ms"`
+	ThresholdMS     int64     `json:"threshold_ms"`
+}
+
+// recordTimeToSuccess records the delay between creation and delivery of a
+// delivered task and reports an SLA breach. Tasks scheduled before creation
+// times were tracked have no CreatedAt and are skipped.
+func (s *TaskService) recordTimeToSuccess(ctx context.Context, task *entity.Task, logger *zap.Logger) {
+	if s.sla == nil || task.CreatedAt.IsZero() {
+		return
+	}
+
+	deliveredAt := s.now()
+	elapsed := deliveredAt.Sub(task.CreatedAt)
+	destination := task.Destination.Name()
+
+	if err := s.sla.Record(ctx, task.ClientID, destination, elapsed); err != nil {
+		logger.Warn("failed to record time to success", zap.Error(err))
+	}
+
+	if s.slaPolicy.Threshold <= 0 || elapsed <= s.slaPolicy.Threshold {
+		return
+	}
+
+	logger.Warn("delivery SLA breached",
+		zap.String("client_id", task.ClientID),
+		zap.String("destination", destination),
+		zap.Duration("time_to_success", elapsed),
+		zap.Duration("threshold", s.slaPolicy.Threshold),
+	)
+
+	if s.slaPolicy.BreachURL == "" {
+		return
+	}
+
+	value, err := json.Marshal(slaBreachEvent{
+		TaskID:          task.ID,
+		Source:          task.Source,
+		ClientID:        task.ClientID,
+		Destination:     destination,
+		Attempts:        task.Attempt + 1,
+		CreatedAt:       task.CreatedAt.UTC(),
+		DeliveredAt:     deliveredAt.UTC(),
+		TimeToSuccessMS: elapsed.Milliseconds(),
+		ThresholdMS:     s.slaPolicy.Threshold.Milliseconds(),
+	})
+	if err != nil {
+		logger.Error("failed to build SLA breach event", zap.Error(err))
+		return
+	}
+
+	// Best effort, like outcome callbacks: the task is already delivered.
+	key := []byte(fmt.Sprintf("%s|sla-breach", task.ID))
+	if err := s.producer.Produce(ctx, entity.Destination{URL: s.slaPolicy.BreachURL}, secondary.Message{Key: key, Value: value}); err != nil {
+		logger.Warn("SLA breach event failed",
+			zap.Error(err),
+			zap.String("breach_url", s.slaPolicy.BreachURL),
+		)
+	}
+}
+
+// SLAService reports time-to-success percentiles from an SLAStore.
+type SLAService struct {
+	store secondary.SLAStore
+}
+
+// NewSLAService creates an SLAService backed by store.
+func NewSLAService(store secondary.SLAStore) *SLAService {
+	return &SLAService{store: store}
+}
+
+// TimeToSuccess returns time-to-success percentiles per client and destination.
+func (s *SLAService) TimeToSuccess(ctx context.Context) ([]entity.TimeToSuccessStats, error) {
+	groups, err := s.store.Samples(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("reading time to success samples: %w", err)
+	}
+
+	stats := make([]entity.TimeToSuccessStats, 0, len(groups))
+	for _, g := range groups {
+		stats = append(stats, entity.NewTimeToSuccessStats(g.ClientID, g.Destination, g.Samples))
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].ClientID != stats[j].ClientID {
+			return stats[i].ClientID < stats[j].ClientID
+		}
+		return stats[i].Destination < stats[j].Destination
+	})
+	return stats, nil
+}
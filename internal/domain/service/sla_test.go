@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+func TestTaskService_ProcessDueTasks_SLA(t *testing.T) {
+	tests := []struct {
+		name       string
+		createdAgo time.Duration
+		policy     SLAPolicy
+		wantBreach bool
+	}{
+		{
+			name:       "within threshold",
+			createdAgo: 10 * time.Second,
+			policy:     SLAPolicy{Threshold: time.Minute, BreachURL: "http://alerts/sla"},
+		},
+		{
+			name:       "breach emits event",
+			createdAgo: 2 * time.Minute,
+			policy:     SLAPolicy{Threshold: time.Minute, BreachURL: "http://alerts/sla"},
+			wantBreach: true,
+		},
+		{
+			name:       "breach without url only logs",
+			createdAgo: 2 * time.Minute,
+			policy:     SLAPolicy{Threshold: time.Minute},
+		},
+		{
+			name:       "zero threshold disables breach detection",
+			createdAgo: time.Hour,
+			policy:     SLAPolicy{BreachURL: "http://alerts/sla"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := testHTTPTask()
+			task.CreatedAt = time.Now().Add(-tt.createdAgo)
+
+			scheduler := &mockScheduler{
+				fetchDueFunc: func(_ context.Context, _ int) ([]*entity.Task, error) {
+					return []*entity.Task{task}, nil
+				},
+			}
+			producer := &mockProducer{}
+			store := newMockSLAStore()
+			svc := NewTaskService(scheduler, producer, zap.NewNop(), WithSLATracking(store, tt.policy))
+
+			if _, err := svc.ProcessDueTasks(context.Background()); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			samples := store.samples[[2]string{"client-1", "http://localhost:8090/webhook"}]
+			if len(samples) != 1 || samples[0] < tt.createdAgo {
+				t.Fatalf("expected one sample of at least %v, got %v", tt.createdAgo, samples)
+			}
+
+			var breaches []produceCall
+			for _, call := range producer.produceCalls {
+				if call.Destination.URL == "http://alerts/sla" {
+					breaches = append(breaches, call)
+				}
+			}
+			if tt.wantBreach != (len(breaches) == 1) {
+				t.Fatalf("wantBreach=%v, got %d breach events", tt.wantBreach, len(breaches))
+			}
+			if tt.wantBreach {
+				var event slaBreachEvent
+				if err := json.Unmarshal(breaches[0].Value, &event); err != nil {
+					t.Fatalf("decoding breach event: %v", err)
+				}
+				if event.TaskID != task.ID || event.ThresholdMS != 60000 || event.TimeToSuccessMS < 120000 {
+					t.Fatalf("unexpected breach event: %+v", event)
+				}
+			}
+		})
+	}
+}
+
+func TestTaskService_ProcessDueTasks_SLASkipsUntrackedTasks(t *testing.T) {
+	scheduler := &mockScheduler{
+		fetchDueFunc: func(_ context.Context, _ int) ([]*entity.Task, error) {
+			return []*entity.Task{testHTTPTask()}, nil
+		},
+	}
+	store := newMockSLAStore()
+	svc := NewTaskService(scheduler, &mockProducer{}, zap.NewNop(), WithSLATracking(store, SLAPolicy{}))
+
+	if _, err := svc.ProcessDueTasks(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(store.samples) != 0 {
+		t.Fatalf("tasks without CreatedAt should not be sampled, got %v", store.samples)
+	}
+}
+
+func TestSLAService_TimeToSuccess(t *testing.T) {
+	store := newMockSLAStore()
+	ctx := context.Background()
+	_ = store.Record(ctx, "client-b", "orders", 3*time.Second)
+	_ = store.Record(ctx, "client-a", "orders", time.Second)
+	_ = store.Record(ctx, "client-a", "orders", 2*time.Second)
+
+	stats, err := NewSLAService(store).TimeToSuccess(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(stats) != 2 {
+		t.Fatalf("expected 2 groups, got %d", len(stats))
+	}
+	if stats[0].ClientID != "client-a" || stats[0].Count != 2 || stats[0].Max != 2*time.Second {
+		t.Fatalf("unexpected first group: %+v", stats[0])
+	}
+	if stats[1].ClientID != "client-b" || stats[1].P50 != 3*time.Second {
+		t.Fatalf("unexpected second group: %+v", stats[1])
+	}
+}
@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+// stormState counts the retries scheduled for one destination in the
+// current window.
+type stormState struct {
+	windowStart time.Time
+	retries     int
+	throttled   bool
+}
+
+// throttleStorm counts a retry scheduled for the task's destination and
+// returns delay stretched by the storm multiplier while the destination
+// is throttled. Throttling engages once Threshold retries are scheduled
+// within a window. A stretched delay stays within the task's MaxDelay,
+// though a longer delay asked for by the destination is kept.
+func (s *TaskService) throttleStorm(ctx context.Context, task *entity.Task, delay time.Duration) time.Duration {
+	if s.stormPolicy.Threshold <= 0 || task.Source == domain.LifecycleEventSource {
+		return delay
+	}
+	name := task.Destination.Name()
+	now := s.now()
+
+	s.stormMu.Lock()
+	state, released := s.rollStorm(s.storms[name], now)
+	state.retries++
+	engaged := !state.throttled && state.retries >= s.stormPolicy.Threshold
+	if engaged {
+		state.throttled = true
+	}
+	s.storms[name] = state
+	s.stormMu.Unlock()
+
+	if released {
+		s.stormEvent(ctx, EventRetryStormReleased, name, 0)
+	}
+	if engaged {
+		s.stormEvent(ctx, EventRetryStormEngaged, name, state.retries)
+	}
+	if !state.throttled {
+		return delay
+	}
+	stretched := delay * time.Duration(s.stormPolicy.Multiplier)
+	if task.MaxDelay > 0 && stretched > task.MaxDelay {
+		return max(task.MaxDelay, delay)
+	}
+	return stretched
+}
+
+// releaseStorms ends the windows that have run out, so throttling is
+// released even when no more retries are scheduled for the destination.
+func (s *TaskService) releaseStorms(ctx context.Context) {
+	if s.stormPolicy.Threshold <= 0 {
+		return
+	}
+	now := s.now()
+
+	var released []string
+	s.stormMu.Lock()
+	for name, state := range s.storms {
+		next, wasReleased := s.rollStorm(state, now)
+		if wasReleased {
+			released = append(released, name)
+		}
+		if !next.throttled && next.retries == 0 {
+			delete(s.storms, name)
+			continue
+		}
+		s.storms[name] = next
+	}
+	s.stormMu.Unlock()
+
+	for _, name := range released {
+		s.stormEvent(ctx, EventRetryStormReleased, name, 0)
+	}
+}
+
+// rollStorm starts a new window once the current one has run out. A
+// throttled destination is released when the window ending saw fewer than
+// half of Threshold retries; the hysteresis keeps the stretched delays
+// themselves from releasing it while the outage lasts. Callers hold stormMu.
+func (s *TaskService) rollStorm(state stormState, now time.Time) (stormState, bool) {
+	if now.Sub(state.windowStart) < s.stormPolicy.Window {
+		return state, false
+	}
+	released := state.throttled && state.retries*2 < s.stormPolicy.Threshold
+	return stormState{windowStart: now, throttled: state.throttled && !released}, released
+}
+
+// stormEvent logs and raises a retry storm event. retries is only
+// reported when throttling engages.
+func (s *TaskService) stormEvent(ctx context.Context, eventType, destination string, retries int) {
+	logger := s.logger.With(zap.String("destination", destination))
+	event := lifecycleEvent{
+		Type:        eventType,
+		OccurredAt:  s.now().UTC(),
+		Destination: destination,
+	}
+	if eventType == EventRetryStormEngaged {
+		logger.Warn("retry storm detected, stretching retry delays",
+			zap.Int("retries", retries),
+			zap.Duration("window", s.stormPolicy.Window),
+			zap.Int("multiplier", s.stormPolicy.Multiplier),
+		)
+		event.Retries = retries
+		event.DelayMultiplier = s.stormPolicy.Multiplier
+	} else {
+		logger.Info("retry storm over, retry delays back to normal")
+	}
+	s.raiseEvent(ctx, event, destination, logger)
+}
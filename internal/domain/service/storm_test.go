@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+func TestTaskService_retryStormThrottling(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	start := now
+
+	var due []*entity.Task
+	scheduler := &mockScheduler{
+		fetchDueFunc: func(context.Context, int) ([]*entity.Task, error) {
+			tasks := due
+			due = nil
+			return tasks, nil
+		},
+	}
+	producer := &mockProducer{
+		produceFunc: func(context.Context, entity.Destination, []byte, []byte) error {
+			return errors.New("kafka down")
+		},
+	}
+	svc := NewTaskService(scheduler, producer, zap.NewNop(),
+		WithClock(func() time.Time { return now }),
+		WithLifecycleWebhooks([]string{"http://ops.internal/alarms"}),
+		WithRetryStormThrottling(StormPolicy{Threshold: 3, Window: time.Minute, Multiplier: 4}),
+	)
+
+	poll := func(at time.Duration, tasks int) (delays []time.Duration, events []lifecycleEvent) {
+		t.Helper()
+		now = start.Add(at)
+		for i := 0; i < tasks; i++ {
+			task := testTask()
+			task.Attempt = 1
+			task.BaseDelay = time.Second
+			due = append(due, task)
+		}
+		scheduler.scheduledTasks = nil
+		if _, err := svc.ProcessDueTasks(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for _, call := range scheduler.scheduledTasks {
+			if call.Task.Source != domain.LifecycleEventSource {
+				delays = append(delays, call.Delay)
+				continue
+			}
+			var event lifecycleEvent
+			if err := json.Unmarshal([]byte(call.Task.MessageData), &event); err != nil {
+				t.Fatalf("event payload is not valid JSON: %v", err)
+			}
+			events = append(events, event)
+		}
+		return delays, events
+	}
+
+	delays, events := poll(0, 3)
+	if len(delays) != 3 || delays[0] != 2*time.Second || delays[1] != 2*time.Second || delays[2] != 8*time.Second {
+		t.Fatalf("expected the third retry to engage throttling, got delays %v", delays)
+	}
+	if len(events) != 1 || events[0].Type != EventRetryStormEngaged || events[0].Destination != "my-topic" ||
+		events[0].Retries != 3 || events[0].DelayMultiplier != 4 {
+		t.Fatalf("unexpected events: %+v", events)
+	}
+
+	if delays, events = poll(30*time.Second, 1); len(delays) != 1 || delays[0] != 8*time.Second || len(events) != 0 {
+		t.Fatalf("expected a stretched retry and no event, got %v %+v", delays, events)
+	}
+
+	// The first window saw 4 retries, so the destination stays throttled
+	// into the next one.
+	if _, events = poll(2*time.Minute, 0); len(events) != 0 {
+		t.Fatalf("expected throttling to continue, got %+v", events)
+	}
+	if _, events = poll(4*time.Minute, 0); len(events) != 1 || events[0].Type != EventRetryStormReleased {
+		t.Fatalf("expected throttling to be released, got %+v", events)
+	}
+
+	if delays, _ = poll(5*time.Minute, 1); len(delays) != 1 || delays[0] != 2*time.Second {
+		t.Fatalf("expected normal delays after release, got %v", delays)
+	}
+}
+
+func TestTaskService_retryStormThrottlingMaxDelay(t *testing.T) {
+	tests := []struct {
+		name     string
+		maxDelay time.Duration
+		attempt  int
+		want     time.Duration
+	}{
+		{name: "uncapped", want: 8 * time.Second},
+		{name: "stretch below the cap", maxDelay: 10 * time.Second, attempt: 1, want: 8 * time.Second},
+		{name: "stretch at the cap", maxDelay: 8 * time.Second, attempt: 1, want: 8 * time.Second},
+		{name: "stretch past the cap", maxDelay: 5 * time.Second, attempt: 1, want: 5 * time.Second},
+		{name: "delay already at the cap", maxDelay: 5 * time.Second, attempt: 6, want: 5 * time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := testTask()
+			task.Attempt = max(tt.attempt, 1)
+			task.MaxRetries = 10
+			task.BaseDelay = time.Second
+			task.MaxDelay = tt.maxDelay
+
+			scheduler := &mockScheduler{
+				fetchDueFunc: func(context.Context, int) ([]*entity.Task, error) {
+					return []*entity.Task{task}, nil
+				},
+			}
+			producer := &mockProducer{
+				produceFunc: func(context.Context, entity.Destination, []byte, []byte) error {
+					return errors.New("kafka down")
+				},
+			}
+			svc := NewTaskService(scheduler, producer, zap.NewNop(),
+				WithRetryStormThrottling(StormPolicy{Threshold: 1, Window: time.Minute, Multiplier: 4}),
+			)
+
+			if _, err := svc.ProcessDueTasks(context.Background()); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(scheduler.scheduledTasks) != 1 || scheduler.scheduledTasks[0].Delay != tt.want {
+				t.Fatalf("expected a %v retry, got %+v", tt.want, scheduler.scheduledTasks)
+			}
+		})
+	}
+}
+
+func TestTaskService_retryStormThrottlingDisabled(t *testing.T) {
+	task := testTask()
+	scheduler := &mockScheduler{
+		fetchDueFunc: func(context.Context, int) ([]*entity.Task, error) {
+			return []*entity.Task{task}, nil
+		},
+	}
+	producer := &mockProducer{
+		produceFunc: func(context.Context, entity.Destination, []byte, []byte) error {
+			return errors.New("kafka down")
+		},
+	}
+	svc := NewTaskService(scheduler, producer, zap.NewNop())
+
+	for i := 0; i < 3; i++ {
+		if _, err := svc.ProcessDueTasks(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if len(svc.storms) != 0 {
+		t.Fatalf("expected no storm state without a threshold, got %+v", svc.storms)
+	}
+}
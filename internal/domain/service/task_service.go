@@ -2,13 +2,18 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math/rand"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"go.uber.org/zap"
 
 	"github.com/ruudy-sib/rebound/internal/domain"
 	"github.com/ruudy-sib/rebound/internal/domain/entity"
+	"github.com/ruudy-sib/rebound/internal/domain/valueobject"
 	"github.com/ruudy-sib/rebound/internal/port/secondary"
 )
 
@@ -17,6 +22,83 @@ type TaskService struct {
 	scheduler secondary.TaskScheduler
 	producer  secondary.MessageProducer
 	logger    *zap.Logger
+	now       func() time.Time
+	random    func() float64
+
+	jitter   entity.JitterMode
+	maxDelay time.Duration
+
+	logSampling    LogSampling
+	deliveryLogger *zap.Logger
+	redaction      valueobject.Redaction
+
+	quarantine   secondary.QuarantineStore
+	poisonPolicy PoisonPolicy
+
+	deadLetterStore  secondary.DeadLetterStore
+	deadLetterPolicy DeadLetterPolicy
+	deadLetterMode   entity.DeadLetterMode
+	redrivePolicies  map[string]RedrivePolicy
+
+	ordering secondary.OrderingStore
+
+	completions   secondary.CompletionStore
+	completionTTL time.Duration
+
+	results   secondary.DeliveryResultStore
+	resultTTL time.Duration
+
+	httpBatchSize int
+
+	maintenance secondary.MaintenanceStore
+
+	sla       secondary.SLAStore
+	slaPolicy SLAPolicy
+
+	usage       secondary.UsageStore
+	usageEvents entity.Destination
+
+	notifier secondary.TaskNotifier
+
+	cancelled    secondary.CancelledStore
+	cancelledTTL time.Duration
+
+	heartbeat    secondary.HeartbeatStore
+	heartbeatTTL time.Duration
+
+	inFlight     secondary.InFlightStore
+	reclaimAfter int
+
+	attemptHooks map[string][]AttemptHook
+	hookTimeout  time.Duration
+
+	batchSize       int
+	concurrency     int
+	deliveryTimeout time.Duration
+
+	newID             IDGenerator
+	bounds            ValidationBounds
+	queues            map[string]QueuePolicy
+	validators        []Validator
+	classifyError     ErrorClassifier
+	retryableStatuses valueobject.RetryableStatuses
+
+	maxPending     int64
+	overflowAction entity.OverflowAction
+	overflowStore  secondary.OverflowStore
+	overflowCount  overflowCounters
+
+	prober      secondary.HealthProber
+	probePolicy ProbePolicy
+	probeMu     sync.Mutex
+	probes      map[string]probeState
+
+	stormPolicy StormPolicy
+	stormMu     sync.Mutex
+	storms      map[string]stormState
+
+	lifecycleURLs  []string
+	lastQueueAlarm atomic.Int64
 }
 
 // NewTaskService creates a TaskService with its dependencies injected.
@@ -24,23 +106,181 @@ func NewTaskService(
 	scheduler secondary.TaskScheduler,
 	producer secondary.MessageProducer,
 	logger *zap.Logger,
+	opts ...Option,
 ) *TaskService {
-	return &TaskService{
+	s := &TaskService{
 		scheduler: scheduler,
 		producer:  producer,
 		logger:    logger.Named("task-service"),
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if s.now == nil {
+		s.now = time.Now
+	}
+	if s.random == nil {
+		s.random = rand.Float64
+	}
+	if s.poisonPolicy.FailureWindow <= 0 {
+		s.poisonPolicy.FailureWindow = domain.DefaultPoisonFailureWindow
+	}
+	if s.deadLetterPolicy.MaxAttempts <= 0 {
+		s.deadLetterPolicy.MaxAttempts = domain.DefaultDeadLetterMaxAttempts
+	}
+	if s.deadLetterPolicy.Backoff <= 0 {
+		s.deadLetterPolicy.Backoff = domain.DefaultDeadLetterBackoff
+	}
+	if s.completionTTL <= 0 {
+		s.completionTTL = domain.DefaultCompletionMarkerTTL
+	}
+	if s.cancelledTTL <= 0 {
+		s.cancelledTTL = domain.DefaultCancelledTaskTTL
+	}
+	if s.heartbeatTTL <= 0 {
+		s.heartbeatTTL = domain.DefaultHeartbeatTTL
+	}
+	if s.reclaimAfter <= 0 {
+		s.reclaimAfter = domain.DefaultReclaimAfterTimeouts
+	}
+	if s.batchSize <= 0 {
+		s.batchSize = domain.DefaultBatchSize
+	}
+	if s.deliveryTimeout <= 0 {
+		s.deliveryTimeout = domain.DefaultDeliveryTimeout
+	}
+	if s.hookTimeout <= 0 {
+		s.hookTimeout = s.deliveryTimeout
+	}
+	if s.concurrency <= 0 {
+		s.concurrency = domain.DefaultDeliveryConcurrency
+	}
+	if s.probePolicy.Interval <= 0 {
+		s.probePolicy.Interval = domain.DefaultProbeInterval
+	}
+	if s.probePolicy.ReleaseRate <= 0 {
+		s.probePolicy.ReleaseRate = domain.DefaultProbeReleaseRate
+	}
+	if s.stormPolicy.Window <= 0 {
+		s.stormPolicy.Window = domain.DefaultRetryStormWindow
+	}
+	if s.stormPolicy.Multiplier <= 0 {
+		s.stormPolicy.Multiplier = domain.DefaultRetryStormMultiplier
+	}
+	if s.bounds.MinBaseDelay <= 0 {
+		s.bounds.MinBaseDelay = domain.MinBaseDelay
+	}
+	if s.bounds.MaxBaseDelay <= 0 {
+		s.bounds.MaxBaseDelay = domain.MaxBaseDelay
+	}
+	if s.bounds.MaxRetryLimit <= 0 {
+		s.bounds.MaxRetryLimit = domain.MaxRetryLimit
+	}
+	s.deliveryLogger = deliveryLogger(s.logger, s.logSampling)
+	s.probes = make(map[string]probeState)
+	s.storms = make(map[string]stormState)
+	return s
 }
 
 // CreateTask validates and schedules a new task for immediate processing.
+// A task with fan-out destinations is scheduled as one task per destination.
 func (s *TaskService) CreateTask(ctx context.Context, task *entity.Task) error {
+	return s.createTask(ctx, task, 0)
+}
+
+// CreateTaskAt validates a new task and schedules its first attempt at the
+// given time instead of BaseDelay from now. A time in the past schedules it
+// immediately.
+func (s *TaskService) CreateTaskAt(ctx context.Context, task *entity.Task, at time.Time) error {
+	return s.createTask(ctx, task, at.Sub(s.now())-task.FirstAttemptDelay())
+}
+
+// GetTask returns a scheduled task without changing it. Tasks still waiting
+// behind an ordering key are not scheduled yet and are not found.
+func (s *TaskService) GetTask(ctx context.Context, taskID string) (*entity.Task, error) {
+	task, err := s.scheduler.Get(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("reading task %s: %w", taskID, err)
+	}
+	return task, nil
+}
+
+func (s *TaskService) createTask(ctx context.Context, task *entity.Task, offset time.Duration) error {
+	tasks, err := s.fanOut(task)
+	if err != nil {
+		return fmt.Errorf("%w: %w", domain.ErrInvalidTask, err)
+	}
+	if admitted, err := s.admit(ctx, tasks); !admitted {
+		return err
+	}
+
+	for _, t := range tasks {
+		if err := s.scheduleNew(ctx, t, offset); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fanOut gives a new task without an ID a generated one, if an ID
+// generator is set, validates it and splits it into one task per
+// destination, validating each of them. The tasks come back normalized
+// for scheduling: a retry schedule sets how often they are retried, and
+// tasks without a delay cap get the deployment's.
+func (s *TaskService) fanOut(task *entity.Task) ([]*entity.Task, error) {
+	if task.ID == "" && s.newID != nil {
+		task.ID = s.newID()
+	}
 	if err := s.validateTask(task); err != nil {
-		return fmt.Errorf("%w: %v", domain.ErrInvalidTask, err)
+		return nil, err
+	}
+
+	tasks := task.FanOut()
+	for i, t := range tasks[1:] {
+		if err := s.validateTask(t); err != nil {
+			return nil, fmt.Errorf("fan-out destination %d: %v", i+1, err)
+		}
 	}
+	for _, t := range tasks {
+		if t.MaxDelay == 0 {
+			t.MaxDelay = s.maxDelay
+		}
+		if len(t.RetryDelays) > 0 {
+			// The schedule decides how often the task is retried.
+			t.MaxRetries = len(t.RetryDelays)
+		}
+	}
+	return tasks, nil
+}
 
+// scheduleNew resets the task's delivery state and schedules its first
+// attempt BaseDelay plus offset from now.
+func (s *TaskService) scheduleNew(ctx context.Context, task *entity.Task, offset time.Duration) error {
 	task.Attempt = 0
+	task.CreatedAt = s.now()
+	task.FirstAttemptAt = time.Time{}
+	task.LastAttemptAt = time.Time{}
+	task.LastError = ""
+	task.LastErrorCode = ""
+	task.RepeatedFailures = 0
+
+	if task.OrderingKey != "" {
+		active, err := s.ordering.Enqueue(ctx, task)
+		if err != nil {
+			return fmt.Errorf("%w: %v", domain.ErrScheduleFailed, err)
+		}
+		if !active {
+			s.logger.Info("task queued behind ordering key",
+				zap.String("task_id", task.ID),
+				zap.String("ordering_key", task.OrderingKey),
+			)
+			return nil
+		}
+	}
 
-	if err := s.scheduler.Schedule(ctx, task, time.Duration(task.BaseDelay)*time.Second); err != nil {
+	if err := s.scheduler.Schedule(ctx, task, task.FirstAttemptDelay()+offset); err != nil {
+		// Do not leave the ordering key held by a task that was never scheduled.
+		s.releaseOrdering(ctx, task, s.logger)
 		return fmt.Errorf("%w: %v", domain.ErrScheduleFailed, err)
 	}
 
@@ -53,65 +293,256 @@ func (s *TaskService) CreateTask(ctx context.Context, task *entity.Task) error {
 	return nil
 }
 
-// ProcessDueTasks fetches due tasks and processes each one.
+// ProcessDueTasks fetches due tasks and processes them, up to the
+// configured concurrency at once, and reports what happened to each.
 // Failed tasks are rescheduled with exponential backoff.
 // Tasks that exceed max retries are sent to the dead-letter destination.
-func (s *TaskService) ProcessDueTasks(ctx context.Context) error {
-	tasks, err := s.scheduler.FetchDue(ctx, domain.DefaultBatchSize)
+// Tasks of named queues are left to ProcessDueTasksIn.
+func (s *TaskService) ProcessDueTasks(ctx context.Context) (entity.ProcessResult, error) {
+	return s.processDue(ctx, "", s.batchSize)
+}
+
+// processDue fetches up to batchSize due tasks of queue and processes them.
+// The default queue's poll also does the housekeeping every worker shares.
+func (s *TaskService) processDue(ctx context.Context, queue string, batchSize int) (entity.ProcessResult, error) {
+	tasks, err := s.scheduler.FetchDue(ctx, queue, batchSize)
 	if err != nil {
-		return fmt.Errorf("fetching due tasks: %w", err)
+		return entity.ProcessResult{}, fmt.Errorf("fetching due tasks: %w", err)
+	}
+	var reclaimed int
+	if queue == "" {
+		s.beat(ctx)
+		s.restoreSpilled(ctx)
+		reclaimed = s.reclaimStuck(ctx)
 	}
 
-	for _, task := range tasks {
-		s.processTask(ctx, task)
+	results := newOutcomes()
+	tasks = s.holdForMaintenance(ctx, tasks, results)
+	tasks = s.holdForProbes(ctx, tasks, results)
+	if queue == "" {
+		s.releaseStorms(ctx)
 	}
 
-	return nil
+	groups := s.batchGroups(tasks)
+	s.trackInFlight(ctx, tasks, len(groups))
+
+	sem := make(chan struct{}, s.queueConcurrency(queue))
+	var wg sync.WaitGroup
+	for _, group := range groups {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			s.processGroup(ctx, group, results)
+		}()
+	}
+	wg.Wait()
+
+	result := results.result()
+	s.releaseInFlight(ctx, result)
+	result.Reclaimed = reclaimed
+	s.recordPoll(ctx, result)
+	s.recordUsage(ctx, result)
+	s.notifyTerminal(ctx, result)
+	return result, nil
+}
+
+// beat records that the worker loop is processing. A failure only costs
+// readiness, so it is logged rather than returned.
+func (s *TaskService) beat(ctx context.Context) {
+	if s.heartbeat == nil {
+		return
+	}
+	if err := s.heartbeat.Beat(ctx, s.heartbeatTTL); err != nil {
+		s.logger.Warn("failed to record worker heartbeat", zap.Error(err))
+	}
 }
 
-func (s *TaskService) processTask(ctx context.Context, task *entity.Task) {
-	logger := s.logger.With(
+// recordPoll adds the poll's attempted and failed tasks to this instance's
+// counters. Held and skipped tasks were not attempted. Like beat, a failure
+// is only logged.
+func (s *TaskService) recordPoll(ctx context.Context, result entity.ProcessResult) {
+	if s.heartbeat == nil || len(result.Tasks) == 0 {
+		return
+	}
+	processed := len(result.Tasks) - result.Count(entity.OutcomeHeld) - result.Count(entity.OutcomeSkipped)
+	errors := processed - result.Count(entity.OutcomeDelivered)
+	if err := s.heartbeat.RecordPoll(ctx, processed, errors); err != nil {
+		s.logger.Warn("failed to record worker stats", zap.Error(err))
+	}
+}
+
+func (s *TaskService) processTask(ctx context.Context, task *entity.Task, results *outcomes) {
+	logger := s.taskLogger(task)
+	logger.Info("processing task")
+
+	if s.alreadyDelivered(ctx, task, logger) {
+		logger.Warn("task already delivered, skipping duplicate delivery")
+		results.add(task, task.Attempt, entity.OutcomeSkipped, nil)
+		return
+	}
+
+	attempt := task.Attempt
+	started := s.now()
+	task.MarkAttempted(started)
+	receipt, err := s.deliver(ctx, task)
+	outcome := s.handleResult(ctx, task, err, s.now().Sub(started), logger)
+	if outcome == entity.OutcomeDelivered {
+		s.recordDeliveryResult(ctx, task, receipt, logger)
+	}
+	results.add(task, attempt, outcome, err)
+}
+
+func (s *TaskService) taskLogger(task *entity.Task) *zap.Logger {
+	return s.deliveryLogger.With(
 		zap.String("task_id", task.ID),
 		zap.Int("attempt", task.Attempt),
 	)
+}
 
-	logger.Info("processing task")
+// handleResult settles a delivery attempt that took elapsed and returned
+// err, and reports the outcome.
+func (s *TaskService) handleResult(ctx context.Context, task *entity.Task, err error, elapsed time.Duration, logger *zap.Logger) entity.Outcome {
+	if err != nil {
+		task.LastErrorCode = errorCode(err)
+		logger.Warn("delivery failed", zap.Error(err), zap.String("error_code", string(task.LastErrorCode)))
+		task.RecordFailure(err.Error(), elapsed < s.poisonPolicy.FailureWindow)
+		if s.permanent(err) {
+			return s.failPermanently(ctx, task, logger)
+		}
+		if s.quarantine != nil && task.IsPoison(s.poisonPolicy.Threshold) {
+			return s.quarantineTask(ctx, task, logger)
+		}
+		return s.handleFailure(ctx, task, retryAfter(err), logger)
+	}
+
+	logger.Info("task completed successfully")
+	s.markDelivered(ctx, task, logger)
+	s.recordTimeToSuccess(ctx, task, logger)
+	s.settle(ctx, task, entity.StateDelivered, logger)
+	return entity.OutcomeDelivered
+}
+
+// alreadyDelivered checks the completion marker. Lookup errors fail open:
+// a possible duplicate is preferred over losing the delivery.
+func (s *TaskService) alreadyDelivered(ctx context.Context, task *entity.Task, logger *zap.Logger) bool {
+	if s.completions == nil {
+		return false
+	}
+
+	done, err := s.completions.IsCompleted(ctx, task.ID)
+	if err != nil {
+		logger.Warn("failed to check completion marker", zap.Error(err))
+		return false
+	}
+	return done
+}
 
-	if err := s.deliver(ctx, task); err != nil {
-		logger.Warn("delivery failed", zap.Error(err))
-		s.handleFailure(ctx, task, logger)
+func (s *TaskService) markDelivered(ctx context.Context, task *entity.Task, logger *zap.Logger) {
+	if s.completions == nil {
 		return
 	}
 
-	logger.Info("task completed successfully")
+	if err := s.completions.MarkCompleted(ctx, task.ID, s.completionTTL); err != nil {
+		logger.Error("failed to record completion marker", zap.Error(err))
+	}
+}
+
+// settle runs the bookkeeping shared by every terminal state.
+func (s *TaskService) settle(ctx context.Context, task *entity.Task, state entity.TaskState, logger *zap.Logger) {
+	s.notifyOutcome(ctx, task, state, logger)
+	s.releaseOrdering(ctx, task, logger)
+}
+
+// releaseOrdering hands the ordering key to the next waiting task, honouring
+// the initial delay it asked for when it was created.
+func (s *TaskService) releaseOrdering(ctx context.Context, task *entity.Task, logger *zap.Logger) {
+	if task.OrderingKey == "" || s.ordering == nil {
+		return
+	}
+
+	next, err := s.ordering.Advance(ctx, task.OrderingKey)
+	if err != nil {
+		logger.Error("failed to advance ordering key",
+			zap.Error(err),
+			zap.String("ordering_key", task.OrderingKey),
+		)
+		return
+	}
+	if next == nil {
+		return
+	}
+
+	delay := next.CreatedAt.Add(next.FirstAttemptDelay()).Sub(s.now())
+	if delay < 0 {
+		delay = 0
+	}
+
+	if err := s.scheduler.Schedule(ctx, next, delay); err != nil {
+		logger.Error("failed to schedule next ordered task",
+			zap.Error(err),
+			zap.String("ordering_key", task.OrderingKey),
+			zap.String("next_task_id", next.ID),
+		)
+		return
+	}
+
+	logger.Info("next ordered task scheduled",
+		zap.String("ordering_key", task.OrderingKey),
+		zap.String("next_task_id", next.ID),
+		zap.Duration("delay", delay),
+	)
 }
 
-func (s *TaskService) deliver(ctx context.Context, task *entity.Task) error {
-	switch task.DestinationType {
-	case entity.DestinationTypeKafka, entity.DestinationTypeHTTP:
-		key := []byte(fmt.Sprintf("%s|%d", task.ID, task.Attempt))
-		value := []byte(task.MessageData)
-		return s.producer.Produce(ctx, task.Destination, key, value)
-	default:
-		return fmt.Errorf("%w: unsupported destination type %q", domain.ErrDeliveryFailed, task.DestinationType)
+func (s *TaskService) deliver(ctx context.Context, task *entity.Task) (receipt entity.DeliveryReceipt, err error) {
+	defer recoverDelivery(&err)
+
+	if err := task.DestinationType.Validate(task.Destination); err != nil {
+		return entity.DeliveryReceipt{}, fmt.Errorf("%w: %v", domain.ErrDeliveryFailed, err)
+	}
+	msg, err := s.attemptMessage(ctx, task)
+	if err != nil {
+		return entity.DeliveryReceipt{}, err
 	}
+
+	ctx, cancel := s.attemptContext(ctx)
+	defer cancel()
+	return s.produce(ctx, task.Destination, msg)
+}
+
+// attemptContext bounds one delivery, once its attempt hooks have run, by
+// the delivery timeout. It derives from the worker's context, so shutdown
+// still cancels an attempt in progress.
+func (s *TaskService) attemptContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, s.deliveryTimeout)
 }
 
-func (s *TaskService) handleFailure(ctx context.Context, task *entity.Task, logger *zap.Logger) {
+// handleFailure retries a task whose attempt failed, waiting at least
+// retryAfter when the destination asked for that, or dead-letters it once
+// it is out of retries.
+func (s *TaskService) handleFailure(ctx context.Context, task *entity.Task, retryAfter time.Duration, logger *zap.Logger) entity.Outcome {
 	task.IncrementAttempt()
 
 	if task.ShouldSendToDeadDestination() {
+		if s.redrive(ctx, task, logger) {
+			return entity.OutcomeRescheduled
+		}
 		logger.Error("max retries exceeded, sending to dead-letter destination",
 			zap.Int("max_retries", task.MaxRetries),
 			zap.Int("attempts", task.Attempt),
+			zap.String("dead_destination_type", string(task.DeadDestination.Type())),
 		)
-		s.sendToDeadLetter(ctx, task, logger)
-		return
+		return s.deadLetter(ctx, task, "retries exhausted", logger)
 	}
 
-	delay := task.NextRetryDelay()
+	// A destination that said when to come back is not retried sooner.
+	delay := s.throttleStorm(ctx, task, max(s.retryDelay(task), retryAfter))
 	logger.Info("scheduling retry",
 		zap.Duration("delay", delay),
+		zap.Duration("retry_after", retryAfter),
 		zap.Int("next_attempt", task.Attempt),
 		zap.String("destination_type", string(task.DestinationType)),
 		zap.String("destination_url", task.Destination.URL),
@@ -120,23 +551,159 @@ func (s *TaskService) handleFailure(ctx context.Context, task *entity.Task, logg
 
 	if err := s.scheduler.Schedule(ctx, task, delay); err != nil {
 		logger.Error("failed to reschedule task", zap.Error(err))
+		return entity.OutcomeErrored
+	}
+	return entity.OutcomeRescheduled
+}
+
+// deadLetter sends a task to its dead destination, or the dead-letter
+// store, for reason, and settles it as dead.
+func (s *TaskService) deadLetter(ctx context.Context, task *entity.Task, reason string, logger *zap.Logger) entity.Outcome {
+	s.sendToDeadLetter(ctx, task, reason, logger)
+	s.settle(ctx, task, entity.StateDead, logger)
+	s.taskEvent(ctx, EventTaskDeadLettered, task, logger)
+	return entity.OutcomeDead
+}
+
+// retryDelay picks the delay before the task's next attempt, applying the
+// task's jitter mode or else the deployment's, and records it on the task
+// for decorrelated jitter to grow from.
+func (s *TaskService) retryDelay(task *entity.Task) time.Duration {
+	mode := task.Jitter
+	if mode == "" {
+		mode = s.jitter
+	}
+	delay := task.RetryDelay(mode, s.random())
+	task.LastRetryDelay = delay
+	return delay
+}
+
+func (s *TaskService) quarantineTask(ctx context.Context, task *entity.Task, logger *zap.Logger) entity.Outcome {
+	reason := fmt.Sprintf("%v: failed %d times in a row with: %s",
+		domain.ErrPoisonMessage, task.RepeatedFailures, task.LastError)
+
+	logger.Warn("poison message detected, moving task to quarantine",
+		zap.Int("repeated_failures", task.RepeatedFailures),
+		zap.String("last_error", task.LastError),
+	)
+
+	if err := s.quarantine.Quarantine(ctx, task, reason); err != nil {
+		// Never lose the task: fall back to the regular retry path.
+		logger.Error("failed to quarantine task", zap.Error(err))
+		return s.handleFailure(ctx, task, 0, logger)
 	}
+
+	s.settle(ctx, task, entity.StateQuarantined, logger)
+	s.taskEvent(ctx, EventTaskQuarantined, task, logger)
+	return entity.OutcomeQuarantined
 }
 
-func (s *TaskService) sendToDeadLetter(ctx context.Context, task *entity.Task, logger *zap.Logger) {
-	if task.DeadDestination.Topic == "" && task.DeadDestination.URL == "" {
-		logger.Warn("no dead-letter destination configured, dropping task")
+func (s *TaskService) sendToDeadLetter(ctx context.Context, task *entity.Task, reason string, logger *zap.Logger) {
+	stored := false
+	if s.deadLetterMode.Stores() {
+		stored = s.storeDeadLetter(ctx, task, reason, logger)
+		if s.deadLetterMode == entity.DeadLetterStoreOnly {
+			return
+		}
+	}
+
+	// Routing is decided per destination, so a Kafka task may dead-letter to
+	// an HTTP endpoint and vice versa.
+	if task.DeadDestination.Type() == "" {
+		if !stored {
+			logger.Warn("no dead-letter destination configured, dropping task")
+		}
 		return
 	}
 
 	key := []byte(fmt.Sprintf("%s|dead|%d", task.ID, task.Attempt))
-	value := []byte(task.MessageData)
+	value, err := newDeadLetterMessage(task, s.redaction).marshal()
+	if err != nil {
+		logger.Error("failed to build dead-letter message", zap.Error(err))
+		if !stored {
+			s.storeDeadLetter(ctx, task, fmt.Sprintf("dead-letter delivery failed: %v", err), logger)
+		}
+		return
+	}
 
-	if err := s.producer.Produce(ctx, task.DeadDestination, key, value); err != nil {
-		logger.Error("failed to send to dead-letter destination", zap.Error(err))
+	msg := secondary.Message{
+		Key:              key,
+		Value:            value,
+		Headers:          task.Metadata,
+		TransportHeaders: correlationHeaders(task),
+		Priority:         task.IsPriority,
+	}
+	chain := append([]entity.Destination{task.DeadDestination}, task.FallbackDeadDestinations...)
+	for i, dest := range chain {
+		err = s.produceDeadLetter(ctx, dest, msg, logger)
+		if err == nil {
+			if i > 0 {
+				logger.Info("task sent to fallback dead-letter destination",
+					zap.Int("fallback", i),
+					zap.String("dead_destination", dest.Name()),
+				)
+			}
+			return
+		}
+		logger.Error("failed to send to dead-letter destination",
+			zap.Error(err),
+			zap.String("dead_destination", dest.Name()),
+		)
+	}
+	if !stored {
+		s.storeDeadLetter(ctx, task, fmt.Sprintf("dead-letter delivery failed: %v", err), logger)
 	}
 }
 
+// produceDeadLetter attempts the dead-letter produce up to MaxAttempts times,
+// doubling the wait between attempts.
+func (s *TaskService) produceDeadLetter(ctx context.Context, dest entity.Destination, msg secondary.Message, logger *zap.Logger) error {
+	backoff := s.deadLetterPolicy.Backoff
+
+	var err error
+	for attempt := 1; attempt <= s.deadLetterPolicy.MaxAttempts; attempt++ {
+		if err = s.producer.Produce(ctx, dest, msg); err == nil {
+			return nil
+		}
+		if attempt == s.deadLetterPolicy.MaxAttempts {
+			break
+		}
+
+		logger.Warn("dead-letter delivery failed, retrying",
+			zap.Error(err),
+			zap.Int("dead_letter_attempt", attempt),
+			zap.Duration("backoff", backoff),
+		)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+
+	return err
+}
+
+// storeDeadLetter persists an exhausted task so it can be inspected and
+// replayed, and reports whether it was stored. It is the last resort when
+// dead-letter delivery fails, and the first stop in the storing modes.
+func (s *TaskService) storeDeadLetter(ctx context.Context, task *entity.Task, reason string, logger *zap.Logger) bool {
+	if s.deadLetterStore == nil {
+		logger.Error("no dead-letter store configured, dropping task")
+		return false
+	}
+
+	if err := s.deadLetterStore.Store(ctx, task, reason); err != nil {
+		logger.Error("failed to store task in dead-letter store", zap.Error(err))
+		return false
+	}
+
+	logger.Info("task stored in dead-letter store", zap.String("reason", reason))
+	return true
+}
+
 func (s *TaskService) validateTask(task *entity.Task) error {
 	if task.ID == "" {
 		return fmt.Errorf("task ID is required")
@@ -147,17 +714,151 @@ func (s *TaskService) validateTask(task *entity.Task) error {
 	if task.DestinationType == "" {
 		return fmt.Errorf("destination type is required")
 	}
-	if task.DestinationType == entity.DestinationTypeKafka && task.Destination.Topic == "" {
-		return fmt.Errorf("destination topic is required")
+	if err := task.DestinationType.Validate(task.Destination); err != nil {
+		return err
+	}
+	if err := task.Destination.ValidateAddress(); err != nil {
+		return withinField("destination", err)
+	}
+	if task.DeadDestination != (entity.Destination{}) && task.DeadDestination.Type() == "" {
+		return fmt.Errorf("dead destination requires a topic or URL")
+	}
+	if err := task.DeadDestination.ValidateAddress(); err != nil {
+		return withinField("dead_destination", err)
+	}
+	if len(task.FallbackDeadDestinations) > 0 && task.DeadDestination.Type() == "" {
+		return fmt.Errorf("fallback dead destinations require a dead destination")
+	}
+	if len(task.FallbackDeadDestinations) > domain.MaxFallbackDeadDestinations {
+		return fmt.Errorf("at most %d fallback dead destinations are allowed", domain.MaxFallbackDeadDestinations)
+	}
+	for i, dest := range task.FallbackDeadDestinations {
+		if dest.Type() == "" {
+			return fmt.Errorf("fallback dead destination %d requires a topic or URL", i+1)
+		}
+		if err := dest.ValidateAddress(); err != nil {
+			return withinField(fmt.Sprintf("fallback_dead_destinations[%d]", i), err)
+		}
+	}
+	if len(task.FanOutDestinations) > domain.MaxFanOutDestinations {
+		return fmt.Errorf("at most %d fan-out destinations are allowed", domain.MaxFanOutDestinations)
+	}
+	for i, dest := range task.FanOutDestinations {
+		if dest.Type() == "" {
+			return fmt.Errorf("fan-out destination %d requires a topic or URL", i+1)
+		}
+		if err := dest.ValidateAddress(); err != nil {
+			return withinField(fmt.Sprintf("fan_out_destinations[%d]", i), err)
+		}
+	}
+	if err := validateCallbackURL(task.CallbackURL); err != nil {
+		return err
+	}
+	if len(task.FanOutDestinations) > 0 && task.OrderingKey != "" {
+		return fmt.Errorf("ordering_key cannot be combined with fan-out destinations")
+	}
+	if task.OrderingKey != "" && s.ordering == nil {
+		return fmt.Errorf("ordering_key is not supported by this deployment")
+	}
+	if _, ok := s.queues[task.Queue]; task.Queue != "" && !ok {
+		return fmt.Errorf("queue %q is not configured", task.Queue)
+	}
+	if err := validateMetadata(task.Metadata); err != nil {
+		return err
 	}
-	if task.DestinationType == entity.DestinationTypeHTTP && task.Destination.URL == "" {
-		return fmt.Errorf("destination URL is required")
+	if !task.Jitter.Valid() {
+		return fmt.Errorf("jitter must be one of none, full, equal or decorrelated")
 	}
-	if task.MaxRetries < 0 || task.MaxRetries > domain.MaxRetryLimit {
-		return fmt.Errorf("max_retries must be between 0 and %d", domain.MaxRetryLimit)
+	if err := s.validateRetrySchedule(task.RetryDelays); err != nil {
+		return err
 	}
-	if task.BaseDelay < domain.MinBaseDelay || task.BaseDelay > domain.MaxBaseDelay {
-		return fmt.Errorf("base_delay must be between %d and %d", domain.MinBaseDelay, domain.MaxBaseDelay)
+	if len(task.RetryDelays) == 0 && (task.MaxRetries < 0 || task.MaxRetries > s.bounds.MaxRetryLimit) {
+		return fmt.Errorf("max_retries must be between 0 and %d", s.bounds.MaxRetryLimit)
+	}
+	// With a retry schedule BaseDelay only delays the first attempt, and
+	// may be left out to attempt it at once.
+	if (len(task.RetryDelays) == 0 || task.BaseDelay != 0) &&
+		(task.BaseDelay < s.bounds.MinBaseDelay || task.BaseDelay > s.bounds.MaxBaseDelay) {
+		return fmt.Errorf("base_delay must be between %s and %s", s.bounds.MinBaseDelay, s.bounds.MaxBaseDelay)
+	}
+	if task.MaxDelay < 0 || (task.MaxDelay > 0 && task.MaxDelay < task.BaseDelay) {
+		return fmt.Errorf("max_delay must not be below base_delay")
+	}
+	for _, validate := range s.validators {
+		if err := validate(task); err != nil {
+			return err
+		}
 	}
 	return nil
 }
+
+// validateRetrySchedule applies the retry bounds to an explicit schedule:
+// no more retries than MaxRetryLimit, none sooner than MinBaseDelay.
+func (s *TaskService) validateRetrySchedule(delays []time.Duration) error {
+	if len(delays) > s.bounds.MaxRetryLimit {
+		return fmt.Errorf("retry_schedule may have at most %d delays", s.bounds.MaxRetryLimit)
+	}
+	for i, d := range delays {
+		if d < s.bounds.MinBaseDelay {
+			return fmt.Errorf("retry_schedule delay %d must be at least %s", i+1, s.bounds.MinBaseDelay)
+		}
+	}
+	return nil
+}
+
+// withinField nests a field error of a destination under the destination's
+// own field in the task API. Other errors are returned as they are.
+func withinField(parent string, err error) error {
+	var fieldErr *entity.FieldError
+	if errors.As(err, &fieldErr) {
+		return fieldErr.Within(parent)
+	}
+	return err
+}
+
+// validateCallbackURL applies the HTTP destination address rules to a
+// task's outcome callback, reporting failures against callback_url.
+func validateCallbackURL(callbackURL string) error {
+	if callbackURL == "" {
+		return nil
+	}
+	err := entity.Destination{URL: callbackURL}.ValidateAddress()
+	var fieldErr *entity.FieldError
+	if errors.As(err, &fieldErr) {
+		return &entity.FieldError{Field: "callback_url", Err: fieldErr.Err}
+	}
+	return err
+}
+
+// validateMetadata bounds metadata and requires keys usable as HTTP and
+// Kafka header names.
+func validateMetadata(metadata map[string]string) error {
+	if len(metadata) > domain.MaxMetadataEntries {
+		return fmt.Errorf("metadata may have at most %d entries", domain.MaxMetadataEntries)
+	}
+	size := 0
+	for k, v := range metadata {
+		if !validMetadataKey(k) {
+			return fmt.Errorf("metadata key %q must be letters, digits, '-', '_' or '.'", k)
+		}
+		size += len(k) + len(v)
+	}
+	if size > domain.MaxMetadataSize {
+		return fmt.Errorf("metadata may be at most %d bytes", domain.MaxMetadataSize)
+	}
+	return nil
+}
+
+func validMetadataKey(k string) bool {
+	if k == "" {
+		return false
+	}
+	for _, c := range k {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '-', c == '_', c == '.':
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -2,7 +2,10 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -10,6 +13,7 @@ import (
 
 	"github.com/ruudy-sib/rebound/internal/domain"
 	"github.com/ruudy-sib/rebound/internal/domain/entity"
+	"github.com/ruudy-sib/rebound/internal/domain/valueobject"
 )
 
 func TestTaskService_CreateTask(t *testing.T) {
@@ -67,6 +71,26 @@ func TestTaskService_CreateTask(t *testing.T) {
 			wantErr:       domain.ErrInvalidTask,
 			wantScheduled: false,
 		},
+		{
+			name: "unsupported destination type returns validation error",
+			task: func() *entity.Task {
+				t := testTask()
+				t.DestinationType = "sqs"
+				return t
+			}(),
+			wantErr:       domain.ErrInvalidTask,
+			wantScheduled: false,
+		},
+		{
+			name: "http destination type without URL returns validation error",
+			task: func() *entity.Task {
+				t := testTask()
+				t.DestinationType = entity.DestinationTypeHTTP
+				return t
+			}(),
+			wantErr:       domain.ErrInvalidTask,
+			wantScheduled: false,
+		},
 		{
 			name: "base delay below minimum returns validation error",
 			task: func() *entity.Task {
@@ -81,7 +105,7 @@ func TestTaskService_CreateTask(t *testing.T) {
 			name: "base delay above maximum returns validation error",
 			task: func() *entity.Task {
 				t := testTask()
-				t.BaseDelay = 9999
+				t.BaseDelay = 9999 * time.Second
 				return t
 			}(),
 			wantErr:       domain.ErrInvalidTask,
@@ -97,6 +121,69 @@ func TestTaskService_CreateTask(t *testing.T) {
 			wantErr:       domain.ErrInvalidTask,
 			wantScheduled: false,
 		},
+		{
+			name: "max delay below base delay returns validation error",
+			task: func() *entity.Task {
+				t := testTask()
+				t.MaxDelay = t.BaseDelay / 2
+				return t
+			}(),
+			wantErr:       domain.ErrInvalidTask,
+			wantScheduled: false,
+		},
+		{
+			name: "unknown jitter mode returns validation error",
+			task: func() *entity.Task {
+				t := testTask()
+				t.Jitter = "random"
+				return t
+			}(),
+			wantErr:       domain.ErrInvalidTask,
+			wantScheduled: false,
+		},
+		{
+			name: "metadata is accepted",
+			task: func() *entity.Task {
+				t := testTask()
+				t.Metadata = map[string]string{"tenant-id": "acme", "trace.id": "abc"}
+				return t
+			}(),
+			wantErr:       nil,
+			wantScheduled: true,
+		},
+		{
+			name: "invalid metadata key returns validation error",
+			task: func() *entity.Task {
+				t := testTask()
+				t.Metadata = map[string]string{"bad key": "x"}
+				return t
+			}(),
+			wantErr:       domain.ErrInvalidTask,
+			wantScheduled: false,
+		},
+		{
+			name: "too many metadata entries returns validation error",
+			task: func() *entity.Task {
+				t := testTask()
+				t.Metadata = make(map[string]string)
+				for i := range domain.MaxMetadataEntries + 1 {
+					t.Metadata[fmt.Sprintf("k%d", i)] = "v"
+				}
+				return t
+			}(),
+			wantErr:       domain.ErrInvalidTask,
+			wantScheduled: false,
+		},
+		{
+			name: "oversized metadata returns validation error",
+			task: func() *entity.Task {
+				t := testTask()
+				t.Metadata = map[string]string{"blob": strings.Repeat("x", domain.MaxMetadataSize)}
+				return t
+			}(),
+			wantErr:       domain.ErrInvalidTask,
+			wantScheduled: false,
+		},
 		{
 			name:          "valid http task is scheduled",
 			task:          testHTTPTask(),
@@ -130,6 +217,47 @@ func TestTaskService_CreateTask(t *testing.T) {
 			wantErr:       domain.ErrScheduleFailed,
 			wantScheduled: false,
 		},
+		{
+			name: "dead destination without topic or url returns validation error",
+			task: func() *entity.Task {
+				t := testTask()
+				t.DeadDestination = entity.Destination{Host: "localhost", Port: "9092"}
+				return t
+			}(),
+			wantErr:       domain.ErrInvalidTask,
+			wantScheduled: false,
+		},
+		{
+			name: "kafka task with http dead destination is scheduled",
+			task: func() *entity.Task {
+				t := testTask()
+				t.DeadDestination = entity.Destination{URL: "http://localhost:8090/dead"}
+				return t
+			}(),
+			wantErr:       nil,
+			wantScheduled: true,
+		},
+		{
+			name: "fallback dead destination without topic or url returns validation error",
+			task: func() *entity.Task {
+				t := testTask()
+				t.FallbackDeadDestinations = []entity.Destination{{Host: "localhost", Port: "9092"}}
+				return t
+			}(),
+			wantErr:       domain.ErrInvalidTask,
+			wantScheduled: false,
+		},
+		{
+			name: "fallback dead destinations without dead destination returns validation error",
+			task: func() *entity.Task {
+				t := testTask()
+				t.DeadDestination = entity.Destination{}
+				t.FallbackDeadDestinations = []entity.Destination{{URL: "http://localhost:8090/audit"}}
+				return t
+			}(),
+			wantErr:       domain.ErrInvalidTask,
+			wantScheduled: false,
+		},
 		{
 			name: "attempt is reset to 0",
 			task: func() *entity.Task {
@@ -179,7 +307,7 @@ func TestTaskService_CreateTask(t *testing.T) {
 			}
 
 			if tt.wantScheduled {
-				wantDelay := time.Duration(tt.task.BaseDelay) * time.Second
+				wantDelay := tt.task.BaseDelay
 				gotDelay := scheduler.scheduledTasks[0].Delay
 				if gotDelay != wantDelay {
 					t.Fatalf("expected initial delay %v, got %v", wantDelay, gotDelay)
@@ -248,7 +376,7 @@ func TestTaskService_ProcessDueTasks(t *testing.T) {
 			logger := zap.NewNop()
 
 			svc := NewTaskService(scheduler, producer, logger)
-			err := svc.ProcessDueTasks(context.Background())
+			_, err := svc.ProcessDueTasks(context.Background())
 
 			if tt.wantErr {
 				if err == nil {
@@ -290,7 +418,7 @@ func TestTaskService_ProcessDueTasks_retryScheduling(t *testing.T) {
 	logger := zap.NewNop()
 
 	svc := NewTaskService(scheduler, producer, logger)
-	err := svc.ProcessDueTasks(context.Background())
+	_, err := svc.ProcessDueTasks(context.Background())
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -309,6 +437,104 @@ func TestTaskService_ProcessDueTasks_retryScheduling(t *testing.T) {
 	}
 }
 
+func TestTaskService_ProcessDueTasks_retryJitter(t *testing.T) {
+	tests := []struct {
+		name       string
+		deployment entity.JitterMode
+		task       entity.JitterMode
+		want       time.Duration
+	}{
+		{name: "no jitter by default", want: 8 * time.Second},
+		{name: "deployment mode applies", deployment: entity.JitterFull, want: 2 * time.Second},
+		{name: "task mode overrides the deployment", deployment: entity.JitterFull, task: entity.JitterEqual, want: 5 * time.Second},
+		{name: "task can opt out", deployment: entity.JitterFull, task: entity.JitterNone, want: 8 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := testTask()
+			task.Attempt = 2
+			task.BaseDelay = 2 * time.Second
+			task.Jitter = tt.task
+
+			scheduler := &mockScheduler{
+				fetchDueFunc: func(_ context.Context, _ int) ([]*entity.Task, error) {
+					return []*entity.Task{task}, nil
+				},
+			}
+			producer := &mockProducer{
+				produceFunc: func(_ context.Context, _ entity.Destination, _, _ []byte) error {
+					return errors.New("kafka down")
+				},
+			}
+
+			svc := NewTaskService(scheduler, producer, zap.NewNop(), WithJitter(tt.deployment))
+			svc.random = func() float64 { return 0.25 }
+			if _, err := svc.ProcessDueTasks(context.Background()); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(scheduler.scheduledTasks) != 1 {
+				t.Fatalf("expected 1 scheduled retry, got %d", len(scheduler.scheduledTasks))
+			}
+			rescheduled := scheduler.scheduledTasks[0]
+			if rescheduled.Delay != tt.want {
+				t.Fatalf("expected delay %v, got %v", tt.want, rescheduled.Delay)
+			}
+			if rescheduled.Task.LastRetryDelay != tt.want {
+				t.Fatalf("expected last retry delay %v, got %v", tt.want, rescheduled.Task.LastRetryDelay)
+			}
+		})
+	}
+}
+
+func TestTaskService_ProcessDueTasks_retryAfter(t *testing.T) {
+	tests := []struct {
+		name       string
+		retryAfter time.Duration
+		want       time.Duration
+	}{
+		{name: "no hint uses backoff", want: 4 * time.Second},
+		{name: "shorter hint keeps backoff", retryAfter: time.Second, want: 4 * time.Second},
+		{name: "longer hint wins", retryAfter: time.Minute, want: time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := testTask()
+			task.Attempt = 1
+			task.BaseDelay = 2 * time.Second
+
+			scheduler := &mockScheduler{
+				fetchDueFunc: func(_ context.Context, _ int) ([]*entity.Task, error) {
+					return []*entity.Task{task}, nil
+				},
+			}
+			producer := &mockProducer{
+				produceFunc: func(_ context.Context, _ entity.Destination, _, _ []byte) error {
+					return fmt.Errorf("producing: %w", &entity.DeliveryError{
+						Code:       entity.ErrorCodeHTTP5xx,
+						Err:        errors.New("http request failed with status 503"),
+						RetryAfter: tt.retryAfter,
+					})
+				},
+			}
+
+			svc := NewTaskService(scheduler, producer, zap.NewNop())
+			if _, err := svc.ProcessDueTasks(context.Background()); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(scheduler.scheduledTasks) != 1 {
+				t.Fatalf("expected 1 scheduled retry, got %d", len(scheduler.scheduledTasks))
+			}
+			if delay := scheduler.scheduledTasks[0].Delay; delay != tt.want {
+				t.Fatalf("expected delay %v, got %v", tt.want, delay)
+			}
+		})
+	}
+}
+
 func TestTaskService_ProcessDueTasks_deadLetterOnExhaustedRetries(t *testing.T) {
 	task := testTask()
 	task.Attempt = 3
@@ -334,7 +560,7 @@ func TestTaskService_ProcessDueTasks_deadLetterOnExhaustedRetries(t *testing.T)
 	logger := zap.NewNop()
 
 	svc := NewTaskService(scheduler, producer, logger)
-	err := svc.ProcessDueTasks(context.Background())
+	_, err := svc.ProcessDueTasks(context.Background())
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -366,6 +592,164 @@ func TestTaskService_ProcessDueTasks_deadLetterOnExhaustedRetries(t *testing.T)
 	}
 }
 
+func TestTaskService_ProcessDueTasks_errorClassifier(t *testing.T) {
+	errBadRequest := &entity.DeliveryError{Code: entity.ErrorCodeHTTP4xx, Err: errors.New("http request failed with status 400")}
+	classify := func(err error) Verdict {
+		var deliveryErr *entity.DeliveryError
+		if errors.As(err, &deliveryErr) && deliveryErr.Code == entity.ErrorCodeHTTP4xx {
+			return VerdictPermanent
+		}
+		return VerdictRetry
+	}
+
+	tests := []struct {
+		name     string
+		err      error
+		wantDead bool
+	}{
+		{name: "permanent error dead-letters at once", err: fmt.Errorf("producing: %w", errBadRequest), wantDead: true},
+		{name: "other errors are retried", err: errors.New("kafka down")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := testTask()
+			task.MaxRetries = 5
+
+			scheduler := &mockScheduler{
+				fetchDueFunc: func(_ context.Context, _ int) ([]*entity.Task, error) {
+					return []*entity.Task{task}, nil
+				},
+			}
+			producer := &mockProducer{
+				produceFunc: func(_ context.Context, dest entity.Destination, _, _ []byte) error {
+					if dest.Topic == "dead-topic" {
+						return nil
+					}
+					return tt.err
+				},
+			}
+
+			// A re-drive policy would otherwise take the task back.
+			svc := NewTaskService(scheduler, producer, zap.NewNop(),
+				WithErrorClassifier(classify),
+				WithRedrivePolicies(map[string]RedrivePolicy{"dead-topic": {MaxRedrives: 3, MaxRetries: 5}}),
+			)
+			result, err := svc.ProcessDueTasks(context.Background())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			wantOutcome := entity.OutcomeRescheduled
+			if tt.wantDead {
+				wantOutcome = entity.OutcomeDead
+			}
+			if got := result.Tasks[0].Outcome; got != wantOutcome {
+				t.Fatalf("expected outcome %s, got %s", wantOutcome, got)
+			}
+			deadLettered := len(producer.produceCalls) == 2 && producer.produceCalls[1].Destination.Topic == "dead-topic"
+			if deadLettered != tt.wantDead {
+				t.Fatalf("expected dead-lettered %v, got produce calls %+v", tt.wantDead, producer.produceCalls)
+			}
+			if rescheduled := len(scheduler.scheduledTasks) == 1; rescheduled == tt.wantDead {
+				t.Fatalf("expected rescheduled %v, got %d scheduled", !tt.wantDead, len(scheduler.scheduledTasks))
+			}
+		})
+	}
+}
+
+func TestTaskService_ProcessDueTasks_retryableStatuses(t *testing.T) {
+	statuses, err := valueobject.NewRetryableStatuses([]string{"408", "429", "5xx"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		err      error
+		wantDead bool
+	}{
+		{name: "retryable status", err: &entity.StatusError{StatusCode: 503}},
+		{name: "listed 4xx status", err: &entity.StatusError{StatusCode: 429}},
+		{name: "other status", err: &entity.StatusError{StatusCode: 400}, wantDead: true},
+		{name: "no status", err: errors.New("connection reset")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := testTask()
+			task.MaxRetries = 5
+
+			scheduler := &mockScheduler{
+				fetchDueFunc: func(_ context.Context, _ int) ([]*entity.Task, error) {
+					return []*entity.Task{task}, nil
+				},
+			}
+			producer := &mockProducer{
+				produceFunc: func(_ context.Context, dest entity.Destination, _, _ []byte) error {
+					if dest.Topic == "dead-topic" {
+						return nil
+					}
+					return &entity.DeliveryError{Code: entity.ErrorCodeUnknown, Err: tt.err}
+				},
+			}
+
+			svc := NewTaskService(scheduler, producer, zap.NewNop(), WithRetryableStatuses(statuses))
+			result, err := svc.ProcessDueTasks(context.Background())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			wantOutcome := entity.OutcomeRescheduled
+			if tt.wantDead {
+				wantOutcome = entity.OutcomeDead
+			}
+			if got := result.Tasks[0].Outcome; got != wantOutcome {
+				t.Fatalf("expected outcome %s, got %s", wantOutcome, got)
+			}
+		})
+	}
+}
+
+func TestTaskService_ProcessDueTasks_priorityInherited(t *testing.T) {
+	for _, priority := range []bool{false, true} {
+		task := testTask()
+		task.Attempt = 3
+		task.MaxRetries = 3
+		task.IsPriority = priority
+		task.CallbackURL = "https://origin.example.com/outcome"
+
+		scheduler := &mockScheduler{
+			fetchDueFunc: func(_ context.Context, _ int) ([]*entity.Task, error) {
+				return []*entity.Task{task}, nil
+			},
+		}
+		producer := &mockProducer{
+			produceFunc: func(_ context.Context, dest entity.Destination, _, _ []byte) error {
+				if dest.Topic == "my-topic" {
+					return errors.New("kafka down")
+				}
+				return nil
+			},
+		}
+
+		svc := NewTaskService(scheduler, producer, zap.NewNop())
+		if _, err := svc.ProcessDueTasks(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		// The attempt, the dead letter and the outcome callback.
+		if len(producer.produceCalls) != 3 {
+			t.Fatalf("expected 3 produce calls, got %+v", producer.produceCalls)
+		}
+		for _, call := range producer.produceCalls {
+			if call.Priority != priority {
+				t.Fatalf("expected priority %v for %s, got %v", priority, call.Destination.Name(), call.Priority)
+			}
+		}
+	}
+}
+
 func TestTaskService_ProcessDueTasks_noDeadLetterWhenNotConfigured(t *testing.T) {
 	task := testTask()
 	task.Attempt = 3
@@ -385,7 +769,7 @@ func TestTaskService_ProcessDueTasks_noDeadLetterWhenNotConfigured(t *testing.T)
 	logger := zap.NewNop()
 
 	svc := NewTaskService(scheduler, producer, logger)
-	err := svc.ProcessDueTasks(context.Background())
+	_, err := svc.ProcessDueTasks(context.Background())
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -415,8 +799,8 @@ func TestTaskService_ProcessDueTasks_httpDelivery(t *testing.T) {
 			wantDestinationURL:  "http://localhost:8090/webhook",
 		},
 		{
-			name: "http task delivery failure triggers retry scheduling",
-			task: testHTTPTask(),
+			name:                "http task delivery failure triggers retry scheduling",
+			task:                testHTTPTask(),
 			produceErr:          errors.New("connection refused"),
 			wantSuccessProduced: 0,
 			wantTotalProduced:   1,
@@ -439,7 +823,7 @@ func TestTaskService_ProcessDueTasks_httpDelivery(t *testing.T) {
 			}
 
 			svc := NewTaskService(scheduler, producer, zap.NewNop())
-			if err := svc.ProcessDueTasks(context.Background()); err != nil {
+			if _, err := svc.ProcessDueTasks(context.Background()); err != nil {
 				t.Fatalf("unexpected error: %v", err)
 			}
 
@@ -481,7 +865,7 @@ func TestTaskService_ProcessDueTasks_httpDeadLetter(t *testing.T) {
 	}
 
 	svc := NewTaskService(scheduler, producer, zap.NewNop())
-	if err := svc.ProcessDueTasks(context.Background()); err != nil {
+	if _, err := svc.ProcessDueTasks(context.Background()); err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
@@ -518,7 +902,7 @@ func TestTaskService_ProcessDueTasks_httpNoDeadLetterWhenNotConfigured(t *testin
 	}
 
 	svc := NewTaskService(scheduler, producer, zap.NewNop())
-	if err := svc.ProcessDueTasks(context.Background()); err != nil {
+	if _, err := svc.ProcessDueTasks(context.Background()); err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
@@ -527,3 +911,1347 @@ func TestTaskService_ProcessDueTasks_httpNoDeadLetterWhenNotConfigured(t *testin
 		t.Fatalf("expected 1 produce call, got %d", len(producer.produceCalls))
 	}
 }
+
+func TestTaskService_ProcessDueTasks_poisonQuarantine(t *testing.T) {
+	tests := []struct {
+		name            string
+		threshold       int
+		repeated        int
+		quarantineErr   error
+		wantQuarantined int
+		wantScheduled   int
+	}{
+		{
+			name:            "detection disabled keeps retrying",
+			threshold:       0,
+			repeated:        5,
+			wantQuarantined: 0,
+			wantScheduled:   1,
+		},
+		{
+			name:            "streak below threshold keeps retrying",
+			threshold:       3,
+			repeated:        1,
+			wantQuarantined: 0,
+			wantScheduled:   1,
+		},
+		{
+			name:            "streak reaching threshold quarantines task",
+			threshold:       3,
+			repeated:        2,
+			wantQuarantined: 1,
+			wantScheduled:   0,
+		},
+		{
+			name:            "quarantine failure falls back to retry",
+			threshold:       3,
+			repeated:        2,
+			quarantineErr:   errors.New("redis down"),
+			wantQuarantined: 0,
+			wantScheduled:   1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := testHTTPTask()
+			task.Attempt = 1
+			task.LastError = "http request failed with status 400: bad payload"
+			task.RepeatedFailures = tt.repeated
+
+			scheduler := &mockScheduler{
+				fetchDueFunc: func(_ context.Context, _ int) ([]*entity.Task, error) {
+					return []*entity.Task{task}, nil
+				},
+			}
+			producer := &mockProducer{
+				produceFunc: func(_ context.Context, _ entity.Destination, _, _ []byte) error {
+					return errors.New("http request failed with status 400: bad payload")
+				},
+			}
+			quarantine := &mockQuarantine{}
+			if tt.quarantineErr != nil {
+				quarantine.quarantineFunc = func(_ context.Context, _ *entity.Task, _ string) error {
+					return tt.quarantineErr
+				}
+			}
+
+			svc := NewTaskService(scheduler, producer, zap.NewNop(),
+				WithQuarantine(quarantine, PoisonPolicy{Threshold: tt.threshold, FailureWindow: time.Minute}),
+			)
+			if _, err := svc.ProcessDueTasks(context.Background()); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(quarantine.quarantined) != tt.wantQuarantined {
+				t.Fatalf("expected %d quarantined tasks, got %d", tt.wantQuarantined, len(quarantine.quarantined))
+			}
+			if len(scheduler.scheduledTasks) != tt.wantScheduled {
+				t.Fatalf("expected %d scheduled retries, got %d", tt.wantScheduled, len(scheduler.scheduledTasks))
+			}
+		})
+	}
+}
+
+func TestTaskService_ProcessDueTasks_deadLetterMetadata(t *testing.T) {
+	firstAttempt := time.Now().Add(-time.Hour).UTC()
+
+	task := testHTTPTask()
+	task.Attempt = 3
+	task.MaxRetries = 3
+	task.FirstAttemptAt = firstAttempt
+
+	callCount := 0
+	producer := &mockProducer{
+		produceFunc: func(_ context.Context, _ entity.Destination, _, _ []byte) error {
+			callCount++
+			if callCount == 1 {
+				return errors.New("endpoint down")
+			}
+			return nil
+		},
+	}
+	scheduler := &mockScheduler{
+		fetchDueFunc: func(_ context.Context, _ int) ([]*entity.Task, error) {
+			return []*entity.Task{task}, nil
+		},
+	}
+
+	svc := NewTaskService(scheduler, producer, zap.NewNop())
+	if _, err := svc.ProcessDueTasks(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(producer.produceCalls) != 2 {
+		t.Fatalf("expected 2 produce calls, got %d", len(producer.produceCalls))
+	}
+
+	var msg deadLetterMessage
+	if err := json.Unmarshal(producer.produceCalls[1].Value, &msg); err != nil {
+		t.Fatalf("dead-letter payload is not valid JSON: %v", err)
+	}
+
+	if msg.TaskID != task.ID {
+		t.Fatalf("expected task_id %q, got %q", task.ID, msg.TaskID)
+	}
+	if msg.Attempts != 4 {
+		t.Fatalf("expected 4 attempts, got %d", msg.Attempts)
+	}
+	if !msg.FirstAttemptAt.Equal(firstAttempt) {
+		t.Fatalf("expected first_attempt_at %v, got %v", firstAttempt, msg.FirstAttemptAt)
+	}
+	if msg.LastAttemptAt.Before(msg.FirstAttemptAt) {
+		t.Fatalf("expected last_attempt_at after first_attempt_at, got %v", msg.LastAttemptAt)
+	}
+	if msg.LastError != "endpoint down" {
+		t.Fatalf("expected last_error %q, got %q", "endpoint down", msg.LastError)
+	}
+	if msg.Destination.URL != "http://localhost:8090/webhook" || msg.Destination.Type != "http" {
+		t.Fatalf("unexpected destination: %+v", msg.Destination)
+	}
+	if msg.MessageData != task.MessageData {
+		t.Fatalf("expected original message_data, got %q", msg.MessageData)
+	}
+}
+
+func TestTaskService_ProcessDueTasks_correlationID(t *testing.T) {
+	task := testHTTPTask()
+	task.Attempt = 3
+	task.MaxRetries = 3
+	task.CorrelationID = "txn-42"
+
+	producer := &mockProducer{
+		produceFunc: func(_ context.Context, dest entity.Destination, _, _ []byte) error {
+			if dest.URL == task.Destination.URL {
+				return errors.New("endpoint down")
+			}
+			return nil
+		},
+	}
+	scheduler := &mockScheduler{
+		fetchDueFunc: func(_ context.Context, _ int) ([]*entity.Task, error) {
+			return []*entity.Task{task}, nil
+		},
+	}
+
+	svc := NewTaskService(scheduler, producer, zap.NewNop())
+	if _, err := svc.ProcessDueTasks(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(producer.produceCalls) != 2 {
+		t.Fatalf("expected attempt and dead-letter produce calls, got %d", len(producer.produceCalls))
+	}
+	for i, call := range producer.produceCalls {
+		if got := call.Transport[domain.CorrelationIDHeader]; got != "txn-42" {
+			t.Fatalf("call %d: expected correlation header %q, got %q", i, "txn-42", got)
+		}
+	}
+
+	var msg deadLetterMessage
+	if err := json.Unmarshal(producer.produceCalls[1].Value, &msg); err != nil {
+		t.Fatalf("dead-letter payload is not valid JSON: %v", err)
+	}
+	if msg.CorrelationID != "txn-42" {
+		t.Fatalf("expected correlation_id %q, got %q", "txn-42", msg.CorrelationID)
+	}
+}
+
+func TestTaskService_ProcessDueTasks_deadLetterRetries(t *testing.T) {
+	tests := []struct {
+		name              string
+		deadLetterFails   int
+		wantProduceCalls  int
+		wantStoredInRedis int
+	}{
+		{
+			name:              "dead-letter succeeds after transient failures",
+			deadLetterFails:   2,
+			wantProduceCalls:  1 + 3,
+			wantStoredInRedis: 0,
+		},
+		{
+			name:              "dead-letter keeps failing and falls back to store",
+			deadLetterFails:   100,
+			wantProduceCalls:  1 + 3,
+			wantStoredInRedis: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := testTask()
+			task.Attempt = 3
+			task.MaxRetries = 3
+
+			deadLetterCalls := 0
+			producer := &mockProducer{
+				produceFunc: func(_ context.Context, dest entity.Destination, _, _ []byte) error {
+					if dest.Topic != "dead-topic" {
+						return errors.New("kafka down")
+					}
+					deadLetterCalls++
+					if deadLetterCalls <= tt.deadLetterFails {
+						return errors.New("dlq down")
+					}
+					return nil
+				},
+			}
+			scheduler := &mockScheduler{
+				fetchDueFunc: func(_ context.Context, _ int) ([]*entity.Task, error) {
+					return []*entity.Task{task}, nil
+				},
+			}
+			store := &mockDeadLetterStore{}
+
+			svc := NewTaskService(scheduler, producer, zap.NewNop(),
+				WithDeadLetterPolicy(DeadLetterPolicy{MaxAttempts: 3, Backoff: time.Millisecond}),
+				WithDeadLetterFallback(store),
+			)
+			if _, err := svc.ProcessDueTasks(context.Background()); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(producer.produceCalls) != tt.wantProduceCalls {
+				t.Fatalf("expected %d produce calls, got %d", tt.wantProduceCalls, len(producer.produceCalls))
+			}
+			if len(store.stored) != tt.wantStoredInRedis {
+				t.Fatalf("expected %d stored tasks, got %d", tt.wantStoredInRedis, len(store.stored))
+			}
+		})
+	}
+}
+
+func TestTaskService_ProcessDueTasks_deadLetterMode(t *testing.T) {
+	tests := []struct {
+		name             string
+		mode             entity.DeadLetterMode
+		noDeadDest       bool
+		dlqDown          bool
+		wantDeadProduces int
+		wantReasons      []string
+	}{
+		{name: "produce", mode: entity.DeadLetterProduce, wantDeadProduces: 1},
+		{name: "produce falls back to store", mode: entity.DeadLetterProduce, dlqDown: true, wantDeadProduces: 1, wantReasons: []string{"dead-letter delivery failed: dlq down"}},
+		{name: "store only", mode: entity.DeadLetterStoreOnly, wantReasons: []string{"retries exhausted"}},
+		{name: "store without dead destination", mode: entity.DeadLetterStoreOnly, noDeadDest: true, wantReasons: []string{"retries exhausted"}},
+		{name: "both", mode: entity.DeadLetterBoth, wantDeadProduces: 1, wantReasons: []string{"retries exhausted"}},
+		{name: "both stores once when dlq is down", mode: entity.DeadLetterBoth, dlqDown: true, wantDeadProduces: 1, wantReasons: []string{"retries exhausted"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := testTask()
+			task.Attempt = 3
+			task.MaxRetries = 3
+			if tt.noDeadDest {
+				task.DeadDestination = entity.Destination{}
+			}
+
+			deadProduces := 0
+			producer := &mockProducer{
+				produceFunc: func(_ context.Context, dest entity.Destination, _, _ []byte) error {
+					if dest.Topic != "dead-topic" {
+						return errors.New("kafka down")
+					}
+					deadProduces++
+					if tt.dlqDown {
+						return errors.New("dlq down")
+					}
+					return nil
+				},
+			}
+			scheduler := &mockScheduler{
+				fetchDueFunc: func(_ context.Context, _ int) ([]*entity.Task, error) {
+					return []*entity.Task{task}, nil
+				},
+			}
+			store := &mockDeadLetterStore{}
+
+			svc := NewTaskService(scheduler, producer, zap.NewNop(),
+				WithDeadLetterPolicy(DeadLetterPolicy{MaxAttempts: 1, Backoff: time.Millisecond}),
+				WithDeadLetterFallback(store),
+				WithDeadLetterMode(tt.mode),
+			)
+			if _, err := svc.ProcessDueTasks(context.Background()); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if deadProduces != tt.wantDeadProduces {
+				t.Fatalf("expected %d dead-letter produces, got %d", tt.wantDeadProduces, deadProduces)
+			}
+			if strings.Join(store.reasons, "|") != strings.Join(tt.wantReasons, "|") {
+				t.Fatalf("stored reasons = %q, want %q", store.reasons, tt.wantReasons)
+			}
+		})
+	}
+}
+
+func TestTaskService_ProcessDueTasks_fallbackDeadDestinations(t *testing.T) {
+	tests := []struct {
+		name              string
+		down              map[string]bool
+		wantDelivered     string
+		wantStoredInRedis int
+	}{
+		{
+			name:          "primary dead destination up",
+			down:          map[string]bool{},
+			wantDelivered: "dead-topic",
+		},
+		{
+			name:          "first fallback used when primary is down",
+			down:          map[string]bool{"dead-topic": true},
+			wantDelivered: "http://localhost:8090/audit",
+		},
+		{
+			name:              "store used when the whole chain is down",
+			down:              map[string]bool{"dead-topic": true, "http://localhost:8090/audit": true, "audit-topic": true},
+			wantStoredInRedis: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := testTask()
+			task.Attempt = 3
+			task.MaxRetries = 3
+			task.FallbackDeadDestinations = []entity.Destination{
+				{URL: "http://localhost:8090/audit"},
+				{Host: "localhost", Port: "9092", Topic: "audit-topic"},
+			}
+
+			producer := &mockProducer{
+				produceFunc: func(_ context.Context, dest entity.Destination, _, _ []byte) error {
+					if dest.Topic == "my-topic" || tt.down[dest.Name()] {
+						return errors.New("down")
+					}
+					return nil
+				},
+			}
+			scheduler := &mockScheduler{
+				fetchDueFunc: func(_ context.Context, _ int) ([]*entity.Task, error) {
+					return []*entity.Task{task}, nil
+				},
+			}
+			store := &mockDeadLetterStore{}
+
+			svc := NewTaskService(scheduler, producer, zap.NewNop(),
+				WithDeadLetterPolicy(DeadLetterPolicy{MaxAttempts: 1}),
+				WithDeadLetterFallback(store),
+			)
+			if _, err := svc.ProcessDueTasks(context.Background()); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			delivered := producer.successfulProduceCalls()
+			if tt.wantDelivered == "" {
+				if len(delivered) != 0 {
+					t.Fatalf("expected no delivery, got %d", len(delivered))
+				}
+			} else if len(delivered) != 1 || delivered[0].Destination.Name() != tt.wantDelivered {
+				t.Fatalf("expected dead letter delivered to %s, got %+v", tt.wantDelivered, delivered)
+			}
+			if len(store.stored) != tt.wantStoredInRedis {
+				t.Fatalf("expected %d stored tasks, got %d", tt.wantStoredInRedis, len(store.stored))
+			}
+		})
+	}
+}
+
+func TestTaskService_ProcessDueTasks_outcomeCallback(t *testing.T) {
+	tests := []struct {
+		name         string
+		attempt      int
+		deliveryErr  error
+		poison       bool // quarantine on the first fast failure
+		callbackURL  string
+		wantCallback bool
+		wantState    string
+		wantAttempts int
+	}{
+		{
+			name:         "delivered task notifies callback",
+			attempt:      1,
+			callbackURL:  "http://localhost:8090/outcome",
+			wantCallback: true,
+			wantState:    "delivered",
+			wantAttempts: 2,
+		},
+		{
+			name:         "dead task notifies callback",
+			attempt:      3,
+			deliveryErr:  errors.New("endpoint down"),
+			callbackURL:  "http://localhost:8090/outcome",
+			wantCallback: true,
+			wantState:    "dead",
+			wantAttempts: 4,
+		},
+		{
+			name:         "quarantined task counts its last attempt",
+			attempt:      1,
+			deliveryErr:  errors.New("invalid payload"),
+			poison:       true,
+			callbackURL:  "http://localhost:8090/outcome",
+			wantCallback: true,
+			wantState:    "quarantined",
+			wantAttempts: 2,
+		},
+		{
+			name:         "rescheduled task does not notify callback",
+			attempt:      1,
+			deliveryErr:  errors.New("endpoint down"),
+			callbackURL:  "http://localhost:8090/outcome",
+			wantCallback: false,
+		},
+		{
+			name:         "no callback configured",
+			attempt:      1,
+			wantCallback: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := testHTTPTask()
+			task.Attempt = tt.attempt
+			task.CallbackURL = tt.callbackURL
+
+			producer := &mockProducer{
+				produceFunc: func(_ context.Context, dest entity.Destination, _, _ []byte) error {
+					if dest.URL == task.Destination.URL {
+						return tt.deliveryErr
+					}
+					return nil
+				},
+			}
+			scheduler := &mockScheduler{
+				fetchDueFunc: func(_ context.Context, _ int) ([]*entity.Task, error) {
+					return []*entity.Task{task}, nil
+				},
+			}
+
+			var opts []Option
+			if tt.poison {
+				opts = append(opts, WithQuarantine(&mockQuarantine{}, PoisonPolicy{Threshold: 1, FailureWindow: time.Hour}))
+			}
+			svc := NewTaskService(scheduler, producer, zap.NewNop(), opts...)
+			if _, err := svc.ProcessDueTasks(context.Background()); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var callbacks []produceCall
+			for _, c := range producer.produceCalls {
+				if tt.callbackURL != "" && c.Destination.URL == tt.callbackURL {
+					callbacks = append(callbacks, c)
+				}
+			}
+
+			if !tt.wantCallback {
+				if len(callbacks) != 0 {
+					t.Fatalf("expected no callback, got %d", len(callbacks))
+				}
+				return
+			}
+			if len(callbacks) != 1 {
+				t.Fatalf("expected 1 callback, got %d", len(callbacks))
+			}
+
+			var n outcomeNotification
+			if err := json.Unmarshal(callbacks[0].Value, &n); err != nil {
+				t.Fatalf("callback payload is not valid JSON: %v", err)
+			}
+			if n.State != tt.wantState {
+				t.Fatalf("expected state %q, got %q", tt.wantState, n.State)
+			}
+			if n.Attempts != tt.wantAttempts {
+				t.Fatalf("expected %d attempts, got %d", tt.wantAttempts, n.Attempts)
+			}
+			if n.TaskID != task.ID {
+				t.Fatalf("expected task_id %q, got %q", task.ID, n.TaskID)
+			}
+		})
+	}
+}
+
+func TestTaskService_ProcessDueTasks_mixedDeadDestinations(t *testing.T) {
+	tests := []struct {
+		name     string
+		task     *entity.Task
+		deadDest entity.Destination
+	}{
+		{
+			name:     "kafka task with http dead destination",
+			task:     testTask(),
+			deadDest: entity.Destination{URL: "http://localhost:8090/dead"},
+		},
+		{
+			name:     "http task with kafka dead destination",
+			task:     testHTTPTask(),
+			deadDest: entity.Destination{Host: "localhost", Port: "9092", Topic: "dead-topic"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := tt.task
+			task.Attempt = 3
+			task.MaxRetries = 3
+			task.DeadDestination = tt.deadDest
+
+			producer := &mockProducer{
+				produceFunc: func(_ context.Context, dest entity.Destination, _, _ []byte) error {
+					if dest == task.Destination {
+						return errors.New("destination down")
+					}
+					return nil
+				},
+			}
+			scheduler := &mockScheduler{
+				fetchDueFunc: func(_ context.Context, _ int) ([]*entity.Task, error) {
+					return []*entity.Task{task}, nil
+				},
+			}
+
+			svc := NewTaskService(scheduler, producer, zap.NewNop())
+			if _, err := svc.ProcessDueTasks(context.Background()); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(producer.produceCalls) != 2 {
+				t.Fatalf("expected 2 produce calls, got %d", len(producer.produceCalls))
+			}
+			if producer.produceCalls[1].Destination != tt.deadDest {
+				t.Fatalf("expected dead letter to %+v, got %+v", tt.deadDest, producer.produceCalls[1].Destination)
+			}
+		})
+	}
+}
+
+func TestTaskService_orderingKey(t *testing.T) {
+	scheduler := &mockScheduler{}
+	producer := &mockProducer{}
+	ordering := newMockOrdering()
+	svc := NewTaskService(scheduler, producer, zap.NewNop(), WithOrdering(ordering))
+	ctx := context.Background()
+
+	first := testHTTPTask()
+	first.ID = "event-1"
+	first.OrderingKey = "customer-42"
+	second := testHTTPTask()
+	second.ID = "event-2"
+	second.OrderingKey = "customer-42"
+
+	if err := svc.CreateTask(ctx, first); err != nil {
+		t.Fatalf("unexpected error creating first task: %v", err)
+	}
+	if err := svc.CreateTask(ctx, second); err != nil {
+		t.Fatalf("unexpected error creating second task: %v", err)
+	}
+
+	if len(scheduler.scheduledTasks) != 1 || scheduler.scheduledTasks[0].Task.ID != "event-1" {
+		t.Fatalf("expected only event-1 to be scheduled, got %d scheduled", len(scheduler.scheduledTasks))
+	}
+
+	// A failed attempt reschedules the head but keeps event-2 blocked.
+	producer.produceFunc = func(_ context.Context, _ entity.Destination, _, _ []byte) error {
+		return errors.New("endpoint down")
+	}
+	scheduler.fetchDueFunc = func(_ context.Context, _ int) ([]*entity.Task, error) {
+		return []*entity.Task{first}, nil
+	}
+	if _, err := svc.ProcessDueTasks(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(scheduler.scheduledTasks) != 2 || scheduler.scheduledTasks[1].Task.ID != "event-1" {
+		t.Fatalf("expected event-1 retry to be scheduled, got %+v", scheduler.scheduledTasks)
+	}
+
+	// Delivering the head releases event-2.
+	producer.produceFunc = nil
+	if _, err := svc.ProcessDueTasks(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(scheduler.scheduledTasks) != 3 || scheduler.scheduledTasks[2].Task.ID != "event-2" {
+		t.Fatalf("expected event-2 to be scheduled after event-1 delivered, got %d scheduled", len(scheduler.scheduledTasks))
+	}
+	if ordering.active["customer-42"] != "event-2" {
+		t.Fatalf("expected event-2 to hold the ordering key, got %q", ordering.active["customer-42"])
+	}
+}
+
+func TestTaskService_CreateTask_orderingKeyRequiresStore(t *testing.T) {
+	task := testTask()
+	task.OrderingKey = "customer-42"
+
+	svc := NewTaskService(&mockScheduler{}, &mockProducer{}, zap.NewNop())
+	err := svc.CreateTask(context.Background(), task)
+	if !errors.Is(err, domain.ErrInvalidTask) {
+		t.Fatalf("expected ErrInvalidTask, got %v", err)
+	}
+}
+
+func TestTaskService_CreateTask_validationBounds(t *testing.T) {
+	requireTeam := func(task *entity.Task) error {
+		if task.Metadata["team"] == "" {
+			return errors.New("metadata team is required")
+		}
+		return nil
+	}
+
+	tests := []struct {
+		name       string
+		bounds     ValidationBounds
+		maxRetries int
+		baseDelay  time.Duration
+		metadata   map[string]string
+		wantErr    bool
+	}{
+		{name: "default bounds reject many retries", maxRetries: 500, baseDelay: 2 * time.Second, metadata: map[string]string{"team": "a"}, wantErr: true},
+		{name: "raised retry limit", bounds: ValidationBounds{MaxRetryLimit: 1000}, maxRetries: 500, baseDelay: 2 * time.Second, metadata: map[string]string{"team": "a"}},
+		{name: "lowered max base delay", bounds: ValidationBounds{MaxBaseDelay: time.Minute}, maxRetries: 3, baseDelay: 120 * time.Second, metadata: map[string]string{"team": "a"}, wantErr: true},
+		{name: "raised min base delay", bounds: ValidationBounds{MinBaseDelay: 5 * time.Second}, maxRetries: 3, baseDelay: 2 * time.Second, metadata: map[string]string{"team": "a"}, wantErr: true},
+		{name: "custom validator rejects", maxRetries: 3, baseDelay: 2 * time.Second, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := testTask()
+			task.MaxRetries = tt.maxRetries
+			task.BaseDelay = tt.baseDelay
+			task.Metadata = tt.metadata
+
+			svc := NewTaskService(&mockScheduler{}, &mockProducer{}, zap.NewNop(),
+				WithValidationBounds(tt.bounds),
+				WithValidator(requireTeam),
+			)
+			err := svc.CreateTask(context.Background(), task)
+			if tt.wantErr && !errors.Is(err, domain.ErrInvalidTask) {
+				t.Fatalf("expected ErrInvalidTask, got %v", err)
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestTaskService_CreateTask_fanOut(t *testing.T) {
+	tests := []struct {
+		name       string
+		fanOut     []entity.Destination
+		ordering   bool
+		wantErr    bool
+		wantTopics []string
+	}{
+		{
+			name:       "one task per destination",
+			fanOut:     []entity.Destination{{URL: "https://partner/hook"}, {Host: "localhost", Port: "9092", Topic: "audit"}},
+			wantTopics: []string{"my-topic", "https://partner/hook", "audit"},
+		},
+		{
+			name:    "destination without topic or URL",
+			fanOut:  []entity.Destination{{URL: "https://partner/hook"}, {Host: "kafka"}},
+			wantErr: true,
+		},
+		{
+			name:     "ordering key",
+			fanOut:   []entity.Destination{{Host: "localhost", Port: "9092", Topic: "audit"}},
+			ordering: true,
+			wantErr:  true,
+		},
+		{
+			name:    "too many destinations",
+			fanOut:  make([]entity.Destination, domain.MaxFanOutDestinations+1),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := testTask()
+			task.FanOutDestinations = tt.fanOut
+			if tt.ordering {
+				task.OrderingKey = "customer-42"
+			}
+
+			scheduler := &mockScheduler{}
+			svc := NewTaskService(scheduler, &mockProducer{}, zap.NewNop(), WithOrdering(newMockOrdering()))
+			err := svc.CreateTask(context.Background(), task)
+			if tt.wantErr {
+				if !errors.Is(err, domain.ErrInvalidTask) {
+					t.Fatalf("expected ErrInvalidTask, got %v", err)
+				}
+				if len(scheduler.scheduledTasks) != 0 {
+					t.Fatalf("expected nothing scheduled, got %d tasks", len(scheduler.scheduledTasks))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(scheduler.scheduledTasks) != len(tt.wantTopics) {
+				t.Fatalf("expected %d scheduled tasks, got %d", len(tt.wantTopics), len(scheduler.scheduledTasks))
+			}
+			for i, want := range tt.wantTopics {
+				scheduled := scheduler.scheduledTasks[i].Task
+				if scheduled.Destination.Name() != want {
+					t.Fatalf("task %d: expected destination %q, got %q", i, want, scheduled.Destination.Name())
+				}
+				wantID := task.ID
+				if i > 0 {
+					wantID = entity.FanOutTaskID(task.ID, i)
+				}
+				if scheduled.ID != wantID {
+					t.Fatalf("task %d: expected ID %q, got %q", i, wantID, scheduled.ID)
+				}
+			}
+		})
+	}
+}
+
+func TestTaskService_CreateTask_destinationAddress(t *testing.T) {
+	tests := []struct {
+		name      string
+		modify    func(*entity.Task)
+		wantField string
+	}{
+		{
+			name: "relative URL",
+			modify: func(task *entity.Task) {
+				task.DestinationType = entity.DestinationTypeHTTP
+				task.Destination = entity.Destination{URL: "partner.example.com/hook"}
+			},
+			wantField: "destination.url",
+		},
+		{
+			name: "dead destination port",
+			modify: func(task *entity.Task) {
+				task.DeadDestination.Port = "99999"
+			},
+			wantField: "dead_destination.port",
+		},
+		{
+			name: "fan-out broker with port in host",
+			modify: func(task *entity.Task) {
+				task.FanOutDestinations = []entity.Destination{{Host: "kafka:9092", Port: "9092", Topic: "audit"}}
+			},
+			wantField: "fan_out_destinations[0].host",
+		},
+		{
+			name: "callback URL without scheme",
+			modify: func(task *entity.Task) {
+				task.CallbackURL = "origin.example.com/outcome"
+			},
+			wantField: "callback_url",
+		},
+		{
+			name: "callback URL port",
+			modify: func(task *entity.Task) {
+				task.CallbackURL = "https://origin.example.com:70000/outcome"
+			},
+			wantField: "callback_url",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := testTask()
+			tt.modify(task)
+
+			scheduler := &mockScheduler{}
+			svc := NewTaskService(scheduler, &mockProducer{}, zap.NewNop())
+			err := svc.CreateTask(context.Background(), task)
+			if !errors.Is(err, domain.ErrInvalidTask) {
+				t.Fatalf("expected ErrInvalidTask, got %v", err)
+			}
+			var fieldErr *entity.FieldError
+			if !errors.As(err, &fieldErr) || fieldErr.Field != tt.wantField {
+				t.Fatalf("expected an error for field %q, got %v", tt.wantField, err)
+			}
+			if len(scheduler.scheduledTasks) != 0 {
+				t.Fatal("expected nothing to be scheduled")
+			}
+		})
+	}
+}
+
+func TestTaskService_CreateTask_generatedID(t *testing.T) {
+	n := 0
+	generate := func() string {
+		n++
+		return fmt.Sprintf("gen-%d", n)
+	}
+
+	scheduler := &mockScheduler{}
+	svc := NewTaskService(scheduler, &mockProducer{}, zap.NewNop(), WithIDGenerator(generate))
+
+	withID := testTask()
+	generated := testTask()
+	generated.ID = ""
+	generated.FanOutDestinations = []entity.Destination{{Host: "localhost", Port: "9092", Topic: "audit"}}
+	for _, task := range []*entity.Task{withID, generated} {
+		if err := svc.CreateTask(context.Background(), task); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	var ids []string
+	for _, st := range scheduler.scheduledTasks {
+		ids = append(ids, st.Task.ID)
+	}
+	if want := []string{"task-1", "gen-1", "gen-1#1"}; strings.Join(ids, ",") != strings.Join(want, ",") {
+		t.Fatalf("expected scheduled IDs %v, got %v", want, ids)
+	}
+	if generated.ID != "gen-1" {
+		t.Fatalf("expected the task to carry its generated ID, got %q", generated.ID)
+	}
+
+	// Without a generator an ID is still required.
+	generated = testTask()
+	generated.ID = ""
+	err := NewTaskService(&mockScheduler{}, &mockProducer{}, zap.NewNop()).CreateTask(context.Background(), generated)
+	if !errors.Is(err, domain.ErrInvalidTask) {
+		t.Fatalf("expected ErrInvalidTask, got %v", err)
+	}
+}
+
+func TestTaskService_CreateTask_maxDelay(t *testing.T) {
+	tests := []struct {
+		name     string
+		maxDelay time.Duration
+		want     time.Duration
+	}{
+		{name: "deployment default applies", want: time.Hour},
+		{name: "task cap is kept", maxDelay: 10 * time.Minute, want: 10 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheduler := &mockScheduler{}
+			svc := NewTaskService(scheduler, &mockProducer{}, zap.NewNop(), WithMaxDelay(time.Hour))
+
+			task := testTask()
+			task.MaxDelay = tt.maxDelay
+			task.FanOutDestinations = []entity.Destination{{Host: "localhost", Port: "9092", Topic: "audit"}}
+			if err := svc.CreateTask(context.Background(), task); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(scheduler.scheduledTasks) != 2 {
+				t.Fatalf("expected 2 scheduled tasks, got %d", len(scheduler.scheduledTasks))
+			}
+			for _, call := range scheduler.scheduledTasks {
+				if call.Task.MaxDelay != tt.want {
+					t.Fatalf("task %s: expected max delay %v, got %v", call.Task.ID, tt.want, call.Task.MaxDelay)
+				}
+			}
+		})
+	}
+}
+
+func TestTaskService_CreateTask_retrySchedule(t *testing.T) {
+	tests := []struct {
+		name      string
+		schedule  []time.Duration
+		baseDelay time.Duration
+		wantErr   bool
+	}{
+		{name: "schedule overrides max retries", schedule: []time.Duration{10 * time.Second, time.Minute}, baseDelay: 2 * time.Second},
+		{name: "base delay may be left out", schedule: []time.Duration{10 * time.Second}},
+		{name: "delay below the minimum", schedule: []time.Duration{10 * time.Second, 0}, baseDelay: 2 * time.Second, wantErr: true},
+		{name: "too many delays", schedule: make([]time.Duration, 101), baseDelay: 2 * time.Second, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := testTask()
+			task.MaxRetries = 7
+			task.BaseDelay = tt.baseDelay
+			task.RetryDelays = tt.schedule
+
+			scheduler := &mockScheduler{}
+			svc := NewTaskService(scheduler, &mockProducer{}, zap.NewNop(),
+				WithValidationBounds(ValidationBounds{MaxRetryLimit: 100, MinBaseDelay: time.Second}))
+			err := svc.CreateTask(context.Background(), task)
+			if tt.wantErr {
+				if !errors.Is(err, domain.ErrInvalidTask) {
+					t.Fatalf("expected ErrInvalidTask, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			scheduled := scheduler.scheduledTasks[0]
+			if scheduled.Task.MaxRetries != len(tt.schedule) {
+				t.Fatalf("expected max retries %d, got %d", len(tt.schedule), scheduled.Task.MaxRetries)
+			}
+			if scheduled.Delay != tt.baseDelay {
+				t.Fatalf("expected first attempt after %v, got %v", tt.baseDelay, scheduled.Delay)
+			}
+		})
+	}
+}
+
+func TestTaskService_ProcessDueTasks_retrySchedule(t *testing.T) {
+	schedule := []time.Duration{10 * time.Second, time.Minute, 10 * time.Minute}
+
+	tests := []struct {
+		name      string
+		attempt   int
+		wantDelay time.Duration
+		wantDead  bool
+	}{
+		{name: "first retry", attempt: 0, wantDelay: 10 * time.Second},
+		{name: "uses the delay for the attempt", attempt: 2, wantDelay: 10 * time.Minute},
+		{name: "dead-lettered when the schedule runs out", attempt: 3, wantDead: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := testTask()
+			task.Attempt = tt.attempt
+			task.MaxRetries = len(schedule)
+			task.RetryDelays = schedule
+
+			scheduler := &mockScheduler{
+				fetchDueFunc: func(_ context.Context, _ int) ([]*entity.Task, error) {
+					return []*entity.Task{task}, nil
+				},
+			}
+			producer := &mockProducer{
+				produceFunc: func(_ context.Context, dest entity.Destination, _, _ []byte) error {
+					if dest.Topic == "dead-topic" {
+						return nil
+					}
+					return errors.New("kafka down")
+				},
+			}
+
+			svc := NewTaskService(scheduler, producer, zap.NewNop())
+			if _, err := svc.ProcessDueTasks(context.Background()); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if tt.wantDead {
+				if len(scheduler.scheduledTasks) != 0 {
+					t.Fatalf("expected no retry, got %d", len(scheduler.scheduledTasks))
+				}
+				if len(producer.produceCalls) != 2 || producer.produceCalls[1].Destination.Topic != "dead-topic" {
+					t.Fatalf("expected a dead letter, got %+v", producer.produceCalls)
+				}
+				return
+			}
+			if len(scheduler.scheduledTasks) != 1 {
+				t.Fatalf("expected 1 scheduled retry, got %d", len(scheduler.scheduledTasks))
+			}
+			if delay := scheduler.scheduledTasks[0].Delay; delay != tt.wantDelay {
+				t.Fatalf("expected delay %v, got %v", tt.wantDelay, delay)
+			}
+		})
+	}
+}
+
+func TestTaskService_CreateTaskAt(t *testing.T) {
+	tests := []struct {
+		name      string
+		at        time.Duration
+		wantDelay time.Duration
+	}{
+		{name: "future time", at: 10 * time.Minute, wantDelay: 10 * time.Minute},
+		{name: "past time schedules immediately", at: -time.Minute, wantDelay: -time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheduler := &mockScheduler{}
+			svc := NewTaskService(scheduler, &mockProducer{}, zap.NewNop())
+
+			if err := svc.CreateTaskAt(context.Background(), testTask(), time.Now().Add(tt.at)); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(scheduler.scheduledTasks) != 1 {
+				t.Fatalf("expected 1 scheduled task, got %d", len(scheduler.scheduledTasks))
+			}
+			delay := scheduler.scheduledTasks[0].Delay
+			if diff := tt.wantDelay - delay; diff < 0 || diff > time.Second {
+				t.Fatalf("expected delay close to %v, got %v", tt.wantDelay, delay)
+			}
+		})
+	}
+}
+
+func TestTaskService_CreateTask_attemptImmediately(t *testing.T) {
+	tests := []struct {
+		name      string
+		immediate bool
+		wantDelay time.Duration
+	}{
+		{name: "first attempt waits base delay", wantDelay: 5 * time.Second},
+		{name: "first attempt immediately", immediate: true, wantDelay: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheduler := &mockScheduler{}
+			svc := NewTaskService(scheduler, &mockProducer{}, zap.NewNop())
+
+			task := testTask()
+			task.BaseDelay = 5 * time.Second
+			task.AttemptImmediately = tt.immediate
+			if err := svc.CreateTask(context.Background(), task); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(scheduler.scheduledTasks) != 1 {
+				t.Fatalf("expected 1 scheduled task, got %d", len(scheduler.scheduledTasks))
+			}
+			if delay := scheduler.scheduledTasks[0].Delay; delay != tt.wantDelay {
+				t.Fatalf("expected delay %v, got %v", tt.wantDelay, delay)
+			}
+		})
+	}
+}
+
+func TestTaskService_CreateTaskAt_validates(t *testing.T) {
+	task := testTask()
+	task.ID = ""
+
+	svc := NewTaskService(&mockScheduler{}, &mockProducer{}, zap.NewNop())
+	err := svc.CreateTaskAt(context.Background(), task, time.Now())
+	if !errors.Is(err, domain.ErrInvalidTask) {
+		t.Fatalf("expected ErrInvalidTask, got %v", err)
+	}
+}
+
+func TestTaskService_ProcessDueTasks_completionMarkers(t *testing.T) {
+	tests := []struct {
+		name          string
+		preCompleted  bool
+		checkErr      error
+		wantDelivered int
+	}{
+		{
+			name:          "first delivery records marker",
+			wantDelivered: 1,
+		},
+		{
+			name:          "completed task is not delivered again",
+			preCompleted:  true,
+			wantDelivered: 0,
+		},
+		{
+			name:          "marker lookup failure delivers anyway",
+			preCompleted:  true,
+			checkErr:      errors.New("redis timeout"),
+			wantDelivered: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := testTask()
+			completions := newMockCompletionStore()
+			completions.checkErr = tt.checkErr
+			if tt.preCompleted {
+				completions.completed[task.ID] = time.Hour
+			}
+
+			scheduler := &mockScheduler{
+				fetchDueFunc: func(_ context.Context, _ int) ([]*entity.Task, error) {
+					return []*entity.Task{task}, nil
+				},
+			}
+			producer := &mockProducer{}
+
+			svc := NewTaskService(scheduler, producer, zap.NewNop(),
+				WithCompletionMarkers(completions, time.Hour),
+			)
+			if _, err := svc.ProcessDueTasks(context.Background()); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(producer.produceCalls) != tt.wantDelivered {
+				t.Fatalf("expected %d deliveries, got %d", tt.wantDelivered, len(producer.produceCalls))
+			}
+			if _, ok := completions.completed[task.ID]; !ok {
+				t.Fatal("expected completion marker to be recorded")
+			}
+		})
+	}
+}
+
+func TestTaskService_ProcessDueTasks_httpBatching(t *testing.T) {
+	newTask := func(id, url string) *entity.Task {
+		task := testHTTPTask()
+		task.ID = id
+		task.Destination.URL = url
+		return task
+	}
+
+	tests := []struct {
+		name            string
+		batchSize       int
+		batchErr        error
+		wantBatches     []int
+		wantSingles     int
+		wantRescheduled int
+	}{
+		{
+			name:        "batching disabled delivers individually",
+			batchSize:   0,
+			wantBatches: nil,
+			wantSingles: 5,
+		},
+		{
+			name:        "tasks for the same url are coalesced up to the max size",
+			batchSize:   2,
+			wantBatches: []int{2, 2},
+			wantSingles: 1, // kafka task
+		},
+		{
+			name:            "failed batch reschedules every task",
+			batchSize:       10,
+			batchErr:        errors.New("endpoint down"),
+			wantBatches:     []int{4},
+			wantSingles:     1,
+			wantRescheduled: 4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tasks := []*entity.Task{
+				newTask("a", "http://localhost:8090/hook"),
+				newTask("b", "http://localhost:8090/hook"),
+				testTask(),
+				newTask("c", "http://localhost:8090/hook"),
+				newTask("d", "http://localhost:8090/hook"),
+			}
+			scheduler := &mockScheduler{
+				fetchDueFunc: func(_ context.Context, _ int) ([]*entity.Task, error) {
+					return tasks, nil
+				},
+			}
+			producer := &mockBatchProducer{batchErr: tt.batchErr}
+
+			svc := NewTaskService(scheduler, producer, zap.NewNop(), WithHTTPBatching(tt.batchSize))
+			if _, err := svc.ProcessDueTasks(context.Background()); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(producer.batches) != len(tt.wantBatches) {
+				t.Fatalf("expected %d batches, got %d", len(tt.wantBatches), len(producer.batches))
+			}
+			for i, want := range tt.wantBatches {
+				if len(producer.batches[i]) != want {
+					t.Fatalf("batch %d: expected %d messages, got %d", i, want, len(producer.batches[i]))
+				}
+			}
+			if len(producer.produceCalls) != tt.wantSingles {
+				t.Fatalf("expected %d single deliveries, got %d", tt.wantSingles, len(producer.produceCalls))
+			}
+			if len(scheduler.scheduledTasks) != tt.wantRescheduled {
+				t.Fatalf("expected %d rescheduled tasks, got %d", tt.wantRescheduled, len(scheduler.scheduledTasks))
+			}
+		})
+	}
+}
+
+func TestTaskService_ProcessDueTasks_httpBatchingSkipsChatWebhooks(t *testing.T) {
+	var tasks []*entity.Task
+	for _, id := range []string{"a", "b"} {
+		task := testHTTPTask()
+		task.ID = id
+		task.Destination = entity.Destination{URL: "https://hooks.slack.com/services/T0/B0/x", Format: entity.PayloadFormatSlack}
+		tasks = append(tasks, task)
+	}
+	scheduler := &mockScheduler{
+		fetchDueFunc: func(_ context.Context, _ int) ([]*entity.Task, error) {
+			return tasks, nil
+		},
+	}
+	producer := &mockBatchProducer{}
+
+	svc := NewTaskService(scheduler, producer, zap.NewNop(), WithHTTPBatching(10))
+	if _, err := svc.ProcessDueTasks(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(producer.batches) != 0 || len(producer.produceCalls) != 2 {
+		t.Fatalf("expected 2 single deliveries, got %d batches and %d singles", len(producer.batches), len(producer.produceCalls))
+	}
+}
+
+func TestTaskService_ProcessDueTasks_heartbeat(t *testing.T) {
+	heartbeat := &mockHeartbeat{}
+	var fetchErr error
+	scheduler := &mockScheduler{
+		fetchDueFunc: func(_ context.Context, _ int) ([]*entity.Task, error) {
+			return nil, fetchErr
+		},
+	}
+	svc := NewTaskService(scheduler, &mockProducer{}, zap.NewNop(), WithHeartbeat(heartbeat, 0))
+
+	if _, err := svc.ProcessDueTasks(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(heartbeat.beats) != 1 || heartbeat.beats[0] != domain.DefaultHeartbeatTTL {
+		t.Fatalf("expected one beat with the default ttl, got %v", heartbeat.beats)
+	}
+
+	// A poll that cannot reach the schedule must not count as processing.
+	fetchErr = errors.New("redis down")
+	if _, err := svc.ProcessDueTasks(context.Background()); err == nil {
+		t.Fatal("expected fetch error")
+	}
+	if len(heartbeat.beats) != 1 {
+		t.Fatalf("expected no beat after a failed fetch, got %d", len(heartbeat.beats))
+	}
+}
+
+func TestTaskService_ProcessDueTasks_workerStats(t *testing.T) {
+	ok := testHTTPTask()
+	failing := testHTTPTask()
+	failing.ID = "task-failing"
+	failing.Destination.URL = "http://localhost:8090/down"
+
+	heartbeat := &mockHeartbeat{}
+	producer := &mockProducer{
+		produceFunc: func(_ context.Context, dest entity.Destination, _, _ []byte) error {
+			if dest.URL == failing.Destination.URL {
+				return errors.New("endpoint down")
+			}
+			return nil
+		},
+	}
+	scheduler := &mockScheduler{
+		fetchDueFunc: func(_ context.Context, _ int) ([]*entity.Task, error) {
+			return []*entity.Task{ok, failing}, nil
+		},
+	}
+	svc := NewTaskService(scheduler, producer, zap.NewNop(), WithHeartbeat(heartbeat, 0))
+
+	if _, err := svc.ProcessDueTasks(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if heartbeat.processed != 2 || heartbeat.errors != 1 {
+		t.Fatalf("expected 2 processed and 1 error, got %d and %d", heartbeat.processed, heartbeat.errors)
+	}
+}
+
+func TestTaskService_ProcessDueTasks_metadataHeaders(t *testing.T) {
+	task := testHTTPTask()
+	task.Metadata = map[string]string{"tenant-id": "acme"}
+	scheduler := &mockScheduler{
+		fetchDueFunc: func(_ context.Context, _ int) ([]*entity.Task, error) {
+			return []*entity.Task{task}, nil
+		},
+	}
+	producer := &mockProducer{}
+	svc := NewTaskService(scheduler, producer, zap.NewNop())
+
+	if _, err := svc.ProcessDueTasks(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(producer.produceCalls) != 1 {
+		t.Fatalf("expected 1 produce call, got %d", len(producer.produceCalls))
+	}
+	if got := producer.produceCalls[0].Headers["tenant-id"]; got != "acme" {
+		t.Fatalf("expected metadata to be passed as headers, got %v", producer.produceCalls[0].Headers)
+	}
+}
+
+func TestTaskService_ProcessDueTasks_deliveryTimeout(t *testing.T) {
+	tests := []struct {
+		name    string
+		timeout time.Duration
+		cancel  bool
+	}{
+		{name: "attempt outliving its window times out", timeout: 20 * time.Millisecond},
+		{name: "shutdown cancels the attempt", timeout: time.Hour, cancel: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheduler := &mockScheduler{
+				fetchDueFunc: func(_ context.Context, _ int) ([]*entity.Task, error) {
+					return []*entity.Task{testTask()}, nil
+				},
+			}
+			producer := &mockProducer{
+				produceFunc: func(ctx context.Context, _ entity.Destination, _, _ []byte) error {
+					<-ctx.Done()
+					return ctx.Err()
+				},
+			}
+			svc := NewTaskService(scheduler, producer, zap.NewNop(), WithDeliveryTimeout(tt.timeout))
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tt.cancel {
+				time.AfterFunc(20*time.Millisecond, cancel)
+			}
+
+			done := make(chan entity.ProcessResult, 1)
+			go func() {
+				result, _ := svc.ProcessDueTasks(ctx)
+				done <- result
+			}()
+
+			select {
+			case result := <-done:
+				if len(result.Tasks) != 1 || result.Tasks[0].Error == "" {
+					t.Fatalf("expected a failed attempt, got %+v", result.Tasks)
+				}
+				if !tt.cancel && result.Tasks[0].ErrorCode != entity.ErrorCodeTimeout {
+					t.Fatalf("expected error code %s, got %s", entity.ErrorCodeTimeout, result.Tasks[0].ErrorCode)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("attempt outlived its window")
+			}
+		})
+	}
+}
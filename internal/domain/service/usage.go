@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+	"github.com/ruudy-sib/rebound/internal/port/secondary"
+)
+
+// usageEvent is produced to the usage events destination with one client's
+// counts from one poll. Consumers sum the events of a period to bill it.
+type usageEvent struct {
+	ClientID   string    `json:"client_id"`
+	Period     string    `json:"period"`
+	Delivered  int64     `json:"delivered"`
+	Retried    int64     `json:"retried"`
+	RecordedAt time.Time `json:"recorded_at"`
+}
+
+// recordUsage adds the poll's delivered and rescheduled attempts to the
+// counters of their clients and publishes them as usage events. Like
+// recordPoll, a failure is only logged: the tasks are already settled.
+func (s *TaskService) recordUsage(ctx context.Context, result entity.ProcessResult) {
+	if s.usage == nil {
+		return
+	}
+
+	now := s.now()
+	usage := pollUsage(result, entity.UsagePeriod(now))
+	if len(usage) == 0 {
+		return
+	}
+
+	if err := s.usage.Add(ctx, usage); err != nil {
+		s.logger.Warn("failed to record client usage", zap.Error(err))
+	}
+
+	if s.usageEvents.Type() == "" {
+		return
+	}
+	for _, u := range usage {
+		value, err := json.Marshal(usageEvent{
+			ClientID:   u.ClientID,
+			Period:     u.Period,
+			Delivered:  u.Delivered,
+			Retried:    u.Retried,
+			RecordedAt: now.UTC(),
+		})
+		if err != nil {
+			s.logger.Error("failed to build usage event", zap.Error(err))
+			continue
+		}
+		msg := secondary.Message{Key: []byte(u.ClientID), Value: value}
+		if err := s.producer.Produce(ctx, s.usageEvents, msg); err != nil {
+			s.logger.Warn("usage event failed",
+				zap.Error(err),
+				zap.String("client_id", u.ClientID),
+				zap.String("destination", s.usageEvents.Name()),
+			)
+		}
+	}
+}
+
+// pollUsage counts the delivered and rescheduled tasks of a poll per
+// client, ordered by client ID. Clients with neither are left out.
+func pollUsage(result entity.ProcessResult, period string) []entity.ClientUsage {
+	byClient := make(map[string]*entity.ClientUsage)
+	for _, t := range result.Tasks {
+		if t.Outcome != entity.OutcomeDelivered && t.Outcome != entity.OutcomeRescheduled {
+			continue
+		}
+		u, ok := byClient[t.ClientID]
+		if !ok {
+			u = &entity.ClientUsage{ClientID: t.ClientID, Period: period}
+			byClient[t.ClientID] = u
+		}
+		if t.Outcome == entity.OutcomeDelivered {
+			u.Delivered++
+		} else {
+			u.Retried++
+		}
+	}
+
+	usage := make([]entity.ClientUsage, 0, len(byClient))
+	for _, u := range byClient {
+		usage = append(usage, *u)
+	}
+	sortUsage(usage)
+	return usage
+}
+
+// UsageService reports per-client usage from a UsageStore.
+type UsageService struct {
+	store secondary.UsageStore
+}
+
+// NewUsageService creates a UsageService backed by store.
+func NewUsageService(store secondary.UsageStore) *UsageService {
+	return &UsageService{store: store}
+}
+
+// Usage returns the counters of every client in the billing period, ordered
+// by client ID.
+func (s *UsageService) Usage(ctx context.Context, period string) ([]entity.ClientUsage, error) {
+	if !entity.ValidUsagePeriod(period) {
+		return nil, fmt.Errorf("%w: period %q is not a month such as 2024-05", domain.ErrInvalidQuery, period)
+	}
+
+	usage, err := s.store.Usage(ctx, period)
+	if err != nil {
+		return nil, fmt.Errorf("reading client usage: %w", err)
+	}
+	sortUsage(usage)
+	return usage, nil
+}
+
+func sortUsage(usage []entity.ClientUsage) {
+	sort.Slice(usage, func(i, j int) bool { return usage[i].ClientID < usage[j].ClientID })
+}
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+func TestTaskService_ProcessDueTasks_usage(t *testing.T) {
+	delivered := testHTTPTask()
+	failing := testHTTPTask()
+	failing.ID = "task-http-2"
+	failing.Destination.URL = "http://localhost:8090/down"
+	other := testHTTPTask()
+	other.ID = "task-http-3"
+	other.ClientID = "client-2"
+
+	scheduler := &mockScheduler{
+		fetchDueFunc: func(_ context.Context, _ int) ([]*entity.Task, error) {
+			return []*entity.Task{delivered, failing, other}, nil
+		},
+	}
+	producer := &mockProducer{
+		produceFunc: func(_ context.Context, destination entity.Destination, _, _ []byte) error {
+			if destination.URL == "http://localhost:8090/down" {
+				return errors.New("connection refused")
+			}
+			return nil
+		},
+	}
+	store := newMockUsageStore()
+	svc := NewTaskService(scheduler, producer, zap.NewNop(), WithUsageTracking(store, entity.Destination{Topic: "usage"}))
+
+	if _, err := svc.ProcessDueTasks(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	period := entity.UsagePeriod(time.Now())
+	usage, err := NewUsageService(store).Usage(context.Background(), period)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []entity.ClientUsage{
+		{ClientID: "client-1", Period: period, Delivered: 1, Retried: 1},
+		{ClientID: "client-2", Period: period, Delivered: 1},
+	}
+	if len(usage) != len(want) || usage[0] != want[0] || usage[1] != want[1] {
+		t.Fatalf("expected %+v, got %+v", want, usage)
+	}
+
+	var events []usageEvent
+	for _, call := range producer.produceCalls {
+		if call.Destination.Topic != "usage" {
+			continue
+		}
+		var event usageEvent
+		if err := json.Unmarshal(call.Value, &event); err != nil {
+			t.Fatalf("decoding usage event: %v", err)
+		}
+		if string(call.Key) != event.ClientID {
+			t.Fatalf("expected event keyed by client ID, got %q", call.Key)
+		}
+		events = append(events, event)
+	}
+	if len(events) != 2 || events[0].ClientID != "client-1" || events[0].Retried != 1 || events[1].Delivered != 1 {
+		t.Fatalf("unexpected usage events: %+v", events)
+	}
+}
+
+func TestUsageService_Usage_invalidPeriod(t *testing.T) {
+	svc := NewUsageService(newMockUsageStore())
+
+	if _, err := svc.Usage(context.Background(), "last-month"); !errors.Is(err, domain.ErrInvalidQuery) {
+		t.Fatalf("expected ErrInvalidQuery, got %v", err)
+	}
+}
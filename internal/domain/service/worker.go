@@ -0,0 +1,37 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+	"github.com/ruudy-sib/rebound/internal/port/secondary"
+)
+
+// WorkerService implements primary.WorkerService.
+type WorkerService struct {
+	registry   secondary.WorkerRegistry
+	staleAfter time.Duration
+	now        func() time.Time
+}
+
+// NewWorkerService creates a WorkerService reporting instances whose last
+// heartbeat is older than staleAfter as stale.
+func NewWorkerService(registry secondary.WorkerRegistry, staleAfter time.Duration) *WorkerService {
+	return &WorkerService{registry: registry, staleAfter: staleAfter, now: time.Now}
+}
+
+// Workers returns every registered worker instance, ordered by ID.
+func (s *WorkerService) Workers(ctx context.Context) ([]entity.WorkerInstance, error) {
+	workers, err := s.registry.Workers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing workers: %w", err)
+	}
+
+	now := s.now()
+	for i := range workers {
+		workers[i].Stale = workers[i].HeartbeatAge(now) > s.staleAfter
+	}
+	return workers, nil
+}
@@ -0,0 +1,36 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+func TestWorkerService_Workers(t *testing.T) {
+	now := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	registry := &mockWorkerRegistry{workers: []entity.WorkerInstance{
+		{ID: "pod-a", LastBeat: now.Add(-5 * time.Second)},
+		{ID: "pod-b", LastBeat: now.Add(-time.Minute)},
+	}}
+
+	svc := NewWorkerService(registry, 15*time.Second)
+	svc.now = func() time.Time { return now }
+
+	workers, err := svc.Workers(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if workers[0].Stale || !workers[1].Stale {
+		t.Fatalf("expected only pod-b stale, got %+v", workers)
+	}
+}
+
+func TestWorkerService_Workers_error(t *testing.T) {
+	registry := &mockWorkerRegistry{err: errors.New("redis down")}
+	if _, err := NewWorkerService(registry, time.Minute).Workers(context.Background()); err == nil {
+		t.Fatal("expected an error")
+	}
+}
@@ -0,0 +1,197 @@
+package valueobject
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Redacted replaces every value a Redaction removes.
+const Redacted = "[REDACTED]"
+
+// Redaction is an immutable set of JSONPath expressions naming payload
+// fields to mask before a payload is logged or copied somewhere it is kept
+// for auditing. The zero value redacts nothing.
+//
+// Supported syntax: $.a.b for object keys, $.a[0] and $.a[*] for array
+// elements, $.a.* for every key of an object, and $..a for key a at any
+// depth.
+type Redaction struct {
+	paths [][]pathSegment
+}
+
+type segmentKind int
+
+const (
+	segmentKey segmentKind = iota
+	segmentAnyKey
+	segmentIndex
+	segmentAnyIndex
+	segmentDeepKey
+)
+
+type pathSegment struct {
+	kind  segmentKind
+	key   string
+	index int
+}
+
+// NewRedaction parses the paths.
+func NewRedaction(paths []string) (Redaction, error) {
+	var r Redaction
+	for _, p := range paths {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+		segments, err := parsePath(p)
+		if err != nil {
+			return Redaction{}, fmt.Errorf("invalid redaction path %q: %w", p, err)
+		}
+		r.paths = append(r.paths, segments)
+	}
+	return r, nil
+}
+
+// Empty reports whether the redaction masks nothing.
+func (r Redaction) Empty() bool {
+	return len(r.paths) == 0
+}
+
+// Apply masks the redacted fields of a JSON payload. A payload that is not
+// JSON cannot be inspected and is masked whole.
+func (r Redaction) Apply(payload string) string {
+	if r.Empty() {
+		return payload
+	}
+
+	dec := json.NewDecoder(strings.NewReader(payload))
+	dec.UseNumber()
+	var doc any
+	if err := dec.Decode(&doc); err != nil || dec.More() {
+		return Redacted
+	}
+	for _, path := range r.paths {
+		doc = redactPath(doc, path)
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(doc); err != nil {
+		return Redacted
+	}
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// redactPath masks what path matches in node and returns the result.
+func redactPath(node any, path []pathSegment) any {
+	if len(path) == 0 {
+		return Redacted
+	}
+	seg, rest := path[0], path[1:]
+
+	switch seg.kind {
+	case segmentKey, segmentAnyKey:
+		obj, ok := node.(map[string]any)
+		if !ok {
+			return node
+		}
+		for k, v := range obj {
+			if seg.kind == segmentAnyKey || k == seg.key {
+				obj[k] = redactPath(v, rest)
+			}
+		}
+	case segmentIndex, segmentAnyIndex:
+		arr, ok := node.([]any)
+		if !ok {
+			return node
+		}
+		for i, v := range arr {
+			if seg.kind == segmentAnyIndex || i == seg.index {
+				arr[i] = redactPath(v, rest)
+			}
+		}
+	case segmentDeepKey:
+		switch n := node.(type) {
+		case map[string]any:
+			for k, v := range n {
+				if k == seg.key {
+					n[k] = redactPath(v, rest)
+				} else {
+					n[k] = redactPath(v, path)
+				}
+			}
+		case []any:
+			for i, v := range n {
+				n[i] = redactPath(v, path)
+			}
+		}
+	}
+	return node
+}
+
+// parsePath splits a JSONPath expression into segments.
+func parsePath(p string) ([]pathSegment, error) {
+	if !strings.HasPrefix(p, "$") {
+		return nil, fmt.Errorf("must start with $")
+	}
+	rest := p[1:]
+
+	var segments []pathSegment
+	for rest != "" {
+		switch {
+		case strings.HasPrefix(rest, ".."):
+			name, remaining := splitName(rest[2:])
+			if name == "" || name == "*" {
+				return nil, fmt.Errorf("expected a key after ..")
+			}
+			segments = append(segments, pathSegment{kind: segmentDeepKey, key: name})
+			rest = remaining
+		case rest[0] == '.':
+			name, remaining := splitName(rest[1:])
+			switch name {
+			case "":
+				return nil, fmt.Errorf("expected a key after .")
+			case "*":
+				segments = append(segments, pathSegment{kind: segmentAnyKey})
+			default:
+				segments = append(segments, pathSegment{kind: segmentKey, key: name})
+			}
+			rest = remaining
+		case rest[0] == '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("unclosed [")
+			}
+			inner := rest[1:end]
+			if inner == "*" {
+				segments = append(segments, pathSegment{kind: segmentAnyIndex})
+			} else {
+				index, err := strconv.Atoi(inner)
+				if err != nil || index < 0 {
+					return nil, fmt.Errorf("index %q must be * or a non-negative integer", inner)
+				}
+				segments = append(segments, pathSegment{kind: segmentIndex, index: index})
+			}
+			rest = rest[end+1:]
+		default:
+			return nil, fmt.Errorf("unexpected %q", rest[0])
+		}
+	}
+	if len(segments) == 0 {
+		return nil, fmt.Errorf("must name at least one field")
+	}
+	return segments, nil
+}
+
+// splitName cuts a key name off the front of s, up to the next . or [.
+func splitName(s string) (string, string) {
+	end := strings.IndexAny(s, ".[")
+	if end < 0 {
+		return s, ""
+	}
+	return s[:end], s[end:]
+}
@@ -0,0 +1,84 @@
+package valueobject
+
+import (
+	"testing"
+)
+
+func TestRedaction_Apply(t *testing.T) {
+	payload := `{"user":{"email":"a@example.com","name":"Ann"},"cards":[{"pan":"4111","exp":"12/30"},{"pan":"5500"}],"amount":42.10}`
+
+	tests := []struct {
+		name    string
+		paths   []string
+		payload string
+		want    string
+	}{
+		{
+			name:    "no paths leaves the payload untouched",
+			payload: payload,
+			want:    payload,
+		},
+		{
+			name:    "nested key",
+			paths:   []string{"$.user.email"},
+			payload: payload,
+			want:    `{"amount":42.10,"cards":[{"exp":"12/30","pan":"4111"},{"pan":"5500"}],"user":{"email":"[REDACTED]","name":"Ann"}}`,
+		},
+		{
+			name:    "every array element",
+			paths:   []string{"$.cards[*].pan"},
+			payload: payload,
+			want:    `{"amount":42.10,"cards":[{"exp":"12/30","pan":"[REDACTED]"},{"pan":"[REDACTED]"}],"user":{"email":"a@example.com","name":"Ann"}}`,
+		},
+		{
+			name:    "one array element",
+			paths:   []string{"$.cards[1]"},
+			payload: payload,
+			want:    `{"amount":42.10,"cards":[{"exp":"12/30","pan":"4111"},"[REDACTED]"],"user":{"email":"a@example.com","name":"Ann"}}`,
+		},
+		{
+			name:    "wildcard key",
+			paths:   []string{"$.user.*"},
+			payload: payload,
+			want:    `{"amount":42.10,"cards":[{"exp":"12/30","pan":"4111"},{"pan":"5500"}],"user":{"email":"[REDACTED]","name":"[REDACTED]"}}`,
+		},
+		{
+			name:    "key at any depth",
+			paths:   []string{"$..pan", "$..email"},
+			payload: payload,
+			want:    `{"amount":42.10,"cards":[{"exp":"12/30","pan":"[REDACTED]"},{"pan":"[REDACTED]"}],"user":{"email":"[REDACTED]","name":"Ann"}}`,
+		},
+		{
+			name:    "missing path changes nothing but key order",
+			paths:   []string{"$.nope.deeper"},
+			payload: `{"b":1,"a":"<x>"}`,
+			want:    `{"a":"<x>","b":1}`,
+		},
+		{
+			name:    "non-JSON payload is masked whole",
+			paths:   []string{"$.user"},
+			payload: "email=a@example.com",
+			want:    Redacted,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := NewRedaction(tt.paths)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := r.Apply(tt.payload); got != tt.want {
+				t.Fatalf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestNewRedaction_invalid(t *testing.T) {
+	for _, path := range []string{"user.email", "$", "$.", "$..", "$.a[", "$.a[x]", "$.a[-1]", "$a"} {
+		if _, err := NewRedaction([]string{path}); err == nil {
+			t.Errorf("expected an error for %q", path)
+		}
+	}
+}
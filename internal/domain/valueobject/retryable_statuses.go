@@ -0,0 +1,58 @@
+package valueobject
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// RetryableStatuses is an immutable set of HTTP response statuses worth
+// retrying. Failures with any other status are permanent. The zero value
+// retries every status.
+type RetryableStatuses struct {
+	codes   map[int]bool
+	classes [6]bool // indexed by status / 100
+}
+
+// NewRetryableStatuses parses specs, each a status such as "429" or a class
+// such as "5xx".
+func NewRetryableStatuses(specs []string) (RetryableStatuses, error) {
+	r := RetryableStatuses{codes: make(map[int]bool)}
+	for _, spec := range specs {
+		spec = strings.ToLower(strings.TrimSpace(spec))
+		if spec == "" {
+			continue
+		}
+		if class, ok := strings.CutSuffix(spec, "xx"); ok {
+			n, err := strconv.Atoi(class)
+			if err != nil || n < 1 || n > 5 {
+				return RetryableStatuses{}, fmt.Errorf("invalid status class %q", spec)
+			}
+			r.classes[n] = true
+			continue
+		}
+		status, err := strconv.Atoi(spec)
+		if err != nil || status < 100 || status > 599 {
+			return RetryableStatuses{}, fmt.Errorf("invalid status %q", spec)
+		}
+		r.codes[status] = true
+	}
+	return r, nil
+}
+
+// Empty reports whether no statuses are listed, so every status is retried.
+func (r RetryableStatuses) Empty() bool {
+	return len(r.codes) == 0 && r.classes == [6]bool{}
+}
+
+// Retryable reports whether a failure with status is worth retrying.
+func (r RetryableStatuses) Retryable(status int) bool {
+	if r.Empty() {
+		return true
+	}
+	if r.codes[status] {
+		return true
+	}
+	class := status / 100
+	return class >= 1 && class <= 5 && r.classes[class]
+}
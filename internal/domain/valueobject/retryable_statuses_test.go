@@ -0,0 +1,38 @@
+package valueobject
+
+import "testing"
+
+func TestRetryableStatuses(t *testing.T) {
+	r, err := NewRetryableStatuses([]string{"408", " 429", "5XX", ""})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for status, want := range map[int]bool{
+		408: true,
+		429: true,
+		500: true,
+		503: true,
+		400: false,
+		404: false,
+		401: false,
+		302: false,
+	} {
+		if got := r.Retryable(status); got != want {
+			t.Errorf("Retryable(%d) = %v, want %v", status, got, want)
+		}
+	}
+
+	var all RetryableStatuses
+	if !all.Empty() || !all.Retryable(400) {
+		t.Fatal("expected the zero value to retry every status")
+	}
+}
+
+func TestNewRetryableStatuses_invalid(t *testing.T) {
+	for _, spec := range []string{"abc", "99", "600", "6xx", "0xx", "4x"} {
+		if _, err := NewRetryableStatuses([]string{spec}); err == nil {
+			t.Errorf("expected an error for %q", spec)
+		}
+	}
+}
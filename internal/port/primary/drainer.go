@@ -0,0 +1,14 @@
+package primary
+
+import "github.com/ruudy-sib/rebound/internal/domain/entity"
+
+// Drainer defines the primary port for draining this instance's worker
+// before it is shut down.
+type Drainer interface {
+	// Drain stops the worker fetching new tasks. A poll under way finishes
+	// its deliveries. Calling it again has no effect.
+	Drain() entity.DrainStatus
+
+	// DrainStatus reports whether draining was requested and has finished.
+	DrainStatus() entity.DrainStatus
+}
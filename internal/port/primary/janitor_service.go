@@ -0,0 +1,19 @@
+package primary
+
+import (
+	"context"
+	"time"
+
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+// JanitorService defines the primary port for periodic state repair.
+type JanitorService interface {
+	// Reconcile runs one repair pass unless another instance ran one
+	// within lease, in which case it returns a nil report.
+	Reconcile(ctx context.Context, lease time.Duration) (*entity.RepairReport, error)
+
+	// ReconcileNow runs a repair pass immediately, regardless of the
+	// periodic claim, and reports what it fixed.
+	ReconcileNow(ctx context.Context) (*entity.RepairReport, error)
+}
@@ -0,0 +1,21 @@
+package primary
+
+import (
+	"context"
+	"time"
+
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+// MaintenanceService defines the primary port for managing destination
+// maintenance windows.
+type MaintenanceService interface {
+	// StartMaintenance holds tasks for destination until the given time.
+	StartMaintenance(ctx context.Context, destination string, until time.Time) error
+
+	// EndMaintenance releases tasks for destination immediately.
+	EndMaintenance(ctx context.Context, destination string) error
+
+	// ListMaintenance returns all active maintenance windows.
+	ListMaintenance(ctx context.Context) ([]entity.MaintenanceWindow, error)
+}
@@ -0,0 +1,11 @@
+package primary
+
+import "github.com/ruudy-sib/rebound/internal/domain/entity"
+
+// ProducerStatsService defines the primary port for reading producer
+// performance per destination.
+type ProducerStatsService interface {
+	// ProducerStats returns the stats of every destination written to,
+	// ordered by destination type and name.
+	ProducerStats() []entity.ProducerStats
+}
@@ -0,0 +1,29 @@
+package primary
+
+import (
+	"context"
+
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+// QueueService defines the primary port for whole-queue admin operations.
+type QueueService interface {
+	// Stats returns a size snapshot of every queue.
+	Stats(ctx context.Context) ([]entity.QueueStats, error)
+
+	// PreparePurge reports how many tasks purging queue would drop and
+	// issues a short-lived token confirming the purge.
+	PreparePurge(ctx context.Context, queue entity.Queue) (*entity.PurgePlan, error)
+
+	// Purge drops every task in queue, given the token from PreparePurge,
+	// and returns how many were dropped.
+	Purge(ctx context.Context, queue entity.Queue, token string) (int64, error)
+
+	// ListCorrupt returns up to limit scheduled members that could not be
+	// decoded, with how many were ever found.
+	ListCorrupt(ctx context.Context, limit int) (*entity.CorruptReport, error)
+
+	// DeleteCorrupt removes a corrupt member by ID. It returns
+	// domain.ErrTaskNotFound if there is none.
+	DeleteCorrupt(ctx context.Context, id string) error
+}
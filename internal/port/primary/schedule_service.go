@@ -0,0 +1,32 @@
+package primary
+
+import (
+	"context"
+	"time"
+
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+// ScheduleService defines the primary port for operations on the schedule
+// as a whole.
+type ScheduleService interface {
+	// Forecast counts the tasks becoming due per minute over the coming window.
+	Forecast(ctx context.Context, window time.Duration) (*entity.ScheduleForecast, error)
+
+	// Upcoming returns up to limit scheduled tasks after the cursor,
+	// earliest due first, and the cursor of the next page, which is zero on
+	// the last one.
+	Upcoming(ctx context.Context, after entity.Cursor, limit int) ([]entity.ScheduledTask, entity.Cursor, error)
+
+	// ByDestination returns up to limit scheduled tasks targeting the given
+	// URL or topic, earliest due first.
+	ByDestination(ctx context.Context, destination string, limit int) ([]entity.ScheduledTask, error)
+
+	// Shift moves the due time of every scheduled task matching filter and
+	// returns how many were moved.
+	Shift(ctx context.Context, filter entity.TaskFilter, by time.Duration) (int, error)
+
+	// Reschedule moves one scheduled task's due time in place and returns
+	// its old and new due times.
+	Reschedule(ctx context.Context, taskID string, change entity.Reschedule) (time.Time, time.Time, error)
+}
@@ -0,0 +1,13 @@
+package primary
+
+import (
+	"context"
+
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+// SLAService defines the primary port for reading delivery SLA statistics.
+type SLAService interface {
+	// TimeToSuccess returns time-to-success percentiles per client and destination.
+	TimeToSuccess(ctx context.Context) ([]entity.TimeToSuccessStats, error)
+}
@@ -2,6 +2,7 @@ package primary
 
 import (
 	"context"
+	"time"
 
 	"github.com/ruudy-sib/rebound/internal/domain/entity"
 )
@@ -10,8 +11,59 @@ import (
 // exposed to driving adapters (HTTP handlers, CLI, etc.).
 type TaskService interface {
 	// CreateTask validates and schedules a new task for immediate processing.
+	// It returns domain.ErrQueueFull when too many tasks are already scheduled.
 	CreateTask(ctx context.Context, task *entity.Task) error
 
-	// ProcessDueTasks fetches and processes all tasks whose scheduled time has passed.
-	ProcessDueTasks(ctx context.Context) error
+	// CreateTaskAt validates and schedules a new task whose first attempt
+	// is due at the given time.
+	CreateTaskAt(ctx context.Context, task *entity.Task, at time.Time) error
+
+	// CreateTasksPaced schedules a set of tasks with their first attempts
+	// spread evenly over window, e.g. for large broadcasts.
+	CreateTasksPaced(ctx context.Context, tasks []*entity.Task, window time.Duration) error
+
+	// GetTask returns a scheduled task without changing it. It returns
+	// domain.ErrTaskNotFound if no such task is scheduled.
+	GetTask(ctx context.Context, taskID string) (*entity.Task, error)
+
+	// CancelTask removes a scheduled task from the queue, keeping it
+	// restorable for a limited time.
+	CancelTask(ctx context.Context, taskID string) error
+
+	// GetCancelledTask returns a cancelled task that can still be restored,
+	// without restoring it. It returns domain.ErrTaskNotFound if there is
+	// none.
+	GetCancelledTask(ctx context.Context, taskID string) (*entity.Task, error)
+
+	// RestoreTask reschedules a cancelled task at its original due time,
+	// or immediately if that time has passed.
+	RestoreTask(ctx context.Context, taskID string) error
+
+	// PrioritizeTask marks a scheduled task as priority in place, and with
+	// now set makes it due immediately. It returns the task's due time.
+	PrioritizeTask(ctx context.Context, taskID string, now bool) (time.Time, error)
+
+	// DeliveryResult returns the recorded result of the task's successful
+	// delivery. It returns domain.ErrTaskNotFound if none is recorded.
+	DeliveryResult(ctx context.Context, taskID string) (*entity.DeliveryResult, error)
+
+	// ListDeadLetters returns up to limit tasks from the dead-letter store
+	// after the cursor, most recently stored first, and the cursor of the
+	// next page, which is zero on the last one.
+	ListDeadLetters(ctx context.Context, after entity.Cursor, limit int) ([]entity.DeadLetter, entity.Cursor, error)
+
+	// RequeueDeadLetter schedules a dead-lettered task for immediate
+	// delivery with a fresh retry budget.
+	RequeueDeadLetter(ctx context.Context, taskID string) error
+
+	// OverflowStats reports how many new tasks the overflow policy rejected,
+	// dropped room for, spilled and restored since the service started.
+	OverflowStats() entity.OverflowStats
+
+	// ProcessDueTasks fetches and processes all tasks whose scheduled time
+	// has passed, and reports what happened to each of them.
+	ProcessDueTasks(ctx context.Context) (entity.ProcessResult, error)
+
+	// ProcessDueTasksIn does the same for the due tasks of the named queue.
+	ProcessDueTasksIn(ctx context.Context, queue string) (entity.ProcessResult, error)
 }
@@ -0,0 +1,15 @@
+package primary
+
+import (
+	"context"
+
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+// UsageService defines the primary port for reading per-client usage for
+// billing and quota enforcement.
+type UsageService interface {
+	// Usage returns the counters of every client in the billing period,
+	// such as "2024-05", ordered by client ID.
+	Usage(ctx context.Context, period string) ([]entity.ClientUsage, error)
+}
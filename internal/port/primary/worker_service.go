@@ -0,0 +1,13 @@
+package primary
+
+import (
+	"context"
+
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+// WorkerService defines the primary port for fleet visibility.
+type WorkerService interface {
+	// Workers returns every registered worker instance, ordered by ID.
+	Workers(ctx context.Context) ([]entity.WorkerInstance, error)
+}
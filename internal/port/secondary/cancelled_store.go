@@ -0,0 +1,32 @@
+package secondary
+
+import (
+	"context"
+	"time"
+
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+// CancelledTask is a cancelled task together with when it was due and when
+// it was cancelled.
+type CancelledTask struct {
+	Task        *entity.Task
+	DueAt       time.Time
+	CancelledAt time.Time
+}
+
+// CancelledStore defines the secondary port for the holding area of
+// cancelled tasks. Entries expire after their TTL, after which the
+// cancellation can no longer be undone.
+type CancelledStore interface {
+	// Hold keeps a cancelled task restorable for ttl.
+	Hold(ctx context.Context, cancelled CancelledTask, ttl time.Duration) error
+
+	// Get returns a held task without removing it. It returns
+	// domain.ErrTaskNotFound if the task is not held or has expired.
+	Get(ctx context.Context, taskID string) (*CancelledTask, error)
+
+	// Take removes and returns a held task. It returns
+	// domain.ErrTaskNotFound if the task is not held or has expired.
+	Take(ctx context.Context, taskID string) (*CancelledTask, error)
+}
@@ -0,0 +1,18 @@
+package secondary
+
+import (
+	"context"
+	"time"
+)
+
+// CompletionStore defines the secondary port for recording successful
+// deliveries, so a task ID that was already delivered is never delivered
+// again after a crash or retry race.
+type CompletionStore interface {
+	// MarkCompleted records that the task was delivered. The marker expires
+	// after ttl.
+	MarkCompleted(ctx context.Context, taskID string, ttl time.Duration) error
+
+	// IsCompleted reports whether a completion marker exists for the task.
+	IsCompleted(ctx context.Context, taskID string) (bool, error)
+}
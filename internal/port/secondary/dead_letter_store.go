@@ -0,0 +1,24 @@
+package secondary
+
+import (
+	"context"
+
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+// DeadLetterStore defines the secondary port for persisting exhausted tasks
+// that could not be delivered to their dead-letter destination, so they are
+// never silently dropped.
+type DeadLetterStore interface {
+	// Store persists the task together with the reason it ended up here.
+	Store(ctx context.Context, task *entity.Task, reason string) error
+
+	// List returns up to limit stored tasks after the cursor, most recently
+	// stored first, and the cursor of the next page, which is zero on the
+	// last one.
+	List(ctx context.Context, after entity.Cursor, limit int) ([]entity.DeadLetter, entity.Cursor, error)
+
+	// Take removes a stored task and returns it. It returns
+	// domain.ErrTaskNotFound if no task with that ID is stored.
+	Take(ctx context.Context, taskID string) (*entity.DeadLetter, error)
+}
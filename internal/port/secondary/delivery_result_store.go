@@ -0,0 +1,20 @@
+package secondary
+
+import (
+	"context"
+	"time"
+
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+// DeliveryResultStore defines the secondary port for keeping the results of
+// successful deliveries for a while, keyed by task ID.
+type DeliveryResultStore interface {
+	// Save records the result, replacing any earlier result for the same
+	// task. It expires after ttl.
+	Save(ctx context.Context, result entity.DeliveryResult, ttl time.Duration) error
+
+	// Get returns the result recorded for the task. It returns
+	// domain.ErrTaskNotFound if there is none.
+	Get(ctx context.Context, taskID string) (*entity.DeliveryResult, error)
+}
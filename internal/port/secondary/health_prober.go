@@ -0,0 +1,14 @@
+package secondary
+
+import (
+	"context"
+
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+// HealthProber checks whether a destination is reachable without delivering
+// anything to it.
+type HealthProber interface {
+	// Probe returns an error if the destination looks down.
+	Probe(ctx context.Context, destination entity.Destination) error
+}
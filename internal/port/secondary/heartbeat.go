@@ -0,0 +1,28 @@
+package secondary
+
+import (
+	"context"
+	"time"
+
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+// HeartbeatStore defines the secondary port for recording that this
+// instance's worker loop is processing, so readiness reflects actual
+// processing rather than just a reachable Redis.
+type HeartbeatStore interface {
+	// Beat records a heartbeat that expires after ttl.
+	Beat(ctx context.Context, ttl time.Duration) error
+
+	// RecordPoll adds the tasks a poll attempted, and how many of those
+	// attempts failed, to this instance's counters.
+	RecordPoll(ctx context.Context, processed, errors int) error
+}
+
+// WorkerRegistry defines the secondary port for listing the worker
+// replicas sharing the schedule.
+type WorkerRegistry interface {
+	// Workers returns every registered instance with its last heartbeat
+	// and counters. Stale is left unset.
+	Workers(ctx context.Context) ([]entity.WorkerInstance, error)
+}
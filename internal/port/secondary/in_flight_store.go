@@ -0,0 +1,28 @@
+package secondary
+
+import (
+	"context"
+	"time"
+
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+// InFlightStore defines the secondary port for remembering the tasks a
+// worker has fetched but not yet settled, so tasks held by a worker that
+// died mid-delivery can be found and delivered again.
+type InFlightStore interface {
+	// Track records the tasks as in flight until deadline.
+	Track(ctx context.Context, tasks []*entity.Task, deadline time.Time) error
+
+	// Extend moves the deadline of an in-flight task to deadline unless it
+	// is already later. It returns domain.ErrTaskNotFound if the task is no
+	// longer in flight, e.g. because it was reclaimed.
+	Extend(ctx context.Context, taskID string, deadline time.Time) error
+
+	// Release forgets the tasks with the given IDs once they are settled.
+	Release(ctx context.Context, taskIDs []string) error
+
+	// Reclaim removes and returns up to limit tasks still in flight after
+	// their deadline passed. Each task is returned to one caller only.
+	Reclaim(ctx context.Context, now time.Time, limit int) ([]*entity.Task, error)
+}
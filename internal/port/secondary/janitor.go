@@ -0,0 +1,20 @@
+package secondary
+
+import (
+	"context"
+	"time"
+
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+// StateReconciler defines the secondary port for repairing derived state,
+// such as indexes, that drifts from the data it describes when a process
+// dies between related writes.
+type StateReconciler interface {
+	// Claim reports whether this instance may run the next pass. At most
+	// one instance holds a claim at a time; it lapses after lease.
+	Claim(ctx context.Context, lease time.Duration) (bool, error)
+
+	// Reconcile runs one repair pass and reports what it fixed.
+	Reconcile(ctx context.Context) (entity.RepairReport, error)
+}
@@ -0,0 +1,23 @@
+package secondary
+
+import (
+	"context"
+
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+// MaintenanceStore defines the secondary port for per-destination
+// maintenance windows. Expired windows are never returned.
+type MaintenanceStore interface {
+	// Set declares or replaces the maintenance window for its destination.
+	Set(ctx context.Context, window entity.MaintenanceWindow) error
+
+	// Clear ends the maintenance window for destination, if any.
+	Clear(ctx context.Context, destination string) error
+
+	// Get returns the active window for destination, or nil if there is none.
+	Get(ctx context.Context, destination string) (*entity.MaintenanceWindow, error)
+
+	// List returns all active windows.
+	List(ctx context.Context) ([]entity.MaintenanceWindow, error)
+}
@@ -2,6 +2,7 @@ package secondary
 
 import (
 	"context"
+	"time"
 
 	"github.com/ruudy-sib/rebound/internal/domain/entity"
 )
@@ -10,8 +11,50 @@ import (
 // to an external destination (e.g., Kafka, SQS).
 type MessageProducer interface {
 	// Produce sends a message to the specified destination.
-	Produce(ctx context.Context, destination entity.Destination, key, value []byte) error
+	Produce(ctx context.Context, destination entity.Destination, msg Message) error
 
 	// Close releases any resources held by the producer.
 	Close() error
 }
+
+// Message is a keyed payload. Headers carry task metadata and are sent as
+// HTTP headers or Kafka record headers. TransportHeaders are sent verbatim,
+// without the metadata prefix HTTP delivery adds, so they can carry e.g. an
+// Authorization header; they are never stored with the task.
+type Message struct {
+	Key              []byte
+	Value            []byte
+	Headers          map[string]string
+	TransportHeaders map[string]string
+
+	// Priority marks a message sent for a priority task: an attempt, its
+	// dead letter or its outcome callback. Producers send it ahead of bulk
+	// traffic where their transport allows.
+	Priority bool
+}
+
+// BatchProducer is implemented by producers that can deliver several
+// messages for the same destination in one round trip.
+type BatchProducer interface {
+	// ProduceBatch sends all messages to the destination at once. The batch
+	// succeeds or fails as a whole.
+	ProduceBatch(ctx context.Context, destination entity.Destination, messages []Message) error
+}
+
+// ReceiptProducer is implemented by producers that can report what the
+// destination answered to a delivery.
+type ReceiptProducer interface {
+	// ProduceWithReceipt sends a message like Produce and returns the
+	// destination's receipt when it succeeds.
+	ProduceWithReceipt(ctx context.Context, destination entity.Destination, msg Message) (entity.DeliveryReceipt, error)
+}
+
+// RateLimitedProducer is implemented by producers that learn rate limits
+// from the destinations they deliver to.
+type RateLimitedProducer interface {
+	// RateLimitedFor returns how long until the destination accepts
+	// deliveries again if it has reported its budget as spent, and zero
+	// otherwise. The wait is relative so that callers need not share the
+	// producer's clock.
+	RateLimitedFor(destination entity.Destination) time.Duration
+}
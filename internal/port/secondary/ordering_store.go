@@ -0,0 +1,21 @@
+package secondary
+
+import (
+	"context"
+
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+// OrderingStore defines the secondary port for strict per-key FIFO delivery.
+// At most one task per ordering key is active (scheduled or in flight);
+// the others wait in arrival order until the active task is settled.
+type OrderingStore interface {
+	// Enqueue registers the task under its ordering key. It returns true if
+	// the task became the active task and should be scheduled now, or false
+	// if it was queued behind an earlier task.
+	Enqueue(ctx context.Context, task *entity.Task) (bool, error)
+
+	// Advance releases the active task for the key and promotes the next
+	// waiting task, which is returned. It returns nil when nothing is waiting.
+	Advance(ctx context.Context, orderingKey string) (*entity.Task, error)
+}
@@ -0,0 +1,17 @@
+package secondary
+
+import (
+	"context"
+
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+// OverflowStore defines the secondary port for parking new tasks while the
+// schedule is full.
+type OverflowStore interface {
+	// Spill appends the tasks to the store.
+	Spill(ctx context.Context, tasks ...*entity.Task) error
+
+	// Restore removes and returns up to limit tasks, oldest spilled first.
+	Restore(ctx context.Context, limit int) ([]*entity.Task, error)
+}
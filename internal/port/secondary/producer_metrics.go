@@ -0,0 +1,10 @@
+package secondary
+
+import "github.com/ruudy-sib/rebound/internal/domain/entity"
+
+// ProducerMetrics defines the secondary port producers report each write
+// to, so a slow destination can be told apart from a slow rebound.
+type ProducerMetrics interface {
+	// ObserveWrite records one write. It must not block.
+	ObserveWrite(write entity.ProducerWrite)
+}
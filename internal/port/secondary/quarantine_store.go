@@ -0,0 +1,14 @@
+package secondary
+
+import (
+	"context"
+
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+// QuarantineStore defines the secondary port for parking tasks that were
+// detected as poison messages so they stop consuming retry capacity.
+type QuarantineStore interface {
+	// Quarantine stores the task together with the reason it was isolated.
+	Quarantine(ctx context.Context, task *entity.Task, reason string) error
+}
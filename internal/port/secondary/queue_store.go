@@ -0,0 +1,41 @@
+package secondary
+
+import (
+	"context"
+	"time"
+
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+// QueueStore defines the secondary port for whole-queue admin operations.
+type QueueStore interface {
+	// Count returns the number of tasks in the queue.
+	Count(ctx context.Context, queue entity.Queue) (int64, error)
+
+	// Stats returns a cheap size estimate for the queue, counting tasks due
+	// by now separately where the queue has due times.
+	Stats(ctx context.Context, queue entity.Queue, now time.Time) (entity.QueueStats, error)
+
+	// Purge drops every task in the queue and returns how many were dropped.
+	Purge(ctx context.Context, queue entity.Queue) (int64, error)
+
+	// SavePurgeToken records token as the pending purge confirmation for
+	// the queue, replacing any earlier one.
+	SavePurgeToken(ctx context.Context, queue entity.Queue, token string, ttl time.Duration) error
+
+	// TakePurgeToken removes the pending confirmation for the queue and
+	// reports whether it matched token. A mismatch still removes it.
+	TakePurgeToken(ctx context.Context, queue entity.Queue, token string) (bool, error)
+
+	// ListCorrupt returns up to limit corrupt members, most recently found
+	// first.
+	ListCorrupt(ctx context.Context, limit int) ([]entity.CorruptMember, error)
+
+	// CorruptTotal returns how many members were ever moved aside as
+	// corrupt.
+	CorruptTotal(ctx context.Context) (int64, error)
+
+	// DeleteCorrupt removes the corrupt member with the given ID and
+	// reports whether it existed.
+	DeleteCorrupt(ctx context.Context, id string) (bool, error)
+}
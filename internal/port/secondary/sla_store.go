@@ -0,0 +1,25 @@
+package secondary
+
+import (
+	"context"
+	"time"
+)
+
+// TimeToSuccessSamples holds recent time-to-success samples for one client
+// and destination.
+type TimeToSuccessSamples struct {
+	ClientID    string
+	Destination string
+	Samples     []time.Duration
+}
+
+// SLAStore defines the secondary port for recording how long tasks took
+// from creation to successful delivery. Implementations keep a bounded
+// number of recent samples per client and destination.
+type SLAStore interface {
+	// Record adds a time-to-success sample.
+	Record(ctx context.Context, clientID, destination string, timeToSuccess time.Duration) error
+
+	// Samples returns the retained samples of every client and destination.
+	Samples(ctx context.Context) ([]TimeToSuccessSamples, error)
+}
@@ -0,0 +1,14 @@
+package secondary
+
+import (
+	"context"
+
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+// TaskNotifier defines the secondary port for announcing tasks that reached
+// a terminal state.
+type TaskNotifier interface {
+	// Notify announces every task in outcomes.
+	Notify(ctx context.Context, outcomes []entity.TaskOutcome) error
+}
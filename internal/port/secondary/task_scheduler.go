@@ -13,10 +13,51 @@ type TaskScheduler interface {
 	// Schedule adds a task to the queue with the given delay from now.
 	Schedule(ctx context.Context, task *entity.Task, delay time.Duration) error
 
-	// FetchDue retrieves up to limit tasks whose scheduled time has passed.
-	FetchDue(ctx context.Context, limit int) ([]*entity.Task, error)
+	// FetchDue retrieves up to limit tasks of the named queue whose
+	// scheduled time has passed. The empty name is the default queue.
+	FetchDue(ctx context.Context, queue string, limit int) ([]*entity.Task, error)
 
 	// Remove removes a task from the queue. The raw member is used for
 	// exact match removal from the sorted set.
 	Remove(ctx context.Context, rawMember string) error
+
+	// Get returns the scheduled task with the given ID without removing
+	// it. It returns domain.ErrTaskNotFound if no such task is scheduled.
+	Get(ctx context.Context, taskID string) (*entity.Task, error)
+
+	// Dequeue removes the scheduled task with the given ID and returns it
+	// with the time it was due. It returns domain.ErrTaskNotFound if no
+	// such task is scheduled.
+	Dequeue(ctx context.Context, taskID string) (*entity.Task, time.Time, error)
+
+	// Reschedule moves the due time of the scheduled task with the given
+	// ID without taking it out of the schedule, and returns its old and new
+	// due times. It returns domain.ErrTaskNotFound if no such task is
+	// scheduled.
+	Reschedule(ctx context.Context, taskID string, change entity.Reschedule) (time.Time, time.Time, error)
+
+	// Peek returns up to limit scheduled tasks after the cursor, earliest
+	// due first, without removing them, and the cursor of the next page,
+	// which is zero on the last one.
+	Peek(ctx context.Context, after entity.Cursor, limit int) ([]entity.ScheduledTask, entity.Cursor, error)
+
+	// FindByDestination returns up to limit scheduled tasks whose
+	// destination URL or topic is destination, earliest due first.
+	FindByDestination(ctx context.Context, destination string, limit int) ([]entity.ScheduledTask, error)
+
+	// Count returns how many tasks are scheduled, due or not.
+	Count(ctx context.Context) (int64, error)
+
+	// CountByDestination returns about how many tasks are scheduled for
+	// the destination URL or topic, due or not.
+	CountByDestination(ctx context.Context, destination string) (int64, error)
+
+	// CountDueBy returns, for each of the given times, how many scheduled
+	// tasks are due at or before it.
+	CountDueBy(ctx context.Context, times []time.Time) ([]int64, error)
+
+	// Shift moves the due time of every scheduled task matching filter by
+	// the given amount and returns how many were moved. Tasks picked up
+	// while the shift is in progress are left alone.
+	Shift(ctx context.Context, filter entity.TaskFilter, by time.Duration) (int, error)
 }
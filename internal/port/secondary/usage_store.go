@@ -0,0 +1,17 @@
+package secondary
+
+import (
+	"context"
+
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+// UsageStore defines the secondary port for per-client usage counters,
+// kept per billing period.
+type UsageStore interface {
+	// Add increments the counters of every client in usage for its period.
+	Add(ctx context.Context, usage []entity.ClientUsage) error
+
+	// Usage returns the counters of every client that used period.
+	Usage(ctx context.Context, period string) ([]entity.ClientUsage, error)
+}
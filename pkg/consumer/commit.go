@@ -0,0 +1,74 @@
+package consumer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// CommitMode decides when the consumer commits a message's offset.
+type CommitMode int
+
+const (
+	// AtLeastOnce commits once the message was handled or its retry was
+	// scheduled. A crash in between redelivers the message. This is the
+	// default.
+	AtLeastOnce CommitMode = iota
+
+	// AtMostOnce commits before the message is handled. A crash while
+	// handling loses the message, but it is never handled twice. If the
+	// commit fails, the message is skipped.
+	AtMostOnce
+
+	// ManualCommit never commits on its own. The handler commits with
+	// Commit, or the application with Consumer.Commit, e.g. once a batch
+	// of messages has been flushed to storage. Failed messages are still
+	// scheduled for retry.
+	ManualCommit
+)
+
+func (m CommitMode) String() string {
+	switch m {
+	case AtLeastOnce:
+		return "at-least-once"
+	case AtMostOnce:
+		return "at-most-once"
+	case ManualCommit:
+		return "manual"
+	default:
+		return fmt.Sprintf("CommitMode(%d)", int(m))
+	}
+}
+
+// ErrNoMessage is returned by Commit when ctx was not passed to a Handler.
+var ErrNoMessage = errors.New("no message to commit in context")
+
+type committerKey struct{}
+
+// committer commits the message it was created for.
+type committer func(ctx context.Context) error
+
+func withCommitter(ctx context.Context, reader Reader, msg kafka.Message) context.Context {
+	return context.WithValue(ctx, committerKey{}, committer(func(ctx context.Context) error {
+		return reader.CommitMessages(ctx, msg)
+	}))
+}
+
+// Commit commits the offset of the message being handled. Call it from a
+// Handler with the context it was given; it is meant for ManualCommit, but
+// works in every mode.
+func Commit(ctx context.Context) error {
+	commit, ok := ctx.Value(committerKey{}).(committer)
+	if !ok {
+		return ErrNoMessage
+	}
+	return commit(ctx)
+}
+
+// Commit commits the offsets of msgs. Use it with ManualCommit to commit
+// messages after their handler has returned.
+func (c *Consumer) Commit(ctx context.Context, msgs ...kafka.Message) error {
+	return c.reader.CommitMessages(ctx, msgs...)
+}
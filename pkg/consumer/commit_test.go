@@ -0,0 +1,68 @@
+package consumer
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/segmentio/kafka-go"
+)
+
+func TestConsumer_CommitModes(t *testing.T) {
+	tests := []struct {
+		name              string
+		mode              CommitMode
+		handlerCommits    bool
+		wantBeforeHandler int
+		wantAfterHandler  int
+	}{
+		{name: "at least once commits after handling", mode: AtLeastOnce, wantAfterHandler: 1},
+		{name: "at most once commits before handling", mode: AtMostOnce, wantBeforeHandler: 1, wantAfterHandler: 1},
+		{name: "manual leaves commits to the handler", mode: ManualCommit},
+		{name: "manual commit from the handler", mode: ManualCommit, handlerCommits: true, wantAfterHandler: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader := &fakeReader{}
+			committedBefore := -1
+			handler := func(ctx context.Context, _ kafka.Message) error {
+				committedBefore = len(reader.commits())
+				if tt.handlerCommits {
+					return Commit(ctx)
+				}
+				return nil
+			}
+
+			cfg := testConfig()
+			cfg.CommitMode = tt.mode
+			c, err := newConsumer(cfg, reader, &fakeScheduler{}, handler)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			c.process(context.Background(), kafka.Message{Topic: "invoices", Offset: 3})
+
+			if committedBefore != tt.wantBeforeHandler {
+				t.Fatalf("expected %d commits before the handler, got %d", tt.wantBeforeHandler, committedBefore)
+			}
+			if got := len(reader.commits()); got != tt.wantAfterHandler {
+				t.Fatalf("expected %d commits, got %d", tt.wantAfterHandler, got)
+			}
+		})
+	}
+}
+
+func TestCommit_OutsideHandler(t *testing.T) {
+	if err := Commit(context.Background()); !errors.Is(err, ErrNoMessage) {
+		t.Fatalf("expected ErrNoMessage, got %v", err)
+	}
+}
+
+func TestNewConsumer_RejectsUnknownCommitMode(t *testing.T) {
+	cfg := testConfig()
+	cfg.CommitMode = CommitMode(7)
+	if _, err := newConsumer(cfg, &fakeReader{}, &fakeScheduler{}, func(context.Context, kafka.Message) error { return nil }); err == nil {
+		t.Fatal("expected an error for an unknown commit mode")
+	}
+}
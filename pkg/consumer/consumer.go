@@ -0,0 +1,324 @@
+// Package consumer reads messages from a Kafka topic and hands those that
+// fail processing to rebound, which redelivers them with exponential
+// backoff. A failing message therefore never blocks the partition it came
+// from.
+package consumer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync/atomic"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/pkg/rebound"
+)
+
+// Handler processes one message. A non-nil error schedules the message for
+// retry through rebound.
+type Handler func(ctx context.Context, msg kafka.Message) error
+
+// Scheduler creates rebound tasks. *rebound.Rebound implements it.
+type Scheduler interface {
+	CreateTask(ctx context.Context, task *rebound.Task) error
+}
+
+// Reader is the subset of *kafka.Reader the consumer uses.
+type Reader interface {
+	FetchMessage(ctx context.Context) (kafka.Message, error)
+	CommitMessages(ctx context.Context, msgs ...kafka.Message) error
+	Close() error
+}
+
+// Config holds configuration for a Consumer.
+type Config struct {
+	// Brokers, GroupID and Topic select what is consumed. The first broker
+	// is also where retries and dead letters are produced.
+	Brokers []string
+	GroupID string
+	Topic   string
+
+	// Concurrency is how many messages are handled at once. Messages of one
+	// partition are always handled in order by the same goroutine.
+	// Defaults to 1.
+	Concurrency int
+
+	// CommitMode decides when offsets are committed. Defaults to
+	// AtLeastOnce.
+	CommitMode CommitMode
+
+	// CommitInterval batches offset commits, flushing them at this
+	// interval. Zero commits every message synchronously.
+	CommitInterval time.Duration
+
+	// RetryTopic receives failed messages once rebound redelivers them.
+	// Defaults to Topic, so retries are consumed by this consumer again.
+	// It is ignored when Tiers are set.
+	RetryTopic string
+
+	// Tiers switch the consumer to the retry-topic pattern. A message
+	// failing on Topic is moved to the first tier's topic after that tier's
+	// delay, one failing there to the next tier, and one failing on the last
+	// tier to DeadLetterTopic. The consumer reads Topic and every tier
+	// topic, and tracks the attempt in the HeaderAttempt header.
+	Tiers []Tier
+
+	// DeadLetterTopic receives messages whose retries are exhausted.
+	// Defaults to Topic with a "-dlq" suffix.
+	DeadLetterTopic string
+
+	// MaxRetries and BaseDelay (in seconds) are the retry policy of the
+	// tasks created for failed messages. They default to 3 and 5. With
+	// Tiers, MaxRetries only covers producing into the next topic, and each
+	// tier's delay replaces BaseDelay.
+	MaxRetries int
+	BaseDelay  int
+
+	// Source and ClientID identify the created tasks. They default to
+	// "kafka-consumer" and GroupID.
+	Source   string
+	ClientID string
+
+	// Logger (if nil, a default logger will be created)
+	Logger *zap.Logger
+}
+
+// Tier is one stage of the retry-topic pattern: failed messages wait Delay
+// before they are produced to Topic. Delay must be between one second and
+// one hour.
+type Tier struct {
+	Topic string
+	Delay time.Duration
+}
+
+// Bounds of a tier delay, matching the base delays rebound accepts.
+const (
+	minTierDelay = time.Second
+	maxTierDelay = time.Hour
+)
+
+// Default retry policy for failed messages.
+const (
+	DefaultMaxRetries = 3
+	DefaultBaseDelay  = 5
+)
+
+// maxScheduleBackoff caps the wait between attempts to hand a failed message
+// to rebound.
+const maxScheduleBackoff = 5 * time.Second
+
+// Consumer consumes one Kafka topic and schedules failed messages for retry.
+type Consumer struct {
+	loop      *fetchLoop
+	reader    Reader
+	scheduler Scheduler
+	handler   Handler
+	cfg       Config
+	broker    rebound.Destination
+	logger    *zap.Logger
+
+	succeeded    atomic.Int64
+	retried      atomic.Int64
+	deadLettered atomic.Int64
+}
+
+// New creates a Consumer reading cfg.Topic as part of cfg.GroupID, handling
+// each message with handler and scheduling failures with scheduler.
+func New(cfg Config, scheduler Scheduler, handler Handler) (*Consumer, error) {
+	if len(cfg.Brokers) == 0 {
+		return nil, errors.New("at least one broker is required")
+	}
+	if cfg.GroupID == "" || cfg.Topic == "" {
+		return nil, errors.New("group ID and topic are required")
+	}
+
+	readerCfg := kafka.ReaderConfig{
+		Brokers:        cfg.Brokers,
+		GroupID:        cfg.GroupID,
+		Topic:          cfg.Topic,
+		MinBytes:       1,
+		MaxBytes:       10e6, // 10MB
+		CommitInterval: cfg.CommitInterval,
+	}
+	if len(cfg.Tiers) > 0 {
+		readerCfg.Topic = ""
+		readerCfg.GroupTopics = []string{cfg.Topic}
+		for _, tier := range cfg.Tiers {
+			readerCfg.GroupTopics = append(readerCfg.GroupTopics, tier.Topic)
+		}
+	}
+	reader := kafka.NewReader(readerCfg)
+
+	c, err := newConsumer(cfg, reader, scheduler, handler)
+	if err != nil {
+		_ = reader.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+// newConsumer applies defaults to cfg and creates a Consumer over reader.
+func newConsumer(cfg Config, reader Reader, scheduler Scheduler, handler Handler) (*Consumer, error) {
+	if scheduler == nil || handler == nil {
+		return nil, errors.New("scheduler and handler are required")
+	}
+	if err := validateTiers(cfg.Topic, cfg.Tiers); err != nil {
+		return nil, err
+	}
+
+	host, port, err := net.SplitHostPort(cfg.Brokers[0])
+	if err != nil {
+		return nil, fmt.Errorf("parsing broker address %q: %w", cfg.Brokers[0], err)
+	}
+
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = 1
+	}
+	if cfg.CommitMode < AtLeastOnce || cfg.CommitMode > ManualCommit {
+		return nil, fmt.Errorf("unknown commit mode %d", cfg.CommitMode)
+	}
+	if cfg.RetryTopic == "" {
+		cfg.RetryTopic = cfg.Topic
+	}
+	if cfg.DeadLetterTopic == "" {
+		cfg.DeadLetterTopic = cfg.Topic + "-dlq"
+	}
+	if cfg.MaxRetries <= 0 {
+		cfg.MaxRetries = DefaultMaxRetries
+	}
+	if cfg.BaseDelay <= 0 {
+		cfg.BaseDelay = DefaultBaseDelay
+	}
+	if cfg.Source == "" {
+		cfg.Source = "kafka-consumer"
+	}
+	if cfg.ClientID == "" {
+		cfg.ClientID = cfg.GroupID
+	}
+
+	logger := cfg.Logger
+	if logger == nil {
+		logger, err = zap.NewProduction()
+		if err != nil {
+			return nil, fmt.Errorf("creating logger: %w", err)
+		}
+	}
+
+	logger = logger.Named("consumer")
+	return &Consumer{
+		loop:      newFetchLoop(reader, cfg.Concurrency, logger),
+		reader:    reader,
+		scheduler: scheduler,
+		handler:   handler,
+		cfg:       cfg,
+		broker:    rebound.Destination{Host: host, Port: port},
+		logger:    logger,
+	}, nil
+}
+
+// Run consumes messages until ctx is cancelled. Messages already fetched
+// are handled before it returns, so they are not consumed twice.
+func (c *Consumer) Run(ctx context.Context) error {
+	c.logger.Info("consumer started",
+		zap.String("topic", c.cfg.Topic),
+		zap.String("group_id", c.cfg.GroupID),
+		zap.Int("concurrency", c.cfg.Concurrency),
+		zap.Stringer("commit_mode", c.cfg.CommitMode),
+	)
+	return c.loop.run(ctx, c.process)
+}
+
+// process handles msg, schedules it for retry if that fails, and commits
+// its offset as the commit mode asks. It reports false if the message failed
+// and could not be scheduled, which only happens once ctx is cancelled.
+func (c *Consumer) process(ctx context.Context, msg kafka.Message) bool {
+	logger := c.logger.With(
+		zap.String("topic", msg.Topic),
+		zap.Int("partition", msg.Partition),
+		zap.Int64("offset", msg.Offset),
+	)
+
+	// A message already fetched is finished even if shutdown begins.
+	workCtx := context.WithoutCancel(ctx)
+
+	if c.cfg.CommitMode == AtMostOnce {
+		if err := c.reader.CommitMessages(workCtx, msg); err != nil {
+			logger.Error("failed to commit offset, skipping message", zap.Error(err))
+			return true
+		}
+	}
+
+	if err := c.handle(withCommitter(workCtx, c.reader, msg), msg); err != nil {
+		logger.Warn("message processing failed, scheduling retry", zap.Error(err))
+		if err := c.scheduleRetry(ctx, msg, err, logger); err != nil {
+			// Leave the offset uncommitted so the message is consumed again.
+			logger.Error("failed to schedule retry", zap.Error(err))
+			return false
+		}
+	} else {
+		c.succeeded.Add(1)
+	}
+
+	if c.cfg.CommitMode == AtLeastOnce {
+		if err := c.reader.CommitMessages(workCtx, msg); err != nil {
+			logger.Error("failed to commit offset", zap.Error(err))
+		}
+	}
+	return true
+}
+
+// handle runs the handler, turning a panic into an error so the message is
+// retried instead of crashing the consumer.
+func (c *Consumer) handle(ctx context.Context, msg kafka.Message) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic handling message: %v", r)
+		}
+	}()
+	return c.handler(ctx, msg)
+}
+
+// Close closes the Kafka reader. Call it after Run has returned.
+func (c *Consumer) Close() error {
+	stats := c.Stats()
+	c.logger.Info("closing consumer",
+		zap.Int64("messages", stats.Messages),
+		zap.Int64("succeeded", stats.Succeeded),
+		zap.Int64("retried", stats.Retried),
+		zap.Int64("dead_lettered", stats.DeadLettered),
+		zap.Int64("read_errors", stats.ReadErrors),
+	)
+
+	if err := c.reader.Close(); err != nil {
+		return fmt.Errorf("closing kafka reader: %w", err)
+	}
+	return nil
+}
+
+// Stats counts what the consumer has done since it was created.
+type Stats struct {
+	Messages   int64 // messages fetched
+	Succeeded  int64 // messages handled without error
+	Retried    int64 // failed messages scheduled for retry
+	ReadErrors int64 // failed fetches
+
+	// DeadLettered counts messages that failed on the last tier and were
+	// scheduled for the dead-letter topic. It stays zero without Tiers.
+	DeadLettered int64
+}
+
+// Stats returns the consumer's counters.
+func (c *Consumer) Stats() Stats {
+	return Stats{
+		Messages:   c.loop.messages.Load(),
+		Succeeded:  c.succeeded.Load(),
+		Retried:    c.retried.Load(),
+		ReadErrors: c.loop.readErrors.Load(),
+
+		DeadLettered: c.deadLettered.Load(),
+	}
+}
@@ -0,0 +1,203 @@
+package consumer
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/pkg/rebound"
+)
+
+// fakeReader serves a fixed list of messages, then blocks until the context
+// is cancelled.
+type fakeReader struct {
+	mu        sync.Mutex
+	messages  []kafka.Message
+	committed []kafka.Message
+	closed    bool
+}
+
+func (r *fakeReader) FetchMessage(ctx context.Context) (kafka.Message, error) {
+	r.mu.Lock()
+	if r.closed {
+		r.mu.Unlock()
+		return kafka.Message{}, io.EOF
+	}
+	if len(r.messages) > 0 {
+		msg := r.messages[0]
+		r.messages = r.messages[1:]
+		r.mu.Unlock()
+		return msg, nil
+	}
+	r.mu.Unlock()
+
+	<-ctx.Done()
+	return kafka.Message{}, ctx.Err()
+}
+
+func (r *fakeReader) CommitMessages(_ context.Context, msgs ...kafka.Message) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.committed = append(r.committed, msgs...)
+	return nil
+}
+
+func (r *fakeReader) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.closed = true
+	return nil
+}
+
+func (r *fakeReader) commits() []kafka.Message {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]kafka.Message(nil), r.committed...)
+}
+
+// fakeScheduler records created tasks, failing the first failures calls.
+type fakeScheduler struct {
+	mu       sync.Mutex
+	tasks    []*rebound.Task
+	failures int
+}
+
+func (s *fakeScheduler) CreateTask(_ context.Context, task *rebound.Task) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.failures > 0 {
+		s.failures--
+		return errors.New("redis down")
+	}
+	s.tasks = append(s.tasks, task)
+	return nil
+}
+
+func (s *fakeScheduler) created() []*rebound.Task {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*rebound.Task(nil), s.tasks...)
+}
+
+func testConfig() Config {
+	return Config{
+		Brokers: []string{"kafka:9092"},
+		GroupID: "billing",
+		Topic:   "invoices",
+		Logger:  zap.NewNop(),
+	}
+}
+
+// runUntil runs c until cond holds or a second passes, then stops it.
+func runUntil(t *testing.T, c *Consumer, cond func() bool) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- c.Run(ctx) }()
+
+	deadline := time.Now().Add(time.Second)
+	for !cond() && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Fatalf("Run returned %v, want context.Canceled", err)
+	}
+}
+
+func TestConsumer_SchedulesFailedMessages(t *testing.T) {
+	reader := &fakeReader{messages: []kafka.Message{
+		{Topic: "invoices", Partition: 0, Offset: 1, Value: []byte(`ok`)},
+		{Topic: "invoices", Partition: 0, Offset: 2, Value: []byte(`bad`)},
+	}}
+	scheduler := &fakeScheduler{failures: 1}
+	handler := func(_ context.Context, msg kafka.Message) error {
+		if string(msg.Value) == "bad" {
+			return errors.New("rejected")
+		}
+		return nil
+	}
+
+	c, err := newConsumer(testConfig(), reader, scheduler, handler)
+	if err != nil {
+		t.Fatal(err)
+	}
+	runUntil(t, c, func() bool { return len(reader.commits()) == 2 })
+
+	tasks := scheduler.created()
+	if len(tasks) != 1 {
+		t.Fatalf("expected 1 retry task, got %d", len(tasks))
+	}
+	task := tasks[0]
+	if task.ID != "invoices-0-2" || task.MessageData != "bad" {
+		t.Fatalf("unexpected task %+v", task)
+	}
+	if task.Destination != (rebound.Destination{Host: "kafka", Port: "9092", Topic: "invoices"}) {
+		t.Fatalf("unexpected destination %+v", task.Destination)
+	}
+	if task.DeadDestination.Topic != "invoices-dlq" {
+		t.Fatalf("expected dead-letter topic invoices-dlq, got %q", task.DeadDestination.Topic)
+	}
+	if task.MaxRetries != DefaultMaxRetries || task.BaseDelay != DefaultBaseDelay*time.Second || task.ClientID != "billing" {
+		t.Fatalf("unexpected retry policy %+v", task)
+	}
+
+	stats := c.Stats()
+	if stats != (Stats{Messages: 2, Succeeded: 1, Retried: 1}) {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+}
+
+func TestConsumer_PanickingHandlerIsRetried(t *testing.T) {
+	reader := &fakeReader{messages: []kafka.Message{{Topic: "invoices", Offset: 7}}}
+	scheduler := &fakeScheduler{}
+	handler := func(context.Context, kafka.Message) error { panic("boom") }
+
+	c, err := newConsumer(testConfig(), reader, scheduler, handler)
+	if err != nil {
+		t.Fatal(err)
+	}
+	runUntil(t, c, func() bool { return len(reader.commits()) == 1 })
+
+	if got := len(scheduler.created()); got != 1 {
+		t.Fatalf("expected 1 retry task, got %d", got)
+	}
+}
+
+func TestConsumer_KeepsPartitionOrder(t *testing.T) {
+	var messages []kafka.Message
+	for offset := int64(0); offset < 20; offset++ {
+		messages = append(messages, kafka.Message{Topic: "invoices", Partition: int(offset % 2), Offset: offset})
+	}
+	reader := &fakeReader{messages: messages}
+
+	cfg := testConfig()
+	cfg.Concurrency = 4
+	c, err := newConsumer(cfg, reader, &fakeScheduler{}, func(context.Context, kafka.Message) error { return nil })
+	if err != nil {
+		t.Fatal(err)
+	}
+	runUntil(t, c, func() bool { return len(reader.commits()) == len(messages) })
+
+	last := map[int]int64{0: -1, 1: -1}
+	for _, msg := range reader.commits() {
+		if msg.Offset <= last[msg.Partition] {
+			t.Fatalf("partition %d committed offset %d after %d", msg.Partition, msg.Offset, last[msg.Partition])
+		}
+		last[msg.Partition] = msg.Offset
+	}
+}
+
+func TestNewConsumer_RejectsInvalidBroker(t *testing.T) {
+	cfg := testConfig()
+	cfg.Brokers = []string{"kafka"}
+	if _, err := newConsumer(cfg, &fakeReader{}, &fakeScheduler{}, func(context.Context, kafka.Message) error { return nil }); err == nil {
+		t.Fatal("expected an error for a broker without a port")
+	}
+}
@@ -0,0 +1,140 @@
+package consumer
+
+import (
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// Headers the consumer sets on the messages it schedules through rebound.
+// The origin and first failure are recorded on the first failure and kept
+// unchanged as a message moves through retry tiers, the dead-letter topic
+// and redrives.
+const (
+	// HeaderAttempt carries how many times a message has failed processing.
+	HeaderAttempt = "rebound-attempt"
+
+	// HeaderRedrive carries how many times a message has been redriven from
+	// a dead-letter topic by a Redriver.
+	HeaderRedrive = "rebound-redrive"
+
+	// HeaderOriginalTopic, HeaderOriginalPartition and HeaderOriginalOffset
+	// locate the message as it was first consumed.
+	HeaderOriginalTopic     = "rebound-original-topic"
+	HeaderOriginalPartition = "rebound-original-partition"
+	HeaderOriginalOffset    = "rebound-original-offset"
+
+	// HeaderFirstFailure is when processing first failed, in RFC 3339 with
+	// nanoseconds.
+	HeaderFirstFailure = "rebound-first-failure"
+
+	// HeaderLastError is the error of the most recent failure, truncated to
+	// maxErrorHeaderSize bytes.
+	HeaderLastError = "rebound-last-error"
+)
+
+// maxErrorHeaderSize bounds HeaderLastError, keeping the headers well within
+// rebound's metadata size limit.
+const maxErrorHeaderSize = 512
+
+// RetryInfo is the retry context a message carries in its headers. All
+// fields are zero for a message that has never failed.
+type RetryInfo struct {
+	Attempt  int // failures so far
+	Redrives int // redrives from a dead-letter topic so far
+
+	OriginalTopic     string
+	OriginalPartition int
+	OriginalOffset    int64
+
+	FirstFailure time.Time
+	LastError    string
+}
+
+// ParseRetryInfo reads the retry headers of msg. Missing or invalid headers
+// leave their field zero.
+func ParseRetryInfo(msg kafka.Message) RetryInfo {
+	info := RetryInfo{
+		Attempt:       headerCount(msg, HeaderAttempt),
+		Redrives:      headerCount(msg, HeaderRedrive),
+		OriginalTopic: header(msg, HeaderOriginalTopic),
+		LastError:     header(msg, HeaderLastError),
+	}
+	if n, err := strconv.Atoi(header(msg, HeaderOriginalPartition)); err == nil {
+		info.OriginalPartition = n
+	}
+	if n, err := strconv.ParseInt(header(msg, HeaderOriginalOffset), 10, 64); err == nil {
+		info.OriginalOffset = n
+	}
+	if t, err := time.Parse(time.RFC3339Nano, header(msg, HeaderFirstFailure)); err == nil {
+		info.FirstFailure = t
+	}
+	return info
+}
+
+// retryHeaders returns the headers for the retry of msg, which failed with
+// cause at now: the attempt is incremented, the last error replaced, and the
+// origin, first failure and redrive count carried over or, on the first
+// failure, recorded.
+func retryHeaders(msg kafka.Message, cause error, now time.Time) map[string]string {
+	headers := map[string]string{
+		HeaderAttempt:           strconv.Itoa(attempt(msg) + 1),
+		HeaderOriginalTopic:     msg.Topic,
+		HeaderOriginalPartition: strconv.Itoa(msg.Partition),
+		HeaderOriginalOffset:    strconv.FormatInt(msg.Offset, 10),
+		HeaderFirstFailure:      now.UTC().Format(time.RFC3339Nano),
+	}
+	if cause != nil {
+		headers[HeaderLastError] = truncate(cause.Error(), maxErrorHeaderSize)
+	}
+	if attempt(msg) > 0 {
+		for _, key := range []string{HeaderOriginalTopic, HeaderOriginalPartition, HeaderOriginalOffset, HeaderFirstFailure} {
+			if v := header(msg, key); v != "" {
+				headers[key] = v
+			}
+		}
+	}
+	if redrive := header(msg, HeaderRedrive); redrive != "" {
+		headers[HeaderRedrive] = redrive
+	}
+	return headers
+}
+
+// attempt reads the failure count a message was retried with. Messages
+// without a valid HeaderAttempt have not failed before.
+func attempt(msg kafka.Message) int {
+	return headerCount(msg, HeaderAttempt)
+}
+
+// headerCount parses a counter header, treating a missing or invalid value
+// as zero.
+func headerCount(msg kafka.Message, key string) int {
+	if n, err := strconv.Atoi(header(msg, key)); err == nil && n > 0 {
+		return n
+	}
+	return 0
+}
+
+// header returns the value of the last header named key.
+func header(msg kafka.Message, key string) string {
+	value := ""
+	for _, h := range msg.Headers {
+		if h.Key == key {
+			value = string(h.Value)
+		}
+	}
+	return value
+}
+
+// truncate cuts s to at most n bytes without splitting a UTF-8 sequence.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
@@ -0,0 +1,66 @@
+package consumer
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// asHeaders converts retry metadata to the record headers rebound produces.
+func asHeaders(metadata map[string]string) []kafka.Header {
+	headers := make([]kafka.Header, 0, len(metadata))
+	for k, v := range metadata {
+		headers = append(headers, kafka.Header{Key: k, Value: []byte(v)})
+	}
+	sort.Slice(headers, func(i, j int) bool { return headers[i].Key < headers[j].Key })
+	return headers
+}
+
+func TestRetryHeaders_CarryOriginAcrossRetries(t *testing.T) {
+	firstFailure := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	first := retryHeaders(kafka.Message{Topic: "invoices", Partition: 2, Offset: 41}, errors.New("timeout"), firstFailure)
+	second := retryHeaders(kafka.Message{
+		Topic:     "invoices-retry-5m",
+		Partition: 0,
+		Offset:    7,
+		Headers:   asHeaders(first),
+	}, errors.New("connection refused"), firstFailure.Add(5*time.Minute))
+
+	got := ParseRetryInfo(kafka.Message{Headers: asHeaders(second)})
+	want := RetryInfo{
+		Attempt:           2,
+		OriginalTopic:     "invoices",
+		OriginalPartition: 2,
+		OriginalOffset:    41,
+		FirstFailure:      firstFailure,
+		LastError:         "connection refused",
+	}
+	if got != want {
+		t.Fatalf("expected %+v, got %+v", want, got)
+	}
+}
+
+func TestRetryHeaders_TruncatesLongErrors(t *testing.T) {
+	cause := errors.New(strings.Repeat("é", maxErrorHeaderSize))
+
+	headers := retryHeaders(kafka.Message{Topic: "invoices"}, cause, time.Now())
+
+	lastError := headers[HeaderLastError]
+	if len(lastError) > maxErrorHeaderSize {
+		t.Fatalf("expected at most %d bytes, got %d", maxErrorHeaderSize, len(lastError))
+	}
+	if !strings.HasPrefix(cause.Error(), lastError) || strings.ContainsRune(lastError, '�') {
+		t.Fatalf("expected a clean prefix of the error, got %q", lastError)
+	}
+}
+
+func TestParseRetryInfo_NeverFailed(t *testing.T) {
+	if got := ParseRetryInfo(kafka.Message{Topic: "invoices"}); got != (RetryInfo{}) {
+		t.Fatalf("expected zero retry info, got %+v", got)
+	}
+}
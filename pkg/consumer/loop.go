@@ -0,0 +1,84 @@
+package consumer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+
+	"github.com/segmentio/kafka-go"
+	"go.uber.org/zap"
+)
+
+// fetchLoop fetches messages from a reader and fans them out to a fixed
+// number of lanes. All messages of a partition go to the same lane, so
+// they are processed and committed in order.
+type fetchLoop struct {
+	reader      Reader
+	concurrency int
+	logger      *zap.Logger
+
+	messages   atomic.Int64
+	readErrors atomic.Int64
+}
+
+func newFetchLoop(reader Reader, concurrency int, logger *zap.Logger) *fetchLoop {
+	return &fetchLoop{
+		reader:      reader,
+		concurrency: max(concurrency, 1),
+		logger:      logger,
+	}
+}
+
+// run fetches until ctx is cancelled, passing every message to process.
+// process reports false when it left a message uncommitted that must not
+// be committed past; later messages of that partition are then skipped
+// for the rest of the run, to be consumed again after a restart. Messages
+// already fetched are processed before run returns.
+func (l *fetchLoop) run(ctx context.Context, process func(context.Context, kafka.Message) bool) error {
+	lanes := make([]chan kafka.Message, l.concurrency)
+	var wg sync.WaitGroup
+	for i := range lanes {
+		lanes[i] = make(chan kafka.Message)
+		wg.Add(1)
+		go func(lane <-chan kafka.Message) {
+			defer wg.Done()
+			stalled := make(map[int]bool)
+			for msg := range lane {
+				if stalled[msg.Partition] {
+					continue
+				}
+				if !process(ctx, msg) {
+					stalled[msg.Partition] = true
+				}
+			}
+		}(lanes[i])
+	}
+	defer func() {
+		for _, lane := range lanes {
+			close(lane)
+		}
+		wg.Wait()
+	}()
+
+	for {
+		msg, err := l.reader.FetchMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				l.logger.Info("consumer shutting down")
+				return ctx.Err()
+			}
+			if errors.Is(err, io.EOF) {
+				return fmt.Errorf("kafka reader closed: %w", err)
+			}
+			l.readErrors.Add(1)
+			l.logger.Error("failed to fetch message", zap.Error(err))
+			continue
+		}
+
+		l.messages.Add(1)
+		lanes[msg.Partition%len(lanes)] <- msg
+	}
+}
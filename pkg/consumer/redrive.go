@@ -0,0 +1,331 @@
+package consumer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/pkg/rebound"
+)
+
+// DelayedScheduler creates rebound tasks due at a given time.
+// *rebound.Rebound implements it.
+type DelayedScheduler interface {
+	CreateTaskAt(ctx context.Context, task *rebound.Task, at time.Time) error
+}
+
+// Approver decides whether a dead-lettered message is redriven. It may
+// block, e.g. until an operator has reviewed the message, and should
+// return when ctx is cancelled. Returning false discards the message; an
+// error leaves it uncommitted, so it is read again after a restart.
+type Approver func(ctx context.Context, msg kafka.Message) (bool, error)
+
+// RedriveConfig holds configuration for a Redriver.
+type RedriveConfig struct {
+	// Brokers, GroupID and Topic select the dead-letter topic that is
+	// consumed. The first broker is also where messages are redriven to.
+	Brokers []string
+	GroupID string
+	Topic   string
+
+	// Destination is the topic redriven messages are produced to, usually
+	// the topic they were originally consumed from.
+	Destination string
+
+	// Concurrency and CommitInterval work as in Config.
+	Concurrency    int
+	CommitInterval time.Duration
+
+	// Cooldown is how long after it was dead-lettered a message is
+	// redriven, giving the cause of the failure time to be fixed.
+	Cooldown time.Duration
+
+	// Approve, if set, is asked about every message before it is redriven.
+	Approve Approver
+
+	// MaxRedrives is how many times one message may be redriven; once
+	// reached, the message is discarded. Redrives are counted in the
+	// HeaderRedrive header, which Consumer keeps on the messages it retries.
+	// Defaults to 1.
+	MaxRedrives int
+
+	// MaxRetries and BaseDelay (in seconds) are the fresh retry policy for
+	// producing a redriven message to Destination. They default to 3 and 5.
+	MaxRetries int
+	BaseDelay  int
+
+	// DeadLetterTopic receives redriven messages that could not be produced
+	// to Destination. Defaults to Topic.
+	DeadLetterTopic string
+
+	// Source and ClientID identify the created tasks. They default to
+	// "dlq-redriver" and GroupID.
+	Source   string
+	ClientID string
+
+	// Logger (if nil, a default logger will be created)
+	Logger *zap.Logger
+}
+
+// DefaultMaxRedrives is how many times a message is redriven by default.
+const DefaultMaxRedrives = 1
+
+// Redriver consumes a dead-letter topic and schedules its messages back to
+// their original topic through rebound, closing the loop on dead-lettered
+// traffic.
+type Redriver struct {
+	loop      *fetchLoop
+	reader    Reader
+	scheduler DelayedScheduler
+	cfg       RedriveConfig
+	broker    rebound.Destination
+	logger    *zap.Logger
+
+	redriven  atomic.Int64
+	rejected  atomic.Int64
+	exhausted atomic.Int64
+}
+
+// NewRedriver creates a Redriver reading cfg.Topic as part of cfg.GroupID
+// and scheduling redrives with scheduler.
+func NewRedriver(cfg RedriveConfig, scheduler DelayedScheduler) (*Redriver, error) {
+	if len(cfg.Brokers) == 0 {
+		return nil, errors.New("at least one broker is required")
+	}
+	if cfg.GroupID == "" || cfg.Topic == "" {
+		return nil, errors.New("group ID and topic are required")
+	}
+
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:        cfg.Brokers,
+		GroupID:        cfg.GroupID,
+		Topic:          cfg.Topic,
+		MinBytes:       1,
+		MaxBytes:       10e6, // 10MB
+		CommitInterval: cfg.CommitInterval,
+	})
+
+	r, err := newRedriver(cfg, reader, scheduler)
+	if err != nil {
+		_ = reader.Close()
+		return nil, err
+	}
+	return r, nil
+}
+
+// newRedriver applies defaults to cfg and creates a Redriver over reader.
+func newRedriver(cfg RedriveConfig, reader Reader, scheduler DelayedScheduler) (*Redriver, error) {
+	if scheduler == nil {
+		return nil, errors.New("scheduler is required")
+	}
+	if cfg.Destination == "" {
+		return nil, errors.New("destination topic is required")
+	}
+
+	host, port, err := net.SplitHostPort(cfg.Brokers[0])
+	if err != nil {
+		return nil, fmt.Errorf("parsing broker address %q: %w", cfg.Brokers[0], err)
+	}
+
+	if cfg.MaxRedrives <= 0 {
+		cfg.MaxRedrives = DefaultMaxRedrives
+	}
+	if cfg.MaxRetries <= 0 {
+		cfg.MaxRetries = DefaultMaxRetries
+	}
+	if cfg.BaseDelay <= 0 {
+		cfg.BaseDelay = DefaultBaseDelay
+	}
+	if cfg.DeadLetterTopic == "" {
+		cfg.DeadLetterTopic = cfg.Topic
+	}
+	if cfg.Source == "" {
+		cfg.Source = "dlq-redriver"
+	}
+	if cfg.ClientID == "" {
+		cfg.ClientID = cfg.GroupID
+	}
+
+	logger := cfg.Logger
+	if logger == nil {
+		logger, err = zap.NewProduction()
+		if err != nil {
+			return nil, fmt.Errorf("creating logger: %w", err)
+		}
+	}
+
+	logger = logger.Named("redriver")
+	return &Redriver{
+		loop:      newFetchLoop(reader, cfg.Concurrency, logger),
+		reader:    reader,
+		scheduler: scheduler,
+		cfg:       cfg,
+		broker:    rebound.Destination{Host: host, Port: port},
+		logger:    logger,
+	}, nil
+}
+
+// Run redrives messages until ctx is cancelled.
+func (r *Redriver) Run(ctx context.Context) error {
+	r.logger.Info("redriver started",
+		zap.String("topic", r.cfg.Topic),
+		zap.String("destination", r.cfg.Destination),
+		zap.Duration("cooldown", r.cfg.Cooldown),
+	)
+	return r.loop.run(ctx, r.process)
+}
+
+// process redrives msg unless it is exhausted or rejected, then commits its
+// offset. It reports false if the message was left uncommitted.
+func (r *Redriver) process(ctx context.Context, msg kafka.Message) bool {
+	logger := r.logger.With(
+		zap.String("topic", msg.Topic),
+		zap.Int("partition", msg.Partition),
+		zap.Int64("offset", msg.Offset),
+	)
+
+	redrives := headerCount(msg, HeaderRedrive)
+	if redrives >= r.cfg.MaxRedrives {
+		logger.Warn("message redriven too often, discarding", zap.Int("redrives", redrives))
+		r.exhausted.Add(1)
+		return r.commit(ctx, msg, logger)
+	}
+
+	if r.cfg.Approve != nil {
+		ok, err := r.cfg.Approve(ctx, msg)
+		if err != nil {
+			logger.Error("failed to approve redrive", zap.Error(err))
+			return false
+		}
+		if !ok {
+			logger.Info("redrive rejected, discarding message")
+			r.rejected.Add(1)
+			return r.commit(ctx, msg, logger)
+		}
+	}
+
+	task := r.redriveTask(msg, redrives)
+	at := msg.Time.Add(r.cfg.Cooldown)
+	err := schedule(ctx, logger, func(ctx context.Context) error {
+		return r.scheduler.CreateTaskAt(ctx, task, at)
+	})
+	if err != nil {
+		logger.Error("failed to schedule redrive", zap.Error(err))
+		return false
+	}
+	logger.Info("message redriven", zap.String("task_id", task.ID), zap.Time("due_at", at))
+	r.redriven.Add(1)
+	return r.commit(ctx, msg, logger)
+}
+
+// commit commits msg's offset. It always reports true: a failed commit is
+// logged, and at worst the message is read and redriven again.
+func (r *Redriver) commit(ctx context.Context, msg kafka.Message, logger *zap.Logger) bool {
+	if err := r.reader.CommitMessages(context.WithoutCancel(ctx), msg); err != nil {
+		logger.Error("failed to commit offset", zap.Error(err))
+	}
+	return true
+}
+
+// redriveTask builds the task producing msg back to the destination with a
+// fresh attempt count. Messages dead-lettered by rebound are unwrapped
+// first, so the original payload and metadata are redriven.
+func (r *Redriver) redriveTask(msg kafka.Message, redrives int) *rebound.Task {
+	dest := r.broker
+	dest.Topic = r.cfg.Destination
+	dead := r.broker
+	dead.Topic = r.cfg.DeadLetterTopic
+
+	payload, metadata := unwrapDeadLetter(msg)
+	delete(metadata, HeaderAttempt)
+	metadata[HeaderRedrive] = strconv.Itoa(redrives + 1)
+
+	return &rebound.Task{
+		ID:              fmt.Sprintf("redrive-%s-%d-%d", msg.Topic, msg.Partition, msg.Offset),
+		Source:          r.cfg.Source,
+		Destination:     dest,
+		DeadDestination: dead,
+		MaxRetries:      r.cfg.MaxRetries,
+		BaseDelay:       time.Duration(r.cfg.BaseDelay) * time.Second,
+		ClientID:        r.cfg.ClientID,
+		MessageData:     payload,
+		DestinationType: rebound.DestinationTypeKafka,
+		Metadata:        metadata,
+	}
+}
+
+// deadLetterEnvelope is the part of the message rebound produces to a
+// dead-letter destination that a redrive needs.
+type deadLetterEnvelope struct {
+	TaskID      string            `json:"task_id"`
+	MessageData *string           `json:"message_data"`
+	Metadata    map[string]string `json:"metadata"`
+}
+
+// unwrapDeadLetter returns the payload and metadata of msg. Envelopes
+// produced by rebound's dead-letter delivery yield the original message;
+// any other message is taken as is, keeping only its rebound headers,
+// since arbitrary headers may not be valid task metadata.
+func unwrapDeadLetter(msg kafka.Message) (string, map[string]string) {
+	var envelope deadLetterEnvelope
+	if err := json.Unmarshal(msg.Value, &envelope); err == nil && envelope.TaskID != "" && envelope.MessageData != nil {
+		metadata := make(map[string]string, len(envelope.Metadata)+1)
+		for k, v := range envelope.Metadata {
+			metadata[k] = v
+		}
+		return *envelope.MessageData, metadata
+	}
+
+	metadata := make(map[string]string)
+	for _, h := range msg.Headers {
+		if strings.HasPrefix(h.Key, "rebound-") {
+			metadata[h.Key] = string(h.Value)
+		}
+	}
+	return string(msg.Value), metadata
+}
+
+// Close closes the Kafka reader. Call it after Run has returned.
+func (r *Redriver) Close() error {
+	stats := r.Stats()
+	r.logger.Info("closing redriver",
+		zap.Int64("messages", stats.Messages),
+		zap.Int64("redriven", stats.Redriven),
+		zap.Int64("rejected", stats.Rejected),
+		zap.Int64("exhausted", stats.Exhausted),
+	)
+
+	if err := r.reader.Close(); err != nil {
+		return fmt.Errorf("closing kafka reader: %w", err)
+	}
+	return nil
+}
+
+// RedriveStats counts what a Redriver has done since it was created.
+type RedriveStats struct {
+	Messages   int64 // messages fetched
+	Redriven   int64 // messages scheduled back to the destination
+	Rejected   int64 // messages the approver discarded
+	Exhausted  int64 // messages discarded after MaxRedrives
+	ReadErrors int64 // failed fetches
+}
+
+// Stats returns the redriver's counters.
+func (r *Redriver) Stats() RedriveStats {
+	return RedriveStats{
+		Messages:   r.loop.messages.Load(),
+		Redriven:   r.redriven.Load(),
+		Rejected:   r.rejected.Load(),
+		Exhausted:  r.exhausted.Load(),
+		ReadErrors: r.loop.readErrors.Load(),
+	}
+}
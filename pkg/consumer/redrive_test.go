@@ -0,0 +1,163 @@
+package consumer
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/pkg/rebound"
+)
+
+// fakeDelayedScheduler records created tasks and when they are due.
+type fakeDelayedScheduler struct {
+	mu    sync.Mutex
+	tasks []*rebound.Task
+	due   []time.Time
+}
+
+func (s *fakeDelayedScheduler) CreateTaskAt(_ context.Context, task *rebound.Task, at time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tasks = append(s.tasks, task)
+	s.due = append(s.due, at)
+	return nil
+}
+
+func testRedriveConfig() RedriveConfig {
+	return RedriveConfig{
+		Brokers:     []string{"kafka:9092"},
+		GroupID:     "billing-redrive",
+		Topic:       "invoices-dlq",
+		Destination: "invoices",
+		Cooldown:    time.Hour,
+		Logger:      zap.NewNop(),
+	}
+}
+
+func TestRedriver_Process(t *testing.T) {
+	deadAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	envelope := `{"task_id":"invoices-0-2","attempts":4,"message_data":"{\"id\":1}","metadata":{"rebound-attempt":"4","tenant":"acme"}}`
+
+	tests := []struct {
+		name          string
+		msg           kafka.Message
+		approve       Approver
+		wantCommitted bool
+		wantTask      bool
+		wantPayload   string
+		wantMetadata  map[string]string
+		wantStats     RedriveStats
+	}{
+		{
+			name:          "rebound envelope is unwrapped",
+			msg:           kafka.Message{Topic: "invoices-dlq", Offset: 9, Time: deadAt, Value: []byte(envelope)},
+			wantCommitted: true,
+			wantTask:      true,
+			wantPayload:   `{"id":1}`,
+			wantMetadata:  map[string]string{"tenant": "acme", HeaderRedrive: "1"},
+			wantStats:     RedriveStats{Redriven: 1},
+		},
+		{
+			name: "raw message keeps only rebound headers",
+			msg: kafka.Message{Topic: "invoices-dlq", Offset: 9, Time: deadAt, Value: []byte(`raw`), Headers: []kafka.Header{
+				{Key: HeaderAttempt, Value: []byte("3")},
+				{Key: "Content Type", Value: []byte("text/plain")},
+			}},
+			wantCommitted: true,
+			wantTask:      true,
+			wantPayload:   "raw",
+			wantMetadata:  map[string]string{HeaderRedrive: "1"},
+			wantStats:     RedriveStats{Redriven: 1},
+		},
+		{
+			name: "message redriven too often is discarded",
+			msg: kafka.Message{Topic: "invoices-dlq", Time: deadAt, Headers: []kafka.Header{
+				{Key: HeaderRedrive, Value: []byte("1")},
+			}},
+			wantCommitted: true,
+			wantStats:     RedriveStats{Exhausted: 1},
+		},
+		{
+			name:          "rejected message is discarded",
+			msg:           kafka.Message{Topic: "invoices-dlq", Time: deadAt},
+			approve:       func(context.Context, kafka.Message) (bool, error) { return false, nil },
+			wantCommitted: true,
+			wantStats:     RedriveStats{Rejected: 1},
+		},
+		{
+			name:    "approval error leaves the message uncommitted",
+			msg:     kafka.Message{Topic: "invoices-dlq", Time: deadAt},
+			approve: func(context.Context, kafka.Message) (bool, error) { return false, errors.New("review service down") },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := testRedriveConfig()
+			cfg.Approve = tt.approve
+			reader := &fakeReader{}
+			scheduler := &fakeDelayedScheduler{}
+			r, err := newRedriver(cfg, reader, scheduler)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := r.process(context.Background(), tt.msg); got != tt.wantCommitted {
+				t.Fatalf("expected process to report %v, got %v", tt.wantCommitted, got)
+			}
+			if got := len(reader.commits()) == 1; got != tt.wantCommitted {
+				t.Fatalf("expected committed %v, got %v", tt.wantCommitted, got)
+			}
+			if got := r.Stats(); got != tt.wantStats {
+				t.Fatalf("expected stats %+v, got %+v", tt.wantStats, got)
+			}
+			if !tt.wantTask {
+				if len(scheduler.tasks) != 0 {
+					t.Fatalf("expected no task, got %+v", scheduler.tasks)
+				}
+				return
+			}
+
+			if len(scheduler.tasks) != 1 {
+				t.Fatalf("expected 1 task, got %d", len(scheduler.tasks))
+			}
+			task := scheduler.tasks[0]
+			if task.ID != "redrive-invoices-dlq-0-9" || task.Destination.Topic != "invoices" || task.DeadDestination.Topic != "invoices-dlq" {
+				t.Fatalf("unexpected task %+v", task)
+			}
+			if task.MessageData != tt.wantPayload {
+				t.Fatalf("expected payload %q, got %q", tt.wantPayload, task.MessageData)
+			}
+			if len(task.Metadata) != len(tt.wantMetadata) {
+				t.Fatalf("expected metadata %v, got %v", tt.wantMetadata, task.Metadata)
+			}
+			for k, v := range tt.wantMetadata {
+				if task.Metadata[k] != v {
+					t.Fatalf("expected metadata %v, got %v", tt.wantMetadata, task.Metadata)
+				}
+			}
+			if want := deadAt.Add(time.Hour); !scheduler.due[0].Equal(want) {
+				t.Fatalf("expected redrive due at %s, got %s", want, scheduler.due[0])
+			}
+		})
+	}
+}
+
+func TestConsumer_RetryKeepsRedriveCount(t *testing.T) {
+	c, err := newConsumer(testConfig(), &fakeReader{}, &fakeScheduler{}, func(context.Context, kafka.Message) error { return nil })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	task, _ := c.retryTask(kafka.Message{Topic: "invoices", Headers: []kafka.Header{
+		{Key: HeaderRedrive, Value: []byte("1")},
+	}}, errors.New("rejected"), time.Now())
+	if got := task.Metadata[HeaderRedrive]; got != "1" {
+		t.Fatalf("expected redrive count 1, got %q", got)
+	}
+}
@@ -0,0 +1,123 @@
+package consumer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/pkg/rebound"
+)
+
+// scheduleRetry hands msg, which failed with cause, to rebound for its next
+// attempt.
+func (c *Consumer) scheduleRetry(ctx context.Context, msg kafka.Message, cause error, logger *zap.Logger) error {
+	task, dead := c.retryTask(msg, cause, time.Now())
+	err := schedule(ctx, logger, func(ctx context.Context) error {
+		return c.scheduler.CreateTask(ctx, task)
+	})
+	if err != nil {
+		return err
+	}
+
+	if dead {
+		c.deadLettered.Add(1)
+	} else {
+		c.retried.Add(1)
+	}
+	return nil
+}
+
+// schedule calls create, retrying with a doubling backoff until it
+// succeeds or ctx is cancelled. Moving on without scheduling would lose the
+// message once a later offset of its partition is committed.
+func schedule(ctx context.Context, logger *zap.Logger, create func(context.Context) error) error {
+	backoff := 100 * time.Millisecond
+	for {
+		// Each attempt runs to completion; only the wait between attempts
+		// is cut short by shutdown.
+		err := create(context.WithoutCancel(ctx))
+		if err == nil {
+			return nil
+		}
+		logger.Warn("failed to schedule task, trying again", zap.Error(err), zap.Duration("backoff", backoff))
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("scheduling task: %w", err)
+		case <-time.After(backoff):
+		}
+		backoff = min(2*backoff, maxScheduleBackoff)
+	}
+}
+
+// retryTask builds the rebound task redelivering msg, which failed with
+// cause at now, and reports whether it goes to the dead-letter topic. Its ID
+// is derived from the message's position, so a message consumed twice does
+// not schedule two retries.
+func (c *Consumer) retryTask(msg kafka.Message, cause error, now time.Time) (*rebound.Task, bool) {
+	dead := c.broker
+	dead.Topic = c.cfg.DeadLetterTopic
+
+	task := &rebound.Task{
+		ID:              fmt.Sprintf("%s-%d-%d", msg.Topic, msg.Partition, msg.Offset),
+		Source:          c.cfg.Source,
+		Destination:     c.broker,
+		DeadDestination: dead,
+		MaxRetries:      c.cfg.MaxRetries,
+		BaseDelay:       time.Duration(c.cfg.BaseDelay) * time.Second,
+		ClientID:        c.cfg.ClientID,
+		MessageData:     string(msg.Value),
+		DestinationType: rebound.DestinationTypeKafka,
+		Metadata:        retryHeaders(msg, cause, now),
+	}
+
+	if len(c.cfg.Tiers) == 0 {
+		task.Destination.Topic = c.cfg.RetryTopic
+		return task, false
+	}
+
+	next := c.tierOf(msg.Topic) + 1
+	if next == len(c.cfg.Tiers) {
+		// Out of tiers: produce to the dead-letter topic as soon as rebound
+		// allows.
+		task.Destination.Topic = c.cfg.DeadLetterTopic
+		task.BaseDelay = minTierDelay
+		return task, true
+	}
+	task.Destination.Topic = c.cfg.Tiers[next].Topic
+	task.BaseDelay = c.cfg.Tiers[next].Delay
+	return task, false
+}
+
+// tierOf returns the index of the tier consuming topic, or -1 for the main
+// topic.
+func (c *Consumer) tierOf(topic string) int {
+	for i, tier := range c.cfg.Tiers {
+		if tier.Topic == topic {
+			return i
+		}
+	}
+	return -1
+}
+
+// validateTiers requires distinct tier topics, none of them topic, with
+// delays rebound can schedule.
+func validateTiers(topic string, tiers []Tier) error {
+	seen := map[string]bool{topic: true}
+	for i, tier := range tiers {
+		if tier.Topic == "" {
+			return fmt.Errorf("tier %d: topic is required", i)
+		}
+		if seen[tier.Topic] {
+			return fmt.Errorf("tier %d: topic %q is consumed twice", i, tier.Topic)
+		}
+		seen[tier.Topic] = true
+		if tier.Delay < minTierDelay || tier.Delay > maxTierDelay {
+			return fmt.Errorf("tier %d: delay must be between %s and %s", i, minTierDelay, maxTierDelay)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package consumer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+func TestConsumer_TieredRetryTask(t *testing.T) {
+	cfg := testConfig()
+	cfg.Tiers = []Tier{
+		{Topic: "invoices-retry-5m", Delay: 5 * time.Minute},
+		{Topic: "invoices-retry-30m", Delay: 30 * time.Minute},
+	}
+	c, err := newConsumer(cfg, &fakeReader{}, &fakeScheduler{}, func(context.Context, kafka.Message) error { return nil })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		msg         kafka.Message
+		wantTopic   string
+		wantDelay   time.Duration
+		wantAttempt string
+		wantDead    bool
+	}{
+		{
+			name:        "main topic moves to the first tier",
+			msg:         kafka.Message{Topic: "invoices"},
+			wantTopic:   "invoices-retry-5m",
+			wantDelay:   5 * time.Minute,
+			wantAttempt: "1",
+		},
+		{
+			name: "first tier moves to the second tier",
+			msg: kafka.Message{Topic: "invoices-retry-5m", Headers: []kafka.Header{
+				{Key: HeaderAttempt, Value: []byte("1")},
+			}},
+			wantTopic:   "invoices-retry-30m",
+			wantDelay:   30 * time.Minute,
+			wantAttempt: "2",
+		},
+		{
+			name: "last tier moves to the dead-letter topic",
+			msg: kafka.Message{Topic: "invoices-retry-30m", Headers: []kafka.Header{
+				{Key: HeaderAttempt, Value: []byte("2")},
+			}},
+			wantTopic:   "invoices-dlq",
+			wantDelay:   time.Second,
+			wantAttempt: "3",
+			wantDead:    true,
+		},
+		{
+			name: "invalid attempt header counts as the first failure",
+			msg: kafka.Message{Topic: "invoices", Headers: []kafka.Header{
+				{Key: HeaderAttempt, Value: []byte("many")},
+			}},
+			wantTopic:   "invoices-retry-5m",
+			wantDelay:   5 * time.Minute,
+			wantAttempt: "1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task, dead := c.retryTask(tt.msg, errors.New("rejected"), time.Now())
+			if task.Destination.Topic != tt.wantTopic {
+				t.Fatalf("expected topic %q, got %q", tt.wantTopic, task.Destination.Topic)
+			}
+			if task.BaseDelay != tt.wantDelay {
+				t.Fatalf("expected delay %s, got %s", tt.wantDelay, task.BaseDelay)
+			}
+			if got := task.Metadata[HeaderAttempt]; got != tt.wantAttempt {
+				t.Fatalf("expected attempt %q, got %q", tt.wantAttempt, got)
+			}
+			if dead != tt.wantDead {
+				t.Fatalf("expected dead %v, got %v", tt.wantDead, dead)
+			}
+		})
+	}
+}
+
+func TestValidateTiers(t *testing.T) {
+	tests := []struct {
+		name    string
+		tiers   []Tier
+		wantErr bool
+	}{
+		{name: "no tiers"},
+		{name: "valid tiers", tiers: []Tier{{Topic: "a", Delay: time.Second}, {Topic: "b", Delay: time.Hour}}},
+		{name: "missing topic", tiers: []Tier{{Delay: time.Minute}}, wantErr: true},
+		{name: "main topic reused", tiers: []Tier{{Topic: "invoices", Delay: time.Minute}}, wantErr: true},
+		{name: "duplicate topic", tiers: []Tier{{Topic: "a", Delay: time.Minute}, {Topic: "a", Delay: time.Minute}}, wantErr: true},
+		{name: "delay too short", tiers: []Tier{{Topic: "a", Delay: time.Millisecond}}, wantErr: true},
+		{name: "delay too long", tiers: []Tier{{Topic: "a", Delay: 2 * time.Hour}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateTiers("invoices", tt.tiers); (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package rebound
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/adapter/secondary/asynqsource"
+)
+
+// AsynqJob is a delayed job read from asynq's scheduled or retry set.
+type AsynqJob struct {
+	ID        string
+	Type      string
+	Payload   []byte
+	Queue     string
+	Retry     int       // max retries configured in asynq
+	Retried   int       // retries already used
+	ErrorMsg  string    // last error, for jobs in the retry set
+	ProcessAt time.Time // when asynq would have run the job
+	Retrying  bool      // true if read from the retry set
+}
+
+// AsynqMapper converts an asynq job into a rebound task. Returning a nil
+// task skips the job; returning an error counts it as failed.
+type AsynqMapper func(job AsynqJob) (*Task, error)
+
+// ImportReport summarizes an import run.
+type ImportReport struct {
+	Read     int
+	Imported int
+	Skipped  int
+	Failed   int
+}
+
+// ImportAsynq reads the scheduled and retry sets of the given asynq queues
+// from source and creates a rebound task for each job, due when asynq would
+// have processed it. The asynq data is left untouched, so running the import
+// twice creates duplicates; drain or delete the asynq queues afterwards.
+func (r *Rebound) ImportAsynq(ctx context.Context, source goredis.UniversalClient, queues []string, mapper AsynqMapper) (ImportReport, error) {
+	var report ImportReport
+	reader := asynqsource.NewReader(source, r.logger)
+
+	for _, queue := range queues {
+		jobs, err := reader.Jobs(ctx, queue)
+		if err != nil {
+			return report, fmt.Errorf("reading asynq queue %s: %w", queue, err)
+		}
+
+		for _, j := range jobs {
+			report.Read++
+			job := AsynqJob{
+				ID:        j.ID,
+				Type:      j.Type,
+				Payload:   j.Payload,
+				Queue:     j.Queue,
+				Retry:     j.Retry,
+				Retried:   j.Retried,
+				ErrorMsg:  j.ErrorMsg,
+				ProcessAt: j.ProcessAt,
+				Retrying:  j.Set == asynqsource.SetRetry,
+			}
+
+			task, err := mapper(job)
+			if err != nil {
+				report.Failed++
+				r.logger.Warn("asynq job mapping failed", zap.String("job_id", job.ID), zap.Error(err))
+				continue
+			}
+			if task == nil {
+				report.Skipped++
+				continue
+			}
+
+			if err := r.taskService.CreateTaskAt(ctx, task.toDomain(), job.ProcessAt); err != nil {
+				report.Failed++
+				r.logger.Warn("asynq job import failed", zap.String("job_id", job.ID), zap.Error(err))
+				continue
+			}
+			report.Imported++
+		}
+	}
+
+	r.logger.Info("asynq import finished",
+		zap.Int("read", report.Read),
+		zap.Int("imported", report.Imported),
+		zap.Int("skipped", report.Skipped),
+		zap.Int("failed", report.Failed),
+	)
+	return report, nil
+}
+
+// AsynqPayloadMapper returns a mapper that delivers each job's payload
+// unchanged to destination, keeps the asynq job ID, and carries over the
+// remaining retry budget (at least one retry).
+func AsynqPayloadMapper(source string, destType DestinationType, destination, deadDestination Destination) AsynqMapper {
+	return func(job AsynqJob) (*Task, error) {
+		retries := job.Retry - job.Retried
+		if retries < 1 {
+			retries = 1
+		}
+		return &Task{
+			ID:              job.ID,
+			Source:          source,
+			Destination:     destination,
+			DeadDestination: deadDestination,
+			MaxRetries:      retries,
+			BaseDelay:       time.Second,
+			ClientID:        job.Type,
+			MessageData:     string(job.Payload),
+			DestinationType: destType,
+		}, nil
+	}
+}
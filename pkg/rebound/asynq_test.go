@@ -0,0 +1,94 @@
+package rebound
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	goredis "github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/adapter/secondary/memstore"
+	"github.com/ruudy-sib/rebound/internal/domain/service"
+)
+
+// asynqMessage encodes the asynq TaskMessage fields the importer reads.
+func asynqMessage(id, typ, payload string, retry, retried uint64) string {
+	var b []byte
+	for field, v := range map[uint64]string{1: typ, 2: payload, 3: id} {
+		b = binary.AppendUvarint(b, field<<3|2)
+		b = binary.AppendUvarint(b, uint64(len(v)))
+		b = append(b, v...)
+	}
+	b = binary.AppendUvarint(binary.AppendUvarint(b, 5<<3), retry)
+	b = binary.AppendUvarint(binary.AppendUvarint(b, 6<<3), retried)
+	return string(b)
+}
+
+func TestRebound_ImportAsynq(t *testing.T) {
+	mr := miniredis.RunT(t)
+	source := goredis.NewClient(&goredis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { source.Close() })
+
+	now := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	seed := func(set, id, typ string, at time.Time, retry, retried uint64) {
+		mr.HSet("asynq:{default}:t:"+id, "msg", asynqMessage(id, typ, `{"n":1}`, retry, retried))
+		if _, err := mr.ZAdd("asynq:{default}:"+set, float64(at.Unix()), id); err != nil {
+			t.Fatalf("seeding %s: %v", id, err)
+		}
+	}
+	seed("scheduled", "sched-1", "email:send", now.Add(time.Hour), 25, 0)
+	seed("retry", "retry-1", "email:send", now.Add(time.Minute), 3, 3)
+	seed("scheduled", "skip-1", "report:build", now.Add(time.Hour), 25, 0)
+	seed("scheduled", "bad-1", "email:bad", now.Add(time.Hour), 25, 0)
+
+	scheduler := memstore.NewScheduler(func() time.Time { return now })
+	r := &Rebound{
+		taskService: service.NewTaskService(scheduler, nil, zap.NewNop(),
+			service.WithClock(func() time.Time { return now })),
+		logger: zap.NewNop(),
+	}
+
+	mapper := AsynqPayloadMapper("importer", DestinationTypeHTTP,
+		Destination{URL: "https://example.com/hook"}, Destination{URL: "https://example.com/dlq"})
+	report, err := r.ImportAsynq(context.Background(), source, []string{"default"}, func(job AsynqJob) (*Task, error) {
+		switch job.Type {
+		case "report:build":
+			return nil, nil
+		case "email:bad":
+			return nil, errors.New("unmappable")
+		}
+		return mapper(job)
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := ImportReport{Read: 4, Imported: 2, Skipped: 1, Failed: 1}
+	if report != want {
+		t.Fatalf("expected %+v, got %+v", want, report)
+	}
+
+	tests := []struct {
+		id      string
+		due     time.Time
+		retries int
+	}{
+		{id: "sched-1", due: now.Add(time.Hour), retries: 25},
+		{id: "retry-1", due: now.Add(time.Minute), retries: 1}, // budget spent, keep one retry
+	}
+	for _, tt := range tests {
+		task, due, err := scheduler.Dequeue(context.Background(), tt.id)
+		if err != nil {
+			t.Fatalf("%s not imported: %v", tt.id, err)
+		}
+		if !due.Equal(tt.due) {
+			t.Errorf("%s: expected due at %v, got %v", tt.id, tt.due, due)
+		}
+		if task.MaxRetries != tt.retries || task.ClientID != "email:send" || task.MessageData != `{"n":1}` {
+			t.Errorf("%s: unexpected task %+v", tt.id, task)
+		}
+	}
+}
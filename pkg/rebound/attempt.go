@@ -0,0 +1,96 @@
+package rebound
+
+import (
+	"context"
+	"time"
+
+	"github.com/ruudy-sib/rebound/internal/domain"
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+	"github.com/ruudy-sib/rebound/internal/domain/service"
+	"github.com/ruudy-sib/rebound/internal/port/secondary"
+)
+
+// Attempt is a delivery about to be made. An AttemptHook may change
+// Payload, Metadata and Headers; the changes apply to this attempt only.
+type Attempt struct {
+	TaskID          string
+	Attempt         int
+	DestinationType DestinationType
+	Destination     Destination
+
+	// Payload is the body sent to the destination, initially the task's
+	// MessageData.
+	Payload []byte
+
+	// Metadata starts as a copy of the task's metadata and is sent as
+	// X-Metadata-<key> HTTP headers or Kafka record headers. It is never nil.
+	Metadata map[string]string
+
+	// Headers are sent as they are, as HTTP request headers or Kafka record
+	// headers, e.g. "Authorization". It starts empty but never nil.
+	Headers map[string]string
+}
+
+// AttemptHook runs right before a delivery attempt, e.g. to refresh an
+// expiring auth token or re-sign the payload. An error fails the attempt,
+// which is retried like any other failed delivery. Hooks are bounded by
+// Config.AttemptHookTimeout; one that may run for minutes should call
+// ExtendLease with its ctx so the task is not reclaimed from under it.
+type AttemptHook func(ctx context.Context, attempt *Attempt) error
+
+var (
+	// ErrNoLease is returned by ExtendLease when ctx was not passed to an
+	// AttemptHook.
+	ErrNoLease = domain.ErrNoLease
+
+	// ErrLeaseLost is returned by ExtendLease when the task was already
+	// reclaimed and will be delivered again; the hook should give up.
+	ErrLeaseLost = domain.ErrTaskNotFound
+)
+
+// ExtendLease keeps the task being delivered from being reclaimed for at
+// least d from now, like extending an SQS visibility timeout. Tasks are
+// otherwise reclaimed once in flight for Config.ReclaimAfterTimeouts
+// delivery timeouts. Call it from an AttemptHook with the context it was
+// given.
+func ExtendLease(ctx context.Context, d time.Duration) error {
+	return service.ExtendLease(ctx, d)
+}
+
+// attemptHookOptions registers hooks keyed by destination URL or topic.
+func attemptHookOptions(hooks map[string]AttemptHook) []service.Option {
+	opts := make([]service.Option, 0, len(hooks))
+	for destination, hook := range hooks {
+		opts = append(opts, service.WithAttemptHook(destination, adaptAttemptHook(hook)))
+	}
+	return opts
+}
+
+// adaptAttemptHook exposes the message about to be sent to hook as an Attempt
+// and copies its changes back.
+func adaptAttemptHook(hook AttemptHook) service.AttemptHook {
+	return func(ctx context.Context, task *entity.Task, msg *secondary.Message) error {
+		attempt := &Attempt{
+			TaskID:          task.ID,
+			Attempt:         task.Attempt,
+			DestinationType: DestinationType(task.DestinationType),
+			Destination:     destinationFromDomain(task.Destination),
+			Payload:         msg.Value,
+			Metadata:        msg.Headers,
+			Headers:         msg.TransportHeaders,
+		}
+		if attempt.Metadata == nil {
+			attempt.Metadata = make(map[string]string)
+		}
+		if attempt.Headers == nil {
+			attempt.Headers = make(map[string]string)
+		}
+		if err := hook(ctx, attempt); err != nil {
+			return err
+		}
+		msg.Value = attempt.Payload
+		msg.Headers = attempt.Metadata
+		msg.TransportHeaders = attempt.Headers
+		return nil
+	}
+}
@@ -0,0 +1,48 @@
+package rebound
+
+import (
+	"errors"
+
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+	"github.com/ruudy-sib/rebound/internal/domain/service"
+)
+
+// Verdict is what an ErrorClassifier decides about a failed delivery.
+type Verdict int
+
+const (
+	// VerdictRetry retries the task with backoff. It is the zero value, so
+	// errors a classifier does not recognize are retried.
+	VerdictRetry Verdict = Verdict(service.VerdictRetry)
+
+	// VerdictPermanent sends the task straight to its dead destination
+	// without using up its remaining retries.
+	VerdictPermanent Verdict = Verdict(service.VerdictPermanent)
+)
+
+// ErrorClassifier decides whether a failed delivery is worth retrying, e.g.
+// to give up at once on a 400 or a payload the destination's schema
+// rejects. It is called with the error of every failed attempt and must be
+// safe for concurrent use.
+type ErrorClassifier func(err error) Verdict
+
+// DeliveryErrorCode returns the code the producer tagged a delivery error
+// with, such as "HTTP_4XX" or "AUTH_FAILED", or "" when err carries none.
+// The codes are listed in the README.
+func DeliveryErrorCode(err error) string {
+	var deliveryErr *entity.DeliveryError
+	if errors.As(err, &deliveryErr) {
+		return string(deliveryErr.Code)
+	}
+	return ""
+}
+
+// classifierOption registers classify, if set.
+func classifierOption(classify ErrorClassifier) []service.Option {
+	if classify == nil {
+		return nil
+	}
+	return []service.Option{service.WithErrorClassifier(func(err error) service.Verdict {
+		return service.Verdict(classify(err))
+	})}
+}
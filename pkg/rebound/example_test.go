@@ -2,6 +2,7 @@ package rebound_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"time"
@@ -17,7 +18,6 @@ func Example_basic() {
 	// Create configuration
 	cfg := &rebound.Config{
 		RedisAddr:    "localhost:6379",
-		KafkaBrokers: []string{"localhost:9092"},
 		PollInterval: 1 * time.Second,
 	}
 
@@ -49,7 +49,7 @@ func Example_basic() {
 			Topic: "orders-dlq",
 		},
 		MaxRetries:      3,
-		BaseDelay:       5,
+		BaseDelay:       5 * time.Second,
 		ClientID:        "order-service",
 		MessageData:     `{"order_id": 123, "action": "process"}`,
 		DestinationType: rebound.DestinationTypeKafka,
@@ -70,7 +70,7 @@ func Example_basic() {
 			URL: "https://api.partner.com/webhooks/dlq",
 		},
 		MaxRetries:      5,
-		BaseDelay:       10,
+		BaseDelay:       10 * time.Second,
 		ClientID:        "notification-service",
 		MessageData:     `{"event": "user.created", "user_id": 456}`,
 		DestinationType: rebound.DestinationTypeHTTP,
@@ -98,7 +98,6 @@ func Example_dependencyInjection() {
 	container.Provide(func() *rebound.Config {
 		return &rebound.Config{
 			RedisAddr:    "localhost:6379",
-			KafkaBrokers: []string{"localhost:9092"},
 			PollInterval: 1 * time.Second,
 		}
 	})
@@ -123,7 +122,7 @@ func Example_dependencyInjection() {
 				URL: "https://api.example.com/webhook",
 			},
 			MaxRetries:      3,
-			BaseDelay:       5,
+			BaseDelay:       5 * time.Second,
 			ClientID:        "my-service",
 			MessageData:     `{"hello": "world"}`,
 			DestinationType: rebound.DestinationTypeHTTP,
@@ -157,9 +156,98 @@ func Example_gracefulShutdown() {
 	// ... do work ...
 
 	// Graceful shutdown
-	cancel()                     // Stop the worker
+	cancel()                           // Stop the worker
 	time.Sleep(100 * time.Millisecond) // Wait for in-flight tasks
-	rb.Close()                   // Release resources
+	rb.Close()                         // Release resources
 
 	fmt.Println("Shutdown complete")
 }
+
+// ExamplePreviewSchedule shows when a task's attempts would be due if every
+// one of them failed, with equal jitter spreading the retries.
+func ExamplePreviewSchedule() {
+	task := &rebound.Task{
+		MaxRetries: 3,
+		BaseDelay:  4 * time.Second,
+		Jitter:     rebound.JitterEqual,
+	}
+
+	created := time.Now()
+	schedule, err := rebound.PreviewSchedule(task)
+	if err != nil {
+		log.Fatalf("Failed to preview schedule: %v", err)
+	}
+
+	for i, w := range schedule {
+		fmt.Printf("attempt %d: +%v to +%v\n", i+1,
+			w.Earliest.Sub(created).Round(time.Second), w.Latest.Sub(created).Round(time.Second))
+	}
+	// Output:
+	// attempt 1: +4s to +4s
+	// attempt 2: +6s to +8s
+	// attempt 3: +10s to +16s
+	// attempt 4: +18s to +32s
+}
+
+// ExampleSlackDestination retries an alert to a Slack channel until the
+// webhook accepts it, with a Teams channel as the dead destination.
+func ExampleSlackDestination() {
+	rb, err := rebound.New(rebound.DefaultConfig())
+	if err != nil {
+		log.Fatalf("Failed to create rebound: %v", err)
+	}
+	defer rb.Close()
+
+	err = rb.CreateTask(context.Background(), &rebound.Task{
+		ID:              "alert-42",
+		Source:          "monitoring",
+		Destination:     rebound.SlackDestination("https://hooks.slack.com/services/T000/B000/XXXX"),
+		DeadDestination: rebound.TeamsDestination("https://example.webhook.office.com/webhookb2/XXXX"),
+		DestinationType: rebound.DestinationTypeHTTP,
+		MaxRetries:      5,
+		BaseDelay:       10 * time.Second,
+		MessageData:     `{"text":"Disk usage on db-1 is above 90%"}`,
+	})
+	if err != nil {
+		log.Fatalf("Failed to create task: %v", err)
+	}
+}
+
+// ExampleTestHarness checks when a task whose destination is down is
+// dead-lettered, without Redis and without waiting.
+func ExampleTestHarness() {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	h := rebound.NewTestHarness(start, func(d rebound.Delivery) error {
+		if d.DeadLetter {
+			return nil
+		}
+		return errors.New("503 Service Unavailable")
+	})
+
+	err := h.CreateTask(context.Background(), &rebound.Task{
+		ID:                 "order-123",
+		Source:             "order-service",
+		Destination:        rebound.Destination{URL: "https://partner.example.com/hook"},
+		DeadDestination:    rebound.Destination{URL: "https://partner.example.com/dlq"},
+		DestinationType:    rebound.DestinationTypeHTTP,
+		MaxRetries:         3,
+		BaseDelay:          10 * time.Second,
+		AttemptImmediately: true,
+		MessageData:        `{"order_id":"123"}`,
+	})
+	if err != nil {
+		log.Fatalf("Failed to create task: %v", err)
+	}
+	if err := h.AdvanceTime(time.Minute + 10*time.Second); err != nil {
+		log.Fatalf("Failed to advance time: %v", err)
+	}
+
+	for _, e := range h.Events() {
+		fmt.Printf("attempt %d at +%v: %s\n", e.Attempt, e.At.Sub(start), e.Outcome)
+	}
+	// Output:
+	// attempt 0 at +0s: rescheduled
+	// attempt 1 at +10s: rescheduled
+	// attempt 2 at +30s: rescheduled
+	// attempt 3 at +1m10s: dead
+}
@@ -0,0 +1,214 @@
+package rebound
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/adapter/secondary/memstore"
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+	"github.com/ruudy-sib/rebound/internal/domain/service"
+	"github.com/ruudy-sib/rebound/internal/port/secondary"
+)
+
+// Outcome is what happened to a task when it came due.
+type Outcome string
+
+const (
+	// OutcomeDelivered means the attempt succeeded.
+	OutcomeDelivered Outcome = Outcome(entity.OutcomeDelivered)
+	// OutcomeRescheduled means the attempt failed and a retry was scheduled.
+	OutcomeRescheduled Outcome = Outcome(entity.OutcomeRescheduled)
+	// OutcomeDead means the attempt failed with no retries left and the
+	// task was dead-lettered.
+	OutcomeDead Outcome = Outcome(entity.OutcomeDead)
+	// OutcomeQuarantined means the task was isolated as a poison message.
+	OutcomeQuarantined Outcome = Outcome(entity.OutcomeQuarantined)
+)
+
+// Delivery is one message a TestHarness was asked to send: an attempt at
+// a task's destination, or its dead-letter message.
+type Delivery struct {
+	TaskID      string
+	Destination Destination
+	DeadLetter  bool
+	Payload     []byte
+	At          time.Time // on the harness clock
+}
+
+// DeliveryFunc decides whether a delivery made by a TestHarness succeeds.
+// It is called one delivery at a time, and may read the harness clock and
+// recorded deliveries.
+type DeliveryFunc func(d Delivery) error
+
+// TaskEvent reports what happened to a task when it came due on the
+// harness clock.
+type TaskEvent struct {
+	TaskID  string
+	Attempt int // retries made before this attempt, 0 for the first
+	Outcome Outcome
+	Error   string
+	At      time.Time
+}
+
+// TestHarness runs tasks through Rebound's retry logic in memory, on a
+// clock that only moves when AdvanceTime is called, so a test can check
+// e.g. that a task failing every attempt is dead-lettered exactly at
+// t+70s. It needs no Redis, broker or endpoint: deliver decides the result
+// of every delivery. Features backed by Redis stores, such as ordering
+// keys, cancellation and the dead-letter store, are not available, and
+// tasks naming a Queue are rejected.
+type TestHarness struct {
+	mu     sync.Mutex
+	now    time.Time
+	events []TaskEvent
+	sent   []Delivery
+
+	deliverMu sync.Mutex // serializes calls to deliver
+	deliver   DeliveryFunc
+
+	scheduler *memstore.Scheduler
+	service   *service.TaskService
+}
+
+// NewTestHarness creates a harness whose clock starts at start. A nil
+// deliver makes every delivery succeed.
+func NewTestHarness(start time.Time, deliver DeliveryFunc) *TestHarness {
+	h := &TestHarness{now: start, deliver: deliver}
+	h.scheduler = memstore.NewScheduler(h.Now)
+	h.service = service.NewTaskService(h.scheduler, harnessProducer{h}, zap.NewNop(),
+		service.WithClock(h.Now),
+		service.WithConcurrency(1),
+		service.WithDeadLetterPolicy(service.DeadLetterPolicy{MaxAttempts: 1}),
+	)
+	return h
+}
+
+// Now returns the harness clock.
+func (h *TestHarness) Now() time.Time {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.now
+}
+
+// CreateTask validates and schedules task as Rebound.CreateTask does,
+// relative to the harness clock.
+func (h *TestHarness) CreateTask(ctx context.Context, task *Task) error {
+	return h.service.CreateTask(ctx, task.toDomain())
+}
+
+// AdvanceTime moves the clock forward by d, stopping at each moment a task
+// becomes due to process it, retries scheduled along the way included.
+// When it returns, everything due by the new time has been processed.
+func (h *TestHarness) AdvanceTime(d time.Duration) error {
+	if d < 0 {
+		return fmt.Errorf("cannot advance time by %s", d)
+	}
+	target := h.Now().Add(d)
+
+	for {
+		due, ok := h.scheduler.NextDue()
+		if !ok || due.After(target) {
+			break
+		}
+		h.setNow(due)
+		if err := h.processDue(context.Background()); err != nil {
+			return err
+		}
+	}
+	h.setNow(target)
+	return nil
+}
+
+// processDue runs polls until nothing is due at the current time.
+func (h *TestHarness) processDue(ctx context.Context) error {
+	for {
+		result, err := h.service.ProcessDueTasks(ctx)
+		if err != nil {
+			return err
+		}
+		if len(result.Tasks) == 0 {
+			return nil
+		}
+
+		at := h.Now()
+		h.mu.Lock()
+		for _, t := range result.Tasks {
+			h.events = append(h.events, TaskEvent{
+				TaskID:  t.TaskID,
+				Attempt: t.Attempt,
+				Outcome: Outcome(t.Outcome),
+				Error:   t.Error,
+				At:      at,
+			})
+		}
+		h.mu.Unlock()
+	}
+}
+
+// setNow never moves the clock backwards: a task due in the past is
+// processed at the current time.
+func (h *TestHarness) setNow(t time.Time) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if t.After(h.now) {
+		h.now = t
+	}
+}
+
+// Events returns what happened to each task that came due, in order.
+func (h *TestHarness) Events() []TaskEvent {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]TaskEvent(nil), h.events...)
+}
+
+// Deliveries returns every delivery made, in order, including failed ones.
+func (h *TestHarness) Deliveries() []Delivery {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]Delivery(nil), h.sent...)
+}
+
+// Scheduled returns how many tasks are waiting for a future attempt.
+func (h *TestHarness) Scheduled(ctx context.Context) (int64, error) {
+	return h.scheduler.Count(ctx)
+}
+
+// harnessProducer hands every message to the harness's DeliveryFunc.
+type harnessProducer struct {
+	h *TestHarness
+}
+
+// deadLetterKey marks the message keys the service gives dead-letter messages.
+var deadLetterKey = []byte("|dead|")
+
+func (p harnessProducer) Produce(_ context.Context, destination entity.Destination, msg secondary.Message) error {
+	id, _, _ := bytes.Cut(msg.Key, []byte("|"))
+	d := Delivery{
+		TaskID:      string(id),
+		Destination: destinationFromDomain(destination),
+		DeadLetter:  bytes.Contains(msg.Key, deadLetterKey),
+		Payload:     msg.Value,
+		At:          p.h.Now(),
+	}
+
+	p.h.mu.Lock()
+	p.h.sent = append(p.h.sent, d)
+	p.h.mu.Unlock()
+
+	if p.h.deliver == nil {
+		return nil
+	}
+	p.h.deliverMu.Lock()
+	defer p.h.deliverMu.Unlock()
+	return p.h.deliver(d)
+}
+
+func (p harnessProducer) Close() error {
+	return nil
+}
@@ -0,0 +1,141 @@
+package rebound_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ruudy-sib/rebound/pkg/rebound"
+)
+
+func TestTestHarness_AdvanceTime(t *testing.T) {
+	errDown := errors.New("503 Service Unavailable")
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		failures  int // attempts that fail before one succeeds
+		advance   []time.Duration
+		outcome   rebound.Outcome
+		at        time.Duration
+		events    int
+		scheduled int64
+	}{
+		{
+			name:     "dead-lettered after every retry fails",
+			failures: 100,
+			advance:  []time.Duration{70 * time.Second},
+			outcome:  rebound.OutcomeDead,
+			at:       70 * time.Second,
+			events:   4,
+		},
+		{
+			name:     "delivered on the third attempt",
+			failures: 2,
+			advance:  []time.Duration{time.Hour},
+			outcome:  rebound.OutcomeDelivered,
+			at:       30 * time.Second,
+			events:   3,
+		},
+		{
+			name:      "retry not yet due",
+			failures:  100,
+			advance:   []time.Duration{5 * time.Second, 20 * time.Second},
+			outcome:   rebound.OutcomeRescheduled,
+			at:        10 * time.Second,
+			events:    2,
+			scheduled: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts := 0
+			h := rebound.NewTestHarness(start, func(d rebound.Delivery) error {
+				if d.DeadLetter {
+					return nil
+				}
+				attempts++
+				if attempts <= tt.failures {
+					return errDown
+				}
+				return nil
+			})
+
+			err := h.CreateTask(context.Background(), &rebound.Task{
+				ID:                 "task-1",
+				Source:             "test",
+				Destination:        rebound.Destination{URL: "https://partner.example.com/hook"},
+				DeadDestination:    rebound.Destination{URL: "https://partner.example.com/dlq"},
+				DestinationType:    rebound.DestinationTypeHTTP,
+				MaxRetries:         3,
+				BaseDelay:          10 * time.Second,
+				AttemptImmediately: true,
+				MessageData:        `{"id":1}`,
+			})
+			if err != nil {
+				t.Fatalf("CreateTask: %v", err)
+			}
+			for _, d := range tt.advance {
+				if err := h.AdvanceTime(d); err != nil {
+					t.Fatalf("AdvanceTime: %v", err)
+				}
+			}
+
+			events := h.Events()
+			if len(events) != tt.events {
+				t.Fatalf("expected %d events, got %+v", tt.events, events)
+			}
+			last := events[len(events)-1]
+			if last.Outcome != tt.outcome || last.At.Sub(start) != tt.at {
+				t.Fatalf("expected %s at +%v, got %s at +%v", tt.outcome, tt.at, last.Outcome, last.At.Sub(start))
+			}
+			if scheduled, _ := h.Scheduled(context.Background()); scheduled != tt.scheduled {
+				t.Fatalf("expected %d scheduled, got %d", tt.scheduled, scheduled)
+			}
+		})
+	}
+}
+
+func TestTestHarness_deadLetterDelivery(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	h := rebound.NewTestHarness(start, func(d rebound.Delivery) error {
+		if d.DeadLetter {
+			return nil
+		}
+		return errors.New("connection refused")
+	})
+
+	err := h.CreateTask(context.Background(), &rebound.Task{
+		ID:              "task-1",
+		Source:          "test",
+		Destination:     rebound.Destination{URL: "https://partner.example.com/hook"},
+		DeadDestination: rebound.Destination{URL: "https://partner.example.com/dlq"},
+		DestinationType: rebound.DestinationTypeHTTP,
+		MaxRetries:      1,
+		BaseDelay:       time.Second,
+		MessageData:     `{"id":1}`,
+	})
+	if err != nil {
+		t.Fatalf("CreateTask: %v", err)
+	}
+	if err := h.AdvanceTime(time.Minute); err != nil {
+		t.Fatalf("AdvanceTime: %v", err)
+	}
+	if !h.Now().Equal(start.Add(time.Minute)) {
+		t.Fatalf("expected clock at +1m, got %v", h.Now().Sub(start))
+	}
+
+	deliveries := h.Deliveries()
+	if len(deliveries) != 3 {
+		t.Fatalf("expected 2 attempts and a dead letter, got %+v", deliveries)
+	}
+	dead := deliveries[2]
+	if !dead.DeadLetter || dead.TaskID != "task-1" || dead.Destination.URL != "https://partner.example.com/dlq" {
+		t.Fatalf("unexpected dead-letter delivery: %+v", dead)
+	}
+	if dead.At.Sub(start) != 2*time.Second {
+		t.Fatalf("expected dead letter at +2s, got +%v", dead.At.Sub(start))
+	}
+}
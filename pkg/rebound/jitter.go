@@ -0,0 +1,21 @@
+package rebound
+
+// JitterMode randomizes retry delays so tasks that failed together do not
+// all retry at the same moment.
+type JitterMode string
+
+const (
+	// JitterNone waits the exponential delay exactly. It is the default.
+	JitterNone JitterMode = "none"
+
+	// JitterFull waits a random time between zero and the exponential delay.
+	JitterFull JitterMode = "full"
+
+	// JitterEqual waits half the exponential delay plus a random time up to
+	// the other half.
+	JitterEqual JitterMode = "equal"
+
+	// JitterDecorrelated waits a random time between BaseDelay and three
+	// times the previous delay.
+	JitterDecorrelated JitterMode = "decorrelated"
+)
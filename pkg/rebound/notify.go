@@ -0,0 +1,30 @@
+package rebound
+
+// PayloadFormat names a chat platform schema an HTTP destination wraps the
+// message in before posting it.
+type PayloadFormat string
+
+const (
+	// PayloadFormatSlack posts the message as a Slack incoming webhook
+	// text message.
+	PayloadFormatSlack PayloadFormat = "slack"
+
+	// PayloadFormatTeams posts the message as a Microsoft Teams webhook
+	// message holding an Adaptive Card with the text.
+	PayloadFormatTeams PayloadFormat = "teams"
+)
+
+// SlackDestination returns an HTTP destination posting to a Slack incoming
+// webhook. MessageData becomes the text of the Slack message: a JSON
+// object's "text" field if it has one, otherwise the message as it is.
+// Use it with DestinationTypeHTTP; tasks for it are never batched.
+func SlackDestination(webhookURL string) Destination {
+	return Destination{URL: webhookURL, Format: PayloadFormatSlack}
+}
+
+// TeamsDestination returns an HTTP destination posting to a Microsoft
+// Teams incoming webhook or workflow, with MessageData read as for
+// SlackDestination.
+func TeamsDestination(webhookURL string) Destination {
+	return Destination{URL: webhookURL, Format: PayloadFormatTeams}
+}
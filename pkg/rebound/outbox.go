@@ -0,0 +1,113 @@
+package rebound
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ruudy-sib/rebound/internal/adapter/secondary/outboxsource"
+	"github.com/ruudy-sib/rebound/internal/domain"
+)
+
+// OutboxDialect selects the SQL syntax of the outbox database.
+type OutboxDialect string
+
+const (
+	OutboxPostgres OutboxDialect = "postgres"
+	OutboxMySQL    OutboxDialect = "mysql"
+)
+
+// OutboxConfig configures RelayOutbox. DB and Dialect are required.
+type OutboxConfig struct {
+	DB           *sql.DB
+	Dialect      OutboxDialect
+	Table        string        // defaults to "rebound_outbox"
+	BatchSize    int           // rows relayed per transaction; defaults to 100
+	PollInterval time.Duration // wait after a poll that relayed nothing; defaults to 1s
+}
+
+// OutboxRow is a pending row of the outbox table.
+type OutboxRow struct {
+	ID      int64
+	Payload []byte
+}
+
+// OutboxMapper converts an outbox row into a rebound task. Returning a nil
+// task drops the row; returning an error keeps the row with the error
+// recorded and skips it from then on.
+type OutboxMapper func(row OutboxRow) (*Task, error)
+
+// RelayOutbox tails an outbox table and creates a task for each row, so a
+// service can write the row in the same database transaction as its
+// business data instead of calling CreateTask after committing. It runs
+// until ctx is cancelled, and returns an error only for an invalid cfg.
+//
+// The table needs an ascending integer id, a payload, and a nullable error
+// column. Rows are deleted once their task is created; if the deletion is
+// lost, the row is relayed again, so derive the task ID from the row to let
+// duplicate deliveries be recognized. Rows the mapper or task validation
+// rejects keep the reason in error. Other failures, such as Redis being
+// unavailable, leave the row for the next poll.
+func (r *Rebound) RelayOutbox(ctx context.Context, cfg OutboxConfig, mapper OutboxMapper) error {
+	if cfg.DB == nil {
+		return errors.New("outbox DB is required")
+	}
+	if mapper == nil {
+		return errors.New("outbox mapper is required")
+	}
+	if cfg.Table == "" {
+		cfg.Table = "rebound_outbox"
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 100
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = time.Second
+	}
+	reader, err := outboxsource.NewReader(cfg.DB, outboxsource.Dialect(cfg.Dialect), cfg.Table, r.logger)
+	if err != nil {
+		return err
+	}
+
+	handle := func(ctx context.Context, row outboxsource.Row) error {
+		task, err := mapper(OutboxRow{ID: row.ID, Payload: row.Payload})
+		if err != nil {
+			return fmt.Errorf("%w: %v", outboxsource.ErrRejected, err)
+		}
+		if task == nil {
+			return nil
+		}
+		err = r.taskService.CreateTask(ctx, task.toDomain())
+		if errors.Is(err, domain.ErrInvalidTask) {
+			return fmt.Errorf("%w: %v", outboxsource.ErrRejected, err)
+		}
+		return err
+	}
+
+	for {
+		result, err := reader.Process(ctx, cfg.BatchSize, handle)
+		if err != nil && ctx.Err() == nil {
+			r.logger.Error("outbox relay failed", zap.Error(err))
+		}
+		if result.Relayed > 0 || result.Rejected > 0 {
+			r.logger.Info("outbox rows relayed",
+				zap.Int("relayed", result.Relayed),
+				zap.Int("rejected", result.Rejected),
+			)
+		}
+		// A full batch likely means more rows are waiting.
+		if err == nil && result.Relayed+result.Rejected == cfg.BatchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(cfg.PollInterval):
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package rebound
+
+import (
+	"errors"
+	"time"
+
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+)
+
+// AttemptWindow bounds when an attempt would be due. Without jitter both
+// ends are the same time; with it, the attempt lands anywhere in between.
+type AttemptWindow struct {
+	Earliest time.Time
+	Latest   time.Time
+}
+
+// PreviewSchedule returns when each attempt of task would be due if it were
+// created now and every attempt failed: the first attempt, then one per
+// retry up to MaxRetries or through RetrySchedule. The task's Jitter
+// applies, so each attempt comes as a window; a task without one previews
+// JitterNone, since the deployment default is not known here. A task whose
+// last attempt fails is dead-lettered right after it. Time spent
+// delivering and waiting for the next poll is not included, so real
+// attempts land slightly later.
+func PreviewSchedule(task *Task) ([]AttemptWindow, error) {
+	if task == nil {
+		return nil, errors.New("task is required")
+	}
+	if task.BaseDelay <= 0 && len(task.RetrySchedule) == 0 {
+		return nil, errors.New("base delay must be positive")
+	}
+	if task.MaxRetries < 0 {
+		return nil, errors.New("max retries must not be negative")
+	}
+	mode := entity.JitterMode(task.Jitter)
+	if !mode.Valid() {
+		return nil, errors.New("unknown jitter mode")
+	}
+
+	t, now := task.toDomain(), time.Now()
+	earliest := t.RetrySchedule(now, mode, 0)
+	latest := t.RetrySchedule(now, mode, 1)
+	windows := make([]AttemptWindow, len(earliest))
+	for i := range windows {
+		windows[i] = AttemptWindow{Earliest: earliest[i], Latest: latest[i]}
+	}
+	return windows, nil
+}
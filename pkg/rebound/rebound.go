@@ -9,13 +9,19 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/ruudy-sib/rebound/internal/adapter/primary/worker"
+	"github.com/ruudy-sib/rebound/internal/adapter/secondary/egress"
+	"github.com/ruudy-sib/rebound/internal/adapter/secondary/healthprobe"
 	"github.com/ruudy-sib/rebound/internal/adapter/secondary/httpproducer"
+	"github.com/ruudy-sib/rebound/internal/adapter/secondary/idgen"
 	"github.com/ruudy-sib/rebound/internal/adapter/secondary/kafkaproducer"
 	"github.com/ruudy-sib/rebound/internal/adapter/secondary/producerfactory"
+	"github.com/ruudy-sib/rebound/internal/adapter/secondary/producermetrics"
 	"github.com/ruudy-sib/rebound/internal/adapter/secondary/redisstore"
 	"github.com/ruudy-sib/rebound/internal/config"
+	"github.com/ruudy-sib/rebound/internal/domain"
 	"github.com/ruudy-sib/rebound/internal/domain/entity"
 	"github.com/ruudy-sib/rebound/internal/domain/service"
+	"github.com/ruudy-sib/rebound/internal/domain/valueobject"
 	"github.com/ruudy-sib/rebound/internal/port/primary"
 	"github.com/ruudy-sib/rebound/internal/port/secondary"
 )
@@ -24,9 +30,16 @@ import (
 // It can be embedded in other Go applications to provide retry functionality.
 type Rebound struct {
 	taskService primary.TaskService
+	maintenance primary.MaintenanceService
+	sla         primary.SLAService
+	usage       primary.UsageService
 	worker      *worker.Worker
+	janitor     *worker.Janitor
 	producer    secondary.MessageProducer
+	metrics     *producermetrics.Recorder
 	redisClient goredis.UniversalClient
+	replicator  *redisstore.Replicator
+	migrator    *redisstore.Migrator
 	logger      *zap.Logger
 	config      *Config
 }
@@ -48,20 +61,323 @@ type Config struct {
 	// Cluster Redis (RedisMode = "cluster")
 	RedisClusterAddrs []string
 
-	// Worker configuration
+	// ReplicaRedisAddr, if set, is a standalone Redis, e.g. in another
+	// region, that scheduled tasks are mirrored to asynchronously. Pointing
+	// RedisAddr at it after losing the primary resumes the retry backlog,
+	// less writes not yet replicated.
+	ReplicaRedisAddr     string
+	ReplicaRedisPassword string
+
+	// MigrationTargetRedisAddr (standalone) or
+	// MigrationTargetRedisClusterAddrs (cluster), if set, is a Redis the
+	// schedule is being moved to. New writes are dual-written to it while
+	// Start copies the tasks already scheduled; once that is logged as
+	// complete and ReplicationStatus shows the target caught up, switch the
+	// Redis settings to the target. It cannot be combined with
+	// ReplicaRedisAddr.
+	MigrationTargetRedisAddr         string
+	MigrationTargetRedisClusterAddrs []string
+	MigrationTargetRedisPassword     string
+
+	// TaskEncoding is how newly scheduled tasks are stored in Redis: "json"
+	// (default) or "protobuf", which uses about half the memory. Tasks in
+	// either encoding are always readable, so it can be switched at any time.
+	TaskEncoding string
+
+	// Worker configuration. A PollInterval under one second switches to
+	// low-latency polling; see the README for the Redis load it adds.
 	PollInterval time.Duration
 
+	// BatchSize is how many due tasks one poll fetches. Defaults to 10.
+	BatchSize int
+
+	// PriorityShare caps the percentage of each fetched batch that priority
+	// tasks take while normal tasks are due too, so a flood of priority
+	// tasks does not starve the rest. Either kind fills the batch when the
+	// other runs short. Zero, the default, fetches strictly by due time.
+	PriorityShare int
+
+	// DeliveryConcurrency is how many due tasks, or HTTP batches, one poll
+	// delivers at once. Defaults to 10.
+	DeliveryConcurrency int
+
+	// Queues declares named queues, each polled on its own loop with its
+	// own interval, batch size and share of DeliveryConcurrency. Tasks pick
+	// one with Task.Queue; the rest stay in the default queue.
+	Queues map[string]QueueConfig
+
+	// DeliveryTimeout bounds each delivery attempt, and its attempt hooks
+	// unless AttemptHookTimeout is set. An attempt still running when it elapses, or when the context passed
+	// to Start is cancelled and DrainTimeout has passed, fails as a timeout.
+	// Defaults to 30s.
+	DeliveryTimeout time.Duration
+
+	// MinBaseDelay, MaxBaseDelay and MaxRetryLimit bound the BaseDelay and
+	// MaxRetries of new tasks. They default to 100ms, 1h and 100. Like
+	// Task.BaseDelay, a delay under a millisecond is taken as whole seconds.
+	MinBaseDelay  time.Duration
+	MaxBaseDelay  time.Duration
+	MaxRetryLimit int
+
+	// IDStrategy gives tasks created without an ID a generated one:
+	// "uuidv7", "ulid", or "snowflake", which embeds IDNodeID (0 to 1023)
+	// and so needs a different one per instance. Generated IDs sort in
+	// creation order. CreateTask sets the ID on the task passed to it.
+	// Empty, the default, rejects tasks without an ID.
+	IDStrategy string
+	IDNodeID   int
+
+	// IDGenerator, if set, generates the IDs of tasks created without one
+	// instead of IDStrategy. It must be safe for concurrent use and return
+	// unique IDs.
+	IDGenerator func() string
+
+	// Jitter randomizes the retry delays of tasks that do not set their
+	// own Task.Jitter. Defaults to JitterNone.
+	Jitter JitterMode
+
+	// MaxDelay caps the retry delays of tasks that do not set their own
+	// Task.MaxDelay. Zero, the default, leaves them uncapped.
+	MaxDelay time.Duration
+
+	// Validators check every new task after the built-in rules, in order.
+	// A validator's error rejects the task.
+	Validators []TaskValidator
+
+	// ErrorClassifier, if set, is asked whether each failed delivery is
+	// worth retrying. Errors it marks VerdictPermanent dead-letter the task
+	// at once, skipping its remaining retries and any re-drive policy.
+	// Without it every failure is retried.
+	ErrorClassifier ErrorClassifier
+
+	// MaxPendingTasks caps how many tasks may be scheduled at once. Creating
+	// a task beyond it is handled by OverflowPolicy; retries are never
+	// rejected. Zero disables the cap.
+	MaxPendingTasks int64
+
+	// OverflowPolicy is what happens to new tasks beyond MaxPendingTasks:
+	// "reject" (the default) fails them with ErrQueueFull, "drop-oldest"
+	// drops the earliest scheduled non-priority tasks to make room, and
+	// "spill" parks them in Redis and schedules them once there is room.
+	OverflowPolicy string
+
+	// DrainTimeout is how long deliveries in progress when the context
+	// passed to Start is cancelled may keep running before they are
+	// aborted. Defaults to 10s.
+	DrainTimeout time.Duration
+
+	// ReclaimAfterTimeouts is how many DeliveryTimeouts a task may stay in
+	// flight before it is presumed lost with a crashed worker and retried.
+	// Defaults to 3.
+	ReclaimAfterTimeouts int
+
+	// JanitorInterval is how often Redis indexes and registries are
+	// reconciled against the data they describe, repairing state left
+	// behind by a crashed process. One instance does the work per interval.
+	// Defaults to 5m.
+	JanitorInterval time.Duration
+
+	// PoisonThreshold quarantines a task after this many consecutive instant
+	// failures with the same error (e.g. a malformed payload rejected with 400).
+	// Zero disables poison message detection.
+	PoisonThreshold int
+
+	// PoisonFailureWindow is the attempt duration below which a failure counts
+	// as instant. Defaults to 1s.
+	PoisonFailureWindow time.Duration
+
+	// DeadLetterMaxAttempts is how many times producing to a dead destination
+	// is attempted before the task is stored in the Redis dead-letter set.
+	// Defaults to 3.
+	DeadLetterMaxAttempts int
+
+	// DeadLetterBackoff is the initial wait between dead-letter produce
+	// attempts; it doubles after each failure. Defaults to 200ms.
+	DeadLetterBackoff time.Duration
+
+	// DeadLetterMode is where tasks that exhausted their retries are kept:
+	// "produce" (the default) produces them to their DeadDestination, "store"
+	// keeps them in the Redis dead-letter store instead, for deployments
+	// without a dead-letter queue, and "both" does both. Stored tasks are
+	// listed with ListDeadLetters, replayed with RequeueDeadLetter and kept
+	// for DeadLetterRetention.
+	DeadLetterMode string
+
+	// DeadLetterRedrive re-drives tasks that exhausted their retries instead
+	// of dead-lettering them, keyed by dead destination (URL or topic).
+	DeadLetterRedrive map[string]RedrivePolicy
+
+	// CompletionMarkerTTL is how long a delivered task ID is remembered to
+	// suppress duplicate deliveries of the same ID. Defaults to 24h.
+	CompletionMarkerTTL time.Duration
+
+	// DeliveryResultTTL is how long the result of each successful delivery,
+	// including the HTTP response or Kafka offset, is kept for
+	// DeliveryResult. Zero disables recording.
+	DeliveryResultTTL time.Duration
+
+	// DeadLetterRetention is how long tasks stored in the Redis dead-letter
+	// set are kept before the janitor deletes them. Zero keeps them until
+	// they are requeued.
+	DeadLetterRetention time.Duration
+
+	// QuarantineRetention is how long quarantined poison tasks are kept
+	// before the janitor deletes them. Zero keeps them forever.
+	QuarantineRetention time.Duration
+
+	// CancelledTaskTTL is how long a cancelled task can be restored with
+	// RestoreTask. Defaults to 7 days.
+	CancelledTaskTTL time.Duration
+
+	// HTTPBatchSize coalesces up to this many due HTTP tasks targeting the
+	// same URL into one POST with a JSON array body. The receiver must accept
+	// arrays. Zero or one disables batching.
+	HTTPBatchSize int
+
+	// HTTPMaxRequestBytes is the largest body an HTTP delivery sends; a
+	// larger one fails with error code PAYLOAD_TOO_LARGE without being sent.
+	// Defaults to 10 MiB.
+	HTTPMaxRequestBytes int
+
+	// HTTPMaxResponseBytes is how much of an HTTP destination's response
+	// body is read; the rest is discarded. Defaults to 64 KiB.
+	HTTPMaxResponseBytes int
+
+	// HTTPDNSCacheTTL caches the DNS answers for HTTP destinations this
+	// long, so mass retries to one hostname do not hammer the resolver.
+	// Zero, the default, resolves on every new connection.
+	HTTPDNSCacheTTL time.Duration
+
+	// HTTPHostOverrides dials the hostname or IP mapped to a destination's
+	// hostname instead of resolving it, e.g. to point a staging deployment
+	// at a sandbox. Requests keep their Host header and TLS server name.
+	HTTPHostOverrides map[string]string
+
+	// HTTPRetryableStatuses, if set, lists the HTTP response statuses
+	// worth retrying, as codes such as "429" or classes such as "5xx". A
+	// delivery failing with any other status is dead-lettered at once.
+	// Empty, the default, retries every status.
+	HTTPRetryableStatuses []string
+
+	// HTTPEgressDenyPrivate refuses HTTP deliveries, callbacks and probes
+	// to private, loopback, link-local and other non-public addresses,
+	// IPv4 and IPv6, so task submitters cannot reach internal services.
+	// Refused attempts fail with error code EGRESS_DENIED.
+	HTTPEgressDenyPrivate bool
+
+	// HTTPEgressAllow, if set, is the only set of destinations HTTP
+	// deliveries may reach: CIDRs, IP addresses, hostnames, or
+	// "*.example.com" for any subdomain. Listed CIDRs and IPs are allowed
+	// even with HTTPEgressDenyPrivate; listed hostnames are not.
+	HTTPEgressAllow []string
+
+	// ProbeBacklogThreshold enables health probing of destinations with at
+	// least this many scheduled tasks: a HEAD request for URLs, a TCP
+	// connect for Kafka brokers. While a probe fails their tasks are held
+	// without counting an attempt; after recovery the backlog is released
+	// gradually. Zero disables probing.
+	ProbeBacklogThreshold int
+
+	// ProbeInterval is how often such a destination is probed. Defaults to 10s.
+	ProbeInterval time.Duration
+
+	// ProbeTimeout bounds each probe. Defaults to 2s.
+	ProbeTimeout time.Duration
+
+	// ProbeReleaseRate is how many due tasks of a recovered destination are
+	// delivered in the first poll, doubling every poll after. Defaults to 1.
+	ProbeReleaseRate int
+
+	// RetryStormThreshold throttles a destination once this many retries
+	// are scheduled for it within RetryStormWindow, a sign it is down:
+	// retries to it wait RetryStormMultiplier times longer until a window
+	// sees fewer than half as many. Zero disables throttling.
+	RetryStormThreshold int
+
+	// RetryStormWindow is the window retries are counted over. Defaults to 1m.
+	RetryStormWindow time.Duration
+
+	// RetryStormMultiplier is how many times longer retries to a throttled
+	// destination wait. Defaults to 4.
+	RetryStormMultiplier int
+
+	// SLAThreshold reports a breach when a task is delivered more than this
+	// long after it was created. Zero disables breach detection.
+	SLAThreshold time.Duration
+
+	// SLABreachURL optionally receives a JSON event for every SLA breach.
+	SLABreachURL string
+
+	// UsageEvents optionally receives each poll's delivered and retried
+	// counts as JSON events, one per client, keyed by client ID. Set Topic
+	// (with Host and Port) for Kafka or URL for HTTP.
+	UsageEvents Destination
+
+	// NotifyChannel optionally names a Redis Pub/Sub channel on which a
+	// compact JSON notification (task ID, state, client ID) is published
+	// for every task delivered, dead-lettered or quarantined.
+	NotifyChannel string
+
+	// AttemptHooks run right before each delivery attempt and may rewrite
+	// it. They are keyed by destination URL (HTTP) or topic (Kafka); the
+	// hook under "" runs for every task, before the destination's own.
+	AttemptHooks map[string]AttemptHook
+
+	// AttemptHookTimeout bounds the attempt hooks run before each attempt,
+	// apart from the delivery itself. Hooks allowed to run for longer than
+	// ReclaimAfterTimeouts delivery timeouts must call ExtendLease, or
+	// their task is reclaimed. Defaults to DeliveryTimeout.
+	AttemptHookTimeout time.Duration
+
+	// LifecycleWebhookURLs receive a JSON event when a task is dead-lettered
+	// or quarantined, or when the queue reaches MaxPendingTasks. Events are
+	// delivered as ordinary HTTP tasks, with retries.
+	LifecycleWebhookURLs []string
+
+	// RedactFields are JSONPath expressions (e.g. "$.user.email",
+	// "$..password", "$.items[*].card") of payload fields replaced with
+	// "[REDACTED]" in dead-letter messages. A payload that is not JSON is
+	// redacted whole when any are set.
+	RedactFields []string
+
+	// DeliveryLogSampleFirst samples the per-task delivery logs: each
+	// second, the first this many with the same message are logged, then
+	// every DeliveryLogSampleThereafter-th one. Warnings and errors are
+	// never sampled. Zero disables sampling.
+	DeliveryLogSampleFirst      int
+	DeliveryLogSampleThereafter int
+
 	// Logger (if nil, a default logger will be created)
 	Logger *zap.Logger
 }
 
+// RedrivePolicy re-schedules a task that exhausted its retries after
+// Cooldown, with MaxRetries more retries. A task is re-driven at most
+// MaxRedrives times (default 1) before it is dead-lettered.
+type RedrivePolicy struct {
+	Cooldown    time.Duration
+	MaxRetries  int
+	MaxRedrives int
+}
+
+// QueueConfig configures a named queue. PollInterval and BatchSize default
+// to Config.PollInterval and Config.BatchSize. Weight is the queue's share
+// of DeliveryConcurrency relative to the default queue, which weighs 1;
+// zero counts as 1.
+type QueueConfig struct {
+	PollInterval time.Duration
+	BatchSize    int
+	Weight       int
+}
+
 // DefaultConfig returns a configuration with sensible defaults.
 func DefaultConfig() *Config {
 	return &Config{
-		RedisAddr:    "localhost:6379",
+		RedisAddr:     "localhost:6379",
 		RedisPassword: "",
-		RedisDB:      0,
-		PollInterval: 1 * time.Second,
+		RedisDB:       0,
+		PollInterval:  1 * time.Second,
+		BatchSize:     10,
 	}
 }
 
@@ -81,6 +397,37 @@ func New(cfg *Config) (*Rebound, error) {
 		}
 	}
 
+	redaction, err := valueobject.NewRedaction(cfg.RedactFields)
+	if err != nil {
+		return nil, fmt.Errorf("RedactFields: %w", err)
+	}
+	retryableStatuses, err := valueobject.NewRetryableStatuses(cfg.HTTPRetryableStatuses)
+	if err != nil {
+		return nil, fmt.Errorf("HTTPRetryableStatuses: %w", err)
+	}
+	newID := service.IDGenerator(cfg.IDGenerator)
+	if newID == nil && cfg.IDStrategy != "" {
+		if newID, err = idgen.New(cfg.IDStrategy, cfg.IDNodeID); err != nil {
+			return nil, fmt.Errorf("IDStrategy: %w", err)
+		}
+	}
+	deadLetterMode := entity.DeadLetterMode(cfg.DeadLetterMode)
+	if deadLetterMode != "" && !deadLetterMode.Valid() {
+		return nil, fmt.Errorf("DeadLetterMode: unknown mode %q", cfg.DeadLetterMode)
+	}
+	if cfg.PriorityShare < 0 || cfg.PriorityShare > 100 {
+		return nil, fmt.Errorf("PriorityShare: must be between 0 and 100, got %d", cfg.PriorityShare)
+	}
+	if !entity.JitterMode(cfg.Jitter).Valid() {
+		return nil, fmt.Errorf("Jitter: unknown mode %q", cfg.Jitter)
+	}
+	if _, ok := cfg.Queues[""]; ok {
+		return nil, fmt.Errorf("Queues: a queue name must not be empty")
+	}
+	if cfg.MaxDelay < 0 {
+		return nil, fmt.Errorf("MaxDelay: must not be negative, got %s", cfg.MaxDelay)
+	}
+
 	// Convert to internal config format
 	internalCfg := &config.Config{
 		RedisMode:          cfg.RedisMode,
@@ -91,6 +438,15 @@ func New(cfg *Config) (*Rebound, error) {
 		RedisSentinelAddrs: cfg.RedisSentinelAddrs,
 		RedisClusterAddrs:  cfg.RedisClusterAddrs,
 		PollInterval:       cfg.PollInterval,
+		DeliveryTimeout:    cfg.DeliveryTimeout,
+
+		HTTPMaxRequestBytes:  cfg.HTTPMaxRequestBytes,
+		HTTPMaxResponseBytes: cfg.HTTPMaxResponseBytes,
+		HTTPDNSCacheTTL:      cfg.HTTPDNSCacheTTL,
+		HTTPHostOverrides:    cfg.HTTPHostOverrides,
+
+		HTTPEgressDenyPrivate: cfg.HTTPEgressDenyPrivate,
+		HTTPEgressAllow:       cfg.HTTPEgressAllow,
 	}
 
 	// Create Redis client
@@ -99,25 +455,156 @@ func New(cfg *Config) (*Rebound, error) {
 		return nil, fmt.Errorf("creating redis client: %w", err)
 	}
 
+	// Create the migrator moving the schedule to another Redis, if any
+	var migrator *redisstore.Migrator
+	if cfg.MigrationTargetRedisAddr != "" || len(cfg.MigrationTargetRedisClusterAddrs) > 0 {
+		if cfg.ReplicaRedisAddr != "" {
+			_ = redisClient.Close()
+			return nil, fmt.Errorf("ReplicaRedisAddr cannot be set during a migration")
+		}
+		targetCfg := &config.Config{
+			RedisAddr:     cfg.MigrationTargetRedisAddr,
+			RedisPassword: cfg.MigrationTargetRedisPassword,
+		}
+		if len(cfg.MigrationTargetRedisClusterAddrs) > 0 {
+			targetCfg.RedisMode = "cluster"
+			targetCfg.RedisClusterAddrs = cfg.MigrationTargetRedisClusterAddrs
+		}
+		target, err := redisstore.NewClient(context.Background(), targetCfg, logger)
+		if err != nil {
+			_ = redisClient.Close()
+			return nil, fmt.Errorf("creating migration target redis client: %w", err)
+		}
+		migrator = redisstore.NewMigrator(redisClient, target, logger)
+	}
+
+	// Create the replicator mirroring the schedule to a standby or
+	// migration target, if any
+	var replicator *redisstore.Replicator
+	if migrator != nil {
+		replicator = migrator.Replicator()
+	} else if cfg.ReplicaRedisAddr != "" {
+		standby, err := redisstore.NewClient(context.Background(), &config.Config{
+			RedisAddr:     cfg.ReplicaRedisAddr,
+			RedisPassword: cfg.ReplicaRedisPassword,
+		}, logger)
+		if err != nil {
+			_ = redisClient.Close()
+			return nil, fmt.Errorf("creating standby redis client: %w", err)
+		}
+		replicator = redisstore.NewReplicator(redisClient, standby, logger)
+	}
+
 	// Create scheduler
-	scheduler := redisstore.NewScheduler(redisClient, logger)
+	encoding := redisstore.WithTaskEncoding(redisstore.TaskEncoding(cfg.TaskEncoding))
+	scheduler := redisstore.NewScheduler(redisClient, logger, encoding, redisstore.WithReplicator(replicator),
+		redisstore.WithLowLatency(cfg.PollInterval < domain.LowLatencyPollInterval),
+		redisstore.WithPriorityShare(cfg.PriorityShare),
+		redisstore.WithNamedQueues(len(cfg.Queues) > 0))
 
 	// Create producers — Kafka connections are established per destination at delivery time.
-	kafkaProd := kafkaproducer.NewDestinationProducer(logger)
-	httpProd := httpproducer.NewProducer(internalCfg, logger)
+	metrics := producermetrics.NewRecorder()
+	kafkaProd := kafkaproducer.NewDestinationProducer(logger, kafkaproducer.WithMetrics(metrics))
+	httpProd := httpproducer.NewProducer(internalCfg, logger, httpproducer.WithMetrics(metrics))
 	producer := producerfactory.NewFactory(kafkaProd, httpProd, logger)
+	prober := healthprobe.NewProber(cfg.ProbeTimeout, logger,
+		healthprobe.WithEgressPolicy(egress.NewPolicy(cfg.HTTPEgressDenyPrivate, cfg.HTTPEgressAllow)))
 
 	// Create domain service
-	taskService := service.NewTaskService(scheduler, producer, logger)
+	quarantine := redisstore.NewQuarantineStore(redisClient, logger)
+	maintenanceStore := redisstore.NewMaintenanceStore(redisClient, logger)
+	slaStore := redisstore.NewSLAStore(redisClient, logger)
+	usageStore := redisstore.NewUsageStore(redisClient, logger)
+	var notifier secondary.TaskNotifier
+	if cfg.NotifyChannel != "" {
+		notifier = redisstore.NewNotifier(redisClient, cfg.NotifyChannel)
+	}
+	serviceOpts := []service.Option{
+		service.WithQuarantine(quarantine, service.PoisonPolicy{
+			Threshold:     cfg.PoisonThreshold,
+			FailureWindow: cfg.PoisonFailureWindow,
+		}),
+		service.WithDeadLetterPolicy(service.DeadLetterPolicy{
+			MaxAttempts: cfg.DeadLetterMaxAttempts,
+			Backoff:     cfg.DeadLetterBackoff,
+		}),
+		service.WithDeadLetterFallback(redisstore.NewDeadLetterStore(redisClient, logger)),
+		service.WithDeadLetterMode(deadLetterMode),
+		service.WithRedrivePolicies(redrivePolicies(cfg.DeadLetterRedrive)),
+		service.WithOrdering(redisstore.NewOrderingStore(redisClient, logger, encoding)),
+		service.WithCompletionMarkers(redisstore.NewCompletionStore(redisClient), cfg.CompletionMarkerTTL),
+		service.WithDeliveryResults(redisstore.NewDeliveryResultStore(redisClient), cfg.DeliveryResultTTL),
+		service.WithCancellation(redisstore.NewCancelledStore(redisClient), cfg.CancelledTaskTTL),
+		service.WithBatchSize(cfg.BatchSize),
+		service.WithQueues(queuePolicies(cfg.Queues)),
+		service.WithDeliveryTimeout(cfg.DeliveryTimeout),
+		service.WithInFlightReclaim(redisstore.NewInFlightStore(redisClient, logger), cfg.ReclaimAfterTimeouts),
+		service.WithHTTPBatching(cfg.HTTPBatchSize),
+		service.WithConcurrency(cfg.DeliveryConcurrency),
+		service.WithValidationBounds(service.ValidationBounds{
+			MinBaseDelay:  legacyDelay(cfg.MinBaseDelay),
+			MaxBaseDelay:  legacyDelay(cfg.MaxBaseDelay),
+			MaxRetryLimit: cfg.MaxRetryLimit,
+		}),
+		service.WithMaxPending(cfg.MaxPendingTasks),
+		service.WithJitter(entity.JitterMode(cfg.Jitter)),
+		service.WithMaxDelay(cfg.MaxDelay),
+		service.WithOverflow(entity.OverflowAction(cfg.OverflowPolicy), redisstore.NewOverflowStore(redisClient, logger, encoding)),
+		service.WithMaintenance(maintenanceStore),
+		service.WithSLATracking(slaStore, service.SLAPolicy{
+			Threshold: cfg.SLAThreshold,
+			BreachURL: cfg.SLABreachURL,
+		}),
+		service.WithUsageTracking(usageStore, cfg.UsageEvents.toDomain()),
+		service.WithTerminalNotifications(notifier),
+		service.WithHealthProbes(prober, service.ProbePolicy{
+			BacklogThreshold: cfg.ProbeBacklogThreshold,
+			Interval:         cfg.ProbeInterval,
+			ReleaseRate:      cfg.ProbeReleaseRate,
+		}),
+		service.WithRetryStormThrottling(service.StormPolicy{
+			Threshold:  cfg.RetryStormThreshold,
+			Window:     cfg.RetryStormWindow,
+			Multiplier: cfg.RetryStormMultiplier,
+		}),
+		service.WithRetryableStatuses(retryableStatuses),
+		service.WithIDGenerator(newID),
+		service.WithRedaction(redaction),
+		service.WithLifecycleWebhooks(cfg.LifecycleWebhookURLs),
+		service.WithDeliveryLogSampling(service.LogSampling{
+			First:      cfg.DeliveryLogSampleFirst,
+			Thereafter: cfg.DeliveryLogSampleThereafter,
+		}),
+	}
+	serviceOpts = append(serviceOpts, attemptHookOptions(cfg.AttemptHooks)...)
+	serviceOpts = append(serviceOpts, service.WithAttemptHookTimeout(cfg.AttemptHookTimeout))
+	serviceOpts = append(serviceOpts, validatorOptions(cfg.Validators)...)
+	serviceOpts = append(serviceOpts, classifierOption(cfg.ErrorClassifier)...)
+	taskService := service.NewTaskService(scheduler, producer, logger, serviceOpts...)
 
 	// Create worker
-	wrk := worker.NewWorker(taskService, cfg.PollInterval, logger)
+	wrk := worker.NewWorker(taskService, cfg.PollInterval, logger,
+		worker.WithDrainTimeout(cfg.DrainTimeout),
+		worker.WithQueueIntervals(queueIntervals(cfg.Queues)),
+	)
+	reconciler := redisstore.NewReconciler(redisClient, redisstore.Retention{
+		DeadLetters: cfg.DeadLetterRetention,
+		Quarantined: cfg.QuarantineRetention,
+	}, logger)
+	janitor := worker.NewJanitor(service.NewJanitorService(reconciler, logger), cfg.JanitorInterval, logger)
 
 	return &Rebound{
 		taskService: taskService,
+		maintenance: service.NewMaintenanceService(maintenanceStore, logger),
+		sla:         service.NewSLAService(slaStore),
+		usage:       service.NewUsageService(usageStore),
 		worker:      wrk,
+		janitor:     janitor,
 		producer:    producer,
+		metrics:     metrics,
 		redisClient: redisClient,
+		replicator:  replicator,
+		migrator:    migrator,
 		logger:      logger,
 		config:      cfg,
 	}, nil
@@ -128,13 +615,285 @@ func New(cfg *Config) (*Rebound, error) {
 func (r *Rebound) Start(ctx context.Context) error {
 	r.logger.Info("starting rebound retry service")
 	go r.worker.Run(ctx)
+	go r.janitor.Run(ctx)
+	if r.replicator != nil {
+		go r.replicator.Run(ctx)
+	}
+	if r.migrator != nil {
+		go r.migrator.Run(ctx)
+	}
 	return nil
 }
 
-// CreateTask schedules a new task for retry with exponential backoff.
+// ErrQueueFull is returned when creating a task while Config.MaxPendingTasks
+// tasks are already scheduled and the overflow policy rejects it.
+var ErrQueueFull = domain.ErrQueueFull
+
+// CreateTask schedules a new task for retry with exponential backoff. A
+// task without an ID is given a generated one when Config.IDStrategy or
+// IDGenerator is set.
 func (r *Rebound) CreateTask(ctx context.Context, task *Task) error {
 	domainTask := task.toDomain()
-	return r.taskService.CreateTask(ctx, domainTask)
+	err := r.taskService.CreateTask(ctx, domainTask)
+	task.ID = domainTask.ID
+	return err
+}
+
+// CreateTaskAt schedules a new task whose first attempt is made at the
+// given time instead of BaseDelay from now. A time in the past schedules it
+// immediately.
+func (r *Rebound) CreateTaskAt(ctx context.Context, task *Task, at time.Time) error {
+	domainTask := task.toDomain()
+	err := r.taskService.CreateTaskAt(ctx, domainTask, at)
+	task.ID = domainTask.ID
+	return err
+}
+
+// CreateTasksPaced schedules tasks with their first attempts spread evenly
+// over window instead of all becoming due at the same moment. Use it for
+// broadcasts such as notifying every tenant, so neither the worker nor the
+// destinations are overwhelmed. No task is scheduled if any is invalid.
+func (r *Rebound) CreateTasksPaced(ctx context.Context, tasks []*Task, window time.Duration) error {
+	domainTasks := make([]*entity.Task, len(tasks))
+	for i, task := range tasks {
+		domainTasks[i] = task.toDomain()
+	}
+	err := r.taskService.CreateTasksPaced(ctx, domainTasks, window)
+	for i, task := range tasks {
+		task.ID = domainTasks[i].ID
+	}
+	return err
+}
+
+// CancelTask removes a scheduled task from the queue. The task stays
+// restorable with RestoreTask for Config.CancelledTaskTTL.
+func (r *Rebound) CancelTask(ctx context.Context, taskID string) error {
+	return r.taskService.CancelTask(ctx, taskID)
+}
+
+// RestoreTask reschedules a cancelled task at its original due time, or
+// immediately if that time has passed.
+func (r *Rebound) RestoreTask(ctx context.Context, taskID string) error {
+	return r.taskService.RestoreTask(ctx, taskID)
+}
+
+// PrioritizeTask marks a scheduled task as priority without cancelling and
+// recreating it, e.g. when a customer escalates a stuck delivery. With now
+// set the task is also made due immediately. It returns the task's due time.
+func (r *Rebound) PrioritizeTask(ctx context.Context, taskID string, now bool) (time.Time, error) {
+	return r.taskService.PrioritizeTask(ctx, taskID, now)
+}
+
+// DeliveryResult proves a task's successful delivery. HTTPStatus and
+// ResponseBody (its first 1 KiB) are set for HTTP deliveries; Partition and
+// Offset for Kafka deliveries, or -1 when they are unknown.
+type DeliveryResult struct {
+	TaskID          string
+	DestinationType DestinationType
+	Destination     string
+	Attempts        int
+	DeliveredAt     time.Time
+	HTTPStatus      int
+	ResponseBody    string
+	Partition       int
+	Offset          int64
+}
+
+// DeliveryResult returns the result of the task's successful delivery. It
+// fails if none is recorded, see Config.DeliveryResultTTL.
+func (r *Rebound) DeliveryResult(ctx context.Context, taskID string) (*DeliveryResult, error) {
+	result, err := r.taskService.DeliveryResult(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	return &DeliveryResult{
+		TaskID:          result.TaskID,
+		DestinationType: DestinationType(result.DestinationType),
+		Destination:     result.Destination,
+		Attempts:        result.Attempts,
+		DeliveredAt:     result.DeliveredAt,
+		HTTPStatus:      result.Receipt.HTTPStatus,
+		ResponseBody:    result.Receipt.ResponseBody,
+		Partition:       result.Receipt.Partition,
+		Offset:          result.Receipt.Offset,
+	}, nil
+}
+
+// DeadLetter is a task kept in the Redis dead-letter store.
+type DeadLetter struct {
+	Task     *Task
+	Reason   string
+	StoredAt time.Time
+}
+
+// ListDeadLetters returns up to limit tasks from the Redis dead-letter
+// store, most recently stored first. limit must be between 1 and 500.
+func (r *Rebound) ListDeadLetters(ctx context.Context, limit int) ([]DeadLetter, error) {
+	entries, _, err := r.taskService.ListDeadLetters(ctx, entity.Cursor{}, limit)
+	if err != nil {
+		return nil, err
+	}
+	deadLetters := make([]DeadLetter, len(entries))
+	for i, e := range entries {
+		deadLetters[i] = DeadLetter{Task: taskFromDomain(e.Task), Reason: e.Reason, StoredAt: e.StoredAt}
+	}
+	return deadLetters, nil
+}
+
+// RequeueDeadLetter schedules a task from the Redis dead-letter store for
+// immediate delivery with a fresh retry budget.
+func (r *Rebound) RequeueDeadLetter(ctx context.Context, taskID string) error {
+	return r.taskService.RequeueDeadLetter(ctx, taskID)
+}
+
+// StartMaintenance holds tasks for destination (an HTTP URL or Kafka topic)
+// until the given time, e.g. during a partner's planned downtime. Held tasks
+// are released at until without using up a retry.
+func (r *Rebound) StartMaintenance(ctx context.Context, destination string, until time.Time) error {
+	return r.maintenance.StartMaintenance(ctx, destination, until)
+}
+
+// EndMaintenance ends the maintenance window for destination early.
+func (r *Rebound) EndMaintenance(ctx context.Context, destination string) error {
+	return r.maintenance.EndMaintenance(ctx, destination)
+}
+
+// TimeToSuccess summarizes how long tasks took from creation to successful
+// delivery for one client and destination.
+type TimeToSuccess struct {
+	ClientID    string
+	Destination string
+	Count       int
+	P50         time.Duration
+	P90         time.Duration
+	P99         time.Duration
+	Max         time.Duration
+}
+
+// TimeToSuccessStats returns time-to-success percentiles per client and
+// destination, computed over recent deliveries.
+func (r *Rebound) TimeToSuccessStats(ctx context.Context) ([]TimeToSuccess, error) {
+	stats, err := r.sla.TimeToSuccess(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]TimeToSuccess, len(stats))
+	for i, s := range stats {
+		result[i] = TimeToSuccess(s)
+	}
+	return result, nil
+}
+
+// ClientUsage counts the delivered and retried attempts of one client's
+// tasks during a billing period (a UTC calendar month such as "2024-05").
+type ClientUsage struct {
+	ClientID  string
+	Period    string
+	Delivered int64
+	Retried   int64
+}
+
+// ClientUsage returns the usage of every client in the billing period,
+// ordered by client ID. Pass the current month for usage so far, e.g.
+// time.Now().UTC().Format("2006-01").
+func (r *Rebound) ClientUsage(ctx context.Context, period string) ([]ClientUsage, error) {
+	usage, err := r.usage.Usage(ctx, period)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]ClientUsage, len(usage))
+	for i, u := range usage {
+		result[i] = ClientUsage(u)
+	}
+	return result, nil
+}
+
+// OverflowStats counts what the overflow policy has done since New: new
+// tasks rejected or spilled, scheduled tasks dropped to make room, and
+// spilled tasks later scheduled.
+type OverflowStats struct {
+	Rejected int64
+	Dropped  int64
+	Spilled  int64
+	Restored int64
+}
+
+// OverflowStats returns the overflow policy counters.
+func (r *Rebound) OverflowStats() OverflowStats {
+	return OverflowStats(r.taskService.OverflowStats())
+}
+
+// ProducerStats summarizes this instance's writes to one destination:
+// StatusCodes counts HTTP responses by status, and the latency percentiles
+// cover recent writes.
+type ProducerStats struct {
+	DestinationType DestinationType
+	Destination     string
+	Writes          int64
+	Messages        int64
+	Errors          int64
+	MaxBatchSize    int
+	StatusCodes     map[int]int64
+	Oversized       int64 // HTTP requests not sent for exceeding HTTPMaxRequestBytes
+	Truncated       int64 // HTTP responses cut off at HTTPMaxResponseBytes
+	P50             time.Duration
+	P90             time.Duration
+	P99             time.Duration
+	Max             time.Duration
+}
+
+// ErrorRate returns the fraction of writes that failed.
+func (s ProducerStats) ErrorRate() float64 {
+	return entity.ProducerStats{Writes: s.Writes, Errors: s.Errors}.ErrorRate()
+}
+
+// ProducerStats returns write latency, batch size, response status and
+// error counts per destination, so a slow destination can be told apart
+// from a slow Rebound.
+func (r *Rebound) ProducerStats() []ProducerStats {
+	stats := r.metrics.ProducerStats()
+	result := make([]ProducerStats, len(stats))
+	for i, s := range stats {
+		result[i] = ProducerStats{
+			DestinationType: DestinationType(s.DestinationType),
+			Destination:     s.Destination,
+			Writes:          s.Writes,
+			Messages:        s.Messages,
+			Errors:          s.Errors,
+			MaxBatchSize:    s.MaxBatchSize,
+			StatusCodes:     s.StatusCodes,
+			Oversized:       s.Oversized,
+			Truncated:       s.Truncated,
+			P50:             s.P50,
+			P90:             s.P90,
+			P99:             s.P99,
+			Max:             s.Max,
+		}
+	}
+	return result
+}
+
+// ReplicationStatus reports how far the standby set by
+// Config.ReplicaRedisAddr, or the migration target, is behind: Sequenced counts schedule writes
+// sequenced on the primary and Applied the highest of them applied on the
+// standby. Queued and Dropped are this instance's buffered and lost writes.
+type ReplicationStatus struct {
+	Sequenced int64
+	Applied   int64
+	Queued    int
+	Dropped   int64
+}
+
+// ReplicationStatus reads the replication sequences. It returns the zero
+// status when neither a standby nor a migration target is configured.
+func (r *Rebound) ReplicationStatus(ctx context.Context) (ReplicationStatus, error) {
+	if r.replicator == nil {
+		return ReplicationStatus{}, nil
+	}
+	status, err := r.replicator.Status(ctx)
+	return ReplicationStatus(status), err
 }
 
 // Close gracefully shuts down the Rebound service and releases resources.
@@ -151,6 +910,12 @@ func (r *Rebound) Close() error {
 		errs = append(errs, fmt.Errorf("closing redis client: %w", err))
 	}
 
+	if r.replicator != nil {
+		if err := r.replicator.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("closing standby redis client: %w", err))
+		}
+	}
+
 	if len(errs) > 0 {
 		return fmt.Errorf("errors during shutdown: %v", errs)
 	}
@@ -172,11 +937,48 @@ type Task struct {
 	// DeadDestination is where the message goes after max retries
 	DeadDestination Destination
 
-	// MaxRetries is the maximum number of retry attempts (0-100)
+	// FallbackDeadDestinations are tried in order when producing to
+	// DeadDestination keeps failing, e.g. an HTTP audit endpoint behind a
+	// Kafka DLQ topic. If all fail, the task is kept in the Redis
+	// dead-letter set. At most 5.
+	FallbackDeadDestinations []Destination
+
+	// FanOutDestinations receive the same message as Destination, e.g. an
+	// HTTP webhook next to a Kafka topic. CreateTask schedules one task per
+	// destination, each retried and dead-lettered on its own; the task for
+	// the n-th fan-out destination has the ID "<ID>#<n>". At most 10, and
+	// not combinable with OrderingKey.
+	FanOutDestinations []Destination
+
+	// MaxRetries is the maximum number of retry attempts (0-100 unless
+	// Config.MaxRetryLimit says otherwise)
 	MaxRetries int
 
-	// BaseDelay is the base delay in seconds for exponential backoff (1-3600)
-	BaseDelay int
+	// BaseDelay is the base delay for exponential backoff (100ms-1h unless
+	// Config.MinBaseDelay and Config.MaxBaseDelay say otherwise). A value
+	// under a millisecond is taken as whole seconds, so BaseDelay: 5 written
+	// against the old integer field still means five seconds.
+	BaseDelay time.Duration
+
+	// AttemptImmediately makes the first attempt as soon as the task is
+	// created. By default it is made BaseDelay after creation. Retries back
+	// off from BaseDelay either way.
+	AttemptImmediately bool
+
+	// Jitter randomizes this task's retry delays, overriding Config.Jitter.
+	Jitter JitterMode
+
+	// MaxDelay caps the delay before each retry, overriding
+	// Config.MaxDelay, so long retry chains plateau instead of growing
+	// without bound. It may not be below BaseDelay.
+	MaxDelay time.Duration
+
+	// RetrySchedule, if set, lists the delay before each retry, e.g.
+	// {10s, 1m, 10m, 1h}, in place of exponential backoff. It overrides
+	// MaxRetries: the task is dead-lettered once every delay is used up.
+	// BaseDelay then only delays the first attempt and may be left zero to
+	// make it at once.
+	RetrySchedule []time.Duration
 
 	// ClientID identifies the client making the request
 	ClientID string
@@ -189,6 +991,29 @@ type Task struct {
 
 	// DestinationType is either "kafka" or "http"
 	DestinationType DestinationType
+
+	// OrderingKey, if set, delivers tasks sharing the key strictly one at a
+	// time in creation order: a retrying task blocks the ones behind it.
+	OrderingKey string
+
+	// Queue, if set, names the queue the task is polled from; it must be
+	// one of Config.Queues.
+	Queue string
+
+	// CallbackURL, if set, receives an HTTP POST with the final outcome
+	// (delivered, dead or quarantined) and an attempt summary.
+	CallbackURL string
+
+	// Metadata is passed through with every delivery, as X-Metadata-<key>
+	// HTTP headers or Kafka record headers, and on dead-letter messages.
+	// Keys are letters, digits, '-', '_' and '.'; at most 32 entries and
+	// 4 KiB in total.
+	Metadata map[string]string
+
+	// CorrelationID, if set, is sent on every attempt and on dead-letter
+	// delivery as the X-Correlation-ID HTTP header or Kafka record header,
+	// so the task can be traced across systems.
+	CorrelationID string
 }
 
 // DestinationType specifies how the message should be delivered.
@@ -211,32 +1036,118 @@ type Destination struct {
 	Port  string
 	Topic string
 
-	// HTTP field
-	URL string
+	// HTTP fields
+	URL    string
+	Format PayloadFormat // chat platform schema the message is wrapped in; empty sends it as is
 }
 
 // toDomain converts a public Task to an internal domain entity.
 func (t *Task) toDomain() *entity.Task {
 	return &entity.Task{
-		ID:     t.ID,
-		Source: t.Source,
-		Destination: entity.Destination{
-			Host:  t.Destination.Host,
-			Port:  t.Destination.Port,
-			Topic: t.Destination.Topic,
-			URL:   t.Destination.URL,
-		},
-		DeadDestination: entity.Destination{
-			Host:  t.DeadDestination.Host,
-			Port:  t.DeadDestination.Port,
-			Topic: t.DeadDestination.Topic,
-			URL:   t.DeadDestination.URL,
-		},
+		ID:              t.ID,
+		Source:          t.Source,
+		Destination:     t.Destination.toDomain(),
+		DeadDestination: t.DeadDestination.toDomain(),
 		MaxRetries:      t.MaxRetries,
-		BaseDelay:       t.BaseDelay,
+		BaseDelay:       legacyDelay(t.BaseDelay),
 		ClientID:        t.ClientID,
 		IsPriority:      t.IsPriority,
 		MessageData:     t.MessageData,
 		DestinationType: entity.DestinationType(t.DestinationType),
+		OrderingKey:     t.OrderingKey,
+		Queue:           t.Queue,
+		CallbackURL:     t.CallbackURL,
+		Metadata:        t.Metadata,
+		CorrelationID:   t.CorrelationID,
+
+		AttemptImmediately: t.AttemptImmediately,
+		Jitter:             entity.JitterMode(t.Jitter),
+		MaxDelay:           t.MaxDelay,
+		RetryDelays:        t.RetrySchedule,
+
+		FallbackDeadDestinations: destinationsToDomain(t.FallbackDeadDestinations),
+		FanOutDestinations:       destinationsToDomain(t.FanOutDestinations),
+	}
+}
+
+// taskFromDomain converts an internal domain entity to a public Task.
+func taskFromDomain(t *entity.Task) *Task {
+	task := &Task{
+		ID:              t.ID,
+		Source:          t.Source,
+		Destination:     destinationFromDomain(t.Destination),
+		DeadDestination: destinationFromDomain(t.DeadDestination),
+		MaxRetries:      t.MaxRetries,
+		BaseDelay:       t.BaseDelay,
+		ClientID:        t.ClientID,
+		IsPriority:      t.IsPriority,
+		MessageData:     t.MessageData,
+		DestinationType: DestinationType(t.DestinationType),
+		OrderingKey:     t.OrderingKey,
+		Queue:           t.Queue,
+		CallbackURL:     t.CallbackURL,
+		Metadata:        t.Metadata,
+		CorrelationID:   t.CorrelationID,
+
+		AttemptImmediately: t.AttemptImmediately,
+		Jitter:             JitterMode(t.Jitter),
+		MaxDelay:           t.MaxDelay,
+		RetrySchedule:      t.RetryDelays,
+	}
+	for _, d := range t.FallbackDeadDestinations {
+		task.FallbackDeadDestinations = append(task.FallbackDeadDestinations, destinationFromDomain(d))
+	}
+	return task
+}
+
+// legacyDelay reads a delay under a millisecond as whole seconds, the unit
+// BaseDelay had when it was an int.
+func legacyDelay(d time.Duration) time.Duration {
+	if d > 0 && d < time.Millisecond {
+		return d * time.Second
+	}
+	return d
+}
+
+func redrivePolicies(policies map[string]RedrivePolicy) map[string]service.RedrivePolicy {
+	out := make(map[string]service.RedrivePolicy, len(policies))
+	for dest, p := range policies {
+		out[dest] = service.RedrivePolicy(p)
+	}
+	return out
+}
+
+func queuePolicies(queues map[string]QueueConfig) map[string]service.QueuePolicy {
+	out := make(map[string]service.QueuePolicy, len(queues))
+	for name, q := range queues {
+		out[name] = service.QueuePolicy{BatchSize: q.BatchSize, Weight: q.Weight}
+	}
+	return out
+}
+
+func queueIntervals(queues map[string]QueueConfig) map[string]time.Duration {
+	out := make(map[string]time.Duration, len(queues))
+	for name, q := range queues {
+		out[name] = q.PollInterval
+	}
+	return out
+}
+
+func (d Destination) toDomain() entity.Destination {
+	return entity.Destination{Host: d.Host, Port: d.Port, Topic: d.Topic, URL: d.URL, Format: entity.PayloadFormat(d.Format)}
+}
+
+func destinationFromDomain(d entity.Destination) Destination {
+	return Destination{Host: d.Host, Port: d.Port, Topic: d.Topic, URL: d.URL, Format: PayloadFormat(d.Format)}
+}
+
+func destinationsToDomain(dests []Destination) []entity.Destination {
+	if len(dests) == 0 {
+		return nil
+	}
+	out := make([]entity.Destination, len(dests))
+	for i, d := range dests {
+		out[i] = d.toDomain()
 	}
+	return out
 }
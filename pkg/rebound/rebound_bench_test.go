@@ -3,6 +3,8 @@ package rebound_test
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
@@ -16,7 +18,6 @@ func BenchmarkTaskCreation(b *testing.B) {
 	logger, _ := zap.NewProduction()
 	cfg := &rebound.Config{
 		RedisAddr:    "localhost:6379",
-		KafkaBrokers: []string{"localhost:9092"},
 		PollInterval: 1 * time.Second,
 		Logger:       logger,
 	}
@@ -66,7 +67,6 @@ func BenchmarkTaskCreationHTTP(b *testing.B) {
 	logger, _ := zap.NewProduction()
 	cfg := &rebound.Config{
 		RedisAddr:    "localhost:6379",
-		KafkaBrokers: []string{"localhost:9092"},
 		PollInterval: 1 * time.Second,
 		Logger:       logger,
 	}
@@ -112,7 +112,6 @@ func BenchmarkTaskCreationParallel(b *testing.B) {
 	logger, _ := zap.NewProduction()
 	cfg := &rebound.Config{
 		RedisAddr:    "localhost:6379",
-		KafkaBrokers: []string{"localhost:9092"},
 		PollInterval: 1 * time.Second,
 		Logger:       logger,
 	}
@@ -169,7 +168,6 @@ func BenchmarkTaskCreationWithVariablePayload(b *testing.B) {
 			logger, _ := zap.NewProduction()
 			cfg := &rebound.Config{
 				RedisAddr:    "localhost:6379",
-				KafkaBrokers: []string{"localhost:9092"},
 				PollInterval: 1 * time.Second,
 				Logger:       logger,
 			}
@@ -239,7 +237,6 @@ func BenchmarkTaskCreationWithRetries(b *testing.B) {
 			logger, _ := zap.NewProduction()
 			cfg := &rebound.Config{
 				RedisAddr:    "localhost:6379",
-				KafkaBrokers: []string{"localhost:9092"},
 				PollInterval: 1 * time.Second,
 				Logger:       logger,
 			}
@@ -272,7 +269,7 @@ func BenchmarkTaskCreationWithRetries(b *testing.B) {
 						Topic: "bench-retry-dlq",
 					},
 					MaxRetries:      rc.maxRetries,
-					BaseDelay:       rc.baseDelay,
+					BaseDelay:       time.Duration(rc.baseDelay) * time.Second,
 					ClientID:        "benchmark",
 					MessageData:     `{"retry_test": true}`,
 					DestinationType: rebound.DestinationTypeKafka,
@@ -295,7 +292,6 @@ func BenchmarkReboundInitialization(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		cfg := &rebound.Config{
 			RedisAddr:    "localhost:6379",
-			KafkaBrokers: []string{"localhost:9092"},
 			PollInterval: 1 * time.Second,
 			Logger:       logger,
 		}
@@ -307,3 +303,58 @@ func BenchmarkReboundInitialization(b *testing.B) {
 		rb.Close()
 	}
 }
+
+// BenchmarkFirstRetryLatency measures how long after its due time a task
+// with a 100ms base delay is delivered, with the default one-second polling
+// and with low-latency polling. It reports the mean as ms/delivery; compare
+// it with the Redis commands per second (e.g. redis-cli info commandstats)
+// while it runs.
+func BenchmarkFirstRetryLatency(b *testing.B) {
+	for _, interval := range []time.Duration{time.Second, 100 * time.Millisecond} {
+		b.Run(fmt.Sprintf("Poll-%s", interval), func(b *testing.B) {
+			delivered := make(chan time.Time, 1)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				delivered <- time.Now()
+			}))
+			defer server.Close()
+
+			cfg := &rebound.Config{
+				RedisAddr:    "localhost:6379",
+				PollInterval: interval,
+				Logger:       zap.NewNop(),
+			}
+			rb, err := rebound.New(cfg)
+			if err != nil {
+				b.Fatalf("Failed to create rebound: %v", err)
+			}
+			defer rb.Close()
+
+			ctx := context.Background()
+			if err := rb.Start(ctx); err != nil {
+				b.Fatalf("Failed to start rebound: %v", err)
+			}
+
+			var total time.Duration
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				task := &rebound.Task{
+					ID:              fmt.Sprintf("bench-latency-%s-%d-%d", interval, time.Now().UnixNano(), i),
+					Source:          "benchmark",
+					Destination:     rebound.Destination{URL: server.URL},
+					DeadDestination: rebound.Destination{URL: server.URL},
+					MaxRetries:      1,
+					BaseDelay:       100 * time.Millisecond,
+					ClientID:        "benchmark",
+					MessageData:     `{"benchmark": true}`,
+					DestinationType: rebound.DestinationTypeHTTP,
+				}
+				due := time.Now().Add(task.BaseDelay)
+				if err := rb.CreateTask(ctx, task); err != nil {
+					b.Fatalf("Failed to create task: %v", err)
+				}
+				total += (<-delivered).Sub(due)
+			}
+			b.ReportMetric(float64(total.Milliseconds())/float64(b.N), "ms/delivery")
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package rebound
+
+import (
+	"github.com/ruudy-sib/rebound/internal/domain/entity"
+	"github.com/ruudy-sib/rebound/internal/domain/service"
+)
+
+// TaskValidator checks a new task beyond the built-in rules, e.g. to
+// require a Metadata entry or restrict destinations. A non-nil error
+// rejects the task.
+type TaskValidator func(task *Task) error
+
+// validatorOptions registers validators in order.
+func validatorOptions(validators []TaskValidator) []service.Option {
+	opts := make([]service.Option, len(validators))
+	for i, validate := range validators {
+		opts[i] = service.WithValidator(func(task *entity.Task) error {
+			return validate(taskFromDomain(task))
+		})
+	}
+	return opts
+}